@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/configs"
+	"github.com/vfa-khuongdv/golang-cms/internal/database/importers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/wxr"
+)
+
+// Imports a WordPress export, e.g. go run ./cmd/importer -file export.xml -dry-run
+func main() {
+	file := flag.String("file", "", "Path of the WXR file exported from WordPress (Tools > Export)")
+	dryRun := flag.Bool("dry-run", false, "Report what would be imported without saving anything")
+	defaultAuthor := flag.String("default-author", "", "Email of an existing user credited with the content of authors who cannot be imported")
+	verbose := flag.Bool("verbose", false, "List the created records, not only the skipped ones")
+	flag.Parse()
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load env package
+	configs.LoadEnv()
+
+	// Init logger
+	logger.Init()
+
+	reader, err := os.Open(*file)
+	if err != nil {
+		logger.Fatalf("Failed to open %s: %v", *file, err)
+	}
+	defer reader.Close()
+	export, err := wxr.Parse(reader)
+	if err != nil {
+		logger.Fatalf("Failed to parse %s: %v", *file, err)
+	}
+
+	// MySQL database configuration
+	config := configs.DatabaseConfig{
+		Host:     utils.GetEnv("DB_HOST", "127.0.0.1"),
+		Port:     utils.GetEnv("DB_PORT", "3306"),
+		User:     utils.GetEnv("DB_USERNAME", ""),
+		Password: utils.GetEnv("DB_PASSWORD", ""),
+		DBName:   utils.GetEnv("DB_DATABASE", ""),
+	}
+	db := configs.InitDB(config)
+
+	report, err := importers.NewWordPressImporter(db).Import(export, importers.WordPressOptions{
+		DefaultAuthorEmail: *defaultAuthor,
+		DryRun:             *dryRun,
+	})
+	if err != nil {
+		logger.Fatalf("Import of %s failed, nothing was saved: %v", export.SiteURL, err)
+	}
+	printReport(export, report, *verbose)
+}
+
+// printReport writes the records of the import to the standard output followed by the totals of each kind
+func printReport(export *wxr.Export, report *importers.Report, verbose bool) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer writer.Flush()
+
+	if report.DryRun {
+		fmt.Fprintf(writer, "Dry run of the import of %s, nothing was saved\n\n", export.SiteURL)
+	} else {
+		fmt.Fprintf(writer, "Import of %s\n\n", export.SiteURL)
+	}
+
+	fmt.Fprintln(writer, "ACTION\tKIND\tWORDPRESS ID\tID\tTITLE\tREASON")
+	for _, entry := range report.Entries {
+		if entry.Action == importers.ActionCreate && !verbose {
+			continue
+		}
+		target := "-"
+		if entry.TargetID != 0 {
+			target = fmt.Sprint(entry.TargetID)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%s\n", entry.Action, entry.Kind, entry.SourceID, target, entry.Title, entry.Reason)
+	}
+
+	fmt.Fprintln(writer, "\nKIND\tCREATED\tSKIPPED")
+	kinds := []string{
+		models.ImportKindUser,
+		models.ImportKindCategory,
+		models.ImportKindTag,
+		models.ImportKindMedia,
+		models.ImportKindPage,
+		models.ImportKindPost,
+		models.ImportKindComment,
+	}
+	for _, kind := range kinds {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", kind, report.Count(kind, importers.ActionCreate), report.Count(kind, importers.ActionSkip))
+	}
+}
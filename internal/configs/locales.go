@@ -0,0 +1,36 @@
+package configs
+
+import (
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+// InitLocales creates the locales content is published in from the environment
+// Environment variables:
+//   - DEFAULT_LOCALE: The locale of the content stored on posts and pages (default: "en")
+//   - SUPPORTED_LOCALES: Comma-separated locales translations can be written in, e.g. "en,vi,pt-BR"
+//   - LOCALE_FALLBACKS: Comma-separated locale:fallback pairs, e.g. "pt-BR:pt", every chain ends with the default locale
+//
+// Returns:
+//   - *i18n.Locales: The configured locales, the application stops if they are invalid
+func InitLocales() *i18n.Locales {
+	fallbacks, err := i18n.ParseFallbacks(utils.GetEnv("LOCALE_FALLBACKS", ""))
+	if err != nil {
+		logger.Fatalf("Failed to parse LOCALE_FALLBACKS: %+v", err)
+	}
+
+	locales, err := i18n.NewLocales(
+		utils.GetEnv("DEFAULT_LOCALE", "en"),
+		strings.Split(utils.GetEnv("SUPPORTED_LOCALES", ""), ","),
+		fallbacks,
+	)
+	if err != nil {
+		logger.Fatalf("Failed to configure locales: %+v", err)
+	}
+
+	logger.Infof("Content locales: %v, default %s", locales.Supported(), locales.Default())
+	return locales
+}
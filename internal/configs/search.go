@@ -0,0 +1,29 @@
+package configs
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/search"
+	"gorm.io/gorm"
+)
+
+// InitSearchIndex creates the full-text search index selected by the SEARCH_DRIVER environment variable
+// Supported drivers:
+//   - "mysql" (default): documents are stored in the search_documents table and matched with FULLTEXT indexes
+//   - "memory": documents are kept in process and rebuilt on start up, for tests and single instance setups
+//
+// Parameters:
+//   - db: The database connection used by the MySQL index
+//
+// Returns:
+//   - search.Index: The configured index
+//   - bool: true when the index starts empty and must be rebuilt
+func InitSearchIndex(db *gorm.DB) (search.Index, bool) {
+	if utils.GetEnv("SEARCH_DRIVER", "mysql") == "memory" {
+		logger.Info("Using in-process search index")
+		return search.NewMemoryIndex(), true
+	}
+
+	logger.Info("Using MySQL full-text search index")
+	return search.NewMySQLIndex(db), false
+}
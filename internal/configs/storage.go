@@ -0,0 +1,86 @@
+package configs
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+)
+
+// InitStorage creates the file storage backend selected by the STORAGE_DRIVER environment variable
+// Supported drivers:
+//   - "local" (default): files are written below STORAGE_LOCAL_PATH and served from STORAGE_BASE_URL
+//   - "s3": files are uploaded to an S3-compatible bucket configured by the S3_* variables
+//
+// Returns:
+//   - storage.Storage: The configured storage backend
+func InitStorage() storage.Storage {
+	return newStorage(
+		utils.GetEnv("S3_BUCKET", ""),
+		utils.GetEnv("S3_PUBLIC_URL", ""),
+		utils.GetEnv("STORAGE_LOCAL_PATH", "./storage"),
+		utils.GetEnv("STORAGE_BASE_URL", "http://localhost:3000/storage"),
+	)
+}
+
+// InitPrivateStorage creates the storage backend of files only handed out by the API after authentication, such as data exports
+// It uses the driver selected by STORAGE_DRIVER:
+//   - "local" (default): files are written below STORAGE_PRIVATE_PATH, which must not be inside STORAGE_LOCAL_PATH
+//   - "s3": files are uploaded to S3_PRIVATE_BUCKET (default: S3_BUCKET), which must not be publicly readable
+//
+// Returns:
+//   - storage.Storage: The configured storage backend, its files have no public URL
+func InitPrivateStorage() storage.Storage {
+	privatePath := utils.GetEnv("STORAGE_PRIVATE_PATH", "./private-storage")
+	if utils.GetEnv("STORAGE_DRIVER", "local") == "local" && isWithin(privatePath, utils.GetEnv("STORAGE_LOCAL_PATH", "./storage")) {
+		logger.Fatalf("STORAGE_PRIVATE_PATH %s must not be inside STORAGE_LOCAL_PATH, which is served publicly", privatePath)
+	}
+
+	return newStorage(
+		utils.GetEnv("S3_PRIVATE_BUCKET", utils.GetEnv("S3_BUCKET", "")),
+		"",
+		privatePath,
+		"",
+	)
+}
+
+// isWithin reports whether path is the directory root or one of its subdirectories
+func isWithin(path, root string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absRoot, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// newStorage creates a backend of the driver selected by STORAGE_DRIVER with the given bucket or directory
+func newStorage(bucket, publicURL, localPath, baseURL string) storage.Storage {
+	driver := utils.GetEnv("STORAGE_DRIVER", "local")
+
+	if driver == "s3" {
+		s3Storage, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:     utils.GetEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:       utils.GetEnv("S3_REGION", "us-east-1"),
+			Bucket:       bucket,
+			AccessKey:    utils.GetEnv("S3_ACCESS_KEY", ""),
+			SecretKey:    utils.GetEnv("S3_SECRET_KEY", ""),
+			PublicURL:    publicURL,
+			UsePathStyle: utils.GetEnv("S3_USE_PATH_STYLE", "false") == "true",
+		}, nil)
+		if err != nil {
+			logger.Fatalf("Failed to initialize S3 storage: %+v", err)
+		}
+		logger.Infof("Using S3 file storage in bucket %s", bucket)
+		return s3Storage
+	}
+
+	logger.Infof("Using local file storage in %s", localPath)
+	return storage.NewLocalStorage(localPath, baseURL)
+}
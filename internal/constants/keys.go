@@ -2,5 +2,24 @@ package constants
 
 const PROFILE string = "PROFILE_"
 
+// IMPERSONATION is the key prefix of active impersonation sessions, followed by the session ID
+const IMPERSONATION string = "IMPERSONATION_"
+
+// RESPONSE_CACHE is the key prefix of the cached responses of the delivery API, followed by the key of the request
+const RESPONSE_CACHE string = "RESPONSE_"
+
+// RESPONSE_TAG is the key prefix of the sets listing the cached responses with a tag, followed by the tag
+const RESPONSE_TAG string = "RESPONSE_TAG_"
+
+// SITEMAP is the key prefix of the hashes holding the files of a sitemap section and their ID ranges, followed by the section
+const SITEMAP string = "SITEMAP_"
+
+// RENDERED is the key prefix of the rendered bodies of posts and pages, followed by the SHA-256 of the format and body
+// Its version changes with the output of the renderer, so bodies rendered by an older release are not served
+const RENDERED string = "RENDERED_V2_"
+
+// EDIT_LOCK is the key prefix of the hashes holding the edit locks of records, followed by the resource and the ID, e.g. EDIT_LOCK_posts_12
+const EDIT_LOCK string = "EDIT_LOCK_"
+
 // LIMIT is the maximum number of items to be returned in a single page
 const LIMIT int = 50
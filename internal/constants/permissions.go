@@ -0,0 +1,51 @@
+package constants
+
+// Permission names checked by middlewares.PermissionMiddleware
+const (
+	PermissionManageAttributes   = "attributes.manage"    // Define custom user attributes
+	PermissionImpersonateUsers   = "users.impersonate"    // Act as another user
+	PermissionViewAuditLogs      = "audit.view"           // Read the audit trail
+	PermissionViewUsers          = "users.view"           // List users and read their details and attributes
+	PermissionInviteUsers        = "users.invite"         // Invite new users and manage pending invitations
+	PermissionReviewPosts        = "posts.review"         // Approve or reject posts submitted for review
+	PermissionPublishPosts       = "posts.publish"        // Schedule, publish, unpublish, archive and restore posts and set their expiry
+	PermissionUpdatePosts        = "posts.update"         // Update posts of other authors and restore their revisions
+	PermissionDeletePosts        = "posts.delete"         // Delete posts of other authors
+	PermissionManageTaxonomy     = "taxonomy.manage"      // Create, update, move and delete categories and tags
+	PermissionManageMedia        = "media.manage"         // Delete media files and manage media folders
+	PermissionManagePages        = "pages.manage"         // Create, update, move and delete static pages
+	PermissionManageMenus        = "menus.manage"         // Create, update and delete navigation menus
+	PermissionSearchUsers        = "users.search"         // Search users on their name and email
+	PermissionManageSearch       = "search.manage"        // Rebuild the search index
+	PermissionModerateComments   = "comments.moderate"    // Review the comment queue, comments of moderators skip it
+	PermissionManageContentTypes = "content_types.manage" // Define content types and their fields
+	PermissionManageContent      = "content.manage"       // Create, update and delete entries of content types
+	PermissionManageRedirects    = "redirects.manage"     // Create, update and delete redirects of old URLs
+	PermissionManageLocks        = "locks.manage"         // Release the edit locks held by other users
+	PermissionManageBlocks       = "blocks.manage"        // Create, update and delete global blocks
+)
+
+// Permissions lists every permission known to the application, used by the seeder
+var Permissions = map[string]string{
+	PermissionManageAttributes:   "Create, update and delete custom user attribute definitions",
+	PermissionImpersonateUsers:   "Sign in as another user for support purposes",
+	PermissionViewAuditLogs:      "View the audit trail",
+	PermissionViewUsers:          "List users, filter them on their attributes and read their details",
+	PermissionInviteUsers:        "Invite new users with roles and manage pending invitations",
+	PermissionReviewPosts:        "Approve or reject posts submitted for review, submit posts of other authors",
+	PermissionPublishPosts:       "Schedule, publish, unpublish, archive and restore approved posts and set their expiry",
+	PermissionUpdatePosts:        "Update the posts of other authors and restore their revisions",
+	PermissionDeletePosts:        "Delete the posts of other authors",
+	PermissionManageTaxonomy:     "Create, update, move and delete categories and tags",
+	PermissionManageMedia:        "Delete files from the media library and create, rename and delete media folders",
+	PermissionManagePages:        "Create, update, move and delete static pages",
+	PermissionManageMenus:        "Create, update and delete navigation menus and their items",
+	PermissionSearchUsers:        "Search users on their name and email",
+	PermissionManageSearch:       "Rebuild the full-text search index",
+	PermissionModerateComments:   "Approve, reject, mark as spam and delete comments, own comments are approved without review",
+	PermissionManageContentTypes: "Create, update and delete content types and the fields of their entries",
+	PermissionManageContent:      "Create, update and delete entries of every content type",
+	PermissionManageRedirects:    "Create, update and delete the redirects of old URLs to their new location",
+	PermissionManageLocks:        "Force the release of edit locks held by other users, e.g. when an editor was left open",
+	PermissionManageBlocks:       "Create, update and delete the global blocks shared by the bodies of posts and pages",
+}
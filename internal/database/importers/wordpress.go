@@ -0,0 +1,877 @@
+package importers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/mail"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/wxr"
+	"gorm.io/gorm"
+)
+
+// Actions reported for the records of an export
+const (
+	ActionCreate = "create"
+	ActionSkip   = "skip"
+)
+
+// mediaKeyPrefix prefixes the storage keys of imported attachments, nothing is stored under them
+const mediaKeyPrefix = "imports/wordpress/"
+
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+// ReportEntry is the outcome of a record of an export
+type ReportEntry struct {
+	Kind     string // One of the models.ImportKind constants
+	SourceID int    // ID of the record in WordPress, 0 for terms only named on items
+	Title    string
+	Action   string // ActionCreate or ActionSkip
+	TargetID uint   // Record created or matched, 0 when nothing matched
+	Reason   string // Why the record was skipped
+}
+
+// Report lists what an import created and skipped, in the order the records were imported
+type Report struct {
+	DryRun  bool // Nothing was saved, the IDs of created records were rolled back
+	Entries []ReportEntry
+}
+
+// Count returns the number of records of a kind with an action
+func (report *Report) Count(kind, action string) int {
+	count := 0
+	for _, entry := range report.Entries {
+		if entry.Kind == kind && entry.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// WordPressOptions configures an import of a WordPress export
+type WordPressOptions struct {
+	DefaultAuthorEmail string // Existing user credited with the content of authors who cannot be imported, empty to skip that content
+	DryRun             bool   // Report what would be imported without saving anything
+}
+
+// WordPressImporter imports the content of WordPress eXtended RSS exports
+type WordPressImporter struct {
+	db *gorm.DB
+}
+
+// NewWordPressImporter creates a new instance of WordPressImporter
+// Parameters:
+//   - db: The database the content is imported into
+//
+// Returns:
+//   - *WordPressImporter: New WordPressImporter instance
+func NewWordPressImporter(db *gorm.DB) *WordPressImporter {
+	return &WordPressImporter{db: db}
+}
+
+// wordpressRun holds the records of an export mapped so far, keyed by their WordPress identifiers
+type wordpressRun struct {
+	tx            *gorm.DB
+	source        string
+	report        *Report
+	defaultAuthor uint
+	authors       map[string]uint // Login => user ID
+	authorIDs     map[int]uint    // WordPress user ID => user ID
+	categories    map[string]uint // Slug in the export => category ID
+	tags          map[string]models.Tag
+	pages         map[int]uint
+	posts         map[int]uint
+	comments      map[int]uint
+}
+
+// Import imports an export in a single transaction, records imported by an earlier run of the same site are skipped
+// Parameters:
+//   - export: The parsed export
+//   - options: The author of orphaned content and whether to save anything
+//
+// Returns:
+//   - *Report: Every record of the export with what was done with it
+//   - error: The error that stopped the import, nothing is saved then
+//
+// The function imports, in order:
+//  1. Authors as active users with random passwords, matched to existing users on their email
+//  2. Categories and tags, matched to existing ones on their slug
+//  3. Attachments as media records pointing at their URL on the WordPress site
+//  4. Pages as nested pages and posts with their category, tags and a first revision
+//  5. Comments of posts with their threads, pingbacks and trackbacks are skipped
+func (importer *WordPressImporter) Import(export *wxr.Export, options WordPressOptions) (*Report, error) {
+	if export.SiteURL == "" {
+		return nil, errors.New("the export does not name its site")
+	}
+
+	report := &Report{DryRun: options.DryRun}
+	err := importer.db.Transaction(func(tx *gorm.DB) error {
+		run := &wordpressRun{
+			tx:         tx,
+			source:     export.SiteURL,
+			report:     report,
+			authors:    map[string]uint{},
+			authorIDs:  map[int]uint{},
+			categories: map[string]uint{},
+			tags:       map[string]models.Tag{},
+			pages:      map[int]uint{},
+			posts:      map[int]uint{},
+			comments:   map[int]uint{},
+		}
+		if options.DefaultAuthorEmail != "" {
+			var user models.User
+			if err := tx.Where("email = ?", options.DefaultAuthorEmail).First(&user).Error; err != nil {
+				return fmt.Errorf("find default author %s: %w", options.DefaultAuthorEmail, err)
+			}
+			run.defaultAuthor = user.ID
+		}
+
+		steps := []func(*wxr.Export) error{
+			run.importAuthors,
+			run.importCategories,
+			run.importTags,
+			run.importAttachments,
+			run.importPages,
+			run.importPosts,
+			run.importComments,
+		}
+		for _, step := range steps {
+			if err := step(export); err != nil {
+				return err
+			}
+		}
+		if options.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (run *wordpressRun) importAuthors(export *wxr.Export) error {
+	for _, author := range export.Authors {
+		entry := ReportEntry{Kind: models.ImportKindUser, SourceID: author.ID, Title: author.Login}
+		id, found, err := run.lookup(models.ImportKindUser, author.ID)
+		if err != nil {
+			return err
+		}
+		if found {
+			run.mapAuthor(author, id)
+			run.skip(entry, id, "already imported")
+			continue
+		}
+
+		email := strings.ToLower(author.Email)
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			run.skip(entry, 0, "the author has no valid email")
+			continue
+		}
+		var user models.User
+		err = run.tx.Unscoped().Where("email = ?", email).First(&user).Error
+		switch {
+		case err == nil && user.DeletedAt.Valid:
+			run.skip(entry, 0, fmt.Sprintf("%s belongs to deleted user %d", email, user.ID))
+			continue
+		case err == nil:
+			if err := run.record(models.ImportKindUser, author.ID, user.ID); err != nil {
+				return err
+			}
+			run.mapAuthor(author, user.ID)
+			run.skip(entry, user.ID, fmt.Sprintf("%s is already used by user %d, who is credited with the content", email, user.ID))
+			continue
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+		if len(email) > 45 {
+			run.skip(entry, 0, "the email is longer than 45 characters")
+			continue
+		}
+
+		name := author.DisplayName
+		if name == "" {
+			name = strings.TrimSpace(author.FirstName + " " + author.LastName)
+		}
+		if name == "" {
+			name = author.Login
+		}
+		// The password is unusable, imported authors sign in after resetting it
+		secret, err := utils.GenerateSecureToken(32)
+		if err != nil {
+			return fmt.Errorf("generate password for %s: %w", email, err)
+		}
+		user = models.User{
+			Email:    email,
+			Name:     truncate(name, 45),
+			Password: utils.HashPassword(secret),
+			Status:   models.UserStatusActive,
+		}
+		if err := run.tx.Create(&user).Error; err != nil {
+			return fmt.Errorf("create user %s: %w", email, err)
+		}
+		if err := run.record(models.ImportKindUser, author.ID, user.ID); err != nil {
+			return err
+		}
+		run.mapAuthor(author, user.ID)
+		run.create(entry, user.ID)
+	}
+	return nil
+}
+
+func (run *wordpressRun) mapAuthor(author wxr.Author, userID uint) {
+	run.authors[author.Login] = userID
+	run.authorIDs[author.ID] = userID
+}
+
+// author returns the user credited with an item, the default author when its author was not imported
+func (run *wordpressRun) author(item wxr.Item) (uint, bool) {
+	if id, ok := run.authors[item.Creator]; ok {
+		return id, true
+	}
+	return run.defaultAuthor, run.defaultAuthor != 0
+}
+
+func (run *wordpressRun) importCategories(export *wxr.Export) error {
+	categories := slices.Clone(export.Categories)
+	// Categories only named on items are not always declared in partial exports
+	for _, item := range export.Items {
+		for _, term := range item.Terms {
+			declared := slices.ContainsFunc(categories, func(category wxr.Category) bool { return category.Slug == term.Slug })
+			if term.Domain == wxr.DomainCategory && term.Slug != "" && !declared {
+				categories = append(categories, wxr.Category{Slug: term.Slug, Name: term.Name})
+			}
+		}
+	}
+
+	// Parents are imported first, a parent missing from the export makes a root category
+	for len(categories) > 0 {
+		var next []wxr.Category
+		for _, category := range categories {
+			_, parentMapped := run.categories[category.Parent]
+			parentDeclared := slices.ContainsFunc(categories, func(other wxr.Category) bool { return other.Slug == category.Parent })
+			if category.Parent != "" && !parentMapped && parentDeclared {
+				next = append(next, category)
+				continue
+			}
+			if err := run.importCategory(category); err != nil {
+				return err
+			}
+		}
+		// Categories which are their own ancestors are imported as roots
+		if len(next) == len(categories) {
+			for i := range next {
+				next[i].Parent = ""
+			}
+		}
+		categories = next
+	}
+	return nil
+}
+
+func (run *wordpressRun) importCategory(category wxr.Category) error {
+	entry := ReportEntry{Kind: models.ImportKindCategory, SourceID: category.TermID, Title: category.Name}
+	if category.TermID > 0 {
+		id, found, err := run.lookup(models.ImportKindCategory, category.TermID)
+		if err != nil {
+			return err
+		}
+		if found {
+			run.categories[category.Slug] = id
+			run.skip(entry, id, "already imported")
+			return nil
+		}
+	}
+
+	slug := termSlug(category.Slug, category.Name)
+	if slug == "" {
+		run.skip(entry, 0, "the category has no slug")
+		return nil
+	}
+	var stored models.Category
+	err := run.tx.Where("slug = ?", slug).First(&stored).Error
+	if err == nil {
+		run.categories[category.Slug] = stored.ID
+		run.skip(entry, stored.ID, fmt.Sprintf("slug %s is already used by category %d, which is assigned instead", slug, stored.ID))
+		return run.recordTerm(models.ImportKindCategory, category.TermID, stored.ID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	created := models.Category{
+		Name:        truncate(category.Name, 100),
+		Slug:        slug,
+		Description: optional(truncate(category.Description, 500)),
+	}
+	if created.Name == "" {
+		created.Name = slug
+	}
+	if parentID, ok := run.categories[category.Parent]; ok {
+		created.ParentID = &parentID
+	}
+	// New categories are added after their siblings
+	var siblings int64
+	query := run.tx.Model(&models.Category{})
+	if created.ParentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *created.ParentID)
+	}
+	if err := query.Count(&siblings).Error; err != nil {
+		return err
+	}
+	created.Position = int(siblings)
+
+	if err := repositories.NewCategoryRepository(run.tx).Create(&created); err != nil {
+		return fmt.Errorf("create category %s: %w", slug, err)
+	}
+	run.categories[category.Slug] = created.ID
+	run.create(entry, created.ID)
+	return run.recordTerm(models.ImportKindCategory, category.TermID, created.ID)
+}
+
+func (run *wordpressRun) importTags(export *wxr.Export) error {
+	tags := slices.Clone(export.Tags)
+	for _, item := range export.Items {
+		for _, term := range item.Terms {
+			declared := slices.ContainsFunc(tags, func(tag wxr.Tag) bool { return tag.Slug == term.Slug })
+			if term.Domain == wxr.DomainTag && term.Slug != "" && !declared {
+				tags = append(tags, wxr.Tag{Slug: term.Slug, Name: term.Name})
+			}
+		}
+	}
+
+	for _, tag := range tags {
+		entry := ReportEntry{Kind: models.ImportKindTag, SourceID: tag.TermID, Title: tag.Name}
+		if tag.TermID > 0 {
+			id, found, err := run.lookup(models.ImportKindTag, tag.TermID)
+			if err != nil {
+				return err
+			}
+			if found {
+				var stored models.Tag
+				if err := run.tx.First(&stored, id).Error; err == nil {
+					run.tags[tag.Slug] = stored
+				}
+				run.skip(entry, id, "already imported")
+				continue
+			}
+		}
+
+		slug := termSlug(tag.Slug, tag.Name)
+		if slug == "" {
+			run.skip(entry, 0, "the tag has no slug")
+			continue
+		}
+		var stored models.Tag
+		err := run.tx.Where("slug = ?", slug).First(&stored).Error
+		if err == nil {
+			run.tags[tag.Slug] = stored
+			run.skip(entry, stored.ID, fmt.Sprintf("slug %s is already used by tag %d, which is assigned instead", slug, stored.ID))
+			if err := run.recordTerm(models.ImportKindTag, tag.TermID, stored.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		created := models.Tag{Name: truncate(tag.Name, 100), Slug: slug}
+		if created.Name == "" {
+			created.Name = slug
+		}
+		if err := run.tx.Create(&created).Error; err != nil {
+			return fmt.Errorf("create tag %s: %w", slug, err)
+		}
+		run.tags[tag.Slug] = created
+		run.create(entry, created.ID)
+		if err := run.recordTerm(models.ImportKindTag, tag.TermID, created.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importAttachments adds the attachments to the media library as references to the files on the WordPress site
+// The files are not downloaded: bodies keep linking to the same URLs, which must stay reachable
+func (run *wordpressRun) importAttachments(export *wxr.Export) error {
+	for _, item := range export.Items {
+		if item.Type != wxr.ItemTypeAttachment {
+			continue
+		}
+		entry := ReportEntry{Kind: models.ImportKindMedia, SourceID: item.ID, Title: item.AttachmentURL}
+		id, found, err := run.lookup(models.ImportKindMedia, item.ID)
+		if err != nil {
+			return err
+		}
+		if found {
+			run.skip(entry, id, "already imported")
+			continue
+		}
+
+		location, err := url.Parse(item.AttachmentURL)
+		if item.AttachmentURL == "" || err != nil || location.Host == "" || len(item.AttachmentURL) > 1000 {
+			run.skip(entry, 0, "the attachment has no valid URL")
+			continue
+		}
+
+		// The checksum of the URL stands for the checksum of the content, so an attachment is referenced once
+		sum := sha256.Sum256([]byte(item.AttachmentURL))
+		checksum := hex.EncodeToString(sum[:])
+		var stored models.Media
+		err = run.tx.Where("checksum = ?", checksum).First(&stored).Error
+		if err == nil {
+			run.skip(entry, stored.ID, fmt.Sprintf("already referenced by media %d", stored.ID))
+			if err := run.record(models.ImportKindMedia, item.ID, stored.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		mimeType, _, _ := mime.ParseMediaType(mime.TypeByExtension(path.Ext(location.Path)))
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		media := models.Media{
+			FileName:  truncate(path.Base(location.Path), 255),
+			Key:       truncate(mediaKeyPrefix+location.Host+location.Path, 500),
+			URL:       item.AttachmentURL,
+			MimeType:  mimeType,
+			Checksum:  checksum,
+			AltText:   optional(truncate(item.Meta["_wp_attachment_image_alt"], 255)),
+			Caption:   optional(truncate(item.Excerpt, 1000)),
+			CreatedAt: item.Date,
+		}
+		if uploaderID, ok := run.author(item); ok {
+			media.UploaderID = &uploaderID
+		}
+		if err := run.tx.Create(&media).Error; err != nil {
+			return fmt.Errorf("create media %s: %w", item.AttachmentURL, err)
+		}
+		if err := run.record(models.ImportKindMedia, item.ID, media.ID); err != nil {
+			return err
+		}
+		run.create(entry, media.ID)
+	}
+	return nil
+}
+
+func (run *wordpressRun) importPages(export *wxr.Export) error {
+	var pages []wxr.Item
+	for _, item := range export.Items {
+		if item.Type == wxr.ItemTypePage {
+			pages = append(pages, item)
+		}
+	}
+
+	// Parents are imported first, a parent missing from the export or skipped makes a root page
+	for len(pages) > 0 {
+		var next []wxr.Item
+		for _, page := range pages {
+			_, parentMapped := run.pages[page.ParentID]
+			parentWaiting := slices.ContainsFunc(pages, func(other wxr.Item) bool { return other.ID == page.ParentID })
+			if page.ParentID != 0 && !parentMapped && parentWaiting {
+				next = append(next, page)
+				continue
+			}
+			if err := run.importPage(page); err != nil {
+				return err
+			}
+		}
+		if len(next) == len(pages) {
+			for i := range next {
+				next[i].ParentID = 0
+			}
+		}
+		pages = next
+	}
+	return nil
+}
+
+func (run *wordpressRun) importPage(item wxr.Item) error {
+	entry := ReportEntry{Kind: models.ImportKindPage, SourceID: item.ID, Title: item.Title}
+	id, found, err := run.lookup(models.ImportKindPage, item.ID)
+	if err != nil {
+		return err
+	}
+	if found {
+		run.pages[item.ID] = id
+		run.skip(entry, id, "already imported")
+		return nil
+	}
+
+	var status string
+	switch item.Status {
+	case "publish":
+		status = models.PageStatusPublished
+	case "draft", "pending", "private", "future":
+		status = models.PageStatusDraft
+	default:
+		run.skip(entry, 0, fmt.Sprintf("pages with status %q are not imported", item.Status))
+		return nil
+	}
+	authorID, ok := run.author(item)
+	if !ok {
+		run.skip(entry, 0, fmt.Sprintf("author %q is not imported and no default author is set", item.Creator))
+		return nil
+	}
+
+	page := models.Page{
+		Title:      title(item),
+		Body:       wxr.Autop(item.Content),
+		BodyFormat: models.BodyFormatHTML,
+		Template:   models.PageTemplateDefault,
+		Status:     status,
+		Position:   item.MenuOrder,
+		AuthorID:   authorID,
+		CreatedAt:  item.Date,
+		UpdatedAt:  item.Modified,
+	}
+	parentPath := ""
+	if parentID, ok := run.pages[item.ParentID]; ok {
+		var parent models.Page
+		if err := run.tx.Select("id", "path").First(&parent, parentID).Error; err == nil {
+			page.ParentID = &parent.ID
+			parentPath = parent.Path + "/"
+		}
+	}
+	page.Slug, page.Path, err = run.pagePath(parentPath, termSlug(item.Slug, item.Title))
+	if err != nil {
+		return err
+	}
+
+	if err := repositories.NewPageRepository(run.tx).Create(&page); err != nil {
+		return fmt.Errorf("create page %s: %w", page.Path, err)
+	}
+	if err := run.record(models.ImportKindPage, item.ID, page.ID); err != nil {
+		return err
+	}
+	run.pages[item.ID] = page.ID
+	run.create(entry, page.ID)
+	return nil
+}
+
+// pagePath returns a slug for a page which is free under its parent, with the resulting path
+func (run *wordpressRun) pagePath(parentPath, slug string) (string, string, error) {
+	if slug == "" {
+		slug = "page"
+	}
+	candidate := slug
+	for attempt := 2; ; attempt++ {
+		var count int64
+		if err := run.tx.Model(&models.Page{}).Where("path = ?", parentPath+candidate).Count(&count).Error; err != nil {
+			return "", "", err
+		}
+		if count == 0 {
+			return candidate, parentPath + candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", slug, attempt)
+	}
+}
+
+func (run *wordpressRun) importPosts(export *wxr.Export) error {
+	for _, item := range export.Items {
+		if item.Type != wxr.ItemTypePost {
+			continue
+		}
+		entry := ReportEntry{Kind: models.ImportKindPost, SourceID: item.ID, Title: item.Title}
+		id, found, err := run.lookup(models.ImportKindPost, item.ID)
+		if err != nil {
+			return err
+		}
+		if found {
+			run.posts[item.ID] = id
+			run.skip(entry, id, "already imported")
+			continue
+		}
+
+		post := models.Post{
+			Title:      title(item),
+			Body:       wxr.Autop(item.Content),
+			BodyFormat: models.BodyFormatHTML,
+			Excerpt:    optional(truncate(item.Excerpt, 500)),
+			CreatedAt:  item.Date,
+			UpdatedAt:  item.Modified,
+		}
+		switch item.Status {
+		case "publish":
+			post.Status = models.PostStatusPublished
+			post.PublishedAt = optionalTime(item.Date)
+		case "future":
+			post.Status = models.PostStatusScheduled
+			post.PublishAt = optionalTime(item.Date)
+		case "pending":
+			post.Status = models.PostStatusInReview
+		case "draft", "private":
+			post.Status = models.PostStatusDraft
+		default:
+			run.skip(entry, 0, fmt.Sprintf("posts with status %q are not imported", item.Status))
+			continue
+		}
+		authorID, ok := run.author(item)
+		if !ok {
+			run.skip(entry, 0, fmt.Sprintf("author %q is not imported and no default author is set", item.Creator))
+			continue
+		}
+		post.AuthorID = authorID
+
+		if post.Slug, err = run.postSlug(termSlug(item.Slug, item.Title)); err != nil {
+			return err
+		}
+		for _, slug := range item.TermSlugs(wxr.DomainCategory) {
+			if categoryID, ok := run.categories[slug]; ok {
+				post.CategoryID = &categoryID
+				break
+			}
+		}
+		for _, slug := range item.TermSlugs(wxr.DomainTag) {
+			if tag, ok := run.tags[slug]; ok {
+				post.Tags = append(post.Tags, tag)
+			}
+		}
+
+		revision := &models.PostRevision{
+			EditorID:   &authorID,
+			Title:      post.Title,
+			Slug:       post.Slug,
+			Excerpt:    post.Excerpt,
+			Body:       post.Body,
+			BodyFormat: post.BodyFormat,
+			CreatedAt:  item.Date,
+		}
+		if err := repositories.NewPostRepository(run.tx).Create(&post, revision); err != nil {
+			return fmt.Errorf("create post %s: %w", post.Slug, err)
+		}
+		if err := run.record(models.ImportKindPost, item.ID, post.ID); err != nil {
+			return err
+		}
+		run.posts[item.ID] = post.ID
+		run.create(entry, post.ID)
+	}
+	return nil
+}
+
+// postSlug returns a slug which is not used by another post, deleted posts included
+func (run *wordpressRun) postSlug(slug string) (string, error) {
+	if slug == "" {
+		slug = "post"
+	}
+	candidate := slug
+	for attempt := 2; ; attempt++ {
+		var count int64
+		if err := run.tx.Unscoped().Model(&models.Post{}).Where("slug = ?", candidate).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", slug, attempt)
+	}
+}
+
+func (run *wordpressRun) importComments(export *wxr.Export) error {
+	for _, item := range export.Items {
+		postID, imported := run.posts[item.ID]
+		comments := item.Comments
+		// Replies are imported after the comment they answer
+		for len(comments) > 0 {
+			var next []wxr.Comment
+			for _, comment := range comments {
+				_, parentMapped := run.comments[comment.ParentID]
+				parentWaiting := slices.ContainsFunc(comments, func(other wxr.Comment) bool { return other.ID == comment.ParentID })
+				if comment.ParentID != 0 && !parentMapped && parentWaiting {
+					next = append(next, comment)
+					continue
+				}
+
+				entry := ReportEntry{Kind: models.ImportKindComment, SourceID: comment.ID, Title: truncate(comment.Content, 60)}
+				switch {
+				case item.Type != wxr.ItemTypePost:
+					run.skip(entry, 0, "only comments of posts are imported")
+				case !imported:
+					run.skip(entry, 0, "the post of the comment is not imported")
+				default:
+					if err := run.importComment(postID, comment, entry); err != nil {
+						return err
+					}
+				}
+			}
+			if len(next) == len(comments) {
+				for i := range next {
+					next[i].ParentID = 0
+				}
+			}
+			comments = next
+		}
+	}
+	return nil
+}
+
+func (run *wordpressRun) importComment(postID uint, comment wxr.Comment, entry ReportEntry) error {
+	id, found, err := run.lookup(models.ImportKindComment, comment.ID)
+	if err != nil {
+		return err
+	}
+	if found {
+		run.comments[comment.ID] = id
+		run.skip(entry, id, "already imported")
+		return nil
+	}
+
+	var status string
+	switch {
+	case comment.Type == "pingback" || comment.Type == "trackback":
+		run.skip(entry, 0, comment.Type+"s are not imported")
+		return nil
+	case comment.Approved == "trash" || comment.Content == "":
+		run.skip(entry, 0, "the comment is empty or in the trash")
+		return nil
+	case comment.Approved == "1":
+		status = models.CommentStatusApproved
+	case comment.Approved == "spam":
+		status = models.CommentStatusSpam
+	default:
+		status = models.CommentStatusPending
+	}
+
+	created := models.Comment{
+		PostID:    postID,
+		Body:      comment.Content,
+		Status:    status,
+		IPAddress: truncate(comment.AuthorIP, 45),
+		CreatedAt: comment.Date,
+	}
+	if authorID, ok := run.authorIDs[comment.UserID]; ok && comment.UserID != 0 {
+		created.AuthorID = &authorID
+	} else {
+		name := truncate(comment.Author, 100)
+		if name == "" {
+			name = "Anonymous"
+		}
+		created.GuestName = &name
+		created.GuestEmail = optional(truncate(comment.AuthorEmail, 255))
+	}
+	if parentID, ok := run.comments[comment.ParentID]; ok {
+		var parent models.Comment
+		if err := run.tx.Select("id", "root_id").First(&parent, parentID).Error; err == nil {
+			created.ParentID = &parent.ID
+			created.RootID = parent.RootID
+			if created.RootID == nil {
+				created.RootID = &parent.ID
+			}
+		}
+	}
+
+	if err := repositories.NewCommentRepository(run.tx).Create(&created); err != nil {
+		return fmt.Errorf("create comment %d: %w", comment.ID, err)
+	}
+	if err := run.record(models.ImportKindComment, comment.ID, created.ID); err != nil {
+		return err
+	}
+	run.comments[comment.ID] = created.ID
+	run.create(entry, created.ID)
+	return nil
+}
+
+// lookup returns the record imported for a WordPress record by an earlier run
+func (run *wordpressRun) lookup(kind string, sourceID int) (uint, bool, error) {
+	var record models.ImportRecord
+	err := run.tx.Where("source = ? AND kind = ? AND source_id = ?", run.source, kind, sourceID).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return record.TargetID, true, nil
+}
+
+// record remembers the record imported for a WordPress record
+func (run *wordpressRun) record(kind string, sourceID int, targetID uint) error {
+	return run.tx.Create(&models.ImportRecord{
+		Source:   run.source,
+		Kind:     kind,
+		SourceID: int64(sourceID),
+		TargetID: targetID,
+	}).Error
+}
+
+// recordTerm records a category or tag, terms only named on items have no ID and are matched on their slug
+func (run *wordpressRun) recordTerm(kind string, termID int, targetID uint) error {
+	if termID == 0 {
+		return nil
+	}
+	return run.record(kind, termID, targetID)
+}
+
+func (run *wordpressRun) create(entry ReportEntry, targetID uint) {
+	entry.Action = ActionCreate
+	entry.TargetID = targetID
+	run.report.Entries = append(run.report.Entries, entry)
+}
+
+func (run *wordpressRun) skip(entry ReportEntry, targetID uint, reason string) {
+	entry.Action = ActionSkip
+	entry.TargetID = targetID
+	entry.Reason = reason
+	run.report.Entries = append(run.report.Entries, entry)
+}
+
+// termSlug returns the slug of a WordPress slug, which is URL encoded for letters outside ASCII, or of the name
+func termSlug(slug, name string) string {
+	if decoded, err := url.PathUnescape(slug); err == nil {
+		slug = decoded
+	}
+	if converted := utils.Slugify(slug); converted != "" {
+		return truncate(converted, 240)
+	}
+	return strings.TrimRight(truncate(utils.Slugify(name), 240), "-")
+}
+
+// title returns the title of a post or page, WordPress allows empty titles
+func title(item wxr.Item) string {
+	if item.Title == "" {
+		return "Untitled"
+	}
+	return truncate(item.Title, 255)
+}
+
+// truncate shortens a text to a number of characters
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit])
+}
+
+func optional(text string) *string {
+	if text == "" {
+		return nil
+	}
+	return &text
+}
+
+func optionalTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
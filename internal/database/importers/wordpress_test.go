@@ -0,0 +1,188 @@
+package importers_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/database/importers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/pkg/wxr"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type WordPressImporterTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	importer *importers.WordPressImporter
+	editor   *models.User
+}
+
+func (s *WordPressImporterTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Category{}, &models.Tag{}, &models.MediaFolder{}, &models.Media{},
+		&models.Page{}, &models.Post{}, &models.PostRevision{}, &models.Comment{}, &models.ImportRecord{})
+	s.Require().NoError(err)
+	s.db = db
+	s.importer = importers.NewWordPressImporter(db)
+
+	s.editor = &models.User{Email: "editor@example.com", Name: "Editor", Password: "x"}
+	s.Require().NoError(db.Create(s.editor).Error)
+	s.Require().NoError(db.Create(&models.Tag{Name: "Golang", Slug: "go"}).Error)
+}
+
+// sampleExport returns an export using every kind of record
+func sampleExport() *wxr.Export {
+	published := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	return &wxr.Export{
+		Title:   "Old Blog",
+		SiteURL: "https://blog.example.com",
+		Authors: []wxr.Author{
+			{ID: 2, Login: "jane", Email: "Jane@Example.com", DisplayName: "Jane Roe"},
+			{ID: 3, Login: "editor", Email: "editor@example.com", DisplayName: "Someone"},
+			{ID: 4, Login: "ghost", Email: "not an email"},
+		},
+		Categories: []wxr.Category{
+			{TermID: 4, Slug: "local", Parent: "news", Name: "Local news"},
+			{TermID: 3, Slug: "news", Name: "News"},
+		},
+		Tags: []wxr.Tag{{TermID: 5, Slug: "go", Name: "Go"}},
+		Items: []wxr.Item{
+			{ID: 20, Type: wxr.ItemTypePage, Title: "Team", Slug: "team", Status: "publish", ParentID: 21, Creator: "jane"},
+			{ID: 21, Type: wxr.ItemTypePage, Title: "About", Slug: "about", Status: "publish", Creator: "jane"},
+			{ID: 12, Type: wxr.ItemTypeAttachment, Title: "logo", Creator: "jane", Date: published,
+				AttachmentURL: "https://blog.example.com/wp-content/uploads/logo.png", Meta: map[string]string{"_wp_attachment_image_alt": "Our logo"}},
+			{
+				ID: 10, Type: wxr.ItemTypePost, Title: "Hello", Slug: "hello", Status: "publish", Creator: "jane", Date: published,
+				Content: "First\n\nSecond",
+				Terms: []wxr.Term{
+					{Domain: wxr.DomainCategory, Slug: "local", Name: "Local news"},
+					{Domain: wxr.DomainTag, Slug: "go", Name: "Go"},
+					{Domain: wxr.DomainTag, Slug: "new-tag", Name: "New tag"},
+				},
+				Comments: []wxr.Comment{
+					{ID: 8, Author: "Bob", Content: "Reply", Approved: "1", ParentID: 7, Date: published},
+					{ID: 7, Author: "Alice", AuthorEmail: "alice@example.com", Content: "Nice", Approved: "1", Date: published},
+					{ID: 9, Content: "Jane here", Approved: "0", UserID: 2, Date: published},
+					{ID: 6, Author: "Other blog", Content: "Linked", Approved: "1", Type: "pingback"},
+				},
+			},
+			{ID: 11, Type: wxr.ItemTypePost, Title: "Orphan", Status: "draft", Creator: "ghost"},
+			{ID: 13, Type: wxr.ItemTypePost, Title: "Deleted", Status: "trash", Creator: "jane"},
+			{ID: 14, Type: wxr.ItemTypePost, Title: "Later", Slug: "later", Status: "future", Creator: "editor", Date: published.AddDate(1, 0, 0)},
+		},
+	}
+}
+
+func (s *WordPressImporterTestSuite) TestImport() {
+	report, err := s.importer.Import(sampleExport(), importers.WordPressOptions{})
+	s.Require().NoError(err)
+
+	s.Equal(1, report.Count(models.ImportKindUser, importers.ActionCreate))
+	s.Equal(2, report.Count(models.ImportKindUser, importers.ActionSkip), "existing email and invalid email")
+	s.Equal(2, report.Count(models.ImportKindCategory, importers.ActionCreate))
+	s.Equal(1, report.Count(models.ImportKindTag, importers.ActionCreate))
+	s.Equal(1, report.Count(models.ImportKindTag, importers.ActionSkip))
+	s.Equal(1, report.Count(models.ImportKindMedia, importers.ActionCreate))
+	s.Equal(2, report.Count(models.ImportKindPage, importers.ActionCreate))
+	s.Equal(2, report.Count(models.ImportKindPost, importers.ActionCreate))
+	s.Equal(2, report.Count(models.ImportKindPost, importers.ActionSkip), "author not imported and trashed post")
+	s.Equal(3, report.Count(models.ImportKindComment, importers.ActionCreate))
+	s.Equal(1, report.Count(models.ImportKindComment, importers.ActionSkip))
+
+	var jane models.User
+	s.Require().NoError(s.db.Where("email = ?", "jane@example.com").First(&jane).Error)
+	s.Equal("Jane Roe", jane.Name)
+	s.NotEmpty(jane.Password)
+
+	var local models.Category
+	s.Require().NoError(s.db.Where("slug = ?", "local").First(&local).Error)
+	s.Require().NotNil(local.ParentID, "the parent is imported first")
+
+	var team models.Page
+	s.Require().NoError(s.db.Where("slug = ?", "team").First(&team).Error)
+	s.Equal("about/team", team.Path)
+	s.Equal(models.PageStatusPublished, team.Status)
+
+	var media models.Media
+	s.Require().NoError(s.db.First(&media).Error)
+	s.Equal("logo.png", media.FileName)
+	s.Equal("image/png", media.MimeType)
+	s.Equal("Our logo", *media.AltText)
+
+	var post models.Post
+	s.Require().NoError(s.db.Preload("Tags").Where("slug = ?", "hello").First(&post).Error)
+	s.Equal(models.PostStatusPublished, post.Status)
+	s.Equal("<p>First</p>\n<p>Second</p>", post.Body)
+	s.Equal(jane.ID, post.AuthorID)
+	s.Equal(local.ID, *post.CategoryID)
+	s.Len(post.Tags, 2)
+	s.Equal(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), post.PublishedAt.UTC())
+	var revisions int64
+	s.db.Model(&models.PostRevision{}).Where("post_id = ?", post.ID).Count(&revisions)
+	s.Equal(int64(1), revisions)
+
+	var later models.Post
+	s.Require().NoError(s.db.Where("slug = ?", "later").First(&later).Error)
+	s.Equal(models.PostStatusScheduled, later.Status)
+	s.Equal(s.editor.ID, later.AuthorID, "matched on the email")
+
+	var reply models.Comment
+	s.Require().NoError(s.db.Where("body = ?", "Reply").First(&reply).Error)
+	s.Require().NotNil(reply.RootID)
+	var first models.Comment
+	s.Require().NoError(s.db.First(&first, *reply.RootID).Error)
+	s.Equal("Alice", *first.GuestName)
+	var own models.Comment
+	s.Require().NoError(s.db.Where("body = ?", "Jane here").First(&own).Error)
+	s.Equal(jane.ID, *own.AuthorID)
+	s.Equal(models.CommentStatusPending, own.Status)
+}
+
+func (s *WordPressImporterTestSuite) TestImportTwice() {
+	_, err := s.importer.Import(sampleExport(), importers.WordPressOptions{})
+	s.Require().NoError(err)
+
+	report, err := s.importer.Import(sampleExport(), importers.WordPressOptions{})
+	s.Require().NoError(err)
+	for _, entry := range report.Entries {
+		s.Equal(importers.ActionSkip, entry.Action, "%s %d was imported again", entry.Kind, entry.SourceID)
+	}
+
+	var posts int64
+	s.db.Model(&models.Post{}).Count(&posts)
+	s.Equal(int64(2), posts)
+}
+
+func (s *WordPressImporterTestSuite) TestDefaultAuthor() {
+	report, err := s.importer.Import(sampleExport(), importers.WordPressOptions{DefaultAuthorEmail: "editor@example.com"})
+	s.Require().NoError(err)
+	s.Equal(3, report.Count(models.ImportKindPost, importers.ActionCreate))
+
+	var orphan models.Post
+	s.Require().NoError(s.db.Where("slug = ?", "orphan").First(&orphan).Error)
+	s.Equal(s.editor.ID, orphan.AuthorID)
+
+	_, err = s.importer.Import(sampleExport(), importers.WordPressOptions{DefaultAuthorEmail: "missing@example.com"})
+	s.Error(err)
+}
+
+func (s *WordPressImporterTestSuite) TestDryRun() {
+	report, err := s.importer.Import(sampleExport(), importers.WordPressOptions{DryRun: true})
+	s.Require().NoError(err)
+	s.True(report.DryRun)
+	s.Equal(2, report.Count(models.ImportKindPost, importers.ActionCreate))
+
+	for _, table := range []any{&models.Post{}, &models.Page{}, &models.Comment{}, &models.Media{}, &models.ImportRecord{}} {
+		var count int64
+		s.db.Model(table).Count(&count)
+		s.Zero(count)
+	}
+}
+
+func TestWordPressImporterTestSuite(t *testing.T) {
+	suite.Run(t, new(WordPressImporterTestSuite))
+}
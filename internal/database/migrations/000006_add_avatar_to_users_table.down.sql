@@ -0,0 +1,4 @@
+ALTER TABLE `users`
+  DROP COLUMN `avatar_key`,
+  DROP COLUMN `avatar_url`,
+  DROP COLUMN `avatar_thumbnail_url`;
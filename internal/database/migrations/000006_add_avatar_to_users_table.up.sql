@@ -0,0 +1,4 @@
+ALTER TABLE `users`
+  ADD COLUMN `avatar_key` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `expired_at`,
+  ADD COLUMN `avatar_url` varchar(512) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `avatar_key`,
+  ADD COLUMN `avatar_thumbnail_url` varchar(512) COLLATE utf8mb4_unicode_ci DEFAULT NULL AFTER `avatar_url`;
@@ -0,0 +1,13 @@
+CREATE TABLE `user_attributes` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `user_id` bigint UNSIGNED NOT NULL,
+  `attribute_definition_id` bigint UNSIGNED NOT NULL,
+  `value` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_user_attributes_user_definition` (`user_id`, `attribute_definition_id`),
+  KEY `idx_user_attributes_definition_value` (`attribute_definition_id`, `value`(191)),
+  CONSTRAINT `fk_user_attributes_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_user_attributes_definition` FOREIGN KEY (`attribute_definition_id`) REFERENCES `attribute_definitions` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,14 @@
+CREATE TABLE `audit_logs` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `actor_id` bigint UNSIGNED NOT NULL,
+  `action` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `subject_type` varchar(45) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `subject_id` bigint UNSIGNED NOT NULL,
+  `metadata` json DEFAULT NULL,
+  `ip_address` varchar(45) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT '',
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_audit_logs_actor_id` (`actor_id`),
+  KEY `idx_audit_logs_action` (`action`),
+  KEY `idx_audit_logs_subject` (`subject_type`, `subject_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
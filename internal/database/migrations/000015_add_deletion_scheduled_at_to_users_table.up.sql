@@ -0,0 +1,3 @@
+ALTER TABLE `users`
+  ADD COLUMN `deletion_scheduled_at` datetime(3) DEFAULT NULL AFTER `avatar_thumbnail_url`,
+  ADD KEY `idx_users_deletion_scheduled_at` (`deletion_scheduled_at`);
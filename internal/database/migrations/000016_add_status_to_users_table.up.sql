@@ -0,0 +1,2 @@
+ALTER TABLE `users`
+  ADD COLUMN `status` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'active' AFTER `gender`;
@@ -0,0 +1,3 @@
+ALTER TABLE `posts`
+  DROP KEY `idx_posts_publish_at`,
+  DROP COLUMN `publish_at`;
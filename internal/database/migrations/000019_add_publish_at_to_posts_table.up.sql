@@ -0,0 +1,3 @@
+ALTER TABLE `posts`
+  ADD COLUMN `publish_at` datetime(3) DEFAULT NULL AFTER `status`,
+  ADD KEY `idx_posts_publish_at` (`publish_at`);
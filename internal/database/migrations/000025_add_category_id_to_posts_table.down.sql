@@ -0,0 +1,4 @@
+ALTER TABLE `posts`
+  DROP FOREIGN KEY `fk_posts_category`,
+  DROP KEY `idx_posts_category_id`,
+  DROP COLUMN `category_id`;
@@ -0,0 +1,4 @@
+ALTER TABLE `posts`
+  ADD COLUMN `category_id` bigint UNSIGNED DEFAULT NULL AFTER `author_id`,
+  ADD KEY `idx_posts_category_id` (`category_id`),
+  ADD CONSTRAINT `fk_posts_category` FOREIGN KEY (`category_id`) REFERENCES `categories` (`id`) ON DELETE SET NULL;
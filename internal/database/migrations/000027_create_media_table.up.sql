@@ -0,0 +1,25 @@
+CREATE TABLE `media` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `folder_id` bigint UNSIGNED DEFAULT NULL,
+  `uploader_id` bigint UNSIGNED DEFAULT NULL,
+  `file_name` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `key` varchar(500) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `url` varchar(1000) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `mime_type` varchar(100) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `size` bigint NOT NULL,
+  `checksum` char(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `width` int DEFAULT NULL,
+  `height` int DEFAULT NULL,
+  `alt_text` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `caption` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `variants` json DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_media_checksum` (`checksum`),
+  KEY `idx_media_folder_id` (`folder_id`),
+  KEY `idx_media_uploader_id` (`uploader_id`),
+  KEY `idx_media_mime_type` (`mime_type`),
+  CONSTRAINT `fk_media_folder` FOREIGN KEY (`folder_id`) REFERENCES `media_folders` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_media_uploader` FOREIGN KEY (`uploader_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
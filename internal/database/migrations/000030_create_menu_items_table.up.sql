@@ -0,0 +1,18 @@
+CREATE TABLE `menu_items` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `menu_id` bigint UNSIGNED NOT NULL,
+  `parent_id` bigint UNSIGNED DEFAULT NULL,
+  `label` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `type` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `target_id` bigint UNSIGNED DEFAULT NULL,
+  `url` varchar(1000) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `open_in_new_tab` tinyint(1) NOT NULL DEFAULT '0',
+  `position` int NOT NULL DEFAULT 0,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_menu_items_menu_id` (`menu_id`),
+  KEY `idx_menu_items_parent_id` (`parent_id`),
+  CONSTRAINT `fk_menu_items_menu` FOREIGN KEY (`menu_id`) REFERENCES `menus` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_menu_items_parent` FOREIGN KEY (`parent_id`) REFERENCES `menu_items` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
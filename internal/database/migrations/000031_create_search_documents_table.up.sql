@@ -0,0 +1,13 @@
+CREATE TABLE `search_documents` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `doc_type` varchar(50) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `doc_id` bigint UNSIGNED NOT NULL,
+  `title` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `body` longtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `fields` json DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_search_documents_doc` (`doc_type`, `doc_id`),
+  FULLTEXT KEY `ft_search_documents_title` (`title`),
+  FULLTEXT KEY `ft_search_documents_content` (`title`, `body`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,29 @@
+CREATE TABLE `comments` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `post_id` bigint UNSIGNED NOT NULL,
+  `parent_id` bigint UNSIGNED DEFAULT NULL,
+  `root_id` bigint UNSIGNED DEFAULT NULL,
+  `author_id` bigint UNSIGNED DEFAULT NULL,
+  `guest_name` varchar(100) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `guest_email` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `body` text COLLATE utf8mb4_unicode_ci NOT NULL,
+  `status` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `spam_score` int NOT NULL DEFAULT 0,
+  `ip_address` varchar(45) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `user_agent` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `moderator_id` bigint UNSIGNED DEFAULT NULL,
+  `moderated_at` datetime(3) DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_comments_post_id` (`post_id`),
+  KEY `idx_comments_parent_id` (`parent_id`),
+  KEY `idx_comments_root_id` (`root_id`),
+  KEY `idx_comments_author_id` (`author_id`),
+  KEY `idx_comments_status` (`status`),
+  KEY `idx_comments_created_at` (`created_at`),
+  CONSTRAINT `fk_comments_post` FOREIGN KEY (`post_id`) REFERENCES `posts` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_comments_parent` FOREIGN KEY (`parent_id`) REFERENCES `comments` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_comments_author` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`) ON DELETE SET NULL,
+  CONSTRAINT `fk_comments_moderator` FOREIGN KEY (`moderator_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
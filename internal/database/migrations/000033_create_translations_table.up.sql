@@ -0,0 +1,17 @@
+CREATE TABLE `translations` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `content_type` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `content_id` bigint UNSIGNED NOT NULL,
+  `locale` varchar(35) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `title` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `slug` varchar(255) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `excerpt` varchar(500) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `body` longtext COLLATE utf8mb4_unicode_ci NOT NULL,
+  `translator_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_translations_content_locale` (`content_type`, `content_id`, `locale`),
+  UNIQUE KEY `uni_translations_locale_slug` (`content_type`, `locale`, `slug`),
+  CONSTRAINT `fk_translations_translator` FOREIGN KEY (`translator_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
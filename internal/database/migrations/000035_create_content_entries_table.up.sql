@@ -0,0 +1,13 @@
+CREATE TABLE `content_entries` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `content_type_id` bigint UNSIGNED NOT NULL,
+  `data` json DEFAULT NULL,
+  `author_id` bigint UNSIGNED DEFAULT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_content_entries_content_type_id` (`content_type_id`),
+  KEY `idx_content_entries_author_id` (`author_id`),
+  CONSTRAINT `fk_content_entries_content_type` FOREIGN KEY (`content_type_id`) REFERENCES `content_types` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_content_entries_author` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
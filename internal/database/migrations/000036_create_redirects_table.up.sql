@@ -0,0 +1,12 @@
+CREATE TABLE `redirects` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `from_path` varchar(700) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `to_path` varchar(2048) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `status_code` smallint NOT NULL DEFAULT 301,
+  `automatic` tinyint(1) NOT NULL DEFAULT 0,
+  `created_at` datetime(3) DEFAULT NULL,
+  `updated_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_redirects_from_path` (`from_path`),
+  KEY `idx_redirects_to_path` (`to_path`(255))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+ALTER TABLE `posts`
+  ADD COLUMN `body_format` varchar(20) COLLATE utf8mb4_unicode_ci NOT NULL DEFAULT 'html' AFTER `body`;
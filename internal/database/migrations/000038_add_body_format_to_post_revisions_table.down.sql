@@ -0,0 +1,2 @@
+ALTER TABLE `post_revisions`
+  DROP COLUMN `body_format`;
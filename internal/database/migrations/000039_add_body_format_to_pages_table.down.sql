@@ -0,0 +1,2 @@
+ALTER TABLE `pages`
+  DROP COLUMN `body_format`;
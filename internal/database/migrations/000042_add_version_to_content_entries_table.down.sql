@@ -0,0 +1,2 @@
+ALTER TABLE `content_entries`
+  DROP COLUMN `version`;
@@ -0,0 +1,2 @@
+ALTER TABLE `content_entries`
+  ADD COLUMN `version` int unsigned NOT NULL DEFAULT 1 AFTER `updated_at`;
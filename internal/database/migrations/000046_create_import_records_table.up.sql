@@ -0,0 +1,10 @@
+CREATE TABLE `import_records` (
+  `id` bigint UNSIGNED NOT NULL AUTO_INCREMENT,
+  `source` varchar(255) NOT NULL,
+  `kind` varchar(20) NOT NULL,
+  `source_id` bigint NOT NULL,
+  `target_id` bigint UNSIGNED NOT NULL,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uni_import_records_source_kind_id` (`source`, `kind`, `source_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
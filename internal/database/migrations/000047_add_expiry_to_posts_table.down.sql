@@ -0,0 +1,5 @@
+ALTER TABLE `posts`
+  DROP KEY `idx_posts_expire_at`,
+  DROP COLUMN `expiry_notified_at`,
+  DROP COLUMN `expire_action`,
+  DROP COLUMN `expire_at`;
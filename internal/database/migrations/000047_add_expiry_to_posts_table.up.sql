@@ -0,0 +1,5 @@
+ALTER TABLE `posts`
+  ADD COLUMN `expire_at` datetime(3) DEFAULT NULL AFTER `published_at`,
+  ADD COLUMN `expire_action` varchar(20) DEFAULT NULL AFTER `expire_at`,
+  ADD COLUMN `expiry_notified_at` datetime(3) DEFAULT NULL AFTER `expire_action`,
+  ADD KEY `idx_posts_expire_at` (`expire_at`);
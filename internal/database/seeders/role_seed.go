@@ -0,0 +1,38 @@
+package seeders
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// SeedRoles seeds every known permission and an "admin" role holding all of them.
+// The admin role is assigned to john@example.com.
+func SeedRoles(db *gorm.DB) error {
+	var permissions []models.Permission
+	for name, description := range constants.Permissions {
+		permission := models.Permission{Name: name}
+		if err := db.Where(permission).Attrs(models.Permission{Description: description}).FirstOrCreate(&permission).Error; err != nil {
+			logger.Errorf("Error creating permission %s: %v", name, err)
+			continue
+		}
+		permissions = append(permissions, permission)
+	}
+
+	admin := models.Role{Name: "admin"}
+	if err := db.Where(admin).Attrs(models.Role{DisplayName: "Administrator"}).FirstOrCreate(&admin).Error; err != nil {
+		return err
+	}
+	if err := db.Model(&admin).Association("Permissions").Append(permissions); err != nil {
+		return err
+	}
+
+	var user models.User
+	if err := db.Where("email = ?", "john@example.com").First(&user).Error; err != nil {
+		logger.Errorf("Error finding admin user: %v", err)
+		return nil
+	}
+	userRole := models.UserRole{UserID: user.ID, RoleID: admin.ID}
+	return db.Where(userRole).FirstOrCreate(&userRole).Error
+}
@@ -13,4 +13,9 @@ func Run(db *gorm.DB) {
 		logger.Infof("Something else error when run seeding user: %+v", err)
 	}
 
+	// SeedRoles seeds permissions and the admin role
+	if err := SeedRoles(db); err != nil {
+		logger.Infof("Something else error when run seeding role: %+v", err)
+	}
+
 }
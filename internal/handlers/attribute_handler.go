@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+type IAttributeHandler interface {
+	GetDefinitions(c *gin.Context)
+	CreateDefinition(c *gin.Context)
+	UpdateDefinition(c *gin.Context)
+	DeleteDefinition(c *gin.Context)
+	UpdateUserAttributes(c *gin.Context)
+}
+
+type AttributeHandler struct {
+	attributeService services.IAttributeService
+	userService      services.IUserService
+	redisService     services.IRedisService
+}
+
+// attributeRulesInput is the request representation of models.AttributeRules
+type attributeRulesInput struct {
+	MinLength *int     `json:"min_length" binding:"omitempty,gte=0"`
+	MaxLength *int     `json:"max_length" binding:"omitempty,gte=1"`
+	Pattern   string   `json:"pattern" binding:"omitempty,max=255"`
+	Min       *float64 `json:"min"`
+	Max       *float64 `json:"max"`
+	Options   []string `json:"options" binding:"omitempty,unique,dive,required,max=100"`
+}
+
+func (input attributeRulesInput) toModel() models.AttributeRules {
+	return models.AttributeRules{
+		MinLength: input.MinLength,
+		MaxLength: input.MaxLength,
+		Pattern:   input.Pattern,
+		Min:       input.Min,
+		Max:       input.Max,
+		Options:   input.Options,
+	}
+}
+
+func NewAttributeHandler(attributeService services.IAttributeService, userService services.IUserService, redisService services.IRedisService) *AttributeHandler {
+	return &AttributeHandler{
+		attributeService: attributeService,
+		userService:      userService,
+		redisService:     redisService,
+	}
+}
+
+func (handler *AttributeHandler) GetDefinitions(ctx *gin.Context) {
+	definitions, err := handler.attributeService.GetDefinitions()
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, definitions)
+}
+
+func (handler *AttributeHandler) CreateDefinition(ctx *gin.Context) {
+	var input struct {
+		Name      string              `json:"name" binding:"required,max=45"`
+		Label     string              `json:"label" binding:"required,min=1,max=100,not_blank"`
+		Type      string              `json:"type" binding:"required,oneof=text number boolean date select"`
+		Required  bool                `json:"required"`
+		Rules     attributeRulesInput `json:"rules"`
+		SortOrder int                 `json:"sort_order"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	definition := models.AttributeDefinition{
+		Name:      input.Name,
+		Label:     input.Label,
+		Type:      input.Type,
+		Required:  input.Required,
+		Rules:     input.Rules.toModel(),
+		SortOrder: input.SortOrder,
+	}
+
+	if err := handler.attributeService.CreateDefinition(&definition); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, definition)
+}
+
+func (handler *AttributeHandler) UpdateDefinition(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid AttributeID"),
+		)
+		return
+	}
+
+	// The type cannot be changed because stored values would no longer match it
+	var input struct {
+		Name      *string              `json:"name" binding:"omitempty,max=45"`
+		Label     *string              `json:"label" binding:"omitempty,min=1,max=100,not_blank"`
+		Required  *bool                `json:"required"`
+		Rules     *attributeRulesInput `json:"rules"`
+		SortOrder *int                 `json:"sort_order"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	definition, err := handler.attributeService.GetDefinition(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Name != nil {
+		definition.Name = *input.Name
+	}
+	if input.Label != nil {
+		definition.Label = *input.Label
+	}
+	if input.Required != nil {
+		definition.Required = *input.Required
+	}
+	if input.Rules != nil {
+		definition.Rules = input.Rules.toModel()
+	}
+	if input.SortOrder != nil {
+		definition.SortOrder = *input.SortOrder
+	}
+
+	if err := handler.attributeService.UpdateDefinition(definition); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, definition)
+}
+
+func (handler *AttributeHandler) DeleteDefinition(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid AttributeID"),
+		)
+		return
+	}
+
+	definition, err := handler.attributeService.GetDefinition(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.attributeService.DeleteDefinition(definition.ID); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete attribute successfully"})
+}
+
+func (handler *AttributeHandler) UpdateUserAttributes(ctx *gin.Context) {
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// Values are keyed by attribute name, a null value clears the attribute
+	var input struct {
+		Attributes map[string]any `json:"attributes" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	// Make sure the user exists
+	user, err := handler.userService.GetUser(uint(userId))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	attributes, err := handler.attributeService.SetUserAttributes(user.ID, input.Attributes)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	// The cached profile contains the attributes
+	profileKey := constants.PROFILE + strconv.Itoa(int(user.ID))
+	if err := handler.redisService.Delete(profileKey); err != nil {
+		logger.Warnf("Failed to clear cache: %v", err)
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"attributes": attributes})
+}
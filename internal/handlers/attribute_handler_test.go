@@ -0,0 +1,277 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newAttributeHandler() (*handlers.AttributeHandler, *mocks.MockAttributeService, *mocks.MockUserService, *mocks.MockRedisService) {
+	attributeService := new(mocks.MockAttributeService)
+	userService := new(mocks.MockUserService)
+	redisService := new(mocks.MockRedisService)
+	return handlers.NewAttributeHandler(attributeService, userService, redisService), attributeService, userService, redisService
+}
+
+func TestGetDefinitions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("GetDefinitions - Success", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		attributeService.On("GetDefinitions").Return([]models.AttributeDefinition{{ID: 1, Name: "department", Type: models.AttributeTypeText}}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/api/v1/attributes", nil)
+
+		handler.GetDefinitions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"department"`)
+	})
+
+	t.Run("GetDefinitions - Error", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		attributeService.On("GetDefinitions").Return([]models.AttributeDefinition(nil), apperror.NewDBQueryError("db error"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/api/v1/attributes", nil)
+
+		handler.GetDefinitions(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestCreateDefinition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("CreateDefinition - Success", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		attributeService.On("CreateDefinition", mock.MatchedBy(func(definition *models.AttributeDefinition) bool {
+			return definition.Name == "department" && definition.Type == models.AttributeTypeSelect &&
+				assert.ObjectsAreEqual([]string{"Sales", "IT"}, definition.Rules.Options) && definition.Required
+		})).Return(nil)
+
+		body := `{"name":"department","label":"Department","type":"select","required":true,"rules":{"options":["Sales","IT"]}}`
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/api/v1/attributes", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.CreateDefinition(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		attributeService.AssertExpectations(t)
+	})
+
+	t.Run("CreateDefinition - Validation error", func(t *testing.T) {
+		handler, _, _, _ := newAttributeHandler()
+
+		body := `{"name":"department","label":"Department","type":"color","rules":{"options":["A","A"]}}`
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/api/v1/attributes", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.CreateDefinition(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"type"`)
+		assert.Contains(t, w.Body.String(), `"field":"rules.options"`)
+	})
+
+	t.Run("CreateDefinition - Service error", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		attributeService.On("CreateDefinition", mock.Anything).Return(apperror.NewValidationError("Validation failed", []apperror.FieldError{{Field: "name", Message: "name is already taken"}}))
+
+		body := `{"name":"department","label":"Department","type":"text"}`
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/api/v1/attributes", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handler.CreateDefinition(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "name is already taken")
+	})
+}
+
+func TestUpdateDefinition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("UpdateDefinition - Success", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		definition := &models.AttributeDefinition{ID: 1, Name: "department", Label: "Department", Type: models.AttributeTypeText}
+		attributeService.On("GetDefinition", uint(1)).Return(definition, nil)
+		attributeService.On("UpdateDefinition", definition).Return(nil)
+
+		body := `{"label":"Team","sort_order":3}`
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PATCH", "/api/v1/attributes/1", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		handler.UpdateDefinition(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Team", definition.Label)
+		assert.Equal(t, 3, definition.SortOrder)
+		assert.Equal(t, models.AttributeTypeText, definition.Type)
+		attributeService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateDefinition - Invalid ID", func(t *testing.T) {
+		handler, _, _, _ := newAttributeHandler()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PATCH", "/api/v1/attributes/abc", bytes.NewBufferString(`{}`))
+		c.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		handler.UpdateDefinition(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid AttributeID"}`, w.Body.String())
+	})
+
+	t.Run("UpdateDefinition - Not found", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		attributeService.On("GetDefinition", uint(9)).Return(&models.AttributeDefinition{}, apperror.NewNotFoundError("record not found"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PATCH", "/api/v1/attributes/9", bytes.NewBufferString(`{"label":"Team"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "9"}}
+
+		handler.UpdateDefinition(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestDeleteDefinition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("DeleteDefinition - Success", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		attributeService.On("GetDefinition", uint(1)).Return(&models.AttributeDefinition{ID: 1}, nil)
+		attributeService.On("DeleteDefinition", uint(1)).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/attributes/1", nil)
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		handler.DeleteDefinition(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Delete attribute successfully"}`, w.Body.String())
+		attributeService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteDefinition - Error", func(t *testing.T) {
+		handler, attributeService, _, _ := newAttributeHandler()
+		attributeService.On("GetDefinition", uint(1)).Return(&models.AttributeDefinition{ID: 1}, nil)
+		attributeService.On("DeleteDefinition", uint(1)).Return(apperror.NewDBDeleteError("db error"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/attributes/1", nil)
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		handler.DeleteDefinition(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestUpdateUserAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("UpdateUserAttributes - Success", func(t *testing.T) {
+		handler, attributeService, userService, redisService := newAttributeHandler()
+		userService.On("GetUser", uint(1)).Return(&models.User{ID: 1}, nil)
+		attributeService.On("SetUserAttributes", uint(1), map[string]any{"department": "Sales", "phone": nil}).
+			Return(map[string]any{"department": "Sales"}, nil)
+		redisService.On("Delete", constants.PROFILE+"1").Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PUT", "/api/v1/users/1/attributes", bytes.NewBufferString(`{"attributes":{"department":"Sales","phone":null}}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		handler.UpdateUserAttributes(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"attributes":{"department":"Sales"}}`, w.Body.String())
+		attributeService.AssertExpectations(t)
+		redisService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateUserAttributes - Missing attributes", func(t *testing.T) {
+		handler, _, _, _ := newAttributeHandler()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PUT", "/api/v1/users/1/attributes", bytes.NewBufferString(`{}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		handler.UpdateUserAttributes(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "attributes is required")
+	})
+
+	t.Run("UpdateUserAttributes - User not found", func(t *testing.T) {
+		handler, _, userService, _ := newAttributeHandler()
+		userService.On("GetUser", uint(5)).Return(&models.User{}, apperror.NewNotFoundError("record not found"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PUT", "/api/v1/users/5/attributes", bytes.NewBufferString(`{"attributes":{}}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "5"}}
+
+		handler.UpdateUserAttributes(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("UpdateUserAttributes - Invalid value", func(t *testing.T) {
+		handler, attributeService, userService, _ := newAttributeHandler()
+		userService.On("GetUser", uint(1)).Return(&models.User{ID: 1}, nil)
+		attributeService.On("SetUserAttributes", uint(1), mock.Anything).Return(map[string]any(nil),
+			apperror.NewValidationError("Validation failed", []apperror.FieldError{{Field: "attributes.floor", Message: "attributes.floor must be a number"}}))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PUT", "/api/v1/users/1/attributes", bytes.NewBufferString(`{"attributes":{"floor":"high"}}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+		handler.UpdateUserAttributes(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "attributes.floor must be a number")
+	})
+}
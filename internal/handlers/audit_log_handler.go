@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type IAuditLogHandler interface {
+	GetAuditLogs(c *gin.Context)
+}
+
+type AuditLogHandler struct {
+	auditLogService services.IAuditLogService
+}
+
+func NewAuditLogHandler(auditLogService services.IAuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditLogService: auditLogService,
+	}
+}
+
+func (handler *AuditLogHandler) GetAuditLogs(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Optional filters, e.g. ?action=impersonation.start&actor_id=1
+	actorId, _ := strconv.Atoi(ctx.Query("actor_id"))
+	subjectId, _ := strconv.Atoi(ctx.Query("subject_id"))
+	filter := repositories.AuditLogFilter{
+		ActorID:     uint(max(actorId, 0)),
+		Action:      ctx.Query("action"),
+		SubjectType: ctx.Query("subject_type"),
+		SubjectID:   uint(max(subjectId, 0)),
+	}
+
+	pagination, err := handler.auditLogService.PaginateAuditLogs(page, limit, filter)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
@@ -0,0 +1,57 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestGetAuditLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("GetAuditLogs - Success", func(t *testing.T) {
+		service := new(mocks.MockAuditLogService)
+		handler := handlers.NewAuditLogHandler(service)
+		filter := repositories.AuditLogFilter{ActorID: 1, Action: models.AuditActionImpersonationStart}
+		service.On("PaginateAuditLogs", 1, 50, filter).Return(&utils.Pagination{
+			Page:       1,
+			Limit:      50,
+			TotalItems: 1,
+			TotalPages: 1,
+			Data:       []models.AuditLog{{ID: 1, ActorID: 1, Action: models.AuditActionImpersonationStart}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/api/v1/audit-logs?actor_id=1&action=impersonation.start&subject_id=abc", nil)
+
+		handler.GetAuditLogs(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"action":"impersonation.start"`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("GetAuditLogs - Error", func(t *testing.T) {
+		service := new(mocks.MockAuditLogService)
+		handler := handlers.NewAuditLogHandler(service)
+		service.On("PaginateAuditLogs", 1, 50, repositories.AuditLogFilter{}).Return(&utils.Pagination{}, apperror.NewDBQueryError("db error"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/api/v1/audit-logs", nil)
+
+		handler.GetAuditLogs(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
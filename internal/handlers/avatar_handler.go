@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+type IAvatarHandler interface {
+	UpdateAvatar(c *gin.Context)
+	DeleteAvatar(c *gin.Context)
+}
+
+type AvatarHandler struct {
+	userService   services.IUserService
+	avatarService services.IAvatarService
+	redisService  services.IRedisService
+}
+
+func NewAvatarHandler(userService services.IUserService, avatarService services.IAvatarService, redisService services.IRedisService) *AvatarHandler {
+	return &AvatarHandler{
+		userService:   userService,
+		avatarService: avatarService,
+		redisService:  redisService,
+	}
+}
+
+func (handler *AvatarHandler) UpdateAvatar(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// Read the uploaded image from the "avatar" multipart field
+	fileHeader, err := ctx.FormFile("avatar")
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewValidationDataError("avatar is required"),
+		)
+		return
+	}
+
+	data, err := readUpload(fileHeader, handler.avatarService.MaxUploadSize(), "Avatar")
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	// Get user from database
+	user, err := handler.userService.GetUser(userId)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	// Validate, resize and store the avatar
+	if err := handler.avatarService.UploadAvatar(user, data); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	handler.clearProfileCache(userId)
+
+	utils.RespondWithOK(ctx, http.StatusOK, user)
+}
+
+func (handler *AvatarHandler) DeleteAvatar(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// Get user from database
+	user, err := handler.userService.GetUser(userId)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.avatarService.DeleteAvatar(user); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	handler.clearProfileCache(userId)
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete avatar successfully"})
+}
+
+// clearProfileCache removes the cached profile so the next GetProfile returns the new avatar URLs
+func (handler *AvatarHandler) clearProfileCache(userId uint) {
+	profileKey := constants.PROFILE + strconv.Itoa(int(userId))
+	if err := handler.redisService.Delete(profileKey); err != nil {
+		logger.Warnf("Failed to clear cache: %v", err)
+	}
+}
+
+// readUpload reads an uploaded file into memory, a file larger than maxSize is rejected without being read
+// Parameters:
+//   - fileHeader: The uploaded file
+//   - maxSize: The largest accepted size in bytes
+//   - name: Name of the file in the error messages, e.g. "Avatar"
+//
+// Returns:
+//   - []byte: The content of the file
+//   - error: FileTooLarge error if the file is larger than maxSize, Parse error if it cannot be read
+func readUpload(fileHeader *multipart.FileHeader, maxSize int64, name string) ([]byte, error) {
+	tooLarge := apperror.NewFileTooLargeError(fmt.Sprintf("%s must not be larger than %d bytes", name, maxSize))
+	if fileHeader.Size > maxSize {
+		return nil, tooLarge
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, apperror.NewParseError("Failed to read " + strings.ToLower(name))
+	}
+	defer file.Close()
+
+	// The size in the header comes from the parsed request, the read stays bounded whatever it says
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, apperror.NewParseError("Failed to read " + strings.ToLower(name))
+	}
+	if int64(len(data)) > maxSize {
+		return nil, tooLarge
+	}
+	return data, nil
+}
@@ -0,0 +1,226 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newAvatarRequest(t *testing.T, field string, content []byte) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, "avatar.png")
+	assert.NoError(t, err)
+	_, _ = part.Write(content)
+	_ = writer.Close()
+
+	req, _ := http.NewRequest("PUT", "/api/v1/profile/avatar", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUpdateAvatar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("UpdateAvatar - Success", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		avatarService := new(mocks.MockAvatarService)
+		redisService := new(mocks.MockRedisService)
+		handler := handlers.NewAvatarHandler(userService, avatarService, redisService)
+
+		avatarURL := "http://localhost/storage/avatars/1/abc/avatar.png"
+		user := &models.User{ID: 1, Name: "User"}
+		userService.On("GetUser", uint(1)).Return(user, nil)
+		avatarService.On("MaxUploadSize").Return(int64(1024))
+		avatarService.On("UploadAvatar", user, []byte("image-bytes")).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.User).AvatarURL = &avatarURL
+		}).Return(nil)
+		redisService.On("Delete", constants.PROFILE+"1").Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = newAvatarRequest(t, "avatar", []byte("image-bytes"))
+		c.Set("UserID", uint(1))
+
+		handler.UpdateAvatar(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"avatarUrl":"`+avatarURL+`"`)
+		userService.AssertExpectations(t)
+		avatarService.AssertExpectations(t)
+		redisService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateAvatar - Invalid UserID", func(t *testing.T) {
+		handler := handlers.NewAvatarHandler(new(mocks.MockUserService), new(mocks.MockAvatarService), new(mocks.MockRedisService))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = newAvatarRequest(t, "avatar", []byte("image-bytes"))
+
+		handler.UpdateAvatar(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid UserID"}`, w.Body.String())
+	})
+
+	t.Run("UpdateAvatar - Missing file", func(t *testing.T) {
+		handler := handlers.NewAvatarHandler(new(mocks.MockUserService), new(mocks.MockAvatarService), new(mocks.MockRedisService))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = newAvatarRequest(t, "picture", []byte("image-bytes"))
+		c.Set("UserID", uint(1))
+
+		handler.UpdateAvatar(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4001,"message":"avatar is required"}`, w.Body.String())
+	})
+
+	t.Run("UpdateAvatar - File too large", func(t *testing.T) {
+		avatarService := new(mocks.MockAvatarService)
+		handler := handlers.NewAvatarHandler(new(mocks.MockUserService), avatarService, new(mocks.MockRedisService))
+		avatarService.On("MaxUploadSize").Return(int64(4))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = newAvatarRequest(t, "avatar", []byte("image-bytes"))
+		c.Set("UserID", uint(1))
+
+		handler.UpdateAvatar(c)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "Avatar must not be larger than 4 bytes")
+		avatarService.AssertNotCalled(t, "UploadAvatar", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpdateAvatar - User not found", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		avatarService := new(mocks.MockAvatarService)
+		handler := handlers.NewAvatarHandler(userService, avatarService, new(mocks.MockRedisService))
+		avatarService.On("MaxUploadSize").Return(int64(1024))
+		userService.On("GetUser", uint(1)).Return(&models.User{}, apperror.NewNotFoundError("record not found"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = newAvatarRequest(t, "avatar", []byte("image-bytes"))
+		c.Set("UserID", uint(1))
+
+		handler.UpdateAvatar(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		userService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateAvatar - Upload error", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		avatarService := new(mocks.MockAvatarService)
+		handler := handlers.NewAvatarHandler(userService, avatarService, new(mocks.MockRedisService))
+
+		user := &models.User{ID: 1}
+		userService.On("GetUser", uint(1)).Return(user, nil)
+		avatarService.On("MaxUploadSize").Return(int64(1024))
+		avatarService.On("UploadAvatar", user, mock.Anything).Return(apperror.NewUnsupportedFileTypeError("Avatar must be a JPEG, PNG or GIF image"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = newAvatarRequest(t, "avatar", []byte("not-an-image"))
+		c.Set("UserID", uint(1))
+
+		handler.UpdateAvatar(c)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+		assert.JSONEq(t, `{"code":5001,"message":"Avatar must be a JPEG, PNG or GIF image"}`, w.Body.String())
+		avatarService.AssertExpectations(t)
+	})
+}
+
+func TestDeleteAvatar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("DeleteAvatar - Success", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		avatarService := new(mocks.MockAvatarService)
+		redisService := new(mocks.MockRedisService)
+		handler := handlers.NewAvatarHandler(userService, avatarService, redisService)
+
+		user := &models.User{ID: 1}
+		userService.On("GetUser", uint(1)).Return(user, nil)
+		avatarService.On("DeleteAvatar", user).Return(nil)
+		redisService.On("Delete", constants.PROFILE+"1").Return(errors.New("redis down"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/profile/avatar", nil)
+		c.Set("UserID", uint(1))
+
+		handler.DeleteAvatar(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Delete avatar successfully"}`, w.Body.String())
+		avatarService.AssertExpectations(t)
+		redisService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteAvatar - Invalid UserID", func(t *testing.T) {
+		handler := handlers.NewAvatarHandler(new(mocks.MockUserService), new(mocks.MockAvatarService), new(mocks.MockRedisService))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/profile/avatar", nil)
+
+		handler.DeleteAvatar(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("DeleteAvatar - No avatar", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		avatarService := new(mocks.MockAvatarService)
+		handler := handlers.NewAvatarHandler(userService, avatarService, new(mocks.MockRedisService))
+
+		user := &models.User{ID: 1}
+		userService.On("GetUser", uint(1)).Return(user, nil)
+		avatarService.On("DeleteAvatar", user).Return(apperror.NewNotFoundError("User has no avatar"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/profile/avatar", nil)
+		c.Set("UserID", uint(1))
+
+		handler.DeleteAvatar(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"code":1001,"message":"User has no avatar"}`, w.Body.String())
+	})
+
+	t.Run("DeleteAvatar - User not found", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		avatarService := new(mocks.MockAvatarService)
+		handler := handlers.NewAvatarHandler(userService, avatarService, new(mocks.MockRedisService))
+		avatarService.On("MaxUploadSize").Return(int64(1024))
+		userService.On("GetUser", uint(1)).Return(&models.User{}, apperror.NewNotFoundError("record not found"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/profile/avatar", nil)
+		c.Set("UserID", uint(1))
+
+		handler.DeleteAvatar(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type ICategoryHandler interface {
+	GetCategories(c *gin.Context)
+	GetCategory(c *gin.Context)
+	GetBreadcrumbs(c *gin.Context)
+	GetPublicBreadcrumbs(c *gin.Context)
+	CreateCategory(c *gin.Context)
+	UpdateCategory(c *gin.Context)
+	MoveCategory(c *gin.Context)
+	ReorderCategories(c *gin.Context)
+	DeleteCategory(c *gin.Context)
+}
+
+type CategoryHandler struct {
+	categoryService services.ICategoryService
+}
+
+func NewCategoryHandler(categoryService services.ICategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+func (handler *CategoryHandler) GetCategories(ctx *gin.Context) {
+	tree, err := handler.categoryService.GetTree()
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, tree)
+}
+
+func (handler *CategoryHandler) GetCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid CategoryID"),
+		)
+		return
+	}
+
+	category, err := handler.categoryService.GetCategory(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, category)
+}
+
+func (handler *CategoryHandler) GetBreadcrumbs(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid CategoryID"),
+		)
+		return
+	}
+
+	breadcrumbs, err := handler.categoryService.GetBreadcrumbs(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, breadcrumbs)
+}
+
+func (handler *CategoryHandler) GetPublicBreadcrumbs(ctx *gin.Context) {
+	category, err := handler.categoryService.GetCategoryBySlug(ctx.Param("slug"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	breadcrumbs, err := handler.categoryService.GetBreadcrumbs(category.ID)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	items := make([]publicTerm, len(breadcrumbs))
+	for i, breadcrumb := range breadcrumbs {
+		items[i] = publicTerm{ID: breadcrumb.ID, Name: breadcrumb.Name, Slug: breadcrumb.Slug}
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, items)
+}
+
+func (handler *CategoryHandler) CreateCategory(ctx *gin.Context) {
+	var input struct {
+		ParentID    *uint   `json:"parent_id" binding:"omitempty,min=1"` // Empty for a root category
+		Name        string  `json:"name" binding:"required,max=100,not_blank"`
+		Slug        string  `json:"slug" binding:"omitempty,max=255"` // Generated from the name when empty
+		Description *string `json:"description" binding:"omitempty,max=500"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	category := models.Category{
+		ParentID:    input.ParentID,
+		Name:        input.Name,
+		Slug:        input.Slug,
+		Description: input.Description,
+	}
+
+	if err := handler.categoryService.CreateCategory(&category); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, category)
+}
+
+func (handler *CategoryHandler) UpdateCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid CategoryID"),
+		)
+		return
+	}
+
+	// The parent and position are changed through MoveCategory
+	var input struct {
+		Name        *string `json:"name" binding:"omitempty,max=100,not_blank"`
+		Slug        *string `json:"slug" binding:"omitempty,min=1,max=255"`
+		Description *string `json:"description" binding:"omitempty,max=500"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	category, err := handler.categoryService.GetCategory(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Name != nil {
+		category.Name = *input.Name
+	}
+	if input.Slug != nil {
+		category.Slug = *input.Slug
+	}
+	if input.Description != nil {
+		category.Description = utils.StringToPtr(*input.Description)
+	}
+
+	if err := handler.categoryService.UpdateCategory(category); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, category)
+}
+
+func (handler *CategoryHandler) MoveCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid CategoryID"),
+		)
+		return
+	}
+
+	var input struct {
+		ParentID *uint `json:"parent_id" binding:"omitempty,min=1"` // Empty to make the category a root
+		Position *int  `json:"position" binding:"omitempty,min=0"`  // Empty to place the category last
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	category, err := handler.categoryService.MoveCategory(uint(id), input.ParentID, input.Position)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, category)
+}
+
+func (handler *CategoryHandler) ReorderCategories(ctx *gin.Context) {
+	var input struct {
+		ParentID *uint  `json:"parent_id" binding:"omitempty,min=1"` // Empty to reorder the root categories
+		IDs      []uint `json:"ids" binding:"required,min=1,unique"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	if err := handler.categoryService.ReorderCategories(input.ParentID, input.IDs); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Reorder categories successfully"})
+}
+
+func (handler *CategoryHandler) DeleteCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid CategoryID"),
+		)
+		return
+	}
+
+	// Make sure the category exists before deleting it
+	category, err := handler.categoryService.GetCategory(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.categoryService.DeleteCategory(category.ID); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete category successfully"})
+}
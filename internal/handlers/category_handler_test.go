@@ -0,0 +1,169 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestCategoryHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetCategories - Success", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewCategoryHandler(categoryService)
+		categoryService.On("GetTree").Return([]models.Category{
+			{ID: 1, Name: "News", Slug: "news", Children: []models.Category{{ID: 2, Name: "World", Slug: "world"}}},
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/categories", "", nil)
+
+		handler.GetCategories(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"children":[{"id":2`)
+		categoryService.AssertExpectations(t)
+	})
+
+	t.Run("GetPublicBreadcrumbs - Success", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewCategoryHandler(categoryService)
+		categoryService.On("GetCategoryBySlug", "world").Return(&models.Category{ID: 2, Slug: "world"}, nil)
+		categoryService.On("GetBreadcrumbs", uint(2)).Return([]models.Category{
+			{ID: 1, Name: "News", Slug: "news"},
+			{ID: 2, Name: "World", Slug: "world"},
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/categories/world/breadcrumbs", "", gin.Params{{Key: "slug", Value: "world"}})
+
+		handler.GetPublicBreadcrumbs(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[{"id":1,"name":"News","slug":"news"},{"id":2,"name":"World","slug":"world"}]`, w.Body.String())
+		categoryService.AssertExpectations(t)
+	})
+
+	t.Run("GetBreadcrumbs - Invalid CategoryID", func(t *testing.T) {
+		handler := handlers.NewCategoryHandler(new(mocks.MockCategoryService))
+
+		w, c := newPostRequest("GET", "/api/v1/categories/abc/breadcrumbs", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.GetBreadcrumbs(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid CategoryID")
+	})
+
+	t.Run("CreateCategory - Success", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewCategoryHandler(categoryService)
+		categoryService.On("CreateCategory", mock.MatchedBy(func(category *models.Category) bool {
+			return category.Name == "World" && *category.ParentID == 1
+		})).Run(func(args mock.Arguments) {
+			category := args.Get(0).(*models.Category)
+			category.ID = 2
+			category.Slug = "world"
+		}).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/categories", `{"name":"World","parent_id":1}`, nil)
+
+		handler.CreateCategory(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"world"`)
+		categoryService.AssertExpectations(t)
+	})
+
+	t.Run("CreateCategory - Missing name", func(t *testing.T) {
+		handler := handlers.NewCategoryHandler(new(mocks.MockCategoryService))
+
+		w, c := newPostRequest("POST", "/api/v1/categories", `{}`, nil)
+
+		handler.CreateCategory(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "name")
+	})
+
+	t.Run("UpdateCategory - Success", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewCategoryHandler(categoryService)
+		category := &models.Category{ID: 2, Name: "World", Slug: "world"}
+		categoryService.On("GetCategory", uint(2)).Return(category, nil)
+		categoryService.On("UpdateCategory", category).Return(nil)
+
+		w, c := newPostRequest("PATCH", "/api/v1/categories/2", `{"name":"International"}`, gin.Params{{Key: "id", Value: "2"}})
+
+		handler.UpdateCategory(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "International", category.Name)
+		categoryService.AssertExpectations(t)
+	})
+
+	t.Run("MoveCategory - Under a descendant", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewCategoryHandler(categoryService)
+		parentID := uint(3)
+		categoryService.On("MoveCategory", uint(1), &parentID, (*int)(nil)).Return(nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "parent_id", Message: "a category cannot be moved under itself or one of its descendants"},
+		}))
+
+		w, c := newPostRequest("POST", "/api/v1/categories/1/move", `{"parent_id":3}`, gin.Params{{Key: "id", Value: "1"}})
+
+		handler.MoveCategory(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "descendants")
+		categoryService.AssertExpectations(t)
+	})
+
+	t.Run("ReorderCategories - Success", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewCategoryHandler(categoryService)
+		categoryService.On("ReorderCategories", (*uint)(nil), []uint{4, 1}).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/categories/reorder", `{"ids":[4,1]}`, nil)
+
+		handler.ReorderCategories(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Reorder categories successfully"}`, w.Body.String())
+		categoryService.AssertExpectations(t)
+	})
+
+	t.Run("ReorderCategories - Duplicate IDs", func(t *testing.T) {
+		handler := handlers.NewCategoryHandler(new(mocks.MockCategoryService))
+
+		w, c := newPostRequest("POST", "/api/v1/categories/reorder", `{"ids":[4,4]}`, nil)
+
+		handler.ReorderCategories(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "ids")
+	})
+
+	t.Run("DeleteCategory - Has subcategories", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewCategoryHandler(categoryService)
+		categoryService.On("GetCategory", uint(1)).Return(&models.Category{ID: 1}, nil)
+		categoryService.On("DeleteCategory", uint(1)).Return(apperror.NewBadRequestError("Category has subcategories, move or delete them first"))
+
+		w, c := newPostRequest("DELETE", "/api/v1/categories/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		handler.DeleteCategory(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "subcategories")
+		categoryService.AssertExpectations(t)
+	})
+}
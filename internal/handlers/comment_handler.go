@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// publicComment is the representation of a comment on the public API, the details of guests stay private
+type publicComment struct {
+	ID        uint            `json:"id"`
+	ParentID  *uint           `json:"parentId,omitempty"`
+	Body      string          `json:"body"`
+	Status    string          `json:"status"` // "pending" for a new comment waiting for moderation
+	CreatedAt time.Time       `json:"createdAt"`
+	Author    *publicAuthor   `json:"author,omitempty"`    // Empty for guests
+	GuestName *string         `json:"guestName,omitempty"` // Empty for users
+	Replies   []publicComment `json:"replies"`
+}
+
+// toPublicComment converts a comment and its replies into their public representation
+func toPublicComment(comment *models.Comment) publicComment {
+	result := publicComment{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		Body:      comment.Body,
+		Status:    comment.Status,
+		CreatedAt: comment.CreatedAt,
+		GuestName: comment.GuestName,
+		Replies:   make([]publicComment, len(comment.Replies)),
+	}
+	if comment.Author != nil {
+		result.Author = &publicAuthor{
+			ID:                 comment.Author.ID,
+			Name:               comment.Author.Name,
+			AvatarThumbnailURL: comment.Author.AvatarThumbnailURL,
+		}
+		result.GuestName = nil
+	}
+	for i := range comment.Replies {
+		result.Replies[i] = toPublicComment(&comment.Replies[i])
+	}
+	return result
+}
+
+type ICommentHandler interface {
+	GetPublicComments(c *gin.Context)
+	CreateComment(c *gin.Context)
+	GetComments(c *gin.Context)
+	GetComment(c *gin.Context)
+	ModerateComments(c *gin.Context)
+}
+
+type CommentHandler struct {
+	commentService services.ICommentService
+}
+
+func NewCommentHandler(commentService services.ICommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// GetPublicComments lists the approved threads of a published post, each with its nested replies
+func (handler *CommentHandler) GetPublicComments(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	pagination, err := handler.commentService.GetPublicThreads(ctx.Param("slug"), page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if comments, ok := pagination.Data.([]models.Comment); ok {
+		items := make([]publicComment, len(comments))
+		for i := range comments {
+			items[i] = toPublicComment(&comments[i])
+		}
+		pagination.Data = items
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+// CreateComment posts a comment on a published post, signed in or as a guest
+func (handler *CommentHandler) CreateComment(ctx *gin.Context) {
+	var input struct {
+		ParentID   *uint  `json:"parent_id" binding:"omitempty,min=1"` // Empty to start a thread
+		Body       string `json:"body" binding:"required,max=5000,not_blank"`
+		GuestName  string `json:"guest_name" binding:"omitempty,max=100"` // Required for guests
+		GuestEmail string `json:"guest_email" binding:"omitempty,email"`  // Required for guests
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	// The user ID is only set when the request is signed in
+	comment, err := handler.commentService.CreateComment(ctx.Param("slug"), services.CommentInput{
+		AuthorID:   ctx.GetUint("UserID"),
+		GuestName:  input.GuestName,
+		GuestEmail: input.GuestEmail,
+		ParentID:   input.ParentID,
+		Body:       input.Body,
+		IPAddress:  ctx.ClientIP(),
+		UserAgent:  ctx.GetHeader("User-Agent"),
+	})
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, toPublicComment(comment))
+}
+
+// GetComments lists the moderation queue, e.g. ?status=pending&post_id=3
+func (handler *CommentHandler) GetComments(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	filter := repositories.CommentFilter{
+		Status: ctx.Query("status"),
+	}
+	if filter.Status != "" && !slices.Contains(models.CommentStatuses, filter.Status) {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewValidationDataError(fmt.Sprintf("status must be one of %v", models.CommentStatuses)),
+		)
+		return
+	}
+	if postId := ctx.Query("post_id"); postId != "" {
+		id, err := strconv.Atoi(postId)
+		if err != nil || id <= 0 {
+			utils.RespondWithError(
+				ctx,
+				apperror.NewParseError("Invalid PostID"),
+			)
+			return
+		}
+		filter.PostID = uint(id)
+	}
+
+	pagination, err := handler.commentService.GetComments(page, limit, filter)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *CommentHandler) GetComment(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid CommentID"),
+		)
+		return
+	}
+
+	comment, err := handler.commentService.GetComment(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, comment)
+}
+
+// ModerateComments applies an action to several comments at once, deleting a comment also deletes its replies
+func (handler *CommentHandler) ModerateComments(ctx *gin.Context) {
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	var input struct {
+		IDs    []uint `json:"ids" binding:"required,min=1,max=100,dive,min=1"`
+		Action string `json:"action" binding:"required,oneof=approve spam reject requeue delete"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	affected, err := handler.commentService.ModerateComments(userId, input.IDs, input.Action)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"affected": affected})
+}
@@ -0,0 +1,170 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestCommentHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetPublicComments - Success", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+		parentID := uint(1)
+		commentService.On("GetPublicThreads", "hello", 1, 50).Return(&utils.Pagination{
+			Page: 1, Limit: 50, TotalItems: 1, TotalPages: 1,
+			Data: []models.Comment{{
+				ID: 1, Body: "First", Status: models.CommentStatusApproved,
+				GuestName: utils.StringToPtr("Guest"), GuestEmail: utils.StringToPtr("guest@example.com"), IPAddress: "10.0.0.1",
+				Replies: []models.Comment{{ID: 2, ParentID: &parentID, Body: "Reply", Author: &models.User{ID: 3, Name: "Reader"}}},
+			}},
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts/hello/comments", "", gin.Params{{Key: "slug", Value: "hello"}})
+
+		handler.GetPublicComments(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"guestName":"Guest"`)
+		assert.Contains(t, w.Body.String(), `"name":"Reader"`)
+		assert.NotContains(t, w.Body.String(), "guest@example.com")
+		assert.NotContains(t, w.Body.String(), "10.0.0.1")
+		commentService.AssertExpectations(t)
+	})
+
+	t.Run("CreateComment - Guest", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+		commentService.On("CreateComment", "hello", mock.MatchedBy(func(input services.CommentInput) bool {
+			return input.AuthorID == 0 && input.GuestName == "Guest" && input.Body == "Nice" && input.UserAgent == "test-agent"
+		})).Return(&models.Comment{ID: 5, Body: "Nice", Status: models.CommentStatusPending, GuestName: utils.StringToPtr("Guest")}, nil)
+
+		body := `{"body":"Nice","guest_name":"Guest","guest_email":"guest@example.com"}`
+		w, c := newPostRequest("POST", "/api/v1/public/posts/hello/comments", body, gin.Params{{Key: "slug", Value: "hello"}})
+		c.Request.Header.Set("User-Agent", "test-agent")
+
+		handler.CreateComment(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+		commentService.AssertExpectations(t)
+	})
+
+	t.Run("CreateComment - Signed in", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+		commentService.On("CreateComment", "hello", mock.MatchedBy(func(input services.CommentInput) bool {
+			return input.AuthorID == 3 && *input.ParentID == 1
+		})).Return(&models.Comment{ID: 6, Body: "Agreed", Status: models.CommentStatusApproved}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/public/posts/hello/comments", `{"body":"Agreed","parent_id":1}`, gin.Params{{Key: "slug", Value: "hello"}})
+		c.Set("UserID", uint(3))
+
+		handler.CreateComment(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		commentService.AssertExpectations(t)
+	})
+
+	t.Run("CreateComment - Validation Error", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+
+		w, c := newPostRequest("POST", "/api/v1/public/posts/hello/comments", `{"body":"Hi","guest_email":"not-an-email"}`, gin.Params{{Key: "slug", Value: "hello"}})
+
+		handler.CreateComment(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		commentService.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GetComments - Filter", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+		commentService.On("GetComments", 1, 50, repositories.CommentFilter{Status: models.CommentStatusSpam, PostID: 4}).
+			Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Comment{}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/comments?status=spam&post_id=4", "", nil)
+
+		handler.GetComments(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		commentService.AssertExpectations(t)
+	})
+
+	t.Run("GetComments - Invalid status", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+
+		w, c := newPostRequest("GET", "/api/v1/comments?status=deleted", "", nil)
+
+		handler.GetComments(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("GetComment - Invalid ID", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+
+		w, c := newPostRequest("GET", "/api/v1/comments/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.GetComment(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ModerateComments - Success", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+		commentService.On("ModerateComments", uint(1), []uint{4, 5}, models.CommentActionSpam).Return(int64(2), nil)
+
+		w, c := newPostRequest("POST", "/api/v1/comments/moderate", `{"ids":[4,5],"action":"spam"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.ModerateComments(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"affected":2`)
+		commentService.AssertExpectations(t)
+	})
+
+	t.Run("ModerateComments - Unknown action", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+
+		w, c := newPostRequest("POST", "/api/v1/comments/moderate", `{"ids":[4],"action":"publish"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.ModerateComments(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ModerateComments - Not found", func(t *testing.T) {
+		commentService := new(mocks.MockCommentService)
+		handler := handlers.NewCommentHandler(commentService)
+		commentService.On("ModerateComments", uint(1), []uint{9}, models.CommentActionApprove).
+			Return(int64(0), apperror.NewNotFoundError("Comments not found"))
+
+		w, c := newPostRequest("POST", "/api/v1/comments/moderate", `{"ids":[9],"action":"approve"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.ModerateComments(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
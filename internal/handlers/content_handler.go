@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IContentHandler interface {
+	GetContentTypes(c *gin.Context)
+	GetContentType(c *gin.Context)
+	CreateContentType(c *gin.Context)
+	UpdateContentType(c *gin.Context)
+	DeleteContentType(c *gin.Context)
+	GetEntries(c *gin.Context)
+	GetEntry(c *gin.Context)
+	CreateEntry(c *gin.Context)
+	UpdateEntry(c *gin.Context)
+	DeleteEntry(c *gin.Context)
+}
+
+type ContentHandler struct {
+	contentService services.IContentService
+}
+
+// contentFieldRulesInput is the request representation of models.ContentFieldRules
+type contentFieldRulesInput struct {
+	MinLength *int     `json:"min_length" binding:"omitempty,gte=0"`
+	MaxLength *int     `json:"max_length" binding:"omitempty,gte=1"`
+	Pattern   string   `json:"pattern" binding:"omitempty,max=255"`
+	Options   []string `json:"options" binding:"omitempty,unique,dive,required,max=100"`
+	Min       *float64 `json:"min"`
+	Max       *float64 `json:"max"`
+	Integer   bool     `json:"integer"`
+	Target    string   `json:"target" binding:"omitempty,max=45"`
+	ItemType  string   `json:"item_type" binding:"omitempty,max=20"`
+	MinItems  *int     `json:"min_items" binding:"omitempty,gte=0"`
+	MaxItems  *int     `json:"max_items" binding:"omitempty,gte=1"`
+}
+
+// contentFieldInput is the request representation of models.ContentField
+type contentFieldInput struct {
+	Name     string                 `json:"name" binding:"required,max=45"`
+	Label    string                 `json:"label" binding:"required,max=100,not_blank"`
+	Type     string                 `json:"type" binding:"required,oneof=text rich_text number date boolean reference media list"`
+	Required bool                   `json:"required"`
+	Rules    contentFieldRulesInput `json:"rules"`
+}
+
+func toContentFields(inputs []contentFieldInput) []models.ContentField {
+	fields := make([]models.ContentField, len(inputs))
+	for i, input := range inputs {
+		fields[i] = models.ContentField{
+			Name:     input.Name,
+			Label:    input.Label,
+			Type:     input.Type,
+			Required: input.Required,
+			Rules: models.ContentFieldRules{
+				MinLength: input.Rules.MinLength,
+				MaxLength: input.Rules.MaxLength,
+				Pattern:   input.Rules.Pattern,
+				Options:   input.Rules.Options,
+				Min:       input.Rules.Min,
+				Max:       input.Rules.Max,
+				Integer:   input.Rules.Integer,
+				Target:    input.Rules.Target,
+				ItemType:  input.Rules.ItemType,
+				MinItems:  input.Rules.MinItems,
+				MaxItems:  input.Rules.MaxItems,
+			},
+		}
+	}
+	return fields
+}
+
+func NewContentHandler(contentService services.IContentService) *ContentHandler {
+	return &ContentHandler{
+		contentService: contentService,
+	}
+}
+
+func (handler *ContentHandler) GetContentTypes(ctx *gin.Context) {
+	contentTypes, err := handler.contentService.GetTypes()
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, contentTypes)
+}
+
+func (handler *ContentHandler) GetContentType(ctx *gin.Context) {
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, contentType)
+}
+
+func (handler *ContentHandler) CreateContentType(ctx *gin.Context) {
+	var input struct {
+		Slug        string              `json:"slug" binding:"required,max=45"`
+		Name        string              `json:"name" binding:"required,max=100,not_blank"`
+		Description string              `json:"description" binding:"omitempty,max=500"`
+		Fields      []contentFieldInput `json:"fields" binding:"required,min=1,dive"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	contentType := models.ContentType{
+		Slug:        input.Slug,
+		Name:        input.Name,
+		Description: utils.StringToPtr(input.Description),
+		Fields:      toContentFields(input.Fields),
+	}
+
+	if err := handler.contentService.CreateType(&contentType); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, contentType)
+}
+
+func (handler *ContentHandler) UpdateContentType(ctx *gin.Context) {
+	// The slug cannot be changed because it is used in the URLs and by reference fields
+	var input struct {
+		Name        *string              `json:"name" binding:"omitempty,max=100,not_blank"`
+		Description *string              `json:"description" binding:"omitempty,max=500"`
+		Fields      *[]contentFieldInput `json:"fields" binding:"omitempty,min=1,dive"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Name != nil {
+		contentType.Name = *input.Name
+	}
+	if input.Description != nil {
+		contentType.Description = utils.StringToPtr(*input.Description)
+	}
+	if input.Fields != nil {
+		contentType.Fields = toContentFields(*input.Fields)
+	}
+
+	if err := handler.contentService.UpdateType(contentType); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, contentType)
+}
+
+func (handler *ContentHandler) DeleteContentType(ctx *gin.Context) {
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.contentService.DeleteType(contentType); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete content type successfully"})
+}
+
+func (handler *ContentHandler) GetEntries(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	pagination, err := handler.contentService.PaginateEntries(contentType, page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *ContentHandler) GetEntry(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid EntryID"),
+		)
+		return
+	}
+
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	entry, err := handler.contentService.GetEntry(contentType, uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, entry)
+}
+
+func (handler *ContentHandler) CreateEntry(ctx *gin.Context) {
+	// The authenticated user is recorded as the author
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// Values are keyed by field name and validated against the fields of the content type
+	var input struct {
+		Data map[string]any `json:"data" binding:"required"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	entry, err := handler.contentService.CreateEntry(contentType, input.Data, userId)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, entry)
+}
+
+func (handler *ContentHandler) UpdateEntry(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid EntryID"),
+		)
+		return
+	}
+
+	// Omitted fields are kept, a null value clears a field
+	var input struct {
+		Data    map[string]any `json:"data" binding:"required"`
+		Version *uint          `json:"version" binding:"omitempty,min=1"` // The version of the loaded entry, omitted to overwrite it
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	entry, err := handler.contentService.UpdateEntry(contentType, uint(id), input.Version, input.Data)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, entry)
+}
+
+func (handler *ContentHandler) DeleteEntry(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid EntryID"),
+		)
+		return
+	}
+
+	contentType, err := handler.contentService.GetType(ctx.Param("type"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.contentService.DeleteEntry(contentType, uint(id)); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete entry successfully"})
+}
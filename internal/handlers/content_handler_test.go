@@ -0,0 +1,182 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestContentHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	product := &models.ContentType{ID: 1, Slug: "product", Name: "Product", Fields: []models.ContentField{{Name: "name", Type: models.ContentFieldText}}}
+
+	t.Run("GetContentTypes - Success", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("GetTypes").Return([]models.ContentType{*product}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/content-types", "", nil)
+
+		handler.GetContentTypes(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"product"`)
+	})
+
+	t.Run("CreateContentType - Success", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("CreateType", mock.MatchedBy(func(contentType *models.ContentType) bool {
+			return contentType.Slug == "event" && len(contentType.Fields) == 2 &&
+				contentType.Fields[1].Type == models.ContentFieldList && contentType.Fields[1].Rules.ItemType == models.ContentFieldMedia &&
+				*contentType.Fields[1].Rules.MaxItems == 5
+		})).Return(nil)
+
+		body := `{"slug":"event","name":"Event","fields":[
+			{"name":"title","label":"Title","type":"text","required":true},
+			{"name":"photos","label":"Photos","type":"list","rules":{"item_type":"media","max_items":5}}
+		]}`
+		w, c := newPostRequest("POST", "/api/v1/content-types", body, nil)
+
+		handler.CreateContentType(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		contentService.AssertExpectations(t)
+	})
+
+	t.Run("CreateContentType - Validation error", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+
+		body := `{"slug":"event","name":"Event","fields":[{"name":"title","label":"Title","type":"color"}]}`
+		w, c := newPostRequest("POST", "/api/v1/content-types", body, nil)
+
+		handler.CreateContentType(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		contentService.AssertNotCalled(t, "CreateType", mock.Anything)
+	})
+
+	t.Run("UpdateContentType - Success", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("GetType", "product").Return(&models.ContentType{ID: 1, Slug: "product", Name: "Product"}, nil)
+		contentService.On("UpdateType", mock.MatchedBy(func(contentType *models.ContentType) bool {
+			return contentType.Slug == "product" && contentType.Name == "Products"
+		})).Return(nil)
+
+		w, c := newPostRequest("PATCH", "/api/v1/content-types/product", `{"name":"Products"}`, gin.Params{{Key: "type", Value: "product"}})
+
+		handler.UpdateContentType(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		contentService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteContentType - Not found", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("GetType", "missing").Return(nil, apperror.NewNotFoundError("Content type not found"))
+
+		w, c := newPostRequest("DELETE", "/api/v1/content-types/missing", "", gin.Params{{Key: "type", Value: "missing"}})
+
+		handler.DeleteContentType(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetEntries - Success", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("GetType", "product").Return(product, nil)
+		contentService.On("PaginateEntries", product, 1, 50).Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.ContentEntry{
+			{ID: 3, ContentTypeID: 1, Data: map[string]any{"name": "Chair"}},
+		}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/content/product", "", gin.Params{{Key: "type", Value: "product"}})
+
+		handler.GetEntries(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"data":{"name":"Chair"}`)
+	})
+
+	t.Run("GetEntry - Invalid EntryID", func(t *testing.T) {
+		handler := handlers.NewContentHandler(new(mocks.MockContentService))
+
+		w, c := newPostRequest("GET", "/api/v1/content/product/abc", "", gin.Params{{Key: "type", Value: "product"}, {Key: "id", Value: "abc"}})
+
+		handler.GetEntry(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid EntryID")
+	})
+
+	t.Run("CreateEntry - Success", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("GetType", "product").Return(product, nil)
+		contentService.On("CreateEntry", product, map[string]any{"name": "Chair", "stock": float64(3)}, uint(2)).
+			Return(&models.ContentEntry{ID: 4, ContentTypeID: 1, Data: map[string]any{"name": "Chair", "stock": float64(3)}}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/content/product", `{"data":{"name":"Chair","stock":3}}`, gin.Params{{Key: "type", Value: "product"}})
+		c.Set("UserID", uint(2))
+
+		handler.CreateEntry(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		contentService.AssertExpectations(t)
+	})
+
+	t.Run("CreateEntry - Missing data", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+
+		w, c := newPostRequest("POST", "/api/v1/content/product", `{}`, gin.Params{{Key: "type", Value: "product"}})
+		c.Set("UserID", uint(2))
+
+		handler.CreateEntry(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		contentService.AssertNotCalled(t, "GetType", mock.Anything)
+	})
+
+	t.Run("UpdateEntry - Validation error", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("GetType", "product").Return(product, nil)
+		contentService.On("UpdateEntry", product, uint(4), (*uint)(nil), map[string]any{"name": nil}).
+			Return(nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{{Field: "data.name", Message: "data.name is required"}}))
+
+		w, c := newPostRequest("PATCH", "/api/v1/content/product/4", `{"data":{"name":null}}`, gin.Params{{Key: "type", Value: "product"}, {Key: "id", Value: "4"}})
+
+		handler.UpdateEntry(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "data.name is required")
+	})
+
+	t.Run("DeleteEntry - Success", func(t *testing.T) {
+		contentService := new(mocks.MockContentService)
+		handler := handlers.NewContentHandler(contentService)
+		contentService.On("GetType", "product").Return(product, nil)
+		contentService.On("DeleteEntry", product, uint(4)).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/content/product/4", "", gin.Params{{Key: "type", Value: "product"}, {Key: "id", Value: "4"}})
+
+		handler.DeleteEntry(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Delete entry successfully"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IEditLockHandler interface {
+	GetLock(c *gin.Context)
+	AcquireLock(c *gin.Context)
+	RenewLock(c *gin.Context)
+	ReleaseLock(c *gin.Context)
+	ForceReleaseLock(c *gin.Context)
+}
+
+type EditLockHandler struct {
+	editLockService services.IEditLockService
+}
+
+func NewEditLockHandler(editLockService services.IEditLockService) *EditLockHandler {
+	return &EditLockHandler{
+		editLockService: editLockService,
+	}
+}
+
+// GetLock tells whether a record is being edited, the lock is null when it is not
+func (handler *EditLockHandler) GetLock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, apperror.NewParseError("Invalid ResourceID"))
+		return
+	}
+
+	lock, err := handler.editLockService.GetLock(ctx.Param("resource"), uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"lock": lock})
+}
+
+// AcquireLock is called when an editor opens a record
+func (handler *EditLockHandler) AcquireLock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, apperror.NewParseError("Invalid ResourceID"))
+		return
+	}
+
+	lock, err := handler.editLockService.Acquire(ctx.Param("resource"), uint(id), ctx.GetUint("UserID"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, lock)
+}
+
+// RenewLock is the heartbeat of an open editor, it must be called before the lock expires
+func (handler *EditLockHandler) RenewLock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, apperror.NewParseError("Invalid ResourceID"))
+		return
+	}
+
+	lock, err := handler.editLockService.Renew(ctx.Param("resource"), uint(id), ctx.GetUint("UserID"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, lock)
+}
+
+// ReleaseLock is called when an editor is closed
+func (handler *EditLockHandler) ReleaseLock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, apperror.NewParseError("Invalid ResourceID"))
+		return
+	}
+
+	if err := handler.editLockService.Release(ctx.Param("resource"), uint(id), ctx.GetUint("UserID")); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Edit lock released successfully"})
+}
+
+// ForceReleaseLock lets an administrator unlock a record held by another user
+func (handler *EditLockHandler) ForceReleaseLock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(ctx, apperror.NewParseError("Invalid ResourceID"))
+		return
+	}
+
+	if err := handler.editLockService.ForceRelease(ctx.Param("resource"), uint(id)); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Edit lock released successfully"})
+}
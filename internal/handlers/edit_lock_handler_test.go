@@ -0,0 +1,114 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestEditLockHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+	params := gin.Params{{Key: "resource", Value: "posts"}, {Key: "id", Value: "5"}}
+
+	t.Run("GetLock - Not locked", func(t *testing.T) {
+		editLockService := new(mocks.MockEditLockService)
+		handler := handlers.NewEditLockHandler(editLockService)
+		editLockService.On("GetLock", "posts", uint(5)).Return(nil, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/locks/posts/5", "", params)
+
+		handler.GetLock(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"lock":null}`, w.Body.String())
+	})
+
+	t.Run("AcquireLock - Success", func(t *testing.T) {
+		editLockService := new(mocks.MockEditLockService)
+		handler := handlers.NewEditLockHandler(editLockService)
+		editLockService.On("Acquire", "posts", uint(5), uint(1)).Return(&services.EditLock{Resource: "posts", ResourceID: 5, UserID: 1, UserName: "John"}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/locks/posts/5", "", params)
+		c.Set("UserID", uint(1))
+
+		handler.AcquireLock(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"userName":"John"`)
+		editLockService.AssertExpectations(t)
+	})
+
+	t.Run("AcquireLock - Held by another user", func(t *testing.T) {
+		editLockService := new(mocks.MockEditLockService)
+		handler := handlers.NewEditLockHandler(editLockService)
+		editLockService.On("Acquire", "posts", uint(5), uint(1)).Return(nil, apperror.NewResourceLockedError("This record is being edited by Jane"))
+
+		w, c := newPostRequest("POST", "/api/v1/locks/posts/5", "", params)
+		c.Set("UserID", uint(1))
+
+		handler.AcquireLock(c)
+
+		assert.Equal(t, http.StatusLocked, w.Code)
+	})
+
+	t.Run("AcquireLock - Invalid ID", func(t *testing.T) {
+		editLockService := new(mocks.MockEditLockService)
+		handler := handlers.NewEditLockHandler(editLockService)
+
+		w, c := newPostRequest("POST", "/api/v1/locks/posts/abc", "", gin.Params{{Key: "resource", Value: "posts"}, {Key: "id", Value: "abc"}})
+
+		handler.AcquireLock(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		editLockService.AssertNotCalled(t, "Acquire", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RenewLock - Expired", func(t *testing.T) {
+		editLockService := new(mocks.MockEditLockService)
+		handler := handlers.NewEditLockHandler(editLockService)
+		editLockService.On("Renew", "posts", uint(5), uint(1)).Return(nil, apperror.NewNotFoundError("The edit lock has expired, acquire it again"))
+
+		w, c := newPostRequest("PUT", "/api/v1/locks/posts/5", "", params)
+		c.Set("UserID", uint(1))
+
+		handler.RenewLock(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ReleaseLock - Success", func(t *testing.T) {
+		editLockService := new(mocks.MockEditLockService)
+		handler := handlers.NewEditLockHandler(editLockService)
+		editLockService.On("Release", "posts", uint(5), uint(1)).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/locks/posts/5", "", params)
+		c.Set("UserID", uint(1))
+
+		handler.ReleaseLock(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		editLockService.AssertExpectations(t)
+	})
+
+	t.Run("ForceReleaseLock - Success", func(t *testing.T) {
+		editLockService := new(mocks.MockEditLockService)
+		handler := handlers.NewEditLockHandler(editLockService)
+		editLockService.On("ForceRelease", "posts", uint(5)).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/locks/posts/5/force", "", params)
+
+		handler.ForceReleaseLock(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		editLockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/feed"
+)
+
+type IFeedHandler interface {
+	GetRSSFeed(c *gin.Context)
+	GetAtomFeed(c *gin.Context)
+	GetJSONFeed(c *gin.Context)
+}
+
+type FeedHandler struct {
+	feedService services.IFeedService
+}
+
+func NewFeedHandler(feedService services.IFeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// GetRSSFeed retrieves the latest published posts as RSS 2.0, of a category or a tag when the route has a :category or :tag
+func (handler *FeedHandler) GetRSSFeed(ctx *gin.Context) {
+	handler.respondWithFeed(ctx, feed.RSSContentType, feed.RSS)
+}
+
+// GetAtomFeed retrieves the latest published posts as Atom 1.0, of a category or a tag when the route has a :category or :tag
+func (handler *FeedHandler) GetAtomFeed(ctx *gin.Context) {
+	handler.respondWithFeed(ctx, feed.AtomContentType, feed.Atom)
+}
+
+// GetJSONFeed retrieves the latest published posts as JSON Feed 1.1, of a category or a tag when the route has a :category or :tag
+func (handler *FeedHandler) GetJSONFeed(ctx *gin.Context) {
+	handler.respondWithFeed(ctx, feed.JSONContentType, feed.JSON)
+}
+
+// respondWithFeed builds the feed of the request and writes it in a format
+// The Last-Modified header is the time of the latest change of a post, the ResponseCacheMiddleware answers conditional requests with it
+func (handler *FeedHandler) respondWithFeed(ctx *gin.Context, contentType string, encode func(*feed.Feed) ([]byte, error)) {
+	result, err := handler.feedService.GetFeed(services.FeedQuery{
+		CategorySlug: ctx.Param("category"),
+		TagSlug:      ctx.Param("tag"),
+		Locale:       ctx.GetString("Locale"),
+		FeedURL:      requestURL(ctx),
+	})
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	body, err := encode(result)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.Header("Last-Modified", result.Updated.UTC().Format(http.TimeFormat))
+	ctx.Data(http.StatusOK, contentType, body)
+}
+
+// requestURL returns the absolute URL of a request, behind a proxy the scheme is taken from X-Forwarded-Proto
+func requestURL(ctx *gin.Context) string {
+	scheme := "http"
+	if ctx.Request.TLS != nil || ctx.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + ctx.Request.Host + ctx.Request.URL.RequestURI()
+}
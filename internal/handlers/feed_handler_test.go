@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/feed"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestFeedHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	updated := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	result := &feed.Feed{Title: "Blog", Link: "https://example.com", Updated: updated, Items: []feed.Item{
+		{ID: "tag:example.com,2030-01-02:posts/1", Title: "Hello", Published: updated, Updated: updated},
+	}}
+
+	t.Run("GetRSSFeed - Success", func(t *testing.T) {
+		feedService := new(mocks.MockFeedService)
+		handler := handlers.NewFeedHandler(feedService)
+		feedService.On("GetFeed", services.FeedQuery{Locale: "en", FeedURL: "https://blog.example.com/feeds/rss.xml"}).Return(result, nil)
+
+		w, c := newPostRequest("GET", "https://blog.example.com/feeds/rss.xml", "", nil)
+		c.Request.Host = "blog.example.com"
+		c.Request.Header.Set("X-Forwarded-Proto", "https")
+		c.Set("Locale", "en")
+
+		handler.GetRSSFeed(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, feed.RSSContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "Wed, 02 Jan 2030 03:04:05 GMT", w.Header().Get("Last-Modified"))
+		assert.Contains(t, w.Body.String(), "<title>Hello</title>")
+		feedService.AssertExpectations(t)
+	})
+
+	t.Run("GetAtomFeed - Category", func(t *testing.T) {
+		feedService := new(mocks.MockFeedService)
+		handler := handlers.NewFeedHandler(feedService)
+		feedService.On("GetFeed", services.FeedQuery{CategorySlug: "news", Locale: "vi", FeedURL: "http://localhost/feeds/categories/news/atom.xml"}).Return(result, nil)
+
+		w, c := newPostRequest("GET", "/feeds/categories/news/atom.xml", "", gin.Params{{Key: "category", Value: "news"}})
+		c.Request.Host = "localhost"
+		c.Set("Locale", "vi")
+
+		handler.GetAtomFeed(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, feed.AtomContentType, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "<updated>2030-01-02T03:04:05Z</updated>")
+	})
+
+	t.Run("GetJSONFeed - Unknown tag", func(t *testing.T) {
+		feedService := new(mocks.MockFeedService)
+		handler := handlers.NewFeedHandler(feedService)
+		feedService.On("GetFeed", services.FeedQuery{TagSlug: "missing", FeedURL: "http://localhost/feeds/tags/missing/feed.json"}).
+			Return(nil, apperror.NewNotFoundError("Tag not found"))
+
+		w, c := newPostRequest("GET", "/feeds/tags/missing/feed.json", "", gin.Params{{Key: "tag", Value: "missing"}})
+		c.Request.Host = "localhost"
+
+		handler.GetJSONFeed(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetJSONFeed - Internal error", func(t *testing.T) {
+		feedService := new(mocks.MockFeedService)
+		handler := handlers.NewFeedHandler(feedService)
+		feedService.On("GetFeed", services.FeedQuery{FeedURL: "http://localhost/feeds/feed.json"}).Return(nil, errors.New("boom"))
+
+		w, c := newPostRequest("GET", "/feeds/feed.json", "", nil)
+		c.Request.Host = "localhost"
+
+		handler.GetJSONFeed(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
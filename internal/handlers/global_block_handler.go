@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+)
+
+type IGlobalBlockHandler interface {
+	GetGlobalBlocks(c *gin.Context)
+	GetGlobalBlock(c *gin.Context)
+	CreateGlobalBlock(c *gin.Context)
+	UpdateGlobalBlock(c *gin.Context)
+	DeleteGlobalBlock(c *gin.Context)
+}
+
+type GlobalBlockHandler struct {
+	globalBlockService services.IGlobalBlockService
+}
+
+func NewGlobalBlockHandler(globalBlockService services.IGlobalBlockService) *GlobalBlockHandler {
+	return &GlobalBlockHandler{
+		globalBlockService: globalBlockService,
+	}
+}
+
+func (handler *GlobalBlockHandler) GetGlobalBlocks(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Search on the name, e.g. ?search=footer
+	pagination, err := handler.globalBlockService.PaginateGlobalBlocks(page, limit, ctx.Query("search"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *GlobalBlockHandler) GetGlobalBlock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid GlobalBlockID"),
+		)
+		return
+	}
+
+	block, err := handler.globalBlockService.GetGlobalBlock(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, block)
+}
+
+func (handler *GlobalBlockHandler) CreateGlobalBlock(ctx *gin.Context) {
+	// The blocks are validated by type in the service, the errors point at them, e.g. "blocks[1].data.url"
+	var input struct {
+		Name        string         `json:"name" binding:"required,max=100,not_blank"`
+		Description *string        `json:"description" binding:"omitempty,max=500"`
+		Blocks      []blocks.Block `json:"blocks" binding:"required"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	block := models.GlobalBlock{
+		Name:        input.Name,
+		Description: input.Description,
+		Blocks:      input.Blocks,
+	}
+
+	if err := handler.globalBlockService.CreateGlobalBlock(&block); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, block)
+}
+
+func (handler *GlobalBlockHandler) UpdateGlobalBlock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid GlobalBlockID"),
+		)
+		return
+	}
+
+	var input struct {
+		Name        *string        `json:"name" binding:"omitempty,max=100,not_blank"`
+		Description *string        `json:"description" binding:"omitempty,max=500"`
+		Blocks      []blocks.Block `json:"blocks"` // Replaces every block when given
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	block, err := handler.globalBlockService.GetGlobalBlock(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Name != nil {
+		block.Name = *input.Name
+	}
+	if input.Description != nil {
+		block.Description = input.Description
+	}
+	if input.Blocks != nil {
+		block.Blocks = input.Blocks
+	}
+
+	if err := handler.globalBlockService.UpdateGlobalBlock(block); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, block)
+}
+
+func (handler *GlobalBlockHandler) DeleteGlobalBlock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid GlobalBlockID"),
+		)
+		return
+	}
+
+	if err := handler.globalBlockService.DeleteGlobalBlock(uint(id)); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete global block successfully"})
+}
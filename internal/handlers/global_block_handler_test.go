@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestGlobalBlockHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetGlobalBlocks - Search", func(t *testing.T) {
+		globalBlockService := new(mocks.MockGlobalBlockService)
+		handler := handlers.NewGlobalBlockHandler(globalBlockService)
+		globalBlockService.On("PaginateGlobalBlocks", 1, 50, "foot").Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.GlobalBlock{{ID: 1, Name: "Footer"}}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/global-blocks?search=foot", "", nil)
+
+		handler.GetGlobalBlocks(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"Footer"`)
+		globalBlockService.AssertExpectations(t)
+	})
+
+	t.Run("CreateGlobalBlock - Success", func(t *testing.T) {
+		globalBlockService := new(mocks.MockGlobalBlockService)
+		handler := handlers.NewGlobalBlockHandler(globalBlockService)
+		globalBlockService.On("CreateGlobalBlock", mock.MatchedBy(func(block *models.GlobalBlock) bool {
+			return block.Name == "Newsletter" && len(block.Blocks) == 1 && block.Blocks[0].Type == blocks.TypeCallToAction
+		})).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/global-blocks",
+			`{"name":"Newsletter","blocks":[{"type":"call_to_action","data":{"label":"Subscribe","url":"/newsletter"}}]}`, nil)
+
+		handler.CreateGlobalBlock(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"label":"Subscribe"`)
+		globalBlockService.AssertExpectations(t)
+	})
+
+	t.Run("CreateGlobalBlock - Missing blocks", func(t *testing.T) {
+		globalBlockService := new(mocks.MockGlobalBlockService)
+		handler := handlers.NewGlobalBlockHandler(globalBlockService)
+
+		w, c := newPostRequest("POST", "/api/v1/global-blocks", `{"name":"Newsletter"}`, nil)
+
+		handler.CreateGlobalBlock(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		globalBlockService.AssertNotCalled(t, "CreateGlobalBlock", mock.Anything)
+	})
+
+	t.Run("UpdateGlobalBlock - Invalid block", func(t *testing.T) {
+		globalBlockService := new(mocks.MockGlobalBlockService)
+		handler := handlers.NewGlobalBlockHandler(globalBlockService)
+		block := &models.GlobalBlock{ID: 1, Name: "Footer", Blocks: []blocks.Block{}}
+		globalBlockService.On("GetGlobalBlock", uint(1)).Return(block, nil)
+		globalBlockService.On("UpdateGlobalBlock", block).Return(apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "blocks[0].data.text", Message: "text is required"},
+		}))
+
+		w, c := newPostRequest("PATCH", "/api/v1/global-blocks/1", `{"blocks":[{"type":"paragraph","data":{}}]}`, gin.Params{{Key: "id", Value: "1"}})
+
+		handler.UpdateGlobalBlock(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "blocks[0].data.text")
+		assert.Equal(t, blocks.TypeParagraph, block.Blocks[0].Type)
+	})
+
+	t.Run("DeleteGlobalBlock - Invalid ID", func(t *testing.T) {
+		globalBlockService := new(mocks.MockGlobalBlockService)
+		handler := handlers.NewGlobalBlockHandler(globalBlockService)
+
+		w, c := newPostRequest("DELETE", "/api/v1/global-blocks/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.DeleteGlobalBlock(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		globalBlockService.AssertNotCalled(t, "DeleteGlobalBlock", mock.Anything)
+	})
+}
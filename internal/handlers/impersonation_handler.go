@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IImpersonationHandler interface {
+	StartImpersonation(c *gin.Context)
+	StopImpersonation(c *gin.Context)
+}
+
+type ImpersonationHandler struct {
+	impersonationService services.IImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService services.IImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+func (handler *ImpersonationHandler) StartImpersonation(ctx *gin.Context) {
+	// Get the support user ID from the context
+	impersonatorId := ctx.GetUint("UserID")
+	if impersonatorId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// Get the ID of the user to impersonate
+	userId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	var input struct {
+		Reason string `json:"reason" binding:"required,min=1,max=255,not_blank"` // Why the session is needed, e.g. a ticket number
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	res, err := handler.impersonationService.Start(impersonatorId, uint(userId), input.Reason, ctx.ClientIP())
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, res)
+}
+
+func (handler *ImpersonationHandler) StopImpersonation(ctx *gin.Context) {
+	// Only impersonation tokens carry the impersonator ID
+	impersonatorId := ctx.GetUint("ImpersonatorID")
+	if impersonatorId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewBadRequestError("Not impersonating"),
+		)
+		return
+	}
+
+	userId := ctx.GetUint("UserID")
+	sessionId := ctx.GetString("ImpersonationID")
+	if err := handler.impersonationService.Stop(impersonatorId, userId, sessionId, ctx.ClientIP()); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Stop impersonation successfully"})
+}
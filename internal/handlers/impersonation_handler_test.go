@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestStartImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	newRequest := func(id, body string) (*httptest.ResponseRecorder, *gin.Context) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/api/v1/users/"+id+"/impersonate", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		return w, c
+	}
+
+	t.Run("StartImpersonation - Success", func(t *testing.T) {
+		service := new(mocks.MockImpersonationService)
+		handler := handlers.NewImpersonationHandler(service)
+		service.On("Start", uint(1), uint(5), "TICKET-1", mock.Anything).Return(&services.ImpersonationResponse{
+			AccessToken:    services.JwtResult{Token: "token", ExpiresAt: 100},
+			ImpersonatorID: 1,
+			User:           &models.User{ID: 5},
+		}, nil)
+
+		w, c := newRequest("5", `{"reason":"TICKET-1"}`)
+		c.Set("UserID", uint(1))
+
+		handler.StartImpersonation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"accessToken":{"token":"token","expiresAt":100}`)
+		assert.Contains(t, w.Body.String(), `"impersonatorId":1`)
+		service.AssertExpectations(t)
+	})
+
+	t.Run("StartImpersonation - Invalid UserID", func(t *testing.T) {
+		handler := handlers.NewImpersonationHandler(new(mocks.MockImpersonationService))
+
+		w, c := newRequest("abc", `{"reason":"TICKET-1"}`)
+		c.Set("UserID", uint(1))
+
+		handler.StartImpersonation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid UserID"}`, w.Body.String())
+	})
+
+	t.Run("StartImpersonation - Missing actor", func(t *testing.T) {
+		handler := handlers.NewImpersonationHandler(new(mocks.MockImpersonationService))
+
+		w, c := newRequest("5", `{"reason":"TICKET-1"}`)
+
+		handler.StartImpersonation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("StartImpersonation - Missing reason", func(t *testing.T) {
+		handler := handlers.NewImpersonationHandler(new(mocks.MockImpersonationService))
+
+		w, c := newRequest("5", `{"reason":"  "}`)
+		c.Set("UserID", uint(1))
+
+		handler.StartImpersonation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "reason must not be blank")
+	})
+
+	t.Run("StartImpersonation - Service error", func(t *testing.T) {
+		service := new(mocks.MockImpersonationService)
+		handler := handlers.NewImpersonationHandler(service)
+		service.On("Start", uint(1), uint(2), "TICKET-1", mock.Anything).
+			Return((*services.ImpersonationResponse)(nil), apperror.NewForbiddenError("This user cannot be impersonated"))
+
+		w, c := newRequest("2", `{"reason":"TICKET-1"}`)
+		c.Set("UserID", uint(1))
+
+		handler.StartImpersonation(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"code":3001,"message":"This user cannot be impersonated"}`, w.Body.String())
+	})
+}
+
+func TestStopImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("StopImpersonation - Success", func(t *testing.T) {
+		service := new(mocks.MockImpersonationService)
+		handler := handlers.NewImpersonationHandler(service)
+		service.On("Stop", uint(1), uint(5), "session", mock.Anything).Return(nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/impersonation", nil)
+		c.Set("UserID", uint(5))
+		c.Set("ImpersonatorID", uint(1))
+		c.Set("ImpersonationID", "session")
+
+		handler.StopImpersonation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Stop impersonation successfully"}`, w.Body.String())
+		service.AssertExpectations(t)
+	})
+
+	t.Run("StopImpersonation - Not impersonating", func(t *testing.T) {
+		handler := handlers.NewImpersonationHandler(new(mocks.MockImpersonationService))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/impersonation", nil)
+		c.Set("UserID", uint(5))
+
+		handler.StopImpersonation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":1002,"message":"Not impersonating"}`, w.Body.String())
+	})
+
+	t.Run("StopImpersonation - Service error", func(t *testing.T) {
+		service := new(mocks.MockImpersonationService)
+		handler := handlers.NewImpersonationHandler(service)
+		service.On("Stop", uint(1), uint(5), "session", mock.Anything).Return(apperror.NewDBInsertError("db error"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/api/v1/impersonation", nil)
+		c.Set("UserID", uint(5))
+		c.Set("ImpersonatorID", uint(1))
+		c.Set("ImpersonationID", "session")
+
+		handler.StopImpersonation(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
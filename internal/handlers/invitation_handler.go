@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// invitationStatusFilters are the accepted values of the status query parameter, "all" disables the filter
+var invitationStatusFilters = []string{
+	models.InvitationStatusPending,
+	models.InvitationStatusAccepted,
+	models.InvitationStatusRevoked,
+	"all",
+}
+
+type IInvitationHandler interface {
+	InviteUser(c *gin.Context)
+	GetInvitations(c *gin.Context)
+	ResendInvitation(c *gin.Context)
+	RevokeInvitation(c *gin.Context)
+	AcceptInvitation(c *gin.Context)
+}
+
+type InvitationHandler struct {
+	invitationService services.IInvitationService
+}
+
+func NewInvitationHandler(invitationService services.IInvitationService) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+	}
+}
+
+func (handler *InvitationHandler) InviteUser(ctx *gin.Context) {
+	// Get the inviting user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	var input struct {
+		Email   string `json:"email" binding:"required,email,max=45"`
+		Name    string `json:"name" binding:"required,min=1,max=45,not_blank"`  // Name must be between 1-45 chars and not blank
+		RoleIds []uint `json:"role_ids" binding:"required,min=1,dive,required"` // RoleIds must be a non-empty array of uints
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	invitation, err := handler.invitationService.Invite(userId, services.InviteInput{
+		Email:   input.Email,
+		Name:    input.Name,
+		RoleIDs: input.RoleIds,
+	})
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, invitation)
+}
+
+func (handler *InvitationHandler) GetInvitations(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Only pending invitations are listed unless another status is requested, e.g. ?status=all
+	status := ctx.DefaultQuery("status", models.InvitationStatusPending)
+	if !slices.Contains(invitationStatusFilters, status) {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewValidationDataError("status must be one of [pending accepted revoked all]"),
+		)
+		return
+	}
+	if status == "all" {
+		status = ""
+	}
+
+	pagination, err := handler.invitationService.PaginateInvitations(page, limit, status)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *InvitationHandler) ResendInvitation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid InvitationID"),
+		)
+		return
+	}
+
+	invitation, err := handler.invitationService.Resend(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, invitation)
+}
+
+func (handler *InvitationHandler) RevokeInvitation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid InvitationID"),
+		)
+		return
+	}
+
+	if _, err := handler.invitationService.Revoke(uint(id)); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Revoke invitation successfully"})
+}
+
+func (handler *InvitationHandler) AcceptInvitation(ctx *gin.Context) {
+	var input struct {
+		Token           string `json:"token" binding:"required"`
+		Password        string `json:"password" binding:"required,min=6,max=255"`
+		ConfirmPassword string `json:"confirm_password" binding:"required,min=6,max=255"`
+	}
+
+	// Bind and validate JSON request body
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	// Check if password and confirm password match
+	if input.Password != input.ConfirmPassword {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewPasswordMismatchError("Password and confirm password do not match"),
+		)
+		return
+	}
+
+	if _, err := handler.invitationService.Accept(input.Token, input.Password); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Accept invitation successfully"})
+}
@@ -0,0 +1,198 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newInvitationRequest(method, url, body string, params gin.Params) (*httptest.ResponseRecorder, *gin.Context) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, url, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	return w, c
+}
+
+func TestInvitationHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("InviteUser - Success", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("Invite", uint(1), services.InviteInput{Email: "new@example.com", Name: "New", RoleIDs: []uint{2}}).
+			Return(&models.Invitation{ID: 3, UserID: 5, Status: models.InvitationStatusPending}, nil)
+
+		w, c := newInvitationRequest("POST", "/api/v1/users/invite", `{"email":"new@example.com","name":"New","role_ids":[2]}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.InviteUser(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+		assert.NotContains(t, w.Body.String(), "token")
+		invitationService.AssertExpectations(t)
+	})
+
+	t.Run("InviteUser - Invalid UserID", func(t *testing.T) {
+		handler := handlers.NewInvitationHandler(new(mocks.MockInvitationService))
+
+		w, c := newInvitationRequest("POST", "/api/v1/users/invite", `{}`, nil)
+
+		handler.InviteUser(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid UserID"}`, w.Body.String())
+	})
+
+	t.Run("InviteUser - Validation Error", func(t *testing.T) {
+		handler := handlers.NewInvitationHandler(new(mocks.MockInvitationService))
+
+		w, c := newInvitationRequest("POST", "/api/v1/users/invite", `{"email":"not-an-email","name":"New","role_ids":[]}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.InviteUser(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "email")
+		assert.Contains(t, w.Body.String(), "role_ids")
+	})
+
+	t.Run("GetInvitations - Default pending", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("PaginateInvitations", 1, 50, models.InvitationStatusPending).
+			Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Invitation{}}, nil)
+
+		w, c := newInvitationRequest("GET", "/api/v1/invitations", "", nil)
+
+		handler.GetInvitations(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		invitationService.AssertExpectations(t)
+	})
+
+	t.Run("GetInvitations - All", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("PaginateInvitations", 1, 50, "").
+			Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Invitation{}}, nil)
+
+		w, c := newInvitationRequest("GET", "/api/v1/invitations?status=all", "", nil)
+
+		handler.GetInvitations(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		invitationService.AssertExpectations(t)
+	})
+
+	t.Run("GetInvitations - Invalid status", func(t *testing.T) {
+		handler := handlers.NewInvitationHandler(new(mocks.MockInvitationService))
+
+		w, c := newInvitationRequest("GET", "/api/v1/invitations?status=unknown", "", nil)
+
+		handler.GetInvitations(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "status must be one of")
+	})
+
+	t.Run("ResendInvitation - Success", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("Resend", uint(3)).Return(&models.Invitation{ID: 3, Status: models.InvitationStatusPending}, nil)
+
+		w, c := newInvitationRequest("POST", "/api/v1/invitations/3/resend", `{}`, gin.Params{{Key: "id", Value: "3"}})
+
+		handler.ResendInvitation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		invitationService.AssertExpectations(t)
+	})
+
+	t.Run("ResendInvitation - Invalid InvitationID", func(t *testing.T) {
+		handler := handlers.NewInvitationHandler(new(mocks.MockInvitationService))
+
+		w, c := newInvitationRequest("POST", "/api/v1/invitations/abc/resend", `{}`, gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.ResendInvitation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid InvitationID"}`, w.Body.String())
+	})
+
+	t.Run("RevokeInvitation - Success", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("Revoke", uint(3)).Return(&models.Invitation{ID: 3, Status: models.InvitationStatusRevoked}, nil)
+
+		w, c := newInvitationRequest("DELETE", "/api/v1/invitations/3", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.RevokeInvitation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Revoke invitation successfully"}`, w.Body.String())
+	})
+
+	t.Run("RevokeInvitation - Not pending", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("Revoke", uint(3)).Return(nil, apperror.NewBadRequestError("Only pending invitations can be changed"))
+
+		w, c := newInvitationRequest("DELETE", "/api/v1/invitations/3", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.RevokeInvitation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Only pending invitations can be changed")
+	})
+
+	t.Run("AcceptInvitation - Success", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("Accept", "token", "secret123").Return(&models.User{ID: 5, Status: models.UserStatusActive}, nil)
+
+		w, c := newInvitationRequest("POST", "/api/v1/invitations/accept", `{"token":"token","password":"secret123","confirm_password":"secret123"}`, nil)
+
+		handler.AcceptInvitation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Accept invitation successfully"}`, w.Body.String())
+		invitationService.AssertExpectations(t)
+	})
+
+	t.Run("AcceptInvitation - Password mismatch", func(t *testing.T) {
+		handler := handlers.NewInvitationHandler(new(mocks.MockInvitationService))
+
+		w, c := newInvitationRequest("POST", "/api/v1/invitations/accept", `{"token":"token","password":"secret123","confirm_password":"secret456"}`, nil)
+
+		handler.AcceptInvitation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Password and confirm password do not match")
+	})
+
+	t.Run("AcceptInvitation - Expired", func(t *testing.T) {
+		invitationService := new(mocks.MockInvitationService)
+		handler := handlers.NewInvitationHandler(invitationService)
+		invitationService.On("Accept", "token", "secret123").Return(nil, apperror.NewTokenExpiredError("Invitation has expired"))
+
+		w, c := newInvitationRequest("POST", "/api/v1/invitations/accept", `{"token":"token","password":"secret123","confirm_password":"secret123"}`, nil)
+
+		handler.AcceptInvitation(c)
+
+		assert.Contains(t, w.Body.String(), "Invitation has expired")
+	})
+}
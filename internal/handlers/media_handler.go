@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// mediaTypePrefixes maps the ?type filter of the media library to MIME type prefixes
+var mediaTypePrefixes = map[string]string{
+	"image":    "image/",
+	"video":    "video/",
+	"audio":    "audio/",
+	"document": "application/",
+}
+
+type IMediaHandler interface {
+	GetMedia(c *gin.Context)
+	GetMediaItem(c *gin.Context)
+	UploadMedia(c *gin.Context)
+	UpdateMedia(c *gin.Context)
+	DeleteMedia(c *gin.Context)
+	GetFolders(c *gin.Context)
+	CreateFolder(c *gin.Context)
+	UpdateFolder(c *gin.Context)
+	DeleteFolder(c *gin.Context)
+}
+
+type MediaHandler struct {
+	mediaService services.IMediaService
+}
+
+func NewMediaHandler(mediaService services.IMediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+func (handler *MediaHandler) GetMedia(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Filter on folder, type and text, e.g. ?folder_id=2&type=image&search=logo
+	filter := repositories.MediaFilter{
+		Search: ctx.Query("search"),
+	}
+	if folderId := ctx.Query("folder_id"); folderId != "" {
+		id, err := strconv.Atoi(folderId)
+		if err != nil || id <= 0 {
+			utils.RespondWithError(
+				ctx,
+				apperror.NewParseError("Invalid FolderID"),
+			)
+			return
+		}
+		filter.FolderID = uint(id)
+	}
+	if mediaType := ctx.Query("type"); mediaType != "" {
+		prefix, ok := mediaTypePrefixes[mediaType]
+		if !ok {
+			types := make([]string, 0, len(mediaTypePrefixes))
+			for name := range mediaTypePrefixes {
+				types = append(types, name)
+			}
+			slices.Sort(types)
+			utils.RespondWithError(
+				ctx,
+				apperror.NewValidationDataError(fmt.Sprintf("type must be one of %v", types)),
+			)
+			return
+		}
+		filter.MimePrefix = prefix
+	}
+
+	pagination, err := handler.mediaService.PaginateMedia(page, limit, filter)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *MediaHandler) GetMediaItem(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid MediaID"),
+		)
+		return
+	}
+
+	media, err := handler.mediaService.GetMedia(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, media)
+}
+
+func (handler *MediaHandler) UploadMedia(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// Multipart form with the file in the "file" field and optional metadata fields
+	var input struct {
+		File     *multipart.FileHeader `form:"file" json:"file" binding:"required"`
+		FolderID *uint                 `form:"folder_id" json:"folder_id" binding:"omitempty,min=1"`
+		AltText  *string               `form:"alt_text" json:"alt_text" binding:"omitempty,max=255"`
+		Caption  *string               `form:"caption" json:"caption" binding:"omitempty,max=1000"`
+	}
+
+	if err := ctx.ShouldBind(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	data, err := readUpload(input.File, handler.mediaService.MaxUploadSize(), "File")
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	media, created, err := handler.mediaService.UploadMedia(services.MediaUpload{
+		UploaderID: userId,
+		FileName:   input.File.Filename,
+		Data:       data,
+		FolderID:   input.FolderID,
+		AltText:    input.AltText,
+		Caption:    input.Caption,
+	})
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	// An identical file already in the library is returned instead of being stored twice
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	utils.RespondWithOK(ctx, status, media)
+}
+
+func (handler *MediaHandler) UpdateMedia(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid MediaID"),
+		)
+		return
+	}
+
+	var input struct {
+		FolderID *uint   `json:"folder_id" binding:"omitempty"` // 0 moves the file to the root of the library
+		FileName *string `json:"file_name" binding:"omitempty,max=255,not_blank"`
+		AltText  *string `json:"alt_text" binding:"omitempty,max=255"`
+		Caption  *string `json:"caption" binding:"omitempty,max=1000"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	media, err := handler.mediaService.GetMedia(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.FolderID != nil {
+		media.FolderID = input.FolderID
+		if *input.FolderID == 0 {
+			media.FolderID = nil
+		}
+		media.Folder = nil
+	}
+	if input.FileName != nil {
+		media.FileName = *input.FileName
+	}
+	if input.AltText != nil {
+		media.AltText = utils.StringToPtr(*input.AltText)
+	}
+	if input.Caption != nil {
+		media.Caption = utils.StringToPtr(*input.Caption)
+	}
+
+	if err := handler.mediaService.UpdateMedia(media); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, media)
+}
+
+func (handler *MediaHandler) DeleteMedia(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid MediaID"),
+		)
+		return
+	}
+
+	media, err := handler.mediaService.GetMedia(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.mediaService.DeleteMedia(media); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete media successfully"})
+}
+
+func (handler *MediaHandler) GetFolders(ctx *gin.Context) {
+	folders, err := handler.mediaService.GetFolders()
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, folders)
+}
+
+func (handler *MediaHandler) CreateFolder(ctx *gin.Context) {
+	var input struct {
+		ParentID *uint  `json:"parent_id" binding:"omitempty,min=1"` // Empty for a folder at the root of the library
+		Name     string `json:"name" binding:"required,max=100,not_blank"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	folder := models.MediaFolder{
+		ParentID: input.ParentID,
+		Name:     input.Name,
+	}
+
+	if err := handler.mediaService.CreateFolder(&folder); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, folder)
+}
+
+func (handler *MediaHandler) UpdateFolder(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid FolderID"),
+		)
+		return
+	}
+
+	var input struct {
+		ParentID *uint   `json:"parent_id" binding:"omitempty"` // 0 moves the folder to the root of the library
+		Name     *string `json:"name" binding:"omitempty,max=100,not_blank"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	folder, err := handler.mediaService.GetFolder(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.ParentID != nil {
+		folder.ParentID = input.ParentID
+		if *input.ParentID == 0 {
+			folder.ParentID = nil
+		}
+	}
+	if input.Name != nil {
+		folder.Name = *input.Name
+	}
+
+	if err := handler.mediaService.UpdateFolder(folder); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, folder)
+}
+
+func (handler *MediaHandler) DeleteFolder(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid FolderID"),
+		)
+		return
+	}
+
+	// Make sure the folder exists before deleting it
+	folder, err := handler.mediaService.GetFolder(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.mediaService.DeleteFolder(folder.ID); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete folder successfully"})
+}
@@ -0,0 +1,221 @@
+package handlers_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newMediaUploadRequest(t *testing.T, fields map[string]string, content []byte) (*httptest.ResponseRecorder, *gin.Context) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		_ = writer.WriteField(name, value)
+	}
+	if content != nil {
+		part, err := writer.CreateFormFile("file", "logo.png")
+		assert.NoError(t, err)
+		_, _ = part.Write(content)
+	}
+	_ = writer.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/api/v1/media", body)
+	c.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	return w, c
+}
+
+func TestMediaHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("UploadMedia - Created", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		mediaService.On("MaxUploadSize").Return(int64(1024))
+		mediaService.On("UploadMedia", mock.MatchedBy(func(upload services.MediaUpload) bool {
+			return upload.UploaderID == 1 && upload.FileName == "logo.png" && string(upload.Data) == "image-bytes" &&
+				*upload.FolderID == 2 && *upload.AltText == "Logo"
+		})).Return(&models.Media{ID: 4, FileName: "logo.png"}, true, nil)
+
+		w, c := newMediaUploadRequest(t, map[string]string{"folder_id": "2", "alt_text": "Logo"}, []byte("image-bytes"))
+		c.Set("UserID", uint(1))
+
+		handler.UploadMedia(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"fileName":"logo.png"`)
+		mediaService.AssertExpectations(t)
+	})
+
+	t.Run("UploadMedia - Duplicate", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		mediaService.On("MaxUploadSize").Return(int64(1024))
+		mediaService.On("UploadMedia", mock.Anything).Return(&models.Media{ID: 4}, false, nil)
+
+		w, c := newMediaUploadRequest(t, nil, []byte("image-bytes"))
+		c.Set("UserID", uint(1))
+
+		handler.UploadMedia(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("UploadMedia - Missing file", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+
+		w, c := newMediaUploadRequest(t, map[string]string{"alt_text": "Logo"}, nil)
+		c.Set("UserID", uint(1))
+
+		handler.UploadMedia(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "file is required")
+		mediaService.AssertNotCalled(t, "UploadMedia", mock.Anything)
+	})
+
+	t.Run("UploadMedia - Unsupported type", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		mediaService.On("MaxUploadSize").Return(int64(1024))
+		mediaService.On("UploadMedia", mock.Anything).Return(nil, false, apperror.NewUnsupportedFileTypeError("unsupported"))
+
+		w, c := newMediaUploadRequest(t, nil, []byte("text"))
+		c.Set("UserID", uint(1))
+
+		handler.UploadMedia(c)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	})
+
+	t.Run("UploadMedia - File too large", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		mediaService.On("MaxUploadSize").Return(int64(4))
+
+		w, c := newMediaUploadRequest(t, nil, []byte("image-bytes"))
+		c.Set("UserID", uint(1))
+
+		handler.UploadMedia(c)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), "File must not be larger than 4 bytes")
+		mediaService.AssertNotCalled(t, "UploadMedia", mock.Anything)
+	})
+
+	t.Run("GetMedia - Filters", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		filter := repositories.MediaFilter{FolderID: 2, MimePrefix: "image/", Search: "logo"}
+		mediaService.On("PaginateMedia", 1, 50, filter).Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Media{}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/media?folder_id=2&type=image&search=logo", "", nil)
+
+		handler.GetMedia(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mediaService.AssertExpectations(t)
+	})
+
+	t.Run("GetMedia - Invalid type", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+
+		w, c := newPostRequest("GET", "/api/v1/media?type=spreadsheet", "", nil)
+
+		handler.GetMedia(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "type must be one of")
+	})
+
+	t.Run("UpdateMedia - Move to root", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		folderID := uint(2)
+		media := &models.Media{ID: 4, FolderID: &folderID, Folder: &models.MediaFolder{ID: 2}}
+		mediaService.On("GetMedia", uint(4)).Return(media, nil)
+		mediaService.On("UpdateMedia", media).Return(nil)
+
+		w, c := newPostRequest("PATCH", "/api/v1/media/4", `{"folder_id":0,"caption":"Our logo"}`, gin.Params{{Key: "id", Value: "4"}})
+
+		handler.UpdateMedia(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, media.FolderID)
+		assert.Nil(t, media.Folder)
+		assert.Equal(t, "Our logo", *media.Caption)
+	})
+
+	t.Run("DeleteMedia - Success", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		media := &models.Media{ID: 4}
+		mediaService.On("GetMedia", uint(4)).Return(media, nil)
+		mediaService.On("DeleteMedia", media).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/media/4", "", gin.Params{{Key: "id", Value: "4"}})
+
+		handler.DeleteMedia(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Delete media successfully")
+		mediaService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteMedia - Invalid ID", func(t *testing.T) {
+		handler := handlers.NewMediaHandler(new(mocks.MockMediaService))
+
+		w, c := newPostRequest("DELETE", "/api/v1/media/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.DeleteMedia(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid MediaID")
+	})
+
+	t.Run("CreateFolder - Success", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		mediaService.On("CreateFolder", mock.MatchedBy(func(folder *models.MediaFolder) bool {
+			return folder.Name == "Logos" && *folder.ParentID == 1
+		})).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/media-folders", `{"name":"Logos","parent_id":1}`, nil)
+
+		handler.CreateFolder(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mediaService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteFolder - Has subfolders", func(t *testing.T) {
+		mediaService := new(mocks.MockMediaService)
+		handler := handlers.NewMediaHandler(mediaService)
+		mediaService.On("GetFolder", uint(1)).Return(&models.MediaFolder{ID: 1}, nil)
+		mediaService.On("DeleteFolder", uint(1)).Return(apperror.NewBadRequestError("Folder has subfolders, move or delete them first"))
+
+		w, c := newPostRequest("DELETE", "/api/v1/media-folders/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		handler.DeleteFolder(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Folder has subfolders")
+	})
+}
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// menuItemInput is a menu item of a request, nested items are given in its children
+type menuItemInput struct {
+	Label        *string         `json:"label" binding:"omitempty,max=255"` // Defaults to the title of the target
+	Type         string          `json:"type" binding:"required,oneof=page post category url"`
+	TargetID     *uint           `json:"target_id" binding:"omitempty,min=1"` // Required for page, post and category items
+	URL          *string         `json:"url" binding:"omitempty,max=1000"`    // Required for url items
+	OpenInNewTab bool            `json:"open_in_new_tab"`
+	Children     []menuItemInput `json:"children" binding:"omitempty,max=50,dive"`
+}
+
+type IMenuHandler interface {
+	GetMenus(c *gin.Context)
+	GetMenu(c *gin.Context)
+	GetPublicMenu(c *gin.Context)
+	CreateMenu(c *gin.Context)
+	UpdateMenu(c *gin.Context)
+	ReplaceMenuItems(c *gin.Context)
+	DeleteMenu(c *gin.Context)
+}
+
+type MenuHandler struct {
+	menuService services.IMenuService
+}
+
+func NewMenuHandler(menuService services.IMenuService) *MenuHandler {
+	return &MenuHandler{
+		menuService: menuService,
+	}
+}
+
+func (handler *MenuHandler) GetMenus(ctx *gin.Context) {
+	menus, err := handler.menuService.GetMenus()
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, menus)
+}
+
+func (handler *MenuHandler) GetMenu(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid MenuID"),
+		)
+		return
+	}
+
+	menu, err := handler.menuService.GetMenu(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, menu)
+}
+
+func (handler *MenuHandler) GetPublicMenu(ctx *gin.Context) {
+	links, err := handler.menuService.GetPublicMenu(ctx.Param("handle"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, links)
+}
+
+func (handler *MenuHandler) CreateMenu(ctx *gin.Context) {
+	var input struct {
+		Name   string `json:"name" binding:"required,max=100,not_blank"`
+		Handle string `json:"handle" binding:"omitempty,max=100"` // Generated from the name when empty
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	menu := models.Menu{
+		Name:   input.Name,
+		Handle: input.Handle,
+	}
+
+	if err := handler.menuService.CreateMenu(&menu); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, menu)
+}
+
+func (handler *MenuHandler) UpdateMenu(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid MenuID"),
+		)
+		return
+	}
+
+	var input struct {
+		Name   *string `json:"name" binding:"omitempty,max=100,not_blank"`
+		Handle *string `json:"handle" binding:"omitempty,min=1,max=100"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	menu, err := handler.menuService.GetMenu(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Name != nil {
+		menu.Name = *input.Name
+	}
+	if input.Handle != nil {
+		menu.Handle = *input.Handle
+	}
+
+	if err := handler.menuService.UpdateMenu(menu); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, menu)
+}
+
+func (handler *MenuHandler) ReplaceMenuItems(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid MenuID"),
+		)
+		return
+	}
+
+	// The whole tree of items is sent at once, an empty list clears the menu
+	var input struct {
+		Items []menuItemInput `json:"items" binding:"required,max=100,dive"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	menu, err := handler.menuService.GetMenu(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.menuService.ReplaceItems(menu, toMenuItems(input.Items)); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, menu)
+}
+
+func (handler *MenuHandler) DeleteMenu(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid MenuID"),
+		)
+		return
+	}
+
+	// Make sure the menu exists before deleting it
+	menu, err := handler.menuService.GetMenu(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.menuService.DeleteMenu(menu.ID); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete menu successfully"})
+}
+
+// toMenuItems converts the menu items of a request into menu items, they are validated by the menu service
+func toMenuItems(inputs []menuItemInput) []models.MenuItem {
+	items := make([]models.MenuItem, len(inputs))
+	for i, input := range inputs {
+		items[i] = models.MenuItem{
+			Label:        input.Label,
+			Type:         input.Type,
+			TargetID:     input.TargetID,
+			URL:          input.URL,
+			OpenInNewTab: input.OpenInNewTab,
+			Children:     toMenuItems(input.Children),
+		}
+	}
+	return items
+}
@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestMenuHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetPublicMenu - Success", func(t *testing.T) {
+		menuService := new(mocks.MockMenuService)
+		handler := handlers.NewMenuHandler(menuService)
+		menuService.On("GetPublicMenu", "main").Return([]services.MenuLink{
+			{Label: "About", URL: "/about", Type: models.MenuItemTypePage, Children: []services.MenuLink{}},
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/menus/main", "", gin.Params{{Key: "handle", Value: "main"}})
+
+		handler.GetPublicMenu(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"url":"/about"`)
+		menuService.AssertExpectations(t)
+	})
+
+	t.Run("GetPublicMenu - Not found", func(t *testing.T) {
+		menuService := new(mocks.MockMenuService)
+		handler := handlers.NewMenuHandler(menuService)
+		menuService.On("GetPublicMenu", "missing").Return(nil, apperror.NewNotFoundError("record not found"))
+
+		w, c := newPostRequest("GET", "/api/v1/public/menus/missing", "", gin.Params{{Key: "handle", Value: "missing"}})
+
+		handler.GetPublicMenu(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("CreateMenu - Success", func(t *testing.T) {
+		menuService := new(mocks.MockMenuService)
+		handler := handlers.NewMenuHandler(menuService)
+		menuService.On("CreateMenu", mock.MatchedBy(func(menu *models.Menu) bool {
+			return menu.Name == "Main" && menu.Handle == ""
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Menu).Handle = "main"
+		}).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/menus", `{"name":"Main"}`, nil)
+
+		handler.CreateMenu(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"handle":"main"`)
+		menuService.AssertExpectations(t)
+	})
+
+	t.Run("ReplaceMenuItems - Success", func(t *testing.T) {
+		menuService := new(mocks.MockMenuService)
+		handler := handlers.NewMenuHandler(menuService)
+		menu := &models.Menu{ID: 1, Name: "Main", Handle: "main"}
+		menuService.On("GetMenu", uint(1)).Return(menu, nil)
+		menuService.On("ReplaceItems", menu, mock.MatchedBy(func(items []models.MenuItem) bool {
+			return len(items) == 1 && items[0].Type == models.MenuItemTypePage && *items[0].TargetID == 2 &&
+				len(items[0].Children) == 1 && *items[0].Children[0].URL == "/blog"
+		})).Return(nil)
+
+		body := `{"items":[{"type":"page","target_id":2,"children":[{"type":"url","label":"Blog","url":"/blog"}]}]}`
+		w, c := newPostRequest("PUT", "/api/v1/menus/1/items", body, gin.Params{{Key: "id", Value: "1"}})
+
+		handler.ReplaceMenuItems(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		menuService.AssertExpectations(t)
+	})
+
+	t.Run("ReplaceMenuItems - Invalid type", func(t *testing.T) {
+		menuService := new(mocks.MockMenuService)
+		handler := handlers.NewMenuHandler(menuService)
+
+		w, c := newPostRequest("PUT", "/api/v1/menus/1/items", `{"items":[{"type":"file"}]}`, gin.Params{{Key: "id", Value: "1"}})
+
+		handler.ReplaceMenuItems(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		menuService.AssertNotCalled(t, "ReplaceItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DeleteMenu - Success", func(t *testing.T) {
+		menuService := new(mocks.MockMenuService)
+		handler := handlers.NewMenuHandler(menuService)
+		menuService.On("GetMenu", uint(1)).Return(&models.Menu{ID: 1}, nil)
+		menuService.On("DeleteMenu", uint(1)).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/menus/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		handler.DeleteMenu(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Delete menu successfully")
+	})
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+)
+
+// publicPage is the representation of a page on the public API
+type publicPage struct {
+	ID              uint             `json:"id"`
+	Title           string           `json:"title"`
+	Path            string           `json:"path"` // URL of the page, e.g. "/about/team"
+	Body            string           `json:"body"`
+	BodyFormat      string           `json:"bodyFormat"`
+	BodyHTML        string           `json:"bodyHtml"`         // The body rendered to sanitized HTML, see services.IRenderService
+	Blocks          json.RawMessage  `json:"blocks,omitempty"` // Resolved block tree of bodies in the blocks format
+	TableOfContents []markup.Heading `json:"tableOfContents"`
+	ReadingTime     int              `json:"readingTime"` // Minutes
+	Template        string           `json:"template"`
+	Locale          string           `json:"locale"` // Locale of the title and body, the requested one or a fallback
+	UpdatedAt       time.Time        `json:"updatedAt"`
+}
+
+type IPageHandler interface {
+	GetPages(c *gin.Context)
+	GetPage(c *gin.Context)
+	GetPublishedPage(c *gin.Context)
+	CreatePage(c *gin.Context)
+	UpdatePage(c *gin.Context)
+	MovePage(c *gin.Context)
+	DeletePage(c *gin.Context)
+}
+
+type PageHandler struct {
+	pageService        services.IPageService
+	translationService services.ITranslationService
+	renderService      services.IRenderService
+}
+
+func NewPageHandler(
+	pageService services.IPageService,
+	translationService services.ITranslationService,
+	renderService services.IRenderService,
+) *PageHandler {
+	return &PageHandler{
+		pageService:        pageService,
+		translationService: translationService,
+		renderService:      renderService,
+	}
+}
+
+func (handler *PageHandler) GetPages(ctx *gin.Context) {
+	tree, err := handler.pageService.GetTree()
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, tree)
+}
+
+func (handler *PageHandler) GetPage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PageID"),
+		)
+		return
+	}
+
+	page, err := handler.pageService.GetPage(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, page)
+}
+
+func (handler *PageHandler) GetPublishedPage(ctx *gin.Context) {
+	// The full path of the page follows the route prefix, e.g. /public/pages/about/team
+	path := strings.Trim(ctx.Param("path"), "/")
+	if path == "" {
+		utils.RespondWithError(ctx, apperror.NewNotFoundError("Page not found"))
+		return
+	}
+
+	// The path may be translated in the locale selected by the LocaleMiddleware, e.g. /gioi-thieu/nhom
+	locale := ctx.GetString("Locale")
+	path, err := handler.translationService.ResolvePagePath(path, locale)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	page, err := handler.pageService.GetPublishedPage(path)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	locale, err = handler.translationService.LocalizePage(page, locale)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	rendered, err := handler.renderService.Render(page.Body, page.BodyFormat)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, publicPage{
+		ID:              page.ID,
+		Title:           page.Title,
+		Path:            "/" + page.Path,
+		Body:            page.Body,
+		BodyFormat:      page.BodyFormat,
+		BodyHTML:        rendered.HTML,
+		Blocks:          rendered.Blocks,
+		TableOfContents: rendered.TableOfContents,
+		ReadingTime:     rendered.ReadingTime,
+		Template:        page.Template,
+		Locale:          locale,
+		UpdatedAt:       page.UpdatedAt,
+	})
+}
+
+func (handler *PageHandler) CreatePage(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	var input struct {
+		ParentID   *uint  `json:"parent_id" binding:"omitempty,min=1"` // Empty for a root page
+		Title      string `json:"title" binding:"required,max=255,not_blank"`
+		Slug       string `json:"slug" binding:"omitempty,max=255"` // Generated from the title when empty
+		Body       string `json:"body" binding:"omitempty"`
+		BodyFormat string `json:"body_format" binding:"omitempty,oneof=html markdown blocks"` // html when empty
+		Template   string `json:"template" binding:"omitempty,max=50"`                        // Defaults to "default"
+		Status     string `json:"status" binding:"omitempty,oneof=draft published"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	page := models.Page{
+		ParentID:   input.ParentID,
+		Title:      input.Title,
+		Slug:       input.Slug,
+		Body:       input.Body,
+		BodyFormat: input.BodyFormat,
+		Template:   input.Template,
+		Status:     input.Status,
+		AuthorID:   userId,
+	}
+	if page.Status == "" {
+		page.Status = models.PageStatusDraft
+	}
+	if page.BodyFormat == "" {
+		page.BodyFormat = models.BodyFormatHTML
+	}
+
+	if err := handler.pageService.CreatePage(&page); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, page)
+}
+
+func (handler *PageHandler) UpdatePage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PageID"),
+		)
+		return
+	}
+
+	// The parent and position are changed through MovePage
+	var input struct {
+		Title      *string `json:"title" binding:"omitempty,max=255,not_blank"`
+		Slug       *string `json:"slug" binding:"omitempty,min=1,max=255"`
+		Body       *string `json:"body" binding:"omitempty"`
+		BodyFormat *string `json:"body_format" binding:"omitempty,oneof=html markdown blocks"`
+		Template   *string `json:"template" binding:"omitempty,min=1,max=50"`
+		Status     *string `json:"status" binding:"omitempty,oneof=draft published"`
+		Version    *uint   `json:"version" binding:"omitempty,min=1"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	page, err := handler.pageService.GetPage(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Title != nil {
+		page.Title = *input.Title
+	}
+	if input.Slug != nil {
+		page.Slug = *input.Slug
+	}
+	if input.Body != nil {
+		page.Body = *input.Body
+	}
+	if input.BodyFormat != nil {
+		page.BodyFormat = *input.BodyFormat
+	}
+	if input.Template != nil {
+		page.Template = *input.Template
+	}
+	if input.Status != nil {
+		page.Status = *input.Status
+	}
+	if input.Version != nil {
+		page.Version = *input.Version
+	}
+
+	if err := handler.pageService.UpdatePage(page); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, page)
+}
+
+func (handler *PageHandler) MovePage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PageID"),
+		)
+		return
+	}
+
+	var input struct {
+		ParentID *uint `json:"parent_id" binding:"omitempty,min=1"` // Empty to make the page a root
+		Position *int  `json:"position" binding:"omitempty,min=0"`  // Empty to place the page last
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	page, err := handler.pageService.MovePage(uint(id), input.ParentID, input.Position)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, page)
+}
+
+func (handler *PageHandler) DeletePage(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PageID"),
+		)
+		return
+	}
+
+	// Make sure the page exists before deleting it
+	page, err := handler.pageService.GetPage(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.pageService.DeletePage(page.ID); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete page successfully"})
+}
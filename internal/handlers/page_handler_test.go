@@ -0,0 +1,165 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestPageHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetPublishedPage - Success", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		translationService := new(mocks.MockTranslationService)
+		renderService := new(mocks.MockRenderService)
+		handler := handlers.NewPageHandler(pageService, translationService, renderService)
+		translationService.On("ResolvePagePath", "about/team", "").Return("about/team", nil)
+		translationService.On("LocalizePage", mock.Anything, "").Return("en", nil)
+		pageService.On("GetPublishedPage", "about/team").Return(&models.Page{
+			ID: 2, Title: "Team", Path: "about/team", Body: "## Members", BodyFormat: models.BodyFormatMarkdown, Template: "default",
+		}, nil)
+		renderService.On("Render", "## Members", models.BodyFormatMarkdown).Return(&markup.Result{
+			HTML:            `<h2 id="members">Members</h2>`,
+			TableOfContents: []markup.Heading{{Level: 2, ID: "members", Text: "Members"}},
+			WordCount:       1,
+			ReadingTime:     1,
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/pages/about/team", "", gin.Params{{Key: "path", Value: "/about/team/"}})
+
+		handler.GetPublishedPage(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"path":"/about/team"`)
+		assert.Contains(t, w.Body.String(), `"body":"## Members"`)
+		assert.Contains(t, w.Body.String(), `"bodyHtml":"\u003ch2 id=\"members\"\u003eMembers\u003c/h2\u003e"`)
+		assert.Contains(t, w.Body.String(), `"tableOfContents":[{"level":2,"id":"members","text":"Members"}]`)
+		assert.Contains(t, w.Body.String(), `"readingTime":1`)
+		pageService.AssertExpectations(t)
+		renderService.AssertExpectations(t)
+	})
+
+	t.Run("GetPublishedPage - Empty path", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("GET", "/api/v1/public/pages/", "", gin.Params{{Key: "path", Value: "/"}})
+
+		handler.GetPublishedPage(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		pageService.AssertNotCalled(t, "GetPublishedPage", mock.Anything)
+	})
+
+	t.Run("CreatePage - Success", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		pageService.On("CreatePage", mock.MatchedBy(func(page *models.Page) bool {
+			return page.Title == "About" && page.AuthorID == 1 && page.Status == models.PageStatusDraft
+		})).Run(func(args mock.Arguments) {
+			page := args.Get(0).(*models.Page)
+			page.ID = 1
+			page.Path = "about"
+		}).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/pages", `{"title":"About"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.CreatePage(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"path":"about"`)
+		pageService.AssertExpectations(t)
+	})
+
+	t.Run("CreatePage - Invalid UserID", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("POST", "/api/v1/pages", `{"title":"About"}`, nil)
+
+		handler.CreatePage(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		pageService.AssertNotCalled(t, "CreatePage", mock.Anything)
+	})
+
+	t.Run("CreatePage - Validation error", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("POST", "/api/v1/pages", `{"title":"About","status":"archived"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.CreatePage(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		pageService.AssertNotCalled(t, "CreatePage", mock.Anything)
+	})
+
+	t.Run("UpdatePage - Success", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		pageService.On("GetPage", uint(1)).Return(&models.Page{ID: 1, Title: "About", Slug: "about"}, nil)
+		pageService.On("UpdatePage", mock.MatchedBy(func(page *models.Page) bool {
+			return page.Title == "About" && page.Slug == "about-us" && page.Status == models.PageStatusPublished
+		})).Return(nil)
+
+		w, c := newPostRequest("PATCH", "/api/v1/pages/1", `{"slug":"about-us","status":"published"}`, gin.Params{{Key: "id", Value: "1"}})
+
+		handler.UpdatePage(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		pageService.AssertExpectations(t)
+	})
+
+	t.Run("MovePage - Success", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		parentID, position := uint(1), 0
+		pageService.On("MovePage", uint(3), &parentID, &position).Return(&models.Page{ID: 3, ParentID: &parentID}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/pages/3/move", `{"parent_id":1,"position":0}`, gin.Params{{Key: "id", Value: "3"}})
+
+		handler.MovePage(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		pageService.AssertExpectations(t)
+	})
+
+	t.Run("DeletePage - Has subpages", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		pageService.On("GetPage", uint(1)).Return(&models.Page{ID: 1}, nil)
+		pageService.On("DeletePage", uint(1)).Return(apperror.NewBadRequestError("Page has subpages, move or delete them first"))
+
+		w, c := newPostRequest("DELETE", "/api/v1/pages/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		handler.DeletePage(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		pageService.AssertExpectations(t)
+	})
+
+	t.Run("GetPage - Invalid ID", func(t *testing.T) {
+		pageService := new(mocks.MockPageService)
+		handler := handlers.NewPageHandler(pageService, new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("GET", "/api/v1/pages/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.GetPage(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IPostExpiryHandler interface {
+	SetPostExpiry(c *gin.Context)
+	ClearPostExpiry(c *gin.Context)
+}
+
+type PostExpiryHandler struct {
+	expiryService services.IPostExpiryService
+}
+
+func NewPostExpiryHandler(expiryService services.IPostExpiryService) *PostExpiryHandler {
+	return &PostExpiryHandler{
+		expiryService: expiryService,
+	}
+}
+
+func (handler *PostExpiryHandler) SetPostExpiry(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	var input struct {
+		ExpireAt *time.Time `json:"expire_at" binding:"required"`                       // RFC 3339 format
+		Action   string     `json:"action" binding:"omitempty,oneof=archive unpublish"` // archive when empty
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	post, err := handler.expiryService.SetExpiry(uint(postId), services.PostExpiryInput{
+		ExpireAt: *input.ExpireAt,
+		Action:   input.Action,
+	})
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, post)
+}
+
+func (handler *PostExpiryHandler) ClearPostExpiry(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	post, err := handler.expiryService.ClearExpiry(uint(postId))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, post)
+}
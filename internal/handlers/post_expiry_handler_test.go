@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestPostExpiryHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("SetPostExpiry - Success", func(t *testing.T) {
+		expiryService := new(mocks.MockPostExpiryService)
+		handler := handlers.NewPostExpiryHandler(expiryService)
+		expireAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		unpublish := models.PostActionUnpublish
+		expiryService.On("SetExpiry", uint(3), mock.MatchedBy(func(input services.PostExpiryInput) bool {
+			return input.Action == models.PostActionUnpublish && input.ExpireAt.Equal(expireAt)
+		})).Return(&models.Post{ID: 3, ExpireAt: &expireAt, ExpireAction: &unpublish}, nil)
+
+		w, c := newPostRequest("PUT", "/api/v1/posts/3/expiry", `{"expire_at":"2030-01-01T09:00:00Z","action":"unpublish"}`, gin.Params{{Key: "id", Value: "3"}})
+
+		handler.SetPostExpiry(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"expireAction":"unpublish"`)
+		expiryService.AssertExpectations(t)
+	})
+
+	t.Run("SetPostExpiry - Validation error", func(t *testing.T) {
+		handler := handlers.NewPostExpiryHandler(new(mocks.MockPostExpiryService))
+
+		w, c := newPostRequest("PUT", "/api/v1/posts/3/expiry", `{"action":"delete"}`, gin.Params{{Key: "id", Value: "3"}})
+
+		handler.SetPostExpiry(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "expire_at")
+		assert.Contains(t, w.Body.String(), "action")
+	})
+
+	t.Run("SetPostExpiry - Archived post", func(t *testing.T) {
+		expiryService := new(mocks.MockPostExpiryService)
+		handler := handlers.NewPostExpiryHandler(expiryService)
+		expiryService.On("SetExpiry", uint(3), mock.Anything).
+			Return(nil, apperror.NewInvalidTransitionError("Cannot set the expiry of an archived post"))
+
+		w, c := newPostRequest("PUT", "/api/v1/posts/3/expiry", `{"expire_at":"2030-01-01T09:00:00Z"}`, gin.Params{{Key: "id", Value: "3"}})
+
+		handler.SetPostExpiry(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"code":6000,"message":"Cannot set the expiry of an archived post"}`, w.Body.String())
+	})
+
+	t.Run("ClearPostExpiry - Success", func(t *testing.T) {
+		expiryService := new(mocks.MockPostExpiryService)
+		handler := handlers.NewPostExpiryHandler(expiryService)
+		expiryService.On("ClearExpiry", uint(3)).Return(&models.Post{ID: 3, Status: models.PostStatusPublished}, nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/posts/3/expiry", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.ClearPostExpiry(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "expireAt")
+		expiryService.AssertExpectations(t)
+	})
+
+	t.Run("ClearPostExpiry - Invalid PostID", func(t *testing.T) {
+		handler := handlers.NewPostExpiryHandler(new(mocks.MockPostExpiryService))
+
+		w, c := newPostRequest("DELETE", "/api/v1/posts/abc/expiry", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.ClearPostExpiry(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid PostID"}`, w.Body.String())
+	})
+}
@@ -0,0 +1,541 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+)
+
+// publicPost is the representation of a post on the public API, it leaves out the account details of the author
+type publicPost struct {
+	ID              uint             `json:"id"`
+	Title           string           `json:"title"`
+	Slug            string           `json:"slug"`
+	Excerpt         *string          `json:"excerpt,omitempty"`
+	Body            string           `json:"body"`
+	BodyFormat      string           `json:"bodyFormat"`
+	BodyHTML        string           `json:"bodyHtml"`         // The body rendered to sanitized HTML, see services.IRenderService
+	Blocks          json.RawMessage  `json:"blocks,omitempty"` // Resolved block tree of bodies in the blocks format
+	TableOfContents []markup.Heading `json:"tableOfContents"`
+	ReadingTime     int              `json:"readingTime"` // Minutes
+	PublishedAt     *time.Time       `json:"publishedAt,omitempty"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+	Locale          string           `json:"locale"` // Locale of the title, excerpt and body, the requested one or a fallback
+	Author          *publicAuthor    `json:"author,omitempty"`
+	Category        *publicTerm      `json:"category,omitempty"`
+	Tags            []publicTerm     `json:"tags"`
+}
+
+type publicAuthor struct {
+	ID                 uint    `json:"id"`
+	Name               string  `json:"name"`
+	AvatarThumbnailURL *string `json:"avatarThumbnailUrl,omitempty"`
+}
+
+// publicTerm is the representation of a category or a tag of a post on the public API
+type publicTerm struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// toPublicPost converts a published post localized in a locale into its public representation
+func toPublicPost(post *models.Post, locale string) publicPost {
+	result := publicPost{
+		ID:          post.ID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		Excerpt:     post.Excerpt,
+		Body:        post.Body,
+		BodyFormat:  post.BodyFormat,
+		PublishedAt: post.PublishedAt,
+		UpdatedAt:   post.UpdatedAt,
+		Locale:      locale,
+		Tags:        make([]publicTerm, len(post.Tags)),
+	}
+	for i, tag := range post.Tags {
+		result.Tags[i] = publicTerm{ID: tag.ID, Name: tag.Name, Slug: tag.Slug}
+	}
+	if post.Category != nil {
+		result.Category = &publicTerm{ID: post.Category.ID, Name: post.Category.Name, Slug: post.Category.Slug}
+	}
+	if post.Author != nil {
+		result.Author = &publicAuthor{
+			ID:                 post.Author.ID,
+			Name:               post.Author.Name,
+			AvatarThumbnailURL: post.Author.AvatarThumbnailURL,
+		}
+	}
+	return result
+}
+
+type IPostHandler interface {
+	CreatePost(c *gin.Context)
+	GetPosts(c *gin.Context)
+	GetPost(c *gin.Context)
+	UpdatePost(c *gin.Context)
+	DeletePost(c *gin.Context)
+	GetPublishedPosts(c *gin.Context)
+	GetPublishedPost(c *gin.Context)
+}
+
+type PostHandler struct {
+	postService        services.IPostService
+	categoryService    services.ICategoryService
+	tagService         services.ITagService
+	translationService services.ITranslationService
+	renderService      services.IRenderService
+}
+
+func NewPostHandler(
+	postService services.IPostService,
+	categoryService services.ICategoryService,
+	tagService services.ITagService,
+	translationService services.ITranslationService,
+	renderService services.IRenderService,
+) *PostHandler {
+	return &PostHandler{
+		postService:        postService,
+		categoryService:    categoryService,
+		tagService:         tagService,
+		translationService: translationService,
+		renderService:      renderService,
+	}
+}
+
+func (handler *PostHandler) CreatePost(ctx *gin.Context) {
+	// The author of the post is the authenticated user
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	var input struct {
+		Title      string   `json:"title" binding:"required,max=255,not_blank"`
+		Slug       string   `json:"slug" binding:"omitempty,max=255"` // Generated from the title when empty
+		Excerpt    *string  `json:"excerpt" binding:"omitempty,max=500"`
+		Body       string   `json:"body" binding:"required"`
+		BodyFormat string   `json:"body_format" binding:"omitempty,oneof=html markdown blocks"` // html when empty
+		CategoryID *uint    `json:"category_id" binding:"omitempty,min=1"`
+		Tags       []string `json:"tags" binding:"omitempty,max=20,dive,required,max=100"` // Tags that do not exist yet are created
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	post := models.Post{
+		Title:      input.Title,
+		Slug:       input.Slug,
+		Excerpt:    input.Excerpt,
+		Body:       input.Body,
+		BodyFormat: input.BodyFormat,
+		AuthorID:   userId,
+		CategoryID: input.CategoryID,
+		Tags:       toTags(input.Tags),
+	}
+	if post.BodyFormat == "" {
+		post.BodyFormat = models.BodyFormatHTML
+	}
+
+	if err := handler.postService.CreatePost(&post); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, post)
+}
+
+func (handler *PostHandler) GetPosts(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Filter on status, author, category and tag, e.g. ?status=draft&author_id=3&category_id=2&tag_id=5
+	// Posts in the subcategories of the category are included
+	filter := repositories.PostFilter{
+		Status: ctx.Query("status"),
+	}
+	if filter.Status != "" && !slices.Contains(models.PostStatuses, filter.Status) {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewValidationDataError(fmt.Sprintf("status must be one of %v", models.PostStatuses)),
+		)
+		return
+	}
+	if authorId := ctx.Query("author_id"); authorId != "" {
+		id, err := strconv.Atoi(authorId)
+		if err != nil || id <= 0 {
+			utils.RespondWithError(
+				ctx,
+				apperror.NewParseError("Invalid AuthorID"),
+			)
+			return
+		}
+		filter.AuthorID = uint(id)
+	}
+	if categoryId := ctx.Query("category_id"); categoryId != "" {
+		id, err := strconv.Atoi(categoryId)
+		if err != nil || id <= 0 {
+			utils.RespondWithError(
+				ctx,
+				apperror.NewParseError("Invalid CategoryID"),
+			)
+			return
+		}
+		filter.CategoryIDs, err = handler.categoryService.GetSubtreeIDs(uint(id))
+		if err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+	}
+	if tagId := ctx.Query("tag_id"); tagId != "" {
+		id, err := strconv.Atoi(tagId)
+		if err != nil || id <= 0 {
+			utils.RespondWithError(
+				ctx,
+				apperror.NewParseError("Invalid TagID"),
+			)
+			return
+		}
+		filter.TagID = uint(id)
+	}
+	// Posts due to expire within the number of days, soonest first, e.g. ?status=published&expiring_within_days=7
+	if expiringWithinDays := ctx.Query("expiring_within_days"); expiringWithinDays != "" {
+		days, err := strconv.Atoi(expiringWithinDays)
+		if err != nil || days <= 0 {
+			utils.RespondWithError(
+				ctx,
+				apperror.NewParseError("Invalid ExpiringWithinDays"),
+			)
+			return
+		}
+		expiringBefore := time.Now().AddDate(0, 0, days)
+		filter.ExpiringBefore = &expiringBefore
+	}
+
+	pagination, err := handler.postService.PaginatePosts(page, limit, filter)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *PostHandler) GetPost(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	post, err := handler.postService.GetPost(uint(postId))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, post)
+}
+
+func (handler *PostHandler) UpdatePost(ctx *gin.Context) {
+	// The authenticated user is recorded as the editor of the new revision
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	// Only the fields present in the body are changed
+	var input struct {
+		Title      *string   `json:"title" binding:"omitempty,max=255,not_blank"`
+		Slug       *string   `json:"slug" binding:"omitempty,min=1,max=255"`
+		Excerpt    *string   `json:"excerpt" binding:"omitempty,max=500"`
+		Body       *string   `json:"body" binding:"omitempty,min=1"`
+		BodyFormat *string   `json:"body_format" binding:"omitempty,oneof=html markdown blocks"`
+		CategoryID *uint     `json:"category_id"`                                           // 0 removes the post from its category
+		Tags       *[]string `json:"tags" binding:"omitempty,max=20,dive,required,max=100"` // An empty list removes every tag
+		Version    *uint     `json:"version" binding:"omitempty,min=1"`                     // Version the changes were made to, a stale one is rejected
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	post, err := handler.postService.GetPost(uint(postId))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Title != nil {
+		post.Title = *input.Title
+	}
+	if input.Slug != nil {
+		post.Slug = *input.Slug
+	}
+	if input.Excerpt != nil {
+		post.Excerpt = utils.StringToPtr(*input.Excerpt)
+	}
+	if input.Body != nil {
+		post.Body = *input.Body
+	}
+	if input.BodyFormat != nil {
+		post.BodyFormat = *input.BodyFormat
+	}
+	if input.CategoryID != nil {
+		post.CategoryID = input.CategoryID
+		if *input.CategoryID == 0 {
+			post.CategoryID = nil
+		}
+	}
+	if input.Tags != nil {
+		post.Tags = toTags(*input.Tags)
+	}
+	if input.Version != nil {
+		post.Version = *input.Version
+	}
+
+	if err := handler.postService.UpdatePost(userId, post); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, post)
+}
+
+func (handler *PostHandler) DeletePost(ctx *gin.Context) {
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	// Make sure the post exists before deleting it
+	post, err := handler.postService.GetPost(uint(postId))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.postService.DeletePost(userId, post); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete post successfully"})
+}
+
+func (handler *PostHandler) GetPublishedPosts(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Filter on category and tag slugs, e.g. ?category=news&tag=golang
+	// Posts in the subcategories of the category are included
+	var filter repositories.PostFilter
+	if slug := ctx.Query("category"); slug != "" {
+		category, err := handler.categoryService.GetCategoryBySlug(slug)
+		if err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+		filter.CategoryIDs, err = handler.categoryService.GetSubtreeIDs(category.ID)
+		if err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+	}
+	if slug := ctx.Query("tag"); slug != "" {
+		tag, err := handler.tagService.GetTagBySlug(slug)
+		if err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+		filter.TagID = tag.ID
+	}
+
+	pagination, err := handler.postService.PaginatePublishedPosts(page, limit, filter)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	// The posts are returned in the locale selected by the LocaleMiddleware, see services.ITranslationService
+	if posts, ok := pagination.Data.([]models.Post); ok {
+		items, err := handler.toPublicPosts(posts, ctx.GetString("Locale"))
+		if err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+		pagination.Data = items
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+// GetPublishedPost retrieves a published post by its slug in the default locale or in the selected one
+func (handler *PostHandler) GetPublishedPost(ctx *gin.Context) {
+	locale := ctx.GetString("Locale")
+	slug, err := handler.translationService.ResolvePostSlug(ctx.Param("slug"), locale)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	post, err := handler.postService.GetPublishedPost(slug)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	items, err := handler.toPublicPosts([]models.Post{*post}, locale)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, items[0])
+}
+
+// GetLatestPosts retrieves the most recently published posts, e.g. for a widget, ?limit= caps them at 50
+func (handler *PostHandler) GetLatestPosts(ctx *gin.Context) {
+	limit := parseListLimit(ctx, 10)
+
+	pagination, err := handler.postService.PaginatePublishedPosts(1, limit, repositories.PostFilter{})
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	posts, _ := pagination.Data.([]models.Post)
+	handler.respondWithPublicPosts(ctx, posts)
+}
+
+// GetRelatedPosts retrieves the published posts sharing the most tags or the category with a post, ?limit= caps them at 50
+func (handler *PostHandler) GetRelatedPosts(ctx *gin.Context) {
+	limit := parseListLimit(ctx, 5)
+
+	slug, err := handler.translationService.ResolvePostSlug(ctx.Param("slug"), ctx.GetString("Locale"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	post, err := handler.postService.GetPublishedPost(slug)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	posts, err := handler.postService.GetRelatedPosts(post, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	handler.respondWithPublicPosts(ctx, posts)
+}
+
+// respondWithPublicPosts responds with a list of posts in the locale selected by the LocaleMiddleware
+func (handler *PostHandler) respondWithPublicPosts(ctx *gin.Context, posts []models.Post) {
+	items, err := handler.toPublicPosts(posts, ctx.GetString("Locale"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, items)
+}
+
+// toPublicPosts localizes published posts and converts them into their public representation with their rendered bodies
+func (handler *PostHandler) toPublicPosts(posts []models.Post, locale string) ([]publicPost, error) {
+	locales, err := handler.translationService.LocalizePosts(posts, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]publicPost, len(posts))
+	for i := range posts {
+		items[i] = toPublicPost(&posts[i], locales[i])
+		if err := renderPublicPost(handler.renderService, &items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// renderPublicPost fills in the rendered HTML, block tree, table of contents and reading time of a post on the public API
+func renderPublicPost(renderService services.IRenderService, item *publicPost) error {
+	rendered, err := renderService.Render(item.Body, item.BodyFormat)
+	if err != nil {
+		return err
+	}
+	item.BodyHTML = rendered.HTML
+	item.Blocks = rendered.Blocks
+	item.TableOfContents = rendered.TableOfContents
+	item.ReadingTime = rendered.ReadingTime
+	return nil
+}
+
+// parseListLimit reads the ?limit= of a list without pagination, invalid values fall back to the default
+func parseListLimit(ctx *gin.Context, defaultLimit int) int {
+	limit, err := strconv.Atoi(ctx.Query("limit"))
+	if err != nil || limit <= 0 {
+		return defaultLimit
+	}
+	return min(limit, 50)
+}
+
+// toTags converts the tag names of a request into tags, they are resolved by the post service
+func toTags(names []string) []models.Tag {
+	tags := make([]models.Tag, len(names))
+	for i, name := range names {
+		tags[i] = models.Tag{Name: name}
+	}
+	return tags
+}
@@ -0,0 +1,489 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newPostRequest(method, url, body string, params gin.Params) (*httptest.ResponseRecorder, *gin.Context) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, url, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	return w, c
+}
+
+func TestPostHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("CreatePost - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		postService.On("CreatePost", mock.MatchedBy(func(post *models.Post) bool {
+			return post.Title == "Hello" && post.AuthorID == 1 && post.BodyFormat == models.BodyFormatHTML
+		})).Run(func(args mock.Arguments) {
+			post := args.Get(0).(*models.Post)
+			post.ID = 3
+			post.Slug = "hello"
+			post.Status = models.PostStatusDraft
+		}).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/posts", `{"title":"Hello","body":"World"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.CreatePost(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"hello"`)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("CreatePost - With category and tags", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		postService.On("CreatePost", mock.MatchedBy(func(post *models.Post) bool {
+			return *post.CategoryID == 2 && len(post.Tags) == 2 && post.Tags[0].Name == "Go" && post.Tags[1].Name == "News"
+		})).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/posts", `{"title":"Hello","body":"World","category_id":2,"tags":["Go","News"]}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.CreatePost(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("CreatePost - Invalid body format", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("POST", "/api/v1/posts", `{"title":"Hello","body":"World","body_format":"textile"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.CreatePost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "body_format")
+		postService.AssertNotCalled(t, "CreatePost", mock.Anything)
+	})
+
+	t.Run("CreatePost - Empty tag name", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("POST", "/api/v1/posts", `{"title":"Hello","body":"World","tags":["Go",""]}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.CreatePost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "tags")
+	})
+
+	t.Run("CreatePost - Invalid UserID", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("POST", "/api/v1/posts", `{}`, nil)
+
+		handler.CreatePost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid UserID"}`, w.Body.String())
+	})
+
+	t.Run("CreatePost - Validation Error", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("POST", "/api/v1/posts", `{"title":" "}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.CreatePost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "title")
+		assert.Contains(t, w.Body.String(), "body")
+	})
+
+	t.Run("GetPosts - Filters", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		postService.On("PaginatePosts", 1, 50, repositories.PostFilter{Status: models.PostStatusInReview, AuthorID: 3}).
+			Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Post{}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/posts?status=in_review&author_id=3", "", nil)
+
+		handler.GetPosts(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("GetPosts - Expiring soon", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		postService.On("PaginatePosts", 1, 50, mock.MatchedBy(func(filter repositories.PostFilter) bool {
+			return filter.Status == models.PostStatusPublished && filter.ExpiringBefore != nil &&
+				filter.ExpiringBefore.Sub(time.Now().AddDate(0, 0, 7)).Abs() < time.Minute
+		})).Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Post{}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/posts?status=published&expiring_within_days=7", "", nil)
+
+		handler.GetPosts(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("GetPosts - Invalid ExpiringWithinDays", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("GET", "/api/v1/posts?expiring_within_days=0", "", nil)
+
+		handler.GetPosts(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid ExpiringWithinDays"}`, w.Body.String())
+	})
+
+	t.Run("GetPosts - Invalid status", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("GET", "/api/v1/posts?status=unknown", "", nil)
+
+		handler.GetPosts(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "status must be one of")
+	})
+
+	t.Run("GetPosts - Invalid AuthorID", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("GET", "/api/v1/posts?author_id=abc", "", nil)
+
+		handler.GetPosts(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid AuthorID"}`, w.Body.String())
+	})
+
+	t.Run("GetPost - Not found", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		postService.On("GetPost", uint(9)).Return(nil, apperror.NewNotFoundError("record not found"))
+
+		w, c := newPostRequest("GET", "/api/v1/posts/9", "", gin.Params{{Key: "id", Value: "9"}})
+
+		handler.GetPost(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetPost - Invalid PostID", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("GET", "/api/v1/posts/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.GetPost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid PostID"}`, w.Body.String())
+	})
+
+	t.Run("UpdatePost - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		post := &models.Post{ID: 3, Title: "Hello", Slug: "hello", Body: "World", Status: models.PostStatusDraft}
+		postService.On("GetPost", uint(3)).Return(post, nil)
+		postService.On("UpdatePost", uint(1), post).Return(nil)
+
+		w, c := newPostRequest("PATCH", "/api/v1/posts/3", `{"title":"Updated","status":"published","excerpt":""}`, gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.UpdatePost(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Updated", post.Title)
+		assert.Equal(t, "hello", post.Slug)
+		assert.Equal(t, models.PostStatusDraft, post.Status, "status only changes through the workflow")
+		assert.Nil(t, post.Excerpt)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("UpdatePost - Slug taken", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		post := &models.Post{ID: 3, Title: "Hello", Slug: "hello"}
+		postService.On("GetPost", uint(3)).Return(post, nil)
+		postService.On("UpdatePost", uint(1), post).Return(apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "slug", Message: "slug is already taken"},
+		}))
+
+		w, c := newPostRequest("PATCH", "/api/v1/posts/3", `{"slug":"taken"}`, gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.UpdatePost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "slug is already taken")
+	})
+
+	t.Run("UpdatePost - Remove category and tags", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		categoryID := uint(2)
+		post := &models.Post{ID: 3, Title: "Hello", Slug: "hello", CategoryID: &categoryID, Tags: []models.Tag{{ID: 1, Name: "Go"}}}
+		postService.On("GetPost", uint(3)).Return(post, nil)
+		postService.On("UpdatePost", uint(1), post).Return(nil)
+
+		w, c := newPostRequest("PATCH", "/api/v1/posts/3", `{"category_id":0,"tags":[]}`, gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.UpdatePost(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, post.CategoryID)
+		assert.Empty(t, post.Tags)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("UpdatePost - Post of another author", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		post := &models.Post{ID: 3, AuthorID: 2, Title: "Hello", Slug: "hello"}
+		postService.On("GetPost", uint(3)).Return(post, nil)
+		postService.On("UpdatePost", uint(1), post).Return(apperror.NewForbiddenError("You do not have permission to perform this action"))
+
+		w, c := newPostRequest("PATCH", "/api/v1/posts/3", `{"title":"Updated"}`, gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.UpdatePost(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("DeletePost - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		post := &models.Post{ID: 3, AuthorID: 1}
+		postService.On("GetPost", uint(3)).Return(post, nil)
+		postService.On("DeletePost", uint(1), post).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/posts/3", "", gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.DeletePost(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Delete post successfully"}`, w.Body.String())
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("DeletePost - Post of another author", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		post := &models.Post{ID: 3, AuthorID: 2}
+		postService.On("GetPost", uint(3)).Return(post, nil)
+		postService.On("DeletePost", uint(1), post).Return(apperror.NewForbiddenError("You do not have permission to perform this action"))
+
+		w, c := newPostRequest("DELETE", "/api/v1/posts/3", "", gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.DeletePost(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("GetPublishedPosts - Hides author account details", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		translationService := new(mocks.MockTranslationService)
+		renderService := new(mocks.MockRenderService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), translationService, renderService)
+		renderService.On("Render", mock.Anything, mock.Anything).Return(&markup.Result{}, nil)
+		translationService.On("LocalizePosts", mock.Anything, "").Return([]string{"en"}, nil)
+		publishedAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		postService.On("PaginatePublishedPosts", 1, 50, repositories.PostFilter{}).Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Post{
+			{ID: 1, Title: "Hello", Slug: "hello", PublishedAt: &publishedAt, Author: &models.User{ID: 2, Name: "Author", Email: "author@example.com"}},
+		}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts", "", nil)
+
+		handler.GetPublishedPosts(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"author":{"id":2,"name":"Author"}`)
+		assert.NotContains(t, w.Body.String(), "author@example.com")
+	})
+
+	t.Run("GetPublishedPosts - Filter on category subtree and tag", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		categoryService := new(mocks.MockCategoryService)
+		tagService := new(mocks.MockTagService)
+		translationService := new(mocks.MockTranslationService)
+		renderService := new(mocks.MockRenderService)
+		handler := handlers.NewPostHandler(postService, categoryService, tagService, translationService, renderService)
+		renderService.On("Render", mock.Anything, mock.Anything).Return(&markup.Result{}, nil)
+		translationService.On("LocalizePosts", mock.Anything, "").Return([]string{"en"}, nil)
+		categoryService.On("GetCategoryBySlug", "news").Return(&models.Category{ID: 1, Slug: "news"}, nil)
+		categoryService.On("GetSubtreeIDs", uint(1)).Return([]uint{1, 2}, nil)
+		tagService.On("GetTagBySlug", "go").Return(&models.Tag{ID: 5, Slug: "go"}, nil)
+		postService.On("PaginatePublishedPosts", 1, 50, repositories.PostFilter{CategoryIDs: []uint{1, 2}, TagID: 5}).
+			Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Post{
+				{ID: 1, Title: "Hello", Slug: "hello", Category: &models.Category{ID: 2, Name: "World", Slug: "world"}, Tags: []models.Tag{{ID: 5, Name: "Go", Slug: "go"}}},
+			}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts?category=news&tag=go", "", nil)
+
+		handler.GetPublishedPosts(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"category":{"id":2,"name":"World","slug":"world"}`)
+		assert.Contains(t, w.Body.String(), `"tags":[{"id":5,"name":"Go","slug":"go"}]`)
+		postService.AssertExpectations(t)
+		categoryService.AssertExpectations(t)
+		tagService.AssertExpectations(t)
+	})
+
+	t.Run("GetPublishedPosts - Unknown category", func(t *testing.T) {
+		categoryService := new(mocks.MockCategoryService)
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), categoryService, new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+		categoryService.On("GetCategoryBySlug", "missing").Return(nil, apperror.NewNotFoundError("record not found"))
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts?category=missing", "", nil)
+
+		handler.GetPublishedPosts(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetPosts - Invalid CategoryID", func(t *testing.T) {
+		handler := handlers.NewPostHandler(new(mocks.MockPostService), new(mocks.MockCategoryService), new(mocks.MockTagService), new(mocks.MockTranslationService), new(mocks.MockRenderService))
+
+		w, c := newPostRequest("GET", "/api/v1/posts?category_id=abc", "", nil)
+
+		handler.GetPosts(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid CategoryID")
+	})
+
+	t.Run("GetPublishedPost - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		translationService := new(mocks.MockTranslationService)
+		renderService := new(mocks.MockRenderService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), translationService, renderService)
+		translationService.On("ResolvePostSlug", "hello", "").Return("hello", nil)
+		translationService.On("LocalizePosts", mock.Anything, "").Return([]string{"en"}, nil)
+		postService.On("GetPublishedPost", "hello").Return(&models.Post{
+			ID: 1, Title: "Hello", Slug: "hello", Body: "**Hi**", BodyFormat: models.BodyFormatMarkdown,
+			Author: &models.User{ID: 2, Name: "Author", Email: "author@example.com"},
+		}, nil)
+		renderService.On("Render", "**Hi**", models.BodyFormatMarkdown).Return(&markup.Result{
+			HTML: "<p><strong>Hi</strong></p>", TableOfContents: []markup.Heading{}, WordCount: 1, ReadingTime: 1,
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts/hello", "", gin.Params{{Key: "slug", Value: "hello"}})
+
+		handler.GetPublishedPost(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"hello"`)
+		assert.Contains(t, w.Body.String(), `"body":"**Hi**","bodyFormat":"markdown"`)
+		assert.Contains(t, w.Body.String(), `"bodyHtml":"\u003cp\u003e\u003cstrong\u003eHi\u003c/strong\u003e\u003c/p\u003e"`)
+		assert.Contains(t, w.Body.String(), `"readingTime":1`)
+		assert.NotContains(t, w.Body.String(), "author@example.com")
+		renderService.AssertExpectations(t)
+	})
+
+	t.Run("GetPublishedPost - Not found", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), translationService, new(mocks.MockRenderService))
+		translationService.On("ResolvePostSlug", "draft", "").Return("draft", nil)
+		postService.On("GetPublishedPost", "draft").Return(nil, apperror.NewNotFoundError("record not found"))
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts/draft", "", gin.Params{{Key: "slug", Value: "draft"}})
+
+		handler.GetPublishedPost(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetLatestPosts - Limit is capped", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		translationService := new(mocks.MockTranslationService)
+		renderService := new(mocks.MockRenderService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), translationService, renderService)
+		renderService.On("Render", mock.Anything, mock.Anything).Return(&markup.Result{}, nil)
+		translationService.On("LocalizePosts", mock.Anything, "").Return([]string{"en"}, nil)
+		postService.On("PaginatePublishedPosts", 1, 50, repositories.PostFilter{}).Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Post{
+			{ID: 1, Title: "Hello", Slug: "hello"},
+		}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts/latest?limit=500", "", nil)
+
+		handler.GetLatestPosts(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `[{"id":1,`)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("GetRelatedPosts - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		translationService := new(mocks.MockTranslationService)
+		renderService := new(mocks.MockRenderService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), translationService, renderService)
+		post := &models.Post{ID: 1, Title: "Hello", Slug: "hello"}
+		translationService.On("ResolvePostSlug", "hello", "").Return("hello", nil)
+		renderService.On("Render", mock.Anything, mock.Anything).Return(&markup.Result{}, nil)
+		translationService.On("LocalizePosts", mock.Anything, "").Return([]string{"en", "en"}, nil)
+		postService.On("GetPublishedPost", "hello").Return(post, nil)
+		postService.On("GetRelatedPosts", post, 3).Return([]models.Post{
+			{ID: 2, Title: "World", Slug: "world"},
+			{ID: 3, Title: "Again", Slug: "again"},
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts/hello/related?limit=3", "", gin.Params{{Key: "slug", Value: "hello"}})
+
+		handler.GetRelatedPosts(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"world"`)
+		assert.Contains(t, w.Body.String(), `"slug":"again"`)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("GetRelatedPosts - Not found", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewPostHandler(postService, new(mocks.MockCategoryService), new(mocks.MockTagService), translationService, new(mocks.MockRenderService))
+		translationService.On("ResolvePostSlug", "draft", "").Return("draft", nil)
+		postService.On("GetPublishedPost", "draft").Return(nil, apperror.NewNotFoundError("record not found"))
+
+		w, c := newPostRequest("GET", "/api/v1/public/posts/draft/related", "", gin.Params{{Key: "slug", Value: "draft"}})
+
+		handler.GetRelatedPosts(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		postService.AssertNotCalled(t, "GetRelatedPosts", mock.Anything, mock.Anything)
+	})
+}
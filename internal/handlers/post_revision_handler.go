@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IPostRevisionHandler interface {
+	GetRevisions(c *gin.Context)
+	GetRevision(c *gin.Context)
+	DiffRevisions(c *gin.Context)
+	RestoreRevision(c *gin.Context)
+}
+
+type PostRevisionHandler struct {
+	postService services.IPostService
+}
+
+func NewPostRevisionHandler(postService services.IPostService) *PostRevisionHandler {
+	return &PostRevisionHandler{
+		postService: postService,
+	}
+}
+
+func (handler *PostRevisionHandler) GetRevisions(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	pagination, err := handler.postService.PaginateRevisions(uint(postId), page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *PostRevisionHandler) GetRevision(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid revision number"),
+		)
+		return
+	}
+
+	revision, err := handler.postService.GetRevision(uint(postId), number)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, revision)
+}
+
+func (handler *PostRevisionHandler) DiffRevisions(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	// Revisions to compare, e.g. ?from=2&to=5
+	var input struct {
+		From int `form:"from" json:"from" binding:"required,min=1"`
+		To   int `form:"to" json:"to" binding:"required,min=1"`
+	}
+
+	if err := ctx.ShouldBindQuery(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	diff, err := handler.postService.DiffRevisions(uint(postId), input.From, input.To)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, diff)
+}
+
+func (handler *PostRevisionHandler) RestoreRevision(ctx *gin.Context) {
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid revision number"),
+		)
+		return
+	}
+
+	post, err := handler.postService.RestoreRevision(userId, uint(postId), number)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, post)
+}
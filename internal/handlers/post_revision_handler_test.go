@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestPostRevisionHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetRevisions - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostRevisionHandler(postService)
+		postService.On("PaginateRevisions", uint(3), 1, 50).Return(&utils.Pagination{Page: 1, Limit: 50}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/posts/3/revisions", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.GetRevisions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("GetRevision - Invalid number", func(t *testing.T) {
+		handler := handlers.NewPostRevisionHandler(new(mocks.MockPostService))
+
+		w, c := newPostRequest("GET", "/api/v1/posts/3/revisions/abc", "", gin.Params{{Key: "id", Value: "3"}, {Key: "number", Value: "abc"}})
+
+		handler.GetRevision(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid revision number")
+	})
+
+	t.Run("GetRevision - Not found", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostRevisionHandler(postService)
+		postService.On("GetRevision", uint(3), 9).Return(nil, apperror.NewNotFoundError("Revision not found"))
+
+		w, c := newPostRequest("GET", "/api/v1/posts/3/revisions/9", "", gin.Params{{Key: "id", Value: "3"}, {Key: "number", Value: "9"}})
+
+		handler.GetRevision(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("DiffRevisions - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostRevisionHandler(postService)
+		postService.On("DiffRevisions", uint(3), 1, 2).Return(&services.RevisionDiff{
+			PostID:  3,
+			From:    1,
+			To:      2,
+			Changes: []services.RevisionChange{{Field: "title", From: "Old", To: "New"}},
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/posts/3/revisions/diff?from=1&to=2", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.DiffRevisions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"title"`)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("DiffRevisions - Missing query", func(t *testing.T) {
+		handler := handlers.NewPostRevisionHandler(new(mocks.MockPostService))
+
+		w, c := newPostRequest("GET", "/api/v1/posts/3/revisions/diff?from=1", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.DiffRevisions(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "to")
+	})
+
+	t.Run("RestoreRevision - Success", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostRevisionHandler(postService)
+		postService.On("RestoreRevision", uint(1), uint(3), 2).Return(&models.Post{ID: 3, Title: "Old"}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/posts/3/revisions/2/restore", "{}", gin.Params{{Key: "id", Value: "3"}, {Key: "number", Value: "2"}})
+		c.Set("UserID", uint(1))
+
+		handler.RestoreRevision(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"title":"Old"`)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("RestoreRevision - Post of another author", func(t *testing.T) {
+		postService := new(mocks.MockPostService)
+		handler := handlers.NewPostRevisionHandler(postService)
+		postService.On("RestoreRevision", uint(1), uint(3), 2).Return(nil, apperror.NewForbiddenError("You do not have permission to perform this action"))
+
+		w, c := newPostRequest("POST", "/api/v1/posts/3/revisions/2/restore", "{}", gin.Params{{Key: "id", Value: "3"}, {Key: "number", Value: "2"}})
+		c.Set("UserID", uint(1))
+
+		handler.RestoreRevision(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		postService.AssertExpectations(t)
+	})
+
+	t.Run("RestoreRevision - Missing UserID", func(t *testing.T) {
+		handler := handlers.NewPostRevisionHandler(new(mocks.MockPostService))
+
+		w, c := newPostRequest("POST", "/api/v1/posts/3/revisions/2/restore", "{}", gin.Params{{Key: "id", Value: "3"}, {Key: "number", Value: "2"}})
+
+		handler.RestoreRevision(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IPostWorkflowHandler interface {
+	TransitionPost(c *gin.Context)
+	GetPostTransitions(c *gin.Context)
+}
+
+type PostWorkflowHandler struct {
+	workflowService services.IPostWorkflowService
+}
+
+func NewPostWorkflowHandler(workflowService services.IPostWorkflowService) *PostWorkflowHandler {
+	return &PostWorkflowHandler{
+		workflowService: workflowService,
+	}
+}
+
+func (handler *PostWorkflowHandler) TransitionPost(ctx *gin.Context) {
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	var input struct {
+		Action    string     `json:"action" binding:"required,oneof=submit approve reject schedule unschedule publish archive unpublish restore"`
+		Comment   *string    `json:"comment" binding:"omitempty,max=1000"` // Required to reject a post
+		PublishAt *time.Time `json:"publish_at"`                           // Required to schedule a post, RFC 3339 format
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	post, err := handler.workflowService.Transition(userId, uint(postId), services.PostTransitionInput{
+		Action:    input.Action,
+		Comment:   input.Comment,
+		PublishAt: input.PublishAt,
+	})
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, post)
+}
+
+func (handler *PostWorkflowHandler) GetPostTransitions(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	transitions, err := handler.workflowService.GetTransitions(uint(postId))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, transitions)
+}
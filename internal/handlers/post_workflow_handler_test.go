@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestPostWorkflowHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("TransitionPost - Success", func(t *testing.T) {
+		workflowService := new(mocks.MockPostWorkflowService)
+		handler := handlers.NewPostWorkflowHandler(workflowService)
+		publishAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+		workflowService.On("Transition", uint(1), uint(3), mock.MatchedBy(func(input services.PostTransitionInput) bool {
+			return input.Action == models.PostActionSchedule && input.PublishAt.Equal(publishAt)
+		})).Return(&models.Post{ID: 3, Status: models.PostStatusScheduled, PublishAt: &publishAt}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/posts/3/transitions", `{"action":"schedule","publish_at":"2030-01-01T09:00:00Z"}`, gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.TransitionPost(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"scheduled"`)
+		workflowService.AssertExpectations(t)
+	})
+
+	t.Run("TransitionPost - Unknown action", func(t *testing.T) {
+		handler := handlers.NewPostWorkflowHandler(new(mocks.MockPostWorkflowService))
+
+		w, c := newPostRequest("POST", "/api/v1/posts/3/transitions", `{"action":"delete"}`, gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.TransitionPost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "action")
+	})
+
+	t.Run("TransitionPost - Invalid transition", func(t *testing.T) {
+		workflowService := new(mocks.MockPostWorkflowService)
+		handler := handlers.NewPostWorkflowHandler(workflowService)
+		workflowService.On("Transition", uint(1), uint(3), mock.Anything).
+			Return(nil, apperror.NewInvalidTransitionError("Cannot archive a post with status draft"))
+
+		w, c := newPostRequest("POST", "/api/v1/posts/3/transitions", `{"action":"archive"}`, gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.TransitionPost(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.JSONEq(t, `{"code":6000,"message":"Cannot archive a post with status draft"}`, w.Body.String())
+	})
+
+	t.Run("TransitionPost - Invalid PostID", func(t *testing.T) {
+		handler := handlers.NewPostWorkflowHandler(new(mocks.MockPostWorkflowService))
+
+		w, c := newPostRequest("POST", "/api/v1/posts/abc/transitions", `{"action":"submit"}`, gin.Params{{Key: "id", Value: "abc"}})
+		c.Set("UserID", uint(1))
+
+		handler.TransitionPost(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid PostID"}`, w.Body.String())
+	})
+
+	t.Run("GetPostTransitions - Success", func(t *testing.T) {
+		workflowService := new(mocks.MockPostWorkflowService)
+		handler := handlers.NewPostWorkflowHandler(workflowService)
+		comment := "Needs sources"
+		workflowService.On("GetTransitions", uint(3)).Return([]models.PostTransition{
+			{ID: 1, PostID: 3, Action: models.PostActionReject, FromStatus: models.PostStatusInReview, ToStatus: models.PostStatusDraft, Comment: &comment},
+		}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/posts/3/transitions", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.GetPostTransitions(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"comment":"Needs sources"`)
+	})
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+)
+
+type IPreviewHandler interface {
+	CreatePreviewLink(c *gin.Context)
+	GetPreviewLinks(c *gin.Context)
+	RevokePreviewLink(c *gin.Context)
+	GetPreview(c *gin.Context)
+}
+
+type PreviewHandler struct {
+	previewService services.IPreviewService
+	renderService  services.IRenderService
+	locales        *i18n.Locales
+}
+
+func NewPreviewHandler(previewService services.IPreviewService, renderService services.IRenderService, locales *i18n.Locales) *PreviewHandler {
+	return &PreviewHandler{
+		previewService: previewService,
+		renderService:  renderService,
+		locales:        locales,
+	}
+}
+
+func (handler *PreviewHandler) CreatePreviewLink(ctx *gin.Context) {
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	var input struct {
+		RevisionNumber int `json:"revision_number" binding:"omitempty,min=1"` // The latest revision when empty
+		ExpiresIn      int `json:"expires_in" binding:"omitempty,min=60"`     // Seconds, PREVIEW_TTL_HOURS when empty
+	}
+
+	// An empty body shares the latest revision for the default lifetime
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&input); err != nil {
+			validateError := utils.TranslateValidationErrors(err, input)
+			utils.RespondWithError(ctx, validateError)
+			return
+		}
+	}
+
+	link, err := handler.previewService.CreateLink(userId, uint(postId), input.RevisionNumber, time.Duration(input.ExpiresIn)*time.Second)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, link)
+}
+
+func (handler *PreviewHandler) GetPreviewLinks(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	links, err := handler.previewService.GetLinks(uint(postId))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, links)
+}
+
+func (handler *PreviewHandler) RevokePreviewLink(ctx *gin.Context) {
+	postId, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PostID"),
+		)
+		return
+	}
+
+	linkId, err := strconv.Atoi(ctx.Param("linkId"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid PreviewLinkID"),
+		)
+		return
+	}
+
+	if err := handler.previewService.RevokeLink(uint(postId), uint(linkId)); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Preview link revoked successfully"})
+}
+
+// GetPreview shows the revision shared by a preview token in the representation of the public API
+// Revisions are written in the default locale, previews are never cached nor indexed
+func (handler *PreviewHandler) GetPreview(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("X-Robots-Tag", "noindex, nofollow")
+
+	post, err := handler.previewService.Resolve(ctx.Param("token"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	item := toPublicPost(post, handler.locales.Default())
+	if err := renderPublicPost(handler.renderService, &item); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, item)
+}
@@ -0,0 +1,119 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestPreviewHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+	locales, err := i18n.NewLocales("en", []string{"vi"}, nil)
+	require.NoError(t, err)
+
+	t.Run("CreatePreviewLink - Success", func(t *testing.T) {
+		previewService := new(mocks.MockPreviewService)
+		handler := handlers.NewPreviewHandler(previewService, new(mocks.MockRenderService), locales)
+		previewService.On("CreateLink", uint(1), uint(7), 2, time.Hour).Return(&services.PreviewLinkToken{
+			PreviewLink: models.PreviewLink{ID: 3, PostID: 7, RevisionNumber: 2},
+			Token:       "3.1700000000.signature",
+		}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/posts/7/previews", `{"revision_number":2,"expires_in":3600}`, gin.Params{{Key: "id", Value: "7"}})
+		c.Set("UserID", uint(1))
+
+		handler.CreatePreviewLink(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"token":"3.1700000000.signature"`)
+		previewService.AssertExpectations(t)
+	})
+
+	t.Run("CreatePreviewLink - Empty body", func(t *testing.T) {
+		previewService := new(mocks.MockPreviewService)
+		handler := handlers.NewPreviewHandler(previewService, new(mocks.MockRenderService), locales)
+		previewService.On("CreateLink", uint(1), uint(7), 0, time.Duration(0)).Return(&services.PreviewLinkToken{}, nil)
+
+		w, c := newPostRequest("POST", "/api/v1/posts/7/previews", "", gin.Params{{Key: "id", Value: "7"}})
+		c.Set("UserID", uint(1))
+
+		handler.CreatePreviewLink(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		previewService.AssertExpectations(t)
+	})
+
+	t.Run("CreatePreviewLink - Lifetime too short", func(t *testing.T) {
+		previewService := new(mocks.MockPreviewService)
+		handler := handlers.NewPreviewHandler(previewService, new(mocks.MockRenderService), locales)
+
+		w, c := newPostRequest("POST", "/api/v1/posts/7/previews", `{"expires_in":10}`, gin.Params{{Key: "id", Value: "7"}})
+		c.Set("UserID", uint(1))
+
+		handler.CreatePreviewLink(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		previewService.AssertNotCalled(t, "CreateLink", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RevokePreviewLink - Success", func(t *testing.T) {
+		previewService := new(mocks.MockPreviewService)
+		handler := handlers.NewPreviewHandler(previewService, new(mocks.MockRenderService), locales)
+		previewService.On("RevokeLink", uint(7), uint(3)).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/posts/7/previews/3", "", gin.Params{{Key: "id", Value: "7"}, {Key: "linkId", Value: "3"}})
+
+		handler.RevokePreviewLink(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		previewService.AssertExpectations(t)
+	})
+
+	t.Run("GetPreview - Success", func(t *testing.T) {
+		previewService := new(mocks.MockPreviewService)
+		renderService := new(mocks.MockRenderService)
+		handler := handlers.NewPreviewHandler(previewService, renderService, locales)
+		previewService.On("Resolve", "3.1700000000.signature").Return(&models.Post{
+			ID: 7, Title: "Draft", Slug: "draft", Body: "# Draft", BodyFormat: models.BodyFormatMarkdown, Status: models.PostStatusDraft,
+			Author: &models.User{ID: 1, Name: "John", Email: "john@example.com"},
+		}, nil)
+		renderService.On("Render", "# Draft", models.BodyFormatMarkdown).Return(&markup.Result{HTML: "<h1 id=\"draft\">Draft</h1>", ReadingTime: 1}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/previews/3.1700000000.signature", "", gin.Params{{Key: "token", Value: "3.1700000000.signature"}})
+
+		handler.GetPreview(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.Contains(t, w.Body.String(), `"title":"Draft"`)
+		assert.Contains(t, w.Body.String(), `"readingTime":1`)
+		assert.Contains(t, w.Body.String(), `"locale":"en"`)
+		assert.NotContains(t, w.Body.String(), "john@example.com")
+	})
+
+	t.Run("GetPreview - Expired", func(t *testing.T) {
+		previewService := new(mocks.MockPreviewService)
+		handler := handlers.NewPreviewHandler(previewService, new(mocks.MockRenderService), locales)
+		previewService.On("Resolve", "expired").Return(nil, apperror.NewTokenExpiredError("Preview link has expired"))
+
+		w, c := newPostRequest("GET", "/api/v1/public/previews/expired", "", gin.Params{{Key: "token", Value: "expired"}})
+
+		handler.GetPreview(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
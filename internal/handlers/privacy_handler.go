@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+type IPrivacyHandler interface {
+	RequestDataExport(c *gin.Context)
+	GetDataExport(c *gin.Context)
+	DownloadDataExport(c *gin.Context)
+	RequestDeletion(c *gin.Context)
+	CancelDeletion(c *gin.Context)
+}
+
+type PrivacyHandler struct {
+	dataExportService      services.IDataExportService
+	accountDeletionService services.IAccountDeletionService
+	redisService           services.IRedisService
+}
+
+func NewPrivacyHandler(
+	dataExportService services.IDataExportService,
+	accountDeletionService services.IAccountDeletionService,
+	redisService services.IRedisService,
+) *PrivacyHandler {
+	return &PrivacyHandler{
+		dataExportService:      dataExportService,
+		accountDeletionService: accountDeletionService,
+		redisService:           redisService,
+	}
+}
+
+func (handler *PrivacyHandler) RequestDataExport(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// The archive is generated in the background, clients poll the export until it is completed
+	export, err := handler.dataExportService.RequestExport(userId, ctx.ClientIP())
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusAccepted, export)
+}
+
+func (handler *PrivacyHandler) GetDataExport(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid DataExportID"),
+		)
+		return
+	}
+
+	export, err := handler.dataExportService.GetExport(userId, uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, export)
+}
+
+func (handler *PrivacyHandler) DownloadDataExport(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid DataExportID"),
+		)
+		return
+	}
+
+	reader, export, err := handler.dataExportService.OpenExport(userId, uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+	defer reader.Close()
+
+	ctx.DataFromReader(
+		http.StatusOK,
+		export.FileSize,
+		"application/zip",
+		reader,
+		map[string]string{
+			"Content-Disposition": fmt.Sprintf(`attachment; filename="data-export-%d.zip"`, export.ID),
+		},
+	)
+}
+
+func (handler *PrivacyHandler) RequestDeletion(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	// The current password confirms the request
+	var input struct {
+		Password string `json:"password" binding:"required,min=6,max=255"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	user, err := handler.accountDeletionService.RequestDeletion(userId, input.Password, ctx.ClientIP())
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	handler.clearProfileCache(userId)
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"deletionScheduledAt": user.DeletionScheduledAt})
+}
+
+func (handler *PrivacyHandler) CancelDeletion(ctx *gin.Context) {
+	// Get user ID from the context
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	if _, err := handler.accountDeletionService.CancelDeletion(userId, ctx.ClientIP()); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	handler.clearProfileCache(userId)
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Account deletion cancelled"})
+}
+
+// clearProfileCache removes the cached profile so the next GetProfile returns the deletion schedule
+func (handler *PrivacyHandler) clearProfileCache(userId uint) {
+	profileKey := constants.PROFILE + strconv.Itoa(int(userId))
+	if err := handler.redisService.Delete(profileKey); err != nil {
+		logger.Warnf("Failed to clear cache: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package handlers_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newPrivacyRequest(method, url, body string, params gin.Params) (*httptest.ResponseRecorder, *gin.Context) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, url, bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = params
+	return w, c
+}
+
+func TestDataExportHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("RequestDataExport - Success", func(t *testing.T) {
+		exportService := new(mocks.MockDataExportService)
+		handler := handlers.NewPrivacyHandler(exportService, new(mocks.MockAccountDeletionService), new(mocks.MockRedisService))
+		exportService.On("RequestExport", uint(1), mock.Anything).
+			Return(&models.DataExport{ID: 3, UserID: 1, Status: models.DataExportStatusPending}, nil)
+
+		w, c := newPrivacyRequest("POST", "/api/v1/profile/data-export", `{}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.RequestDataExport(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"pending"`)
+		exportService.AssertExpectations(t)
+	})
+
+	t.Run("RequestDataExport - Invalid UserID", func(t *testing.T) {
+		handler := handlers.NewPrivacyHandler(new(mocks.MockDataExportService), new(mocks.MockAccountDeletionService), new(mocks.MockRedisService))
+
+		w, c := newPrivacyRequest("POST", "/api/v1/profile/data-export", `{}`, nil)
+
+		handler.RequestDataExport(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid UserID"}`, w.Body.String())
+	})
+
+	t.Run("GetDataExport - Success", func(t *testing.T) {
+		exportService := new(mocks.MockDataExportService)
+		handler := handlers.NewPrivacyHandler(exportService, new(mocks.MockAccountDeletionService), new(mocks.MockRedisService))
+		exportService.On("GetExport", uint(1), uint(3)).
+			Return(&models.DataExport{ID: 3, UserID: 1, Status: models.DataExportStatusCompleted}, nil)
+
+		w, c := newPrivacyRequest("GET", "/api/v1/profile/data-exports/3", "", gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.GetDataExport(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"completed"`)
+	})
+
+	t.Run("GetDataExport - Invalid DataExportID", func(t *testing.T) {
+		handler := handlers.NewPrivacyHandler(new(mocks.MockDataExportService), new(mocks.MockAccountDeletionService), new(mocks.MockRedisService))
+
+		w, c := newPrivacyRequest("GET", "/api/v1/profile/data-exports/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+		c.Set("UserID", uint(1))
+
+		handler.GetDataExport(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"code":4000,"message":"Invalid DataExportID"}`, w.Body.String())
+	})
+
+	t.Run("DownloadDataExport - Success", func(t *testing.T) {
+		exportService := new(mocks.MockDataExportService)
+		handler := handlers.NewPrivacyHandler(exportService, new(mocks.MockAccountDeletionService), new(mocks.MockRedisService))
+		exportService.On("OpenExport", uint(1), uint(3)).
+			Return(io.NopCloser(strings.NewReader("zip")), &models.DataExport{ID: 3, FileSize: 3}, nil)
+
+		w, c := newPrivacyRequest("GET", "/api/v1/profile/data-exports/3/download", "", gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.DownloadDataExport(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "zip", w.Body.String())
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="data-export-3.zip"`, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("DownloadDataExport - Not ready", func(t *testing.T) {
+		exportService := new(mocks.MockDataExportService)
+		handler := handlers.NewPrivacyHandler(exportService, new(mocks.MockAccountDeletionService), new(mocks.MockRedisService))
+		exportService.On("OpenExport", uint(1), uint(3)).
+			Return(nil, nil, apperror.NewBadRequestError("Data export is not ready for download"))
+
+		w, c := newPrivacyRequest("GET", "/api/v1/profile/data-exports/3/download", "", gin.Params{{Key: "id", Value: "3"}})
+		c.Set("UserID", uint(1))
+
+		handler.DownloadDataExport(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Data export is not ready for download")
+	})
+}
+
+func TestAccountDeletionHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("RequestDeletion - Success", func(t *testing.T) {
+		deletionService := new(mocks.MockAccountDeletionService)
+		redisService := new(mocks.MockRedisService)
+		handler := handlers.NewPrivacyHandler(new(mocks.MockDataExportService), deletionService, redisService)
+		scheduledAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+		deletionService.On("RequestDeletion", uint(1), "secret123", mock.Anything).
+			Return(&models.User{ID: 1, DeletionScheduledAt: &scheduledAt}, nil)
+		redisService.On("Delete", "PROFILE_1").Return(nil)
+
+		w, c := newPrivacyRequest("POST", "/api/v1/profile/deletion", `{"password":"secret123"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.RequestDeletion(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"deletionScheduledAt":"2030-01-01T00:00:00Z"}`, w.Body.String())
+		deletionService.AssertExpectations(t)
+		redisService.AssertExpectations(t)
+	})
+
+	t.Run("RequestDeletion - Missing password", func(t *testing.T) {
+		handler := handlers.NewPrivacyHandler(new(mocks.MockDataExportService), new(mocks.MockAccountDeletionService), new(mocks.MockRedisService))
+
+		w, c := newPrivacyRequest("POST", "/api/v1/profile/deletion", `{}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.RequestDeletion(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "password is required")
+	})
+
+	t.Run("RequestDeletion - Wrong password", func(t *testing.T) {
+		deletionService := new(mocks.MockAccountDeletionService)
+		handler := handlers.NewPrivacyHandler(new(mocks.MockDataExportService), deletionService, new(mocks.MockRedisService))
+		deletionService.On("RequestDeletion", uint(1), "wrong123", mock.Anything).
+			Return(nil, apperror.NewInvalidPasswordError("Password is incorrect"))
+
+		w, c := newPrivacyRequest("POST", "/api/v1/profile/deletion", `{"password":"wrong123"}`, nil)
+		c.Set("UserID", uint(1))
+
+		handler.RequestDeletion(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Password is incorrect")
+	})
+
+	t.Run("CancelDeletion - Success", func(t *testing.T) {
+		deletionService := new(mocks.MockAccountDeletionService)
+		redisService := new(mocks.MockRedisService)
+		handler := handlers.NewPrivacyHandler(new(mocks.MockDataExportService), deletionService, redisService)
+		deletionService.On("CancelDeletion", uint(1), mock.Anything).Return(&models.User{ID: 1}, nil)
+		redisService.On("Delete", "PROFILE_1").Return(nil)
+
+		w, c := newPrivacyRequest("DELETE", "/api/v1/profile/deletion", "", nil)
+		c.Set("UserID", uint(1))
+
+		handler.CancelDeletion(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Account deletion cancelled"}`, w.Body.String())
+	})
+
+	t.Run("CancelDeletion - Nothing scheduled", func(t *testing.T) {
+		deletionService := new(mocks.MockAccountDeletionService)
+		handler := handlers.NewPrivacyHandler(new(mocks.MockDataExportService), deletionService, new(mocks.MockRedisService))
+		deletionService.On("CancelDeletion", uint(1), mock.Anything).
+			Return(nil, apperror.NewBadRequestError("No account deletion is scheduled"))
+
+		w, c := newPrivacyRequest("DELETE", "/api/v1/profile/deletion", "", nil)
+		c.Set("UserID", uint(1))
+
+		handler.CancelDeletion(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IRedirectHandler interface {
+	GetRedirects(c *gin.Context)
+	GetRedirect(c *gin.Context)
+	ResolveRedirect(c *gin.Context)
+	CreateRedirect(c *gin.Context)
+	UpdateRedirect(c *gin.Context)
+	DeleteRedirect(c *gin.Context)
+}
+
+type RedirectHandler struct {
+	redirectService services.IRedirectService
+}
+
+func NewRedirectHandler(redirectService services.IRedirectService) *RedirectHandler {
+	return &RedirectHandler{
+		redirectService: redirectService,
+	}
+}
+
+func (handler *RedirectHandler) GetRedirects(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Search on both paths, e.g. ?search=/posts/
+	pagination, err := handler.redirectService.PaginateRedirects(page, limit, ctx.Query("search"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *RedirectHandler) GetRedirect(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid RedirectID"),
+		)
+		return
+	}
+
+	redirect, err := handler.redirectService.GetRedirect(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, redirect)
+}
+
+// ResolveRedirect looks up the redirect of a path of the website, e.g. ?path=/posts/old-slug
+// The front end calls it before answering a path it has no content for
+func (handler *RedirectHandler) ResolveRedirect(ctx *gin.Context) {
+	path := ctx.Query("path")
+	if path == "" {
+		utils.RespondWithError(ctx, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "path", Message: "path is required"},
+		}))
+		return
+	}
+
+	redirect, err := handler.redirectService.Resolve(path)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{
+		"fromPath":   redirect.FromPath,
+		"toPath":     redirect.ToPath,
+		"statusCode": redirect.StatusCode,
+	})
+}
+
+func (handler *RedirectHandler) CreateRedirect(ctx *gin.Context) {
+	var input struct {
+		FromPath   string `json:"fromPath" binding:"required,max=700"`
+		ToPath     string `json:"toPath" binding:"required,max=2048"`
+		StatusCode int    `json:"statusCode" binding:"omitempty"` // 301 when empty
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	redirect := models.Redirect{
+		FromPath:   input.FromPath,
+		ToPath:     input.ToPath,
+		StatusCode: input.StatusCode,
+	}
+
+	if err := handler.redirectService.CreateRedirect(&redirect); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, redirect)
+}
+
+func (handler *RedirectHandler) UpdateRedirect(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid RedirectID"),
+		)
+		return
+	}
+
+	var input struct {
+		FromPath   *string `json:"fromPath" binding:"omitempty,min=1,max=700"`
+		ToPath     *string `json:"toPath" binding:"omitempty,min=1,max=2048"`
+		StatusCode *int    `json:"statusCode" binding:"omitempty"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	redirect, err := handler.redirectService.GetRedirect(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.FromPath != nil {
+		redirect.FromPath = *input.FromPath
+	}
+	if input.ToPath != nil {
+		redirect.ToPath = *input.ToPath
+	}
+	if input.StatusCode != nil {
+		redirect.StatusCode = *input.StatusCode
+	}
+
+	if err := handler.redirectService.UpdateRedirect(redirect); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, redirect)
+}
+
+func (handler *RedirectHandler) DeleteRedirect(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid RedirectID"),
+		)
+		return
+	}
+
+	if err := handler.redirectService.DeleteRedirect(uint(id)); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete redirect successfully"})
+}
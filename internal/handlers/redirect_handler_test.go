@@ -0,0 +1,130 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestRedirectHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetRedirects - Search", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+		redirectService.On("PaginateRedirects", 1, 50, "/posts/").Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Redirect{{ID: 1, FromPath: "/posts/old"}}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/redirects?search=/posts/", "", nil)
+
+		handler.GetRedirects(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"fromPath":"/posts/old"`)
+		redirectService.AssertExpectations(t)
+	})
+
+	t.Run("ResolveRedirect - Success", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+		redirectService.On("Resolve", "/posts/old").Return(&models.Redirect{ID: 1, FromPath: "/posts/old", ToPath: "/posts/new", StatusCode: 301}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/redirects?path=/posts/old", "", nil)
+
+		handler.ResolveRedirect(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"toPath":"/posts/new"`)
+		assert.Contains(t, w.Body.String(), `"statusCode":301`)
+	})
+
+	t.Run("ResolveRedirect - Missing path", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+
+		w, c := newPostRequest("GET", "/api/v1/public/redirects", "", nil)
+
+		handler.ResolveRedirect(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		redirectService.AssertNotCalled(t, "Resolve", mock.Anything)
+	})
+
+	t.Run("CreateRedirect - Success", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+		redirectService.On("CreateRedirect", mock.MatchedBy(func(redirect *models.Redirect) bool {
+			return redirect.FromPath == "/old" && redirect.ToPath == "/new" && redirect.StatusCode == 302
+		})).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/redirects", `{"fromPath":"/old","toPath":"/new","statusCode":302}`, nil)
+
+		handler.CreateRedirect(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		redirectService.AssertExpectations(t)
+	})
+
+	t.Run("CreateRedirect - Missing paths", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+
+		w, c := newPostRequest("POST", "/api/v1/redirects", `{"statusCode":301}`, nil)
+
+		handler.CreateRedirect(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		redirectService.AssertNotCalled(t, "CreateRedirect", mock.Anything)
+	})
+
+	t.Run("UpdateRedirect - Loop", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+		redirect := &models.Redirect{ID: 1, FromPath: "/a", ToPath: "/b", StatusCode: 301}
+		redirectService.On("GetRedirect", uint(1)).Return(redirect, nil)
+		redirectService.On("UpdateRedirect", redirect).Return(apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "toPath", Message: "toPath redirects back to fromPath"},
+		}))
+
+		w, c := newPostRequest("PATCH", "/api/v1/redirects/1", `{"toPath":"/c"}`, gin.Params{{Key: "id", Value: "1"}})
+
+		handler.UpdateRedirect(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "/c", redirect.ToPath)
+		assert.Contains(t, w.Body.String(), "toPath redirects back to fromPath")
+	})
+
+	t.Run("DeleteRedirect - Invalid ID", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+
+		w, c := newPostRequest("DELETE", "/api/v1/redirects/abc", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.DeleteRedirect(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid RedirectID")
+	})
+
+	t.Run("DeleteRedirect - Success", func(t *testing.T) {
+		redirectService := new(mocks.MockRedirectService)
+		handler := handlers.NewRedirectHandler(redirectService)
+		redirectService.On("DeleteRedirect", uint(1)).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/redirects/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		handler.DeleteRedirect(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Delete redirect successfully")
+	})
+}
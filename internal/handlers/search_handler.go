@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// maxSearchText is the maximum length of the ?q parameter of a search
+const maxSearchText = 200
+
+// contentSearchTypes are the document types searched by the content endpoints
+var contentSearchTypes = []string{services.SearchTypePost, services.SearchTypePage}
+
+type ISearchHandler interface {
+	SearchPublished(c *gin.Context)
+	SearchContent(c *gin.Context)
+	SearchUsers(c *gin.Context)
+	Reindex(c *gin.Context)
+}
+
+type SearchHandler struct {
+	searchService services.ISearchService
+}
+
+func NewSearchHandler(searchService services.ISearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchPublished searches the published posts and pages, e.g. ?q=golang&type=post&category_id=2
+func (handler *SearchHandler) SearchPublished(ctx *gin.Context) {
+	query, err := parseSearchQuery(ctx, contentSearchTypes, "author_id", "category_id")
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+	query.Filters["status"] = models.PostStatusPublished
+
+	handler.search(ctx, query)
+}
+
+// SearchContent searches posts and pages with any status, e.g. ?q=golang&status=draft&author_id=1
+func (handler *SearchHandler) SearchContent(ctx *gin.Context) {
+	query, err := parseSearchQuery(ctx, contentSearchTypes, "status", "author_id", "category_id")
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	handler.search(ctx, query)
+}
+
+// SearchUsers searches users on their name and email, e.g. ?q=jane&status=invited
+func (handler *SearchHandler) SearchUsers(ctx *gin.Context) {
+	query, err := parseSearchQuery(ctx, []string{services.SearchTypeUser}, "status")
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	handler.search(ctx, query)
+}
+
+// Reindex schedules a rebuild of the whole search index, it runs in the background
+func (handler *SearchHandler) Reindex(ctx *gin.Context) {
+	handler.searchService.QueueReindex()
+	utils.RespondWithOK(ctx, http.StatusAccepted, gin.H{"message": "Search index rebuild queued"})
+}
+
+func (handler *SearchHandler) search(ctx *gin.Context, query services.SearchQuery) {
+	pagination, err := handler.searchService.Search(query)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+// parseSearchQuery reads the text, types, filters and page of a search from the query string
+// Parameters:
+//   - ctx: The request context
+//   - types: The document types the endpoint may search, all of them when ?type is empty
+//   - filterKeys: The query parameters copied into the filters, IDs must be numbers
+//
+// Returns:
+//   - services.SearchQuery: The search to run
+//   - error: ValidationError if the text is missing or too long, a type is not allowed or an ID is not a number
+func parseSearchQuery(ctx *gin.Context, types []string, filterKeys ...string) (services.SearchQuery, error) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+	query := services.SearchQuery{
+		Text:    strings.TrimSpace(ctx.Query("q")),
+		Types:   types,
+		Filters: map[string]string{},
+		Page:    page,
+		Limit:   limit,
+	}
+
+	var fields []apperror.FieldError
+	switch {
+	case query.Text == "":
+		fields = append(fields, apperror.FieldError{Field: "q", Message: "q is required"})
+	case len([]rune(query.Text)) > maxSearchText:
+		fields = append(fields, apperror.FieldError{Field: "q", Message: fmt.Sprintf("q must be at most %d characters long", maxSearchText)})
+	}
+
+	// Several types are separated by commas, e.g. ?type=post,page
+	if value := ctx.Query("type"); value != "" {
+		query.Types = nil
+		for _, docType := range strings.Split(value, ",") {
+			docType = strings.TrimSpace(docType)
+			if !slices.Contains(types, docType) {
+				fields = append(fields, apperror.FieldError{Field: "type", Message: fmt.Sprintf("type must be one of %v", types)})
+				break
+			}
+			query.Types = append(query.Types, docType)
+		}
+	}
+
+	for _, key := range filterKeys {
+		value := ctx.Query(key)
+		if value == "" {
+			continue
+		}
+		if strings.HasSuffix(key, "_id") {
+			if id, err := strconv.ParseUint(value, 10, 64); err != nil || id == 0 {
+				fields = append(fields, apperror.FieldError{Field: key, Message: key + " must be a positive number"})
+				continue
+			}
+		}
+		query.Filters[key] = value
+	}
+
+	if len(fields) > 0 {
+		return query, apperror.NewValidationError("Validation failed", fields)
+	}
+	return query, nil
+}
@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/search"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestSearchHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("SearchPublished - Success", func(t *testing.T) {
+		searchService := new(mocks.MockSearchService)
+		handler := handlers.NewSearchHandler(searchService)
+		searchService.On("Search", services.SearchQuery{
+			Text:    "golang",
+			Types:   []string{services.SearchTypePost},
+			Filters: map[string]string{"status": "published", "category_id": "2"},
+			Page:    1,
+			Limit:   10,
+		}).Return(&utils.Pagination{Page: 1, Limit: 10, TotalItems: 1, TotalPages: 1, Data: []search.Hit{
+			{Type: services.SearchTypePost, ID: 1, Title: "Golang", Highlights: map[string]string{"title": "<mark>Golang</mark>"}},
+		}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/public/search?q=golang&type=post&category_id=2&status=draft&limit=10", "", nil)
+
+		handler.SearchPublished(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"highlights":{"title":"\u003cmark\u003eGolang\u003c/mark\u003e"}`)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("SearchContent - All content types", func(t *testing.T) {
+		searchService := new(mocks.MockSearchService)
+		handler := handlers.NewSearchHandler(searchService)
+		searchService.On("Search", mock.MatchedBy(func(query services.SearchQuery) bool {
+			return query.Text == "notes" && len(query.Types) == 2 && query.Filters["status"] == "draft" && query.Filters["author_id"] == "3"
+		})).Return(&utils.Pagination{Data: []search.Hit{}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/search?q=+notes+&status=draft&author_id=3", "", nil)
+
+		handler.SearchContent(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("SearchContent - Validation error", func(t *testing.T) {
+		searchService := new(mocks.MockSearchService)
+		handler := handlers.NewSearchHandler(searchService)
+
+		w, c := newPostRequest("GET", "/api/v1/search?type=user&author_id=abc", "", nil)
+
+		handler.SearchContent(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"field":"q"`)
+		assert.Contains(t, w.Body.String(), `"field":"type"`)
+		assert.Contains(t, w.Body.String(), `"field":"author_id"`)
+		searchService.AssertNotCalled(t, "Search", mock.Anything)
+	})
+
+	t.Run("SearchUsers - Success", func(t *testing.T) {
+		searchService := new(mocks.MockSearchService)
+		handler := handlers.NewSearchHandler(searchService)
+		searchService.On("Search", mock.MatchedBy(func(query services.SearchQuery) bool {
+			return query.Text == "jane" && len(query.Types) == 1 && query.Types[0] == services.SearchTypeUser
+		})).Return(&utils.Pagination{Data: []search.Hit{}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/search/users?q=jane", "", nil)
+
+		handler.SearchUsers(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("Reindex - Queued", func(t *testing.T) {
+		searchService := new(mocks.MockSearchService)
+		handler := handlers.NewSearchHandler(searchService)
+		searchService.On("QueueReindex").Return()
+
+		w, c := newPostRequest("POST", "/api/v1/search/reindex", "", nil)
+
+		handler.Reindex(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		searchService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/sitemap"
+)
+
+type ISitemapHandler interface {
+	GetSitemapIndex(c *gin.Context)
+	GetSitemap(c *gin.Context)
+}
+
+type SitemapHandler struct {
+	sitemapService services.ISitemapService
+}
+
+func NewSitemapHandler(sitemapService services.ISitemapService) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapService: sitemapService,
+	}
+}
+
+// GetSitemapIndex retrieves the sitemap index listing the sitemap files of the published content
+func (handler *SitemapHandler) GetSitemapIndex(ctx *gin.Context) {
+	body, err := handler.sitemapService.GetIndex()
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, sitemap.ContentType, body)
+}
+
+// GetSitemap retrieves a sitemap file listed by the index, the :file parameter is e.g. "posts-1.xml"
+func (handler *SitemapHandler) GetSitemap(ctx *gin.Context) {
+	name, ok := strings.CutSuffix(ctx.Param("file"), ".xml")
+	separator := strings.LastIndex(name, "-")
+	if !ok || separator < 0 {
+		utils.RespondWithError(ctx, apperror.NewNotFoundError("Sitemap not found"))
+		return
+	}
+	number, err := strconv.Atoi(name[separator+1:])
+	if err != nil {
+		utils.RespondWithError(ctx, apperror.NewNotFoundError("Sitemap not found"))
+		return
+	}
+
+	body, err := handler.sitemapService.GetSitemap(name[:separator], number)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.Data(http.StatusOK, sitemap.ContentType, body)
+}
@@ -0,0 +1,70 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/sitemap"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestSitemapHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("GetSitemapIndex - Success", func(t *testing.T) {
+		sitemapService := new(mocks.MockSitemapService)
+		handler := handlers.NewSitemapHandler(sitemapService)
+		sitemapService.On("GetIndex").Return([]byte("<sitemapindex/>"), nil)
+
+		w, c := newPostRequest("GET", "/sitemap.xml", "", nil)
+
+		handler.GetSitemapIndex(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, sitemap.ContentType, w.Header().Get("Content-Type"))
+		assert.Equal(t, "<sitemapindex/>", w.Body.String())
+	})
+
+	t.Run("GetSitemap - Success", func(t *testing.T) {
+		sitemapService := new(mocks.MockSitemapService)
+		handler := handlers.NewSitemapHandler(sitemapService)
+		sitemapService.On("GetSitemap", "posts", 2).Return([]byte("<urlset/>"), nil)
+
+		w, c := newPostRequest("GET", "/sitemaps/posts-2.xml", "", gin.Params{{Key: "file", Value: "posts-2.xml"}})
+
+		handler.GetSitemap(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "<urlset/>", w.Body.String())
+	})
+
+	t.Run("GetSitemap - Invalid file name", func(t *testing.T) {
+		sitemapService := new(mocks.MockSitemapService)
+		handler := handlers.NewSitemapHandler(sitemapService)
+
+		for _, file := range []string{"posts.xml", "posts-a.xml", "posts-1.txt"} {
+			w, c := newPostRequest("GET", "/sitemaps/"+file, "", gin.Params{{Key: "file", Value: file}})
+
+			handler.GetSitemap(c)
+
+			assert.Equal(t, http.StatusNotFound, w.Code, file)
+		}
+		sitemapService.AssertNotCalled(t, "GetSitemap")
+	})
+
+	t.Run("GetSitemap - Not found", func(t *testing.T) {
+		sitemapService := new(mocks.MockSitemapService)
+		handler := handlers.NewSitemapHandler(sitemapService)
+		sitemapService.On("GetSitemap", "posts", 9).Return(nil, apperror.NewNotFoundError("Sitemap not found"))
+
+		w, c := newPostRequest("GET", "/sitemaps/posts-9.xml", "", gin.Params{{Key: "file", Value: "posts-9.xml"}})
+
+		handler.GetSitemap(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
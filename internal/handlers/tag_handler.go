@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type ITagHandler interface {
+	GetTags(c *gin.Context)
+	GetTag(c *gin.Context)
+	CreateTag(c *gin.Context)
+	UpdateTag(c *gin.Context)
+	DeleteTag(c *gin.Context)
+}
+
+type TagHandler struct {
+	tagService services.ITagService
+}
+
+func NewTagHandler(tagService services.ITagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+func (handler *TagHandler) GetTags(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Search on the name, e.g. ?search=go
+	pagination, err := handler.tagService.PaginateTags(page, limit, ctx.Query("search"))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *TagHandler) GetTag(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid TagID"),
+		)
+		return
+	}
+
+	tag, err := handler.tagService.GetTag(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, tag)
+}
+
+func (handler *TagHandler) CreateTag(ctx *gin.Context) {
+	var input struct {
+		Name string `json:"name" binding:"required,max=100,not_blank"`
+		Slug string `json:"slug" binding:"omitempty,max=255"` // Generated from the name when empty
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	tag := models.Tag{
+		Name: input.Name,
+		Slug: input.Slug,
+	}
+
+	if err := handler.tagService.CreateTag(&tag); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusCreated, tag)
+}
+
+func (handler *TagHandler) UpdateTag(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid TagID"),
+		)
+		return
+	}
+
+	var input struct {
+		Name *string `json:"name" binding:"omitempty,max=100,not_blank"`
+		Slug *string `json:"slug" binding:"omitempty,min=1,max=255"`
+	}
+
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	tag, err := handler.tagService.GetTag(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if input.Name != nil {
+		tag.Name = *input.Name
+	}
+	if input.Slug != nil {
+		tag.Slug = *input.Slug
+	}
+
+	if err := handler.tagService.UpdateTag(tag); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, tag)
+}
+
+func (handler *TagHandler) DeleteTag(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid TagID"),
+		)
+		return
+	}
+
+	// Make sure the tag exists before deleting it
+	tag, err := handler.tagService.GetTag(uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	if err := handler.tagService.DeleteTag(tag.ID); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete tag successfully"})
+}
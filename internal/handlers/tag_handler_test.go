@@ -0,0 +1,97 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestTagHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	t.Run("GetTags - Search", func(t *testing.T) {
+		tagService := new(mocks.MockTagService)
+		handler := handlers.NewTagHandler(tagService)
+		tagService.On("PaginateTags", 1, 50, "go").Return(&utils.Pagination{Page: 1, Limit: 50, Data: []models.Tag{{ID: 1, Name: "Go", Slug: "go"}}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/tags?search=go", "", nil)
+
+		handler.GetTags(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"go"`)
+		tagService.AssertExpectations(t)
+	})
+
+	t.Run("CreateTag - Success", func(t *testing.T) {
+		tagService := new(mocks.MockTagService)
+		handler := handlers.NewTagHandler(tagService)
+		tagService.On("CreateTag", mock.MatchedBy(func(tag *models.Tag) bool {
+			return tag.Name == "Go"
+		})).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Tag).Slug = "go"
+		}).Return(nil)
+
+		w, c := newPostRequest("POST", "/api/v1/tags", `{"name":"Go"}`, nil)
+
+		handler.CreateTag(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"go"`)
+		tagService.AssertExpectations(t)
+	})
+
+	t.Run("UpdateTag - Slug taken", func(t *testing.T) {
+		tagService := new(mocks.MockTagService)
+		handler := handlers.NewTagHandler(tagService)
+		tag := &models.Tag{ID: 1, Name: "Go", Slug: "go"}
+		tagService.On("GetTag", uint(1)).Return(tag, nil)
+		tagService.On("UpdateTag", tag).Return(apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "slug", Message: "slug is already taken"},
+		}))
+
+		w, c := newPostRequest("PATCH", "/api/v1/tags/1", `{"slug":"golang"}`, gin.Params{{Key: "id", Value: "1"}})
+
+		handler.UpdateTag(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "slug is already taken")
+	})
+
+	t.Run("DeleteTag - Not found", func(t *testing.T) {
+		tagService := new(mocks.MockTagService)
+		handler := handlers.NewTagHandler(tagService)
+		tagService.On("GetTag", uint(9)).Return(nil, apperror.NewNotFoundError("record not found"))
+
+		w, c := newPostRequest("DELETE", "/api/v1/tags/9", "", gin.Params{{Key: "id", Value: "9"}})
+
+		handler.DeleteTag(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		tagService.AssertExpectations(t)
+	})
+
+	t.Run("DeleteTag - Success", func(t *testing.T) {
+		tagService := new(mocks.MockTagService)
+		handler := handlers.NewTagHandler(tagService)
+		tagService.On("GetTag", uint(1)).Return(&models.Tag{ID: 1}, nil)
+		tagService.On("DeleteTag", uint(1)).Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/tags/1", "", gin.Params{{Key: "id", Value: "1"}})
+
+		handler.DeleteTag(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Delete tag successfully"}`, w.Body.String())
+		tagService.AssertExpectations(t)
+	})
+}
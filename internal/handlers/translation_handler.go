@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+)
+
+type ITranslationHandler interface {
+	GetLocales(c *gin.Context)
+	GetPostTranslations(c *gin.Context)
+	SavePostTranslation(c *gin.Context)
+	DeletePostTranslation(c *gin.Context)
+	GetPageTranslations(c *gin.Context)
+	SavePageTranslation(c *gin.Context)
+	DeletePageTranslation(c *gin.Context)
+	GetMissingTranslations(c *gin.Context)
+}
+
+type TranslationHandler struct {
+	translationService services.ITranslationService
+	locales            *i18n.Locales
+}
+
+func NewTranslationHandler(translationService services.ITranslationService, locales *i18n.Locales) *TranslationHandler {
+	return &TranslationHandler{
+		translationService: translationService,
+		locales:            locales,
+	}
+}
+
+// GetLocales lists the supported locales, the content of posts and pages is written in the default one
+func (handler *TranslationHandler) GetLocales(ctx *gin.Context) {
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{
+		"default":   handler.locales.Default(),
+		"supported": handler.locales.Supported(),
+	})
+}
+
+func (handler *TranslationHandler) GetPostTranslations(ctx *gin.Context) {
+	handler.getTranslations(ctx, models.TranslationTypePost, "Invalid PostID")
+}
+
+// SavePostTranslation creates or replaces the translation of a post in the locale of the path, e.g. PUT /posts/3/translations/vi
+func (handler *TranslationHandler) SavePostTranslation(ctx *gin.Context) {
+	handler.saveTranslation(ctx, models.TranslationTypePost, "Invalid PostID")
+}
+
+func (handler *TranslationHandler) DeletePostTranslation(ctx *gin.Context) {
+	handler.deleteTranslation(ctx, models.TranslationTypePost, "Invalid PostID")
+}
+
+func (handler *TranslationHandler) GetPageTranslations(ctx *gin.Context) {
+	handler.getTranslations(ctx, models.TranslationTypePage, "Invalid PageID")
+}
+
+// SavePageTranslation creates or replaces the translation of a page in the locale of the path, e.g. PUT /pages/3/translations/vi
+func (handler *TranslationHandler) SavePageTranslation(ctx *gin.Context) {
+	handler.saveTranslation(ctx, models.TranslationTypePage, "Invalid PageID")
+}
+
+func (handler *TranslationHandler) DeletePageTranslation(ctx *gin.Context) {
+	handler.deleteTranslation(ctx, models.TranslationTypePage, "Invalid PageID")
+}
+
+// GetMissingTranslations lists the posts or pages not translated in a locale yet, e.g. ?type=page&locale=vi
+func (handler *TranslationHandler) GetMissingTranslations(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	contentType := ctx.DefaultQuery("type", models.TranslationTypePost)
+	pagination, err := handler.translationService.PaginateMissing(contentType, ctx.Query("locale"), page, limit)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
+func (handler *TranslationHandler) getTranslations(ctx *gin.Context, contentType, invalidIDMessage string) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError(invalidIDMessage),
+		)
+		return
+	}
+
+	translations, err := handler.translationService.GetTranslations(contentType, uint(id))
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, translations)
+}
+
+func (handler *TranslationHandler) saveTranslation(ctx *gin.Context, contentType, invalidIDMessage string) {
+	// The authenticated user is recorded as the translator
+	userId := ctx.GetUint("UserID")
+	if userId == 0 {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError("Invalid UserID"),
+		)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError(invalidIDMessage),
+		)
+		return
+	}
+
+	var input struct {
+		Title   string `json:"title" binding:"required,max=255,not_blank"`
+		Slug    string `json:"slug" binding:"omitempty,max=255"`    // Generated from the title when empty
+		Excerpt string `json:"excerpt" binding:"omitempty,max=500"` // Ignored for pages
+		Body    string `json:"body" binding:"required"`
+	}
+
+	// Bind and validate the JSON request body to the input struct
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		validateError := utils.TranslateValidationErrors(err, input)
+		utils.RespondWithError(ctx, validateError)
+		return
+	}
+
+	translation := models.Translation{
+		ContentType:  contentType,
+		ContentID:    uint(id),
+		Locale:       ctx.Param("locale"),
+		Title:        input.Title,
+		Slug:         input.Slug,
+		Excerpt:      utils.StringToPtr(input.Excerpt),
+		Body:         input.Body,
+		TranslatorID: &userId,
+	}
+	created, err := handler.translationService.SaveTranslation(&translation)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	utils.RespondWithOK(ctx, status, translation)
+}
+
+func (handler *TranslationHandler) deleteTranslation(ctx *gin.Context, contentType, invalidIDMessage string) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		utils.RespondWithError(
+			ctx,
+			apperror.NewParseError(invalidIDMessage),
+		)
+		return
+	}
+
+	if err := handler.translationService.DeleteTranslation(contentType, uint(id), ctx.Param("locale")); err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Delete translation successfully"})
+}
@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestTranslationHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	utils.InitValidator()
+
+	locales, err := i18n.NewLocales("en", []string{"vi", "pt-BR"}, nil)
+	require.NoError(t, err)
+
+	t.Run("GetLocales - Success", func(t *testing.T) {
+		handler := handlers.NewTranslationHandler(new(mocks.MockTranslationService), locales)
+
+		w, c := newPostRequest("GET", "/api/v1/public/locales", "", nil)
+
+		handler.GetLocales(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"default":"en","supported":["en","vi","pt-BR"]}`, w.Body.String())
+	})
+
+	t.Run("GetPostTranslations - Success", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+		translationService.On("GetTranslations", models.TranslationTypePost, uint(3)).
+			Return([]models.Translation{{ID: 1, ContentType: models.TranslationTypePost, ContentID: 3, Locale: "vi", Slug: "xin-chao"}}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/posts/3/translations", "", gin.Params{{Key: "id", Value: "3"}})
+
+		handler.GetPostTranslations(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"slug":"xin-chao"`)
+		translationService.AssertExpectations(t)
+	})
+
+	t.Run("GetPageTranslations - Invalid PageID", func(t *testing.T) {
+		handler := handlers.NewTranslationHandler(new(mocks.MockTranslationService), locales)
+
+		w, c := newPostRequest("GET", "/api/v1/pages/abc/translations", "", gin.Params{{Key: "id", Value: "abc"}})
+
+		handler.GetPageTranslations(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid PageID")
+	})
+
+	t.Run("SavePostTranslation - Created", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+		translationService.On("SaveTranslation", mock.MatchedBy(func(translation *models.Translation) bool {
+			return translation.ContentType == models.TranslationTypePost && translation.ContentID == 3 &&
+				translation.Locale == "vi" && translation.Title == "Xin chào" && *translation.TranslatorID == 1
+		})).Return(true, nil)
+
+		body := `{"title":"Xin chào","body":"Nội dung"}`
+		w, c := newPostRequest("PUT", "/api/v1/posts/3/translations/vi", body, gin.Params{{Key: "id", Value: "3"}, {Key: "locale", Value: "vi"}})
+		c.Set("UserID", uint(1))
+
+		handler.SavePostTranslation(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		translationService.AssertExpectations(t)
+	})
+
+	t.Run("SavePageTranslation - Updated", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+		translationService.On("SaveTranslation", mock.MatchedBy(func(translation *models.Translation) bool {
+			return translation.ContentType == models.TranslationTypePage && translation.Slug == "nhom"
+		})).Return(false, nil)
+
+		body := `{"title":"Nhóm","slug":"nhom","body":"Nội dung"}`
+		w, c := newPostRequest("PUT", "/api/v1/pages/2/translations/vi", body, gin.Params{{Key: "id", Value: "2"}, {Key: "locale", Value: "vi"}})
+		c.Set("UserID", uint(1))
+
+		handler.SavePageTranslation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		translationService.AssertExpectations(t)
+	})
+
+	t.Run("SavePostTranslation - Validation error", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+
+		w, c := newPostRequest("PUT", "/api/v1/posts/3/translations/vi", `{"title":" "}`, gin.Params{{Key: "id", Value: "3"}, {Key: "locale", Value: "vi"}})
+		c.Set("UserID", uint(1))
+
+		handler.SavePostTranslation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		translationService.AssertNotCalled(t, "SaveTranslation", mock.Anything)
+	})
+
+	t.Run("SavePostTranslation - Invalid UserID", func(t *testing.T) {
+		handler := handlers.NewTranslationHandler(new(mocks.MockTranslationService), locales)
+
+		w, c := newPostRequest("PUT", "/api/v1/posts/3/translations/vi", `{}`, gin.Params{{Key: "id", Value: "3"}, {Key: "locale", Value: "vi"}})
+
+		handler.SavePostTranslation(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid UserID")
+	})
+
+	t.Run("DeletePostTranslation - Success", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+		translationService.On("DeleteTranslation", models.TranslationTypePost, uint(3), "vi").Return(nil)
+
+		w, c := newPostRequest("DELETE", "/api/v1/posts/3/translations/vi", "", gin.Params{{Key: "id", Value: "3"}, {Key: "locale", Value: "vi"}})
+
+		handler.DeletePostTranslation(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"message":"Delete translation successfully"}`, w.Body.String())
+		translationService.AssertExpectations(t)
+	})
+
+	t.Run("DeletePageTranslation - Not found", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+		translationService.On("DeleteTranslation", models.TranslationTypePage, uint(2), "fr").Return(apperror.NewNotFoundError("Translation not found"))
+
+		w, c := newPostRequest("DELETE", "/api/v1/pages/2/translations/fr", "", gin.Params{{Key: "id", Value: "2"}, {Key: "locale", Value: "fr"}})
+
+		handler.DeletePageTranslation(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("GetMissingTranslations - Success", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+		translationService.On("PaginateMissing", models.TranslationTypePage, "vi", 1, 50).Return(&utils.Pagination{Page: 1, Limit: 50}, nil)
+
+		w, c := newPostRequest("GET", "/api/v1/translations/missing?type=page&locale=vi", "", nil)
+
+		handler.GetMissingTranslations(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		translationService.AssertExpectations(t)
+	})
+
+	t.Run("GetMissingTranslations - Defaults to posts", func(t *testing.T) {
+		translationService := new(mocks.MockTranslationService)
+		handler := handlers.NewTranslationHandler(translationService, locales)
+		translationService.On("PaginateMissing", models.TranslationTypePost, "", 1, 50).
+			Return(nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{{Field: "locale", Message: "locale must be one of [vi pt-BR]"}}))
+
+		w, c := newPostRequest("GET", "/api/v1/translations/missing", "", nil)
+
+		handler.GetMissingTranslations(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		translationService.AssertExpectations(t)
+	})
+}
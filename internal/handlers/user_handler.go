@@ -11,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/vfa-khuongdv/golang-cms/internal/constants"
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
 	"github.com/vfa-khuongdv/golang-cms/internal/services"
 	"github.com/vfa-khuongdv/golang-cms/internal/utils"
 	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
@@ -314,6 +315,7 @@ func (handler *UserHandler) UpdateUser(ctx *gin.Context) {
 		Birthday *string `json:"birthday" binding:"omitempty,valid_birthday"`         // Assumes birthday is valid format: YYYY-MM-DD
 		Address  *string `json:"address" binding:"omitempty,min=1,max=255,not_blank"` // Address must be between 1-255 chars and not blank
 		Gender   *int16  `json:"gender" binding:"omitempty,oneof=1 2 3"`              // Gender must be one of [1 2 3]
+		Version  *uint   `json:"version" binding:"omitempty,min=1"`                   // Version the changes were made to, a stale one is rejected
 	}
 
 	if err := ctx.ShouldBindJSON(&input); err != nil {
@@ -343,12 +345,17 @@ func (handler *UserHandler) UpdateUser(ctx *gin.Context) {
 	if input.Gender != nil {
 		user.Gender = *input.Gender
 	}
+	if input.Version != nil {
+		user.Version = *input.Version
+	}
 
 	// Save updated user to database
 	if err := handler.userService.UpdateUser(user); err != nil {
 		utils.RespondWithError(ctx, err)
 		return
 	}
+	// Clear the cached profile, it holds the previous version
+	handler.clearUserProfile(user.ID)
 
 	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Update user successfully"})
 }
@@ -375,6 +382,24 @@ func (handler *UserHandler) GetUser(ctx *gin.Context) {
 	utils.RespondWithOK(ctx, http.StatusOK, user)
 }
 
+func (handler *UserHandler) GetUsers(ctx *gin.Context) {
+	page, limit := utils.ParsePageAndLimit(ctx)
+
+	// Filter on custom attributes, e.g. ?attributes[department]=Sales
+	filter := repositories.UserFilter{
+		Attributes: ctx.QueryMap("attributes"),
+	}
+
+	// Get users from database
+	pagination, err := handler.userService.PaginateUser(page, limit, filter)
+	if err != nil {
+		utils.RespondWithError(ctx, err)
+		return
+	}
+
+	utils.RespondWithOK(ctx, http.StatusOK, pagination)
+}
+
 func (handler *UserHandler) GetProfile(ctx *gin.Context) {
 	// Get user ID from the context
 	userId := ctx.GetUint("UserID")
@@ -441,6 +466,14 @@ func (handler *UserHandler) cacheUserProfile(user *models.User) error {
 	return nil
 }
 
+// clearUserProfile removes the profile cached by GetProfile, a failure is logged and the profile expires with its TTL
+func (handler *UserHandler) clearUserProfile(userID uint) {
+	profileKey := constants.PROFILE + strconv.Itoa(int(userID))
+	if err := handler.redisService.Delete(profileKey); err != nil {
+		logrus.Errorf("Failed to clear cache: %v", err)
+	}
+}
+
 func (handler *UserHandler) UpdateProfile(ctx *gin.Context) {
 	// Get user ID from context and validate
 	userId := ctx.GetUint("UserID")
@@ -458,6 +491,7 @@ func (handler *UserHandler) UpdateProfile(ctx *gin.Context) {
 		Birthday *string `json:"birthday" binding:"omitempty,valid_birthday"`         // Birthday must be a valid date (YYYY-MM-DD) if provided
 		Address  *string `json:"address" binding:"omitempty,min=1,max=255,not_blank"` // Address must be between 1 and 255 characters and not blank if provided
 		Gender   *int16  `json:"gender" binding:"omitempty,oneof=1 2 3"`              // Gender must be 1, 2, or 3 if provided
+		Version  *uint   `json:"version" binding:"omitempty,min=1"`                   // Version of the profile the changes were made to
 	}
 
 	// Bind and validate JSON request body
@@ -489,6 +523,9 @@ func (handler *UserHandler) UpdateProfile(ctx *gin.Context) {
 	if input.Gender != nil {
 		user.Gender = *input.Gender
 	}
+	if input.Version != nil {
+		user.Version = *input.Version
+	}
 
 	// Save updated user to database
 	if err := handler.userService.UpdateUser(user); err != nil {
@@ -496,10 +533,7 @@ func (handler *UserHandler) UpdateProfile(ctx *gin.Context) {
 		return
 	}
 	// Clear cache
-	profileKey := constants.PROFILE + string(rune(user.ID))
-	if err := handler.redisService.Delete(profileKey); err != nil {
-		logrus.Errorf("Failed to clear cache: %v", err)
-	}
+	handler.clearUserProfile(user.ID)
 
 	utils.RespondWithOK(ctx, http.StatusOK, gin.H{"message": "Update profile successfully"})
 }
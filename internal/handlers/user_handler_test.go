@@ -12,13 +12,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/vfa-khuongdv/golang-cms/internal/constants"
 	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
 	"github.com/vfa-khuongdv/golang-cms/internal/utils"
 	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
 	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
@@ -520,8 +524,7 @@ func TestUpdateProfile(t *testing.T) {
 			Name:  "User",
 		}
 
-		// Assuming the cache key is constructed as "profile:<user_id>"
-		profileKey := constants.PROFILE + string(rune(user.ID))
+		profileKey := constants.PROFILE + strconv.Itoa(int(user.ID))
 
 		// Mock the service methods
 		userService.On("GetUser", uint(1)).Return(user, nil)
@@ -556,6 +559,59 @@ func TestUpdateProfile(t *testing.T) {
 		bcryptService.AssertExpectations(t)
 	})
 
+	t.Run("UpdateProfile - Profile read back after a save", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+
+		userService := new(mocks.MockUserService)
+		bcryptService := new(mocks.MockBcryptService)
+		handler := handlers.NewUserHandler(userService, services.NewRedisService(client), bcryptService)
+
+		getProfile := func() uint {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("GET", "/api/v1/profile", nil)
+			c.Set("UserID", uint(1))
+			handler.GetProfile(c)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var profile models.User
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &profile))
+			return profile.Version
+		}
+		updateProfile := func(name string, version uint) {
+			body := fmt.Sprintf(`{"name":%q,"version":%d}`, name, version)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("PUT", "/api/v1/profile", strings.NewReader(body))
+			c.Set("UserID", uint(1))
+			handler.UpdateProfile(c)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		}
+
+		// Every save bumps the version, a save with an older one would be rejected with a conflict
+		userService.On("GetProfile", uint(1)).Return(&models.User{ID: 1, Name: "User", Version: 1}, nil).Once()
+		userService.On("GetUser", uint(1)).Return(&models.User{ID: 1, Name: "User", Version: 1}, nil).Once()
+		userService.On("UpdateUser", mock.MatchedBy(func(user *models.User) bool { return user.Version == 1 })).Return(nil).Once()
+		userService.On("GetProfile", uint(1)).Return(&models.User{ID: 1, Name: "First", Version: 2}, nil).Once()
+		userService.On("GetUser", uint(1)).Return(&models.User{ID: 1, Name: "First", Version: 2}, nil).Once()
+		userService.On("UpdateUser", mock.MatchedBy(func(user *models.User) bool { return user.Version == 2 })).Return(nil).Once()
+
+		version := getProfile()
+		assert.Equal(t, uint(1), version)
+		assert.True(t, server.Exists(constants.PROFILE+"1"))
+
+		updateProfile("First", version)
+		assert.False(t, server.Exists(constants.PROFILE+"1"), "the save clears the cached profile")
+
+		version = getProfile()
+		assert.Equal(t, uint(2), version, "the saved version is read back")
+
+		updateProfile("Second", version)
+		userService.AssertExpectations(t)
+	})
+
 	t.Run("UpdateProfile - Validation Error", func(t *testing.T) {
 		tests := []struct {
 			name           string
@@ -850,7 +906,7 @@ func TestUpdateProfile(t *testing.T) {
 		// Mock the service method
 		userService.On("GetUser", uint(1)).Return(user, nil)
 		userService.On("UpdateUser", user).Return(nil)
-		profileKey := constants.PROFILE + string(rune(user.ID))
+		profileKey := constants.PROFILE + strconv.Itoa(int(user.ID))
 		redisService.On("Delete", profileKey).Return(errors.New("Redis delete error"))
 
 		// Create a test context
@@ -923,6 +979,7 @@ func TestGetProfile(t *testing.T) {
 			"gender":    float64(1),
 			"createdAt": "2023-10-01T00:00:00Z",
 			"updatedAt": "2023-10-01T00:00:00Z",
+			"version":   float64(0),
 			"deletedAt": nil,
 		}
 		var actualBody map[string]any
@@ -973,6 +1030,7 @@ func TestGetProfile(t *testing.T) {
 			"gender":    float64(1),
 			"createdAt": "2023-10-01T00:00:00Z",
 			"updatedAt": "2023-10-01T00:00:00Z",
+			"version":   float64(0),
 			"deletedAt": nil,
 		}
 
@@ -1096,6 +1154,7 @@ func TestGetProfile(t *testing.T) {
 			"gender":    float64(1),
 			"createdAt": "2023-10-01T00:00:00Z",
 			"updatedAt": "2023-10-01T00:00:00Z",
+			"version":   float64(0),
 			"deletedAt": nil,
 		}
 
@@ -1144,6 +1203,52 @@ func TestGetProfile(t *testing.T) {
 	})
 }
 
+func TestGetUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("GetUsers - Success with attribute filter", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		handler := handlers.NewUserHandler(userService, new(mocks.MockRedisService), new(mocks.MockBcryptService))
+
+		filter := repositories.UserFilter{Attributes: map[string]string{"department": "Sales"}}
+		pagination := &utils.Pagination{
+			Page:       2,
+			Limit:      5,
+			TotalItems: 6,
+			TotalPages: 2,
+			Data:       []models.User{{ID: 1, Name: "User", Attributes: map[string]any{"department": "Sales"}}},
+		}
+		userService.On("PaginateUser", 2, 5, filter).Return(pagination, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/users?page=2&limit=5&attributes[department]=Sales", nil)
+
+		handler.GetUsers(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"attributes":{"department":"Sales"}`)
+		assert.Contains(t, w.Body.String(), `"totalItems":6`)
+		userService.AssertExpectations(t)
+	})
+
+	t.Run("GetUsers - Error", func(t *testing.T) {
+		userService := new(mocks.MockUserService)
+		handler := handlers.NewUserHandler(userService, new(mocks.MockRedisService), new(mocks.MockBcryptService))
+		userService.On("PaginateUser", 1, 50, repositories.UserFilter{Attributes: map[string]string{}}).
+			Return(&utils.Pagination{}, apperror.NewDBQueryError("db error"))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/users", nil)
+
+		handler.GetUsers(c)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		userService.AssertExpectations(t)
+	})
+}
+
 func TestGetUser(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -1184,6 +1289,7 @@ func TestGetUser(t *testing.T) {
 			"gender":    float64(1),
 			"createdAt": "2023-10-01T00:00:00Z",
 			"updatedAt": "2023-10-01T00:00:00Z",
+			"version":   float64(0),
 			"deletedAt": nil,
 		}
 		assert.Equal(t, http.StatusOK, w.Code)
@@ -1800,6 +1906,7 @@ func TestUpdateUser(t *testing.T) {
 		// Mock methods
 		userService.On("GetUser", uint(1)).Return(user, nil)
 		userService.On("UpdateUser", user).Return(nil)
+		redisService.On("Delete", constants.PROFILE+"1").Return(nil)
 
 		// Create a test context
 		w := httptest.NewRecorder()
@@ -1813,6 +1920,7 @@ func TestUpdateUser(t *testing.T) {
 		// Assert the response
 		assert.Equal(t, http.StatusOK, w.Code)
 		assert.JSONEq(t, `{"message":"Update user successfully"}`, w.Body.String())
+		redisService.AssertExpectations(t)
 	})
 	t.Run("UpdateUser - Validation Error", func(t *testing.T) {
 		tests := []struct {
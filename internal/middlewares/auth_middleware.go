@@ -15,6 +15,7 @@ import (
 // - Authorization header exists and has "Bearer " prefix
 // - Token is valid and can be parsed
 // If validation succeeds, it sets the user ID from token claims in context
+// For impersonation tokens it also sets ImpersonatorID and ImpersonationID (the session ID)
 // If validation fails, it returns 401 Unauthorized
 func AuthMiddleware() gin.HandlerFunc {
 	jwtService := services.NewJWTService()
@@ -35,6 +36,23 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		ctx.Set("UserID", claims.ID)
+		if claims.ImpersonatorID != 0 {
+			ctx.Set("ImpersonatorID", claims.ImpersonatorID)
+			ctx.Set("ImpersonationID", claims.RegisteredClaims.ID)
+		}
 		ctx.Next()
 	}
 }
+
+// OptionalAuthMiddleware authenticates the request like AuthMiddleware when it has an Authorization header
+// Requests without the header continue as guests, without UserID in context
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	auth := AuthMiddleware()
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") == "" {
+			ctx.Next()
+			return
+		}
+		auth(ctx)
+	}
+}
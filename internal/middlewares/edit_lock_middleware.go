@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+// EditLockMiddleware is a Gin middleware function that rejects changes to a record locked by another user
+// It must run after AuthMiddleware on routes having the ID of the record in the :id parameter
+// Invalid IDs are left to the handler, which answers with its own validation error
+// If another user holds the edit lock, it returns 423 Locked
+func EditLockMiddleware(locks services.IEditLockService, resource string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		if err := locks.CheckEditable(resource, uint(id), ctx.GetUint("UserID")); err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newEditLockRouter(locks *mocks.MockEditLockService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("UserID", uint(1))
+	})
+	router.PATCH("/posts/:id", middlewares.EditLockMiddleware(locks, "posts"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "OK"})
+	})
+	return router
+}
+
+func TestEditLockMiddleware(t *testing.T) {
+	t.Run("Editable", func(t *testing.T) {
+		locks := new(mocks.MockEditLockService)
+		locks.On("CheckEditable", "posts", uint(5), uint(1)).Return(nil)
+
+		resp := httptest.NewRecorder()
+		newEditLockRouter(locks).ServeHTTP(resp, httptest.NewRequest(http.MethodPatch, "/posts/5", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		locks.AssertExpectations(t)
+	})
+
+	t.Run("Locked by another user", func(t *testing.T) {
+		locks := new(mocks.MockEditLockService)
+		locks.On("CheckEditable", "posts", uint(5), uint(1)).Return(apperror.NewResourceLockedError("This record is being edited by Jane"))
+
+		resp := httptest.NewRecorder()
+		newEditLockRouter(locks).ServeHTTP(resp, httptest.NewRequest(http.MethodPatch, "/posts/5", nil))
+
+		assert.Equal(t, http.StatusLocked, resp.Code)
+		assert.JSONEq(t, `{"code":6002,"message":"This record is being edited by Jane"}`, resp.Body.String())
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		locks := new(mocks.MockEditLockService)
+
+		resp := httptest.NewRecorder()
+		newEditLockRouter(locks).ServeHTTP(resp, httptest.NewRequest(http.MethodPatch, "/posts/abc", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		locks.AssertNotCalled(t, "CheckEditable")
+	})
+}
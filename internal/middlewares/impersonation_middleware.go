@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// ImpersonationMiddleware is a Gin middleware function that rejects impersonation tokens whose session was stopped
+// It must run after AuthMiddleware. Regular tokens are passed through without a Redis lookup
+// If the session no longer exists, it returns 401 Unauthorized
+func ImpersonationMiddleware(redisService services.IRedisService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetUint("ImpersonatorID") == 0 {
+			ctx.Next()
+			return
+		}
+
+		exists, err := redisService.Exists(constants.IMPERSONATION + ctx.GetString("ImpersonationID"))
+		if err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+		if !exists {
+			utils.RespondWithError(ctx, apperror.NewUnauthorizedError("Impersonation session has ended"))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// BlockImpersonationMiddleware is a Gin middleware function that protects sensitive actions such as changing the password
+// It must run after AuthMiddleware
+// If the request is made with an impersonation token, it returns 403 Forbidden
+func BlockImpersonationMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetUint("ImpersonatorID") != 0 {
+			utils.RespondWithError(ctx, apperror.NewForbiddenError("This action is not allowed while impersonating"))
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,92 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newImpersonationRouter(impersonatorID uint, handlers ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("UserID", uint(5))
+		if impersonatorID != 0 {
+			c.Set("ImpersonatorID", impersonatorID)
+			c.Set("ImpersonationID", "session")
+		}
+	})
+	handlers = append(handlers, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "OK"})
+	})
+	router.GET("/test", handlers...)
+	return router
+}
+
+func TestImpersonationMiddleware(t *testing.T) {
+	t.Run("Regular token skips the session check", func(t *testing.T) {
+		redisService := new(mocks.MockRedisService)
+
+		resp := httptest.NewRecorder()
+		newImpersonationRouter(0, middlewares.ImpersonationMiddleware(redisService)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		redisService.AssertNotCalled(t, "Exists")
+	})
+
+	t.Run("Active session", func(t *testing.T) {
+		redisService := new(mocks.MockRedisService)
+		redisService.On("Exists", constants.IMPERSONATION+"session").Return(true, nil)
+
+		resp := httptest.NewRecorder()
+		newImpersonationRouter(1, middlewares.ImpersonationMiddleware(redisService)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		redisService.AssertExpectations(t)
+	})
+
+	t.Run("Stopped session", func(t *testing.T) {
+		redisService := new(mocks.MockRedisService)
+		redisService.On("Exists", constants.IMPERSONATION+"session").Return(false, nil)
+
+		resp := httptest.NewRecorder()
+		newImpersonationRouter(1, middlewares.ImpersonationMiddleware(redisService)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+		assert.JSONEq(t, `{"code":3000,"message":"Impersonation session has ended"}`, resp.Body.String())
+	})
+
+	t.Run("Cache error", func(t *testing.T) {
+		redisService := new(mocks.MockRedisService)
+		redisService.On("Exists", constants.IMPERSONATION+"session").Return(false, apperror.NewCacheExistsError("redis down"))
+
+		resp := httptest.NewRecorder()
+		newImpersonationRouter(1, middlewares.ImpersonationMiddleware(redisService)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestBlockImpersonationMiddleware(t *testing.T) {
+	t.Run("Allows regular tokens", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		newImpersonationRouter(0, middlewares.BlockImpersonationMiddleware()).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("Blocks impersonation tokens", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		newImpersonationRouter(1, middlewares.BlockImpersonationMiddleware()).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.JSONEq(t, `{"code":3001,"message":"This action is not allowed while impersonating"}`, resp.Body.String())
+	})
+}
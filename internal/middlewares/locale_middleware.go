@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+)
+
+// LocaleMiddleware is a Gin middleware function that selects the locale of the content returned by the public API
+// The ?locale= parameter wins over the Accept-Language header, the default locale is used when neither matches
+// It sets Locale in context and the Content-Language header of the response
+// If ?locale= is not a supported locale, it returns 400 Bad Request
+func LocaleMiddleware(locales *i18n.Locales) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		locale := locales.Negotiate(ctx.GetHeader("Accept-Language"))
+		if value := ctx.Query("locale"); value != "" {
+			normalized, ok := locales.Normalize(value)
+			if !ok {
+				utils.RespondWithError(ctx, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+					{Field: "locale", Message: fmt.Sprintf("locale must be one of %v", locales.Supported())},
+				}))
+				return
+			}
+			locale = normalized
+		}
+
+		ctx.Set("Locale", locale)
+		ctx.Header("Content-Language", locale)
+		ctx.Header("Vary", "Accept-Language")
+		ctx.Next()
+	}
+}
@@ -0,0 +1,62 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+)
+
+func newLocaleRouter(t *testing.T) *gin.Engine {
+	locales, err := i18n.NewLocales("en", []string{"vi", "pt-BR"}, nil)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/test", middlewares.LocaleMiddleware(locales), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("Locale"))
+	})
+	return router
+}
+
+func TestLocaleMiddleware(t *testing.T) {
+	t.Run("Accept-Language", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Accept-Language", "vi-VN,vi;q=0.9,en;q=0.8")
+		resp := httptest.NewRecorder()
+		newLocaleRouter(t).ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, "vi", resp.Body.String())
+		assert.Equal(t, "vi", resp.Header().Get("Content-Language"))
+	})
+
+	t.Run("Query parameter wins", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test?locale=pt_br", nil)
+		req.Header.Set("Accept-Language", "vi")
+		resp := httptest.NewRecorder()
+		newLocaleRouter(t).ServeHTTP(resp, req)
+
+		assert.Equal(t, "pt-BR", resp.Body.String())
+	})
+
+	t.Run("Default locale", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		newLocaleRouter(t).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, "en", resp.Body.String())
+	})
+
+	t.Run("Unsupported locale", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		newLocaleRouter(t).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test?locale=de", nil))
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Contains(t, resp.Body.String(), "locale must be one of")
+	})
+}
@@ -58,9 +58,13 @@ func LogMiddleware() gin.HandlerFunc {
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" || c.Request.Method == "PATCH" {
 			const maxBodySize = 1 << 16 // 64 KB
 			bodyBytes, _ := io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize))
-			c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+			// Put the logged bytes back in front of the unread remainder so larger bodies (e.g. uploads) reach the handler intact
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(bodyBytes), c.Request.Body))
 
-			if strings.Contains(c.Request.Header.Get("Content-Type"), "application/json") {
+			if strings.Contains(c.Request.Header.Get("Content-Type"), "multipart/form-data") {
+				// Do not log binary file content
+				logEntry.Request = "multipart/form-data"
+			} else if strings.Contains(c.Request.Header.Get("Content-Type"), "application/json") {
 				var requestBody any
 				if err := json.Unmarshal(bodyBytes, &requestBody); err == nil {
 					requestBody = utils.CensorSensitiveData(requestBody, sensitiveKeys)
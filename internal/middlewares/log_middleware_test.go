@@ -0,0 +1,58 @@
+package middlewares_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
+)
+
+func TestLogMiddleware_KeepsLargeBodyIntact(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.LogMiddleware())
+
+	var received []byte
+	router.PUT("/upload", func(c *gin.Context) {
+		received, _ = io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusOK, gin.H{"message": "OK"})
+	})
+
+	// Body larger than the 64KB logged by the middleware
+	payload := bytes.Repeat([]byte("a"), 1<<17)
+	req := httptest.NewRequest(http.MethodPut, "/upload", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, len(payload), len(received))
+}
+
+func TestLogMiddleware_JSONBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.LogMiddleware())
+
+	var received []byte
+	router.POST("/login", func(c *gin.Context) {
+		received, _ = io.ReadAll(c.Request.Body)
+		c.JSON(http.StatusOK, gin.H{"token": "secret"})
+	})
+
+	body := `{"email":"john@example.com","password":"password123"}`
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, body, string(received))
+}
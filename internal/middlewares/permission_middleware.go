@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// PermissionMiddleware is a Gin middleware function that only lets users holding the given permission through
+// It must run after AuthMiddleware, which sets the user ID in the context
+// If the user lacks the permission, it returns 403 Forbidden
+func PermissionMiddleware(permissionService services.IPermissionService, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userId := ctx.GetUint("UserID")
+		if userId == 0 {
+			utils.RespondWithError(ctx, apperror.NewUnauthorizedError("Unauthorized"))
+			return
+		}
+
+		allowed, err := permissionService.HasPermission(userId, permission)
+		if err != nil {
+			utils.RespondWithError(ctx, err)
+			return
+		}
+		if !allowed {
+			utils.RespondWithError(ctx, apperror.NewForbiddenError("You do not have permission to perform this action"))
+			return
+		}
+
+		ctx.Next()
+	}
+}
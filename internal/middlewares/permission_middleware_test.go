@@ -0,0 +1,88 @@
+package middlewares_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newPermissionRouter(permissionService *mocks.MockPermissionService, userID uint) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set("UserID", userID)
+		}
+	})
+	router.GET("/test", middlewares.PermissionMiddleware(permissionService, "attributes.manage"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "OK"})
+	})
+	return router
+}
+
+func TestPermissionMiddleware(t *testing.T) {
+	t.Run("Allowed", func(t *testing.T) {
+		permissionService := new(mocks.MockPermissionService)
+		permissionService.On("HasPermission", uint(1), "attributes.manage").Return(true, nil)
+
+		resp := httptest.NewRecorder()
+		newPermissionRouter(permissionService, 1).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		permissionService.AssertExpectations(t)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		permissionService := new(mocks.MockPermissionService)
+		permissionService.On("HasPermission", uint(2), "attributes.manage").Return(false, nil)
+
+		resp := httptest.NewRecorder()
+		newPermissionRouter(permissionService, 2).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+		assert.JSONEq(t, `{"code":3001,"message":"You do not have permission to perform this action"}`, resp.Body.String())
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		newPermissionRouter(new(mocks.MockPermissionService), 0).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		permissionService := new(mocks.MockPermissionService)
+		permissionService.On("HasPermission", uint(1), "attributes.manage").Return(false, apperror.NewDBQueryError(errors.New("db error").Error()))
+
+		resp := httptest.NewRecorder()
+		newPermissionRouter(permissionService, 1).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	})
+}
+
+func TestPermissionMiddlewareListUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	permissionService := new(mocks.MockPermissionService)
+	permissionService.On("HasPermission", uint(3), constants.PermissionViewUsers).Return(false, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) { c.Set("UserID", uint(3)) })
+	router.GET("/users", middlewares.PermissionMiddleware(permissionService, constants.PermissionViewUsers), func(c *gin.Context) {
+		t.Fatal("the users must not be listed without permission")
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/users?department=sales", nil))
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	permissionService.AssertExpectations(t)
+}
@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+// RedirectRoute maps the paths of the website to the route of the public API serving them
+type RedirectRoute struct {
+	SitePrefix string // Prefix of the website paths, e.g. "/posts/"
+	APIPrefix  string // Prefix of the API route, e.g. "/api/v1/public/posts/"
+}
+
+// RedirectMiddleware is a Gin middleware function that answers the old URLs of moved content with a redirect
+// Parameters:
+//   - redirects: Resolves the redirects of the website paths
+//   - routes: Every route of the public API serving website paths, the longest matching prefix wins
+//
+// The middleware:
+//  1. Lets the handlers answer first, so content living at a path always wins over a redirect
+//  2. Maps the path of a 404 Not Found request to the path of the website and looks up its redirect
+//  3. Answers with the status code of the redirect and the API URL of its target, absolute URLs are sent as they are
+func RedirectMiddleware(redirects services.IRedirectService, routes ...RedirectRoute) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			ctx.Next()
+			return
+		}
+
+		writer := ctx.Writer
+		buffer := &responseBuffer{ResponseWriter: writer, status: http.StatusOK}
+		ctx.Writer = buffer
+		ctx.Next()
+		ctx.Writer = writer
+
+		if buffer.status == http.StatusNotFound {
+			if path, ok := sitePath(ctx.Request.URL.Path, routes); ok {
+				if redirect, err := redirects.Resolve(path); err == nil {
+					location := apiLocation(redirect.ToPath, routes)
+					if query := ctx.Request.URL.RawQuery; query != "" {
+						if strings.Contains(location, "?") {
+							location += "&" + query
+						} else {
+							location += "?" + query
+						}
+					}
+					// The body of the 404 response is dropped with its content type
+					writer.Header().Del("Content-Type")
+					writer.Header().Set("Location", location)
+					writer.WriteHeader(redirect.StatusCode)
+					writer.WriteHeaderNow()
+					return
+				}
+			}
+		}
+
+		writer.WriteHeader(buffer.status)
+		_, _ = writer.Write(buffer.body.Bytes())
+	}
+}
+
+// sitePath returns the path of the website served by a path of the public API
+func sitePath(path string, routes []RedirectRoute) (string, bool) {
+	var match *RedirectRoute
+	for i, route := range routes {
+		if strings.HasPrefix(path, route.APIPrefix) && (match == nil || len(route.APIPrefix) > len(match.APIPrefix)) {
+			match = &routes[i]
+		}
+	}
+	if match == nil {
+		return "", false
+	}
+	return match.SitePrefix + strings.TrimPrefix(path, match.APIPrefix), true
+}
+
+// apiLocation returns the URL of the public API serving the target of a redirect
+func apiLocation(target string, routes []RedirectRoute) string {
+	var match *RedirectRoute
+	for i, route := range routes {
+		if strings.HasPrefix(target, route.SitePrefix) && (match == nil || len(route.SitePrefix) > len(match.SitePrefix)) {
+			match = &routes[i]
+		}
+	}
+	if match == nil {
+		return target
+	}
+	return match.APIPrefix + strings.TrimPrefix(target, match.SitePrefix)
+}
@@ -0,0 +1,104 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func newRedirectRouter(redirects *mocks.MockRedirectService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	redirected := middlewares.RedirectMiddleware(redirects,
+		middlewares.RedirectRoute{SitePrefix: "/posts/", APIPrefix: "/api/posts/"},
+		middlewares.RedirectRoute{SitePrefix: "/categories/", APIPrefix: "/api/posts?category="},
+		middlewares.RedirectRoute{SitePrefix: "/", APIPrefix: "/api/pages/"},
+	)
+	router.GET("/api/posts/:slug", redirected, func(c *gin.Context) {
+		if c.Param("slug") != "live" {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Post not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"slug": c.Param("slug")})
+	})
+	router.GET("/api/pages/*path", redirected, func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Page not found"})
+	})
+	return router
+}
+
+func TestRedirectMiddleware(t *testing.T) {
+	t.Run("Live content wins", func(t *testing.T) {
+		redirects := new(mocks.MockRedirectService)
+		router := newRedirectRouter(redirects)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/posts/live", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), `"slug":"live"`)
+		redirects.AssertNotCalled(t, "Resolve", mock.Anything)
+	})
+
+	t.Run("Redirects old post slug keeping the query", func(t *testing.T) {
+		redirects := new(mocks.MockRedirectService)
+		router := newRedirectRouter(redirects)
+		redirects.On("Resolve", "/posts/old").Return(&models.Redirect{ToPath: "/posts/live", StatusCode: 301}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/posts/old?locale=vi", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusMovedPermanently, resp.Code)
+		assert.Equal(t, "/api/posts/live?locale=vi", resp.Header().Get("Location"))
+		assert.Empty(t, resp.Body.String())
+	})
+
+	t.Run("Redirects page to a category", func(t *testing.T) {
+		redirects := new(mocks.MockRedirectService)
+		router := newRedirectRouter(redirects)
+		redirects.On("Resolve", "/about/news").Return(&models.Redirect{ToPath: "/categories/news", StatusCode: 302}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pages/about/news?locale=vi", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusFound, resp.Code)
+		assert.Equal(t, "/api/posts?category=news&locale=vi", resp.Header().Get("Location"))
+	})
+
+	t.Run("Absolute URL target", func(t *testing.T) {
+		redirects := new(mocks.MockRedirectService)
+		router := newRedirectRouter(redirects)
+		redirects.On("Resolve", "/shop").Return(&models.Redirect{ToPath: "https://shop.example.com/", StatusCode: 308}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/pages/shop", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusPermanentRedirect, resp.Code)
+		assert.Equal(t, "https://shop.example.com/", resp.Header().Get("Location"))
+	})
+
+	t.Run("Not redirected", func(t *testing.T) {
+		redirects := new(mocks.MockRedirectService)
+		router := newRedirectRouter(redirects)
+		redirects.On("Resolve", "/posts/missing").Return(nil, apperror.NewNotFoundError("Redirect not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/posts/missing", nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Contains(t, resp.Body.String(), "Post not found")
+	})
+}
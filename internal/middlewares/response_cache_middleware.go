@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+// responseBuffer holds the response written by the handlers so headers can still be set once it is complete
+type responseBuffer struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseBuffer) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *responseBuffer) WriteHeaderNow() {}
+
+func (w *responseBuffer) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *responseBuffer) WriteString(data string) (int, error) {
+	return w.body.WriteString(data)
+}
+
+func (w *responseBuffer) Status() int {
+	return w.status
+}
+
+func (w *responseBuffer) Size() int {
+	return w.body.Len()
+}
+
+func (w *responseBuffer) Written() bool {
+	return w.body.Len() > 0
+}
+
+// ResponseCacheMiddleware is a Gin middleware function that adds HTTP caching to the read-only routes of the delivery API
+// Parameters:
+//   - cache: Stores the rendered responses, a failing cache only costs the rendering
+//   - maxAge: The max-age of the Cache-Control header, how long clients and proxies may reuse a response
+//   - tags: The tags of the content shown by the route, e.g. services.CacheTagPosts, a change of this content drops the response
+//
+// The middleware:
+//  1. Replays the cached response of the URL in the locale set by the LocaleMiddleware, or renders it with the handlers
+//  2. Sets the ETag and Last-Modified headers of successful responses and caches them
+//  3. Answers 304 Not Modified when If-None-Match, or else If-Modified-Since, matches the response
+func ResponseCacheMiddleware(cache services.IResponseCacheService, maxAge time.Duration, tags ...string) gin.HandlerFunc {
+	cacheControl := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+			ctx.Next()
+			return
+		}
+
+		sum := sha256.Sum256([]byte(ctx.GetString("Locale") + " " + ctx.Request.URL.RequestURI()))
+		key := hex.EncodeToString(sum[:])
+
+		cached, err := cache.Get(key)
+		if err != nil {
+			logger.Warnf("Failed to get cached response: %v", err)
+		}
+		if cached != nil {
+			ctx.Header("X-Cache", "HIT")
+			writeCachedResponse(ctx, cached, cacheControl)
+			ctx.Abort()
+			return
+		}
+
+		writer := ctx.Writer
+		buffer := &responseBuffer{ResponseWriter: writer, status: http.StatusOK}
+		ctx.Writer = buffer
+		ctx.Next()
+		ctx.Writer = writer
+
+		// Errors are neither cached nor revalidated
+		if buffer.status != http.StatusOK {
+			writer.WriteHeader(buffer.status)
+			_, _ = writer.Write(buffer.body.Bytes())
+			return
+		}
+
+		// Handlers knowing when their content last changed set Last-Modified themselves, e.g. the feeds
+		lastModified, err := http.ParseTime(writer.Header().Get("Last-Modified"))
+		if err != nil {
+			lastModified = time.Now().UTC().Truncate(time.Second)
+		}
+
+		sum = sha256.Sum256(buffer.body.Bytes())
+		response := &services.CachedResponse{
+			Status:       buffer.status,
+			ContentType:  writer.Header().Get("Content-Type"),
+			Body:         buffer.body.Bytes(),
+			ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+			LastModified: lastModified.UTC(),
+		}
+		if err := cache.Set(key, response, tags); err != nil {
+			logger.Warnf("Failed to cache response: %v", err)
+		}
+
+		ctx.Header("X-Cache", "MISS")
+		writeCachedResponse(ctx, response, cacheControl)
+	}
+}
+
+// writeCachedResponse writes a response with its validators, or 304 Not Modified when the client already has it
+func writeCachedResponse(ctx *gin.Context, response *services.CachedResponse, cacheControl string) {
+	ctx.Header("ETag", response.ETag)
+	ctx.Header("Last-Modified", response.LastModified.Format(http.TimeFormat))
+	ctx.Header("Cache-Control", cacheControl)
+
+	if notModified(ctx.Request, response) {
+		ctx.Writer.WriteHeader(http.StatusNotModified)
+		ctx.Writer.WriteHeaderNow()
+		return
+	}
+
+	ctx.Header("Content-Type", response.ContentType)
+	ctx.Writer.WriteHeader(response.Status)
+	if ctx.Request.Method == http.MethodHead {
+		ctx.Writer.WriteHeaderNow()
+		return
+	}
+	_, _ = ctx.Writer.Write(response.Body)
+}
+
+// notModified reports whether the conditional headers of a request match a response
+// If-Modified-Since is ignored when If-None-Match is present, as required by RFC 9110
+func notModified(request *http.Request, response *services.CachedResponse) bool {
+	if header := request.Header.Get("If-None-Match"); header != "" {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == response.ETag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := request.Header.Get("If-Modified-Since"); header != "" {
+		since, err := http.ParseTime(header)
+		return err == nil && !response.LastModified.After(since)
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+// newResponseCacheRouter returns a router counting the renderings of its routes
+func newResponseCacheRouter(t *testing.T) (*gin.Engine, *services.ResponseCacheService, *int) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	cache := services.NewResponseCacheService(client, time.Minute)
+
+	renders := 0
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	cached := middlewares.ResponseCacheMiddleware(cache, time.Minute, services.CacheTagPosts)
+	router.GET("/posts/:slug", func(c *gin.Context) {
+		c.Set("Locale", c.Query("locale"))
+	}, cached, func(c *gin.Context) {
+		renders++
+		if c.Param("slug") == "dated" {
+			c.Header("Last-Modified", "Wed, 02 Jan 2030 03:04:05 GMT")
+		}
+		if c.Param("slug") == "missing" {
+			c.JSON(http.StatusNotFound, gin.H{"message": "Post not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"slug": c.Param("slug")})
+	})
+	return router, cache, &renders
+}
+
+func getPost(router *gin.Engine, url string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestResponseCacheMiddleware(t *testing.T) {
+	t.Run("Caches successful responses", func(t *testing.T) {
+		router, _, renders := newResponseCacheRouter(t)
+
+		first := getPost(router, "/posts/hello", nil)
+		second := getPost(router, "/posts/hello", nil)
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+		assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+		assert.JSONEq(t, `{"slug":"hello"}`, second.Body.String())
+		assert.Equal(t, "application/json; charset=utf-8", second.Header().Get("Content-Type"))
+		assert.Equal(t, "public, max-age=60", second.Header().Get("Cache-Control"))
+		assert.Equal(t, first.Header().Get("ETag"), second.Header().Get("ETag"))
+		assert.NotEmpty(t, first.Header().Get("Last-Modified"))
+		assert.Equal(t, 1, *renders)
+	})
+
+	t.Run("Separates locales", func(t *testing.T) {
+		router, _, renders := newResponseCacheRouter(t)
+
+		getPost(router, "/posts/hello", nil)
+		resp := getPost(router, "/posts/hello?locale=vi", nil)
+
+		assert.Equal(t, "MISS", resp.Header().Get("X-Cache"))
+		assert.Equal(t, 2, *renders)
+	})
+
+	t.Run("If-None-Match", func(t *testing.T) {
+		router, _, _ := newResponseCacheRouter(t)
+		etag := getPost(router, "/posts/hello", nil).Header().Get("ETag")
+
+		resp := getPost(router, "/posts/hello", map[string]string{"If-None-Match": `"other", W/` + etag})
+		assert.Equal(t, http.StatusNotModified, resp.Code)
+		assert.Empty(t, resp.Body.String())
+		assert.Equal(t, etag, resp.Header().Get("ETag"))
+
+		resp = getPost(router, "/posts/hello", map[string]string{"If-None-Match": `"other"`})
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("If-Modified-Since", func(t *testing.T) {
+		router, _, _ := newResponseCacheRouter(t)
+		lastModified := getPost(router, "/posts/hello", nil).Header().Get("Last-Modified")
+
+		resp := getPost(router, "/posts/hello", map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusNotModified, resp.Code)
+
+		earlier := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+		resp = getPost(router, "/posts/hello", map[string]string{"If-Modified-Since": earlier})
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		// If-None-Match takes precedence
+		resp = getPost(router, "/posts/hello", map[string]string{"If-Modified-Since": lastModified, "If-None-Match": `"other"`})
+		assert.Equal(t, http.StatusOK, resp.Code)
+	})
+
+	t.Run("Keeps Last-Modified of the handler", func(t *testing.T) {
+		router, _, _ := newResponseCacheRouter(t)
+
+		getPost(router, "/posts/dated", nil)
+		resp := getPost(router, "/posts/dated", nil)
+		assert.Equal(t, "HIT", resp.Header().Get("X-Cache"))
+		assert.Equal(t, "Wed, 02 Jan 2030 03:04:05 GMT", resp.Header().Get("Last-Modified"))
+
+		resp = getPost(router, "/posts/dated", map[string]string{"If-Modified-Since": "Wed, 02 Jan 2030 03:04:05 GMT"})
+		assert.Equal(t, http.StatusNotModified, resp.Code)
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		router, _, renders := newResponseCacheRouter(t)
+
+		getPost(router, "/posts/missing", nil)
+		resp := getPost(router, "/posts/missing", nil)
+
+		assert.Equal(t, http.StatusNotFound, resp.Code)
+		assert.Contains(t, resp.Body.String(), "Post not found")
+		assert.Empty(t, resp.Header().Get("ETag"))
+		assert.Equal(t, 2, *renders)
+	})
+
+	t.Run("Invalidation renders again", func(t *testing.T) {
+		router, cache, renders := newResponseCacheRouter(t)
+
+		getPost(router, "/posts/hello", nil)
+		assert.NoError(t, cache.Invalidate(services.CacheTagPosts))
+		resp := getPost(router, "/posts/hello", nil)
+
+		assert.Equal(t, "MISS", resp.Header().Get("X-Cache"))
+		assert.Equal(t, 2, *renders)
+	})
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+// Types supported by custom user attributes
+const (
+	AttributeTypeText    = "text"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeDate    = "date" // Format: YYYY-MM-DD
+	AttributeTypeSelect  = "select"
+)
+
+// AttributeRules holds the optional validation rules of an attribute definition
+type AttributeRules struct {
+	MinLength *int     `json:"minLength,omitempty"` // text: minimum number of characters
+	MaxLength *int     `json:"maxLength,omitempty"` // text: maximum number of characters
+	Pattern   string   `json:"pattern,omitempty"`   // text: regular expression the value must match
+	Min       *float64 `json:"min,omitempty"`       // number: minimum value
+	Max       *float64 `json:"max,omitempty"`       // number: maximum value
+	Options   []string `json:"options,omitempty"`   // select: allowed values
+}
+
+// AttributeDefinition describes a custom field that can be stored for every user (e.g. department, employee ID)
+type AttributeDefinition struct {
+	ID        uint           `gorm:"column:id;primaryKey" json:"id"`
+	Name      string         `gorm:"column:name;type:varchar(45);unique;not null" json:"name"` // Machine name used as key in API payloads
+	Label     string         `gorm:"column:label;type:varchar(100);not null" json:"label"`
+	Type      string         `gorm:"column:type;type:varchar(20);not null" json:"type"`
+	Required  bool           `gorm:"column:required;not null;default:false" json:"required"`
+	Rules     AttributeRules `gorm:"column:rules;type:json;serializer:json" json:"rules"`
+	SortOrder int            `gorm:"column:sort_order;not null;default:0" json:"sortOrder"`
+	CreatedAt time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+// UserAttribute stores the value of a custom attribute for a single user
+// Values are kept in their canonical string form, see services.AttributeService
+type UserAttribute struct {
+	ID                    uint      `gorm:"column:id;primaryKey" json:"id"`
+	UserID                uint      `gorm:"column:user_id;not null;uniqueIndex:uni_user_attributes_user_definition" json:"userId"`
+	AttributeDefinitionID uint      `gorm:"column:attribute_definition_id;not null;uniqueIndex:uni_user_attributes_user_definition" json:"attributeDefinitionId"`
+	Value                 string    `gorm:"column:value;type:text;not null" json:"value"`
+	CreatedAt             time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt             time.Time `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	User       User                `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID" json:"-"`
+	Definition AttributeDefinition `gorm:"constraint:OnDelete:CASCADE;foreignKey:AttributeDefinitionID" json:"definition"`
+}
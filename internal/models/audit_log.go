@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// Actions recorded in the audit trail
+const (
+	AuditActionImpersonationStart = "impersonation.start"
+	AuditActionImpersonationStop  = "impersonation.stop"
+	AuditActionDataExport         = "privacy.data_export"
+	AuditActionDeletionRequest    = "privacy.deletion_request"
+	AuditActionDeletionCancel     = "privacy.deletion_cancel"
+	AuditActionErasure            = "privacy.erasure"
+)
+
+// Kinds of records an audit log entry can apply to
+const (
+	AuditSubjectUser = "user"
+)
+
+// AuditLog records a security relevant action performed by a user
+type AuditLog struct {
+	ID          uint           `gorm:"column:id;primaryKey" json:"id"`
+	ActorID     uint           `gorm:"column:actor_id;not null;index" json:"actorId"`                           // User who performed the action
+	Action      string         `gorm:"column:action;type:varchar(100);not null;index" json:"action"`            // e.g. impersonation.start
+	SubjectType string         `gorm:"column:subject_type;type:varchar(45);not null" json:"subjectType"`        // Kind of record the action applies to, e.g. user
+	SubjectID   uint           `gorm:"column:subject_id;not null" json:"subjectId"`                             // ID of the record the action applies to
+	Metadata    map[string]any `gorm:"column:metadata;type:json;serializer:json" json:"metadata,omitempty"`     // Additional details of the action
+	IpAddress   string         `gorm:"column:ip_address;type:varchar(45);not null;default:''" json:"ipAddress"` // IP address the action was performed from
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"createdAt"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+// Statuses of a comment in the moderation queue, only approved comments are visible on the public API
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusSpam     = "spam"
+	CommentStatusRejected = "rejected"
+)
+
+// CommentStatuses lists every status of the moderation queue
+var CommentStatuses = []string{
+	CommentStatusPending,
+	CommentStatusApproved,
+	CommentStatusSpam,
+	CommentStatusRejected,
+}
+
+// Moderation actions applied to comments in bulk
+const (
+	CommentActionApprove = "approve" // Publish the comments
+	CommentActionSpam    = "spam"    // Mark the comments as spam
+	CommentActionReject  = "reject"  // Hide the comments without marking them as spam
+	CommentActionRequeue = "requeue" // Put the comments back in the moderation queue
+	CommentActionDelete  = "delete"  // Remove the comments together with their replies
+)
+
+// Comment is a reply to a post or to another comment, written by a user or by a guest
+type Comment struct {
+	ID          uint       `gorm:"column:id;primaryKey" json:"id"`
+	PostID      uint       `gorm:"column:post_id;not null;index" json:"postId"`
+	ParentID    *uint      `gorm:"column:parent_id;default:null;index" json:"parentId,omitempty"`
+	RootID      *uint      `gorm:"column:root_id;default:null;index" json:"rootId,omitempty"`     // First comment of the thread, empty for the first comment itself
+	AuthorID    *uint      `gorm:"column:author_id;default:null;index" json:"authorId,omitempty"` // Empty for guests
+	GuestName   *string    `gorm:"column:guest_name;type:varchar(100);default:null" json:"guestName,omitempty"`
+	GuestEmail  *string    `gorm:"column:guest_email;type:varchar(255);default:null" json:"guestEmail,omitempty"`
+	Body        string     `gorm:"column:body;type:text;not null" json:"body"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null;index" json:"status"`
+	SpamScore   int        `gorm:"column:spam_score;not null;default:0" json:"spamScore"` // Number of spam signals found when the comment was posted
+	IPAddress   string     `gorm:"column:ip_address;type:varchar(45);not null" json:"ipAddress"`
+	UserAgent   string     `gorm:"column:user_agent;type:varchar(255);not null" json:"userAgent"`
+	ModeratorID *uint      `gorm:"column:moderator_id;default:null" json:"moderatorId,omitempty"` // Last user who changed the status
+	ModeratedAt *time.Time `gorm:"column:moderated_at;default:null" json:"moderatedAt,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at;index" json:"createdAt"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Post      *Post     `gorm:"constraint:OnDelete:CASCADE;foreignKey:PostID" json:"post,omitempty"`
+	Parent    *Comment  `gorm:"constraint:OnDelete:CASCADE;foreignKey:ParentID" json:"-"`
+	Author    *User     `gorm:"constraint:OnDelete:SET NULL;foreignKey:AuthorID" json:"author,omitempty"`
+	Moderator *User     `gorm:"constraint:OnDelete:SET NULL;foreignKey:ModeratorID" json:"-"`
+	Replies   []Comment `gorm:"-" json:"replies,omitempty"` // Filled when the thread is built
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+)
+
+// Types of the fields of a content type
+const (
+	ContentFieldText      = "text"
+	ContentFieldRichText  = "rich_text" // HTML or Markdown written in the editor
+	ContentFieldNumber    = "number"
+	ContentFieldDate      = "date" // Format: YYYY-MM-DD
+	ContentFieldBoolean   = "boolean"
+	ContentFieldReference = "reference" // ID of an entry of the content type named in the rules
+	ContentFieldMedia     = "media"     // ID of a file of the media library
+	ContentFieldList      = "list"      // Values of the item type named in the rules
+)
+
+// ContentFieldTypes lists every type a field of a content type can have
+var ContentFieldTypes = []string{
+	ContentFieldText,
+	ContentFieldRichText,
+	ContentFieldNumber,
+	ContentFieldDate,
+	ContentFieldBoolean,
+	ContentFieldReference,
+	ContentFieldMedia,
+	ContentFieldList,
+}
+
+// ContentFieldRules holds the optional validation rules of a field
+type ContentFieldRules struct {
+	MinLength *int     `json:"minLength,omitempty"` // text, rich_text: minimum number of characters
+	MaxLength *int     `json:"maxLength,omitempty"` // text, rich_text: maximum number of characters
+	Pattern   string   `json:"pattern,omitempty"`   // text: regular expression the value must match
+	Options   []string `json:"options,omitempty"`   // text: allowed values
+	Min       *float64 `json:"min,omitempty"`       // number: minimum value
+	Max       *float64 `json:"max,omitempty"`       // number: maximum value
+	Integer   bool     `json:"integer,omitempty"`   // number: only whole numbers are accepted
+	Target    string   `json:"target,omitempty"`    // reference: slug of the referenced content type
+	ItemType  string   `json:"itemType,omitempty"`  // list: type of the items, any type but list
+	MinItems  *int     `json:"minItems,omitempty"`  // list: minimum number of items
+	MaxItems  *int     `json:"maxItems,omitempty"`  // list: maximum number of items
+}
+
+// ContentField describes a field of the entries of a content type
+type ContentField struct {
+	Name     string            `json:"name"` // Key of the value in the data of the entries
+	Label    string            `json:"label"`
+	Type     string            `json:"type"`
+	Required bool              `json:"required"`
+	Rules    ContentFieldRules `json:"rules"`
+}
+
+// ContentType is a schema defined by admins at runtime, e.g. "product" or "event", whose entries are managed through /content/:type
+type ContentType struct {
+	ID          uint           `gorm:"column:id;primaryKey" json:"id"`
+	Slug        string         `gorm:"column:slug;type:varchar(45);unique;not null" json:"slug"` // Name used in the URLs, cannot be changed
+	Name        string         `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Description *string        `gorm:"column:description;type:varchar(500);default:null" json:"description,omitempty"`
+	Fields      []ContentField `gorm:"column:fields;type:json;serializer:json" json:"fields"`
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+// ContentEntry is a record of a content type, its data is validated against the fields of the type on write
+type ContentEntry struct {
+	ID            uint           `gorm:"column:id;primaryKey" json:"id"`
+	ContentTypeID uint           `gorm:"column:content_type_id;not null;index" json:"contentTypeId"`
+	Data          map[string]any `gorm:"column:data;type:json;serializer:json" json:"data"` // Values keyed by field name
+	AuthorID      *uint          `gorm:"column:author_id;default:null;index" json:"authorId,omitempty"`
+	CreatedAt     time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt     time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+	Version       uint           `gorm:"column:version;not null;default:1" json:"version"`
+
+	// Relations
+	ContentType *ContentType `gorm:"constraint:OnDelete:CASCADE;foreignKey:ContentTypeID" json:"-"`
+	Author      *User        `gorm:"constraint:OnDelete:SET NULL;foreignKey:AuthorID" json:"-"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// Statuses of a data export request
+const (
+	DataExportStatusPending    = "pending"
+	DataExportStatusProcessing = "processing"
+	DataExportStatusCompleted  = "completed"
+	DataExportStatusFailed     = "failed"
+	DataExportStatusExpired    = "expired"
+)
+
+// DataExport is a request of a user for an archive of all personal data we hold about them
+type DataExport struct {
+	ID          uint       `gorm:"column:id;primaryKey" json:"id"`
+	UserID      uint       `gorm:"column:user_id;not null;index" json:"userId"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null;index" json:"status"`
+	FileKey     *string    `gorm:"column:file_key;type:varchar(255);default:null" json:"-"` // Storage key of the archive
+	FileSize    int64      `gorm:"column:file_size;not null;default:0" json:"fileSize"`
+	Error       *string    `gorm:"column:error;type:varchar(255);default:null" json:"error,omitempty"`
+	CompletedAt *time.Time `gorm:"column:completed_at;default:null" json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at;default:null" json:"expiresAt,omitempty"` // The archive is deleted after this time
+	CreatedAt   time.Time  `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at" json:"updatedAt"`
+}
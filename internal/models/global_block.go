@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+)
+
+// GlobalBlock is a reusable group of blocks referenced from the bodies of many posts and pages
+// The references are resolved when the bodies are delivered, so an edit shows everywhere at once
+type GlobalBlock struct {
+	ID          uint           `gorm:"column:id;primaryKey" json:"id"`
+	Name        string         `gorm:"column:name;type:varchar(100);not null;unique" json:"name"`
+	Description *string        `gorm:"column:description;type:varchar(500);default:null" json:"description,omitempty"`
+	Blocks      []blocks.Block `gorm:"column:blocks;type:json;serializer:json" json:"blocks"` // Cannot reference other global blocks
+	CreatedAt   time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Kinds of records created by an import
+const (
+	ImportKindUser     = "user"
+	ImportKindCategory = "category"
+	ImportKindTag      = "tag"
+	ImportKindMedia    = "media"
+	ImportKindPage     = "page"
+	ImportKindPost     = "post"
+	ImportKindComment  = "comment"
+)
+
+// ImportRecord maps a record of an imported site to the record created or matched for it
+// Running an import again skips the records it already maps, even when they were edited or deleted since
+type ImportRecord struct {
+	ID        uint      `gorm:"column:id;primaryKey" json:"id"`
+	Source    string    `gorm:"column:source;type:varchar(255);not null;uniqueIndex:uni_import_records_source_kind_id" json:"source"` // URL of the imported site
+	Kind      string    `gorm:"column:kind;type:varchar(20);not null;uniqueIndex:uni_import_records_source_kind_id" json:"kind"`
+	SourceID  int64     `gorm:"column:source_id;not null;uniqueIndex:uni_import_records_source_kind_id" json:"sourceId"` // ID of the record on the imported site
+	TargetID  uint      `gorm:"column:target_id;not null" json:"targetId"`                                               // ID of the record of the kind in this application
+	CreatedAt time.Time `gorm:"column:created_at" json:"createdAt"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// Statuses of an invitation, a pending invitation past its expiry time can no longer be accepted
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// Invitation lets an invited user choose their password and activate their account
+type Invitation struct {
+	ID         uint       `gorm:"column:id;primaryKey" json:"id"`
+	UserID     uint       `gorm:"column:user_id;not null;index" json:"userId"`
+	InvitedBy  uint       `gorm:"column:invited_by;not null" json:"invitedBy"`                 // User who sent the invitation
+	TokenHash  string     `gorm:"column:token_hash;type:varchar(64);not null;unique" json:"-"` // SHA-256 of the token sent by email
+	Status     string     `gorm:"column:status;type:varchar(20);not null;index" json:"status"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null" json:"expiresAt"`
+	SentAt     time.Time  `gorm:"column:sent_at;not null" json:"sentAt"` // Last time the invitation email was sent
+	AcceptedAt *time.Time `gorm:"column:accepted_at;default:null" json:"acceptedAt,omitempty"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at;default:null" json:"revokedAt,omitempty"`
+	CreatedAt  time.Time  `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	User *User `gorm:"constraint:OnDelete:CASCADE;foreignKey:UserID" json:"user,omitempty"`
+}
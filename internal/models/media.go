@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// Names of the resized copies generated for uploaded images
+const (
+	MediaVariantThumbnail = "thumbnail"
+	MediaVariantMedium    = "medium"
+	MediaVariantLarge     = "large"
+)
+
+// MediaVariant is a resized copy of an uploaded image
+type MediaVariant struct {
+	Key    string `json:"key"` // Storage key of the file
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// MediaFolder organizes media files in a tree, a folder without parent is at the root of the library
+type MediaFolder struct {
+	ID        uint      `gorm:"column:id;primaryKey" json:"id"`
+	ParentID  *uint     `gorm:"column:parent_id;default:null;index" json:"parentId,omitempty"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Parent *MediaFolder `gorm:"constraint:OnDelete:RESTRICT;foreignKey:ParentID" json:"-"`
+}
+
+// Media is a file uploaded to the media library
+type Media struct {
+	ID         uint                    `gorm:"column:id;primaryKey" json:"id"`
+	FolderID   *uint                   `gorm:"column:folder_id;default:null;index" json:"folderId,omitempty"`
+	UploaderID *uint                   `gorm:"column:uploader_id;default:null;index" json:"uploaderId,omitempty"`
+	FileName   string                  `gorm:"column:file_name;type:varchar(255);not null" json:"fileName"` // Name of the file on the uploader's device
+	Key        string                  `gorm:"column:key;type:varchar(500);not null" json:"key"`            // Storage key of the original file
+	URL        string                  `gorm:"column:url;type:varchar(1000);not null" json:"url"`
+	MimeType   string                  `gorm:"column:mime_type;type:varchar(100);not null;index" json:"mimeType"` // Sniffed from the file content
+	Size       int64                   `gorm:"column:size;not null" json:"size"`                                  // Size of the original file in bytes
+	Checksum   string                  `gorm:"column:checksum;type:char(64);not null;unique" json:"checksum"`     // SHA-256 of the content, identical uploads are stored once
+	Width      *int                    `gorm:"column:width;default:null" json:"width,omitempty"`                  // Images only
+	Height     *int                    `gorm:"column:height;default:null" json:"height,omitempty"`                // Images only
+	AltText    *string                 `gorm:"column:alt_text;type:varchar(255);default:null" json:"altText,omitempty"`
+	Caption    *string                 `gorm:"column:caption;type:varchar(1000);default:null" json:"caption,omitempty"`
+	Variants   map[string]MediaVariant `gorm:"column:variants;type:json;serializer:json" json:"variants,omitempty"` // Keyed by variant name, images only
+	CreatedAt  time.Time               `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt  time.Time               `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Folder   *MediaFolder `gorm:"constraint:OnDelete:SET NULL;foreignKey:FolderID" json:"folder,omitempty"`
+	Uploader *User        `gorm:"constraint:OnDelete:SET NULL;foreignKey:UploaderID" json:"-"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Types of menu items, every type except url links to a record of the CMS
+const (
+	MenuItemTypePage     = "page"
+	MenuItemTypePost     = "post"
+	MenuItemTypeCategory = "category"
+	MenuItemTypeURL      = "url" // External or custom URL
+)
+
+// MenuItemTypes lists every type of menu item
+var MenuItemTypes = []string{
+	MenuItemTypePage,
+	MenuItemTypePost,
+	MenuItemTypeCategory,
+	MenuItemTypeURL,
+}
+
+// Menu is a navigation menu such as the main menu or the footer, rendered by the frontend through its handle
+type Menu struct {
+	ID        uint      `gorm:"column:id;primaryKey" json:"id"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Handle    string    `gorm:"column:handle;type:varchar(100);not null;unique" json:"handle"` // Key used by the public API, e.g. "main"
+	CreatedAt time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Items []MenuItem `gorm:"-" json:"items,omitempty"` // Filled when the item tree is built
+}
+
+// MenuItem is an entry of a menu, items are nested under other items of the same menu
+type MenuItem struct {
+	ID           uint      `gorm:"column:id;primaryKey" json:"id"`
+	MenuID       uint      `gorm:"column:menu_id;not null;index" json:"menuId"`
+	ParentID     *uint     `gorm:"column:parent_id;default:null;index" json:"parentId,omitempty"`
+	Label        *string   `gorm:"column:label;type:varchar(255);default:null" json:"label,omitempty"` // Defaults to the title of the target
+	Type         string    `gorm:"column:type;type:varchar(20);not null" json:"type"`
+	TargetID     *uint     `gorm:"column:target_id;default:null" json:"targetId,omitempty"` // ID of the page, post or category
+	URL          *string   `gorm:"column:url;type:varchar(1000);default:null" json:"url,omitempty"`
+	OpenInNewTab bool      `gorm:"column:open_in_new_tab;not null;default:false" json:"openInNewTab"`
+	Position     int       `gorm:"column:position;not null;default:0" json:"position"` // Order of the item among its siblings
+	CreatedAt    time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt    time.Time `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Menu     *Menu      `gorm:"constraint:OnDelete:CASCADE;foreignKey:MenuID" json:"-"`
+	Parent   *MenuItem  `gorm:"constraint:OnDelete:CASCADE;foreignKey:ParentID" json:"-"`
+	Children []MenuItem `gorm:"-" json:"children,omitempty"` // Filled when the tree is built
+}
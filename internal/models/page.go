@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// Statuses of a page, only published pages are visible on the public API
+const (
+	PageStatusDraft     = "draft"
+	PageStatusPublished = "published"
+)
+
+// PageTemplateDefault is the layout key of pages created without a template
+const PageTemplateDefault = "default"
+
+// Page is a static page such as "About us", pages are nested to build hierarchical URLs like /about/team
+type Page struct {
+	ID         uint      `gorm:"column:id;primaryKey" json:"id"`
+	ParentID   *uint     `gorm:"column:parent_id;default:null;index" json:"parentId,omitempty"`
+	Title      string    `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	Slug       string    `gorm:"column:slug;type:varchar(255);not null" json:"slug"`                                      // Last segment of the path, unique among siblings
+	Path       string    `gorm:"column:path;type:varchar(700);not null;unique" json:"path"`                               // Slugs of the ancestors and the page joined by "/", e.g. "about/team"
+	Body       string    `gorm:"column:body;type:longtext;not null" json:"body,omitempty"`                                // Left out when the page tree is listed
+	BodyFormat string    `gorm:"column:body_format;type:varchar(20);not null;default:'html'" json:"bodyFormat,omitempty"` // html or markdown
+	Template   string    `gorm:"column:template;type:varchar(50);not null" json:"template"`                               // Layout key the frontend renders the page with
+	Status     string    `gorm:"column:status;type:varchar(20);not null;index" json:"status"`                             // draft or published
+	Position   int       `gorm:"column:position;not null;default:0" json:"position"`                                      // Order of the page among its siblings
+	AuthorID   uint      `gorm:"column:author_id;not null;index" json:"authorId"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt  time.Time `gorm:"column:updated_at" json:"updatedAt"`
+	Version    uint      `gorm:"column:version;not null;default:1" json:"version"` // See Post.Version
+
+	// Relations
+	Parent   *Page  `gorm:"constraint:OnDelete:RESTRICT;foreignKey:ParentID" json:"-"`
+	Author   *User  `gorm:"constraint:OnDelete:RESTRICT;foreignKey:AuthorID" json:"-"`
+	Children []Page `gorm:"-" json:"children,omitempty"` // Filled when the tree is built
+}
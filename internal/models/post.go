@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Statuses of a post in the editorial workflow, only published posts are visible on the public API
+const (
+	PostStatusDraft     = "draft"
+	PostStatusInReview  = "in_review"
+	PostStatusApproved  = "approved"
+	PostStatusScheduled = "scheduled" // Published by the scheduler once PublishAt has passed
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
+
+// PostStatuses lists every status of the editorial workflow
+var PostStatuses = []string{
+	PostStatusDraft,
+	PostStatusInReview,
+	PostStatusApproved,
+	PostStatusScheduled,
+	PostStatusPublished,
+	PostStatusArchived,
+}
+
+// Formats of the body of posts and pages, the public API returns the body together with its rendered HTML
+const (
+	BodyFormatHTML     = "html"
+	BodyFormatMarkdown = "markdown"
+	BodyFormatBlocks   = "blocks" // JSON array of typed blocks, see pkg/blocks
+)
+
+// Actions moving a post through the editorial workflow
+const (
+	PostActionSubmit     = "submit"     // draft => in_review
+	PostActionApprove    = "approve"    // in_review => approved
+	PostActionReject     = "reject"     // in_review => draft
+	PostActionSchedule   = "schedule"   // approved => scheduled
+	PostActionUnschedule = "unschedule" // scheduled => approved
+	PostActionPublish    = "publish"    // approved, scheduled => published
+	PostActionArchive    = "archive"    // published => archived
+	PostActionUnpublish  = "unpublish"  // published => draft
+	PostActionRestore    = "restore"    // archived => draft
+)
+
+// PostExpireActions lists the actions the expiry worker may apply to a published post once its ExpireAt has passed
+var PostExpireActions = []string{PostActionArchive, PostActionUnpublish}
+
+// Post is an article written by a user
+type Post struct {
+	ID               uint           `gorm:"column:id;primaryKey" json:"id"`
+	Title            string         `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	Slug             string         `gorm:"column:slug;type:varchar(255);not null;unique" json:"slug"` // URL friendly identifier used by the public API
+	Excerpt          *string        `gorm:"column:excerpt;type:varchar(500);default:null" json:"excerpt,omitempty"`
+	Body             string         `gorm:"column:body;type:longtext;not null" json:"body"`
+	BodyFormat       string         `gorm:"column:body_format;type:varchar(20);not null;default:'html'" json:"bodyFormat"` // html or markdown
+	AuthorID         uint           `gorm:"column:author_id;not null;index" json:"authorId"`
+	CategoryID       *uint          `gorm:"column:category_id;default:null;index" json:"categoryId,omitempty"`
+	Status           string         `gorm:"column:status;type:varchar(20);not null;index" json:"status"`                      // Changed through the editorial workflow only
+	PublishAt        *time.Time     `gorm:"column:publish_at;default:null;index" json:"publishAt,omitempty"`                  // Time a scheduled post gets published
+	PublishedAt      *time.Time     `gorm:"column:published_at;default:null;index" json:"publishedAt,omitempty"`              // Set the first time the post is published
+	ExpireAt         *time.Time     `gorm:"column:expire_at;default:null;index" json:"expireAt,omitempty"`                    // Time the post is taken offline, cleared once it is
+	ExpireAction     *string        `gorm:"column:expire_action;type:varchar(20);default:null" json:"expireAction,omitempty"` // archive or unpublish, set together with ExpireAt
+	ExpiryNotifiedAt *time.Time     `gorm:"column:expiry_notified_at;default:null" json:"expiryNotifiedAt,omitempty"`         // Set once the author was told about the coming expiry
+	CreatedAt        time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt        time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+	Version          uint           `gorm:"column:version;not null;default:1" json:"version"` // Incremented on every save, an update with an older version is rejected
+	DeletedAt        gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deletedAt,omitempty"`
+
+	// Relations
+	Author   *User     `gorm:"constraint:OnDelete:RESTRICT;foreignKey:AuthorID" json:"author,omitempty"`
+	Category *Category `gorm:"constraint:OnDelete:SET NULL;foreignKey:CategoryID" json:"category,omitempty"`
+	Tags     []Tag     `gorm:"many2many:post_tags;constraint:OnDelete:CASCADE" json:"tags,omitempty"`
+}
+
+// PostTransition records a move of a post through the editorial workflow together with the comment of the reviewer
+type PostTransition struct {
+	ID         uint      `gorm:"column:id;primaryKey" json:"id"`
+	PostID     uint      `gorm:"column:post_id;not null;index" json:"postId"`
+	UserID     *uint     `gorm:"column:user_id;default:null" json:"userId,omitempty"` // Empty when the scheduler published the post
+	Action     string    `gorm:"column:action;type:varchar(20);not null" json:"action"`
+	FromStatus string    `gorm:"column:from_status;type:varchar(20);not null" json:"fromStatus"`
+	ToStatus   string    `gorm:"column:to_status;type:varchar(20);not null" json:"toStatus"`
+	Comment    *string   `gorm:"column:comment;type:text;default:null" json:"comment,omitempty"`
+	CreatedAt  time.Time `gorm:"column:created_at" json:"createdAt"`
+
+	// Relations
+	Post *Post `gorm:"constraint:OnDelete:CASCADE;foreignKey:PostID" json:"-"`
+	User *User `gorm:"constraint:OnDelete:SET NULL;foreignKey:UserID" json:"user,omitempty"`
+}
+
+// PostRevision is an immutable snapshot of the content of a post, written on every save
+type PostRevision struct {
+	ID           uint      `gorm:"column:id;primaryKey" json:"id"`
+	PostID       uint      `gorm:"column:post_id;not null;uniqueIndex:uni_post_revisions_post_number" json:"postId"`
+	Number       int       `gorm:"column:number;not null;uniqueIndex:uni_post_revisions_post_number" json:"number"` // Sequence of the revision within its post, starting at 1
+	EditorID     *uint     `gorm:"column:editor_id;default:null" json:"editorId,omitempty"`                         // User who saved the revision, empty once the user is deleted
+	Title        string    `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	Slug         string    `gorm:"column:slug;type:varchar(255);not null" json:"slug"`
+	Excerpt      *string   `gorm:"column:excerpt;type:varchar(500);default:null" json:"excerpt,omitempty"`
+	Body         string    `gorm:"column:body;type:longtext;not null" json:"body"`
+	BodyFormat   string    `gorm:"column:body_format;type:varchar(20);not null;default:'html'" json:"bodyFormat"`
+	RestoredFrom *int      `gorm:"column:restored_from;default:null" json:"restoredFrom,omitempty"` // Number of the revision this one restores
+	CreatedAt    time.Time `gorm:"column:created_at" json:"createdAt"`
+
+	// Relations
+	Post   *Post `gorm:"constraint:OnDelete:CASCADE;foreignKey:PostID" json:"-"`
+	Editor *User `gorm:"constraint:OnDelete:SET NULL;foreignKey:EditorID" json:"editor,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PreviewLink shares a revision of a post with people who have no account, through a signed token that expires
+type PreviewLink struct {
+	ID             uint       `gorm:"column:id;primaryKey" json:"id"`
+	PostID         uint       `gorm:"column:post_id;not null;index" json:"postId"`
+	RevisionNumber int        `gorm:"column:revision_number;not null" json:"revisionNumber"`     // The revision shown, later saves of the post do not change the preview
+	CreatedBy      *uint      `gorm:"column:created_by;default:null" json:"createdBy,omitempty"` // Empty once the user is deleted
+	ExpiresAt      time.Time  `gorm:"column:expires_at;not null" json:"expiresAt"`
+	RevokedAt      *time.Time `gorm:"column:revoked_at;default:null" json:"revokedAt,omitempty"`
+	CreatedAt      time.Time  `gorm:"column:created_at" json:"createdAt"`
+
+	// Relations
+	Post    *Post `gorm:"constraint:OnDelete:CASCADE;foreignKey:PostID" json:"-"`
+	Creator *User `gorm:"constraint:OnDelete:SET NULL;foreignKey:CreatedBy" json:"creator,omitempty"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// RedirectStatusCodes lists the status codes a redirect can answer with
+var RedirectStatusCodes = []int{301, 302, 307, 308}
+
+// Redirect sends the visitors of a path of the website to another path or URL
+type Redirect struct {
+	ID         uint      `gorm:"column:id;primaryKey" json:"id"`
+	FromPath   string    `gorm:"column:from_path;type:varchar(700);not null;unique" json:"fromPath"` // Path of the website, e.g. "/posts/old-slug"
+	ToPath     string    `gorm:"column:to_path;type:varchar(2048);not null;index" json:"toPath"`     // Path of the website or absolute URL
+	StatusCode int       `gorm:"column:status_code;not null;default:301" json:"statusCode"`
+	Automatic  bool      `gorm:"column:automatic;not null;default:false" json:"automatic"` // Recorded when the slug or path of published content changed
+	CreatedAt  time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt  time.Time `gorm:"column:updated_at" json:"updatedAt"`
+}
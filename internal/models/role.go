@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+type Role struct {
+	ID          uint      `gorm:"column:id;primaryKey" json:"id"`
+	Name        string    `gorm:"column:name;type:varchar(45);unique;not null" json:"name"`
+	DisplayName string    `gorm:"column:display_name;type:varchar(100);not null" json:"displayName"`
+	CreatedAt   time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Permissions []Permission `gorm:"many2many:role_permissions;constraint:OnDelete:CASCADE" json:"permissions,omitempty"`
+}
+
+type Permission struct {
+	ID          uint      `gorm:"column:id;primaryKey" json:"id"`
+	Name        string    `gorm:"column:name;type:varchar(100);unique;not null" json:"name"` // e.g. "attributes.manage"
+	Description string    `gorm:"column:description;type:varchar(255)" json:"description"`
+	CreatedAt   time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"column:updated_at" json:"updatedAt"`
+}
+
+// UserRole links a user to one of their roles
+type UserRole struct {
+	UserID uint `gorm:"column:user_id;primaryKey" json:"userId"`
+	RoleID uint `gorm:"column:role_id;primaryKey" json:"roleId"`
+}
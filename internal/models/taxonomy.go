@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// Category groups posts in a tree, a category without parent is a root of the tree
+type Category struct {
+	ID          uint      `gorm:"column:id;primaryKey" json:"id"`
+	ParentID    *uint     `gorm:"column:parent_id;default:null;index" json:"parentId,omitempty"`
+	Name        string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Slug        string    `gorm:"column:slug;type:varchar(255);not null;unique" json:"slug"`
+	Description *string   `gorm:"column:description;type:varchar(500);default:null" json:"description,omitempty"`
+	Position    int       `gorm:"column:position;not null;default:0" json:"position"` // Order of the category among its siblings
+	CreatedAt   time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Parent   *Category  `gorm:"constraint:OnDelete:RESTRICT;foreignKey:ParentID" json:"-"`
+	Children []Category `gorm:"-" json:"children,omitempty"` // Filled when the tree is built
+}
+
+// Tag is a free-form label, posts may have any number of tags
+type Tag struct {
+	ID        uint      `gorm:"column:id;primaryKey" json:"id"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Slug      string    `gorm:"column:slug;type:varchar(255);not null;unique" json:"slug"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updatedAt"`
+}
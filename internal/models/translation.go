@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Types of content with translations
+const (
+	TranslationTypePost = "post"
+	TranslationTypePage = "page"
+)
+
+// TranslationTypes lists every type of content with translations
+var TranslationTypes = []string{TranslationTypePost, TranslationTypePage}
+
+// Translation is the content of a post or a page in another locale than the default one
+// The content in the default locale is the one stored on the post or the page itself
+type Translation struct {
+	ID           uint      `gorm:"column:id;primaryKey" json:"id"`
+	ContentType  string    `gorm:"column:content_type;type:varchar(20);not null;uniqueIndex:uni_translations_content_locale,priority:1;uniqueIndex:uni_translations_locale_slug,priority:1" json:"contentType"`
+	ContentID    uint      `gorm:"column:content_id;not null;uniqueIndex:uni_translations_content_locale,priority:2" json:"contentId"`
+	Locale       string    `gorm:"column:locale;type:varchar(35);not null;uniqueIndex:uni_translations_content_locale,priority:3;uniqueIndex:uni_translations_locale_slug,priority:2" json:"locale"` // BCP 47 tag, e.g. "pt-BR"
+	Title        string    `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	Slug         string    `gorm:"column:slug;type:varchar(255);not null;uniqueIndex:uni_translations_locale_slug,priority:3" json:"slug"` // Unique per type and locale
+	Excerpt      *string   `gorm:"column:excerpt;type:varchar(500);default:null" json:"excerpt,omitempty"`                                 // Posts only
+	Body         string    `gorm:"column:body;type:longtext;not null" json:"body"`
+	TranslatorID *uint     `gorm:"column:translator_id;default:null" json:"translatorId,omitempty"` // Last user who saved the translation
+	CreatedAt    time.Time `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt    time.Time `gorm:"column:updated_at" json:"updatedAt"`
+
+	// Relations
+	Translator *User `gorm:"constraint:OnDelete:SET NULL;foreignKey:TranslatorID" json:"-"`
+}
@@ -6,17 +6,32 @@ import (
 	"gorm.io/gorm"
 )
 
+// Statuses of a user account
+const (
+	UserStatusActive  = "active"
+	UserStatusInvited = "invited" // The user was invited and has not set a password yet
+)
+
 type User struct {
-	ID        uint           `gorm:"column:id;primaryKey" json:"id"`
-	Email     string         `gorm:"column:email;type:varchar(45);unique;not null" json:"email"`
-	Password  string         `gorm:"column:password;type:varchar(255);not null" json:"-"`
-	Name      string         `gorm:"column:name;type:varchar(45);not null" json:"name"`
-	Birthday  *string        `gorm:"column:birthday;type:date;default:null" json:"birthday,omitempty"`
-	Address   *string        `gorm:"column:address;type:varchar(255);default:null" json:"address,omitempty"`
-	Gender    int16          `gorm:"column:gender;type:smallint;not null" json:"gender"` // 1. Male, 2. Felmale, 3. Other
-	Token     *string        `gorm:"column:token;type:varchar(100);default:null;unique" json:"-"`
-	ExpiredAt *int64         `gorm:"column:expired_at;type:bigint;default:null" json:"expiredAt,omitempty"`
-	CreatedAt time.Time      `gorm:"column:created_at" json:"createdAt"`
-	UpdatedAt time.Time      `gorm:"column:updated_at" json:"updatedAt"`
-	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deletedAt,omitempty"`
+	ID                  uint           `gorm:"column:id;primaryKey" json:"id"`
+	Email               string         `gorm:"column:email;type:varchar(45);unique;not null" json:"email"`
+	Password            string         `gorm:"column:password;type:varchar(255);not null" json:"-"`
+	Name                string         `gorm:"column:name;type:varchar(45);not null" json:"name"`
+	Birthday            *string        `gorm:"column:birthday;type:date;default:null" json:"birthday,omitempty"`
+	Address             *string        `gorm:"column:address;type:varchar(255);default:null" json:"address,omitempty"`
+	Gender              int16          `gorm:"column:gender;type:smallint;not null" json:"gender"` // 1. Male, 2. Felmale, 3. Other
+	Status              string         `gorm:"column:status;type:varchar(20);not null;default:active" json:"status,omitempty"`
+	Token               *string        `gorm:"column:token;type:varchar(100);default:null;unique" json:"-"`
+	ExpiredAt           *int64         `gorm:"column:expired_at;type:bigint;default:null" json:"expiredAt,omitempty"`
+	AvatarKey           *string        `gorm:"column:avatar_key;type:varchar(255);default:null" json:"-"` // Storage key of the full size avatar
+	AvatarURL           *string        `gorm:"column:avatar_url;type:varchar(512);default:null" json:"avatarUrl,omitempty"`
+	AvatarThumbnailURL  *string        `gorm:"column:avatar_thumbnail_url;type:varchar(512);default:null" json:"avatarThumbnailUrl,omitempty"`
+	DeletionScheduledAt *time.Time     `gorm:"column:deletion_scheduled_at;default:null;index" json:"deletionScheduledAt,omitempty"` // Personal data is erased after this time unless the request is cancelled
+	CreatedAt           time.Time      `gorm:"column:created_at" json:"createdAt"`
+	UpdatedAt           time.Time      `gorm:"column:updated_at" json:"updatedAt"`
+	Version             uint           `gorm:"column:version;not null;default:1" json:"version"` // Incremented on every save of the account, see Post.Version
+	DeletedAt           gorm.DeletedAt `gorm:"column:deleted_at;index" json:"deletedAt,omitempty"`
+
+	// Attributes holds the custom attribute values keyed by attribute name, loaded by the user service
+	Attributes map[string]any `gorm:"-" json:"attributes,omitempty"`
 }
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+)
+
+type IAttributeRepository interface {
+	GetAll() ([]models.AttributeDefinition, error)
+	GetByID(id uint) (*models.AttributeDefinition, error)
+	Create(definition *models.AttributeDefinition) error
+	Update(definition *models.AttributeDefinition) error
+	Delete(id uint) error
+}
+
+type AttributeRepository struct {
+	db *gorm.DB
+}
+
+// NewAttributeRepository creates a new instance of AttributeRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *AttributeRepository: pointer to the newly created AttributeRepository
+func NewAttributeRepository(db *gorm.DB) *AttributeRepository {
+	return &AttributeRepository{db: db}
+}
+
+// GetAll retrieves all attribute definitions ordered by sort order
+// Returns:
+//   - []models.AttributeDefinition: Slice containing all attribute definitions
+//   - error: Error if there was a database error, nil on success
+func (repo *AttributeRepository) GetAll() ([]models.AttributeDefinition, error) {
+	var definitions []models.AttributeDefinition
+	if err := repo.db.Order("sort_order ASC, id ASC").Find(&definitions).Error; err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+// GetByID retrieves an attribute definition by its ID
+// Parameters:
+//   - id: The unique identifier of the attribute definition
+//
+// Returns:
+//   - *models.AttributeDefinition: Pointer to the retrieved definition
+//   - error: Error if the definition is not found or if there was a database error
+func (repo *AttributeRepository) GetByID(id uint) (*models.AttributeDefinition, error) {
+	var definition models.AttributeDefinition
+	if err := repo.db.First(&definition, id).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// Create inserts a new attribute definition
+// Parameters:
+//   - definition: Pointer to the definition to be created
+//
+// Returns:
+//   - error: Error if there was a problem creating the definition, nil on success
+func (repo *AttributeRepository) Create(definition *models.AttributeDefinition) error {
+	return repo.db.Create(definition).Error
+}
+
+// Update saves an existing attribute definition
+// Parameters:
+//   - definition: Pointer to the definition to be updated
+//
+// Returns:
+//   - error: Error if there was a problem updating the definition, nil on success
+func (repo *AttributeRepository) Update(definition *models.AttributeDefinition) error {
+	return repo.db.Save(definition).Error
+}
+
+// Delete removes an attribute definition together with the values stored for it
+// Parameters:
+//   - id: The ID of the definition to delete
+//
+// Returns:
+//   - error: Error if there was a problem deleting the definition, nil on success
+func (repo *AttributeRepository) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("attribute_definition_id = ?", id).Delete(&models.UserAttribute{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.AttributeDefinition{}, id).Error
+	})
+}
@@ -0,0 +1,87 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type AttributeRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.AttributeRepository
+}
+
+func (s *AttributeRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.AttributeDefinition{}, &models.UserAttribute{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewAttributeRepository(db)
+}
+
+func (s *AttributeRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *AttributeRepositoryTestSuite) TestCreateAndGetAll() {
+	maxLength := 20
+	second := &models.AttributeDefinition{Name: "employee_id", Label: "Employee ID", Type: models.AttributeTypeText, SortOrder: 2, Rules: models.AttributeRules{MaxLength: &maxLength}}
+	first := &models.AttributeDefinition{Name: "department", Label: "Department", Type: models.AttributeTypeSelect, SortOrder: 1, Rules: models.AttributeRules{Options: []string{"Sales", "IT"}}}
+	s.Require().NoError(s.repo.Create(second))
+	s.Require().NoError(s.repo.Create(first))
+
+	definitions, err := s.repo.GetAll()
+	s.NoError(err)
+	s.Require().Len(definitions, 2)
+	s.Equal("department", definitions[0].Name)
+	s.Equal([]string{"Sales", "IT"}, definitions[0].Rules.Options)
+	s.Equal(20, *definitions[1].Rules.MaxLength)
+
+	// Names are unique
+	s.Error(s.repo.Create(&models.AttributeDefinition{Name: "department", Label: "Dup", Type: models.AttributeTypeText}))
+}
+
+func (s *AttributeRepositoryTestSuite) TestGetByIDAndUpdate() {
+	definition := &models.AttributeDefinition{Name: "phone", Label: "Phone", Type: models.AttributeTypeText}
+	s.Require().NoError(s.repo.Create(definition))
+
+	definition.Label = "Phone number"
+	definition.Required = true
+	s.NoError(s.repo.Update(definition))
+
+	found, err := s.repo.GetByID(definition.ID)
+	s.NoError(err)
+	s.Equal("Phone number", found.Label)
+	s.True(found.Required)
+
+	_, err = s.repo.GetByID(999)
+	s.Error(err)
+}
+
+func (s *AttributeRepositoryTestSuite) TestDelete() {
+	definition := &models.AttributeDefinition{Name: "phone", Label: "Phone", Type: models.AttributeTypeText}
+	s.Require().NoError(s.repo.Create(definition))
+	s.Require().NoError(s.db.Create(&models.UserAttribute{UserID: 1, AttributeDefinitionID: definition.ID, Value: "0123"}).Error)
+
+	s.NoError(s.repo.Delete(definition.ID))
+
+	var count int64
+	s.db.Model(&models.UserAttribute{}).Count(&count)
+	s.Equal(int64(0), count, "stored values are deleted with the definition")
+	_, err := s.repo.GetByID(definition.ID)
+	s.Error(err)
+}
+
+func TestAttributeRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AttributeRepositoryTestSuite))
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter holds the optional criteria applied when listing audit logs
+type AuditLogFilter struct {
+	ActorID     uint
+	Action      string
+	SubjectType string
+	SubjectID   uint
+}
+
+type IAuditLogRepository interface {
+	Create(log *models.AuditLog) error
+	Paginate(page, limit int, filter AuditLogFilter) (*utils.Pagination, error)
+	FindByUserID(userID uint) ([]models.AuditLog, error)
+}
+
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new instance of AuditLogRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *AuditLogRepository: pointer to the newly created AuditLogRepository
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// Create stores a new audit log entry
+// Parameters:
+//   - log: pointer to the AuditLog model to be saved
+//
+// Returns:
+//   - error: nil if successful, error otherwise
+func (repo *AuditLogRepository) Create(log *models.AuditLog) error {
+	return repo.db.Create(log).Error
+}
+
+// Paginate retrieves audit log entries, newest first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of entries per page
+//   - filter: Optional criteria, zero values are ignored
+//
+// Returns:
+//   - *utils.Pagination: The page of audit logs
+//   - error: nil if successful, error otherwise
+func (repo *AuditLogRepository) Paginate(page, limit int, filter AuditLogFilter) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.AuditLog{})
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.SubjectType != "" {
+		query = query.Where("subject_type = ?", filter.SubjectType)
+	}
+	if filter.SubjectID != 0 {
+		query = query.Where("subject_id = ?", filter.SubjectID)
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var logs []models.AuditLog
+	if err := query.Offset((page - 1) * limit).Limit(limit).Order("id DESC").Find(&logs).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       logs,
+	}, nil
+}
+
+// FindByUserID retrieves every audit log entry performed by a user or applying to them
+// Parameters:
+//   - userID: The ID of the user
+//
+// Returns:
+//   - []models.AuditLog: The entries, oldest first
+//   - error: nil if successful, error otherwise
+func (repo *AuditLogRepository) FindByUserID(userID uint) ([]models.AuditLog, error) {
+	var logs []models.AuditLog
+	err := repo.db.Where("actor_id = ? OR (subject_type = ? AND subject_id = ?)", userID, models.AuditSubjectUser, userID).
+		Order("id ASC").Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
@@ -0,0 +1,89 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.AuditLogRepository
+}
+
+func (s *AuditLogRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.AuditLog{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewAuditLogRepository(db)
+}
+
+func (s *AuditLogRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *AuditLogRepositoryTestSuite) TestCreateAndPaginate() {
+	logs := []*models.AuditLog{
+		{ActorID: 1, Action: models.AuditActionImpersonationStart, SubjectType: models.AuditSubjectUser, SubjectID: 5, Metadata: map[string]any{"reason": "TICKET-1"}},
+		{ActorID: 1, Action: models.AuditActionImpersonationStop, SubjectType: models.AuditSubjectUser, SubjectID: 5},
+		{ActorID: 2, Action: models.AuditActionImpersonationStart, SubjectType: models.AuditSubjectUser, SubjectID: 6},
+	}
+	for _, log := range logs {
+		s.Require().NoError(s.repo.Create(log))
+	}
+
+	pagination, err := s.repo.Paginate(1, 2, repositories.AuditLogFilter{})
+	s.NoError(err)
+	s.Equal(3, pagination.TotalItems)
+	s.Equal(2, pagination.TotalPages)
+	data := pagination.Data.([]models.AuditLog)
+	s.Require().Len(data, 2)
+	s.Equal(logs[2].ID, data[0].ID, "newest entries come first")
+
+	pagination, err = s.repo.Paginate(1, 10, repositories.AuditLogFilter{ActorID: 1, Action: models.AuditActionImpersonationStart, SubjectType: models.AuditSubjectUser, SubjectID: 5})
+	s.NoError(err)
+	data = pagination.Data.([]models.AuditLog)
+	s.Require().Len(data, 1)
+	s.Equal("TICKET-1", data[0].Metadata["reason"])
+}
+
+func (s *AuditLogRepositoryTestSuite) TestFindByUserID() {
+	logs := []*models.AuditLog{
+		{ActorID: 1, Action: models.AuditActionImpersonationStart, SubjectType: models.AuditSubjectUser, SubjectID: 5},
+		{ActorID: 5, Action: models.AuditActionDataExport, SubjectType: models.AuditSubjectUser, SubjectID: 5},
+		{ActorID: 2, Action: models.AuditActionImpersonationStart, SubjectType: models.AuditSubjectUser, SubjectID: 6},
+	}
+	for _, log := range logs {
+		s.Require().NoError(s.repo.Create(log))
+	}
+
+	result, err := s.repo.FindByUserID(5)
+	s.NoError(err)
+	s.Require().Len(result, 2)
+	s.Equal(logs[0].ID, result[0].ID)
+	s.Equal(logs[1].ID, result[1].ID)
+}
+
+func (s *AuditLogRepositoryTestSuite) TestPaginateError() {
+	sqlDB, err := s.db.DB()
+	s.Require().NoError(err)
+	s.Require().NoError(sqlDB.Close())
+
+	_, err = s.repo.Paginate(1, 10, repositories.AuditLogFilter{})
+	s.Error(err)
+}
+
+func TestAuditLogRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditLogRepositoryTestSuite))
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ICategoryRepository interface {
+	GetAll() ([]models.Category, error)
+	GetByID(id uint) (*models.Category, error)
+	FindBySlug(slug string) (*models.Category, error)
+	SlugExists(slug string, excludeID uint) (bool, error)
+	Create(category *models.Category) error
+	Update(category *models.Category) error
+	UpdatePositions(parentID *uint, orderedIDs []uint) error
+	Delete(id uint) error
+}
+
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository creates a new instance of CategoryRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *CategoryRepository: pointer to the newly created CategoryRepository
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// GetAll retrieves every category ordered by position within their parents
+// Returns:
+//   - []models.Category: Flat list of all categories, the tree is built by the caller
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CategoryRepository) GetAll() ([]models.Category, error) {
+	var categories []models.Category
+	if err := repo.db.Order("position ASC, id ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetByID retrieves a category by its ID
+// Parameters:
+//   - id: The ID of the category
+//
+// Returns:
+//   - *models.Category: The category
+//   - error: gorm.ErrRecordNotFound if the category does not exist, otherwise the error that occurred
+func (repo *CategoryRepository) GetByID(id uint) (*models.Category, error) {
+	var category models.Category
+	if err := repo.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// FindBySlug retrieves a category by its slug
+// Parameters:
+//   - slug: The slug of the category
+//
+// Returns:
+//   - *models.Category: The category
+//   - error: gorm.ErrRecordNotFound if no category has this slug, otherwise the error that occurred
+func (repo *CategoryRepository) FindBySlug(slug string) (*models.Category, error) {
+	var category models.Category
+	if err := repo.db.Where("slug = ?", slug).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// SlugExists checks whether a slug is used by a category other than the excluded one
+// Parameters:
+//   - slug: The slug to look for
+//   - excludeID: ID of the category being saved, 0 when creating a category
+//
+// Returns:
+//   - bool: true if another category uses the slug
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CategoryRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Model(&models.Category{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create stores a new category
+// Parameters:
+//   - category: The category to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CategoryRepository) Create(category *models.Category) error {
+	return repo.db.Omit(clause.Associations).Create(category).Error
+}
+
+// Update saves an existing category, its parent and position are only changed by UpdatePositions
+// The archive of the old slug is redirected to the new one
+// Parameters:
+//   - category: The category to save
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CategoryRepository) Update(category *models.Category) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var stored models.Category
+		if err := tx.Select("slug").First(&stored, category.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations, "parent_id", "position").Save(category).Error; err != nil {
+			return err
+		}
+		return recordMoves(tx, map[string]string{"/categories/" + stored.Slug: "/categories/" + category.Slug})
+	})
+}
+
+// UpdatePositions places categories under a parent in the given order within a single transaction
+// Parameters:
+//   - parentID: ID of the parent of the categories, nil for root categories
+//   - orderedIDs: IDs of the categories, the position of each category is its index in the slice
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CategoryRepository) UpdatePositions(parentID *uint, orderedIDs []uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range orderedIDs {
+			if err := tx.Model(&models.Category{}).
+				Where("id = ?", id).
+				Updates(map[string]any{"parent_id": parentID, "position": position}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete removes a category, its posts are left without category, deleted posts included
+// Parameters:
+//   - id: The ID of the category
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CategoryRepository) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Post{}).
+			Where("category_id = ?", id).
+			Update("category_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Category{}, id).Error
+	})
+}
@@ -0,0 +1,113 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type CategoryRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.CategoryRepository
+}
+
+func (s *CategoryRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Category{}, &models.Tag{}, &models.Post{}, &models.Redirect{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewCategoryRepository(db)
+}
+
+func (s *CategoryRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *CategoryRepositoryTestSuite) newCategory(slug string, parentID *uint, position int) *models.Category {
+	category := &models.Category{Name: slug, Slug: slug, ParentID: parentID, Position: position}
+	s.Require().NoError(s.repo.Create(category))
+	return category
+}
+
+func (s *CategoryRepositoryTestSuite) TestCreateUpdateAndGet() {
+	category := s.newCategory("news", nil, 0)
+	s.NotZero(category.ID)
+
+	found, err := s.repo.FindBySlug("news")
+	s.Require().NoError(err)
+	s.Equal(category.ID, found.ID)
+
+	// Update leaves the place of the category in the tree untouched
+	category.Name = "World news"
+	category.Position = 5
+	s.Require().NoError(s.repo.Update(category))
+
+	found, err = s.repo.GetByID(category.ID)
+	s.Require().NoError(err)
+	s.Equal("World news", found.Name)
+	s.Equal(0, found.Position)
+
+	exists, err := s.repo.SlugExists("news", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+
+	exists, err = s.repo.SlugExists("news", category.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	_, err = s.repo.FindBySlug("missing")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *CategoryRepositoryTestSuite) TestUpdatePositions() {
+	first := s.newCategory("first", nil, 0)
+	second := s.newCategory("second", nil, 1)
+	child := s.newCategory("child", &first.ID, 0)
+
+	s.Require().NoError(s.repo.UpdatePositions(&second.ID, []uint{child.ID, first.ID}))
+
+	categories, err := s.repo.GetAll()
+	s.Require().NoError(err)
+	s.Require().Len(categories, 3)
+	// Ordered by position first
+	s.Equal(child.ID, categories[0].ID)
+	s.Equal(0, categories[0].Position)
+	s.Equal(second.ID, *categories[0].ParentID)
+	s.Equal(first.ID, categories[1].ID)
+	s.Equal(1, categories[1].Position)
+	s.Equal(second.ID, *categories[1].ParentID)
+	s.Equal(second.ID, categories[2].ID)
+	s.Nil(categories[2].ParentID)
+}
+
+func (s *CategoryRepositoryTestSuite) TestDelete() {
+	category := s.newCategory("news", nil, 0)
+	author := &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(s.db.Create(author).Error)
+	post := &models.Post{Title: "Hello", Slug: "hello", Body: "Body", AuthorID: author.ID, Status: models.PostStatusDraft, CategoryID: &category.ID}
+	s.Require().NoError(s.db.Create(post).Error)
+
+	s.Require().NoError(s.repo.Delete(category.ID))
+
+	_, err := s.repo.GetByID(category.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	// The post is kept without category
+	var stored models.Post
+	s.Require().NoError(s.db.First(&stored, post.ID).Error)
+	s.Nil(stored.CategoryID)
+}
+
+func TestCategoryRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CategoryRepositoryTestSuite))
+}
@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CommentFilter holds the optional criteria applied when listing comments in the moderation queue
+type CommentFilter struct {
+	Status string // Only comments with this status, empty for any status
+	PostID uint   // Only comments on this post, 0 for any post
+}
+
+type ICommentRepository interface {
+	PaginateComments(page, limit int, filter CommentFilter) (*utils.Pagination, error)
+	PaginateThreads(postID uint, page, limit int) (*utils.Pagination, error)
+	FindApprovedReplies(rootIDs []uint) ([]models.Comment, error)
+	GetByID(id uint) (*models.Comment, error)
+	CountApprovedByAuthor(authorID uint) (int64, error)
+	Create(comment *models.Comment) error
+	UpdateStatus(ids []uint, status string, moderatorID uint) (int64, error)
+	DeleteWithReplies(ids []uint) (int64, error)
+}
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository creates a new instance of CommentRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *CommentRepository: pointer to the newly created CommentRepository
+func NewCommentRepository(db *gorm.DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// PaginateComments retrieves a page of comments of any thread with their posts and authors, newest first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of comments per page
+//   - filter: Optional criteria, every criterion must match for a comment to be returned
+//
+// Returns:
+//   - *utils.Pagination: The page of comments
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CommentRepository) PaginateComments(page, limit int, filter CommentFilter) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.Comment{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.PostID != 0 {
+		query = query.Where("post_id = ?", filter.PostID)
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var comments []models.Comment
+	if err := query.
+		Preload("Post", func(db *gorm.DB) *gorm.DB { return db.Unscoped().Select("id", "title", "slug", "author_id", "status") }).
+		Preload("Author").
+		Order("id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       comments,
+	}, nil
+}
+
+// PaginateThreads retrieves a page of the approved comments starting a thread on a post, oldest first
+// Parameters:
+//   - postID: The ID of the post
+//   - page: The page number to retrieve
+//   - limit: The number of threads per page
+//
+// Returns:
+//   - *utils.Pagination: The page of comments with their authors, the replies are loaded by FindApprovedReplies
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CommentRepository) PaginateThreads(postID uint, page, limit int) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.Comment{}).
+		Where("post_id = ? AND parent_id IS NULL AND status = ?", postID, models.CommentStatusApproved)
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var comments []models.Comment
+	if err := query.Preload("Author").Order("id ASC").Offset((page - 1) * limit).Limit(limit).Find(&comments).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       comments,
+	}, nil
+}
+
+// FindApprovedReplies retrieves the approved replies of the given threads with their authors, oldest first
+// Parameters:
+//   - rootIDs: IDs of the comments starting the threads
+//
+// Returns:
+//   - []models.Comment: Flat list of replies at any depth, the tree is built by the caller
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CommentRepository) FindApprovedReplies(rootIDs []uint) ([]models.Comment, error) {
+	var comments []models.Comment
+	if len(rootIDs) == 0 {
+		return comments, nil
+	}
+	if err := repo.db.Preload("Author").
+		Where("root_id IN ? AND status = ?", rootIDs, models.CommentStatusApproved).
+		Order("id ASC").
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetByID retrieves a comment by its ID together with its author
+// Parameters:
+//   - id: The ID of the comment
+//
+// Returns:
+//   - *models.Comment: The comment
+//   - error: gorm.ErrRecordNotFound if the comment does not exist, otherwise the error that occurred
+func (repo *CommentRepository) GetByID(id uint) (*models.Comment, error) {
+	var comment models.Comment
+	if err := repo.db.Preload("Author").First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// CountApprovedByAuthor counts the approved comments of a user, used to decide whether the user is trusted
+func (repo *CommentRepository) CountApprovedByAuthor(authorID uint) (int64, error) {
+	var count int64
+	if err := repo.db.Model(&models.Comment{}).
+		Where("author_id = ? AND status = ?", authorID, models.CommentStatusApproved).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Create stores a new comment
+// Parameters:
+//   - comment: The comment to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CommentRepository) Create(comment *models.Comment) error {
+	return repo.db.Omit(clause.Associations).Create(comment).Error
+}
+
+// UpdateStatus moves comments to a status of the moderation queue and records who moderated them
+// Parameters:
+//   - ids: IDs of the comments
+//   - status: The new status
+//   - moderatorID: The ID of the user moderating the comments
+//
+// Returns:
+//   - int64: Number of comments found
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CommentRepository) UpdateStatus(ids []uint, status string, moderatorID uint) (int64, error) {
+	result := repo.db.Model(&models.Comment{}).Where("id IN ?", ids).Updates(map[string]any{
+		"status":       status,
+		"moderator_id": moderatorID,
+		"moderated_at": time.Now(),
+	})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteWithReplies removes comments together with every reply below them in a single transaction
+// Parameters:
+//   - ids: IDs of the comments
+//
+// Returns:
+//   - int64: Number of comments removed, replies included
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *CommentRepository) DeleteWithReplies(ids []uint) (int64, error) {
+	var deleted int64
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		// Collect the replies level by level, the deepest ones are removed first
+		levels := [][]uint{ids}
+		for {
+			var children []uint
+			if err := tx.Model(&models.Comment{}).Where("parent_id IN ?", levels[len(levels)-1]).Pluck("id", &children).Error; err != nil {
+				return err
+			}
+			if len(children) == 0 {
+				break
+			}
+			levels = append(levels, children)
+		}
+
+		for i := len(levels) - 1; i >= 0; i-- {
+			result := tx.Where("id IN ?", levels[i]).Delete(&models.Comment{})
+			if result.Error != nil {
+				return result.Error
+			}
+			deleted += result.RowsAffected
+		}
+		return nil
+	})
+	return deleted, err
+}
@@ -0,0 +1,156 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type CommentRepositoryTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	repo   *repositories.CommentRepository
+	author *models.User
+	post   *models.Post
+}
+
+func (s *CommentRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewCommentRepository(db)
+
+	s.author = &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(db.Create(s.author).Error)
+	s.post = &models.Post{Title: "Hello", Slug: "hello", Body: "Body", AuthorID: s.author.ID, Status: models.PostStatusPublished}
+	s.Require().NoError(db.Create(s.post).Error)
+}
+
+func (s *CommentRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *CommentRepositoryTestSuite) newComment(parent *models.Comment, status string) *models.Comment {
+	comment := &models.Comment{
+		PostID:    s.post.ID,
+		AuthorID:  &s.author.ID,
+		Body:      "Comment",
+		Status:    status,
+		IPAddress: "127.0.0.1",
+	}
+	if parent != nil {
+		comment.ParentID = &parent.ID
+		comment.RootID = parent.RootID
+		if comment.RootID == nil {
+			comment.RootID = &parent.ID
+		}
+	}
+	s.Require().NoError(s.repo.Create(comment))
+	return comment
+}
+
+func (s *CommentRepositoryTestSuite) TestThreads() {
+	root := s.newComment(nil, models.CommentStatusApproved)
+	s.newComment(nil, models.CommentStatusPending)
+	reply := s.newComment(root, models.CommentStatusApproved)
+	s.newComment(reply, models.CommentStatusApproved)
+	s.newComment(root, models.CommentStatusSpam)
+
+	pagination, err := s.repo.PaginateThreads(s.post.ID, 1, 10)
+	s.Require().NoError(err)
+	s.Equal(1, pagination.TotalItems)
+	threads := pagination.Data.([]models.Comment)
+	s.Require().Len(threads, 1)
+	s.Equal(root.ID, threads[0].ID)
+	s.Require().NotNil(threads[0].Author)
+
+	// Replies at any depth share the ID of the first comment of the thread
+	replies, err := s.repo.FindApprovedReplies([]uint{root.ID})
+	s.Require().NoError(err)
+	s.Require().Len(replies, 2)
+	s.Equal(reply.ID, *replies[1].ParentID)
+
+	replies, err = s.repo.FindApprovedReplies(nil)
+	s.Require().NoError(err)
+	s.Empty(replies)
+}
+
+func (s *CommentRepositoryTestSuite) TestPaginateComments() {
+	s.newComment(nil, models.CommentStatusApproved)
+	pending := s.newComment(nil, models.CommentStatusPending)
+	s.Require().NoError(s.repo.Create(&models.Comment{
+		PostID: s.post.ID, GuestName: utils.StringToPtr("Guest"), GuestEmail: utils.StringToPtr("guest@example.com"),
+		Body: "Hi", Status: models.CommentStatusPending, IPAddress: "127.0.0.1",
+	}))
+
+	pagination, err := s.repo.PaginateComments(1, 10, repositories.CommentFilter{Status: models.CommentStatusPending, PostID: s.post.ID})
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+	comments := pagination.Data.([]models.Comment)
+	s.Require().Len(comments, 2)
+	s.Equal(pending.ID, comments[1].ID)
+	s.Require().NotNil(comments[1].Post)
+	s.Equal("hello", comments[1].Post.Slug)
+	s.Nil(comments[0].Author)
+
+	pagination, err = s.repo.PaginateComments(1, 10, repositories.CommentFilter{PostID: 999})
+	s.Require().NoError(err)
+	s.Zero(pagination.TotalItems)
+}
+
+func (s *CommentRepositoryTestSuite) TestUpdateStatusAndCount() {
+	first := s.newComment(nil, models.CommentStatusPending)
+	second := s.newComment(nil, models.CommentStatusPending)
+
+	count, err := s.repo.CountApprovedByAuthor(s.author.ID)
+	s.Require().NoError(err)
+	s.Zero(count)
+
+	updated, err := s.repo.UpdateStatus([]uint{first.ID, second.ID, 999}, models.CommentStatusApproved, s.author.ID)
+	s.Require().NoError(err)
+	s.Equal(int64(2), updated)
+
+	count, err = s.repo.CountApprovedByAuthor(s.author.ID)
+	s.Require().NoError(err)
+	s.Equal(int64(2), count)
+
+	found, err := s.repo.GetByID(first.ID)
+	s.Require().NoError(err)
+	s.Equal(s.author.ID, *found.ModeratorID)
+	s.NotNil(found.ModeratedAt)
+}
+
+func (s *CommentRepositoryTestSuite) TestDeleteWithReplies() {
+	root := s.newComment(nil, models.CommentStatusApproved)
+	reply := s.newComment(root, models.CommentStatusApproved)
+	s.newComment(reply, models.CommentStatusPending)
+	other := s.newComment(nil, models.CommentStatusApproved)
+
+	deleted, err := s.repo.DeleteWithReplies([]uint{root.ID})
+	s.Require().NoError(err)
+	s.Equal(int64(3), deleted)
+
+	_, err = s.repo.GetByID(other.ID)
+	s.NoError(err)
+	_, err = s.repo.GetByID(reply.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	deleted, err = s.repo.DeleteWithReplies([]uint{999})
+	s.Require().NoError(err)
+	s.Zero(deleted)
+}
+
+func TestCommentRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentRepositoryTestSuite))
+}
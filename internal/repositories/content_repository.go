@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IContentRepository interface {
+	GetTypes() ([]models.ContentType, error)
+	GetTypeBySlug(slug string) (*models.ContentType, error)
+	CreateType(contentType *models.ContentType) error
+	UpdateType(contentType *models.ContentType) error
+	DeleteType(id uint) error
+	PaginateEntries(contentTypeID uint, page, limit int) (*utils.Pagination, error)
+	GetEntry(contentTypeID, id uint) (*models.ContentEntry, error)
+	GetEntryByID(id uint) (*models.ContentEntry, error)
+	CountEntries(contentTypeID uint, ids []uint) (int64, error)
+	CreateEntry(entry *models.ContentEntry) error
+	UpdateEntry(entry *models.ContentEntry) error
+	DeleteEntry(contentTypeID, id uint) (int64, error)
+}
+
+type ContentRepository struct {
+	db *gorm.DB
+}
+
+// NewContentRepository creates a new instance of ContentRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *ContentRepository: pointer to the newly created ContentRepository
+func NewContentRepository(db *gorm.DB) *ContentRepository {
+	return &ContentRepository{db: db}
+}
+
+// GetTypes retrieves every content type ordered by name
+func (repo *ContentRepository) GetTypes() ([]models.ContentType, error) {
+	var contentTypes []models.ContentType
+	if err := repo.db.Order("name ASC, id ASC").Find(&contentTypes).Error; err != nil {
+		return nil, err
+	}
+	return contentTypes, nil
+}
+
+// GetTypeBySlug retrieves a content type by the slug used in the URLs
+// Returns gorm.ErrRecordNotFound if no content type has this slug
+func (repo *ContentRepository) GetTypeBySlug(slug string) (*models.ContentType, error) {
+	var contentType models.ContentType
+	if err := repo.db.Where("slug = ?", slug).First(&contentType).Error; err != nil {
+		return nil, err
+	}
+	return &contentType, nil
+}
+
+// CreateType inserts a new content type
+func (repo *ContentRepository) CreateType(contentType *models.ContentType) error {
+	return repo.db.Create(contentType).Error
+}
+
+// UpdateType saves an existing content type
+func (repo *ContentRepository) UpdateType(contentType *models.ContentType) error {
+	return repo.db.Save(contentType).Error
+}
+
+// DeleteType removes a content type together with its entries
+// Parameters:
+//   - id: The ID of the content type to delete
+//
+// Returns:
+//   - error: Error if there was a problem deleting the content type, nil on success
+func (repo *ContentRepository) DeleteType(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("content_type_id = ?", id).Delete(&models.ContentEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ContentType{}, id).Error
+	})
+}
+
+// PaginateEntries retrieves a page of the entries of a content type, newest first
+// Parameters:
+//   - contentTypeID: The ID of the content type
+//   - page: The page number to retrieve
+//   - limit: The number of items per page
+//
+// Returns:
+//   - *utils.Pagination: The page of []models.ContentEntry
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *ContentRepository) PaginateEntries(contentTypeID uint, page, limit int) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.ContentEntry{}).Where("content_type_id = ?", contentTypeID)
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var entries []models.ContentEntry
+	if err := query.Offset((page - 1) * limit).Limit(limit).Order("id DESC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       entries,
+	}, nil
+}
+
+// GetEntry retrieves an entry of a content type by its ID
+// Returns gorm.ErrRecordNotFound if the entry does not exist or belongs to another content type
+func (repo *ContentRepository) GetEntry(contentTypeID, id uint) (*models.ContentEntry, error) {
+	var entry models.ContentEntry
+	if err := repo.db.Where("content_type_id = ?", contentTypeID).First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetEntryByID retrieves an entry of any content type by its ID, entry IDs are unique across content types
+// Returns gorm.ErrRecordNotFound if the entry does not exist
+func (repo *ContentRepository) GetEntryByID(id uint) (*models.ContentEntry, error) {
+	var entry models.ContentEntry
+	if err := repo.db.First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// CountEntries counts how many of the given IDs are entries of a content type, used to check references
+func (repo *ContentRepository) CountEntries(contentTypeID uint, ids []uint) (int64, error) {
+	var count int64
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := repo.db.Model(&models.ContentEntry{}).
+		Where("content_type_id = ? AND id IN ?", contentTypeID, ids).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CreateEntry inserts a new entry
+func (repo *ContentRepository) CreateEntry(entry *models.ContentEntry) error {
+	return repo.db.Omit(clause.Associations).Create(entry).Error
+}
+
+// UpdateEntry saves the data of an existing entry
+// It returns ErrVersionConflict when the entry was saved by someone else since its version was loaded
+func (repo *ContentRepository) UpdateEntry(entry *models.ContentEntry) error {
+	return saveVersioned(repo.db, entry, &entry.Version)
+}
+
+// DeleteEntry removes an entry of a content type
+// Returns:
+//   - int64: Number of entries removed, 0 if the content type has no entry with this ID
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *ContentRepository) DeleteEntry(contentTypeID, id uint) (int64, error) {
+	result := repo.db.Where("content_type_id = ?", contentTypeID).Delete(&models.ContentEntry{}, id)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,149 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type ContentRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.ContentRepository
+}
+
+func (s *ContentRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.ContentType{}, &models.ContentEntry{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewContentRepository(db)
+}
+
+func (s *ContentRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *ContentRepositoryTestSuite) createType(slug, name string) *models.ContentType {
+	contentType := &models.ContentType{
+		Slug:   slug,
+		Name:   name,
+		Fields: []models.ContentField{{Name: "title", Label: "Title", Type: models.ContentFieldText, Required: true}},
+	}
+	s.Require().NoError(s.repo.CreateType(contentType))
+	return contentType
+}
+
+func (s *ContentRepositoryTestSuite) createEntry(contentTypeID uint, title string) *models.ContentEntry {
+	entry := &models.ContentEntry{ContentTypeID: contentTypeID, Data: map[string]any{"title": title}}
+	s.Require().NoError(s.repo.CreateEntry(entry))
+	return entry
+}
+
+func (s *ContentRepositoryTestSuite) TestTypes() {
+	s.createType("product", "Product")
+	s.createType("event", "Event")
+
+	contentTypes, err := s.repo.GetTypes()
+	s.Require().NoError(err)
+	s.Require().Len(contentTypes, 2)
+	s.Equal("event", contentTypes[0].Slug)
+
+	contentType, err := s.repo.GetTypeBySlug("product")
+	s.Require().NoError(err)
+	s.Require().Len(contentType.Fields, 1)
+	s.Equal(models.ContentFieldText, contentType.Fields[0].Type)
+
+	contentType.Name = "Products"
+	s.Require().NoError(s.repo.UpdateType(contentType))
+	updated, err := s.repo.GetTypeBySlug("product")
+	s.Require().NoError(err)
+	s.Equal("Products", updated.Name)
+
+	_, err = s.repo.GetTypeBySlug("missing")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *ContentRepositoryTestSuite) TestDeleteTypeRemovesEntries() {
+	product, event := s.createType("product", "Product"), s.createType("event", "Event")
+	s.createEntry(product.ID, "Chair")
+	s.createEntry(event.ID, "Meetup")
+
+	s.Require().NoError(s.repo.DeleteType(product.ID))
+
+	var count int64
+	s.Require().NoError(s.db.Model(&models.ContentEntry{}).Count(&count).Error)
+	s.Equal(int64(1), count)
+	_, err := s.repo.GetTypeBySlug("product")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *ContentRepositoryTestSuite) TestEntries() {
+	product, event := s.createType("product", "Product"), s.createType("event", "Event")
+	chair, table := s.createEntry(product.ID, "Chair"), s.createEntry(product.ID, "Table")
+	meetup := s.createEntry(event.ID, "Meetup")
+
+	s.Run("Paginate newest first", func() {
+		pagination, err := s.repo.PaginateEntries(product.ID, 1, 10)
+		s.Require().NoError(err)
+		s.Equal(2, pagination.TotalItems)
+		entries := pagination.Data.([]models.ContentEntry)
+		s.Equal(table.ID, entries[0].ID)
+		s.Equal("Chair", entries[1].Data["title"])
+	})
+
+	s.Run("Get scoped to the content type", func() {
+		entry, err := s.repo.GetEntry(product.ID, chair.ID)
+		s.Require().NoError(err)
+		s.Equal("Chair", entry.Data["title"])
+
+		_, err = s.repo.GetEntry(product.ID, meetup.ID)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+	})
+
+	s.Run("Get by ID of any content type", func() {
+		entry, err := s.repo.GetEntryByID(meetup.ID)
+		s.Require().NoError(err)
+		s.Equal(event.ID, entry.ContentTypeID)
+
+		_, err = s.repo.GetEntryByID(999)
+		s.ErrorIs(err, gorm.ErrRecordNotFound)
+	})
+
+	s.Run("Count", func() {
+		count, err := s.repo.CountEntries(product.ID, []uint{chair.ID, table.ID, meetup.ID})
+		s.Require().NoError(err)
+		s.Equal(int64(2), count)
+	})
+
+	s.Run("Update", func() {
+		chair.Data = map[string]any{"title": "Armchair"}
+		s.Require().NoError(s.repo.UpdateEntry(chair))
+		entry, err := s.repo.GetEntry(product.ID, chair.ID)
+		s.Require().NoError(err)
+		s.Equal("Armchair", entry.Data["title"])
+	})
+
+	s.Run("Delete scoped to the content type", func() {
+		deleted, err := s.repo.DeleteEntry(product.ID, meetup.ID)
+		s.Require().NoError(err)
+		s.Equal(int64(0), deleted)
+
+		deleted, err = s.repo.DeleteEntry(event.ID, meetup.ID)
+		s.Require().NoError(err)
+		s.Equal(int64(1), deleted)
+	})
+}
+
+func TestContentRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentRepositoryTestSuite))
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+)
+
+type IDataExportRepository interface {
+	Create(export *models.DataExport) error
+	Update(export *models.DataExport) error
+	GetByID(id uint) (*models.DataExport, error)
+	FindByUserID(userID uint) ([]models.DataExport, error)
+	FindActiveByUserID(userID uint) (*models.DataExport, error)
+	FindPending(limit int) ([]models.DataExport, error)
+	FindExpired(now time.Time, limit int) ([]models.DataExport, error)
+	DeleteByUserID(userID uint) error
+}
+
+type DataExportRepository struct {
+	db *gorm.DB
+}
+
+// NewDataExportRepository creates a new instance of DataExportRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *DataExportRepository: pointer to the newly created DataExportRepository
+func NewDataExportRepository(db *gorm.DB) *DataExportRepository {
+	return &DataExportRepository{db: db}
+}
+
+// Create stores a new data export request
+func (repo *DataExportRepository) Create(export *models.DataExport) error {
+	return repo.db.Create(export).Error
+}
+
+// Update saves the status and file of a data export request
+func (repo *DataExportRepository) Update(export *models.DataExport) error {
+	return repo.db.Save(export).Error
+}
+
+// GetByID retrieves a data export request by its ID
+func (repo *DataExportRepository) GetByID(id uint) (*models.DataExport, error) {
+	var export models.DataExport
+	if err := repo.db.First(&export, id).Error; err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// FindByUserID retrieves every data export request of a user, newest first
+func (repo *DataExportRepository) FindByUserID(userID uint) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	if err := repo.db.Where("user_id = ?", userID).Order("id DESC").Find(&exports).Error; err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// FindActiveByUserID retrieves the pending or processing data export request of a user
+// Returns gorm.ErrRecordNotFound if the user has no such request
+func (repo *DataExportRepository) FindActiveByUserID(userID uint) (*models.DataExport, error) {
+	var export models.DataExport
+	err := repo.db.Where("user_id = ? AND status IN ?", userID, []string{models.DataExportStatusPending, models.DataExportStatusProcessing}).
+		First(&export).Error
+	if err != nil {
+		return nil, err
+	}
+	return &export, nil
+}
+
+// FindPending retrieves the oldest data export requests waiting to be processed
+// Parameters:
+//   - limit: Maximum number of requests to return
+//
+// Returns:
+//   - []models.DataExport: The pending requests, oldest first
+//   - error: nil if successful, error otherwise
+func (repo *DataExportRepository) FindPending(limit int) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	if err := repo.db.Where("status = ?", models.DataExportStatusPending).Order("id ASC").Limit(limit).Find(&exports).Error; err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// FindExpired retrieves completed data exports whose archive should be deleted
+// Parameters:
+//   - now: Exports expiring before this time are returned
+//   - limit: Maximum number of exports to return
+//
+// Returns:
+//   - []models.DataExport: The expired exports
+//   - error: nil if successful, error otherwise
+func (repo *DataExportRepository) FindExpired(now time.Time, limit int) ([]models.DataExport, error) {
+	var exports []models.DataExport
+	err := repo.db.Where("status = ? AND expires_at <= ?", models.DataExportStatusCompleted, now).
+		Order("id ASC").Limit(limit).Find(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+// DeleteByUserID removes every data export request of a user
+func (repo *DataExportRepository) DeleteByUserID(userID uint) error {
+	return repo.db.Where("user_id = ?", userID).Delete(&models.DataExport{}).Error
+}
@@ -0,0 +1,116 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type DataExportRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.DataExportRepository
+}
+
+func (s *DataExportRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.DataExport{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewDataExportRepository(db)
+}
+
+func (s *DataExportRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *DataExportRepositoryTestSuite) TestCreateUpdateAndGet() {
+	export := &models.DataExport{UserID: 1, Status: models.DataExportStatusPending}
+	s.Require().NoError(s.repo.Create(export))
+	s.NotZero(export.ID)
+
+	key := "exports/1/archive.zip"
+	export.Status = models.DataExportStatusCompleted
+	export.FileKey = &key
+	s.Require().NoError(s.repo.Update(export))
+
+	found, err := s.repo.GetByID(export.ID)
+	s.NoError(err)
+	s.Equal(models.DataExportStatusCompleted, found.Status)
+	s.Equal(key, *found.FileKey)
+
+	_, err = s.repo.GetByID(999)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *DataExportRepositoryTestSuite) TestFindActiveAndPending() {
+	completed := &models.DataExport{UserID: 1, Status: models.DataExportStatusCompleted}
+	pending := &models.DataExport{UserID: 1, Status: models.DataExportStatusPending}
+	other := &models.DataExport{UserID: 2, Status: models.DataExportStatusPending}
+	for _, export := range []*models.DataExport{completed, pending, other} {
+		s.Require().NoError(s.repo.Create(export))
+	}
+
+	active, err := s.repo.FindActiveByUserID(1)
+	s.NoError(err)
+	s.Equal(pending.ID, active.ID)
+
+	_, err = s.repo.FindActiveByUserID(3)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	exports, err := s.repo.FindPending(10)
+	s.NoError(err)
+	s.Require().Len(exports, 2)
+	s.Equal(pending.ID, exports[0].ID, "oldest requests come first")
+
+	exports, err = s.repo.FindByUserID(1)
+	s.NoError(err)
+	s.Require().Len(exports, 2)
+	s.Equal(pending.ID, exports[0].ID, "newest requests come first")
+}
+
+func (s *DataExportRepositoryTestSuite) TestFindExpiredAndDelete() {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	expired := &models.DataExport{UserID: 1, Status: models.DataExportStatusCompleted, ExpiresAt: &past}
+	valid := &models.DataExport{UserID: 1, Status: models.DataExportStatusCompleted, ExpiresAt: &future}
+	s.Require().NoError(s.repo.Create(expired))
+	s.Require().NoError(s.repo.Create(valid))
+
+	exports, err := s.repo.FindExpired(time.Now(), 10)
+	s.NoError(err)
+	s.Require().Len(exports, 1)
+	s.Equal(expired.ID, exports[0].ID)
+
+	s.Require().NoError(s.repo.DeleteByUserID(1))
+	exports, err = s.repo.FindByUserID(1)
+	s.NoError(err)
+	s.Empty(exports)
+}
+
+func (s *DataExportRepositoryTestSuite) TestQueryErrors() {
+	sqlDB, err := s.db.DB()
+	s.Require().NoError(err)
+	s.Require().NoError(sqlDB.Close())
+
+	_, err = s.repo.FindPending(10)
+	s.Error(err)
+	_, err = s.repo.FindExpired(time.Now(), 10)
+	s.Error(err)
+	_, err = s.repo.FindByUserID(1)
+	s.Error(err)
+}
+
+func TestDataExportRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(DataExportRepositoryTestSuite))
+}
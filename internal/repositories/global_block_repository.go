@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+)
+
+type IGlobalBlockRepository interface {
+	PaginateGlobalBlocks(page, limit int, search string) (*utils.Pagination, error)
+	GetByID(id uint) (*models.GlobalBlock, error)
+	FindByIDs(ids []uint) ([]models.GlobalBlock, error)
+	NameExists(name string, excludeID uint) (bool, error)
+	Create(block *models.GlobalBlock) error
+	Update(block *models.GlobalBlock) error
+	Delete(id uint) (int64, error)
+}
+
+type GlobalBlockRepository struct {
+	db *gorm.DB
+}
+
+// NewGlobalBlockRepository creates a new instance of GlobalBlockRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *GlobalBlockRepository: pointer to the newly created GlobalBlockRepository
+func NewGlobalBlockRepository(db *gorm.DB) *GlobalBlockRepository {
+	return &GlobalBlockRepository{db: db}
+}
+
+// PaginateGlobalBlocks retrieves a page of global blocks ordered by name
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of global blocks per page
+//   - search: Only global blocks whose name contains this text, empty for every global block
+//
+// Returns:
+//   - *utils.Pagination: The page of global blocks
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *GlobalBlockRepository) PaginateGlobalBlocks(page, limit int, search string) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.GlobalBlock{})
+	if search != "" {
+		query = query.Where("name LIKE ?", "%"+search+"%")
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var globalBlocks []models.GlobalBlock
+	if err := query.Offset((page - 1) * limit).Limit(limit).Order("name ASC").Find(&globalBlocks).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       globalBlocks,
+	}, nil
+}
+
+// GetByID retrieves a global block by its ID
+// Parameters:
+//   - id: The ID of the global block
+//
+// Returns:
+//   - *models.GlobalBlock: The global block
+//   - error: gorm.ErrRecordNotFound if the global block does not exist, otherwise the error that occurred
+func (repo *GlobalBlockRepository) GetByID(id uint) (*models.GlobalBlock, error) {
+	var globalBlock models.GlobalBlock
+	if err := repo.db.First(&globalBlock, id).Error; err != nil {
+		return nil, err
+	}
+	return &globalBlock, nil
+}
+
+// FindByIDs retrieves the global blocks referenced by a body
+// Parameters:
+//   - ids: The IDs of the global blocks, unknown IDs are ignored
+//
+// Returns:
+//   - []models.GlobalBlock: The global blocks found, in no particular order
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *GlobalBlockRepository) FindByIDs(ids []uint) ([]models.GlobalBlock, error) {
+	var globalBlocks []models.GlobalBlock
+	if len(ids) == 0 {
+		return globalBlocks, nil
+	}
+	if err := repo.db.Where("id IN ?", ids).Find(&globalBlocks).Error; err != nil {
+		return nil, err
+	}
+	return globalBlocks, nil
+}
+
+// NameExists checks whether a name is used by a global block other than the excluded one
+// Parameters:
+//   - name: The name to look for
+//   - excludeID: ID of the global block being saved, 0 when creating a global block
+//
+// Returns:
+//   - bool: true if another global block has this name
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *GlobalBlockRepository) NameExists(name string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Model(&models.GlobalBlock{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create stores a new global block
+// Parameters:
+//   - block: The global block to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *GlobalBlockRepository) Create(block *models.GlobalBlock) error {
+	return repo.db.Create(block).Error
+}
+
+// Update saves an existing global block
+// Parameters:
+//   - block: The global block to save
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *GlobalBlockRepository) Update(block *models.GlobalBlock) error {
+	return repo.db.Save(block).Error
+}
+
+// Delete removes a global block, the bodies referencing it no longer show it
+// Parameters:
+//   - id: The ID of the global block
+//
+// Returns:
+//   - int64: The number of deleted global blocks, 0 if the global block does not exist
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *GlobalBlockRepository) Delete(id uint) (int64, error) {
+	result := repo.db.Delete(&models.GlobalBlock{}, id)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,87 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type GlobalBlockRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.GlobalBlockRepository
+}
+
+func (s *GlobalBlockRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.GlobalBlock{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewGlobalBlockRepository(db)
+}
+
+func (s *GlobalBlockRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *GlobalBlockRepositoryTestSuite) TestCRUD() {
+	newsletter := &models.GlobalBlock{Name: "Newsletter", Blocks: []blocks.Block{
+		{Type: blocks.TypeCallToAction, Data: map[string]any{"label": "Subscribe", "url": "/newsletter"}},
+	}}
+	footer := &models.GlobalBlock{Name: "Footer", Blocks: []blocks.Block{}}
+	s.Require().NoError(s.repo.Create(newsletter))
+	s.Require().NoError(s.repo.Create(footer))
+
+	found, err := s.repo.GetByID(newsletter.ID)
+	s.Require().NoError(err)
+	s.Require().Len(found.Blocks, 1)
+	s.Equal("Subscribe", found.Blocks[0].Data["label"])
+
+	list, err := s.repo.FindByIDs([]uint{newsletter.ID, 99})
+	s.Require().NoError(err)
+	s.Len(list, 1)
+	list, err = s.repo.FindByIDs(nil)
+	s.Require().NoError(err)
+	s.Empty(list)
+
+	pagination, err := s.repo.PaginateGlobalBlocks(1, 10, "")
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+	s.Equal("Footer", pagination.Data.([]models.GlobalBlock)[0].Name)
+	pagination, err = s.repo.PaginateGlobalBlocks(1, 10, "news")
+	s.Require().NoError(err)
+	s.Equal(1, pagination.TotalItems)
+
+	exists, err := s.repo.NameExists("Newsletter", footer.ID)
+	s.Require().NoError(err)
+	s.True(exists)
+	exists, err = s.repo.NameExists("Newsletter", newsletter.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	found.Blocks = append(found.Blocks, blocks.Block{Type: blocks.TypeParagraph, Data: map[string]any{"text": "Weekly"}})
+	s.Require().NoError(s.repo.Update(found))
+	found, err = s.repo.GetByID(newsletter.ID)
+	s.Require().NoError(err)
+	s.Len(found.Blocks, 2)
+
+	deleted, err := s.repo.Delete(newsletter.ID)
+	s.Require().NoError(err)
+	s.Equal(int64(1), deleted)
+	_, err = s.repo.GetByID(newsletter.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func TestGlobalBlockRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(GlobalBlockRepositoryTestSuite))
+}
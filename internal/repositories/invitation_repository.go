@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IInvitationRepository interface {
+	CreateWithTx(tx *gorm.DB, invitation *models.Invitation) error
+	RevokePendingWithTx(tx *gorm.DB, userID uint, revokedAt time.Time) error
+	Update(invitation *models.Invitation) error
+	GetByID(id uint) (*models.Invitation, error)
+	FindByTokenHash(tokenHash string) (*models.Invitation, error)
+	Paginate(page, limit int, status string) (*utils.Pagination, error)
+	Accept(invitation *models.Invitation, user *models.User) error
+}
+
+type InvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository creates a new instance of InvitationRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *InvitationRepository: pointer to the newly created InvitationRepository
+func NewInvitationRepository(db *gorm.DB) *InvitationRepository {
+	return &InvitationRepository{db: db}
+}
+
+// CreateWithTx stores a new invitation within a transaction
+// Parameters:
+//   - tx: Pointer to the gorm.DB transaction
+//   - invitation: The invitation to create
+//
+// Returns:
+//   - error: nil if successful, error otherwise
+func (repo *InvitationRepository) CreateWithTx(tx *gorm.DB, invitation *models.Invitation) error {
+	return tx.Omit(clause.Associations).Create(invitation).Error
+}
+
+// RevokePendingWithTx revokes every pending invitation of a user within a transaction
+// Parameters:
+//   - tx: Pointer to the gorm.DB transaction
+//   - userID: The ID of the invited user
+//   - revokedAt: The time stored as revocation time
+//
+// Returns:
+//   - error: nil if successful, error otherwise
+func (repo *InvitationRepository) RevokePendingWithTx(tx *gorm.DB, userID uint, revokedAt time.Time) error {
+	return tx.Model(&models.Invitation{}).
+		Where("user_id = ? AND status = ?", userID, models.InvitationStatusPending).
+		Updates(map[string]any{"status": models.InvitationStatusRevoked, "revoked_at": revokedAt}).Error
+}
+
+// Update saves an existing invitation
+func (repo *InvitationRepository) Update(invitation *models.Invitation) error {
+	return repo.db.Omit(clause.Associations).Save(invitation).Error
+}
+
+// GetByID retrieves an invitation by its ID together with the invited user
+func (repo *InvitationRepository) GetByID(id uint) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := repo.db.Preload("User").First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindByTokenHash retrieves an invitation by the hash of its token together with the invited user
+func (repo *InvitationRepository) FindByTokenHash(tokenHash string) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := repo.db.Preload("User").Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// Paginate retrieves invitations with their invited users, newest first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of invitations per page
+//   - status: Only invitations with this status are returned, empty returns all
+//
+// Returns:
+//   - *utils.Pagination: The page of invitations
+//   - error: nil if successful, error otherwise
+func (repo *InvitationRepository) Paginate(page, limit int, status string) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.Invitation{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var invitations []models.Invitation
+	if err := query.Preload("User").Offset((page - 1) * limit).Limit(limit).Order("id DESC").Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       invitations,
+	}, nil
+}
+
+// Accept saves the accepted invitation and the activated user in a single transaction
+// Parameters:
+//   - invitation: The invitation with its accepted status set
+//   - user: The invited user with their password and active status set
+//
+// Returns:
+//   - error: nil if successful, error otherwise
+func (repo *InvitationRepository) Accept(invitation *models.Invitation, user *models.User) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := saveVersioned(tx, user, &user.Version); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Save(invitation).Error
+	})
+}
@@ -0,0 +1,136 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type InvitationRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.InvitationRepository
+	user *models.User
+}
+
+func (s *InvitationRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Invitation{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewInvitationRepository(db)
+
+	s.user = &models.User{Email: "invited@example.com", Name: "Invited", Password: "x", Status: models.UserStatusInvited}
+	s.Require().NoError(db.Create(s.user).Error)
+}
+
+func (s *InvitationRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *InvitationRepositoryTestSuite) newInvitation(tokenHash, status string) *models.Invitation {
+	invitation := &models.Invitation{
+		UserID:    s.user.ID,
+		InvitedBy: 1,
+		TokenHash: tokenHash,
+		Status:    status,
+		ExpiresAt: time.Now().Add(time.Hour),
+		SentAt:    time.Now(),
+	}
+	s.Require().NoError(s.repo.CreateWithTx(s.db, invitation))
+	return invitation
+}
+
+func (s *InvitationRepositoryTestSuite) TestCreateAndFind() {
+	invitation := s.newInvitation("hash-1", models.InvitationStatusPending)
+	s.NotZero(invitation.ID)
+
+	found, err := s.repo.GetByID(invitation.ID)
+	s.NoError(err)
+	s.Require().NotNil(found.User)
+	s.Equal("invited@example.com", found.User.Email)
+
+	found, err = s.repo.FindByTokenHash("hash-1")
+	s.NoError(err)
+	s.Equal(invitation.ID, found.ID)
+	s.NotNil(found.User)
+
+	_, err = s.repo.FindByTokenHash("unknown")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	_, err = s.repo.GetByID(999)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *InvitationRepositoryTestSuite) TestRevokePendingWithTx() {
+	pending := s.newInvitation("hash-1", models.InvitationStatusPending)
+	accepted := s.newInvitation("hash-2", models.InvitationStatusAccepted)
+
+	s.Require().NoError(s.repo.RevokePendingWithTx(s.db, s.user.ID, time.Now()))
+
+	found, err := s.repo.GetByID(pending.ID)
+	s.Require().NoError(err)
+	s.Equal(models.InvitationStatusRevoked, found.Status)
+	s.NotNil(found.RevokedAt)
+
+	found, err = s.repo.GetByID(accepted.ID)
+	s.Require().NoError(err)
+	s.Equal(models.InvitationStatusAccepted, found.Status)
+	s.Nil(found.RevokedAt)
+}
+
+func (s *InvitationRepositoryTestSuite) TestPaginate() {
+	s.newInvitation("hash-1", models.InvitationStatusRevoked)
+	second := s.newInvitation("hash-2", models.InvitationStatusPending)
+	third := s.newInvitation("hash-3", models.InvitationStatusPending)
+
+	pagination, err := s.repo.Paginate(1, 10, models.InvitationStatusPending)
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+	invitations := pagination.Data.([]models.Invitation)
+	s.Require().Len(invitations, 2)
+	s.Equal(third.ID, invitations[0].ID)
+	s.Equal(second.ID, invitations[1].ID)
+	s.NotNil(invitations[0].User)
+
+	pagination, err = s.repo.Paginate(1, 10, "")
+	s.Require().NoError(err)
+	s.Equal(3, pagination.TotalItems)
+	s.Equal(utils.CalculateTotalPages(3, 10), pagination.TotalPages)
+}
+
+func (s *InvitationRepositoryTestSuite) TestAccept() {
+	invitation := s.newInvitation("hash-1", models.InvitationStatusPending)
+	invitation, err := s.repo.GetByID(invitation.ID)
+	s.Require().NoError(err)
+
+	now := time.Now()
+	invitation.Status = models.InvitationStatusAccepted
+	invitation.AcceptedAt = &now
+	invitation.User.Status = models.UserStatusActive
+	invitation.User.Password = "hashed"
+
+	s.Require().NoError(s.repo.Accept(invitation, invitation.User))
+
+	found, err := s.repo.GetByID(invitation.ID)
+	s.Require().NoError(err)
+	s.Equal(models.InvitationStatusAccepted, found.Status)
+	s.NotNil(found.AcceptedAt)
+	s.Equal(models.UserStatusActive, found.User.Status)
+	s.Equal("hashed", found.User.Password)
+}
+
+func TestInvitationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(InvitationRepositoryTestSuite))
+}
@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MediaFilter holds the optional criteria applied when listing media
+type MediaFilter struct {
+	FolderID   uint   // Only media in this folder, 0 for any folder
+	MimePrefix string // Only media whose MIME type starts with this prefix (e.g. "image/"), empty for any type
+	Search     string // Only media whose file name, alt text or caption contains this text, empty for every file
+}
+
+type IMediaRepository interface {
+	PaginateMedia(page, limit int, filter MediaFilter) (*utils.Pagination, error)
+	GetByID(id uint) (*models.Media, error)
+	FindByIDs(ids []uint) ([]models.Media, error)
+	FindByChecksum(checksum string) (*models.Media, error)
+	Create(media *models.Media) error
+	Update(media *models.Media) error
+	Delete(id uint) error
+	GetFolders() ([]models.MediaFolder, error)
+	GetFolderByID(id uint) (*models.MediaFolder, error)
+	FolderNameExists(parentID *uint, name string, excludeID uint) (bool, error)
+	CreateFolder(folder *models.MediaFolder) error
+	UpdateFolder(folder *models.MediaFolder) error
+	DeleteFolder(id uint) error
+}
+
+type MediaRepository struct {
+	db *gorm.DB
+}
+
+// NewMediaRepository creates a new instance of MediaRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *MediaRepository: pointer to the newly created MediaRepository
+func NewMediaRepository(db *gorm.DB) *MediaRepository {
+	return &MediaRepository{db: db}
+}
+
+// PaginateMedia retrieves a page of media, most recent uploads first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of media per page
+//   - filter: Optional criteria the media must match
+//
+// Returns:
+//   - *utils.Pagination: The page of media
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) PaginateMedia(page, limit int, filter MediaFilter) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.Media{})
+	if filter.FolderID != 0 {
+		query = query.Where("folder_id = ?", filter.FolderID)
+	}
+	if filter.MimePrefix != "" {
+		query = query.Where("mime_type LIKE ?", filter.MimePrefix+"%")
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("file_name LIKE ? OR alt_text LIKE ? OR caption LIKE ?", search, search, search)
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var media []models.Media
+	if err := query.Offset((page - 1) * limit).Limit(limit).Order("id DESC").Find(&media).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       media,
+	}, nil
+}
+
+// GetByID retrieves a media file by its ID
+// Parameters:
+//   - id: The ID of the media
+//
+// Returns:
+//   - *models.Media: The media with its folder
+//   - error: gorm.ErrRecordNotFound if the media does not exist, otherwise the error that occurred
+func (repo *MediaRepository) GetByID(id uint) (*models.Media, error) {
+	var media models.Media
+	if err := repo.db.Preload("Folder").First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// FindByIDs retrieves the media files with the given IDs, missing IDs are skipped
+// Parameters:
+//   - ids: The IDs of the media
+//
+// Returns:
+//   - []models.Media: The media files found, without their folder
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) FindByIDs(ids []uint) ([]models.Media, error) {
+	var media []models.Media
+	if len(ids) == 0 {
+		return media, nil
+	}
+	if err := repo.db.Where("id IN ?", ids).Find(&media).Error; err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// FindByChecksum retrieves the media file with the given content checksum
+// Parameters:
+//   - checksum: Hex encoded SHA-256 of the file content
+//
+// Returns:
+//   - *models.Media: The media
+//   - error: gorm.ErrRecordNotFound if no media has this checksum, otherwise the error that occurred
+func (repo *MediaRepository) FindByChecksum(checksum string) (*models.Media, error) {
+	var media models.Media
+	if err := repo.db.Where("checksum = ?", checksum).First(&media).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// Create stores a new media record
+// Parameters:
+//   - media: The media to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) Create(media *models.Media) error {
+	return repo.db.Omit(clause.Associations).Create(media).Error
+}
+
+// Update saves an existing media record
+// Parameters:
+//   - media: The media to save
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) Update(media *models.Media) error {
+	return repo.db.Omit(clause.Associations).Save(media).Error
+}
+
+// Delete removes a media record, the stored files are removed by the caller
+// Parameters:
+//   - id: The ID of the media
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) Delete(id uint) error {
+	return repo.db.Delete(&models.Media{}, id).Error
+}
+
+// GetFolders retrieves every media folder ordered by name
+// Returns:
+//   - []models.MediaFolder: Flat list of all folders
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) GetFolders() ([]models.MediaFolder, error) {
+	var folders []models.MediaFolder
+	if err := repo.db.Order("name ASC, id ASC").Find(&folders).Error; err != nil {
+		return nil, err
+	}
+	return folders, nil
+}
+
+// GetFolderByID retrieves a media folder by its ID
+// Parameters:
+//   - id: The ID of the folder
+//
+// Returns:
+//   - *models.MediaFolder: The folder
+//   - error: gorm.ErrRecordNotFound if the folder does not exist, otherwise the error that occurred
+func (repo *MediaRepository) GetFolderByID(id uint) (*models.MediaFolder, error) {
+	var folder models.MediaFolder
+	if err := repo.db.First(&folder, id).Error; err != nil {
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// FolderNameExists checks whether a parent already holds a folder with the given name, other than the excluded one
+// Parameters:
+//   - parentID: ID of the parent folder, nil for the root of the library
+//   - name: The folder name to look for
+//   - excludeID: ID of the folder being saved, 0 when creating a folder
+//
+// Returns:
+//   - bool: true if another folder of the parent has this name
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) FolderNameExists(parentID *uint, name string, excludeID uint) (bool, error) {
+	query := repo.db.Model(&models.MediaFolder{}).Where("name = ? AND id <> ?", name, excludeID)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateFolder stores a new media folder
+// Parameters:
+//   - folder: The folder to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) CreateFolder(folder *models.MediaFolder) error {
+	return repo.db.Omit(clause.Associations).Create(folder).Error
+}
+
+// UpdateFolder saves an existing media folder
+// Parameters:
+//   - folder: The folder to save
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) UpdateFolder(folder *models.MediaFolder) error {
+	return repo.db.Omit(clause.Associations).Save(folder).Error
+}
+
+// DeleteFolder removes a media folder, its files are moved to the root of the library
+// Parameters:
+//   - id: The ID of the folder
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MediaRepository) DeleteFolder(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Media{}).
+			Where("folder_id = ?", id).
+			Update("folder_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.MediaFolder{}, id).Error
+	})
+}
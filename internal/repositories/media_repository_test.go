@@ -0,0 +1,154 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type MediaRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.MediaRepository
+}
+
+func (s *MediaRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.MediaFolder{}, &models.Media{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewMediaRepository(db)
+}
+
+func (s *MediaRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *MediaRepositoryTestSuite) createMedia(fileName, mimeType, checksum string, folderID *uint) *models.Media {
+	media := &models.Media{
+		FolderID: folderID,
+		FileName: fileName,
+		Key:      "media/" + fileName,
+		URL:      "http://localhost/storage/media/" + fileName,
+		MimeType: mimeType,
+		Size:     100,
+		Checksum: checksum,
+	}
+	s.Require().NoError(s.repo.Create(media))
+	return media
+}
+
+func (s *MediaRepositoryTestSuite) TestPaginateMedia() {
+	folder := &models.MediaFolder{Name: "Logos"}
+	s.Require().NoError(s.repo.CreateFolder(folder))
+
+	logo := s.createMedia("logo.png", "image/png", "a", &folder.ID)
+	logo.AltText = utils.StringToPtr("Company banner")
+	s.Require().NoError(s.repo.Update(logo))
+	s.createMedia("photo.jpg", "image/jpeg", "b", nil)
+	s.createMedia("report.pdf", "application/pdf", "c", nil)
+
+	pagination, err := s.repo.PaginateMedia(1, 10, repositories.MediaFilter{})
+	s.Require().NoError(err)
+	s.Equal(3, pagination.TotalItems)
+	media := pagination.Data.([]models.Media)
+	s.Equal("report.pdf", media[0].FileName)
+
+	pagination, err = s.repo.PaginateMedia(1, 10, repositories.MediaFilter{MimePrefix: "image/"})
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+
+	pagination, err = s.repo.PaginateMedia(1, 10, repositories.MediaFilter{FolderID: folder.ID})
+	s.Require().NoError(err)
+	s.Equal(1, pagination.TotalItems)
+
+	// The search matches the alt text as well as the file name
+	pagination, err = s.repo.PaginateMedia(1, 10, repositories.MediaFilter{Search: "banner"})
+	s.Require().NoError(err)
+	s.Require().Equal(1, pagination.TotalItems)
+	s.Equal(logo.ID, pagination.Data.([]models.Media)[0].ID)
+
+	pagination, err = s.repo.PaginateMedia(1, 10, repositories.MediaFilter{Search: "photo", MimePrefix: "application/"})
+	s.Require().NoError(err)
+	s.Zero(pagination.TotalItems)
+}
+
+func (s *MediaRepositoryTestSuite) TestFindByChecksum() {
+	media := s.createMedia("logo.png", "image/png", "abc", nil)
+	media.Variants = map[string]models.MediaVariant{
+		models.MediaVariantThumbnail: {Key: "media/logo-thumbnail.png", URL: "http://localhost/thumb", Width: 150, Height: 150},
+	}
+	s.Require().NoError(s.repo.Update(media))
+
+	found, err := s.repo.FindByChecksum("abc")
+	s.Require().NoError(err)
+	s.Equal(media.ID, found.ID)
+	s.Equal(150, found.Variants[models.MediaVariantThumbnail].Width)
+
+	_, err = s.repo.FindByChecksum("missing")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	s.Require().NoError(s.repo.Delete(media.ID))
+	_, err = s.repo.GetByID(media.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *MediaRepositoryTestSuite) TestFindByIDs() {
+	logo := s.createMedia("logo.png", "image/png", "a", nil)
+	photo := s.createMedia("photo.jpg", "image/jpeg", "b", nil)
+
+	media, err := s.repo.FindByIDs([]uint{photo.ID, logo.ID, 999})
+	s.Require().NoError(err)
+	s.Len(media, 2)
+
+	media, err = s.repo.FindByIDs(nil)
+	s.Require().NoError(err)
+	s.Empty(media)
+}
+
+func (s *MediaRepositoryTestSuite) TestFolders() {
+	root := &models.MediaFolder{Name: "Images"}
+	s.Require().NoError(s.repo.CreateFolder(root))
+	child := &models.MediaFolder{Name: "Logos", ParentID: &root.ID}
+	s.Require().NoError(s.repo.CreateFolder(child))
+
+	exists, err := s.repo.FolderNameExists(nil, "Images", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+	exists, err = s.repo.FolderNameExists(nil, "Images", root.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+	exists, err = s.repo.FolderNameExists(&root.ID, "Logos", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+	exists, err = s.repo.FolderNameExists(nil, "Logos", 0)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	folders, err := s.repo.GetFolders()
+	s.Require().NoError(err)
+	s.Len(folders, 2)
+
+	// Deleting a folder moves its files to the root of the library
+	media := s.createMedia("logo.png", "image/png", "abc", &child.ID)
+	s.Require().NoError(s.repo.DeleteFolder(child.ID))
+	_, err = s.repo.GetFolderByID(child.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	found, err := s.repo.GetByID(media.ID)
+	s.Require().NoError(err)
+	s.Nil(found.FolderID)
+}
+
+func TestMediaRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MediaRepositoryTestSuite))
+}
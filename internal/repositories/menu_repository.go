@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IMenuRepository interface {
+	GetAll() ([]models.Menu, error)
+	GetByID(id uint) (*models.Menu, error)
+	FindByHandle(handle string) (*models.Menu, error)
+	HandleExists(handle string, excludeID uint) (bool, error)
+	Create(menu *models.Menu) error
+	Update(menu *models.Menu) error
+	Delete(id uint) error
+	GetItems(menuID uint) ([]models.MenuItem, error)
+	ReplaceItems(menuID uint, items []models.MenuItem) error
+}
+
+type MenuRepository struct {
+	db *gorm.DB
+}
+
+// NewMenuRepository creates a new instance of MenuRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *MenuRepository: pointer to the newly created MenuRepository
+func NewMenuRepository(db *gorm.DB) *MenuRepository {
+	return &MenuRepository{db: db}
+}
+
+// GetAll retrieves every menu ordered by name, without their items
+// Returns:
+//   - []models.Menu: The menus
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MenuRepository) GetAll() ([]models.Menu, error) {
+	var menus []models.Menu
+	if err := repo.db.Order("name ASC, id ASC").Find(&menus).Error; err != nil {
+		return nil, err
+	}
+	return menus, nil
+}
+
+// GetByID retrieves a menu by its ID, without its items
+// Parameters:
+//   - id: The ID of the menu
+//
+// Returns:
+//   - *models.Menu: The menu
+//   - error: gorm.ErrRecordNotFound if the menu does not exist, otherwise the error that occurred
+func (repo *MenuRepository) GetByID(id uint) (*models.Menu, error) {
+	var menu models.Menu
+	if err := repo.db.First(&menu, id).Error; err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+// FindByHandle retrieves a menu by its handle, without its items
+// Parameters:
+//   - handle: The handle of the menu, e.g. "main"
+//
+// Returns:
+//   - *models.Menu: The menu
+//   - error: gorm.ErrRecordNotFound if no menu has this handle, otherwise the error that occurred
+func (repo *MenuRepository) FindByHandle(handle string) (*models.Menu, error) {
+	var menu models.Menu
+	if err := repo.db.Where("handle = ?", handle).First(&menu).Error; err != nil {
+		return nil, err
+	}
+	return &menu, nil
+}
+
+// HandleExists checks whether a handle is used by a menu other than the excluded one
+// Parameters:
+//   - handle: The handle to look for
+//   - excludeID: ID of the menu being saved, 0 when creating a menu
+//
+// Returns:
+//   - bool: true if another menu uses the handle
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MenuRepository) HandleExists(handle string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Model(&models.Menu{}).
+		Where("handle = ? AND id <> ?", handle, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create stores a new menu
+// Parameters:
+//   - menu: The menu to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MenuRepository) Create(menu *models.Menu) error {
+	return repo.db.Create(menu).Error
+}
+
+// Update saves the name and handle of an existing menu
+// Parameters:
+//   - menu: The menu to save
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MenuRepository) Update(menu *models.Menu) error {
+	return repo.db.Save(menu).Error
+}
+
+// Delete removes a menu together with its items
+// Parameters:
+//   - id: The ID of the menu
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MenuRepository) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("menu_id = ?", id).Delete(&models.MenuItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Menu{}, id).Error
+	})
+}
+
+// GetItems retrieves the items of a menu ordered by position within their parents
+// Parameters:
+//   - menuID: The ID of the menu
+//
+// Returns:
+//   - []models.MenuItem: Flat list of the items, the tree is built by the caller
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MenuRepository) GetItems(menuID uint) ([]models.MenuItem, error) {
+	var items []models.MenuItem
+	if err := repo.db.Where("menu_id = ?", menuID).Order("position ASC, id ASC").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ReplaceItems replaces every item of a menu by a new tree of items within a single transaction
+// Parameters:
+//   - menuID: The ID of the menu
+//   - items: The root items, nested items are read from their Children, IDs are set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *MenuRepository) ReplaceItems(menuID uint, items []models.MenuItem) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("menu_id = ?", menuID).Delete(&models.MenuItem{}).Error; err != nil {
+			return err
+		}
+
+		var insert func(parentID *uint, items []models.MenuItem) error
+		insert = func(parentID *uint, items []models.MenuItem) error {
+			for i := range items {
+				items[i].ID = 0
+				items[i].MenuID = menuID
+				items[i].ParentID = parentID
+				items[i].Position = i
+				if err := tx.Omit(clause.Associations).Create(&items[i]).Error; err != nil {
+					return err
+				}
+				if err := insert(&items[i].ID, items[i].Children); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return insert(nil, items)
+	})
+}
@@ -0,0 +1,105 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type MenuRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.MenuRepository
+}
+
+func (s *MenuRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.Menu{}, &models.MenuItem{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewMenuRepository(db)
+}
+
+func (s *MenuRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *MenuRepositoryTestSuite) TestReplaceItems() {
+	menu := &models.Menu{Name: "Main", Handle: "main"}
+	s.Require().NoError(s.repo.Create(menu))
+
+	pageID := uint(3)
+	items := []models.MenuItem{
+		{Type: models.MenuItemTypePage, TargetID: &pageID, Children: []models.MenuItem{
+			{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("Docs"), URL: utils.StringToPtr("https://example.com")},
+			{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("Blog"), URL: utils.StringToPtr("/blog")},
+		}},
+		{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("Contact"), URL: utils.StringToPtr("/contact")},
+	}
+	s.Require().NoError(s.repo.ReplaceItems(menu.ID, items))
+	s.NotZero(items[0].ID)
+	s.Equal(items[0].ID, *items[0].Children[1].ParentID)
+
+	stored, err := s.repo.GetItems(menu.ID)
+	s.Require().NoError(err)
+	s.Require().Len(stored, 4)
+	s.Equal(1, items[1].Position)
+	s.Equal(1, items[0].Children[1].Position)
+
+	// Replacing the items removes the previous ones
+	s.Require().NoError(s.repo.ReplaceItems(menu.ID, []models.MenuItem{
+		{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("Home"), URL: utils.StringToPtr("/")},
+	}))
+	stored, err = s.repo.GetItems(menu.ID)
+	s.Require().NoError(err)
+	s.Require().Len(stored, 1)
+	s.Equal("Home", *stored[0].Label)
+	s.Nil(stored[0].ParentID)
+}
+
+func (s *MenuRepositoryTestSuite) TestMenus() {
+	footer := &models.Menu{Name: "Footer", Handle: "footer"}
+	s.Require().NoError(s.repo.Create(footer))
+	main := &models.Menu{Name: "Main", Handle: "main"}
+	s.Require().NoError(s.repo.Create(main))
+
+	menus, err := s.repo.GetAll()
+	s.Require().NoError(err)
+	s.Require().Len(menus, 2)
+	s.Equal("Footer", menus[0].Name)
+
+	found, err := s.repo.FindByHandle("main")
+	s.Require().NoError(err)
+	s.Equal(main.ID, found.ID)
+
+	exists, err := s.repo.HandleExists("main", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+	exists, err = s.repo.HandleExists("main", main.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	s.Require().NoError(s.repo.ReplaceItems(main.ID, []models.MenuItem{
+		{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("Home"), URL: utils.StringToPtr("/")},
+	}))
+	s.Require().NoError(s.repo.Delete(main.ID))
+	_, err = s.repo.GetByID(main.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	items, err := s.repo.GetItems(main.ID)
+	s.Require().NoError(err)
+	s.Empty(items)
+}
+
+func TestMenuRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MenuRepositoryTestSuite))
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IPageRepository interface {
+	GetAll() ([]models.Page, error)
+	GetByID(id uint) (*models.Page, error)
+	FindPublishedByPath(path string) (*models.Page, error)
+	PathExists(path string, excludeID uint) (bool, error)
+	Create(page *models.Page) error
+	Update(page *models.Page, paths map[uint]string) error
+	UpdatePositions(parentID *uint, orderedIDs []uint, paths map[uint]string) error
+	Delete(id uint) error
+}
+
+type PageRepository struct {
+	db *gorm.DB
+}
+
+// NewPageRepository creates a new instance of PageRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *PageRepository: pointer to the newly created PageRepository
+func NewPageRepository(db *gorm.DB) *PageRepository {
+	return &PageRepository{db: db}
+}
+
+// GetAll retrieves every page without its body, ordered by position within their parents
+// Returns:
+//   - []models.Page: Flat list of all pages, the tree is built by the caller
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PageRepository) GetAll() ([]models.Page, error) {
+	var pages []models.Page
+	if err := repo.db.Omit("body").Order("position ASC, id ASC").Find(&pages).Error; err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// GetByID retrieves a page by its ID
+// Parameters:
+//   - id: The ID of the page
+//
+// Returns:
+//   - *models.Page: The page
+//   - error: gorm.ErrRecordNotFound if the page does not exist, otherwise the error that occurred
+func (repo *PageRepository) GetByID(id uint) (*models.Page, error) {
+	var page models.Page
+	if err := repo.db.First(&page, id).Error; err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// FindPublishedByPath retrieves a published page by its full path
+// Parameters:
+//   - path: The path of the page, e.g. "about/team"
+//
+// Returns:
+//   - *models.Page: The page
+//   - error: gorm.ErrRecordNotFound if no published page has this path, otherwise the error that occurred
+func (repo *PageRepository) FindPublishedByPath(path string) (*models.Page, error) {
+	var page models.Page
+	if err := repo.db.
+		Where("path = ? AND status = ?", path, models.PageStatusPublished).
+		First(&page).Error; err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// PathExists checks whether a path is used by a page other than the excluded one
+// Parameters:
+//   - path: The path to look for
+//   - excludeID: ID of the page being saved, 0 when creating a page
+//
+// Returns:
+//   - bool: true if another page uses the path
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PageRepository) PathExists(path string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Model(&models.Page{}).
+		Where("path = ? AND id <> ?", path, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create stores a new page
+// Parameters:
+//   - page: The page to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PageRepository) Create(page *models.Page) error {
+	return repo.db.Omit(clause.Associations).Create(page).Error
+}
+
+// Update saves an existing page and the new paths of its descendants within a single transaction
+// The parent and position of the page are only changed by UpdatePositions, the old paths of published pages are redirected
+// Parameters:
+//   - page: The page to save
+//   - paths: New paths of the descendants of the page keyed by page ID, empty when the path did not change
+//
+// Returns:
+//   - error: ErrVersionConflict if the page was saved or moved since its version was loaded, otherwise the error that occurred
+func (repo *PageRepository) Update(page *models.Page, paths map[uint]string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		moved := map[uint]string{page.ID: page.Path}
+		for id, path := range paths {
+			moved[id] = path
+		}
+		moves, err := pageMoves(tx, moved)
+		if err != nil {
+			return err
+		}
+
+		if err := saveVersioned(tx, page, &page.Version, "parent_id", "position"); err != nil {
+			return err
+		}
+		if err := updatePagePaths(tx, paths); err != nil {
+			return err
+		}
+		return recordMoves(tx, moves)
+	})
+}
+
+// UpdatePositions places pages under a parent in the given order and saves the new paths of the moved pages
+// The old paths of published pages are redirected to the new ones
+// Parameters:
+//   - parentID: ID of the parent of the pages, nil for root pages
+//   - orderedIDs: IDs of the pages, the position of each page is its index in the slice
+//   - paths: New paths keyed by page ID, for the moved page and its descendants
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PageRepository) UpdatePositions(parentID *uint, orderedIDs []uint, paths map[uint]string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		moves, err := pageMoves(tx, paths)
+		if err != nil {
+			return err
+		}
+
+		for position, id := range orderedIDs {
+			if err := tx.Model(&models.Page{ID: id}).
+				Updates(map[string]any{"parent_id": parentID, "position": position}).Error; err != nil {
+				return err
+			}
+		}
+		if err := updatePagePaths(tx, paths); err != nil {
+			return err
+		}
+		return recordMoves(tx, moves)
+	})
+}
+
+// Delete removes a page together with its translations
+// Parameters:
+//   - id: The ID of the page
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PageRepository) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("content_type = ? AND content_id = ?", models.TranslationTypePage, id).Delete(&models.Translation{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Page{}, id).Error
+	})
+}
+
+// pageMoves returns the new URLs of the published pages whose path changes keyed by their current URL
+func pageMoves(tx *gorm.DB, paths map[uint]string) (map[string]string, error) {
+	moves := map[string]string{}
+	if len(paths) == 0 {
+		return moves, nil
+	}
+
+	ids := make([]uint, 0, len(paths))
+	for id := range paths {
+		ids = append(ids, id)
+	}
+	var pages []models.Page
+	if err := tx.Select("id", "path").
+		Where("id IN ? AND status = ?", ids, models.PageStatusPublished).
+		Find(&pages).Error; err != nil {
+		return nil, err
+	}
+
+	for _, page := range pages {
+		if page.Path != paths[page.ID] {
+			moves["/"+page.Path] = "/" + paths[page.ID]
+		}
+	}
+	return moves, nil
+}
+
+// updatePagePaths saves the paths of pages keyed by page ID
+func updatePagePaths(tx *gorm.DB, paths map[uint]string) error {
+	for id, path := range paths {
+		// The path is saved by edits of the page, so a moved page is a new version
+		if err := tx.Model(&models.Page{ID: id}).
+			Updates(map[string]any{"path": path, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type PageRepositoryTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	repo   *repositories.PageRepository
+	author *models.User
+}
+
+func (s *PageRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Page{}, &models.Translation{}, &models.Redirect{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewPageRepository(db)
+
+	s.author = &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(db.Create(s.author).Error)
+}
+
+func (s *PageRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *PageRepositoryTestSuite) createPage(parentID *uint, slug, path, status string, position int) *models.Page {
+	page := &models.Page{
+		ParentID: parentID,
+		Title:    slug,
+		Slug:     slug,
+		Path:     path,
+		Body:     "Body of " + slug,
+		Template: models.PageTemplateDefault,
+		Status:   status,
+		Position: position,
+		AuthorID: s.author.ID,
+	}
+	s.Require().NoError(s.repo.Create(page))
+	return page
+}
+
+func (s *PageRepositoryTestSuite) TestGetAllAndFind() {
+	about := s.createPage(nil, "about", "about", models.PageStatusPublished, 1)
+	s.createPage(&about.ID, "team", "about/team", models.PageStatusDraft, 0)
+	s.createPage(nil, "contact", "contact", models.PageStatusPublished, 0)
+
+	pages, err := s.repo.GetAll()
+	s.Require().NoError(err)
+	s.Require().Len(pages, 3)
+	s.Equal("about", pages[2].Path)
+	s.Empty(pages[0].Body, "the body is left out of the tree")
+
+	page, err := s.repo.FindPublishedByPath("about")
+	s.Require().NoError(err)
+	s.Equal("Body of about", page.Body)
+
+	_, err = s.repo.FindPublishedByPath("about/team")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	exists, err := s.repo.PathExists("about/team", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+	exists, err = s.repo.PathExists("about", about.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *PageRepositoryTestSuite) TestUpdateRewritesPaths() {
+	about := s.createPage(nil, "about", "about", models.PageStatusPublished, 0)
+	team := s.createPage(&about.ID, "team", "about/team", models.PageStatusPublished, 0)
+
+	about.Slug = "company"
+	about.Path = "company"
+	about.Position = 5
+	s.Require().NoError(s.repo.Update(about, map[uint]string{team.ID: "company/team"}))
+
+	found, err := s.repo.GetByID(about.ID)
+	s.Require().NoError(err)
+	s.Equal("company", found.Path)
+	s.Zero(found.Position, "the position is only changed by UpdatePositions")
+
+	found, err = s.repo.GetByID(team.ID)
+	s.Require().NoError(err)
+	s.Equal("company/team", found.Path)
+	s.Equal(uint(2), found.Version, "a moved descendant is a new version")
+
+	// An edit loaded before the descendant moved would save its old path
+	team.Title = "Our team"
+	s.ErrorIs(s.repo.Update(team, map[uint]string{}), repositories.ErrVersionConflict)
+}
+
+func (s *PageRepositoryTestSuite) TestUpdatePositions() {
+	about := s.createPage(nil, "about", "about", models.PageStatusPublished, 0)
+	contact := s.createPage(nil, "contact", "contact", models.PageStatusPublished, 1)
+	team := s.createPage(&about.ID, "team", "about/team", models.PageStatusPublished, 0)
+
+	// Move contact under about, before team
+	err := s.repo.UpdatePositions(&about.ID, []uint{contact.ID, team.ID}, map[uint]string{contact.ID: "about/contact"})
+	s.Require().NoError(err)
+
+	found, err := s.repo.GetByID(contact.ID)
+	s.Require().NoError(err)
+	s.Equal(about.ID, *found.ParentID)
+	s.Equal("about/contact", found.Path)
+	s.Zero(found.Position)
+
+	found, err = s.repo.GetByID(team.ID)
+	s.Require().NoError(err)
+	s.Equal(1, found.Position)
+
+	s.Require().NoError(s.db.Create(&models.Translation{ContentType: models.TranslationTypePage, ContentID: team.ID, Locale: "vi", Title: "Nhóm", Slug: "nhom"}).Error)
+	s.Require().NoError(s.repo.Delete(team.ID))
+	_, err = s.repo.GetByID(team.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+	var translations int64
+	s.Require().NoError(s.db.Model(&models.Translation{}).Count(&translations).Error)
+	s.Zero(translations)
+}
+
+func TestPageRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PageRepositoryTestSuite))
+}
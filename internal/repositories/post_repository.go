@@ -0,0 +1,539 @@
+package repositories
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrPostStatusChanged is returned by ApplyTransition when the status of the post was changed concurrently
+var ErrPostStatusChanged = errors.New("post status has changed")
+
+// workflowColumns are only written by ApplyTransition so content edits cannot overwrite a concurrent transition
+var workflowColumns = []string{"status", "publish_at", "published_at", "expire_at", "expire_action", "expiry_notified_at"}
+
+// PostFilter holds the optional criteria applied when listing posts
+type PostFilter struct {
+	Status      string // Only posts with this status, empty for any status
+	AuthorID    uint   // Only posts of this author, 0 for any author
+	CategoryIDs []uint // Only posts in one of these categories, empty for any category
+	TagID       uint   // Only posts with this tag, 0 for any tag
+
+	// Only posts due to expire at or before this time, soonest first, nil for any post
+	ExpiringBefore *time.Time
+}
+
+type IPostRepository interface {
+	PaginatePost(page, limit int, filter PostFilter) (*utils.Pagination, error)
+	PaginatePublished(page, limit int, filter PostFilter) (*utils.Pagination, error)
+	GetByID(id uint) (*models.Post, error)
+	FindPublishedBySlug(slug string) (*models.Post, error)
+	FindByIDs(ids []uint) ([]models.Post, error)
+	FindRelatedPublished(post *models.Post, limit int) ([]models.Post, error)
+	SlugExists(slug string, excludeID uint) (bool, error)
+	Create(post *models.Post, revision *models.PostRevision) error
+	Update(post *models.Post, revision *models.PostRevision) error
+	Delete(id uint) error
+	PaginateRevisions(postID uint, page, limit int) (*utils.Pagination, error)
+	GetRevision(postID uint, number int) (*models.PostRevision, error)
+	ApplyTransition(post *models.Post, transition *models.PostTransition) error
+	FindTransitions(postID uint) ([]models.PostTransition, error)
+	FindDueScheduled(before time.Time, limit int) ([]models.Post, error)
+	UpdateExpiry(post *models.Post) error
+	FindDueExpiring(before time.Time, limit int) ([]models.Post, error)
+	FindExpiringUnnotified(before time.Time, limit int) ([]models.Post, error)
+	ClaimExpiryNotification(id uint, notifiedAt time.Time) (bool, error)
+	ReleaseExpiryNotification(id uint) error
+}
+
+type PostRepository struct {
+	db *gorm.DB
+}
+
+// NewPostRepository creates a new instance of PostRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *PostRepository: pointer to the newly created PostRepository
+func NewPostRepository(db *gorm.DB) *PostRepository {
+	return &PostRepository{db: db}
+}
+
+// PaginatePost retrieves a page of posts with their authors, newest first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of posts per page
+//   - filter: Optional criteria, every criterion must match for a post to be returned
+//
+// Returns:
+//   - *utils.Pagination: The page of posts
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) PaginatePost(page, limit int, filter PostFilter) (*utils.Pagination, error) {
+	query := repo.filter(repo.db.Model(&models.Post{}), filter)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ExpiringBefore != nil {
+		query = query.Where("expire_at <= ?", *filter.ExpiringBefore)
+		return repo.paginate(query, page, limit, "expire_at ASC, id ASC")
+	}
+	return repo.paginate(query, page, limit, "id DESC")
+}
+
+// PaginatePublished retrieves a page of published posts with their authors, most recently published first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of posts per page
+//   - filter: Optional criteria, the status and expiry criteria are ignored
+//
+// Returns:
+//   - *utils.Pagination: The page of posts
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) PaginatePublished(page, limit int, filter PostFilter) (*utils.Pagination, error) {
+	query := repo.filter(repo.db.Model(&models.Post{}), filter).Where("status = ?", models.PostStatusPublished)
+	return repo.paginate(query, page, limit, "published_at DESC, id DESC")
+}
+
+// filter applies the author, category and tag criteria of a filter to a query on posts
+func (repo *PostRepository) filter(query *gorm.DB, filter PostFilter) *gorm.DB {
+	if filter.AuthorID != 0 {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+	if len(filter.CategoryIDs) > 0 {
+		query = query.Where("category_id IN ?", filter.CategoryIDs)
+	}
+	if filter.TagID != 0 {
+		query = query.Where("id IN (?)", repo.db.Table("post_tags").Select("post_id").Where("tag_id = ?", filter.TagID))
+	}
+	return query
+}
+
+// paginate counts the rows matched by the query and loads the requested page
+func (repo *PostRepository) paginate(query *gorm.DB, page, limit int, order string) (*utils.Pagination, error) {
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var posts []models.Post
+	if err := query.Preload("Author").Preload("Category").Preload("Tags").Offset((page - 1) * limit).Limit(limit).Order(order).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       posts,
+	}, nil
+}
+
+// GetByID retrieves a post by its ID together with its author, category and tags
+// Parameters:
+//   - id: The ID of the post
+//
+// Returns:
+//   - *models.Post: The post
+//   - error: gorm.ErrRecordNotFound if the post does not exist, otherwise the error that occurred
+func (repo *PostRepository) GetByID(id uint) (*models.Post, error) {
+	var post models.Post
+	if err := repo.db.Preload("Author").Preload("Category").Preload("Tags").First(&post, id).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// FindByIDs retrieves the posts with the given IDs without their body and relations, whatever their status
+// Parameters:
+//   - ids: The IDs of the posts, unknown IDs are ignored
+//
+// Returns:
+//   - []models.Post: The posts found
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) FindByIDs(ids []uint) ([]models.Post, error) {
+	posts := []models.Post{}
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	if err := repo.db.Omit("body").Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// FindRelatedPublished retrieves the published posts sharing tags or the category with a post
+// Parameters:
+//   - post: The post with its tags loaded
+//   - limit: The maximum number of posts to return
+//
+// Returns:
+//   - []models.Post: The related posts with their authors, category and tags, the posts sharing the most tags first,
+//     then the most recently published
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) FindRelatedPublished(post *models.Post, limit int) ([]models.Post, error) {
+	posts := []models.Post{}
+	tagIDs := []uint{0} // Keeps the IN clause valid for a post without tags
+	for _, tag := range post.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	related := "post_tags.tag_id IS NOT NULL"
+	args := []any{}
+	if post.CategoryID != nil {
+		related += " OR posts.category_id = ?"
+		args = append(args, *post.CategoryID)
+	}
+
+	var ids []uint
+	if err := repo.db.Model(&models.Post{}).
+		Joins("LEFT JOIN post_tags ON post_tags.post_id = posts.id AND post_tags.tag_id IN ?", tagIDs).
+		Where("posts.id <> ? AND posts.status = ?", post.ID, models.PostStatusPublished).
+		Where(related, args...).
+		Group("posts.id, posts.published_at").
+		Order("COUNT(post_tags.tag_id) DESC, posts.published_at DESC, posts.id DESC").
+		Limit(limit).
+		Pluck("posts.id", &ids).Error; err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return posts, nil
+	}
+
+	if err := repo.db.Preload("Author").Preload("Category").Preload("Tags").Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	position := make(map[uint]int, len(ids))
+	for i, id := range ids {
+		position[id] = i
+	}
+	slices.SortFunc(posts, func(a, b models.Post) int { return position[a.ID] - position[b.ID] })
+	return posts, nil
+}
+
+// FindPublishedBySlug retrieves a published post by its slug together with its author, category and tags
+// Parameters:
+//   - slug: The slug of the post
+//
+// Returns:
+//   - *models.Post: The post
+//   - error: gorm.ErrRecordNotFound if no published post has this slug, otherwise the error that occurred
+func (repo *PostRepository) FindPublishedBySlug(slug string) (*models.Post, error) {
+	var post models.Post
+	if err := repo.db.Preload("Author").Preload("Category").Preload("Tags").
+		Where("slug = ? AND status = ?", slug, models.PostStatusPublished).
+		First(&post).Error; err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// SlugExists checks whether a slug is already used by another post
+// Deleted posts are included because they keep their slug in the unique index
+// Parameters:
+//   - slug: The slug to check
+//   - excludeID: The ID of the post being updated, 0 when creating a post
+//
+// Returns:
+//   - bool: true if another post uses the slug
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Unscoped().Model(&models.Post{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create stores a new post together with its tags and first revision in a single transaction
+// Parameters:
+//   - post: The post to create, its ID is set on success. Its tags must already be stored
+//   - revision: The snapshot of the content, its post and number are set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) Create(post *models.Post, revision *models.PostRevision) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(post).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(post).Association("Tags").Replace(post.Tags); err != nil {
+			return err
+		}
+		return repo.createRevision(tx, post.ID, revision)
+	})
+}
+
+// Update saves the content of an existing post together with its tags and a new revision in a single transaction
+// The workflow columns are left untouched, the old slug of a published post is redirected to the new one
+// Parameters:
+//   - post: The post to save, its tags replace the current ones and must already be stored
+//   - revision: The snapshot of the content, its post and number are set on success
+//
+// Returns:
+//   - error: ErrVersionConflict if the post was saved by someone else since its version was loaded, otherwise the error that occurred
+func (repo *PostRepository) Update(post *models.Post, revision *models.PostRevision) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var stored models.Post
+		if err := tx.Select("slug", "published_at").First(&stored, post.ID).Error; err != nil {
+			return err
+		}
+		if err := saveVersioned(tx, post, &post.Version, workflowColumns...); err != nil {
+			return err
+		}
+		if err := tx.Model(post).Association("Tags").Replace(post.Tags); err != nil {
+			return err
+		}
+		// The old URL of a post which has been published may be linked from elsewhere
+		if stored.PublishedAt != nil && stored.Slug != post.Slug {
+			if err := recordMoves(tx, map[string]string{"/posts/" + stored.Slug: "/posts/" + post.Slug}); err != nil {
+				return err
+			}
+		}
+		return repo.createRevision(tx, post.ID, revision)
+	})
+}
+
+// createRevision stores a revision with the next number of its post
+// Two concurrent saves of a post get the same number, the unique index rejects the second one
+func (repo *PostRepository) createRevision(tx *gorm.DB, postID uint, revision *models.PostRevision) error {
+	var last int
+	if err := tx.Model(&models.PostRevision{}).
+		Where("post_id = ?", postID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&last).Error; err != nil {
+		return err
+	}
+
+	revision.PostID = postID
+	revision.Number = last + 1
+	return tx.Omit(clause.Associations).Create(revision).Error
+}
+
+// Delete soft deletes a post by its ID
+// Parameters:
+//   - id: The ID of the post
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) Delete(id uint) error {
+	return repo.db.Delete(&models.Post{}, id).Error
+}
+
+// ApplyTransition saves the workflow columns of a post and records the transition in a single transaction
+// The post is only changed while it still has the status the transition starts from
+// Parameters:
+//   - post: The post with its new status, publish time, publication time and expiry set
+//   - transition: The transition to record, its FromStatus is the status expected in the database
+//
+// Returns:
+//   - error: ErrPostStatusChanged if the post no longer has the expected status, otherwise the error that occurred
+func (repo *PostRepository) ApplyTransition(post *models.Post, transition *models.PostTransition) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Post{ID: post.ID}).
+			Where("status = ?", transition.FromStatus).
+			Updates(map[string]any{
+				"status":             post.Status,
+				"publish_at":         post.PublishAt,
+				"published_at":       post.PublishedAt,
+				"expire_at":          post.ExpireAt,
+				"expire_action":      post.ExpireAction,
+				"expiry_notified_at": post.ExpiryNotifiedAt,
+				"updated_at":         time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPostStatusChanged
+		}
+		return tx.Omit(clause.Associations).Create(transition).Error
+	})
+}
+
+// FindTransitions retrieves the workflow history of a post with the users who made each transition, oldest first
+// Parameters:
+//   - postID: The ID of the post
+//
+// Returns:
+//   - []models.PostTransition: The transitions of the post
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) FindTransitions(postID uint) ([]models.PostTransition, error) {
+	var transitions []models.PostTransition
+	if err := repo.db.Preload("User").Where("post_id = ?", postID).Order("id ASC").Find(&transitions).Error; err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+// FindDueScheduled retrieves scheduled posts whose publish time has passed, oldest first
+// Parameters:
+//   - before: Posts scheduled at or before this time are returned
+//   - limit: Maximum number of posts to return
+//
+// Returns:
+//   - []models.Post: The posts to publish
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) FindDueScheduled(before time.Time, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	if err := repo.db.
+		Where("status = ? AND publish_at <= ?", models.PostStatusScheduled, before).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// UpdateExpiry saves the expiry of a post while it keeps the status it was loaded with
+// Parameters:
+//   - post: The post with its expiry time, expire action and notification time set
+//
+// Returns:
+//   - error: ErrPostStatusChanged if the status of the post was changed concurrently, otherwise the error that occurred
+func (repo *PostRepository) UpdateExpiry(post *models.Post) error {
+	result := repo.db.Model(&models.Post{ID: post.ID}).
+		Where("status = ?", post.Status).
+		Updates(map[string]any{
+			"expire_at":          post.ExpireAt,
+			"expire_action":      post.ExpireAction,
+			"expiry_notified_at": post.ExpiryNotifiedAt,
+			"updated_at":         time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPostStatusChanged
+	}
+	return nil
+}
+
+// FindDueExpiring retrieves published posts whose expiry time has passed, oldest expiry first
+// Parameters:
+//   - before: Posts expiring at or before this time are returned
+//   - limit: Maximum number of posts to return
+//
+// Returns:
+//   - []models.Post: The posts to take offline
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) FindDueExpiring(before time.Time, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	if err := repo.db.
+		Where("status = ? AND expire_at <= ?", models.PostStatusPublished, before).
+		Order("expire_at ASC").
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// FindExpiringUnnotified retrieves the published and scheduled posts expiring soon whose author was not told yet,
+// together with their authors
+// Parameters:
+//   - before: Posts expiring at or before this time are returned
+//   - limit: Maximum number of posts to return
+//
+// Returns:
+//   - []models.Post: The posts to notify about, soonest expiry first
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) FindExpiringUnnotified(before time.Time, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	if err := repo.db.Preload("Author").
+		Where("status IN ? AND expire_at <= ? AND expiry_notified_at IS NULL",
+			[]string{models.PostStatusPublished, models.PostStatusScheduled}, before).
+		Order("expire_at ASC").
+		Limit(limit).
+		Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ClaimExpiryNotification marks the expiry of a post as notified unless another worker did it first,
+// the notification is sent only once it is claimed
+// Parameters:
+//   - id: The ID of the post
+//   - notifiedAt: Time the notification is sent
+//
+// Returns:
+//   - bool: true if the notification was claimed, false if it was already notified
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) ClaimExpiryNotification(id uint, notifiedAt time.Time) (bool, error) {
+	result := repo.db.Model(&models.Post{}).
+		Where("id = ? AND expiry_notified_at IS NULL", id).
+		UpdateColumn("expiry_notified_at", notifiedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseExpiryNotification clears a claimed notification whose email could not be sent, it is sent again on the next run
+// Parameters:
+//   - id: The ID of the post
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) ReleaseExpiryNotification(id uint) error {
+	return repo.db.Model(&models.Post{ID: id}).UpdateColumn("expiry_notified_at", nil).Error
+}
+
+// PaginateRevisions retrieves a page of revisions of a post with their editors, newest first
+// Parameters:
+//   - postID: The ID of the post
+//   - page: The page number to retrieve
+//   - limit: The number of revisions per page
+//
+// Returns:
+//   - *utils.Pagination: The page of revisions
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PostRepository) PaginateRevisions(postID uint, page, limit int) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.PostRevision{}).Where("post_id = ?", postID)
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var revisions []models.PostRevision
+	if err := query.Preload("Editor").Offset((page - 1) * limit).Limit(limit).Order("number DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       revisions,
+	}, nil
+}
+
+// GetRevision retrieves a revision of a post by its number together with its editor
+// Parameters:
+//   - postID: The ID of the post
+//   - number: The number of the revision within the post
+//
+// Returns:
+//   - *models.PostRevision: The revision
+//   - error: gorm.ErrRecordNotFound if the revision does not exist, otherwise the error that occurred
+func (repo *PostRepository) GetRevision(postID uint, number int) (*models.PostRevision, error) {
+	var revision models.PostRevision
+	if err := repo.db.Preload("Editor").
+		Where("post_id = ? AND number = ?", postID, number).
+		First(&revision).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,441 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type PostRepositoryTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	repo   *repositories.PostRepository
+	author *models.User
+}
+
+func (s *PostRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.PostTransition{}, &models.PostRevision{}, &models.Category{}, &models.Tag{}, &models.Redirect{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewPostRepository(db)
+
+	s.author = &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(db.Create(s.author).Error)
+}
+
+func (s *PostRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *PostRepositoryTestSuite) newPost(slug, status string, publishedAt *time.Time) *models.Post {
+	post := &models.Post{
+		Title:       slug,
+		Slug:        slug,
+		Body:        "Body",
+		AuthorID:    s.author.ID,
+		Status:      status,
+		PublishedAt: publishedAt,
+	}
+	s.Require().NoError(s.repo.Create(post, &models.PostRevision{EditorID: &s.author.ID, Title: post.Title, Slug: post.Slug, Body: post.Body}))
+	return post
+}
+
+func (s *PostRepositoryTestSuite) TestCreateUpdateAndGet() {
+	post := s.newPost("hello-world", models.PostStatusDraft, nil)
+	s.NotZero(post.ID)
+
+	post.Title = "Hello again"
+	s.Require().NoError(s.repo.Update(post, &models.PostRevision{EditorID: &s.author.ID, Title: post.Title, Slug: post.Slug, Body: post.Body}))
+
+	found, err := s.repo.GetByID(post.ID)
+	s.Require().NoError(err)
+	s.Equal("Hello again", found.Title)
+	s.Require().NotNil(found.Author)
+	s.Equal("Author", found.Author.Name)
+
+	_, err = s.repo.GetByID(999)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *PostRepositoryTestSuite) TestFindPublishedBySlug() {
+	now := time.Now()
+	s.newPost("published", models.PostStatusPublished, &now)
+	s.newPost("draft", models.PostStatusDraft, nil)
+
+	found, err := s.repo.FindPublishedBySlug("published")
+	s.Require().NoError(err)
+	s.NotNil(found.Author)
+
+	_, err = s.repo.FindPublishedBySlug("draft")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *PostRepositoryTestSuite) TestPaginate() {
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+	first := s.newPost("first", models.PostStatusPublished, &newer)
+	second := s.newPost("second", models.PostStatusPublished, &older)
+	draft := s.newPost("third", models.PostStatusDraft, nil)
+
+	pagination, err := s.repo.PaginatePost(1, 10, repositories.PostFilter{})
+	s.Require().NoError(err)
+	s.Equal(3, pagination.TotalItems)
+	posts := pagination.Data.([]models.Post)
+	s.Equal(draft.ID, posts[0].ID)
+
+	pagination, err = s.repo.PaginatePost(1, 10, repositories.PostFilter{Status: models.PostStatusDraft, AuthorID: s.author.ID})
+	s.Require().NoError(err)
+	s.Equal(1, pagination.TotalItems)
+
+	pagination, err = s.repo.PaginatePost(1, 10, repositories.PostFilter{AuthorID: 999})
+	s.Require().NoError(err)
+	s.Equal(0, pagination.TotalItems)
+
+	pagination, err = s.repo.PaginatePublished(1, 10, repositories.PostFilter{})
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+	posts = pagination.Data.([]models.Post)
+	s.Equal(first.ID, posts[0].ID)
+	s.Equal(second.ID, posts[1].ID)
+	s.NotNil(posts[0].Author)
+}
+
+func (s *PostRepositoryTestSuite) TestSlugExistsAndDelete() {
+	post := s.newPost("taken", models.PostStatusDraft, nil)
+
+	exists, err := s.repo.SlugExists("taken", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+
+	exists, err = s.repo.SlugExists("taken", post.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	s.Require().NoError(s.repo.Delete(post.ID))
+	_, err = s.repo.GetByID(post.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	// Deleted posts keep their slug
+	exists, err = s.repo.SlugExists("taken", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+}
+
+func (s *PostRepositoryTestSuite) TestUpdateKeepsWorkflowColumns() {
+	post := s.newPost("hello", models.PostStatusInReview, nil)
+
+	post.Title = "Changed"
+	post.Status = models.PostStatusPublished
+	s.Require().NoError(s.repo.Update(post, &models.PostRevision{EditorID: &s.author.ID, Title: post.Title, Slug: post.Slug, Body: post.Body}))
+
+	found, err := s.repo.GetByID(post.ID)
+	s.Require().NoError(err)
+	s.Equal("Changed", found.Title)
+	s.Equal(models.PostStatusInReview, found.Status)
+}
+
+func (s *PostRepositoryTestSuite) TestUpdateRejectsStaleVersion() {
+	post := s.newPost("hello", models.PostStatusDraft, nil)
+	s.Equal(uint(1), post.Version)
+
+	// Two editors load the same version, the second save loses
+	stale := *post
+	post.Title = "First"
+	s.Require().NoError(s.repo.Update(post, &models.PostRevision{EditorID: &s.author.ID, Title: post.Title, Slug: post.Slug, Body: post.Body}))
+	s.Equal(uint(2), post.Version)
+
+	stale.Title = "Second"
+	err := s.repo.Update(&stale, &models.PostRevision{EditorID: &s.author.ID, Title: stale.Title, Slug: stale.Slug, Body: stale.Body})
+	s.ErrorIs(err, repositories.ErrVersionConflict)
+	s.Equal(uint(1), stale.Version, "the version is kept when the save fails")
+
+	found, err := s.repo.GetByID(post.ID)
+	s.Require().NoError(err)
+	s.Equal("First", found.Title)
+	s.Equal(uint(2), found.Version)
+	var revisions int64
+	s.Require().NoError(s.db.Model(&models.PostRevision{}).Where("post_id = ?", post.ID).Count(&revisions).Error)
+	s.Equal(int64(2), revisions, "the rejected save records no revision")
+}
+
+func (s *PostRepositoryTestSuite) TestUpdateRedirectsOldSlug() {
+	now := time.Now()
+	draft := s.newPost("draft", models.PostStatusDraft, nil)
+	post := s.newPost("first", models.PostStatusPublished, &now)
+	rename := func(post *models.Post, slug string) {
+		post.Slug = slug
+		s.Require().NoError(s.repo.Update(post, &models.PostRevision{EditorID: &s.author.ID, Title: post.Title, Slug: post.Slug, Body: post.Body}))
+	}
+
+	// Drafts have no public URL to keep
+	rename(draft, "draft-2")
+	var count int64
+	s.Require().NoError(s.db.Model(&models.Redirect{}).Count(&count).Error)
+	s.Zero(count)
+
+	rename(post, "second")
+	rename(post, "third")
+
+	var redirects []models.Redirect
+	s.Require().NoError(s.db.Order("from_path ASC").Find(&redirects).Error)
+	s.Require().Len(redirects, 2)
+	// The first slug skips the second one
+	s.Equal("/posts/first", redirects[0].FromPath)
+	s.Equal("/posts/third", redirects[0].ToPath)
+	s.Equal("/posts/second", redirects[1].FromPath)
+	s.Equal("/posts/third", redirects[1].ToPath)
+	s.True(redirects[1].Automatic)
+
+	// Taking back an old slug removes its redirect
+	rename(post, "first")
+	s.Require().NoError(s.db.Order("from_path ASC").Find(&redirects).Error)
+	s.Require().Len(redirects, 2)
+	s.Equal("/posts/second", redirects[0].FromPath)
+	s.Equal("/posts/first", redirects[0].ToPath)
+	s.Equal("/posts/third", redirects[1].FromPath)
+	s.Equal("/posts/first", redirects[1].ToPath)
+}
+
+func (s *PostRepositoryTestSuite) TestApplyTransition() {
+	post := s.newPost("hello", models.PostStatusApproved, nil)
+	publishAt := time.Now().Add(time.Hour)
+	comment := "Ready"
+
+	post.Status = models.PostStatusScheduled
+	post.PublishAt = &publishAt
+	err := s.repo.ApplyTransition(post, &models.PostTransition{
+		PostID:     post.ID,
+		UserID:     &s.author.ID,
+		Action:     models.PostActionSchedule,
+		FromStatus: models.PostStatusApproved,
+		ToStatus:   models.PostStatusScheduled,
+		Comment:    &comment,
+	})
+	s.Require().NoError(err)
+
+	found, err := s.repo.GetByID(post.ID)
+	s.Require().NoError(err)
+	s.Equal(models.PostStatusScheduled, found.Status)
+	s.Require().NotNil(found.PublishAt)
+
+	// A second transition from the old status is rejected and not recorded
+	err = s.repo.ApplyTransition(post, &models.PostTransition{
+		PostID:     post.ID,
+		Action:     models.PostActionSchedule,
+		FromStatus: models.PostStatusApproved,
+		ToStatus:   models.PostStatusScheduled,
+	})
+	s.ErrorIs(err, repositories.ErrPostStatusChanged)
+
+	transitions, err := s.repo.FindTransitions(post.ID)
+	s.Require().NoError(err)
+	s.Require().Len(transitions, 1)
+	s.Equal(models.PostActionSchedule, transitions[0].Action)
+	s.Equal("Ready", *transitions[0].Comment)
+	s.Require().NotNil(transitions[0].User)
+	s.Equal("Author", transitions[0].User.Name)
+}
+
+func (s *PostRepositoryTestSuite) TestFindDueScheduled() {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	due := s.newPost("due", models.PostStatusScheduled, nil)
+	s.Require().NoError(s.db.Model(due).Update("publish_at", past).Error)
+	later := s.newPost("later", models.PostStatusScheduled, nil)
+	s.Require().NoError(s.db.Model(later).Update("publish_at", future).Error)
+	approved := s.newPost("approved", models.PostStatusApproved, nil)
+	s.Require().NoError(s.db.Model(approved).Update("publish_at", past).Error)
+
+	posts, err := s.repo.FindDueScheduled(time.Now(), 10)
+	s.Require().NoError(err)
+	s.Require().Len(posts, 1)
+	s.Equal(due.ID, posts[0].ID)
+}
+
+func (s *PostRepositoryTestSuite) TestExpiry() {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	soon := now.Add(24 * time.Hour)
+	archive := models.PostActionArchive
+
+	due := s.newPost("due", models.PostStatusPublished, &now)
+	due.ExpireAt = &past
+	due.ExpireAction = &archive
+	s.Require().NoError(s.repo.UpdateExpiry(due))
+	expiring := s.newPost("expiring", models.PostStatusPublished, &now)
+	expiring.ExpireAt = &soon
+	s.Require().NoError(s.repo.UpdateExpiry(expiring))
+	draft := s.newPost("draft", models.PostStatusDraft, nil)
+	draft.ExpireAt = &past
+	s.Require().NoError(s.repo.UpdateExpiry(draft))
+	s.newPost("forever", models.PostStatusPublished, &now)
+
+	// Content edits leave the expiry alone
+	due.ExpireAt = nil
+	s.Require().NoError(s.repo.Update(due, &models.PostRevision{EditorID: &s.author.ID, Title: due.Title, Slug: due.Slug, Body: due.Body}))
+
+	posts, err := s.repo.FindDueExpiring(now, 10)
+	s.Require().NoError(err)
+	s.Require().Len(posts, 1)
+	s.Equal(due.ID, posts[0].ID)
+	s.Equal(models.PostActionArchive, *posts[0].ExpireAction)
+
+	in := now.Add(48 * time.Hour)
+	pagination, err := s.repo.PaginatePost(1, 10, repositories.PostFilter{Status: models.PostStatusPublished, ExpiringBefore: &in})
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+	s.Equal(due.ID, pagination.Data.([]models.Post)[0].ID, "soonest first")
+
+	posts, err = s.repo.FindExpiringUnnotified(in, 10)
+	s.Require().NoError(err)
+	s.Require().Len(posts, 2)
+	s.Require().NotNil(posts[1].Author)
+	claimed, err := s.repo.ClaimExpiryNotification(expiring.ID, now)
+	s.Require().NoError(err)
+	s.True(claimed)
+	claimed, err = s.repo.ClaimExpiryNotification(expiring.ID, now)
+	s.Require().NoError(err)
+	s.False(claimed, "a notification is claimed once")
+	posts, err = s.repo.FindExpiringUnnotified(in, 10)
+	s.Require().NoError(err)
+	s.Require().Len(posts, 1)
+	s.Equal(due.ID, posts[0].ID)
+	s.Require().NoError(s.repo.ReleaseExpiryNotification(expiring.ID))
+	posts, err = s.repo.FindExpiringUnnotified(in, 10)
+	s.Require().NoError(err)
+	s.Len(posts, 2)
+
+	// The expiry of a post archived in the meantime is not saved
+	due.Status = models.PostStatusDraft
+	s.ErrorIs(s.repo.UpdateExpiry(due), repositories.ErrPostStatusChanged)
+}
+
+func (s *PostRepositoryTestSuite) TestTaxonomy() {
+	parent := &models.Category{Name: "Tech", Slug: "tech"}
+	s.Require().NoError(s.db.Create(parent).Error)
+	child := &models.Category{Name: "Go", Slug: "go", ParentID: &parent.ID}
+	s.Require().NoError(s.db.Create(child).Error)
+	golang := &models.Tag{Name: "Go", Slug: "go"}
+	news := &models.Tag{Name: "News", Slug: "news"}
+	s.Require().NoError(s.db.Create(golang).Error)
+	s.Require().NoError(s.db.Create(news).Error)
+
+	now := time.Now()
+	tagged := s.newPost("tagged", models.PostStatusPublished, &now)
+	tagged.CategoryID = &child.ID
+	tagged.Tags = []models.Tag{*golang, *news}
+	s.Require().NoError(s.repo.Update(tagged, &models.PostRevision{EditorID: &s.author.ID, Title: tagged.Title, Slug: tagged.Slug, Body: tagged.Body}))
+	s.newPost("plain", models.PostStatusPublished, &now)
+
+	found, err := s.repo.GetByID(tagged.ID)
+	s.Require().NoError(err)
+	s.Equal(models.PostStatusPublished, found.Status)
+	s.Require().NotNil(found.Category)
+	s.Equal("go", found.Category.Slug)
+	s.Len(found.Tags, 2)
+
+	pagination, err := s.repo.PaginatePublished(1, 10, repositories.PostFilter{CategoryIDs: []uint{parent.ID, child.ID}})
+	s.Require().NoError(err)
+	s.Equal(1, pagination.TotalItems)
+	s.Equal(tagged.ID, pagination.Data.([]models.Post)[0].ID)
+
+	pagination, err = s.repo.PaginatePost(1, 10, repositories.PostFilter{TagID: news.ID})
+	s.Require().NoError(err)
+	s.Equal(1, pagination.TotalItems)
+
+	// Saving the post replaces its tags
+	found.Tags = []models.Tag{*news}
+	s.Require().NoError(s.repo.Update(found, &models.PostRevision{EditorID: &s.author.ID, Title: found.Title, Slug: found.Slug, Body: found.Body}))
+	pagination, err = s.repo.PaginatePost(1, 10, repositories.PostFilter{TagID: golang.ID})
+	s.Require().NoError(err)
+	s.Equal(0, pagination.TotalItems)
+
+	found.Tags = []models.Tag{}
+	s.Require().NoError(s.repo.Update(found, &models.PostRevision{EditorID: &s.author.ID, Title: found.Title, Slug: found.Slug, Body: found.Body}))
+	found, err = s.repo.GetByID(tagged.ID)
+	s.Require().NoError(err)
+	s.Empty(found.Tags)
+}
+
+func (s *PostRepositoryTestSuite) TestFindRelatedPublished() {
+	category := &models.Category{Name: "Tech", Slug: "tech"}
+	s.Require().NoError(s.db.Create(category).Error)
+	golang := &models.Tag{Name: "Go", Slug: "go"}
+	news := &models.Tag{Name: "News", Slug: "news"}
+	s.Require().NoError(s.db.Create(golang).Error)
+	s.Require().NoError(s.db.Create(news).Error)
+
+	create := func(slug, status string, publishedAt time.Time, categoryID *uint, tags ...models.Tag) *models.Post {
+		post := s.newPost(slug, status, &publishedAt)
+		post.CategoryID = categoryID
+		post.Tags = tags
+		s.Require().NoError(s.repo.Update(post, &models.PostRevision{EditorID: &s.author.ID, Title: post.Title, Slug: post.Slug, Body: post.Body}))
+		return post
+	}
+	now := time.Now()
+	source := create("source", models.PostStatusPublished, now, &category.ID, *golang, *news)
+	bothTags := create("both-tags", models.PostStatusPublished, now.Add(-2*time.Hour), nil, *golang, *news)
+	oneTag := create("one-tag", models.PostStatusPublished, now.Add(-time.Hour), nil, *news)
+	sameCategory := create("same-category", models.PostStatusPublished, now, &category.ID)
+	create("draft", models.PostStatusDraft, now, &category.ID, *golang)
+	create("unrelated", models.PostStatusPublished, now, nil)
+
+	source, err := s.repo.GetByID(source.ID)
+	s.Require().NoError(err)
+
+	related, err := s.repo.FindRelatedPublished(source, 10)
+	s.Require().NoError(err)
+	s.Require().Len(related, 3)
+	s.Equal([]uint{bothTags.ID, oneTag.ID, sameCategory.ID}, []uint{related[0].ID, related[1].ID, related[2].ID})
+	s.Len(related[0].Tags, 2)
+
+	related, err = s.repo.FindRelatedPublished(&models.Post{ID: sameCategory.ID}, 10)
+	s.Require().NoError(err)
+	s.Empty(related)
+}
+
+func (s *PostRepositoryTestSuite) TestRevisions() {
+	post := s.newPost("hello", models.PostStatusDraft, nil)
+	for _, title := range []string{"Second", "Third"} {
+		post.Title = title
+		s.Require().NoError(s.repo.Update(post, &models.PostRevision{EditorID: &s.author.ID, Title: title, Slug: post.Slug, Body: post.Body}))
+	}
+	other := s.newPost("other", models.PostStatusDraft, nil)
+
+	pagination, err := s.repo.PaginateRevisions(post.ID, 1, 2)
+	s.Require().NoError(err)
+	s.Equal(3, pagination.TotalItems)
+	s.Equal(2, pagination.TotalPages)
+	revisions := pagination.Data.([]models.PostRevision)
+	s.Require().Len(revisions, 2)
+	s.Equal(3, revisions[0].Number)
+	s.Equal("Third", revisions[0].Title)
+	s.Require().NotNil(revisions[0].Editor)
+
+	revision, err := s.repo.GetRevision(post.ID, 1)
+	s.Require().NoError(err)
+	s.Equal("hello", revision.Title)
+
+	// Numbers start at 1 for every post
+	revision, err = s.repo.GetRevision(other.ID, 1)
+	s.Require().NoError(err)
+	s.Equal(other.ID, revision.PostID)
+
+	_, err = s.repo.GetRevision(post.ID, 4)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func TestPostRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PostRepositoryTestSuite))
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IPreviewLinkRepository interface {
+	FindByPost(postID uint) ([]models.PreviewLink, error)
+	GetByID(id uint) (*models.PreviewLink, error)
+	Create(link *models.PreviewLink) error
+	Revoke(postID, id uint, revokedAt time.Time) (int64, error)
+}
+
+type PreviewLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewPreviewLinkRepository creates a new instance of PreviewLinkRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *PreviewLinkRepository: pointer to the newly created PreviewLinkRepository
+func NewPreviewLinkRepository(db *gorm.DB) *PreviewLinkRepository {
+	return &PreviewLinkRepository{db: db}
+}
+
+// FindByPost retrieves every preview link of a post with their creators, newest first
+// Parameters:
+//   - postID: The ID of the post
+//
+// Returns:
+//   - []models.PreviewLink: The preview links, including the expired and revoked ones
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PreviewLinkRepository) FindByPost(postID uint) ([]models.PreviewLink, error) {
+	var links []models.PreviewLink
+	if err := repo.db.Preload("Creator").
+		Where("post_id = ?", postID).
+		Order("id DESC").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetByID retrieves a preview link by its ID
+// Parameters:
+//   - id: The ID of the preview link
+//
+// Returns:
+//   - *models.PreviewLink: The preview link
+//   - error: gorm.ErrRecordNotFound if the preview link does not exist, otherwise the error that occurred
+func (repo *PreviewLinkRepository) GetByID(id uint) (*models.PreviewLink, error) {
+	var link models.PreviewLink
+	if err := repo.db.First(&link, id).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// Create stores a new preview link
+// Parameters:
+//   - link: The preview link to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PreviewLinkRepository) Create(link *models.PreviewLink) error {
+	return repo.db.Omit(clause.Associations).Create(link).Error
+}
+
+// Revoke marks a preview link of a post as revoked, revoking a link twice keeps its first revocation time
+// Parameters:
+//   - postID: The ID of the post the link belongs to
+//   - id: The ID of the preview link
+//   - revokedAt: The revocation time
+//
+// Returns:
+//   - int64: The number of links revoked, 0 when the link does not exist or was already revoked
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *PreviewLinkRepository) Revoke(postID, id uint, revokedAt time.Time) (int64, error) {
+	result := repo.db.Model(&models.PreviewLink{}).
+		Where("id = ? AND post_id = ? AND revoked_at IS NULL", id, postID).
+		Update("revoked_at", revokedAt)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,77 @@
+package repositories_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type PreviewLinkRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.PreviewLinkRepository
+}
+
+func (s *PreviewLinkRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.PreviewLink{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewPreviewLinkRepository(db)
+}
+
+func (s *PreviewLinkRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *PreviewLinkRepositoryTestSuite) TestCreateFindAndRevoke() {
+	user := &models.User{Email: "editor@example.com", Name: "Editor", Password: "secret"}
+	s.Require().NoError(s.db.Create(user).Error)
+
+	expiresAt := time.Now().Add(time.Hour)
+	first := &models.PreviewLink{PostID: 1, RevisionNumber: 1, CreatedBy: &user.ID, ExpiresAt: expiresAt}
+	second := &models.PreviewLink{PostID: 1, RevisionNumber: 2, CreatedBy: &user.ID, ExpiresAt: expiresAt}
+	other := &models.PreviewLink{PostID: 2, RevisionNumber: 1, ExpiresAt: expiresAt}
+	for _, link := range []*models.PreviewLink{first, second, other} {
+		s.Require().NoError(s.repo.Create(link))
+	}
+
+	links, err := s.repo.FindByPost(1)
+	s.Require().NoError(err)
+	s.Require().Len(links, 2)
+	s.Equal(second.ID, links[0].ID)
+	s.Equal("Editor", links[0].Creator.Name)
+
+	// A link is only revoked through its own post
+	revoked, err := s.repo.Revoke(2, first.ID, time.Now())
+	s.Require().NoError(err)
+	s.Zero(revoked)
+
+	revoked, err = s.repo.Revoke(1, first.ID, time.Now())
+	s.Require().NoError(err)
+	s.Equal(int64(1), revoked)
+	revoked, err = s.repo.Revoke(1, first.ID, time.Now())
+	s.Require().NoError(err)
+	s.Zero(revoked)
+
+	found, err := s.repo.GetByID(first.ID)
+	s.Require().NoError(err)
+	s.NotNil(found.RevokedAt)
+
+	_, err = s.repo.GetByID(99)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func TestPreviewLinkRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PreviewLinkRepositoryTestSuite))
+}
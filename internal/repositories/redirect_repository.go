@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+)
+
+type IRedirectRepository interface {
+	PaginateRedirects(page, limit int, search string) (*utils.Pagination, error)
+	GetByID(id uint) (*models.Redirect, error)
+	FindByFromPath(path string) (*models.Redirect, error)
+	FromPathExists(path string, excludeID uint) (bool, error)
+	Create(redirect *models.Redirect) error
+	Update(redirect *models.Redirect) error
+	Delete(id uint) (int64, error)
+}
+
+type RedirectRepository struct {
+	db *gorm.DB
+}
+
+// NewRedirectRepository creates a new instance of RedirectRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *RedirectRepository: pointer to the newly created RedirectRepository
+func NewRedirectRepository(db *gorm.DB) *RedirectRepository {
+	return &RedirectRepository{db: db}
+}
+
+// PaginateRedirects retrieves a page of redirects ordered by their path
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of redirects per page
+//   - search: Only redirects whose paths contain this text, empty for every redirect
+//
+// Returns:
+//   - *utils.Pagination: The page of redirects
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *RedirectRepository) PaginateRedirects(page, limit int, search string) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.Redirect{})
+	if search != "" {
+		query = query.Where("from_path LIKE ? OR to_path LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var redirects []models.Redirect
+	if err := query.Offset((page - 1) * limit).Limit(limit).Order("from_path ASC").Find(&redirects).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       redirects,
+	}, nil
+}
+
+// GetByID retrieves a redirect by its ID
+// Parameters:
+//   - id: The ID of the redirect
+//
+// Returns:
+//   - *models.Redirect: The redirect
+//   - error: gorm.ErrRecordNotFound if the redirect does not exist, otherwise the error that occurred
+func (repo *RedirectRepository) GetByID(id uint) (*models.Redirect, error) {
+	var redirect models.Redirect
+	if err := repo.db.First(&redirect, id).Error; err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// FindByFromPath retrieves the redirect of a path
+// Parameters:
+//   - path: The path of the website, e.g. "/posts/old-slug"
+//
+// Returns:
+//   - *models.Redirect: The redirect
+//   - error: gorm.ErrRecordNotFound if the path is not redirected, otherwise the error that occurred
+func (repo *RedirectRepository) FindByFromPath(path string) (*models.Redirect, error) {
+	var redirect models.Redirect
+	if err := repo.db.Where("from_path = ?", path).First(&redirect).Error; err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// FromPathExists checks whether a path is redirected by a redirect other than the excluded one
+// Parameters:
+//   - path: The path to look for
+//   - excludeID: ID of the redirect being saved, 0 when creating a redirect
+//
+// Returns:
+//   - bool: true if another redirect has this path
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *RedirectRepository) FromPathExists(path string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Model(&models.Redirect{}).
+		Where("from_path = ? AND id <> ?", path, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Create stores a new redirect
+// Parameters:
+//   - redirect: The redirect to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *RedirectRepository) Create(redirect *models.Redirect) error {
+	return repo.db.Create(redirect).Error
+}
+
+// Update saves an existing redirect
+// Parameters:
+//   - redirect: The redirect to save
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *RedirectRepository) Update(redirect *models.Redirect) error {
+	return repo.db.Save(redirect).Error
+}
+
+// Delete removes a redirect
+// Parameters:
+//   - id: The ID of the redirect
+//
+// Returns:
+//   - int64: The number of deleted redirects, 0 if the redirect does not exist
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *RedirectRepository) Delete(id uint) (int64, error) {
+	result := repo.db.Delete(&models.Redirect{}, id)
+	return result.RowsAffected, result.Error
+}
+
+// recordMoves keeps the old URLs of moved content working, it runs in the transaction saving the content
+// For each old path:
+//  1. The redirect of the new path is removed, content lives there again
+//  2. Redirects to the old path are pointed to the new path, visitors never follow a chain
+//  3. The old path is redirected to the new path, replacing a redirect it already had
+//
+// Parameters:
+//   - tx: The transaction saving the content
+//   - moves: New paths keyed by old path, e.g. "/posts/old-slug" => "/posts/new-slug"
+func recordMoves(tx *gorm.DB, moves map[string]string) error {
+	for from, to := range moves {
+		if from == to {
+			continue
+		}
+		if err := tx.Where("from_path = ?", to).Delete(&models.Redirect{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Redirect{}).Where("to_path = ?", from).Update("to_path", to).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("from_path = ?", from).Delete(&models.Redirect{}).Error; err != nil {
+			return err
+		}
+		redirect := models.Redirect{FromPath: from, ToPath: to, StatusCode: 301, Automatic: true}
+		if err := tx.Create(&redirect).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
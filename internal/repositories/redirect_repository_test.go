@@ -0,0 +1,78 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type RedirectRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.RedirectRepository
+}
+
+func (s *RedirectRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.Redirect{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewRedirectRepository(db)
+}
+
+func (s *RedirectRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *RedirectRepositoryTestSuite) TestCreateFindAndDelete() {
+	redirect := &models.Redirect{FromPath: "/posts/old", ToPath: "/posts/new", StatusCode: 301}
+	s.Require().NoError(s.repo.Create(redirect))
+	s.NotZero(redirect.ID)
+
+	found, err := s.repo.FindByFromPath("/posts/old")
+	s.Require().NoError(err)
+	s.Equal("/posts/new", found.ToPath)
+
+	_, err = s.repo.FindByFromPath("/posts/new")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	exists, err := s.repo.FromPathExists("/posts/old", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+	exists, err = s.repo.FromPathExists("/posts/old", redirect.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	deleted, err := s.repo.Delete(redirect.ID)
+	s.Require().NoError(err)
+	s.Equal(int64(1), deleted)
+	deleted, err = s.repo.Delete(redirect.ID)
+	s.Require().NoError(err)
+	s.Zero(deleted)
+}
+
+func (s *RedirectRepositoryTestSuite) TestPaginateRedirects() {
+	for _, path := range []string{"/posts/b", "/about", "/posts/a"} {
+		s.Require().NoError(s.repo.Create(&models.Redirect{FromPath: path, ToPath: "/", StatusCode: 301}))
+	}
+
+	pagination, err := s.repo.PaginateRedirects(1, 10, "/posts/")
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+	redirects := pagination.Data.([]models.Redirect)
+	s.Equal("/posts/a", redirects[0].FromPath)
+	s.Equal("/posts/b", redirects[1].FromPath)
+}
+
+func TestRedirectRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RedirectRepositoryTestSuite))
+}
@@ -12,6 +12,7 @@ type IRefreshTokenRepository interface {
 	Update(token *models.RefreshToken) error
 	FindByToken(token string) (*models.RefreshToken, error)
 	First(token string) (*models.RefreshToken, error)
+	FindByUserID(userID uint) ([]models.RefreshToken, error)
 }
 
 type RefreshTokenRepository struct {
@@ -77,3 +78,18 @@ func (repo *RefreshTokenRepository) FindByToken(token string) (*models.RefreshTo
 func (repo *RefreshTokenRepository) Update(token *models.RefreshToken) error {
 	return repo.db.Save(token).Error
 }
+
+// FindByUserID retrieves every refresh token issued to a user
+// Parameters:
+//   - userID: The ID of the user
+//
+// Returns:
+//   - []models.RefreshToken: The refresh tokens of the user, oldest first
+//   - error: nil if successful, error otherwise
+func (repo *RefreshTokenRepository) FindByUserID(userID uint) ([]models.RefreshToken, error) {
+	var tokens []models.RefreshToken
+	if err := repo.db.Where("user_id = ?", userID).Order("id ASC").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -191,6 +191,23 @@ func (s *RefreshTokenRepositoryTestSuite) TestUpdate() {
 
 }
 
+func (s *RefreshTokenRepositoryTestSuite) TestFindByUserID() {
+	items := []*models.RefreshToken{
+		{RefreshToken: "token1", IpAddress: "127.0.0.1", UserID: 1},
+		{RefreshToken: "token2", IpAddress: "127.0.0.1", UserID: 2},
+		{RefreshToken: "token3", IpAddress: "127.0.0.1", UserID: 1},
+	}
+	for _, item := range items {
+		s.Require().NoError(s.repo.Create(item))
+	}
+
+	tokens, err := s.repo.FindByUserID(1)
+	s.NoError(err)
+	s.Require().Len(tokens, 2)
+	s.Equal("token1", tokens[0].RefreshToken)
+	s.Equal("token3", tokens[1].RefreshToken)
+}
+
 func TestRefreshTokenRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RefreshTokenRepositoryTestSuite))
 }
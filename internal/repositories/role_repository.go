@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+)
+
+type IRoleRepository interface {
+	GetPermissionNamesByUserID(userID uint) ([]string, error)
+	AssignRolesWithTx(tx *gorm.DB, userID uint, roleIDs []uint) error
+	FindByIDs(ids []uint) ([]models.Role, error)
+}
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository creates a new instance of RoleRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *RoleRepository: pointer to the newly created RoleRepository
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+// GetPermissionNamesByUserID retrieves the names of all permissions granted to a user through their roles
+// Parameters:
+//   - userID: The ID of the user
+//
+// Returns:
+//   - []string: Distinct permission names (e.g. "attributes.manage")
+//   - error: nil if successful, error otherwise
+func (repo *RoleRepository) GetPermissionNamesByUserID(userID uint) ([]string, error) {
+	var names []string
+	err := repo.db.Model(&models.Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("permissions.name", &names).Error
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// AssignRolesWithTx links a user to the given roles within a transaction
+// Parameters:
+//   - tx: Pointer to the gorm.DB transaction
+//   - userID: The ID of the user
+//   - roleIDs: IDs of the roles to assign, roles already assigned are ignored
+//
+// Returns:
+//   - error: nil if successful, error otherwise
+func (repo *RoleRepository) AssignRolesWithTx(tx *gorm.DB, userID uint, roleIDs []uint) error {
+	for _, roleID := range roleIDs {
+		userRole := models.UserRole{UserID: userID, RoleID: roleID}
+		if err := tx.Where(userRole).FirstOrCreate(&userRole).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByIDs retrieves the roles with the given IDs and their permissions, unknown IDs are ignored
+// Parameters:
+//   - ids: IDs of the roles to load
+//
+// Returns:
+//   - []models.Role: The roles found
+//   - error: nil if successful, error otherwise
+func (repo *RoleRepository) FindByIDs(ids []uint) ([]models.Role, error) {
+	var roles []models.Role
+	if len(ids) == 0 {
+		return roles, nil
+	}
+	if err := repo.db.Preload("Permissions").Where("id IN ?", ids).Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
@@ -0,0 +1,98 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type RoleRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.RoleRepository
+}
+
+func (s *RoleRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Permission{}, &models.Role{}, &models.UserRole{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewRoleRepository(db)
+}
+
+func (s *RoleRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *RoleRepositoryTestSuite) TestGetPermissionNamesByUserID() {
+	manage := models.Permission{Name: "attributes.manage"}
+	view := models.Permission{Name: "users.view"}
+	admin := models.Role{Name: "admin", Permissions: []models.Permission{manage, view}}
+	s.Require().NoError(s.db.Create(&admin).Error)
+	editor := models.Role{Name: "editor", Permissions: []models.Permission{admin.Permissions[1]}}
+	s.Require().NoError(s.db.Create(&editor).Error)
+
+	s.Require().NoError(s.repo.AssignRolesWithTx(s.db, 1, []uint{admin.ID, editor.ID}))
+
+	names, err := s.repo.GetPermissionNamesByUserID(1)
+	s.NoError(err)
+	s.ElementsMatch([]string{"attributes.manage", "users.view"}, names)
+
+	names, err = s.repo.GetPermissionNamesByUserID(2)
+	s.NoError(err)
+	s.Empty(names)
+}
+
+func (s *RoleRepositoryTestSuite) TestAssignRolesWithTx() {
+	role := models.Role{Name: "admin"}
+	s.Require().NoError(s.db.Create(&role).Error)
+
+	// Assigning the same role twice keeps a single link
+	s.NoError(s.repo.AssignRolesWithTx(s.db, 1, []uint{role.ID}))
+	s.NoError(s.repo.AssignRolesWithTx(s.db, 1, []uint{role.ID}))
+
+	var count int64
+	s.db.Model(&models.UserRole{}).Where("user_id = ?", 1).Count(&count)
+	s.Equal(int64(1), count)
+}
+
+func (s *RoleRepositoryTestSuite) TestFindByIDs() {
+	admin := models.Role{Name: "admin", DisplayName: "Admin", Permissions: []models.Permission{{Name: "users.invite"}}}
+	editor := models.Role{Name: "editor", DisplayName: "Editor"}
+	s.Require().NoError(s.db.Create(&admin).Error)
+	s.Require().NoError(s.db.Create(&editor).Error)
+
+	roles, err := s.repo.FindByIDs([]uint{admin.ID, 999})
+	s.NoError(err)
+	s.Require().Len(roles, 1)
+	s.Equal("admin", roles[0].Name)
+	s.Require().Len(roles[0].Permissions, 1)
+	s.Equal("users.invite", roles[0].Permissions[0].Name)
+
+	roles, err = s.repo.FindByIDs(nil)
+	s.NoError(err)
+	s.Empty(roles)
+}
+
+func (s *RoleRepositoryTestSuite) TestGetPermissionNamesByUserIDError() {
+	sqlDB, err := s.db.DB()
+	s.Require().NoError(err)
+	s.Require().NoError(sqlDB.Close())
+
+	names, err := s.repo.GetPermissionNamesByUserID(1)
+	s.Error(err)
+	s.Nil(names)
+}
+
+func TestRoleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RoleRepositoryTestSuite))
+}
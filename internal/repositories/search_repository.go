@@ -0,0 +1,219 @@
+package repositories
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SearchTables lists the tables whose rows are indexed for full-text search
+var SearchTables = []string{"posts", "pages", "users"}
+
+// ChangeHandler is called after rows of a watched table are created, updated or deleted
+// The IDs are nil when the changed rows cannot be told from the statement
+type ChangeHandler func(table string, ids []uint)
+
+type ISearchRepository interface {
+	FindPosts(ids []uint) ([]models.Post, error)
+	FindPostsAfter(afterID uint, limit int) ([]models.Post, error)
+	FindPages(ids []uint) ([]models.Page, error)
+	FindPagesAfter(afterID uint, limit int) ([]models.Page, error)
+	FindUsers(ids []uint) ([]models.User, error)
+	FindUsersAfter(afterID uint, limit int) ([]models.User, error)
+	WatchChanges(handler ChangeHandler) error
+}
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new instance of SearchRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *SearchRepository: pointer to the newly created SearchRepository
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// FindPosts retrieves the posts with the given IDs, with any status, deleted posts are left out
+func (repo *SearchRepository) FindPosts(ids []uint) ([]models.Post, error) {
+	var posts []models.Post
+	if err := repo.db.Where("id IN ?", ids).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// FindPostsAfter retrieves the next batch of posts ordered by ID, used to rebuild the index
+func (repo *SearchRepository) FindPostsAfter(afterID uint, limit int) ([]models.Post, error) {
+	var posts []models.Post
+	if err := repo.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&posts).Error; err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// FindPages retrieves the pages with the given IDs, with any status
+func (repo *SearchRepository) FindPages(ids []uint) ([]models.Page, error) {
+	var pages []models.Page
+	if err := repo.db.Where("id IN ?", ids).Find(&pages).Error; err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// FindPagesAfter retrieves the next batch of pages ordered by ID, used to rebuild the index
+func (repo *SearchRepository) FindPagesAfter(afterID uint, limit int) ([]models.Page, error) {
+	var pages []models.Page
+	if err := repo.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&pages).Error; err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// FindUsers retrieves the users with the given IDs, deleted users are left out
+func (repo *SearchRepository) FindUsers(ids []uint) ([]models.User, error) {
+	var users []models.User
+	if err := repo.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// FindUsersAfter retrieves the next batch of users ordered by ID, used to rebuild the index
+func (repo *SearchRepository) FindUsersAfter(afterID uint, limit int) ([]models.User, error) {
+	var users []models.User
+	if err := repo.db.Where("id > ?", afterID).Order("id ASC").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// WatchChanges registers GORM callbacks reporting every successful create, update and delete on the SearchTables
+// Every repository sharing the connection is covered, whatever service made the change
+// Parameters:
+//   - handler: Called with the table and the IDs of the changed rows
+//
+// Returns:
+//   - error: nil if successful, otherwise the error returned while registering the callbacks
+func (repo *SearchRepository) WatchChanges(handler ChangeHandler) error {
+	return WatchChanges(repo.db, "search", SearchTables, handler)
+}
+
+// WatchChanges registers GORM callbacks reporting every successful create, update and delete on some tables
+// Parameters:
+//   - db: The connection shared by the repositories
+//   - name: Prefix of the callback names, unique for each watcher, e.g. "search"
+//   - tables: The watched tables
+//   - handler: Called with the table and the IDs of the changed rows
+//
+// Returns:
+//   - error: nil if successful, otherwise the error returned while registering the callbacks
+//
+// The function:
+//  1. Takes the IDs from the primary keys of the saved or deleted models
+//  2. Falls back to primary key conditions such as Delete(&models.Post{}, id)
+//  3. Reports nil IDs when neither is available, e.g. for Where("status = ?").Updates(...)
+//
+// Changes made in a transaction are reported before it is committed
+func WatchChanges(db *gorm.DB, name string, tables []string, handler ChangeHandler) error {
+	callback := func(tx *gorm.DB) {
+		if tx.Error != nil || tx.Statement.Schema == nil || !slices.Contains(tables, tx.Statement.Schema.Table) {
+			return
+		}
+		handler(tx.Statement.Schema.Table, changedIDs(tx))
+	}
+
+	if err := db.Callback().Create().After("gorm:create").Register(name+":create", callback); err != nil {
+		return fmt.Errorf("register %s create callback: %w", name, err)
+	}
+	if err := db.Callback().Update().After("gorm:update").Register(name+":update", callback); err != nil {
+		return fmt.Errorf("register %s update callback: %w", name, err)
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register(name+":delete", callback); err != nil {
+		return fmt.Errorf("register %s delete callback: %w", name, err)
+	}
+	return nil
+}
+
+// changedIDs extracts the IDs of the rows changed by a statement, nil when they are unknown
+func changedIDs(tx *gorm.DB) []uint {
+	var ids []uint
+	add := func(value any) {
+		switch id := value.(type) {
+		case uint:
+			ids = append(ids, id)
+		case int:
+			ids = append(ids, uint(id))
+		case int64:
+			ids = append(ids, uint(id))
+		case uint64:
+			ids = append(ids, uint(id))
+		case string:
+			var parsed uint
+			if _, err := fmt.Sscan(id, &parsed); err == nil {
+				ids = append(ids, parsed)
+			}
+		}
+	}
+
+	// Primary keys of the saved models
+	if field := tx.Statement.Schema.PrioritizedPrimaryField; field != nil {
+		value := tx.Statement.ReflectValue
+		switch value.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				if id, zero := field.ValueOf(tx.Statement.Context, reflect.Indirect(value.Index(i))); !zero {
+					add(id)
+				}
+			}
+		case reflect.Struct:
+			if id, zero := field.ValueOf(tx.Statement.Context, value); !zero {
+				add(id)
+			}
+		}
+	}
+	if len(ids) > 0 {
+		return ids
+	}
+
+	// Primary key conditions, e.g. Delete(&models.Post{}, id)
+	where, ok := tx.Statement.Clauses["WHERE"].Expression.(clause.Where)
+	if !ok {
+		return nil
+	}
+	for _, expression := range where.Exprs {
+		switch condition := expression.(type) {
+		case clause.IN:
+			if isPrimaryColumn(condition.Column) {
+				for _, value := range condition.Values {
+					add(value)
+				}
+			}
+		case clause.Eq:
+			if isPrimaryColumn(condition.Column) {
+				add(condition.Value)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	return ids
+}
+
+func isPrimaryColumn(column any) bool {
+	switch c := column.(type) {
+	case clause.Column:
+		return c.Name == clause.PrimaryKey || c.Name == "id"
+	case string:
+		return c == "id"
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type searchChange struct {
+	table string
+	ids   []uint
+}
+
+type SearchRepositoryTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	repo    *repositories.SearchRepository
+	author  *models.User
+	changes []searchChange
+}
+
+func (s *SearchRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Category{}, &models.Tag{}, &models.Post{}, &models.PostTransition{}, &models.PostRevision{}, &models.Page{}, &models.Redirect{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewSearchRepository(db)
+
+	s.author = &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(db.Create(s.author).Error)
+
+	s.changes = nil
+	s.Require().NoError(s.repo.WatchChanges(func(table string, ids []uint) {
+		s.changes = append(s.changes, searchChange{table: table, ids: ids})
+	}))
+}
+
+func (s *SearchRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *SearchRepositoryTestSuite) createPost(title string) *models.Post {
+	post := &models.Post{Title: title, Slug: title, Body: "Body", AuthorID: s.author.ID, Status: models.PostStatusDraft}
+	s.Require().NoError(s.db.Create(post).Error)
+	return post
+}
+
+func (s *SearchRepositoryTestSuite) TestWatchChanges() {
+	s.Run("Saved models", func() {
+		s.changes = nil
+		post := s.createPost("first")
+		post.Title = "First"
+		s.Require().NoError(s.db.Save(post).Error)
+		s.Equal([]searchChange{{"posts", []uint{post.ID}}, {"posts", []uint{post.ID}}}, s.changes)
+	})
+
+	s.Run("Primary key conditions", func() {
+		post := s.createPost("second")
+		s.changes = nil
+		s.Require().NoError(repositories.NewPostRepository(s.db).Delete(post.ID))
+		s.Require().NoError(s.db.Model(&models.Page{ID: 7}).Update("path", "x").Error)
+		s.Equal([]searchChange{{"posts", []uint{post.ID}}, {"pages", []uint{7}}}, s.changes)
+	})
+
+	s.Run("Workflow transitions", func() {
+		post := s.createPost("third")
+		s.changes = nil
+		post.Status = models.PostStatusInReview
+		err := repositories.NewPostRepository(s.db).ApplyTransition(post, &models.PostTransition{
+			Action: models.PostActionSubmit, FromStatus: models.PostStatusDraft, ToStatus: models.PostStatusInReview,
+		})
+		s.Require().NoError(err)
+		s.Equal([]searchChange{{"posts", []uint{post.ID}}}, s.changes)
+	})
+
+	s.Run("Unknown rows", func() {
+		s.changes = nil
+		s.Require().NoError(s.db.Model(&models.Post{}).Where("category_id = ?", 3).Update("category_id", nil).Error)
+		s.Equal([]searchChange{{"posts", nil}}, s.changes)
+	})
+
+	s.Run("Other tables and failures are ignored", func() {
+		s.changes = nil
+		s.Require().NoError(s.db.Create(&models.Category{Name: "News", Slug: "news"}).Error)
+		s.Error(s.db.Create(&models.User{ID: s.author.ID, Email: "author@example.com", Name: "Dup", Password: "x"}).Error)
+		s.Empty(s.changes)
+	})
+}
+
+func (s *SearchRepositoryTestSuite) TestFind() {
+	first := s.createPost("first")
+	second := s.createPost("second")
+	s.Require().NoError(s.db.Delete(second).Error)
+	third := s.createPost("third")
+
+	posts, err := s.repo.FindPosts([]uint{first.ID, second.ID})
+	s.Require().NoError(err)
+	s.Require().Len(posts, 1)
+	s.Equal("Body", posts[0].Body)
+
+	posts, err = s.repo.FindPostsAfter(first.ID, 10)
+	s.Require().NoError(err)
+	s.Require().Len(posts, 1)
+	s.Equal(third.ID, posts[0].ID)
+
+	users, err := s.repo.FindUsersAfter(0, 10)
+	s.Require().NoError(err)
+	s.Len(users, 1)
+}
+
+func TestSearchRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchRepositoryTestSuite))
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+)
+
+// SitemapEntry is a public URL of the website, identified by the slug or path of its record
+type SitemapEntry struct {
+	ID        uint
+	Slug      string // The path for pages, e.g. "about/team"
+	UpdatedAt time.Time
+}
+
+type ISitemapRepository interface {
+	FindPostsAfter(afterID uint, limit int) ([]SitemapEntry, error)
+	FindPagesAfter(afterID uint, limit int) ([]SitemapEntry, error)
+	FindCategoriesAfter(afterID uint, limit int) ([]SitemapEntry, error)
+	FindTagsAfter(afterID uint, limit int) ([]SitemapEntry, error)
+}
+
+type SitemapRepository struct {
+	db *gorm.DB
+}
+
+// NewSitemapRepository creates a new instance of SitemapRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *SitemapRepository: pointer to the newly created SitemapRepository
+func NewSitemapRepository(db *gorm.DB) *SitemapRepository {
+	return &SitemapRepository{db: db}
+}
+
+// FindPostsAfter retrieves the next batch of published posts ordered by ID
+func (repo *SitemapRepository) FindPostsAfter(afterID uint, limit int) ([]SitemapEntry, error) {
+	query := repo.db.Model(&models.Post{}).
+		Select("id, slug, updated_at").
+		Where("status = ?", models.PostStatusPublished)
+	return repo.findAfter(query, afterID, limit)
+}
+
+// FindPagesAfter retrieves the next batch of published pages ordered by ID, with their path as slug
+func (repo *SitemapRepository) FindPagesAfter(afterID uint, limit int) ([]SitemapEntry, error) {
+	query := repo.db.Model(&models.Page{}).
+		Select("id, path AS slug, updated_at").
+		Where("status = ?", models.PageStatusPublished)
+	return repo.findAfter(query, afterID, limit)
+}
+
+// FindCategoriesAfter retrieves the next batch of categories with published posts ordered by ID
+// Categories without posts of their own are left out, their archive only lists the posts of their subcategories
+func (repo *SitemapRepository) FindCategoriesAfter(afterID uint, limit int) ([]SitemapEntry, error) {
+	published := repo.db.Model(&models.Post{}).
+		Select("category_id").
+		Where("status = ? AND category_id IS NOT NULL", models.PostStatusPublished)
+	query := repo.db.Model(&models.Category{}).
+		Select("id, slug, updated_at").
+		Where("id IN (?)", published)
+	return repo.findAfter(query, afterID, limit)
+}
+
+// FindTagsAfter retrieves the next batch of tags of published posts ordered by ID
+func (repo *SitemapRepository) FindTagsAfter(afterID uint, limit int) ([]SitemapEntry, error) {
+	published := repo.db.Table("post_tags").
+		Select("post_tags.tag_id").
+		Joins("JOIN posts ON posts.id = post_tags.post_id").
+		Where("posts.status = ? AND posts.deleted_at IS NULL", models.PostStatusPublished)
+	query := repo.db.Model(&models.Tag{}).
+		Select("id, slug, updated_at").
+		Where("id IN (?)", published)
+	return repo.findAfter(query, afterID, limit)
+}
+
+// findAfter pages through a query by ID, unlike an offset a page costs the same however far into the table it is
+func (repo *SitemapRepository) findAfter(query *gorm.DB, afterID uint, limit int) ([]SitemapEntry, error) {
+	var entries []SitemapEntry
+	if err := query.Where("id > ?", afterID).Order("id ASC").Limit(limit).Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
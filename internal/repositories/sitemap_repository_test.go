@@ -0,0 +1,107 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type SitemapRepositoryTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	repo   *repositories.SitemapRepository
+	author *models.User
+}
+
+func (s *SitemapRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Category{}, &models.Tag{}, &models.Post{}, &models.Page{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewSitemapRepository(db)
+
+	s.author = &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(db.Create(s.author).Error)
+}
+
+func (s *SitemapRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *SitemapRepositoryTestSuite) createPost(slug, status string, categoryID *uint, tags []models.Tag) *models.Post {
+	post := &models.Post{Title: slug, Slug: slug, Body: "Body", AuthorID: s.author.ID, Status: status, CategoryID: categoryID, Tags: tags}
+	s.Require().NoError(s.db.Create(post).Error)
+	return post
+}
+
+func (s *SitemapRepositoryTestSuite) slugs(entries []repositories.SitemapEntry) []string {
+	slugs := make([]string, len(entries))
+	for i, entry := range entries {
+		s.False(entry.UpdatedAt.IsZero())
+		slugs[i] = entry.Slug
+	}
+	return slugs
+}
+
+func (s *SitemapRepositoryTestSuite) TestFindPostsAfter() {
+	first := s.createPost("first", models.PostStatusPublished, nil, nil)
+	s.createPost("draft", models.PostStatusDraft, nil, nil)
+	s.createPost("second", models.PostStatusPublished, nil, nil)
+	deleted := s.createPost("deleted", models.PostStatusPublished, nil, nil)
+	s.Require().NoError(s.db.Delete(deleted).Error)
+	s.createPost("third", models.PostStatusPublished, nil, nil)
+
+	entries, err := s.repo.FindPostsAfter(0, 2)
+	s.Require().NoError(err)
+	s.Equal([]string{"first", "second"}, s.slugs(entries))
+	s.Equal(first.ID, entries[0].ID)
+
+	entries, err = s.repo.FindPostsAfter(entries[1].ID, 2)
+	s.Require().NoError(err)
+	s.Equal([]string{"third"}, s.slugs(entries))
+}
+
+func (s *SitemapRepositoryTestSuite) TestFindPagesAfter() {
+	s.Require().NoError(s.db.Create(&models.Page{Title: "About", Slug: "about", Path: "about", Status: models.PageStatusPublished, AuthorID: s.author.ID}).Error)
+	s.Require().NoError(s.db.Create(&models.Page{Title: "Team", Slug: "team", Path: "about/team", Status: models.PageStatusPublished, AuthorID: s.author.ID}).Error)
+	s.Require().NoError(s.db.Create(&models.Page{Title: "Draft", Slug: "draft", Path: "draft", Status: models.PageStatusDraft, AuthorID: s.author.ID}).Error)
+
+	entries, err := s.repo.FindPagesAfter(0, 10)
+	s.Require().NoError(err)
+	s.Equal([]string{"about", "about/team"}, s.slugs(entries))
+}
+
+func (s *SitemapRepositoryTestSuite) TestFindTaxonomiesAfter() {
+	news := &models.Category{Name: "News", Slug: "news"}
+	empty := &models.Category{Name: "Empty", Slug: "empty"}
+	drafts := &models.Category{Name: "Drafts", Slug: "drafts"}
+	s.Require().NoError(s.db.Create([]*models.Category{news, empty, drafts}).Error)
+	golang := models.Tag{Name: "Go", Slug: "go"}
+	unused := models.Tag{Name: "Unused", Slug: "unused"}
+	hidden := models.Tag{Name: "Hidden", Slug: "hidden"}
+	s.Require().NoError(s.db.Create([]*models.Tag{&golang, &unused, &hidden}).Error)
+
+	s.createPost("published", models.PostStatusPublished, &news.ID, []models.Tag{golang})
+	s.createPost("draft", models.PostStatusDraft, &drafts.ID, []models.Tag{hidden})
+
+	categories, err := s.repo.FindCategoriesAfter(0, 10)
+	s.Require().NoError(err)
+	s.Equal([]string{"news"}, s.slugs(categories))
+
+	tags, err := s.repo.FindTagsAfter(0, 10)
+	s.Require().NoError(err)
+	s.Equal([]string{"go"}, s.slugs(tags))
+}
+
+func TestSitemapRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(SitemapRepositoryTestSuite))
+}
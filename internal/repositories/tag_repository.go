@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITagRepository interface {
+	PaginateTags(page, limit int, search string) (*utils.Pagination, error)
+	GetByID(id uint) (*models.Tag, error)
+	FindBySlug(slug string) (*models.Tag, error)
+	SlugExists(slug string, excludeID uint) (bool, error)
+	FindOrCreate(tags []models.Tag) ([]models.Tag, error)
+	Create(tag *models.Tag) error
+	Update(tag *models.Tag) error
+	Delete(id uint) error
+}
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new instance of TagRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *TagRepository: pointer to the newly created TagRepository
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// PaginateTags retrieves a page of tags ordered by name
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of tags per page
+//   - search: Only tags whose name contains this text, empty for every tag
+//
+// Returns:
+//   - *utils.Pagination: The page of tags
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TagRepository) PaginateTags(page, limit int, search string) (*utils.Pagination, error) {
+	query := repo.db.Model(&models.Tag{})
+	if search != "" {
+		query = query.Where("name LIKE ?", "%"+search+"%")
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var tags []models.Tag
+	if err := query.Offset((page - 1) * limit).Limit(limit).Order("name ASC, id ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       tags,
+	}, nil
+}
+
+// GetByID retrieves a tag by its ID
+// Parameters:
+//   - id: The ID of the tag
+//
+// Returns:
+//   - *models.Tag: The tag
+//   - error: gorm.ErrRecordNotFound if the tag does not exist, otherwise the error that occurred
+func (repo *TagRepository) GetByID(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	if err := repo.db.First(&tag, id).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindBySlug retrieves a tag by its slug
+// Parameters:
+//   - slug: The slug of the tag
+//
+// Returns:
+//   - *models.Tag: The tag
+//   - error: gorm.ErrRecordNotFound if no tag has this slug, otherwise the error that occurred
+func (repo *TagRepository) FindBySlug(slug string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := repo.db.Where("slug = ?", slug).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// SlugExists checks whether a slug is used by a tag other than the excluded one
+// Parameters:
+//   - slug: The slug to look for
+//   - excludeID: ID of the tag being saved, 0 when creating a tag
+//
+// Returns:
+//   - bool: true if another tag uses the slug
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TagRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Model(&models.Tag{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindOrCreate loads the tags with the given slugs and creates the missing ones
+// Tags created concurrently by another request are reused thanks to the unique slug
+// Parameters:
+//   - tags: The tags to look for, identified by their slugs
+//
+// Returns:
+//   - []models.Tag: The stored tags, ordered by name
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TagRepository) FindOrCreate(tags []models.Tag) ([]models.Tag, error) {
+	if len(tags) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+
+	if err := repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var stored []models.Tag
+	if err := repo.db.Where("slug IN ?", slugs).Order("name ASC, id ASC").Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// Create stores a new tag
+// Parameters:
+//   - tag: The tag to create, its ID is set on success
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TagRepository) Create(tag *models.Tag) error {
+	return repo.db.Create(tag).Error
+}
+
+// Update saves an existing tag, the archive of the old slug is redirected to the new one
+// Parameters:
+//   - tag: The tag to save
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TagRepository) Update(tag *models.Tag) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var stored models.Tag
+		if err := tx.Select("slug").First(&stored, tag.ID).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(tag).Error; err != nil {
+			return err
+		}
+		return recordMoves(tx, map[string]string{"/tags/" + stored.Slug: "/tags/" + tag.Slug})
+	})
+}
+
+// Delete removes a tag and unlinks it from its posts
+// Parameters:
+//   - id: The ID of the tag
+//
+// Returns:
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TagRepository) Delete(id uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("post_tags").Where("tag_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Tag{}, id).Error
+	})
+}
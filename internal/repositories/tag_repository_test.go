@@ -0,0 +1,100 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TagRepositoryTestSuite struct {
+	suite.Suite
+	db   *gorm.DB
+	repo *repositories.TagRepository
+}
+
+func (s *TagRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Category{}, &models.Tag{}, &models.Post{}, &models.Redirect{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewTagRepository(db)
+}
+
+func (s *TagRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *TagRepositoryTestSuite) TestFindOrCreate() {
+	existing := &models.Tag{Name: "Go", Slug: "go"}
+	s.Require().NoError(s.repo.Create(existing))
+
+	tags, err := s.repo.FindOrCreate([]models.Tag{{Name: "golang", Slug: "go"}, {Name: "News", Slug: "news"}})
+	s.Require().NoError(err)
+	s.Require().Len(tags, 2)
+	s.Equal(existing.ID, tags[0].ID)
+	s.Equal("Go", tags[0].Name)
+	s.Equal("news", tags[1].Slug)
+	s.NotZero(tags[1].ID)
+
+	tags, err = s.repo.FindOrCreate(nil)
+	s.Require().NoError(err)
+	s.Empty(tags)
+}
+
+func (s *TagRepositoryTestSuite) TestPaginateTags() {
+	for _, name := range []string{"Go", "Golang", "News"} {
+		s.Require().NoError(s.repo.Create(&models.Tag{Name: name, Slug: name}))
+	}
+
+	pagination, err := s.repo.PaginateTags(1, 10, "Go")
+	s.Require().NoError(err)
+	s.Equal(2, pagination.TotalItems)
+	tags := pagination.Data.([]models.Tag)
+	s.Equal("Go", tags[0].Name)
+	s.Equal("Golang", tags[1].Name)
+
+	pagination, err = s.repo.PaginateTags(1, 2, "")
+	s.Require().NoError(err)
+	s.Equal(3, pagination.TotalItems)
+	s.Equal(2, pagination.TotalPages)
+}
+
+func (s *TagRepositoryTestSuite) TestUpdateAndDelete() {
+	tag := &models.Tag{Name: "Go", Slug: "go"}
+	s.Require().NoError(s.repo.Create(tag))
+	author := &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(s.db.Create(author).Error)
+	post := &models.Post{Title: "Hello", Slug: "hello", Body: "Body", AuthorID: author.ID, Status: models.PostStatusDraft, Tags: []models.Tag{*tag}}
+	s.Require().NoError(s.db.Create(post).Error)
+
+	tag.Name = "Golang"
+	s.Require().NoError(s.repo.Update(tag))
+	found, err := s.repo.FindBySlug("go")
+	s.Require().NoError(err)
+	s.Equal("Golang", found.Name)
+
+	exists, err := s.repo.SlugExists("go", tag.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	s.Require().NoError(s.repo.Delete(tag.ID))
+	_, err = s.repo.GetByID(tag.ID)
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	var links int64
+	s.Require().NoError(s.db.Table("post_tags").Where("tag_id = ?", tag.ID).Count(&links).Error)
+	s.Zero(links)
+}
+
+func TestTagRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TagRepositoryTestSuite))
+}
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MissingTranslation is a post or a page without a translation in a locale
+type MissingTranslation struct {
+	ContentType string    `json:"contentType"`
+	ContentID   uint      `json:"contentId"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"` // Full path for pages, e.g. "about/team"
+	Status      string    `json:"status"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type ITranslationRepository interface {
+	FindByContent(contentType string, contentID uint) ([]models.Translation, error)
+	FindForContents(contentType string, contentIDs []uint, locales []string) ([]models.Translation, error)
+	FindByType(contentType string, locales []string) ([]models.Translation, error)
+	FindBySlug(contentType, slug string, locales []string) ([]models.Translation, error)
+	GetByLocale(contentType string, contentID uint, locale string) (*models.Translation, error)
+	SlugExists(contentType, locale, slug string, excludeID uint) (bool, error)
+	Save(translation *models.Translation) error
+	Delete(contentType string, contentID uint, locale string) (int64, error)
+	PaginateMissing(contentType, locale string, page, limit int) (*utils.Pagination, error)
+}
+
+type TranslationRepository struct {
+	db *gorm.DB
+}
+
+// NewTranslationRepository creates a new instance of TranslationRepository
+// Parameters:
+//   - db: pointer to the gorm.DB instance for database operations
+//
+// Returns:
+//   - *TranslationRepository: pointer to the newly created TranslationRepository
+func NewTranslationRepository(db *gorm.DB) *TranslationRepository {
+	return &TranslationRepository{db: db}
+}
+
+// FindByContent retrieves every translation of a post or a page, ordered by locale
+func (repo *TranslationRepository) FindByContent(contentType string, contentID uint) ([]models.Translation, error) {
+	var translations []models.Translation
+	if err := repo.db.Where("content_type = ? AND content_id = ?", contentType, contentID).
+		Order("locale ASC").
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// FindForContents retrieves the translations of several posts or pages in the given locales
+// Parameters:
+//   - contentType: models.TranslationTypePost or models.TranslationTypePage
+//   - contentIDs: IDs of the posts or pages
+//   - locales: The locales to load, e.g. the fallback chain of the requested locale
+//
+// Returns:
+//   - []models.Translation: The translations found, in no particular order
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TranslationRepository) FindForContents(contentType string, contentIDs []uint, locales []string) ([]models.Translation, error) {
+	var translations []models.Translation
+	if len(contentIDs) == 0 || len(locales) == 0 {
+		return translations, nil
+	}
+	if err := repo.db.
+		Where("content_type = ? AND content_id IN ? AND locale IN ?", contentType, contentIDs, locales).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// FindByType retrieves the translations of every post or page in the given locales without their body
+func (repo *TranslationRepository) FindByType(contentType string, locales []string) ([]models.Translation, error) {
+	var translations []models.Translation
+	if len(locales) == 0 {
+		return translations, nil
+	}
+	if err := repo.db.Omit("body").
+		Where("content_type = ? AND locale IN ?", contentType, locales).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// FindBySlug retrieves the translations using a slug in any of the given locales
+func (repo *TranslationRepository) FindBySlug(contentType, slug string, locales []string) ([]models.Translation, error) {
+	var translations []models.Translation
+	if len(locales) == 0 {
+		return translations, nil
+	}
+	if err := repo.db.
+		Where("content_type = ? AND slug = ? AND locale IN ?", contentType, slug, locales).
+		Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+// GetByLocale retrieves the translation of a post or a page in a locale
+// Returns gorm.ErrRecordNotFound if the content has no translation in this locale
+func (repo *TranslationRepository) GetByLocale(contentType string, contentID uint, locale string) (*models.Translation, error) {
+	var translation models.Translation
+	if err := repo.db.
+		Where("content_type = ? AND content_id = ? AND locale = ?", contentType, contentID, locale).
+		First(&translation).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// SlugExists checks whether a slug is used by another translation of the same type in a locale
+// Parameters:
+//   - contentType: models.TranslationTypePost or models.TranslationTypePage
+//   - locale: The locale of the translation
+//   - slug: The slug to check
+//   - excludeID: The ID of the translation being updated, 0 when creating a translation
+//
+// Returns:
+//   - bool: true if another translation uses the slug
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TranslationRepository) SlugExists(contentType, locale, slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := repo.db.Model(&models.Translation{}).
+		Where("content_type = ? AND locale = ? AND slug = ? AND id <> ?", contentType, locale, slug, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Save creates a translation, or updates it when its ID is set
+func (repo *TranslationRepository) Save(translation *models.Translation) error {
+	return repo.db.Omit(clause.Associations).Save(translation).Error
+}
+
+// Delete removes the translation of a post or a page in a locale
+// Returns:
+//   - int64: Number of translations removed, 0 if the content has no translation in this locale
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TranslationRepository) Delete(contentType string, contentID uint, locale string) (int64, error) {
+	result := repo.db.
+		Where("content_type = ? AND content_id = ? AND locale = ?", contentType, contentID, locale).
+		Delete(&models.Translation{})
+	return result.RowsAffected, result.Error
+}
+
+// PaginateMissing retrieves a page of the posts or pages without a translation in a locale, oldest first
+// Deleted posts are left out
+// Parameters:
+//   - contentType: models.TranslationTypePost or models.TranslationTypePage
+//   - locale: The locale of the missing translations
+//   - page: The page number to retrieve
+//   - limit: The number of items per page
+//
+// Returns:
+//   - *utils.Pagination: The page of []MissingTranslation
+//   - error: nil if successful, otherwise returns the error that occurred
+func (repo *TranslationRepository) PaginateMissing(contentType, locale string, page, limit int) (*utils.Pagination, error) {
+	var table, columns string
+	switch contentType {
+	case models.TranslationTypePost:
+		table, columns = "posts", "posts.id AS content_id, posts.title, posts.slug, posts.status, posts.updated_at"
+	case models.TranslationTypePage:
+		table, columns = "pages", "pages.id AS content_id, pages.title, pages.path AS slug, pages.status, pages.updated_at"
+	default:
+		return nil, fmt.Errorf("unknown content type %q", contentType)
+	}
+
+	// Content with a translation in the locale is joined and left out
+	query := repo.db.Table(table).
+		Joins("LEFT JOIN translations ON translations.content_type = ? AND translations.content_id = "+table+".id AND translations.locale = ?", contentType, locale).
+		Where("translations.id IS NULL")
+	if contentType == models.TranslationTypePost {
+		query = query.Where("posts.deleted_at IS NULL")
+	}
+
+	var totalRows int64
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+
+	var items []MissingTranslation
+	if err := query.Select(columns).Order(table + ".id ASC").Offset((page - 1) * limit).Limit(limit).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].ContentType = contentType
+	}
+
+	return &utils.Pagination{
+		Page:       page,
+		Limit:      limit,
+		TotalItems: int(totalRows),
+		TotalPages: utils.CalculateTotalPages(totalRows, limit),
+		Data:       items,
+	}, nil
+}
@@ -0,0 +1,194 @@
+package repositories_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TranslationRepositoryTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	repo   *repositories.TranslationRepository
+	author *models.User
+}
+
+func (s *TranslationRepositoryTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+
+	err = db.AutoMigrate(&models.User{}, &models.Post{}, &models.Page{}, &models.Translation{})
+	s.Require().NoError(err)
+	s.db = db
+	s.repo = repositories.NewTranslationRepository(db)
+
+	s.author = &models.User{Email: "author@example.com", Name: "Author", Password: "x"}
+	s.Require().NoError(db.Create(s.author).Error)
+}
+
+func (s *TranslationRepositoryTestSuite) TearDownTest() {
+	db, err := s.db.DB()
+	if err == nil {
+		_ = db.Close()
+	}
+}
+
+func (s *TranslationRepositoryTestSuite) createPost(slug string) *models.Post {
+	post := &models.Post{Title: slug, Slug: slug, Body: "Body of " + slug, AuthorID: s.author.ID, Status: models.PostStatusPublished}
+	s.Require().NoError(s.db.Create(post).Error)
+	return post
+}
+
+func (s *TranslationRepositoryTestSuite) createTranslation(contentType string, contentID uint, locale, slug string) *models.Translation {
+	translation := &models.Translation{ContentType: contentType, ContentID: contentID, Locale: locale, Title: slug, Slug: slug, Body: "Body of " + slug}
+	s.Require().NoError(s.repo.Save(translation))
+	return translation
+}
+
+func (s *TranslationRepositoryTestSuite) TestFindByContent() {
+	post := s.createPost("hello")
+	s.createTranslation(models.TranslationTypePost, post.ID, "vi", "xin-chao")
+	s.createTranslation(models.TranslationTypePost, post.ID, "fr", "bonjour")
+	s.createTranslation(models.TranslationTypePage, post.ID, "vi", "trang")
+
+	translations, err := s.repo.FindByContent(models.TranslationTypePost, post.ID)
+	s.Require().NoError(err)
+	s.Require().Len(translations, 2)
+	s.Equal("fr", translations[0].Locale)
+	s.Equal("vi", translations[1].Locale)
+}
+
+func (s *TranslationRepositoryTestSuite) TestFindForContents() {
+	first, second := s.createPost("first"), s.createPost("second")
+	s.createTranslation(models.TranslationTypePost, first.ID, "vi", "thu-nhat")
+	s.createTranslation(models.TranslationTypePost, second.ID, "fr", "deuxieme")
+	s.createTranslation(models.TranslationTypePost, second.ID, "ja", "nibanme")
+
+	translations, err := s.repo.FindForContents(models.TranslationTypePost, []uint{first.ID, second.ID}, []string{"vi", "fr"})
+	s.Require().NoError(err)
+	s.Len(translations, 2)
+
+	translations, err = s.repo.FindForContents(models.TranslationTypePost, nil, []string{"vi"})
+	s.Require().NoError(err)
+	s.Empty(translations)
+}
+
+func (s *TranslationRepositoryTestSuite) TestFindByTypeOmitsBody() {
+	s.createTranslation(models.TranslationTypePage, 1, "vi", "gioi-thieu")
+	s.createTranslation(models.TranslationTypePage, 2, "fr", "equipe")
+
+	translations, err := s.repo.FindByType(models.TranslationTypePage, []string{"vi"})
+	s.Require().NoError(err)
+	s.Require().Len(translations, 1)
+	s.Equal("gioi-thieu", translations[0].Slug)
+	s.Empty(translations[0].Body)
+}
+
+func (s *TranslationRepositoryTestSuite) TestFindBySlug() {
+	s.createTranslation(models.TranslationTypePost, 1, "vi", "xin-chao")
+	s.createTranslation(models.TranslationTypePost, 2, "fr", "xin-chao")
+
+	translations, err := s.repo.FindBySlug(models.TranslationTypePost, "xin-chao", []string{"vi"})
+	s.Require().NoError(err)
+	s.Require().Len(translations, 1)
+	s.Equal(uint(1), translations[0].ContentID)
+}
+
+func (s *TranslationRepositoryTestSuite) TestGetByLocale() {
+	created := s.createTranslation(models.TranslationTypePost, 1, "vi", "xin-chao")
+
+	translation, err := s.repo.GetByLocale(models.TranslationTypePost, 1, "vi")
+	s.Require().NoError(err)
+	s.Equal(created.ID, translation.ID)
+
+	_, err = s.repo.GetByLocale(models.TranslationTypePost, 1, "fr")
+	s.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+func (s *TranslationRepositoryTestSuite) TestSlugExists() {
+	translation := s.createTranslation(models.TranslationTypePost, 1, "vi", "xin-chao")
+
+	exists, err := s.repo.SlugExists(models.TranslationTypePost, "vi", "xin-chao", 0)
+	s.Require().NoError(err)
+	s.True(exists)
+
+	exists, err = s.repo.SlugExists(models.TranslationTypePost, "vi", "xin-chao", translation.ID)
+	s.Require().NoError(err)
+	s.False(exists)
+
+	// Slugs are unique per locale and per type
+	exists, err = s.repo.SlugExists(models.TranslationTypePost, "fr", "xin-chao", 0)
+	s.Require().NoError(err)
+	s.False(exists)
+	exists, err = s.repo.SlugExists(models.TranslationTypePage, "vi", "xin-chao", 0)
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *TranslationRepositoryTestSuite) TestSaveUpdatesExisting() {
+	translation := s.createTranslation(models.TranslationTypePost, 1, "vi", "xin-chao")
+	translation.Title = "Xin chào"
+	s.Require().NoError(s.repo.Save(translation))
+
+	var count int64
+	s.Require().NoError(s.db.Model(&models.Translation{}).Count(&count).Error)
+	s.Equal(int64(1), count)
+
+	stored, err := s.repo.GetByLocale(models.TranslationTypePost, 1, "vi")
+	s.Require().NoError(err)
+	s.Equal("Xin chào", stored.Title)
+}
+
+func (s *TranslationRepositoryTestSuite) TestDelete() {
+	s.createTranslation(models.TranslationTypePost, 1, "vi", "xin-chao")
+
+	deleted, err := s.repo.Delete(models.TranslationTypePost, 1, "vi")
+	s.Require().NoError(err)
+	s.Equal(int64(1), deleted)
+
+	deleted, err = s.repo.Delete(models.TranslationTypePost, 1, "vi")
+	s.Require().NoError(err)
+	s.Equal(int64(0), deleted)
+}
+
+func (s *TranslationRepositoryTestSuite) TestPaginateMissing() {
+	s.Run("Posts", func() {
+		translated, missing, deleted := s.createPost("translated"), s.createPost("missing"), s.createPost("deleted")
+		s.createTranslation(models.TranslationTypePost, translated.ID, "vi", "da-dich")
+		s.Require().NoError(s.db.Delete(deleted).Error)
+
+		pagination, err := s.repo.PaginateMissing(models.TranslationTypePost, "vi", 1, 10)
+		s.Require().NoError(err)
+		s.Equal(1, pagination.TotalItems)
+		items := pagination.Data.([]repositories.MissingTranslation)
+		s.Require().Len(items, 1)
+		s.Equal(missing.ID, items[0].ContentID)
+		s.Equal(models.TranslationTypePost, items[0].ContentType)
+		s.Equal("missing", items[0].Slug)
+	})
+
+	s.Run("Pages use their path", func() {
+		page := &models.Page{Title: "Team", Slug: "team", Path: "about/team", Body: "Body", Template: models.PageTemplateDefault, Status: models.PageStatusPublished, AuthorID: s.author.ID}
+		s.Require().NoError(s.db.Create(page).Error)
+
+		pagination, err := s.repo.PaginateMissing(models.TranslationTypePage, "vi", 1, 10)
+		s.Require().NoError(err)
+		items := pagination.Data.([]repositories.MissingTranslation)
+		s.Require().Len(items, 1)
+		s.Equal("about/team", items[0].Slug)
+		s.Equal(models.PageStatusPublished, items[0].Status)
+	})
+
+	s.Run("Unknown type", func() {
+		_, err := s.repo.PaginateMissing("video", "vi", 1, 10)
+		s.Error(err)
+	})
+}
+
+func TestTranslationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TranslationRepositoryTestSuite))
+}
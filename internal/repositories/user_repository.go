@@ -1,12 +1,21 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
 	"github.com/vfa-khuongdv/golang-cms/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// UserFilter holds the optional criteria applied when listing users
+type UserFilter struct {
+	Attributes map[string]string // Attribute name => exact value in canonical form
+}
+
 type IUserRepository interface {
+	PaginateUser(page, limit int, filter UserFilter) (*utils.Pagination, error)
 	GetAll() ([]models.User, error)
 	GetByID(id uint) (*models.User, error)
 	Create(user *models.User) (*models.User, error)
@@ -16,6 +25,10 @@ type IUserRepository interface {
 	FindByField(field string, value string) (*models.User, error)
 	GetProfile(id uint) (*models.User, error)
 	UpdateProfile(user *models.User) error
+	GetAttributes(userIDs []uint) ([]models.UserAttribute, error)
+	SaveAttributes(userID uint, values []models.UserAttribute, removeDefinitionIDs []uint) error
+	FindDueForDeletion(before time.Time, limit int) ([]models.User, error)
+	Erase(user *models.User) error
 	GetDB() *gorm.DB
 }
 
@@ -31,25 +44,36 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 // Parameters:
 //   - page: The page number to retrieve (default is 1)
 //   - limit: The number of users per page (default is 10)
+//   - filter: Optional criteria, every attribute filter must match for a user to be returned
 //
 // Returns:
 //   - *utils.Pagination: A pointer to the pagination object containing user data
 //   - error: nil if successful, otherwise returns the error that occurred
 //
 // Example:
-//   - users, err := repo.PaginateUser(1, 50) // Gets the first page of users
-func (repo *UserRepository) PaginateUser(page, limit int) (*utils.Pagination, error) {
+//   - users, err := repo.PaginateUser(1, 50, UserFilter{}) // Gets the first page of users
+//   - users, err := repo.PaginateUser(1, 50, UserFilter{Attributes: map[string]string{"department": "Sales"}})
+func (repo *UserRepository) PaginateUser(page, limit int, filter UserFilter) (*utils.Pagination, error) {
 	var totalRows int64
 	offset := (page - 1) * limit
 
+	query := repo.db.Model(&models.User{})
+	for name, value := range filter.Attributes {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM user_attributes JOIN attribute_definitions ON attribute_definitions.id = user_attributes.attribute_definition_id "+
+				"WHERE user_attributes.user_id = users.id AND attribute_definitions.name = ? AND user_attributes.value = ?)",
+			name, value,
+		)
+	}
+
 	// Count total rows
-	if err := repo.db.Model(&models.User{}).Count(&totalRows).Error; err != nil {
+	if err := query.Session(&gorm.Session{}).Count(&totalRows).Error; err != nil {
 		return nil, err
 	}
 
 	var users []models.User
 	// fetch paginated data
-	if err := repo.db.Offset(offset).Limit(limit).Order("id DESC").Find(&users).Error; err != nil {
+	if err := query.Offset(offset).Limit(limit).Order("id DESC").Find(&users).Error; err != nil {
 		return nil, err
 	}
 
@@ -127,9 +151,10 @@ func (repo *UserRepository) CreateWithTx(tx *gorm.DB, user *models.User) (*model
 //   - user: Pointer to the User model to be updated
 //
 // Returns:
-//   - error: Error if there was a problem updating the user, nil on success
+//   - error: ErrVersionConflict if the user was saved by someone else since its version was loaded,
+//     error if there was a problem updating the user, nil on success
 func (repo *UserRepository) Update(user *models.User) error {
-	return repo.db.Save(user).Error
+	return saveVersioned(repo.db, user, &user.Version)
 }
 
 // Delete removes a user from the database
@@ -191,9 +216,107 @@ func (repo *UserRepository) GetProfile(id uint) (*models.User, error) {
 //   - user: Pointer to the User model containing updated profile information
 //
 // Returns:
-//   - error: Error if there was a problem updating the profile, nil on success
+//   - error: ErrVersionConflict if the user was saved by someone else since its version was loaded,
+//     error if there was a problem updating the profile, nil on success
 func (repo *UserRepository) UpdateProfile(user *models.User) error {
-	return repo.db.Save(&user).Error
+	return saveVersioned(repo.db, user, &user.Version)
+}
+
+// GetAttributes retrieves the custom attribute values of the given users together with their definitions
+// Parameters:
+//   - userIDs: IDs of the users whose attribute values are loaded
+//
+// Returns:
+//   - []models.UserAttribute: The attribute values with the Definition relation loaded
+//   - error: Error if there was a database error, nil on success
+func (repo *UserRepository) GetAttributes(userIDs []uint) ([]models.UserAttribute, error) {
+	var values []models.UserAttribute
+	if len(userIDs) == 0 {
+		return values, nil
+	}
+	if err := repo.db.Preload("Definition").Where("user_id IN ?", userIDs).Find(&values).Error; err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// SaveAttributes creates or updates attribute values of a user and removes cleared values in a single transaction
+// Parameters:
+//   - userID: The ID of the user
+//   - values: Attribute values to create or update, matched on attribute definition ID
+//   - removeDefinitionIDs: Definition IDs whose stored values are deleted
+//
+// Returns:
+//   - error: Error if there was a problem saving the values, nil on success
+func (repo *UserRepository) SaveAttributes(userID uint, values []models.UserAttribute, removeDefinitionIDs []uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if len(removeDefinitionIDs) > 0 {
+			if err := tx.Where("user_id = ? AND attribute_definition_id IN ?", userID, removeDefinitionIDs).
+				Delete(&models.UserAttribute{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, value := range values {
+			var existing models.UserAttribute
+			err := tx.Where("user_id = ? AND attribute_definition_id = ?", userID, value.AttributeDefinitionID).First(&existing).Error
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return err
+			}
+
+			existing.UserID = userID
+			existing.AttributeDefinitionID = value.AttributeDefinitionID
+			existing.Value = value.Value
+			if err := tx.Omit(clause.Associations).Save(&existing).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindDueForDeletion retrieves users whose scheduled account deletion is due
+// Parameters:
+//   - before: Users scheduled for deletion before this time are returned
+//   - limit: Maximum number of users to return
+//
+// Returns:
+//   - []models.User: The users to erase, earliest schedule first
+//   - error: Error if there was a database error, nil on success
+func (repo *UserRepository) FindDueForDeletion(before time.Time, limit int) ([]models.User, error) {
+	var users []models.User
+	err := repo.db.Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).
+		Order("deletion_scheduled_at ASC").Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Erase saves the anonymized user, removes the data linked to them and soft deletes the user in a single transaction
+// Parameters:
+//   - user: The user with personal fields already anonymized
+//
+// Returns:
+//   - error: Error if there was a problem erasing the user, nil on success
+func (repo *UserRepository) Erase(user *models.User) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		// The erasure always wins, edits made meanwhile are overwritten and edits still open become stale
+		user.Version++
+		if err := tx.Omit(clause.Associations).Save(user).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&models.UserAttribute{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&models.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&models.DataExport{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(user).Error
+	})
 }
 
 // GetDB returns the database connection
@@ -2,6 +2,7 @@ package repositories_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
@@ -25,6 +26,10 @@ func (s *UserRepositoryTestSuite) SetupTest() {
 	// Auto-migrate the models
 	err = db.AutoMigrate(
 		&models.User{},
+		&models.AttributeDefinition{},
+		&models.UserAttribute{},
+		&models.RefreshToken{},
+		&models.DataExport{},
 	)
 	s.Require().NoError(err)
 	s.db = db
@@ -311,6 +316,129 @@ func (s *UserRepositoryTestSuite) TestUpdate() {
 	s.Equal(int16(1), updatedUser.Gender, "Expected user gender to be 1")
 }
 
+func (s *UserRepositoryTestSuite) TestUpdate_Error_StaleVersion() {
+	user := &models.User{Name: "User", Email: "stale@example.com", Password: "password", Gender: 1}
+	_, err := s.repo.Create(user)
+	s.Require().NoError(err)
+
+	stale := *user
+	user.Name = "Saved first"
+	s.Require().NoError(s.repo.Update(user))
+
+	stale.Name = "Saved second"
+	err = s.repo.Update(&stale)
+	s.ErrorIs(err, repositories.ErrVersionConflict)
+
+	found, err := s.repo.GetByID(user.ID)
+	s.Require().NoError(err)
+	s.Equal("Saved first", found.Name)
+	s.Equal(uint(2), found.Version)
+}
+
+func (s *UserRepositoryTestSuite) createAttributeFixtures() (*models.User, *models.User, *models.AttributeDefinition) {
+	first := &models.User{Name: "User1", Email: "email1@example.com", Password: "password1", Gender: 1}
+	second := &models.User{Name: "User2", Email: "email2@example.com", Password: "password2", Gender: 1}
+	_, err := s.repo.Create(first)
+	s.Require().NoError(err)
+	_, err = s.repo.Create(second)
+	s.Require().NoError(err)
+
+	department := &models.AttributeDefinition{Name: "department", Label: "Department", Type: models.AttributeTypeText}
+	s.Require().NoError(s.db.Create(department).Error)
+	return first, second, department
+}
+
+func (s *UserRepositoryTestSuite) TestPaginateUser() {
+	first, second, department := s.createAttributeFixtures()
+	s.Require().NoError(s.repo.SaveAttributes(first.ID, []models.UserAttribute{{AttributeDefinitionID: department.ID, Value: "Sales"}}, nil))
+	s.Require().NoError(s.repo.SaveAttributes(second.ID, []models.UserAttribute{{AttributeDefinitionID: department.ID, Value: "IT"}}, nil))
+
+	pagination, err := s.repo.PaginateUser(1, 10, repositories.UserFilter{})
+	s.NoError(err)
+	s.Equal(2, pagination.TotalItems)
+
+	pagination, err = s.repo.PaginateUser(1, 10, repositories.UserFilter{Attributes: map[string]string{"department": "Sales"}})
+	s.NoError(err)
+	s.Equal(1, pagination.TotalItems)
+	users := pagination.Data.([]models.User)
+	s.Require().Len(users, 1)
+	s.Equal(first.ID, users[0].ID)
+
+	pagination, err = s.repo.PaginateUser(1, 10, repositories.UserFilter{Attributes: map[string]string{"unknown": "Sales"}})
+	s.NoError(err)
+	s.Equal(0, pagination.TotalItems)
+}
+
+func (s *UserRepositoryTestSuite) TestSaveAndGetAttributes() {
+	first, second, department := s.createAttributeFixtures()
+
+	// Create then update the same value
+	s.Require().NoError(s.repo.SaveAttributes(first.ID, []models.UserAttribute{{AttributeDefinitionID: department.ID, Value: "Sales"}}, nil))
+	s.Require().NoError(s.repo.SaveAttributes(first.ID, []models.UserAttribute{{AttributeDefinitionID: department.ID, Value: "IT"}}, nil))
+
+	values, err := s.repo.GetAttributes([]uint{first.ID, second.ID})
+	s.NoError(err)
+	s.Require().Len(values, 1)
+	s.Equal("IT", values[0].Value)
+	s.Equal("department", values[0].Definition.Name)
+
+	// Remove the value
+	s.Require().NoError(s.repo.SaveAttributes(first.ID, nil, []uint{department.ID}))
+	values, err = s.repo.GetAttributes([]uint{first.ID})
+	s.NoError(err)
+	s.Empty(values)
+
+	values, err = s.repo.GetAttributes(nil)
+	s.NoError(err)
+	s.Empty(values)
+}
+
+func (s *UserRepositoryTestSuite) TestFindDueForDeletion() {
+	first, second, _ := s.createAttributeFixtures()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	first.DeletionScheduledAt = &past
+	second.DeletionScheduledAt = &future
+	s.Require().NoError(s.repo.Update(first))
+	s.Require().NoError(s.repo.Update(second))
+
+	users, err := s.repo.FindDueForDeletion(time.Now(), 10)
+	s.NoError(err)
+	s.Require().Len(users, 1)
+	s.Equal(first.ID, users[0].ID)
+}
+
+func (s *UserRepositoryTestSuite) TestErase() {
+	first, second, department := s.createAttributeFixtures()
+	s.Require().NoError(s.repo.SaveAttributes(first.ID, []models.UserAttribute{{AttributeDefinitionID: department.ID, Value: "Sales"}}, nil))
+	s.Require().NoError(s.repo.SaveAttributes(second.ID, []models.UserAttribute{{AttributeDefinitionID: department.ID, Value: "IT"}}, nil))
+	s.Require().NoError(s.db.Create(&models.RefreshToken{RefreshToken: "token", IpAddress: "127.0.0.1", UserID: first.ID}).Error)
+	s.Require().NoError(s.db.Create(&models.DataExport{UserID: first.ID, Status: models.DataExportStatusCompleted}).Error)
+
+	first.Name = "Deleted user"
+	first.Email = "deleted-1@example.invalid"
+	s.Require().NoError(s.repo.Erase(first))
+
+	_, err := s.repo.GetByID(first.ID)
+	s.Error(err, "Expected the erased user to be soft deleted")
+
+	var erased models.User
+	s.Require().NoError(s.db.Unscoped().First(&erased, first.ID).Error)
+	s.Equal("Deleted user", erased.Name)
+	s.Equal("deleted-1@example.invalid", erased.Email)
+
+	var count int64
+	s.db.Model(&models.RefreshToken{}).Unscoped().Where("user_id = ?", first.ID).Count(&count)
+	s.Zero(count)
+	s.db.Model(&models.DataExport{}).Where("user_id = ?", first.ID).Count(&count)
+	s.Zero(count)
+
+	values, err := s.repo.GetAttributes([]uint{first.ID, second.ID})
+	s.NoError(err)
+	s.Require().Len(values, 1)
+	s.Equal(second.ID, values[0].UserID)
+}
+
 func TestUserRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(UserRepositoryTestSuite))
 }
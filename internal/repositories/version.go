@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict is returned when a record was saved by someone else since the version the caller loaded
+var ErrVersionConflict = errors.New("record has been changed")
+
+// saveVersioned saves every column of a record while the stored version still is the one it was loaded with, and increments the version
+// Unlike Save it never inserts the record when no row matches
+// Parameters:
+//   - tx: The database or transaction to save in
+//   - record: The record to save, its primary key must be set
+//   - version: The Version field of the record
+//   - omit: Columns left unchanged, the associations are never saved
+//
+// Returns:
+//   - error: ErrVersionConflict if the record was changed or deleted since it was loaded, otherwise the error that occurred
+func saveVersioned(tx *gorm.DB, record any, version *uint, omit ...string) error {
+	loaded := *version
+	*version = loaded + 1
+	result := tx.Model(record).
+		Where("version = ?", loaded).
+		Select("*").
+		Omit(append([]string{clause.Associations}, omit...)...).
+		Updates(record)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = loaded
+	}
+	return result.Error
+}
@@ -1,13 +1,22 @@
 package routes
 
 import (
+	"context"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"github.com/vfa-khuongdv/golang-cms/internal/configs"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
 	"github.com/vfa-khuongdv/golang-cms/internal/handlers"
 	"github.com/vfa-khuongdv/golang-cms/internal/middlewares"
 	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
 	"github.com/vfa-khuongdv/golang-cms/internal/services"
 	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/internal/workers"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
 	"gorm.io/gorm"
 )
 
@@ -28,9 +37,36 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		router.StaticFile("/api-docs", "./docs/swagger.html")
 	}
 
+	// Initialize file storage, local files are served by the application itself
+	fileStorage := configs.InitStorage()
+	if localStorage, ok := fileStorage.(*storage.LocalStorage); ok {
+		router.Static("/storage", localStorage.Root())
+	}
+	// Data export archives are kept apart from the public files and only downloaded through the API
+	privateStorage := configs.InitPrivateStorage()
+
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
 	refreshRepo := repositories.NewRefreshTokenRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
+	attributeRepo := repositories.NewAttributeRepository(db)
+	auditLogRepo := repositories.NewAuditLogRepository(db)
+	dataExportRepo := repositories.NewDataExportRepository(db)
+	invitationRepo := repositories.NewInvitationRepository(db)
+	postRepo := repositories.NewPostRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	tagRepo := repositories.NewTagRepository(db)
+	mediaRepo := repositories.NewMediaRepository(db)
+	pageRepo := repositories.NewPageRepository(db)
+	menuRepo := repositories.NewMenuRepository(db)
+	searchRepo := repositories.NewSearchRepository(db)
+	sitemapRepo := repositories.NewSitemapRepository(db)
+	commentRepo := repositories.NewCommentRepository(db)
+	translationRepo := repositories.NewTranslationRepository(db)
+	contentRepo := repositories.NewContentRepository(db)
+	redirectRepo := repositories.NewRedirectRepository(db)
+	previewLinkRepo := repositories.NewPreviewLinkRepository(db)
+	globalBlockRepo := repositories.NewGlobalBlockRepository(db)
 
 	// Initialize services
 	client := redis.NewClient(&redis.Options{
@@ -45,10 +81,134 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	bcryptService := services.NewBcryptService()
 	jwtService := services.NewJWTService()
 	authService := services.NewAuthService(userRepo, refreshTokenService, bcryptService, jwtService)
+	avatarService := services.NewAvatarService(userRepo, fileStorage, int64(utils.GetEnvAsInt("AVATAR_MAX_SIZE", 5<<20)))
+	permissionService := services.NewPermissionService(roleRepo)
+	attributeService := services.NewAttributeService(attributeRepo, userRepo)
+	auditLogService := services.NewAuditLogService(auditLogRepo)
+	impersonationTTL := time.Duration(utils.GetEnvAsInt("IMPERSONATION_TTL_MINUTES", 15)) * time.Minute
+	impersonationService := services.NewImpersonationService(userRepo, roleRepo, auditLogRepo, permissionService, jwtService, redisService, impersonationTTL)
+	exportRetention := time.Duration(utils.GetEnvAsInt("DATA_EXPORT_RETENTION_DAYS", 7)) * 24 * time.Hour
+	dataExportService := services.NewDataExportService(dataExportRepo, userService, refreshRepo, auditLogRepo, fileStorage, privateStorage, exportRetention)
+	deletionGracePeriod := time.Duration(utils.GetEnvAsInt("ACCOUNT_DELETION_GRACE_DAYS", 30)) * 24 * time.Hour
+	accountDeletionService := services.NewAccountDeletionService(userRepo, dataExportRepo, auditLogRepo, bcryptService, fileStorage, privateStorage, deletionGracePeriod)
+	invitationTTL := time.Duration(utils.GetEnvAsInt("INVITATION_TTL_HOURS", 72)) * time.Hour
+	invitationService := services.NewInvitationService(invitationRepo, userRepo, roleRepo, services.NewSMTPMailerService(), bcryptService, invitationTTL)
+	categoryService := services.NewCategoryService(categoryRepo)
+	tagService := services.NewTagService(tagRepo)
+	postService := services.NewPostService(postRepo, categoryService, tagService, permissionService)
+	postWorkflowService := services.NewPostWorkflowService(postRepo, permissionService)
+	// Authors are warned by email before their posts expire, 0 days disables the warnings
+	postExpiryNotice := time.Duration(utils.GetEnvAsInt("POST_EXPIRY_NOTICE_DAYS", 3)) * 24 * time.Hour
+	postExpiryService := services.NewPostExpiryService(postRepo, services.NewSMTPMailerService(), postExpiryNotice)
+	pageService := services.NewPageService(pageRepo)
+	redirectService := services.NewRedirectService(redirectRepo)
+	menuService := services.NewMenuService(menuRepo, pageRepo, postRepo, categoryRepo)
+	mediaService := services.NewMediaService(mediaRepo, fileStorage, int64(utils.GetEnvAsInt("MEDIA_MAX_SIZE", 20<<20)))
+	searchIndex, rebuildSearchIndex := configs.InitSearchIndex(db)
+	searchService := services.NewSearchService(searchRepo, searchIndex)
+	contentService := services.NewContentService(contentRepo, mediaRepo)
+	globalBlockService := services.NewGlobalBlockService(globalBlockRepo)
+	locales := configs.InitLocales()
+	translationService := services.NewTranslationService(translationRepo, postRepo, pageRepo, locales)
+	// Bodies are rendered on request and cached under the hash of their source, a changed media URL shows once the cache expires
+	renderService := services.NewRenderService(mediaRepo, globalBlockRepo, client, time.Duration(utils.GetEnvAsInt("RENDER_CACHE_TTL_HOURS", 24))*time.Hour)
+	// Preview tokens are signed with their own key when one is set, rotating it invalidates every preview link
+	previewKey := utils.GetEnv("PREVIEW_TOKEN_KEY", "")
+	if previewKey == "" {
+		previewKey = utils.GetEnv("JWT_KEY", "replace_your_key")
+	}
+	previewService := services.NewPreviewService(previewLinkRepo, postService, []byte(previewKey),
+		time.Duration(utils.GetEnvAsInt("PREVIEW_TTL_HOURS", 72))*time.Hour,
+		time.Duration(utils.GetEnvAsInt("PREVIEW_MAX_TTL_DAYS", 30))*24*time.Hour,
+	)
+	// Editors renew their locks while they are open, a lock left behind by a closed editor expires on its own
+	editLockService := services.NewEditLockService(userRepo, postRepo, pageRepo, contentRepo, permissionService, client, time.Duration(utils.GetEnvAsInt("EDIT_LOCK_TTL_SECONDS", 120))*time.Second)
+	feedService := services.NewFeedService(postRepo, categoryService, tagService, translationService, renderService, services.FeedConfig{
+		Title:       utils.GetEnv("FEED_TITLE", "Golang CMS"),
+		Description: utils.GetEnv("FEED_DESCRIPTION", ""),
+		SiteURL:     utils.GetEnv("FRONTEND_URL", ""),
+		Limit:       utils.GetEnvAsInt("FEED_LIMIT", 20),
+	})
+	commentService := services.NewCommentService(commentRepo, postRepo, permissionService, services.NewSMTPMailerService(), services.CommentRules{
+		TrustedAfter: utils.GetEnvAsInt("COMMENT_TRUSTED_AFTER", 3),
+		MaxLinks:     utils.GetEnvAsInt("COMMENT_MAX_LINKS", 2),
+		SpamKeywords: strings.Split(utils.GetEnv("COMMENT_SPAM_KEYWORDS", ""), ","),
+	})
+
+	// Every change of a post, page or user is queued for the search index, whatever code path made it
+	if err := searchRepo.WatchChanges(searchService.QueueChange); err != nil {
+		logger.Fatalf("Failed to watch changes for the search index: %+v", err)
+	}
+	if rebuildSearchIndex {
+		searchService.QueueReindex()
+	}
+
+	// Rendered responses of the public API are cached in Redis and dropped when the content they show changes
+	responseCache := services.NewResponseCacheService(client, time.Duration(utils.GetEnvAsInt("DELIVERY_CACHE_TTL", 300))*time.Second)
+	deliveryMaxAge := time.Duration(utils.GetEnvAsInt("DELIVERY_MAX_AGE", 60)) * time.Second
+	cachedTables := make([]string, 0, len(services.CacheTables))
+	for table := range services.CacheTables {
+		cachedTables = append(cachedTables, table)
+	}
+	if err := repositories.WatchChanges(db, "delivery", cachedTables, responseCache.InvalidateTable); err != nil {
+		logger.Fatalf("Failed to watch changes for the delivery cache: %+v", err)
+	}
+
+	// Sitemap sections are generated on request and cached until their content changes
+	sitemapService := services.NewSitemapService(sitemapRepo, client, utils.GetEnv("FRONTEND_URL", ""), time.Duration(utils.GetEnvAsInt("SITEMAP_CACHE_TTL_HOURS", 24))*time.Hour)
+	sitemapTables := make([]string, 0, len(services.SitemapTables))
+	for table := range services.SitemapTables {
+		sitemapTables = append(sitemapTables, table)
+	}
+	if err := repositories.WatchChanges(db, "sitemap", sitemapTables, sitemapService.InvalidateTable); err != nil {
+		logger.Fatalf("Failed to watch changes for the sitemap: %+v", err)
+	}
+
+	// Changes are queued in the memory of the instance that made them, so every instance writes its own queue
+	searchRunner := workers.NewRunner()
+	searchRunner.Add("search-index", time.Duration(utils.GetEnvAsInt("SEARCH_INDEX_INTERVAL_SECONDS", 5))*time.Second, searchService.ProcessPending)
+	searchRunner.Start(context.Background())
+
+	// Start background jobs, disable them on instances that should only serve requests
+	if utils.GetEnv("WORKERS_ENABLED", "true") == "true" {
+		runner := workers.NewRunner()
+		runner.Add("data-export", time.Minute, dataExportService.ProcessPending)
+		runner.Add("data-export-cleanup", time.Hour, dataExportService.CleanupExpired)
+		runner.Add("account-deletion", time.Hour, accountDeletionService.ProcessDue)
+		runner.Add("post-scheduler", time.Minute, postWorkflowService.PublishDue)
+		runner.Add("post-expiry", time.Minute, postExpiryService.ExpireDue)
+		runner.Add("post-expiry-notifier", time.Hour, postExpiryService.NotifyExpiring)
+		runner.Start(context.Background())
+	}
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	userHandler := handlers.NewUserHandler(userService, redisService, bcryptService)
+	avatarHandler := handlers.NewAvatarHandler(userService, avatarService, redisService)
+	attributeHandler := handlers.NewAttributeHandler(attributeService, userService, redisService)
+	impersonationHandler := handlers.NewImpersonationHandler(impersonationService)
+	auditLogHandler := handlers.NewAuditLogHandler(auditLogService)
+	privacyHandler := handlers.NewPrivacyHandler(dataExportService, accountDeletionService, redisService)
+	invitationHandler := handlers.NewInvitationHandler(invitationService)
+	postHandler := handlers.NewPostHandler(postService, categoryService, tagService, translationService, renderService)
+	postWorkflowHandler := handlers.NewPostWorkflowHandler(postWorkflowService)
+	postExpiryHandler := handlers.NewPostExpiryHandler(postExpiryService)
+	postRevisionHandler := handlers.NewPostRevisionHandler(postService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	mediaHandler := handlers.NewMediaHandler(mediaService)
+	pageHandler := handlers.NewPageHandler(pageService, translationService, renderService)
+	menuHandler := handlers.NewMenuHandler(menuService)
+	searchHandler := handlers.NewSearchHandler(searchService)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	translationHandler := handlers.NewTranslationHandler(translationService, locales)
+	feedHandler := handlers.NewFeedHandler(feedService)
+	sitemapHandler := handlers.NewSitemapHandler(sitemapService)
+	contentHandler := handlers.NewContentHandler(contentService)
+	redirectHandler := handlers.NewRedirectHandler(redirectService)
+	editLockHandler := handlers.NewEditLockHandler(editLockService)
+	previewHandler := handlers.NewPreviewHandler(previewService, renderService, locales)
+	globalBlockHandler := handlers.NewGlobalBlockHandler(globalBlockService)
 
 	// Add middleware for CORS and logging
 	router.Use(
@@ -60,6 +220,21 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 
 	router.GET("/healthz", handlers.HealthCheck)
 
+	// Feeds of the published posts, of all posts or of a category or tag, in the locale chosen with ?locale= or Accept-Language
+	feeds := router.Group("/feeds",
+		middlewares.LocaleMiddleware(locales),
+		middlewares.ResponseCacheMiddleware(responseCache, deliveryMaxAge, services.CacheTagPosts),
+	)
+	for _, prefix := range []string{"", "/categories/:category", "/tags/:tag"} {
+		feeds.GET(prefix+"/rss.xml", feedHandler.GetRSSFeed)
+		feeds.GET(prefix+"/atom.xml", feedHandler.GetAtomFeed)
+		feeds.GET(prefix+"/feed.json", feedHandler.GetJSONFeed)
+	}
+
+	// Sitemap index and the sitemap files it lists, e.g. /sitemaps/posts-1.xml
+	router.GET("/sitemap.xml", sitemapHandler.GetSitemapIndex)
+	router.GET("/sitemaps/:file", sitemapHandler.GetSitemap)
+
 	// Setup API routes
 	api := router.Group("/api/v1")
 	{
@@ -68,18 +243,228 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 		api.POST("/refresh-token", authHandler.RefreshToken)
 		api.POST("/forgot-password", userHandler.ForgotPassword)
 		api.POST("/reset-password", userHandler.ResetPassword)
+		api.POST("/invitations/accept", invitationHandler.AcceptInvitation)
+
+		// Published content, readable without signing in, in the locale chosen with ?locale= or Accept-Language
+		public := api.Group("/public", middlewares.LocaleMiddleware(locales))
+		public.GET("/locales", translationHandler.GetLocales)
+		// Unpublished revisions shared through a preview link, never cached so a revoked link stops working at once
+		public.GET("/previews/:token", previewHandler.GetPreview)
+		// Search is not cached, the index is updated in the background after the content changes
+		cached := func(tags ...string) gin.HandlerFunc {
+			return middlewares.ResponseCacheMiddleware(responseCache, deliveryMaxAge, tags...)
+		}
+		public.GET("/posts", cached(services.CacheTagPosts), postHandler.GetPublishedPosts)
+		public.GET("/posts/latest", cached(services.CacheTagPosts), postHandler.GetLatestPosts)
+		// Old URLs of moved posts and pages are answered with a redirect to the API URL of their new location
+		redirected := middlewares.RedirectMiddleware(redirectService,
+			middlewares.RedirectRoute{SitePrefix: "/posts/", APIPrefix: "/api/v1/public/posts/"},
+			middlewares.RedirectRoute{SitePrefix: "/categories/", APIPrefix: "/api/v1/public/posts?category="},
+			middlewares.RedirectRoute{SitePrefix: "/tags/", APIPrefix: "/api/v1/public/posts?tag="},
+			middlewares.RedirectRoute{SitePrefix: "/", APIPrefix: "/api/v1/public/pages/"},
+		)
+		public.GET("/posts/:slug", cached(services.CacheTagPosts), redirected, postHandler.GetPublishedPost)
+		public.GET("/posts/:slug/related", cached(services.CacheTagPosts), postHandler.GetRelatedPosts)
+		public.GET("/categories", cached(services.CacheTagCategories), categoryHandler.GetCategories)
+		public.GET("/categories/:slug/breadcrumbs", cached(services.CacheTagCategories), categoryHandler.GetPublicBreadcrumbs)
+		public.GET("/pages/*path", cached(services.CacheTagPages), redirected, pageHandler.GetPublishedPage)
+		public.GET("/menus/:handle", cached(services.CacheTagMenus), menuHandler.GetPublicMenu)
+		public.GET("/redirects", cached(services.CacheTagRedirects), redirectHandler.ResolveRedirect)
+		public.GET("/search", searchHandler.SearchPublished)
+		public.GET("/posts/:slug/comments", cached(services.CacheTagPosts, services.CacheTagComments), commentHandler.GetPublicComments)
+		// Guests may comment without signing in, signed in users comment under their own name
+		public.POST("/posts/:slug/comments",
+			middlewares.OptionalAuthMiddleware(),
+			middlewares.ImpersonationMiddleware(redisService),
+			commentHandler.CreateComment,
+		)
 
 		authenticated := api.Group("/")
-		authenticated.Use(middlewares.AuthMiddleware())
+		authenticated.Use(
+			middlewares.AuthMiddleware(),
+			middlewares.ImpersonationMiddleware(redisService),
+		)
 		{
-			authenticated.POST("/change-password", userHandler.ChangePassword)
+			// Sensitive actions are not available to support staff acting as another user
+			blockImpersonation := middlewares.BlockImpersonationMiddleware()
+
+			authenticated.POST("/change-password", blockImpersonation, userHandler.ChangePassword)
 			authenticated.GET("/profile", userHandler.GetProfile)
 			authenticated.PATCH("/profile", userHandler.UpdateProfile)
+			authenticated.PUT("/profile/avatar", avatarHandler.UpdateAvatar)
+			authenticated.DELETE("/profile/avatar", avatarHandler.DeleteAvatar)
+			authenticated.POST("/profile/data-export", blockImpersonation, privacyHandler.RequestDataExport)
+			authenticated.GET("/profile/data-exports/:id", blockImpersonation, privacyHandler.GetDataExport)
+			authenticated.GET("/profile/data-exports/:id/download", blockImpersonation, privacyHandler.DownloadDataExport)
+			authenticated.POST("/profile/deletion", blockImpersonation, privacyHandler.RequestDeletion)
+			authenticated.DELETE("/profile/deletion", blockImpersonation, privacyHandler.CancelDeletion)
 
+			viewUsers := middlewares.PermissionMiddleware(permissionService, constants.PermissionViewUsers)
+			authenticated.GET("/users", viewUsers, userHandler.GetUsers)
 			authenticated.POST("/users", userHandler.CreateUser)
-			authenticated.GET("/users/:id", userHandler.GetUser)
-			authenticated.PATCH("/users/:id", userHandler.UpdateUser)
-			authenticated.DELETE("/users/:id", userHandler.DeleteUser)
+			authenticated.GET("/users/:id", viewUsers, userHandler.GetUser)
+			// Records locked by another user cannot be changed, the version sent with an update rejects stale changes
+			lockedUser := middlewares.EditLockMiddleware(editLockService, services.LockResourceUsers)
+			authenticated.PATCH("/users/:id", lockedUser, userHandler.UpdateUser)
+			authenticated.DELETE("/users/:id", lockedUser, userHandler.DeleteUser)
+			authenticated.PUT("/users/:id/attributes", blockImpersonation, attributeHandler.UpdateUserAttributes)
+			authenticated.POST("/users/:id/impersonate",
+				blockImpersonation,
+				middlewares.PermissionMiddleware(permissionService, constants.PermissionImpersonateUsers),
+				impersonationHandler.StartImpersonation,
+			)
+			authenticated.DELETE("/impersonation", impersonationHandler.StopImpersonation)
+
+			// Invited users choose their own password, invitations carry the roles they receive
+			inviteUsers := middlewares.PermissionMiddleware(permissionService, constants.PermissionInviteUsers)
+			authenticated.POST("/users/invite", blockImpersonation, inviteUsers, invitationHandler.InviteUser)
+			authenticated.GET("/invitations", blockImpersonation, inviteUsers, invitationHandler.GetInvitations)
+			authenticated.POST("/invitations/:id/resend", blockImpersonation, inviteUsers, invitationHandler.ResendInvitation)
+			authenticated.DELETE("/invitations/:id", blockImpersonation, inviteUsers, invitationHandler.RevokeInvitation)
+
+			authenticated.GET("/posts", postHandler.GetPosts)
+			authenticated.POST("/posts", postHandler.CreatePost)
+			authenticated.GET("/posts/:id", postHandler.GetPost)
+			// Authors change their own posts, the posts of other authors are checked against posts.update and posts.delete by the post service
+			lockedPost := middlewares.EditLockMiddleware(editLockService, services.LockResourcePosts)
+			authenticated.PATCH("/posts/:id", lockedPost, postHandler.UpdatePost)
+			authenticated.DELETE("/posts/:id", lockedPost, postHandler.DeletePost)
+			// Permissions of the editorial workflow are checked per action by the workflow service
+			authenticated.POST("/posts/:id/transitions", postWorkflowHandler.TransitionPost)
+			authenticated.GET("/posts/:id/transitions", postWorkflowHandler.GetPostTransitions)
+			// Expired posts are archived or unpublished by a background worker, like a publish action
+			publishPosts := middlewares.PermissionMiddleware(permissionService, constants.PermissionPublishPosts)
+			authenticated.PUT("/posts/:id/expiry", publishPosts, postExpiryHandler.SetPostExpiry)
+			authenticated.DELETE("/posts/:id/expiry", publishPosts, postExpiryHandler.ClearPostExpiry)
+			authenticated.GET("/posts/:id/revisions", postRevisionHandler.GetRevisions)
+			authenticated.GET("/posts/:id/revisions/diff", postRevisionHandler.DiffRevisions)
+			authenticated.GET("/posts/:id/revisions/:number", postRevisionHandler.GetRevision)
+			authenticated.POST("/posts/:id/revisions/:number/restore", lockedPost, postRevisionHandler.RestoreRevision)
+			authenticated.GET("/posts/:id/previews", previewHandler.GetPreviewLinks)
+			authenticated.POST("/posts/:id/previews", previewHandler.CreatePreviewLink)
+			authenticated.DELETE("/posts/:id/previews/:linkId", previewHandler.RevokePreviewLink)
+			authenticated.GET("/posts/:id/translations", translationHandler.GetPostTranslations)
+			authenticated.PUT("/posts/:id/translations/:locale", translationHandler.SavePostTranslation)
+			authenticated.DELETE("/posts/:id/translations/:locale", translationHandler.DeletePostTranslation)
+			authenticated.GET("/translations/missing", translationHandler.GetMissingTranslations)
+
+			// Tags are also created when a post is saved with a new tag name
+			manageTaxonomy := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageTaxonomy)
+			authenticated.GET("/categories", categoryHandler.GetCategories)
+			authenticated.POST("/categories", manageTaxonomy, categoryHandler.CreateCategory)
+			authenticated.POST("/categories/reorder", manageTaxonomy, categoryHandler.ReorderCategories)
+			authenticated.GET("/categories/:id", categoryHandler.GetCategory)
+			authenticated.GET("/categories/:id/breadcrumbs", categoryHandler.GetBreadcrumbs)
+			authenticated.PATCH("/categories/:id", manageTaxonomy, categoryHandler.UpdateCategory)
+			authenticated.POST("/categories/:id/move", manageTaxonomy, categoryHandler.MoveCategory)
+			authenticated.DELETE("/categories/:id", manageTaxonomy, categoryHandler.DeleteCategory)
+			authenticated.GET("/tags", tagHandler.GetTags)
+			authenticated.POST("/tags", manageTaxonomy, tagHandler.CreateTag)
+			authenticated.GET("/tags/:id", tagHandler.GetTag)
+			authenticated.PATCH("/tags/:id", manageTaxonomy, tagHandler.UpdateTag)
+			authenticated.DELETE("/tags/:id", manageTaxonomy, tagHandler.DeleteTag)
+
+			managePages := middlewares.PermissionMiddleware(permissionService, constants.PermissionManagePages)
+			authenticated.GET("/pages", pageHandler.GetPages)
+			authenticated.POST("/pages", managePages, pageHandler.CreatePage)
+			authenticated.GET("/pages/:id", pageHandler.GetPage)
+			lockedPage := middlewares.EditLockMiddleware(editLockService, services.LockResourcePages)
+			authenticated.PATCH("/pages/:id", managePages, lockedPage, pageHandler.UpdatePage)
+			authenticated.POST("/pages/:id/move", managePages, lockedPage, pageHandler.MovePage)
+			authenticated.DELETE("/pages/:id", managePages, lockedPage, pageHandler.DeletePage)
+			authenticated.GET("/pages/:id/translations", translationHandler.GetPageTranslations)
+			authenticated.PUT("/pages/:id/translations/:locale", managePages, translationHandler.SavePageTranslation)
+			authenticated.DELETE("/pages/:id/translations/:locale", managePages, translationHandler.DeletePageTranslation)
+
+			// Global blocks are referenced from the bodies of posts and pages, an edit shows in all of them
+			manageBlocks := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageBlocks)
+			authenticated.GET("/global-blocks", globalBlockHandler.GetGlobalBlocks)
+			authenticated.POST("/global-blocks", manageBlocks, globalBlockHandler.CreateGlobalBlock)
+			authenticated.GET("/global-blocks/:id", globalBlockHandler.GetGlobalBlock)
+			authenticated.PATCH("/global-blocks/:id", manageBlocks, globalBlockHandler.UpdateGlobalBlock)
+			authenticated.DELETE("/global-blocks/:id", manageBlocks, globalBlockHandler.DeleteGlobalBlock)
+
+			// Redirects of changed slugs and paths are recorded automatically, admins add their own
+			manageRedirects := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageRedirects)
+			authenticated.GET("/redirects", manageRedirects, redirectHandler.GetRedirects)
+			authenticated.POST("/redirects", manageRedirects, redirectHandler.CreateRedirect)
+			authenticated.GET("/redirects/:id", manageRedirects, redirectHandler.GetRedirect)
+			authenticated.PATCH("/redirects/:id", manageRedirects, redirectHandler.UpdateRedirect)
+			authenticated.DELETE("/redirects/:id", manageRedirects, redirectHandler.DeleteRedirect)
+
+			// Menu items are saved as a whole tree, the public API resolves them to the URLs of their targets
+			manageMenus := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageMenus)
+			authenticated.GET("/menus", menuHandler.GetMenus)
+			authenticated.POST("/menus", manageMenus, menuHandler.CreateMenu)
+			authenticated.GET("/menus/:id", menuHandler.GetMenu)
+			authenticated.PATCH("/menus/:id", manageMenus, menuHandler.UpdateMenu)
+			authenticated.PUT("/menus/:id/items", manageMenus, menuHandler.ReplaceMenuItems)
+			authenticated.DELETE("/menus/:id", manageMenus, menuHandler.DeleteMenu)
+
+			authenticated.GET("/search", searchHandler.SearchContent)
+			authenticated.GET("/search/users",
+				middlewares.PermissionMiddleware(permissionService, constants.PermissionSearchUsers),
+				searchHandler.SearchUsers,
+			)
+			authenticated.POST("/search/reindex",
+				middlewares.PermissionMiddleware(permissionService, constants.PermissionManageSearch),
+				searchHandler.Reindex,
+			)
+
+			moderateComments := middlewares.PermissionMiddleware(permissionService, constants.PermissionModerateComments)
+			authenticated.GET("/comments", moderateComments, commentHandler.GetComments)
+			authenticated.POST("/comments/moderate", moderateComments, commentHandler.ModerateComments)
+			authenticated.GET("/comments/:id", moderateComments, commentHandler.GetComment)
+
+			// Every signed in user may upload to the media library, removing files is restricted
+			manageMedia := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageMedia)
+			authenticated.GET("/media", mediaHandler.GetMedia)
+			authenticated.POST("/media", mediaHandler.UploadMedia)
+			authenticated.GET("/media/:id", mediaHandler.GetMediaItem)
+			authenticated.PATCH("/media/:id", mediaHandler.UpdateMedia)
+			authenticated.DELETE("/media/:id", manageMedia, mediaHandler.DeleteMedia)
+			authenticated.GET("/media-folders", mediaHandler.GetFolders)
+			authenticated.POST("/media-folders", manageMedia, mediaHandler.CreateFolder)
+			authenticated.PATCH("/media-folders/:id", manageMedia, mediaHandler.UpdateFolder)
+			authenticated.DELETE("/media-folders/:id", manageMedia, mediaHandler.DeleteFolder)
+
+			authenticated.GET("/audit-logs",
+				middlewares.PermissionMiddleware(permissionService, constants.PermissionViewAuditLogs),
+				auditLogHandler.GetAuditLogs,
+			)
+
+			// Attribute definitions can be read by everyone to render forms, only admins may change them
+			manageAttributes := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageAttributes)
+			authenticated.GET("/attributes", attributeHandler.GetDefinitions)
+			authenticated.POST("/attributes", blockImpersonation, manageAttributes, attributeHandler.CreateDefinition)
+			authenticated.PATCH("/attributes/:id", blockImpersonation, manageAttributes, attributeHandler.UpdateDefinition)
+			authenticated.DELETE("/attributes/:id", blockImpersonation, manageAttributes, attributeHandler.DeleteDefinition)
+
+			// Content types are defined by admins at runtime, their entries are validated against the fields of the type
+			manageContentTypes := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageContentTypes)
+			authenticated.GET("/content-types", contentHandler.GetContentTypes)
+			authenticated.POST("/content-types", manageContentTypes, contentHandler.CreateContentType)
+			authenticated.GET("/content-types/:type", contentHandler.GetContentType)
+			authenticated.PATCH("/content-types/:type", manageContentTypes, contentHandler.UpdateContentType)
+			authenticated.DELETE("/content-types/:type", manageContentTypes, contentHandler.DeleteContentType)
+
+			manageContent := middlewares.PermissionMiddleware(permissionService, constants.PermissionManageContent)
+			authenticated.GET("/content/:type", contentHandler.GetEntries)
+			authenticated.POST("/content/:type", manageContent, contentHandler.CreateEntry)
+			authenticated.GET("/content/:type/:id", contentHandler.GetEntry)
+			lockedEntry := middlewares.EditLockMiddleware(editLockService, services.LockResourceContent)
+			authenticated.PATCH("/content/:type/:id", manageContent, lockedEntry, contentHandler.UpdateEntry)
+			authenticated.DELETE("/content/:type/:id", manageContent, lockedEntry, contentHandler.DeleteEntry)
+
+			// Edit locks of posts, pages, content entries and users, e.g. /locks/posts/12
+			authenticated.GET("/locks/:resource/:id", editLockHandler.GetLock)
+			authenticated.POST("/locks/:resource/:id", editLockHandler.AcquireLock)
+			authenticated.PUT("/locks/:resource/:id", editLockHandler.RenewLock)
+			authenticated.DELETE("/locks/:resource/:id", editLockHandler.ReleaseLock)
+			authenticated.DELETE("/locks/:resource/:id/force",
+				middlewares.PermissionMiddleware(permissionService, constants.PermissionManageLocks),
+				editLockHandler.ForceReleaseLock,
+			)
 		}
 	}
 
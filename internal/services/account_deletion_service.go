@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+)
+
+// erasedUserName replaces the name of erased users
+const erasedUserName = "Deleted user"
+
+type IAccountDeletionService interface {
+	RequestDeletion(userID uint, password, ipAddress string) (*models.User, error)
+	CancelDeletion(userID uint, ipAddress string) (*models.User, error)
+	ProcessDue(ctx context.Context) error
+}
+
+type AccountDeletionService struct {
+	userRepo      repositories.IUserRepository
+	exportRepo    repositories.IDataExportRepository
+	auditLogRepo  repositories.IAuditLogRepository
+	bcryptService IBcryptService
+	storage       storage.Storage
+	exportStore   storage.Storage
+	gracePeriod   time.Duration
+}
+
+// NewAccountDeletionService creates a new instance of AccountDeletionService
+// Parameters:
+//   - userRepo: User repository used to schedule and erase accounts
+//   - exportRepo: Repository of data exports whose archives are removed on erasure
+//   - auditLogRepo: Repository where requests and erasures are recorded
+//   - bcryptService: Service used to confirm the password of the user
+//   - storage: Storage backend holding the avatar files
+//   - exportStore: Private storage backend holding the data export archives
+//   - gracePeriod: Time between the request and the erasure, during which the request can be cancelled
+//
+// Returns:
+//   - *AccountDeletionService: New AccountDeletionService instance initialized with the provided dependencies
+func NewAccountDeletionService(
+	userRepo repositories.IUserRepository,
+	exportRepo repositories.IDataExportRepository,
+	auditLogRepo repositories.IAuditLogRepository,
+	bcryptService IBcryptService,
+	storage storage.Storage,
+	exportStore storage.Storage,
+	gracePeriod time.Duration,
+) *AccountDeletionService {
+	return &AccountDeletionService{
+		userRepo:      userRepo,
+		exportRepo:    exportRepo,
+		auditLogRepo:  auditLogRepo,
+		bcryptService: bcryptService,
+		storage:       storage,
+		exportStore:   exportStore,
+		gracePeriod:   gracePeriod,
+	}
+}
+
+// RequestDeletion schedules the erasure of a user's account after the grace period
+// Parameters:
+//   - userID: The ID of the user deleting their account
+//   - password: The current password of the user, required to confirm the request
+//   - ipAddress: IP address of the request, stored in the audit trail
+//
+// Returns:
+//   - *models.User: The user with the scheduled deletion time
+//   - error: NotFound if the user does not exist, InvalidPassword if the password is wrong,
+//     BadRequest if a deletion is already scheduled
+func (service *AccountDeletionService) RequestDeletion(userID uint, password, ipAddress string) (*models.User, error) {
+	user, err := service.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	if !service.bcryptService.CheckPasswordHash(password, user.Password) {
+		return nil, apperror.NewInvalidPasswordError("Password is incorrect")
+	}
+	if user.DeletionScheduledAt != nil {
+		return nil, apperror.NewBadRequestError("Account deletion is already scheduled")
+	}
+
+	scheduledAt := time.Now().Add(service.gracePeriod)
+	user.DeletionScheduledAt = &scheduledAt
+	if err := service.userRepo.Update(user); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+
+	if err := service.audit(user.ID, models.AuditActionDeletionRequest, ipAddress, map[string]any{"scheduledAt": scheduledAt}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CancelDeletion cancels a scheduled account deletion
+// Parameters:
+//   - userID: The ID of the user
+//   - ipAddress: IP address of the request, stored in the audit trail
+//
+// Returns:
+//   - *models.User: The user without a scheduled deletion
+//   - error: NotFound if the user does not exist, BadRequest if no deletion is scheduled
+func (service *AccountDeletionService) CancelDeletion(userID uint, ipAddress string) (*models.User, error) {
+	user, err := service.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	if user.DeletionScheduledAt == nil {
+		return nil, apperror.NewBadRequestError("No account deletion is scheduled")
+	}
+
+	user.DeletionScheduledAt = nil
+	if err := service.userRepo.Update(user); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+
+	if err := service.audit(user.ID, models.AuditActionDeletionCancel, ipAddress, nil); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ProcessDue erases the accounts whose grace period has ended
+// An account that cannot be erased is logged and retried on the next run
+//
+// The function:
+//  1. Deletes the avatar files and data export archives of the user
+//  2. Replaces the personal fields with placeholders and makes the password unusable
+//  3. Removes attributes, sessions and exports and soft deletes the user in one transaction
+//  4. Records the erasure in the audit trail, which keeps only the user ID
+func (service *AccountDeletionService) ProcessDue(ctx context.Context) error {
+	users, err := service.userRepo.FindDueForDeletion(time.Now(), dataExportBatchSize)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	for i := range users {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := service.erase(&users[i]); err != nil {
+			logger.Errorf("Failed to erase user %d: %v", users[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// erase removes the personal data of a single user
+func (service *AccountDeletionService) erase(user *models.User) error {
+	exports, err := service.exportRepo.FindByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey != nil {
+		for _, key := range avatarVariantKeys(*user.AvatarKey) {
+			if err := service.storage.Delete(key); err != nil {
+				return err
+			}
+		}
+	}
+	for _, export := range exports {
+		if export.FileKey == nil {
+			continue
+		}
+		if err := service.exportStore.Delete(*export.FileKey); err != nil {
+			return err
+		}
+	}
+
+	secret, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+	password, err := service.bcryptService.HashPassword(secret)
+	if err != nil {
+		return err
+	}
+
+	user.Name = erasedUserName
+	user.Email = fmt.Sprintf("deleted-%d@example.invalid", user.ID)
+	user.Password = password
+	user.Birthday = nil
+	user.Address = nil
+	user.Token = nil
+	user.ExpiredAt = nil
+	user.AvatarKey = nil
+	user.AvatarURL = nil
+	user.AvatarThumbnailURL = nil
+	user.DeletionScheduledAt = nil
+
+	if err := service.userRepo.Erase(user); err != nil {
+		return err
+	}
+
+	return service.audit(user.ID, models.AuditActionErasure, "", nil)
+}
+
+// audit records an action the user performed on their own account
+func (service *AccountDeletionService) audit(userID uint, action, ipAddress string, metadata map[string]any) error {
+	auditLog := &models.AuditLog{
+		ActorID:     userID,
+		Action:      action,
+		SubjectType: models.AuditSubjectUser,
+		SubjectID:   userID,
+		IpAddress:   ipAddress,
+		Metadata:    metadata,
+	}
+	if err := service.auditLogRepo.Create(auditLog); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type AccountDeletionServiceTestSuite struct {
+	suite.Suite
+	userRepo      *mocks.MockUserRepository
+	exportRepo    *mocks.MockDataExportRepository
+	auditLogRepo  *mocks.MockAuditLogRepository
+	bcryptService *mocks.MockBcryptService
+	storage       *mocks.MockStorage
+	exportStore   *mocks.MockStorage
+	service       *services.AccountDeletionService
+}
+
+func (s *AccountDeletionServiceTestSuite) SetupTest() {
+	logger.Init()
+	s.userRepo = new(mocks.MockUserRepository)
+	s.exportRepo = new(mocks.MockDataExportRepository)
+	s.auditLogRepo = new(mocks.MockAuditLogRepository)
+	s.bcryptService = new(mocks.MockBcryptService)
+	s.storage = new(mocks.MockStorage)
+	s.exportStore = new(mocks.MockStorage)
+	s.service = services.NewAccountDeletionService(s.userRepo, s.exportRepo, s.auditLogRepo, s.bcryptService, s.storage, s.exportStore, 30*24*time.Hour)
+}
+
+func (s *AccountDeletionServiceTestSuite) TearDownTest() {
+	s.userRepo.AssertExpectations(s.T())
+	s.exportRepo.AssertExpectations(s.T())
+	s.auditLogRepo.AssertExpectations(s.T())
+	s.bcryptService.AssertExpectations(s.T())
+	s.storage.AssertExpectations(s.T())
+	s.exportStore.AssertExpectations(s.T())
+}
+
+func (s *AccountDeletionServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *AccountDeletionServiceTestSuite) TestRequestDeletion() {
+	s.Run("Success", func() {
+		user := &models.User{ID: 1, Password: "hashed"}
+		s.userRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		s.bcryptService.On("CheckPasswordHash", "secret", "hashed").Return(true).Once()
+		s.userRepo.On("Update", user).Return(nil).Once()
+		s.auditLogRepo.On("Create", mock.MatchedBy(func(log *models.AuditLog) bool {
+			return log.ActorID == 1 && log.Action == models.AuditActionDeletionRequest && log.IpAddress == "10.0.0.1"
+		})).Return(nil).Once()
+
+		result, err := s.service.RequestDeletion(1, "secret", "10.0.0.1")
+		s.NoError(err)
+		s.Require().NotNil(result.DeletionScheduledAt)
+		s.WithinDuration(time.Now().Add(30*24*time.Hour), *result.DeletionScheduledAt, time.Minute)
+	})
+
+	s.Run("Error wrong password", func() {
+		user := &models.User{ID: 1, Password: "hashed"}
+		s.userRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		s.bcryptService.On("CheckPasswordHash", "wrong", "hashed").Return(false).Once()
+
+		_, err := s.service.RequestDeletion(1, "wrong", "10.0.0.1")
+		s.assertCode(err, apperror.ErrInvalidPassword)
+	})
+
+	s.Run("Error already scheduled", func() {
+		scheduledAt := time.Now()
+		user := &models.User{ID: 1, Password: "hashed", DeletionScheduledAt: &scheduledAt}
+		s.userRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		s.bcryptService.On("CheckPasswordHash", "secret", "hashed").Return(true).Once()
+
+		_, err := s.service.RequestDeletion(1, "secret", "10.0.0.1")
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+}
+
+func (s *AccountDeletionServiceTestSuite) TestCancelDeletion() {
+	s.Run("Success", func() {
+		scheduledAt := time.Now()
+		user := &models.User{ID: 1, DeletionScheduledAt: &scheduledAt}
+		s.userRepo.On("GetByID", uint(1)).Return(user, nil).Once()
+		s.userRepo.On("Update", user).Return(nil).Once()
+		s.auditLogRepo.On("Create", mock.MatchedBy(func(log *models.AuditLog) bool {
+			return log.Action == models.AuditActionDeletionCancel
+		})).Return(nil).Once()
+
+		result, err := s.service.CancelDeletion(1, "10.0.0.1")
+		s.NoError(err)
+		s.Nil(result.DeletionScheduledAt)
+	})
+
+	s.Run("Error nothing scheduled", func() {
+		s.userRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1}, nil).Once()
+
+		_, err := s.service.CancelDeletion(1, "10.0.0.1")
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+}
+
+func (s *AccountDeletionServiceTestSuite) TestProcessDue() {
+	s.Run("Erases due users", func() {
+		avatarKey := "avatars/1/abc/avatar.png"
+		exportKey := "exports/1/archive.zip"
+		address := "Hanoi"
+		user := models.User{ID: 1, Name: "John", Email: "john@example.com", Address: &address, AvatarKey: &avatarKey}
+
+		s.userRepo.On("FindDueForDeletion", mock.AnythingOfType("time.Time"), 10).Return([]models.User{user}, nil).Once()
+		s.exportRepo.On("FindByUserID", uint(1)).Return([]models.DataExport{{ID: 2, FileKey: &exportKey}, {ID: 3}}, nil).Once()
+		s.storage.On("Delete", "avatars/1/abc/avatar.png").Return(nil).Once()
+		s.storage.On("Delete", "avatars/1/abc/thumbnail.png").Return(nil).Once()
+		s.exportStore.On("Delete", exportKey).Return(nil).Once()
+		s.bcryptService.On("HashPassword", mock.AnythingOfType("string")).Return("unusable", nil).Once()
+		s.userRepo.On("Erase", mock.MatchedBy(func(u *models.User) bool {
+			return u.Name == "Deleted user" && u.Email == "deleted-1@example.invalid" && u.Password == "unusable" &&
+				u.Address == nil && u.AvatarKey == nil && u.DeletionScheduledAt == nil
+		})).Return(nil).Once()
+		s.auditLogRepo.On("Create", mock.MatchedBy(func(log *models.AuditLog) bool {
+			return log.Action == models.AuditActionErasure && log.SubjectID == 1
+		})).Return(nil).Once()
+
+		s.NoError(s.service.ProcessDue(context.Background()))
+	})
+
+	s.Run("Keeps the user when files cannot be deleted", func() {
+		avatarKey := "avatars/1/abc/avatar.png"
+		user := models.User{ID: 1, AvatarKey: &avatarKey}
+
+		s.userRepo.On("FindDueForDeletion", mock.AnythingOfType("time.Time"), 10).Return([]models.User{user}, nil).Once()
+		s.exportRepo.On("FindByUserID", uint(1)).Return([]models.DataExport{}, nil).Once()
+		s.storage.On("Delete", "avatars/1/abc/avatar.png").Return(errors.New("storage error")).Once()
+
+		s.NoError(s.service.ProcessDue(context.Background()))
+	})
+
+	s.Run("Error query", func() {
+		s.userRepo.On("FindDueForDeletion", mock.AnythingOfType("time.Time"), 10).Return([]models.User{}, errors.New("db error")).Once()
+
+		err := s.service.ProcessDue(context.Background())
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func TestAccountDeletionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountDeletionServiceTestSuite))
+}
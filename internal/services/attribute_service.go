@@ -0,0 +1,362 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+var attributeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,44}$`)
+
+var attributeTypes = []string{
+	models.AttributeTypeText,
+	models.AttributeTypeNumber,
+	models.AttributeTypeBoolean,
+	models.AttributeTypeDate,
+	models.AttributeTypeSelect,
+}
+
+type IAttributeService interface {
+	GetDefinitions() ([]models.AttributeDefinition, error)
+	GetDefinition(id uint) (*models.AttributeDefinition, error)
+	CreateDefinition(definition *models.AttributeDefinition) error
+	UpdateDefinition(definition *models.AttributeDefinition) error
+	DeleteDefinition(id uint) error
+	SetUserAttributes(userID uint, values map[string]any) (map[string]any, error)
+}
+
+type AttributeService struct {
+	repo     repositories.IAttributeRepository
+	userRepo repositories.IUserRepository
+}
+
+// NewAttributeService creates a new instance of AttributeService
+// Parameters:
+//   - repo: Repository of attribute definitions
+//   - userRepo: User repository used to read and write attribute values
+//
+// Returns:
+//   - *AttributeService: New AttributeService instance initialized with the provided repositories
+func NewAttributeService(repo repositories.IAttributeRepository, userRepo repositories.IUserRepository) *AttributeService {
+	return &AttributeService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// GetDefinitions retrieves all attribute definitions ordered by sort order
+func (service *AttributeService) GetDefinitions() ([]models.AttributeDefinition, error) {
+	definitions, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return definitions, nil
+}
+
+// GetDefinition retrieves an attribute definition by its ID
+func (service *AttributeService) GetDefinition(id uint) (*models.AttributeDefinition, error) {
+	definition, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return definition, nil
+}
+
+// CreateDefinition validates and stores a new attribute definition
+// Parameters:
+//   - definition: The definition to create
+//
+// Returns:
+//   - error: ValidationError if the definition is invalid or its name is taken, DBInsert error otherwise
+func (service *AttributeService) CreateDefinition(definition *models.AttributeDefinition) error {
+	if err := service.validateDefinition(definition); err != nil {
+		return err
+	}
+	if err := service.repo.Create(definition); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateDefinition validates and saves an existing attribute definition
+// Parameters:
+//   - definition: The definition to update
+//
+// Returns:
+//   - error: ValidationError if the definition is invalid or its name is taken, DBUpdate error otherwise
+func (service *AttributeService) UpdateDefinition(definition *models.AttributeDefinition) error {
+	if err := service.validateDefinition(definition); err != nil {
+		return err
+	}
+	if err := service.repo.Update(definition); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteDefinition removes an attribute definition and every value stored for it
+func (service *AttributeService) DeleteDefinition(id uint) error {
+	if err := service.repo.Delete(id); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// validateDefinition checks the name, type and rules of a definition
+func (service *AttributeService) validateDefinition(definition *models.AttributeDefinition) error {
+	var fieldErrors []apperror.FieldError
+	addError := func(field, message string) {
+		fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: message})
+	}
+
+	if !attributeNamePattern.MatchString(definition.Name) {
+		addError("name", "name must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+	}
+	if !slices.Contains(attributeTypes, definition.Type) {
+		addError("type", fmt.Sprintf("type must be one of [%s]", strings.Join(attributeTypes, " ")))
+	}
+
+	rules := definition.Rules
+	if definition.Type == models.AttributeTypeSelect && len(rules.Options) == 0 {
+		addError("rules.options", "rules.options is required for select attributes")
+	}
+	if rules.Pattern != "" {
+		if _, err := regexp.Compile(rules.Pattern); err != nil {
+			addError("rules.pattern", "rules.pattern must be a valid regular expression")
+		}
+	}
+	if rules.MinLength != nil && rules.MaxLength != nil && *rules.MinLength > *rules.MaxLength {
+		addError("rules.maxLength", "rules.maxLength must be greater than or equal to rules.minLength")
+	}
+	if rules.Min != nil && rules.Max != nil && *rules.Min > *rules.Max {
+		addError("rules.max", "rules.max must be greater than or equal to rules.min")
+	}
+
+	if len(fieldErrors) == 0 {
+		definitions, err := service.repo.GetAll()
+		if err != nil {
+			return apperror.NewDBQueryError(err.Error())
+		}
+		for _, existing := range definitions {
+			if existing.Name == definition.Name && existing.ID != definition.ID {
+				addError("name", "name is already taken")
+			}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return apperror.NewValidationError("Validation failed", fieldErrors)
+	}
+	return nil
+}
+
+// SetUserAttributes validates and stores custom attribute values of a user
+// Parameters:
+//   - userID: The ID of the user
+//   - values: Attribute values keyed by attribute name. A null value clears the attribute
+//
+// Returns:
+//   - map[string]any: All attribute values of the user after the update
+//   - error: ValidationError listing every invalid value, DBQuery or DBUpdate errors otherwise
+//
+// The function:
+//  1. Rejects unknown attribute names and values that break the definition rules
+//  2. Rejects clearing or omitting required attributes that have no stored value
+//  3. Saves the changed values and deletes the cleared ones in one transaction
+func (service *AttributeService) SetUserAttributes(userID uint, values map[string]any) (map[string]any, error) {
+	definitions, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	existing, err := service.userRepo.GetAttributes([]uint{userID})
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	byName := make(map[string]models.AttributeDefinition, len(definitions))
+	for _, definition := range definitions {
+		byName[definition.Name] = definition
+	}
+	stored := make(map[uint]bool, len(existing))
+	for _, value := range existing {
+		stored[value.AttributeDefinitionID] = true
+	}
+
+	var fieldErrors []apperror.FieldError
+	var updates []models.UserAttribute
+	var removals []uint
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		field := "attributes." + name
+		definition, ok := byName[name]
+		if !ok {
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: field + " is not a defined attribute"})
+			continue
+		}
+
+		raw := values[name]
+		if raw == nil {
+			if definition.Required {
+				fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: field + " is required"})
+				continue
+			}
+			removals = append(removals, definition.ID)
+			continue
+		}
+
+		value, message := CanonicalAttributeValue(definition, raw)
+		if message != "" {
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: field + " " + message})
+			continue
+		}
+		updates = append(updates, models.UserAttribute{UserID: userID, AttributeDefinitionID: definition.ID, Value: value})
+	}
+
+	for _, definition := range definitions {
+		if _, provided := values[definition.Name]; definition.Required && !provided && !stored[definition.ID] {
+			field := "attributes." + definition.Name
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: field + " is required"})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, apperror.NewValidationError("Validation failed", fieldErrors)
+	}
+
+	if err := service.userRepo.SaveAttributes(userID, updates, removals); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+
+	saved, err := service.userRepo.GetAttributes([]uint{userID})
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return AttributeValueMap(saved), nil
+}
+
+// CanonicalAttributeValue validates a raw value against a definition and converts it to its stored string form
+// Numbers are stored without trailing zeros, booleans as "true"/"false" and dates as YYYY-MM-DD.
+// String input is accepted for every type so query parameters can be normalized the same way.
+// Parameters:
+//   - definition: The definition the value belongs to
+//   - raw: The decoded JSON value (string, float64, bool) or a string
+//
+// Returns:
+//   - string: The canonical value
+//   - string: A validation message such as "must be a number", empty when the value is valid
+func CanonicalAttributeValue(definition models.AttributeDefinition, raw any) (string, string) {
+	rules := definition.Rules
+
+	switch definition.Type {
+	case models.AttributeTypeNumber:
+		var number float64
+		switch v := raw.(type) {
+		case float64:
+			number = v
+		case int:
+			number = float64(v)
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return "", "must be a number"
+			}
+			number = parsed
+		default:
+			return "", "must be a number"
+		}
+		if rules.Min != nil && number < *rules.Min {
+			return "", fmt.Sprintf("must be greater than or equal to %s", strconv.FormatFloat(*rules.Min, 'f', -1, 64))
+		}
+		if rules.Max != nil && number > *rules.Max {
+			return "", fmt.Sprintf("must be less than or equal to %s", strconv.FormatFloat(*rules.Max, 'f', -1, 64))
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), ""
+
+	case models.AttributeTypeBoolean:
+		switch v := raw.(type) {
+		case bool:
+			return strconv.FormatBool(v), ""
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return "", "must be a boolean value"
+			}
+			return strconv.FormatBool(parsed), ""
+		default:
+			return "", "must be a boolean value"
+		}
+	}
+
+	str, ok := raw.(string)
+	if !ok {
+		return "", "must be a string"
+	}
+
+	switch definition.Type {
+	case models.AttributeTypeDate:
+		parsed, err := time.Parse("2006-01-02", str)
+		if err != nil {
+			return "", "must be a valid date (YYYY-MM-DD)"
+		}
+		return parsed.Format("2006-01-02"), ""
+
+	case models.AttributeTypeSelect:
+		if !slices.Contains(rules.Options, str) {
+			return "", fmt.Sprintf("must be one of [%s]", strings.Join(rules.Options, " "))
+		}
+		return str, ""
+
+	default:
+		length := utf8.RuneCountInString(str)
+		if rules.MinLength != nil && length < *rules.MinLength {
+			return "", fmt.Sprintf("must be at least %d characters long", *rules.MinLength)
+		}
+		if rules.MaxLength != nil && length > *rules.MaxLength {
+			return "", fmt.Sprintf("must be at most %d characters long", *rules.MaxLength)
+		}
+		if rules.Pattern != "" {
+			pattern, err := regexp.Compile(rules.Pattern)
+			if err != nil || !pattern.MatchString(str) {
+				return "", "has an invalid format"
+			}
+		}
+		return str, ""
+	}
+}
+
+// AttributeValueMap converts stored attribute values into a map keyed by attribute name with typed values
+// Numbers become float64 and booleans bool, every other type stays a string.
+// The Definition relation of every value must be loaded.
+func AttributeValueMap(values []models.UserAttribute) map[string]any {
+	result := make(map[string]any, len(values))
+	for _, value := range values {
+		switch value.Definition.Type {
+		case models.AttributeTypeNumber:
+			if number, err := strconv.ParseFloat(value.Value, 64); err == nil {
+				result[value.Definition.Name] = number
+				continue
+			}
+		case models.AttributeTypeBoolean:
+			if boolean, err := strconv.ParseBool(value.Value); err == nil {
+				result[value.Definition.Name] = boolean
+				continue
+			}
+		}
+		result[value.Definition.Name] = value.Value
+	}
+	return result
+}
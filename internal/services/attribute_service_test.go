@@ -0,0 +1,227 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type AttributeServiceTestSuite struct {
+	suite.Suite
+	repo     *mocks.MockAttributeRepository
+	userRepo *mocks.MockUserRepository
+	service  *services.AttributeService
+}
+
+func (s *AttributeServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockAttributeRepository)
+	s.userRepo = new(mocks.MockUserRepository)
+	s.service = services.NewAttributeService(s.repo, s.userRepo)
+}
+
+func (s *AttributeServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.userRepo.AssertExpectations(s.T())
+}
+
+func (s *AttributeServiceTestSuite) definitions() []models.AttributeDefinition {
+	maxLength := 5
+	minFloor, maxFloor := 1.0, 30.0
+	return []models.AttributeDefinition{
+		{ID: 1, Name: "department", Type: models.AttributeTypeSelect, Required: true, Rules: models.AttributeRules{Options: []string{"Sales", "IT"}}},
+		{ID: 2, Name: "employee_id", Type: models.AttributeTypeText, Rules: models.AttributeRules{MaxLength: &maxLength, Pattern: `^E\d+$`}},
+		{ID: 3, Name: "floor", Type: models.AttributeTypeNumber, Rules: models.AttributeRules{Min: &minFloor, Max: &maxFloor}},
+		{ID: 4, Name: "remote", Type: models.AttributeTypeBoolean},
+		{ID: 5, Name: "joined_on", Type: models.AttributeTypeDate},
+	}
+}
+
+func (s *AttributeServiceTestSuite) assertFieldErrors(err error, fields ...string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	var actual []string
+	for _, field := range validationErr.Fields {
+		actual = append(actual, field.Field)
+	}
+	s.ElementsMatch(fields, actual)
+}
+
+func (s *AttributeServiceTestSuite) TestCreateDefinition() {
+	s.Run("Success", func() {
+		definition := &models.AttributeDefinition{Name: "phone", Label: "Phone", Type: models.AttributeTypeText, Rules: models.AttributeRules{Pattern: `^\+?\d+$`}}
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		s.repo.On("Create", definition).Return(nil).Once()
+
+		s.NoError(s.service.CreateDefinition(definition))
+	})
+
+	s.Run("Error invalid definition", func() {
+		minLength, maxLength := 10, 5
+		err := s.service.CreateDefinition(&models.AttributeDefinition{
+			Name:  "Bad Name",
+			Type:  models.AttributeTypeText,
+			Rules: models.AttributeRules{Pattern: "(", MinLength: &minLength, MaxLength: &maxLength},
+		})
+		s.assertFieldErrors(err, "name", "rules.pattern", "rules.maxLength")
+	})
+
+	s.Run("Error select without options", func() {
+		err := s.service.CreateDefinition(&models.AttributeDefinition{Name: "team", Type: models.AttributeTypeSelect})
+		s.assertFieldErrors(err, "rules.options")
+	})
+
+	s.Run("Error duplicate name", func() {
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		err := s.service.CreateDefinition(&models.AttributeDefinition{Name: "department", Type: models.AttributeTypeText})
+		s.assertFieldErrors(err, "name")
+	})
+
+	s.Run("Error insert", func() {
+		definition := &models.AttributeDefinition{Name: "phone", Type: models.AttributeTypeText}
+		s.repo.On("GetAll").Return([]models.AttributeDefinition{}, nil).Once()
+		s.repo.On("Create", definition).Return(errors.New("db error")).Once()
+
+		appErr, ok := apperror.ToAppError(s.service.CreateDefinition(definition))
+		s.Require().True(ok)
+		s.Equal(apperror.ErrDBInsert, appErr.Code)
+	})
+}
+
+func (s *AttributeServiceTestSuite) TestUpdateDefinition() {
+	s.Run("Success keeps own name", func() {
+		definition := s.definitions()[0]
+		definition.Label = "Team"
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		s.repo.On("Update", &definition).Return(nil).Once()
+
+		s.NoError(s.service.UpdateDefinition(&definition))
+	})
+
+	s.Run("Error update", func() {
+		definition := s.definitions()[1]
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		s.repo.On("Update", &definition).Return(errors.New("db error")).Once()
+
+		appErr, ok := apperror.ToAppError(s.service.UpdateDefinition(&definition))
+		s.Require().True(ok)
+		s.Equal(apperror.ErrDBUpdate, appErr.Code)
+	})
+}
+
+func (s *AttributeServiceTestSuite) TestGetAndDeleteDefinition() {
+	s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+	definitions, err := s.service.GetDefinitions()
+	s.NoError(err)
+	s.Len(definitions, 5)
+
+	s.repo.On("GetByID", uint(9)).Return(&models.AttributeDefinition{}, errors.New("record not found")).Once()
+	_, err = s.service.GetDefinition(9)
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok)
+	s.Equal(apperror.ErrNotFound, appErr.Code)
+
+	s.repo.On("Delete", uint(1)).Return(nil).Once()
+	s.NoError(s.service.DeleteDefinition(1))
+}
+
+func (s *AttributeServiceTestSuite) TestSetUserAttributes() {
+	s.Run("Success", func() {
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		s.userRepo.On("GetAttributes", []uint{1}).Return([]models.UserAttribute{}, nil).Once()
+		s.userRepo.On("SaveAttributes", uint(1), mock.Anything, []uint{4}).Run(func(args mock.Arguments) {
+			values := args.Get(1).([]models.UserAttribute)
+			stored := map[uint]string{}
+			for _, value := range values {
+				stored[value.AttributeDefinitionID] = value.Value
+			}
+			s.Equal(map[uint]string{1: "Sales", 2: "E12", 3: "2.5", 5: "2024-01-31"}, stored)
+		}).Return(nil).Once()
+		s.userRepo.On("GetAttributes", []uint{1}).Return([]models.UserAttribute{
+			{UserID: 1, Value: "Sales", Definition: s.definitions()[0]},
+			{UserID: 1, Value: "2.5", Definition: s.definitions()[2]},
+		}, nil).Once()
+
+		attributes, err := s.service.SetUserAttributes(1, map[string]any{
+			"department":  "Sales",
+			"employee_id": "E12",
+			"floor":       2.50,
+			"remote":      nil,
+			"joined_on":   "2024-01-31",
+		})
+		s.NoError(err)
+		s.Equal(map[string]any{"department": "Sales", "floor": 2.5}, attributes)
+	})
+
+	s.Run("Error invalid values", func() {
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		s.userRepo.On("GetAttributes", []uint{1}).Return([]models.UserAttribute{}, nil).Once()
+
+		_, err := s.service.SetUserAttributes(1, map[string]any{
+			"employee_id": "X123456",
+			"floor":       "high",
+			"remote":      "maybe",
+			"joined_on":   "31/01/2024",
+			"unknown":     "value",
+		})
+		// department is required and has no stored value
+		s.assertFieldErrors(err,
+			"attributes.employee_id", "attributes.floor", "attributes.remote",
+			"attributes.joined_on", "attributes.unknown", "attributes.department")
+	})
+
+	s.Run("Error clearing required attribute", func() {
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		s.userRepo.On("GetAttributes", []uint{1}).Return([]models.UserAttribute{{UserID: 1, AttributeDefinitionID: 1, Value: "IT"}}, nil).Once()
+
+		_, err := s.service.SetUserAttributes(1, map[string]any{"department": nil})
+		s.assertFieldErrors(err, "attributes.department")
+	})
+
+	s.Run("Error save", func() {
+		s.repo.On("GetAll").Return(s.definitions(), nil).Once()
+		s.userRepo.On("GetAttributes", []uint{1}).Return([]models.UserAttribute{{UserID: 1, AttributeDefinitionID: 1, Value: "IT"}}, nil).Once()
+		s.userRepo.On("SaveAttributes", uint(1), mock.Anything, []uint(nil)).Return(errors.New("db error")).Once()
+
+		_, err := s.service.SetUserAttributes(1, map[string]any{"remote": true})
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrDBUpdate, appErr.Code)
+	})
+}
+
+func (s *AttributeServiceTestSuite) TestCanonicalAttributeValue() {
+	minLength := 2
+	tests := []struct {
+		name       string
+		definition models.AttributeDefinition
+		raw        any
+		expected   string
+		valid      bool
+	}{
+		{"number from string", models.AttributeDefinition{Type: models.AttributeTypeNumber}, " 10.0 ", "10", true},
+		{"number from int", models.AttributeDefinition{Type: models.AttributeTypeNumber}, 7, "7", true},
+		{"boolean from string", models.AttributeDefinition{Type: models.AttributeTypeBoolean}, "1", "true", true},
+		{"boolean wrong type", models.AttributeDefinition{Type: models.AttributeTypeBoolean}, 1.0, "", false},
+		{"text wrong type", models.AttributeDefinition{Type: models.AttributeTypeText}, 1.0, "", false},
+		{"text too short", models.AttributeDefinition{Type: models.AttributeTypeText, Rules: models.AttributeRules{MinLength: &minLength}}, "á", "", false},
+		{"select invalid", models.AttributeDefinition{Type: models.AttributeTypeSelect, Rules: models.AttributeRules{Options: []string{"A"}}}, "B", "", false},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			value, message := services.CanonicalAttributeValue(tt.definition, tt.raw)
+			s.Equal(tt.expected, value)
+			s.Equal(tt.valid, message == "")
+		})
+	}
+}
+
+func TestAttributeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AttributeServiceTestSuite))
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IAuditLogService interface {
+	PaginateAuditLogs(page, limit int, filter repositories.AuditLogFilter) (*utils.Pagination, error)
+}
+
+type AuditLogService struct {
+	repo repositories.IAuditLogRepository
+}
+
+// NewAuditLogService creates a new instance of AuditLogService
+// Parameters:
+//   - repo: Repository of audit log entries
+//
+// Returns:
+//   - *AuditLogService: New AuditLogService instance initialized with the provided repository
+func NewAuditLogService(repo repositories.IAuditLogRepository) *AuditLogService {
+	return &AuditLogService{
+		repo: repo,
+	}
+}
+
+// PaginateAuditLogs retrieves a page of audit log entries, newest first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of entries per page
+//   - filter: Optional criteria such as the actor or the action
+//
+// Returns:
+//   - *utils.Pagination: The page of audit logs
+//   - error: DBQuery error if the entries cannot be loaded
+func (service *AuditLogService) PaginateAuditLogs(page, limit int, filter repositories.AuditLogFilter) (*utils.Pagination, error) {
+	pagination, err := service.repo.Paginate(page, limit, filter)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
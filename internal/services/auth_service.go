@@ -2,6 +2,7 @@ package services
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
 	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
 	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
 )
@@ -59,6 +60,11 @@ func (service *AuthService) Login(email, password string, ctx *gin.Context) (*Lo
 		return nil, apperror.NewInvalidPasswordError("Invalid credentials")
 	}
 
+	// Invited users sign in once they have accepted their invitation
+	if user.Status == models.UserStatusInvited {
+		return nil, apperror.NewUnauthorizedError("Invitation has not been accepted")
+	}
+
 	// Generate access token
 	accessToken, err := service.jwtService.GenerateToken(user.ID)
 	if err != nil {
@@ -132,6 +132,30 @@ func (s *AuthServiceTestSuite) TestLogin_InvalidPassword() {
 
 }
 
+func (s *AuthServiceTestSuite) TestLogin_InvitationNotAccepted() {
+	email := "invited@example.com"
+	password := "password123"
+	user := &models.User{
+		ID:       1,
+		Email:    email,
+		Password: "hashed_password",
+		Status:   models.UserStatusInvited,
+	}
+
+	s.repo.On("FindByField", "email", email).Return(user, nil)
+	s.bcryptService.On("CheckPasswordHash", password, user.Password).Return(true).Once()
+
+	ginCtx, _ := gin.CreateTestContext(nil)
+
+	resp, err := s.service.Login(email, password, ginCtx)
+	assert.Nil(s.T(), resp)
+	appError, ok := err.(*apperror.AppError)
+	s.Require().True(ok, "Expected AppError")
+	assert.Equal(s.T(), apperror.ErrUnauthorized, appError.Code)
+
+	s.repo.AssertExpectations(s.T())
+}
+
 func (s *AuthServiceTestSuite) TestLogin_CreateTokenError() {
 	email := "test@example.com"
 	password := "password123"
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"net/http"
+	"path"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/imaging"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+)
+
+const (
+	AvatarSize          = 512  // Width and height of the avatar in pixels
+	AvatarThumbnailSize = 128  // Width and height of the avatar thumbnail in pixels
+	avatarMaxDimension  = 8000 // Largest accepted source width or height, guards against decompression bombs
+)
+
+// allowedAvatarTypes maps accepted upload content types to the format the variants are encoded in
+var allowedAvatarTypes = map[string]string{
+	"image/jpeg": imaging.FormatJPEG,
+	"image/png":  imaging.FormatPNG,
+	"image/gif":  imaging.FormatPNG,
+}
+
+type IAvatarService interface {
+	UploadAvatar(user *models.User, data []byte) error
+	DeleteAvatar(user *models.User) error
+	MaxUploadSize() int64
+}
+
+type AvatarService struct {
+	repo    repositories.IUserRepository
+	storage storage.Storage
+	maxSize int64
+}
+
+// NewAvatarService creates a new instance of AvatarService
+// Parameters:
+//   - repo: User repository used to persist the avatar URLs
+//   - storage: Storage backend the avatar images are written to
+//   - maxSize: Maximum accepted upload size in bytes
+//
+// Returns:
+//   - *AvatarService: New AvatarService instance initialized with the provided dependencies
+func NewAvatarService(repo repositories.IUserRepository, storage storage.Storage, maxSize int64) *AvatarService {
+	return &AvatarService{
+		repo:    repo,
+		storage: storage,
+		maxSize: maxSize,
+	}
+}
+
+// MaxUploadSize returns the largest accepted avatar upload in bytes, checked before the upload is read
+func (service *AvatarService) MaxUploadSize() int64 {
+	return service.maxSize
+}
+
+type avatarVariant struct {
+	name string
+	size int
+}
+
+var avatarVariants = []avatarVariant{
+	{name: "avatar", size: AvatarSize},
+	{name: "thumbnail", size: AvatarThumbnailSize},
+}
+
+// avatarVariantKeys returns the storage keys of every variant belonging to the avatar stored under key
+func avatarVariantKeys(key string) []string {
+	dir, ext := path.Dir(key), path.Ext(key)
+	keys := make([]string, 0, len(avatarVariants))
+	for _, variant := range avatarVariants {
+		keys = append(keys, path.Join(dir, variant.name+ext))
+	}
+	return keys
+}
+
+// UploadAvatar validates an uploaded image, stores a square avatar and thumbnail and saves their URLs on the user
+// Parameters:
+//   - user: The user whose avatar is replaced
+//   - data: Raw bytes of the uploaded image
+//
+// Returns:
+//   - error: FileTooLarge or UnsupportedFileType for invalid uploads, FileStorage or DBUpdate errors otherwise
+//
+// The function:
+//  1. Checks the upload size and sniffs the content type from the file content
+//  2. Decodes the image and rejects images with oversized dimensions
+//  3. Generates and stores the avatar and thumbnail variants
+//  4. Updates the user record and removes the previous avatar files
+func (service *AvatarService) UploadAvatar(user *models.User, data []byte) error {
+	if int64(len(data)) > service.maxSize {
+		return apperror.NewFileTooLargeError(fmt.Sprintf("Avatar must not be larger than %d bytes", service.maxSize))
+	}
+
+	contentType := http.DetectContentType(data)
+	format, ok := allowedAvatarTypes[contentType]
+	if !ok {
+		return apperror.NewUnsupportedFileTypeError("Avatar must be a JPEG, PNG or GIF image")
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return apperror.NewUnsupportedFileTypeError("Avatar is not a valid image")
+	}
+	if config.Width > avatarMaxDimension || config.Height > avatarMaxDimension {
+		return apperror.NewFileTooLargeError(fmt.Sprintf("Avatar dimensions must not exceed %dx%d pixels", avatarMaxDimension, avatarMaxDimension))
+	}
+
+	img, _, err := imaging.Decode(bytes.NewReader(data))
+	if err != nil {
+		return apperror.NewUnsupportedFileTypeError("Avatar is not a valid image")
+	}
+
+	ext := ".png"
+	outputType := "image/png"
+	if format == imaging.FormatJPEG {
+		ext = ".jpg"
+		outputType = "image/jpeg"
+	}
+
+	folder := fmt.Sprintf("avatars/%d/%s", user.ID, utils.GenerateRandomString(16))
+	keys := make([]string, 0, len(avatarVariants))
+	urls := make([]string, 0, len(avatarVariants))
+	for _, variant := range avatarVariants {
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, imaging.Fill(img, variant.size, variant.size), format); err != nil {
+			service.removeFiles(keys)
+			return apperror.NewInternalError(fmt.Sprintf("Failed to encode avatar: %v", err))
+		}
+
+		key := path.Join(folder, variant.name+ext)
+		if err := service.storage.Put(key, &buf, outputType); err != nil {
+			service.removeFiles(keys)
+			return apperror.NewFileStorageError(fmt.Sprintf("Failed to store avatar: %v", err))
+		}
+		keys = append(keys, key)
+		urls = append(urls, service.storage.URL(key))
+	}
+
+	previous := *user
+
+	user.AvatarKey = &keys[0]
+	user.AvatarURL = &urls[0]
+	user.AvatarThumbnailURL = &urls[1]
+
+	if err := service.repo.Update(user); err != nil {
+		service.removeFiles(keys)
+		user.AvatarKey = previous.AvatarKey
+		user.AvatarURL = previous.AvatarURL
+		user.AvatarThumbnailURL = previous.AvatarThumbnailURL
+		return apperror.NewDBUpdateError(err.Error())
+	}
+
+	if previous.AvatarKey != nil {
+		service.removeFiles(avatarVariantKeys(*previous.AvatarKey))
+	}
+	return nil
+}
+
+// DeleteAvatar removes the user's avatar files and clears the avatar URLs
+// Parameters:
+//   - user: The user whose avatar is removed
+//
+// Returns:
+//   - error: NotFound if the user has no avatar, DBUpdate error if the user cannot be saved
+func (service *AvatarService) DeleteAvatar(user *models.User) error {
+	if user.AvatarKey == nil {
+		return apperror.NewNotFoundError("User has no avatar")
+	}
+
+	previous := *user
+	user.AvatarKey = nil
+	user.AvatarURL = nil
+	user.AvatarThumbnailURL = nil
+
+	if err := service.repo.Update(user); err != nil {
+		user.AvatarKey = previous.AvatarKey
+		user.AvatarURL = previous.AvatarURL
+		user.AvatarThumbnailURL = previous.AvatarThumbnailURL
+		return apperror.NewDBUpdateError(err.Error())
+	}
+
+	service.removeFiles(avatarVariantKeys(*previous.AvatarKey))
+	return nil
+}
+
+// removeFiles deletes stored files on a best effort basis, failures are only logged
+func (service *AvatarService) removeFiles(keys []string) {
+	for _, key := range keys {
+		if err := service.storage.Delete(key); err != nil {
+			logger.Warnf("Failed to delete avatar file %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package services_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func encodeTestImage(format string, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: 200, G: uint8(y), B: uint8(x), A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if format == "png" {
+		_ = png.Encode(&buf, img)
+	} else {
+		_ = jpeg.Encode(&buf, img, nil)
+	}
+	return buf.Bytes()
+}
+
+type AvatarServiceTestSuite struct {
+	suite.Suite
+	root    string
+	repo    *mocks.MockUserRepository
+	storage *storage.LocalStorage
+	service *services.AvatarService
+}
+
+func (s *AvatarServiceTestSuite) SetupTest() {
+	s.root = s.T().TempDir()
+	s.repo = new(mocks.MockUserRepository)
+	s.storage = storage.NewLocalStorage(s.root, "http://localhost/storage")
+	s.service = services.NewAvatarService(s.repo, s.storage, 1<<20)
+}
+
+func (s *AvatarServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *AvatarServiceTestSuite) fileExists(key string) bool {
+	_, err := os.Stat(filepath.Join(s.root, filepath.FromSlash(key)))
+	return err == nil
+}
+
+func (s *AvatarServiceTestSuite) TestUploadAvatar() {
+	s.Run("Success JPEG", func() {
+		user := &models.User{ID: 1}
+		s.repo.On("Update", user).Return(nil).Once()
+
+		err := s.service.UploadAvatar(user, encodeTestImage("jpeg", 800, 600))
+		s.NoError(err)
+		s.Require().NotNil(user.AvatarKey)
+		s.True(strings.HasPrefix(*user.AvatarKey, "avatars/1/"))
+		s.True(strings.HasSuffix(*user.AvatarKey, "/avatar.jpg"))
+		s.Equal("http://localhost/storage/"+*user.AvatarKey, *user.AvatarURL)
+		s.True(strings.HasSuffix(*user.AvatarThumbnailURL, "/thumbnail.jpg"))
+
+		// Both variants are stored with the expected dimensions
+		reader, err := s.storage.Get(*user.AvatarKey)
+		s.Require().NoError(err)
+		config, format, err := image.DecodeConfig(reader)
+		_ = reader.Close()
+		s.NoError(err)
+		s.Equal("jpeg", format)
+		s.Equal(services.AvatarSize, config.Width)
+		s.Equal(services.AvatarSize, config.Height)
+
+		thumbnailKey := strings.TrimSuffix(*user.AvatarKey, "avatar.jpg") + "thumbnail.jpg"
+		reader, err = s.storage.Get(thumbnailKey)
+		s.Require().NoError(err)
+		config, _, err = image.DecodeConfig(reader)
+		_ = reader.Close()
+		s.NoError(err)
+		s.Equal(services.AvatarThumbnailSize, config.Width)
+	})
+
+	s.Run("Success replaces previous avatar", func() {
+		user := &models.User{ID: 2}
+		s.repo.On("Update", user).Return(nil).Twice()
+
+		s.Require().NoError(s.service.UploadAvatar(user, encodeTestImage("jpeg", 100, 100)))
+		previousKey := *user.AvatarKey
+		s.True(s.fileExists(previousKey))
+
+		s.Require().NoError(s.service.UploadAvatar(user, encodeTestImage("png", 100, 100)))
+		s.True(strings.HasSuffix(*user.AvatarKey, "/avatar.png"))
+		s.True(s.fileExists(*user.AvatarKey))
+		s.False(s.fileExists(previousKey), "previous avatar should be removed")
+	})
+
+	s.Run("Error file too large", func() {
+		service := services.NewAvatarService(s.repo, s.storage, 10)
+		err := service.UploadAvatar(&models.User{ID: 1}, encodeTestImage("png", 10, 10))
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrFileTooLarge, appErr.Code)
+	})
+
+	s.Run("Error unsupported type", func() {
+		err := s.service.UploadAvatar(&models.User{ID: 1}, []byte("%PDF-1.4 not an image"))
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrUnsupportedFileType, appErr.Code)
+	})
+
+	s.Run("Error corrupted image", func() {
+		data := encodeTestImage("png", 10, 10)[:40]
+		err := s.service.UploadAvatar(&models.User{ID: 1}, data)
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrUnsupportedFileType, appErr.Code)
+	})
+
+	s.Run("Error storage", func() {
+		failing := new(mocks.MockStorage)
+		failing.On("Put", mock.Anything, mock.Anything, "image/png").Return(errors.New("disk full")).Once()
+		service := services.NewAvatarService(s.repo, failing, 1<<20)
+
+		user := &models.User{ID: 1}
+		err := service.UploadAvatar(user, encodeTestImage("png", 10, 10))
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrFileStorage, appErr.Code)
+		s.Nil(user.AvatarKey)
+		failing.AssertExpectations(s.T())
+	})
+
+	s.Run("Error update user", func() {
+		user := &models.User{ID: 3}
+		s.repo.On("Update", user).Return(errors.New("db error")).Once()
+
+		err := s.service.UploadAvatar(user, encodeTestImage("jpeg", 50, 50))
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrDBUpdate, appErr.Code)
+		s.Nil(user.AvatarKey)
+		s.Nil(user.AvatarURL)
+
+		// Uploaded files are cleaned up again
+		entries, _ := os.ReadDir(filepath.Join(s.root, "avatars", "3"))
+		for _, entry := range entries {
+			files, _ := os.ReadDir(filepath.Join(s.root, "avatars", "3", entry.Name()))
+			s.Empty(files)
+		}
+	})
+}
+
+func (s *AvatarServiceTestSuite) TestDeleteAvatar() {
+	s.Run("Success", func() {
+		user := &models.User{ID: 4}
+		s.repo.On("Update", user).Return(nil).Twice()
+		s.Require().NoError(s.service.UploadAvatar(user, encodeTestImage("jpeg", 20, 20)))
+		key := *user.AvatarKey
+
+		s.NoError(s.service.DeleteAvatar(user))
+		s.Nil(user.AvatarKey)
+		s.Nil(user.AvatarURL)
+		s.Nil(user.AvatarThumbnailURL)
+		s.False(s.fileExists(key))
+	})
+
+	s.Run("Error no avatar", func() {
+		err := s.service.DeleteAvatar(&models.User{ID: 5})
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrNotFound, appErr.Code)
+	})
+
+	s.Run("Error update user", func() {
+		key := "avatars/6/abc/avatar.jpg"
+		user := &models.User{ID: 6, AvatarKey: &key}
+		s.repo.On("Update", user).Return(errors.New("db error")).Once()
+
+		err := s.service.DeleteAvatar(user)
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(apperror.ErrDBUpdate, appErr.Code)
+		s.Equal(&key, user.AvatarKey)
+	})
+}
+
+func TestAvatarServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AvatarServiceTestSuite))
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"slices"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type ICategoryService interface {
+	GetTree() ([]models.Category, error)
+	GetCategory(id uint) (*models.Category, error)
+	GetCategoryBySlug(slug string) (*models.Category, error)
+	GetBreadcrumbs(id uint) ([]models.Category, error)
+	GetSubtreeIDs(id uint) ([]uint, error)
+	CreateCategory(category *models.Category) error
+	UpdateCategory(category *models.Category) error
+	MoveCategory(id uint, parentID *uint, position *int) (*models.Category, error)
+	ReorderCategories(parentID *uint, orderedIDs []uint) error
+	DeleteCategory(id uint) error
+}
+
+type CategoryService struct {
+	repo repositories.ICategoryRepository
+}
+
+// NewCategoryService creates a new instance of CategoryService
+// Parameters:
+//   - repo: Repository of categories
+//
+// Returns:
+//   - *CategoryService: New CategoryService instance initialized with the provided repository
+func NewCategoryService(repo repositories.ICategoryRepository) *CategoryService {
+	return &CategoryService{
+		repo: repo,
+	}
+}
+
+// GetTree retrieves every category nested under its parent
+// Returns:
+//   - []models.Category: The root categories with their children, ordered by position
+//   - error: DBQuery error if the categories cannot be loaded
+func (service *CategoryService) GetTree() ([]models.Category, error) {
+	categories, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	children := make(map[uint][]models.Category)
+	roots := []models.Category{}
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+		} else {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		}
+	}
+
+	var attach func(nodes []models.Category) []models.Category
+	attach = func(nodes []models.Category) []models.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots), nil
+}
+
+// GetCategory retrieves a category by its ID
+func (service *CategoryService) GetCategory(id uint) (*models.Category, error) {
+	category, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return category, nil
+}
+
+// GetCategoryBySlug retrieves a category by its slug
+func (service *CategoryService) GetCategoryBySlug(slug string) (*models.Category, error) {
+	category, err := service.repo.FindBySlug(slug)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return category, nil
+}
+
+// GetBreadcrumbs retrieves the path from the root of the tree down to a category
+// Parameters:
+//   - id: The ID of the category
+//
+// Returns:
+//   - []models.Category: The ancestors of the category, root first, followed by the category itself
+//   - error: NotFound if the category does not exist, DBQuery error otherwise
+func (service *CategoryService) GetBreadcrumbs(id uint) ([]models.Category, error) {
+	categories, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	byID := make(map[uint]models.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	category, ok := byID[id]
+	if !ok {
+		return nil, apperror.NewNotFoundError("Category not found")
+	}
+
+	// The length guard protects against a corrupted tree looping forever
+	breadcrumbs := []models.Category{category}
+	for category.ParentID != nil && len(breadcrumbs) <= len(categories) {
+		category, ok = byID[*category.ParentID]
+		if !ok {
+			break
+		}
+		breadcrumbs = append(breadcrumbs, category)
+	}
+	slices.Reverse(breadcrumbs)
+	return breadcrumbs, nil
+}
+
+// GetSubtreeIDs retrieves the ID of a category and of all its descendants
+// Parameters:
+//   - id: The ID of the category at the top of the subtree
+//
+// Returns:
+//   - []uint: The IDs of the subtree, the category itself first
+//   - error: NotFound if the category does not exist, DBQuery error otherwise
+func (service *CategoryService) GetSubtreeIDs(id uint) ([]uint, error) {
+	categories, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	if !slices.ContainsFunc(categories, func(category models.Category) bool { return category.ID == id }) {
+		return nil, apperror.NewNotFoundError("Category not found")
+	}
+	return subtreeIDs(categories, id), nil
+}
+
+// CreateCategory stores a new category as the last child of its parent
+// Parameters:
+//   - category: The category to create, its slug is generated from the name when empty
+//
+// Returns:
+//   - error: ValidationError if the parent does not exist or the slug is invalid or taken, DBInsert error otherwise
+func (service *CategoryService) CreateCategory(category *models.Category) error {
+	categories, err := service.repo.GetAll()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if category.ParentID != nil && !slices.ContainsFunc(categories, func(existing models.Category) bool { return existing.ID == *category.ParentID }) {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "parent_id", Message: "parent category does not exist"},
+		})
+	}
+	if err := service.prepare(category); err != nil {
+		return err
+	}
+
+	category.Position = len(siblingIDs(categories, category.ParentID, 0))
+	if err := service.repo.Create(category); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateCategory saves the name, slug and description of a category, its place in the tree is changed by MoveCategory
+// Parameters:
+//   - category: The category to save, its slug is generated from the name when empty
+//
+// Returns:
+//   - error: ValidationError if the slug is invalid or taken, DBUpdate error otherwise
+func (service *CategoryService) UpdateCategory(category *models.Category) error {
+	if err := service.prepare(category); err != nil {
+		return err
+	}
+	if err := service.repo.Update(category); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// MoveCategory places a category under another parent or at another position among its siblings
+// Parameters:
+//   - id: The ID of the category to move
+//   - parentID: The ID of the new parent, nil to make the category a root
+//   - position: The index of the category among its new siblings, nil to place it last
+//
+// Returns:
+//   - *models.Category: The moved category
+//   - error: NotFound if the category does not exist, ValidationError if the parent does not exist
+//     or is the category itself or one of its descendants, DBUpdate error otherwise
+func (service *CategoryService) MoveCategory(id uint, parentID *uint, position *int) (*models.Category, error) {
+	categories, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	index := slices.IndexFunc(categories, func(category models.Category) bool { return category.ID == id })
+	if index < 0 {
+		return nil, apperror.NewNotFoundError("Category not found")
+	}
+	category := categories[index]
+
+	if parentID != nil {
+		if !slices.ContainsFunc(categories, func(existing models.Category) bool { return existing.ID == *parentID }) {
+			return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "parent_id", Message: "parent category does not exist"},
+			})
+		}
+		if slices.Contains(subtreeIDs(categories, id), *parentID) {
+			return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "parent_id", Message: "a category cannot be moved under itself or one of its descendants"},
+			})
+		}
+	}
+
+	siblings := siblingIDs(categories, parentID, id)
+	at := len(siblings)
+	if position != nil && *position < at {
+		at = max(*position, 0)
+	}
+	siblings = slices.Insert(siblings, at, id)
+
+	if err := service.repo.UpdatePositions(parentID, siblings); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+
+	category.ParentID = parentID
+	category.Position = at
+	return &category, nil
+}
+
+// ReorderCategories changes the order of the children of a parent
+// Parameters:
+//   - parentID: The ID of the parent, nil to reorder the root categories
+//   - orderedIDs: The IDs of every child of the parent in their new order
+//
+// Returns:
+//   - error: ValidationError if the IDs are not exactly the children of the parent, DBUpdate error otherwise
+func (service *CategoryService) ReorderCategories(parentID *uint, orderedIDs []uint) error {
+	categories, err := service.repo.GetAll()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if parentID != nil && !slices.ContainsFunc(categories, func(existing models.Category) bool { return existing.ID == *parentID }) {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "parent_id", Message: "parent category does not exist"},
+		})
+	}
+
+	expected := siblingIDs(categories, parentID, 0)
+	given := slices.Clone(orderedIDs)
+	slices.Sort(expected)
+	slices.Sort(given)
+	if !slices.Equal(expected, given) {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "ids", Message: "ids must list every child of the parent exactly once"},
+		})
+	}
+
+	if err := service.repo.UpdatePositions(parentID, orderedIDs); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteCategory removes a category without subcategories, its posts are left without category
+// Parameters:
+//   - id: The ID of the category
+//
+// Returns:
+//   - error: BadRequest if the category still has subcategories, DBDelete error otherwise
+func (service *CategoryService) DeleteCategory(id uint) error {
+	categories, err := service.repo.GetAll()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if len(siblingIDs(categories, &id, 0)) > 0 {
+		return apperror.NewBadRequestError("Category has subcategories, move or delete them first")
+	}
+	if err := service.repo.Delete(id); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// prepare resolves the slug of a category before it is saved
+func (service *CategoryService) prepare(category *models.Category) error {
+	slug, err := resolveSlug(category.Slug, category.Name, "category", func(slug string) (bool, error) {
+		return service.repo.SlugExists(slug, category.ID)
+	})
+	if err != nil {
+		return err
+	}
+	category.Slug = slug
+	return nil
+}
+
+// siblingIDs lists in order the IDs of the children of a parent, leaving out the excluded category
+func siblingIDs(categories []models.Category, parentID *uint, excludeID uint) []uint {
+	ids := []uint{}
+	for _, category := range categories {
+		if category.ID == excludeID {
+			continue
+		}
+		if (parentID == nil && category.ParentID == nil) ||
+			(parentID != nil && category.ParentID != nil && *parentID == *category.ParentID) {
+			ids = append(ids, category.ID)
+		}
+	}
+	return ids
+}
+
+// subtreeIDs lists the ID of a category followed by the IDs of all its descendants
+func subtreeIDs(categories []models.Category, id uint) []uint {
+	ids := []uint{id}
+	for i := 0; i < len(ids); i++ {
+		parentID := ids[i]
+		ids = append(ids, siblingIDs(categories, &parentID, 0)...)
+	}
+	return ids
+}
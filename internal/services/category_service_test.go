@@ -0,0 +1,238 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type CategoryServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockCategoryRepository
+	service *services.CategoryService
+}
+
+func (s *CategoryServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockCategoryRepository)
+	s.service = services.NewCategoryService(s.repo)
+}
+
+func (s *CategoryServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *CategoryServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *CategoryServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+// categoryTree returns the categories news > world > europe and sport, ordered as the repository returns them
+func categoryTree() []models.Category {
+	news, world := uint(1), uint(2)
+	return []models.Category{
+		{ID: 1, Name: "News", Slug: "news", Position: 0},
+		{ID: 2, Name: "World", Slug: "world", ParentID: &news, Position: 0},
+		{ID: 3, Name: "Europe", Slug: "europe", ParentID: &world, Position: 0},
+		{ID: 4, Name: "Sport", Slug: "sport", Position: 1},
+	}
+}
+
+func (s *CategoryServiceTestSuite) TestGetTree() {
+	s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+	roots, err := s.service.GetTree()
+	s.Require().NoError(err)
+	s.Require().Len(roots, 2)
+	s.Equal("news", roots[0].Slug)
+	s.Require().Len(roots[0].Children, 1)
+	s.Equal("world", roots[0].Children[0].Slug)
+	s.Require().Len(roots[0].Children[0].Children, 1)
+	s.Equal("europe", roots[0].Children[0].Children[0].Slug)
+	s.Empty(roots[1].Children)
+}
+
+func (s *CategoryServiceTestSuite) TestGetBreadcrumbs() {
+	s.Run("Success", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		breadcrumbs, err := s.service.GetBreadcrumbs(3)
+		s.Require().NoError(err)
+		s.Require().Len(breadcrumbs, 3)
+		s.Equal("news", breadcrumbs[0].Slug)
+		s.Equal("world", breadcrumbs[1].Slug)
+		s.Equal("europe", breadcrumbs[2].Slug)
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		_, err := s.service.GetBreadcrumbs(9)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *CategoryServiceTestSuite) TestGetSubtreeIDs() {
+	s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+	ids, err := s.service.GetSubtreeIDs(1)
+	s.Require().NoError(err)
+	s.Equal([]uint{1, 2, 3}, ids)
+
+	s.repo.On("GetAll").Return(nil, errors.New("db error")).Once()
+	_, err = s.service.GetSubtreeIDs(1)
+	s.assertCode(err, apperror.ErrDBQuery)
+}
+
+func (s *CategoryServiceTestSuite) TestCreateCategory() {
+	s.Run("Success placed last", func() {
+		parentID := uint(1)
+		category := &models.Category{Name: "Local news", ParentID: &parentID}
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+		s.repo.On("SlugExists", "local-news", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", category).Return(nil).Once()
+
+		err := s.service.CreateCategory(category)
+		s.Require().NoError(err)
+		s.Equal("local-news", category.Slug)
+		s.Equal(1, category.Position)
+	})
+
+	s.Run("Unknown parent", func() {
+		parentID := uint(9)
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		err := s.service.CreateCategory(&models.Category{Name: "Local", ParentID: &parentID})
+		s.assertFieldError(err, "parent_id")
+	})
+
+	s.Run("Slug taken", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+		s.repo.On("SlugExists", "news", uint(0)).Return(true, nil).Once()
+
+		err := s.service.CreateCategory(&models.Category{Name: "News", Slug: "News"})
+		s.assertFieldError(err, "slug")
+	})
+}
+
+func (s *CategoryServiceTestSuite) TestUpdateCategory() {
+	category := &models.Category{ID: 2, Name: "World", Slug: "world"}
+	s.repo.On("SlugExists", "world", uint(2)).Return(false, nil).Once()
+	s.repo.On("Update", category).Return(errors.New("db error")).Once()
+
+	err := s.service.UpdateCategory(category)
+	s.assertCode(err, apperror.ErrDBUpdate)
+}
+
+func (s *CategoryServiceTestSuite) TestMoveCategory() {
+	s.Run("Success to another parent", func() {
+		sport, position := uint(4), 0
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+		s.repo.On("UpdatePositions", &sport, []uint{2}).Return(nil).Once()
+
+		category, err := s.service.MoveCategory(2, &sport, &position)
+		s.Require().NoError(err)
+		s.Equal(sport, *category.ParentID)
+		s.Equal(0, category.Position)
+	})
+
+	s.Run("Success within its siblings", func() {
+		position := 0
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+		s.repo.On("UpdatePositions", (*uint)(nil), []uint{4, 1}).Return(nil).Once()
+
+		category, err := s.service.MoveCategory(4, nil, &position)
+		s.Require().NoError(err)
+		s.Nil(category.ParentID)
+	})
+
+	s.Run("Under a descendant", func() {
+		europe := uint(3)
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		_, err := s.service.MoveCategory(1, &europe, nil)
+		s.assertFieldError(err, "parent_id")
+	})
+
+	s.Run("Under itself", func() {
+		news := uint(1)
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		_, err := s.service.MoveCategory(1, &news, nil)
+		s.assertFieldError(err, "parent_id")
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		_, err := s.service.MoveCategory(9, nil, nil)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *CategoryServiceTestSuite) TestReorderCategories() {
+	s.Run("Success", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+		s.repo.On("UpdatePositions", (*uint)(nil), []uint{4, 1}).Return(nil).Once()
+
+		s.NoError(s.service.ReorderCategories(nil, []uint{4, 1}))
+	})
+
+	s.Run("Missing child", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		err := s.service.ReorderCategories(nil, []uint{4})
+		s.assertFieldError(err, "ids")
+	})
+
+	s.Run("Child of another parent", func() {
+		news := uint(1)
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		err := s.service.ReorderCategories(&news, []uint{3})
+		s.assertFieldError(err, "ids")
+	})
+}
+
+func (s *CategoryServiceTestSuite) TestDeleteCategory() {
+	s.Run("Success", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+		s.repo.On("Delete", uint(3)).Return(nil).Once()
+
+		s.NoError(s.service.DeleteCategory(3))
+	})
+
+	s.Run("Has subcategories", func() {
+		s.repo.On("GetAll").Return(categoryTree(), nil).Once()
+
+		err := s.service.DeleteCategory(2)
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+}
+
+func (s *CategoryServiceTestSuite) TestGetCategory() {
+	s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err := s.service.GetCategory(9)
+	s.assertCode(err, apperror.ErrNotFound)
+
+	s.repo.On("FindBySlug", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err = s.service.GetCategoryBySlug("missing")
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func TestCategoryServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CategoryServiceTestSuite))
+}
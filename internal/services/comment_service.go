@@ -0,0 +1,329 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+// commentLinkPattern matches the links counted by the spam heuristic
+var commentLinkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)`)
+
+// commentActionStatuses maps the moderation actions to the status they give to the comments
+var commentActionStatuses = map[string]string{
+	models.CommentActionApprove: models.CommentStatusApproved,
+	models.CommentActionSpam:    models.CommentStatusSpam,
+	models.CommentActionReject:  models.CommentStatusRejected,
+	models.CommentActionRequeue: models.CommentStatusPending,
+}
+
+// CommentRules configures which comments skip the moderation queue and which are marked as spam
+type CommentRules struct {
+	TrustedAfter int      // Approved comments after which the new comments of a user are approved, negative to queue every comment
+	MaxLinks     int      // Comments with more links are marked as spam
+	SpamKeywords []string // Comments containing any of these words are marked as spam, matched case-insensitively
+}
+
+// CommentInput is a new comment on a published post
+type CommentInput struct {
+	AuthorID   uint   // 0 for guests
+	GuestName  string // Required for guests
+	GuestEmail string // Required for guests, never shown on the public API
+	ParentID   *uint  // The comment replied to, nil to start a thread
+	Body       string
+	IPAddress  string
+	UserAgent  string
+}
+
+type ICommentService interface {
+	GetComments(page, limit int, filter repositories.CommentFilter) (*utils.Pagination, error)
+	GetComment(id uint) (*models.Comment, error)
+	GetPublicThreads(slug string, page, limit int) (*utils.Pagination, error)
+	CreateComment(slug string, input CommentInput) (*models.Comment, error)
+	ModerateComments(moderatorID uint, ids []uint, action string) (int64, error)
+}
+
+type CommentService struct {
+	repo              repositories.ICommentRepository
+	postRepo          repositories.IPostRepository
+	permissionService IPermissionService
+	mailerService     IMailerService
+	rules             CommentRules
+}
+
+// NewCommentService creates a new instance of CommentService
+// Parameters:
+//   - repo: Repository of comments
+//   - postRepo: Repository of the commented posts
+//   - permissionService: Service telling moderators apart, their comments are always approved
+//   - mailerService: Service notifying the authors of the posts
+//   - rules: Auto-approval and spam rules
+//
+// Returns:
+//   - *CommentService: New CommentService instance initialized with the provided dependencies
+func NewCommentService(
+	repo repositories.ICommentRepository,
+	postRepo repositories.IPostRepository,
+	permissionService IPermissionService,
+	mailerService IMailerService,
+	rules CommentRules,
+) *CommentService {
+	return &CommentService{
+		repo:              repo,
+		postRepo:          postRepo,
+		permissionService: permissionService,
+		mailerService:     mailerService,
+		rules:             rules,
+	}
+}
+
+// GetComments retrieves a page of the moderation queue, newest first
+func (service *CommentService) GetComments(page, limit int, filter repositories.CommentFilter) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginateComments(page, limit, filter)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetComment retrieves a comment by its ID
+func (service *CommentService) GetComment(id uint) (*models.Comment, error) {
+	comment, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return comment, nil
+}
+
+// GetPublicThreads retrieves a page of the approved threads of a published post with their approved replies
+// Replies to a comment that is not approved are hidden together with the comment
+// Parameters:
+//   - slug: The slug of the post
+//   - page: The page number to retrieve
+//   - limit: The number of threads per page
+//
+// Returns:
+//   - *utils.Pagination: The page of []models.Comment, each with its nested replies
+//   - error: NotFound if no published post has this slug, DBQuery error otherwise
+func (service *CommentService) GetPublicThreads(slug string, page, limit int) (*utils.Pagination, error) {
+	post, err := service.postRepo.FindPublishedBySlug(slug)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	pagination, err := service.repo.PaginateThreads(post.ID, page, limit)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	roots, _ := pagination.Data.([]models.Comment)
+	rootIDs := make([]uint, len(roots))
+	for i, root := range roots {
+		rootIDs[i] = root.ID
+	}
+	replies, err := service.repo.FindApprovedReplies(rootIDs)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	children := make(map[uint][]models.Comment)
+	for _, reply := range replies {
+		if reply.ParentID != nil {
+			children[*reply.ParentID] = append(children[*reply.ParentID], reply)
+		}
+	}
+	var attach func(nodes []models.Comment) []models.Comment
+	attach = func(nodes []models.Comment) []models.Comment {
+		for i := range nodes {
+			nodes[i].Replies = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	pagination.Data = attach(roots)
+	return pagination, nil
+}
+
+// CreateComment stores a comment on a published post and notifies the author of the post
+// Parameters:
+//   - slug: The slug of the post
+//   - input: The comment with its author or guest details
+//
+// Returns:
+//   - *models.Comment: The stored comment with its moderation status
+//   - error: NotFound if no published post has this slug, ValidationError if guest details are missing
+//     or the parent is not an approved comment of the post, DBInsert error otherwise
+//
+// The function:
+//  1. Checks the guest details and the comment replied to
+//  2. Marks comments matching the spam heuristic as spam
+//  3. Approves comments of moderators and trusted users, queues the others for moderation
+//  4. Emails the author of the post unless the comment is spam or written by the author, a failed email is only logged
+func (service *CommentService) CreateComment(slug string, input CommentInput) (*models.Comment, error) {
+	post, err := service.postRepo.FindPublishedBySlug(slug)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	comment := models.Comment{
+		PostID:    post.ID,
+		Body:      strings.TrimSpace(input.Body),
+		IPAddress: input.IPAddress,
+		UserAgent: truncateRunes(input.UserAgent, 255),
+	}
+
+	var fields []apperror.FieldError
+	if input.AuthorID != 0 {
+		comment.AuthorID = &input.AuthorID
+	} else {
+		if strings.TrimSpace(input.GuestName) == "" {
+			fields = append(fields, apperror.FieldError{Field: "guest_name", Message: "guest_name is required when not signed in"})
+		}
+		if strings.TrimSpace(input.GuestEmail) == "" {
+			fields = append(fields, apperror.FieldError{Field: "guest_email", Message: "guest_email is required when not signed in"})
+		}
+		comment.GuestName = utils.StringToPtr(strings.TrimSpace(input.GuestName))
+		comment.GuestEmail = utils.StringToPtr(strings.TrimSpace(input.GuestEmail))
+	}
+
+	if input.ParentID != nil {
+		parent, err := service.repo.GetByID(*input.ParentID)
+		if err != nil || parent.PostID != post.ID || parent.Status != models.CommentStatusApproved {
+			fields = append(fields, apperror.FieldError{Field: "parent_id", Message: "parent comment does not exist"})
+		} else {
+			comment.ParentID = &parent.ID
+			comment.RootID = parent.RootID
+			if comment.RootID == nil {
+				comment.RootID = &parent.ID
+			}
+		}
+	}
+	if len(fields) > 0 {
+		return nil, apperror.NewValidationError("Validation failed", fields)
+	}
+
+	comment.SpamScore = service.spamScore(comment.Body, input.GuestName)
+	comment.Status, err = service.initialStatus(input.AuthorID, comment.SpamScore)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.repo.Create(&comment); err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+
+	commenterName := input.GuestName
+	if input.AuthorID != 0 {
+		// Reload the comment so the response and the email carry the name of its author
+		if stored, err := service.repo.GetByID(comment.ID); err == nil && stored.Author != nil {
+			comment.Author = stored.Author
+			commenterName = stored.Author.Name
+		}
+	}
+
+	if comment.Status != models.CommentStatusSpam && post.Author != nil && post.AuthorID != input.AuthorID {
+		if err := service.mailerService.SendMailNewComment(post.Author, post, &comment, commenterName); err != nil {
+			logger.Errorf("Failed to notify the author of post %d about comment %d: %v", post.ID, comment.ID, err)
+		}
+	}
+	return &comment, nil
+}
+
+// ModerateComments applies a moderation action to several comments at once
+// Parameters:
+//   - moderatorID: The ID of the user moderating the comments
+//   - ids: IDs of the comments
+//   - action: One of the models.CommentAction* actions
+//
+// Returns:
+//   - int64: Number of comments changed, replies removed with their parent included
+//   - error: BadRequest if the action is unknown, NotFound if none of the comments exist, DBUpdate or DBDelete error otherwise
+func (service *CommentService) ModerateComments(moderatorID uint, ids []uint, action string) (int64, error) {
+	if action == models.CommentActionDelete {
+		deleted, err := service.repo.DeleteWithReplies(ids)
+		if err != nil {
+			return 0, apperror.NewDBDeleteError(err.Error())
+		}
+		if deleted == 0 {
+			return 0, apperror.NewNotFoundError("Comments not found")
+		}
+		return deleted, nil
+	}
+
+	status, ok := commentActionStatuses[action]
+	if !ok {
+		return 0, apperror.NewBadRequestError("Unknown moderation action " + action)
+	}
+	updated, err := service.repo.UpdateStatus(ids, status, moderatorID)
+	if err != nil {
+		return 0, apperror.NewDBUpdateError(err.Error())
+	}
+	if updated == 0 {
+		return 0, apperror.NewNotFoundError("Comments not found")
+	}
+	return updated, nil
+}
+
+// initialStatus decides whether a new comment is approved, queued or marked as spam
+// Moderators are always approved, other comments with spam signals are marked as spam
+func (service *CommentService) initialStatus(authorID uint, spamScore int) (string, error) {
+	if authorID == 0 {
+		if spamScore > 0 {
+			return models.CommentStatusSpam, nil
+		}
+		return models.CommentStatusPending, nil
+	}
+
+	moderator, err := service.permissionService.HasPermission(authorID, constants.PermissionModerateComments)
+	if err != nil {
+		return "", err
+	}
+	if moderator {
+		return models.CommentStatusApproved, nil
+	}
+	if spamScore > 0 {
+		return models.CommentStatusSpam, nil
+	}
+
+	if service.rules.TrustedAfter < 0 {
+		return models.CommentStatusPending, nil
+	}
+	approved, err := service.repo.CountApprovedByAuthor(authorID)
+	if err != nil {
+		return "", apperror.NewDBQueryError(err.Error())
+	}
+	if approved >= int64(service.rules.TrustedAfter) {
+		return models.CommentStatusApproved, nil
+	}
+	return models.CommentStatusPending, nil
+}
+
+// spamScore counts the spam signals of a comment: each link above the limit and each spam keyword found
+func (service *CommentService) spamScore(body, guestName string) int {
+	score := 0
+	if links := len(commentLinkPattern.FindAllStringIndex(body, -1)); links > service.rules.MaxLinks {
+		score += links - service.rules.MaxLinks
+	}
+
+	text := strings.ToLower(body + " " + guestName)
+	for _, keyword := range service.rules.SpamKeywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" && strings.Contains(text, keyword) {
+			score++
+		}
+	}
+	return score
+}
+
+// truncateRunes cuts a string to at most max characters without splitting a multi-byte character
+func truncateRunes(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
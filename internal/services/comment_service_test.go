@@ -0,0 +1,252 @@
+package services_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type CommentServiceTestSuite struct {
+	suite.Suite
+	repo              *mocks.MockCommentRepository
+	postRepo          *mocks.MockPostRepository
+	permissionService *mocks.MockPermissionService
+	mailerService     *mocks.MockMailerService
+	service           *services.CommentService
+	post              *models.Post
+}
+
+func (s *CommentServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockCommentRepository)
+	s.postRepo = new(mocks.MockPostRepository)
+	s.permissionService = new(mocks.MockPermissionService)
+	s.mailerService = new(mocks.MockMailerService)
+	s.service = services.NewCommentService(s.repo, s.postRepo, s.permissionService, s.mailerService, services.CommentRules{
+		TrustedAfter: 2,
+		MaxLinks:     1,
+		SpamKeywords: []string{"casino", " "},
+	})
+	s.post = &models.Post{ID: 1, Slug: "hello", Title: "Hello", AuthorID: 10, Author: &models.User{ID: 10, Name: "Author"}}
+}
+
+func (s *CommentServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.postRepo.AssertExpectations(s.T())
+	s.permissionService.AssertExpectations(s.T())
+	s.mailerService.AssertExpectations(s.T())
+}
+
+func (s *CommentServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *CommentServiceTestSuite) validationFields(err error) []string {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	fields := make([]string, len(validationErr.Fields))
+	for i, field := range validationErr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func (s *CommentServiceTestSuite) TestCreateComment() {
+	s.Run("Guest comment is queued and the author notified", func() {
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+		s.repo.On("Create", mock.MatchedBy(func(comment *models.Comment) bool {
+			return comment.Status == models.CommentStatusPending && comment.AuthorID == nil && *comment.GuestEmail == "guest@example.com"
+		})).Run(func(args mock.Arguments) { args.Get(0).(*models.Comment).ID = 5 }).Return(nil).Once()
+		s.mailerService.On("SendMailNewComment", s.post.Author, s.post, mock.AnythingOfType("*models.Comment"), "Guest").Return(nil).Once()
+
+		comment, err := s.service.CreateComment("hello", services.CommentInput{
+			GuestName: "Guest", GuestEmail: "guest@example.com", Body: " Nice post ", IPAddress: "127.0.0.1",
+		})
+		s.Require().NoError(err)
+		s.Equal("Nice post", comment.Body)
+		s.Equal(models.CommentStatusPending, comment.Status)
+	})
+
+	s.Run("Guest details are required", func() {
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+
+		_, err := s.service.CreateComment("hello", services.CommentInput{Body: "Hi"})
+		s.Equal([]string{"guest_name", "guest_email"}, s.validationFields(err))
+	})
+
+	s.Run("Post not published", func() {
+		s.postRepo.On("FindPublishedBySlug", "draft").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.CreateComment("draft", services.CommentInput{Body: "Hi"})
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Reply joins the thread of its parent", func() {
+		rootID := uint(2)
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+		s.repo.On("GetByID", uint(3)).Return(&models.Comment{ID: 3, PostID: 1, RootID: &rootID, Status: models.CommentStatusApproved}, nil).Once()
+		s.permissionService.On("HasPermission", uint(20), constants.PermissionModerateComments).Return(false, nil).Once()
+		s.repo.On("CountApprovedByAuthor", uint(20)).Return(int64(2), nil).Once()
+		s.repo.On("Create", mock.MatchedBy(func(comment *models.Comment) bool {
+			return *comment.ParentID == 3 && *comment.RootID == 2 && comment.Status == models.CommentStatusApproved
+		})).Run(func(args mock.Arguments) { args.Get(0).(*models.Comment).ID = 6 }).Return(nil).Once()
+		s.repo.On("GetByID", uint(6)).Return(&models.Comment{ID: 6, Author: &models.User{ID: 20, Name: "Reader"}}, nil).Once()
+		s.mailerService.On("SendMailNewComment", s.post.Author, s.post, mock.AnythingOfType("*models.Comment"), "Reader").Return(errors.New("smtp down")).Once()
+
+		parentID := uint(3)
+		comment, err := s.service.CreateComment("hello", services.CommentInput{AuthorID: 20, ParentID: &parentID, Body: "Agreed"})
+		s.Require().NoError(err, "a failed email does not fail the comment")
+		s.Equal("Reader", comment.Author.Name)
+	})
+
+	s.Run("Parent on another post", func() {
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+		s.repo.On("GetByID", uint(3)).Return(&models.Comment{ID: 3, PostID: 2, Status: models.CommentStatusApproved}, nil).Once()
+
+		parentID := uint(3)
+		_, err := s.service.CreateComment("hello", services.CommentInput{AuthorID: 20, ParentID: &parentID, Body: "Hi"})
+		s.Equal([]string{"parent_id"}, s.validationFields(err))
+	})
+
+	s.Run("Spam is not notified", func() {
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+		s.permissionService.On("HasPermission", uint(20), constants.PermissionModerateComments).Return(false, nil).Once()
+		s.repo.On("Create", mock.MatchedBy(func(comment *models.Comment) bool {
+			// Two links above the limit and one keyword
+			return comment.Status == models.CommentStatusSpam && comment.SpamScore == 3
+		})).Return(nil).Once()
+		s.repo.On("GetByID", uint(0)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.CreateComment("hello", services.CommentInput{
+			AuthorID: 20, Body: "Best CASINO https://a.example http://b.example www.c.example",
+		})
+		s.Require().NoError(err)
+	})
+
+	s.Run("Moderators and post authors", func() {
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+		s.permissionService.On("HasPermission", uint(10), constants.PermissionModerateComments).Return(true, nil).Once()
+		s.repo.On("Create", mock.MatchedBy(func(comment *models.Comment) bool {
+			return comment.Status == models.CommentStatusApproved
+		})).Return(nil).Once()
+		s.repo.On("GetByID", uint(0)).Return(&models.Comment{Author: s.post.Author}, nil).Once()
+
+		comment, err := s.service.CreateComment("hello", services.CommentInput{AuthorID: 10, Body: "Thanks https://a.example https://b.example"})
+		s.Require().NoError(err)
+		s.Equal(models.CommentStatusApproved, comment.Status)
+	})
+
+	s.Run("Users become trusted", func() {
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+		s.permissionService.On("HasPermission", uint(20), constants.PermissionModerateComments).Return(false, nil).Once()
+		s.repo.On("CountApprovedByAuthor", uint(20)).Return(int64(1), nil).Once()
+		s.repo.On("Create", mock.MatchedBy(func(comment *models.Comment) bool {
+			return comment.Status == models.CommentStatusPending
+		})).Return(nil).Once()
+		s.repo.On("GetByID", uint(0)).Return(nil, gorm.ErrRecordNotFound).Once()
+		s.mailerService.On("SendMailNewComment", s.post.Author, s.post, mock.AnythingOfType("*models.Comment"), "").Return(nil).Once()
+
+		_, err := s.service.CreateComment("hello", services.CommentInput{AuthorID: 20, Body: "First"})
+		s.Require().NoError(err)
+	})
+}
+
+func (s *CommentServiceTestSuite) TestGetPublicThreads() {
+	s.Run("Success", func() {
+		one, two, three := uint(1), uint(2), uint(3)
+		s.postRepo.On("FindPublishedBySlug", "hello").Return(s.post, nil).Once()
+		s.repo.On("PaginateThreads", uint(1), 1, 10).Return(&utils.Pagination{
+			Page: 1, Limit: 10, TotalItems: 1, TotalPages: 1,
+			Data: []models.Comment{{ID: 1}},
+		}, nil).Once()
+		s.repo.On("FindApprovedReplies", []uint{1}).Return([]models.Comment{
+			{ID: 2, ParentID: &one, RootID: &one},
+			{ID: 4, ParentID: &two, RootID: &one},
+			// The parent is not approved, the reply is hidden with it
+			{ID: 5, ParentID: &three, RootID: &one},
+		}, nil).Once()
+
+		pagination, err := s.service.GetPublicThreads("hello", 1, 10)
+		s.Require().NoError(err)
+		threads := pagination.Data.([]models.Comment)
+		s.Require().Len(threads, 1)
+		s.Require().Len(threads[0].Replies, 1)
+		s.Equal(uint(2), threads[0].Replies[0].ID)
+		s.Require().Len(threads[0].Replies[0].Replies, 1)
+		s.Equal(uint(4), threads[0].Replies[0].Replies[0].ID)
+	})
+
+	s.Run("Post not found", func() {
+		s.postRepo.On("FindPublishedBySlug", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.GetPublicThreads("missing", 1, 10)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *CommentServiceTestSuite) TestModerateComments() {
+	s.Run("Approve", func() {
+		s.repo.On("UpdateStatus", []uint{1, 2}, models.CommentStatusApproved, uint(7)).Return(int64(2), nil).Once()
+
+		affected, err := s.service.ModerateComments(7, []uint{1, 2}, models.CommentActionApprove)
+		s.Require().NoError(err)
+		s.Equal(int64(2), affected)
+	})
+
+	s.Run("Requeue missing comments", func() {
+		s.repo.On("UpdateStatus", []uint{9}, models.CommentStatusPending, uint(7)).Return(int64(0), nil).Once()
+
+		_, err := s.service.ModerateComments(7, []uint{9}, models.CommentActionRequeue)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Delete", func() {
+		s.repo.On("DeleteWithReplies", []uint{1}).Return(int64(3), nil).Once()
+
+		affected, err := s.service.ModerateComments(7, []uint{1}, models.CommentActionDelete)
+		s.Require().NoError(err)
+		s.Equal(int64(3), affected)
+	})
+
+	s.Run("Delete error", func() {
+		s.repo.On("DeleteWithReplies", []uint{1}).Return(int64(0), errors.New("db error")).Once()
+
+		_, err := s.service.ModerateComments(7, []uint{1}, models.CommentActionDelete)
+		s.assertCode(err, apperror.ErrDBDelete)
+	})
+
+	s.Run("Unknown action", func() {
+		_, err := s.service.ModerateComments(7, []uint{1}, "publish")
+		appErr, ok := apperror.ToAppError(err)
+		s.Require().True(ok)
+		s.Equal(http.StatusBadRequest, appErr.HttpStatusCode)
+	})
+}
+
+func (s *CommentServiceTestSuite) TestGetComments() {
+	filter := repositories.CommentFilter{Status: models.CommentStatusPending}
+	s.repo.On("PaginateComments", 1, 10, filter).Return(nil, errors.New("db error")).Once()
+
+	_, err := s.service.GetComments(1, 10, filter)
+	s.assertCode(err, apperror.ErrDBQuery)
+
+	s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err = s.service.GetComment(9)
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func TestCommentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CommentServiceTestSuite))
+}
@@ -0,0 +1,515 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"gorm.io/gorm"
+)
+
+var (
+	contentTypeSlugPattern  = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,44}$`)
+	contentFieldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,44}$`)
+)
+
+const maxContentFields = 50 // Fields a content type may define
+
+type IContentService interface {
+	GetTypes() ([]models.ContentType, error)
+	GetType(slug string) (*models.ContentType, error)
+	CreateType(contentType *models.ContentType) error
+	UpdateType(contentType *models.ContentType) error
+	DeleteType(contentType *models.ContentType) error
+	PaginateEntries(contentType *models.ContentType, page, limit int) (*utils.Pagination, error)
+	GetEntry(contentType *models.ContentType, id uint) (*models.ContentEntry, error)
+	CreateEntry(contentType *models.ContentType, data map[string]any, authorID uint) (*models.ContentEntry, error)
+	UpdateEntry(contentType *models.ContentType, id uint, version *uint, data map[string]any) (*models.ContentEntry, error)
+	DeleteEntry(contentType *models.ContentType, id uint) error
+}
+
+// ContentService manages the content types defined at runtime and validates their entries against the fields of the type
+type ContentService struct {
+	repo      repositories.IContentRepository
+	mediaRepo repositories.IMediaRepository
+}
+
+// NewContentService creates a new instance of ContentService
+// Parameters:
+//   - repo: Repository of content types and entries
+//   - mediaRepo: Media repository used to check the files referenced by media fields
+//
+// Returns:
+//   - *ContentService: New ContentService instance initialized with the provided repositories
+func NewContentService(repo repositories.IContentRepository, mediaRepo repositories.IMediaRepository) *ContentService {
+	return &ContentService{
+		repo:      repo,
+		mediaRepo: mediaRepo,
+	}
+}
+
+// GetTypes retrieves every content type
+func (service *ContentService) GetTypes() ([]models.ContentType, error) {
+	contentTypes, err := service.repo.GetTypes()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return contentTypes, nil
+}
+
+// GetType retrieves a content type by its slug
+func (service *ContentService) GetType(slug string) (*models.ContentType, error) {
+	contentType, err := service.repo.GetTypeBySlug(slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("Content type not found")
+		}
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return contentType, nil
+}
+
+// CreateType validates and stores a new content type
+// Returns ValidationError if the slug is invalid or taken or a field is invalid, DBInsert error otherwise
+func (service *ContentService) CreateType(contentType *models.ContentType) error {
+	if err := service.validateType(contentType); err != nil {
+		return err
+	}
+	if err := service.repo.CreateType(contentType); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateType validates and saves the name, description and fields of a content type
+// Stored entries are not migrated, values of removed fields are dropped the next time an entry is updated
+func (service *ContentService) UpdateType(contentType *models.ContentType) error {
+	if err := service.validateType(contentType); err != nil {
+		return err
+	}
+	if err := service.repo.UpdateType(contentType); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteType removes a content type and its entries
+// Returns BadRequest while a field of another content type references it, DBDelete error otherwise
+func (service *ContentService) DeleteType(contentType *models.ContentType) error {
+	contentTypes, err := service.repo.GetTypes()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	for _, other := range contentTypes {
+		if other.ID == contentType.ID {
+			continue
+		}
+		for _, field := range other.Fields {
+			if field.Rules.Target == contentType.Slug {
+				return apperror.NewBadRequestError(fmt.Sprintf("Content type is referenced by the field %s of %s", field.Name, other.Slug))
+			}
+		}
+	}
+
+	if err := service.repo.DeleteType(contentType.ID); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// PaginateEntries retrieves a page of the entries of a content type
+func (service *ContentService) PaginateEntries(contentType *models.ContentType, page, limit int) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginateEntries(contentType.ID, page, limit)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetEntry retrieves an entry of a content type
+func (service *ContentService) GetEntry(contentType *models.ContentType, id uint) (*models.ContentEntry, error) {
+	entry, err := service.repo.GetEntry(contentType.ID, id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return entry, nil
+}
+
+// CreateEntry validates data against the fields of a content type and stores it as a new entry
+// Parameters:
+//   - contentType: The content type of the entry
+//   - data: Values keyed by field name, as decoded from JSON
+//   - authorID: The ID of the user creating the entry
+//
+// Returns:
+//   - *models.ContentEntry: The created entry with its normalized data
+//   - error: ValidationError listing every invalid value, DBInsert error otherwise
+func (service *ContentService) CreateEntry(contentType *models.ContentType, data map[string]any, authorID uint) (*models.ContentEntry, error) {
+	values, err := service.validateData(contentType, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.ContentEntry{ContentTypeID: contentType.ID, Data: values, AuthorID: &authorID}
+	if err := service.repo.CreateEntry(entry); err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+	return entry, nil
+}
+
+// UpdateEntry merges data into an entry of a content type and validates the result
+// Parameters:
+//   - contentType: The content type of the entry
+//   - id: The ID of the entry
+//   - version: The version of the entry the changes were made to, nil to overwrite whatever is stored
+//   - data: Values keyed by field name, omitted fields are kept and a null value clears a field
+//
+// Returns:
+//   - *models.ContentEntry: The updated entry
+//   - error: NotFound if the entry does not exist, ValidationError listing every invalid value,
+//     VersionConflict error if the entry was saved by someone else since that version, DBUpdate error otherwise
+func (service *ContentService) UpdateEntry(contentType *models.ContentType, id uint, version *uint, data map[string]any) (*models.ContentEntry, error) {
+	entry, err := service.repo.GetEntry(contentType.ID, id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	if version != nil {
+		entry.Version = *version
+	}
+
+	values, err := service.validateData(contentType, data, entry.Data)
+	if err != nil {
+		return nil, err
+	}
+	entry.Data = values
+	if err := service.repo.UpdateEntry(entry); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, apperror.NewVersionConflictError("The entry was changed by someone else, reload it and try again")
+		}
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+	return entry, nil
+}
+
+// DeleteEntry removes an entry of a content type
+func (service *ContentService) DeleteEntry(contentType *models.ContentType, id uint) error {
+	deleted, err := service.repo.DeleteEntry(contentType.ID, id)
+	if err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	if deleted == 0 {
+		return apperror.NewNotFoundError("Entry not found")
+	}
+	return nil
+}
+
+// validateType checks the slug and the fields of a content type
+func (service *ContentService) validateType(contentType *models.ContentType) error {
+	var fieldErrors []apperror.FieldError
+	addError := func(field, message string) {
+		fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: field + " " + message})
+	}
+
+	contentTypes, err := service.repo.GetTypes()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	slugs := []string{contentType.Slug}
+	for _, existing := range contentTypes {
+		if existing.ID == contentType.ID {
+			continue
+		}
+		if existing.Slug == contentType.Slug {
+			addError("slug", "is already taken")
+		}
+		slugs = append(slugs, existing.Slug)
+	}
+	if !contentTypeSlugPattern.MatchString(contentType.Slug) {
+		addError("slug", "must start with a lowercase letter and contain only lowercase letters, digits, dashes and underscores")
+	}
+
+	if len(contentType.Fields) == 0 || len(contentType.Fields) > maxContentFields {
+		addError("fields", fmt.Sprintf("must contain between 1 and %d fields", maxContentFields))
+	}
+	names := make(map[string]bool, len(contentType.Fields))
+	for i, field := range contentType.Fields {
+		prefix := fmt.Sprintf("fields[%d].", i)
+		if !contentFieldNamePattern.MatchString(field.Name) {
+			addError(prefix+"name", "must start with a lowercase letter and contain only lowercase letters, digits and underscores")
+		} else if names[field.Name] {
+			addError(prefix+"name", "is already used by another field")
+		}
+		names[field.Name] = true
+
+		if !slices.Contains(models.ContentFieldTypes, field.Type) {
+			addError(prefix+"type", fmt.Sprintf("must be one of [%s]", strings.Join(models.ContentFieldTypes, " ")))
+			continue
+		}
+
+		rules := field.Rules
+		itemType := field.Type
+		if field.Type == models.ContentFieldList {
+			itemType = rules.ItemType
+			if itemType == models.ContentFieldList || !slices.Contains(models.ContentFieldTypes, itemType) {
+				addError(prefix+"rules.itemType", "must be a field type other than list")
+			}
+			if rules.MinItems != nil && rules.MaxItems != nil && *rules.MinItems > *rules.MaxItems {
+				addError(prefix+"rules.maxItems", "must be greater than or equal to rules.minItems")
+			}
+		}
+		if itemType != models.ContentFieldReference {
+			// Only references keep a target, so it tells which content types are referenced
+			contentType.Fields[i].Rules.Target = ""
+		} else if !slices.Contains(slugs, rules.Target) {
+			addError(prefix+"rules.target", "must be the slug of a content type")
+		}
+		if rules.Pattern != "" {
+			if _, err := regexp.Compile(rules.Pattern); err != nil {
+				addError(prefix+"rules.pattern", "must be a valid regular expression")
+			}
+		}
+		if rules.MinLength != nil && rules.MaxLength != nil && *rules.MinLength > *rules.MaxLength {
+			addError(prefix+"rules.maxLength", "must be greater than or equal to rules.minLength")
+		}
+		if rules.Min != nil && rules.Max != nil && *rules.Min > *rules.Max {
+			addError(prefix+"rules.max", "must be greater than or equal to rules.min")
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return apperror.NewValidationError("Validation failed", fieldErrors)
+	}
+	return nil
+}
+
+// contentLinks holds the IDs referenced by a field of an entry, checked once every value is valid
+type contentLinks struct {
+	field  string
+	target string // Slug of the referenced content type, empty for media
+	ids    []uint
+}
+
+// validateData validates the values of an entry against the fields of its content type
+//
+// The function:
+//  1. Rejects unknown fields and values that break the rules of their field
+//  2. Merges the values into the stored data, a null value clears a field and fields no longer defined are dropped
+//  3. Rejects missing required fields, then references to entries or media files that do not exist
+func (service *ContentService) validateData(contentType *models.ContentType, data, stored map[string]any) (map[string]any, error) {
+	var fieldErrors []apperror.FieldError
+	addError := func(field, message string) {
+		fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: field + " " + message})
+	}
+
+	fields := make(map[string]models.ContentField, len(contentType.Fields))
+	values := make(map[string]any, len(contentType.Fields))
+	for _, field := range contentType.Fields {
+		fields[field.Name] = field
+		if value, ok := stored[field.Name]; ok {
+			values[field.Name] = value
+		}
+	}
+
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var links []contentLinks
+	for _, name := range names {
+		key := "data." + name
+		field, ok := fields[name]
+		if !ok {
+			addError(key, "is not a field of "+contentType.Slug)
+			continue
+		}
+		if data[name] == nil {
+			delete(values, name)
+			continue
+		}
+
+		value, ids, message := canonicalContentValue(field, data[name])
+		if message != "" {
+			addError(key, message)
+			continue
+		}
+		values[name] = value
+		if len(ids) > 0 {
+			links = append(links, contentLinks{field: key, target: field.Rules.Target, ids: ids})
+		}
+	}
+
+	for _, field := range contentType.Fields {
+		if _, ok := values[field.Name]; field.Required && !ok {
+			addError("data."+field.Name, "is required")
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return nil, apperror.NewValidationError("Validation failed", fieldErrors)
+	}
+
+	for _, link := range links {
+		found, err := service.linksExist(contentType, link)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			if link.target == "" {
+				addError(link.field, "references a missing media file")
+			} else {
+				addError(link.field, "references a missing "+link.target+" entry")
+			}
+		}
+	}
+	if len(fieldErrors) > 0 {
+		return nil, apperror.NewValidationError("Validation failed", fieldErrors)
+	}
+	return values, nil
+}
+
+// linksExist checks that every ID of a reference or media field points to an existing entry or media file
+func (service *ContentService) linksExist(contentType *models.ContentType, link contentLinks) (bool, error) {
+	ids := slices.Compact(slices.Sorted(slices.Values(link.ids)))
+	if link.target == "" {
+		for _, id := range ids {
+			if _, err := service.mediaRepo.GetByID(id); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return false, nil
+				}
+				return false, apperror.NewDBQueryError(err.Error())
+			}
+		}
+		return true, nil
+	}
+
+	target := contentType
+	if link.target != contentType.Slug {
+		var err error
+		if target, err = service.repo.GetTypeBySlug(link.target); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, apperror.NewDBQueryError(err.Error())
+		}
+	}
+	count, err := service.repo.CountEntries(target.ID, ids)
+	if err != nil {
+		return false, apperror.NewDBQueryError(err.Error())
+	}
+	return count == int64(len(ids)), nil
+}
+
+// canonicalContentValue validates a value decoded from JSON against a field and converts it to its stored form
+// Parameters:
+//   - field: The field the value belongs to
+//   - raw: The decoded JSON value (string, float64, bool or []any)
+//
+// Returns:
+//   - any: The value to store, IDs of references and media are stored as numbers
+//   - []uint: The IDs referenced by reference and media values, checked by the caller
+//   - string: A validation message such as "must be a number", empty when the value is valid
+func canonicalContentValue(field models.ContentField, raw any) (any, []uint, string) {
+	rules := field.Rules
+
+	switch field.Type {
+	case models.ContentFieldList:
+		items, ok := raw.([]any)
+		if !ok {
+			return nil, nil, "must be a list"
+		}
+		if rules.MinItems != nil && len(items) < *rules.MinItems {
+			return nil, nil, fmt.Sprintf("must contain at least %d items", *rules.MinItems)
+		}
+		if rules.MaxItems != nil && len(items) > *rules.MaxItems {
+			return nil, nil, fmt.Sprintf("must contain at most %d items", *rules.MaxItems)
+		}
+		item := models.ContentField{Name: field.Name, Type: rules.ItemType, Rules: rules}
+		values := make([]any, len(items))
+		var ids []uint
+		for i, raw := range items {
+			if raw == nil {
+				return nil, nil, fmt.Sprintf("item %d must not be null", i)
+			}
+			value, itemIDs, message := canonicalContentValue(item, raw)
+			if message != "" {
+				return nil, nil, fmt.Sprintf("item %d %s", i, message)
+			}
+			values[i] = value
+			ids = append(ids, itemIDs...)
+		}
+		return values, ids, ""
+
+	case models.ContentFieldNumber:
+		number, ok := raw.(float64)
+		if !ok {
+			return nil, nil, "must be a number"
+		}
+		if rules.Integer && number != math.Trunc(number) {
+			return nil, nil, "must be a whole number"
+		}
+		if rules.Min != nil && number < *rules.Min {
+			return nil, nil, fmt.Sprintf("must be greater than or equal to %v", *rules.Min)
+		}
+		if rules.Max != nil && number > *rules.Max {
+			return nil, nil, fmt.Sprintf("must be less than or equal to %v", *rules.Max)
+		}
+		return number, nil, ""
+
+	case models.ContentFieldBoolean:
+		boolean, ok := raw.(bool)
+		if !ok {
+			return nil, nil, "must be a boolean value"
+		}
+		return boolean, nil, ""
+
+	case models.ContentFieldReference, models.ContentFieldMedia:
+		id, ok := raw.(float64)
+		if !ok || id < 1 || id != math.Trunc(id) || id > math.MaxUint32 {
+			return nil, nil, "must be the ID of an existing record"
+		}
+		return uint(id), []uint{uint(id)}, ""
+	}
+
+	str, ok := raw.(string)
+	if !ok {
+		return nil, nil, "must be a string"
+	}
+
+	if field.Type == models.ContentFieldDate {
+		parsed, err := time.Parse("2006-01-02", str)
+		if err != nil {
+			return nil, nil, "must be a valid date (YYYY-MM-DD)"
+		}
+		return parsed.Format("2006-01-02"), nil, ""
+	}
+
+	length := utf8.RuneCountInString(str)
+	if rules.MinLength != nil && length < *rules.MinLength {
+		return nil, nil, fmt.Sprintf("must be at least %d characters long", *rules.MinLength)
+	}
+	if rules.MaxLength != nil && length > *rules.MaxLength {
+		return nil, nil, fmt.Sprintf("must be at most %d characters long", *rules.MaxLength)
+	}
+	if field.Type == models.ContentFieldText {
+		if len(rules.Options) > 0 && !slices.Contains(rules.Options, str) {
+			return nil, nil, fmt.Sprintf("must be one of [%s]", strings.Join(rules.Options, " "))
+		}
+		if rules.Pattern != "" {
+			pattern, err := regexp.Compile(rules.Pattern)
+			if err != nil || !pattern.MatchString(str) {
+				return nil, nil, "has an invalid format"
+			}
+		}
+	}
+	return str, nil, ""
+}
@@ -0,0 +1,269 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type ContentServiceTestSuite struct {
+	suite.Suite
+	repo      *mocks.MockContentRepository
+	mediaRepo *mocks.MockMediaRepository
+	service   *services.ContentService
+}
+
+func (s *ContentServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockContentRepository)
+	s.mediaRepo = new(mocks.MockMediaRepository)
+	s.service = services.NewContentService(s.repo, s.mediaRepo)
+}
+
+func (s *ContentServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.mediaRepo.AssertExpectations(s.T())
+}
+
+func (s *ContentServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *ContentServiceTestSuite) validationFields(err error) []string {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	fields := make([]string, len(validationErr.Fields))
+	for i, field := range validationErr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+// productType returns a content type using every kind of field
+func productType() *models.ContentType {
+	return &models.ContentType{
+		ID:   1,
+		Slug: "product",
+		Name: "Product",
+		Fields: []models.ContentField{
+			{Name: "name", Type: models.ContentFieldText, Required: true, Rules: models.ContentFieldRules{MaxLength: ptr(20)}},
+			{Name: "size", Type: models.ContentFieldText, Rules: models.ContentFieldRules{Options: []string{"S", "M", "L"}}},
+			{Name: "description", Type: models.ContentFieldRichText},
+			{Name: "stock", Type: models.ContentFieldNumber, Rules: models.ContentFieldRules{Integer: true, Min: ptr(0.0)}},
+			{Name: "released_on", Type: models.ContentFieldDate},
+			{Name: "active", Type: models.ContentFieldBoolean},
+			{Name: "brand", Type: models.ContentFieldReference, Rules: models.ContentFieldRules{Target: "brand"}},
+			{Name: "related", Type: models.ContentFieldList, Rules: models.ContentFieldRules{ItemType: models.ContentFieldReference, Target: "product", MaxItems: ptr(3)}},
+			{Name: "photo", Type: models.ContentFieldMedia},
+		},
+	}
+}
+
+func (s *ContentServiceTestSuite) TestGetType() {
+	s.Run("Success", func() {
+		s.repo.On("GetTypeBySlug", "product").Return(productType(), nil).Once()
+
+		contentType, err := s.service.GetType("product")
+		s.Require().NoError(err)
+		s.Equal("Product", contentType.Name)
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("GetTypeBySlug", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.GetType("missing")
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Database error", func() {
+		s.repo.On("GetTypeBySlug", "broken").Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.GetType("broken")
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *ContentServiceTestSuite) TestCreateType() {
+	s.Run("Success", func() {
+		contentType := &models.ContentType{Slug: "event", Name: "Event", Fields: []models.ContentField{
+			{Name: "title", Type: models.ContentFieldText, Rules: models.ContentFieldRules{Target: "product"}},
+			{Name: "venue", Type: models.ContentFieldReference, Rules: models.ContentFieldRules{Target: "product"}},
+			{Name: "parent", Type: models.ContentFieldReference, Rules: models.ContentFieldRules{Target: "event"}},
+		}}
+		s.repo.On("GetTypes").Return([]models.ContentType{*productType()}, nil).Once()
+		s.repo.On("CreateType", contentType).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreateType(contentType))
+		s.Empty(contentType.Fields[0].Rules.Target, "only references keep a target")
+	})
+
+	s.Run("Invalid schema", func() {
+		contentType := &models.ContentType{Slug: "Product", Name: "Product", Fields: []models.ContentField{
+			{Name: "title", Type: models.ContentFieldText, Rules: models.ContentFieldRules{Pattern: "("}},
+			{Name: "title", Type: models.ContentFieldNumber, Rules: models.ContentFieldRules{Min: ptr(5.0), Max: ptr(1.0)}},
+			{Name: "owner", Type: models.ContentFieldReference, Rules: models.ContentFieldRules{Target: "missing"}},
+			{Name: "tags", Type: models.ContentFieldList, Rules: models.ContentFieldRules{ItemType: models.ContentFieldList}},
+			{Name: "Color", Type: "color"},
+		}}
+		s.repo.On("GetTypes").Return([]models.ContentType{}, nil).Once()
+
+		err := s.service.CreateType(contentType)
+		s.Equal([]string{
+			"slug",
+			"fields[0].rules.pattern",
+			"fields[1].name",
+			"fields[1].rules.max",
+			"fields[2].rules.target",
+			"fields[3].rules.itemType",
+			"fields[4].name",
+			"fields[4].type",
+		}, s.validationFields(err))
+	})
+
+	s.Run("Slug taken", func() {
+		contentType := &models.ContentType{Slug: "product", Name: "Product", Fields: []models.ContentField{{Name: "title", Type: models.ContentFieldText}}}
+		s.repo.On("GetTypes").Return([]models.ContentType{*productType()}, nil).Once()
+
+		err := s.service.CreateType(contentType)
+		s.Equal([]string{"slug"}, s.validationFields(err))
+	})
+}
+
+func (s *ContentServiceTestSuite) TestDeleteType() {
+	s.Run("Referenced by another type", func() {
+		brand := &models.ContentType{ID: 2, Slug: "brand"}
+		s.repo.On("GetTypes").Return([]models.ContentType{*productType(), *brand}, nil).Once()
+
+		err := s.service.DeleteType(brand)
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+
+	s.Run("Success referencing itself", func() {
+		s.repo.On("GetTypes").Return([]models.ContentType{*productType()}, nil).Once()
+		s.repo.On("DeleteType", uint(1)).Return(nil).Once()
+
+		s.NoError(s.service.DeleteType(productType()))
+	})
+}
+
+func (s *ContentServiceTestSuite) TestCreateEntry() {
+	s.Run("Success", func() {
+		brand := &models.ContentType{ID: 2, Slug: "brand"}
+		s.repo.On("GetTypeBySlug", "brand").Return(brand, nil).Once()
+		s.repo.On("CountEntries", uint(2), []uint{4}).Return(int64(1), nil).Once()
+		s.repo.On("CountEntries", uint(1), []uint{7, 8}).Return(int64(2), nil).Once()
+		s.mediaRepo.On("GetByID", uint(9)).Return(&models.Media{ID: 9}, nil).Once()
+		s.repo.On("CreateEntry", mock.AnythingOfType("*models.ContentEntry")).Return(nil).Once()
+
+		entry, err := s.service.CreateEntry(productType(), map[string]any{
+			"name":        "Chair",
+			"size":        "M",
+			"stock":       float64(12),
+			"released_on": "2030-01-02",
+			"active":      true,
+			"brand":       float64(4),
+			"related":     []any{float64(8), float64(7), float64(8)},
+			"photo":       float64(9),
+		}, 3)
+		s.Require().NoError(err)
+		s.Equal(uint(1), entry.ContentTypeID)
+		s.Equal(uint(3), *entry.AuthorID)
+		s.Equal(uint(4), entry.Data["brand"])
+		s.Equal([]any{uint(8), uint(7), uint(8)}, entry.Data["related"])
+	})
+
+	s.Run("Invalid values", func() {
+		_, err := s.service.CreateEntry(productType(), map[string]any{
+			"size":        "XL",
+			"stock":       1.5,
+			"released_on": "02/01/2030",
+			"active":      "yes",
+			"brand":       "4",
+			"related":     []any{float64(1), float64(2), float64(3), float64(4)},
+			"color":       "red",
+		}, 3)
+		s.Equal([]string{
+			"data.active",
+			"data.brand",
+			"data.color",
+			"data.related",
+			"data.released_on",
+			"data.size",
+			"data.stock",
+			"data.name",
+		}, s.validationFields(err))
+	})
+
+	s.Run("Missing reference", func() {
+		s.mediaRepo.On("GetByID", uint(5)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.CreateEntry(productType(), map[string]any{"name": "Chair", "photo": float64(5)}, 3)
+		s.Equal([]string{"data.photo"}, s.validationFields(err))
+	})
+}
+
+func (s *ContentServiceTestSuite) TestUpdateEntry() {
+	s.Run("Success merges and drops removed fields", func() {
+		stored := &models.ContentEntry{ID: 5, ContentTypeID: 1, Data: map[string]any{"name": "Chair", "stock": float64(3), "legacy": "x"}}
+		s.repo.On("GetEntry", uint(1), uint(5)).Return(stored, nil).Once()
+		s.repo.On("UpdateEntry", stored).Return(nil).Once()
+
+		entry, err := s.service.UpdateEntry(productType(), 5, nil, map[string]any{"stock": nil, "active": false})
+		s.Require().NoError(err)
+		s.Equal(map[string]any{"name": "Chair", "active": false}, entry.Data)
+	})
+
+	s.Run("Stale version", func() {
+		stored := &models.ContentEntry{ID: 5, ContentTypeID: 1, Version: 4, Data: map[string]any{"name": "Chair"}}
+		s.repo.On("GetEntry", uint(1), uint(5)).Return(stored, nil).Once()
+		s.repo.On("UpdateEntry", mock.MatchedBy(func(entry *models.ContentEntry) bool { return entry.Version == 3 })).
+			Return(repositories.ErrVersionConflict).Once()
+
+		version := uint(3)
+		_, err := s.service.UpdateEntry(productType(), 5, &version, map[string]any{"name": "Table"})
+		s.assertCode(err, apperror.ErrVersionConflict)
+	})
+
+	s.Run("Clearing a required field", func() {
+		s.repo.On("GetEntry", uint(1), uint(5)).Return(&models.ContentEntry{ID: 5, Data: map[string]any{"name": "Chair"}}, nil).Once()
+
+		_, err := s.service.UpdateEntry(productType(), 5, nil, map[string]any{"name": nil})
+		s.Equal([]string{"data.name"}, s.validationFields(err))
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("GetEntry", uint(1), uint(6)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.UpdateEntry(productType(), 6, nil, map[string]any{})
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *ContentServiceTestSuite) TestDeleteEntry() {
+	s.Run("Success", func() {
+		s.repo.On("DeleteEntry", uint(1), uint(5)).Return(int64(1), nil).Once()
+		s.NoError(s.service.DeleteEntry(productType(), 5))
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("DeleteEntry", uint(1), uint(6)).Return(int64(0), nil).Once()
+		s.assertCode(s.service.DeleteEntry(productType(), 6), apperror.ErrNotFound)
+	})
+}
+
+func TestContentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentServiceTestSuite))
+}
@@ -0,0 +1,354 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+	"gorm.io/gorm"
+)
+
+// dataExportBatchSize is the maximum number of exports handled by one run of the background jobs
+const dataExportBatchSize = 10
+
+type IDataExportService interface {
+	RequestExport(userID uint, ipAddress string) (*models.DataExport, error)
+	GetExport(userID, id uint) (*models.DataExport, error)
+	OpenExport(userID, id uint) (io.ReadCloser, *models.DataExport, error)
+	ProcessPending(ctx context.Context) error
+	CleanupExpired(ctx context.Context) error
+}
+
+type DataExportService struct {
+	repo         repositories.IDataExportRepository
+	userService  IUserService
+	refreshRepo  repositories.IRefreshTokenRepository
+	auditLogRepo repositories.IAuditLogRepository
+	storage      storage.Storage
+	exportStore  storage.Storage
+	retention    time.Duration
+}
+
+// exportSession is the representation of a refresh token in an export, the token value itself is left out
+type exportSession struct {
+	ID        uint      `json:"id"`
+	IpAddress string    `json:"ipAddress"`
+	UsedCount int64     `json:"usedCount"`
+	ExpiredAt int64     `json:"expiredAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// NewDataExportService creates a new instance of DataExportService
+// Parameters:
+//   - repo: Repository of data export requests
+//   - userService: Service used to load the user together with their attributes
+//   - refreshRepo: Repository of the user's sessions
+//   - auditLogRepo: Repository of the audit trail, exported and used to record requests
+//   - storage: Storage backend holding the avatar files copied into the archives
+//   - exportStore: Private storage backend the archives are written to, they are only downloaded through the API
+//   - retention: How long a generated archive stays available for download
+//
+// Returns:
+//   - *DataExportService: New DataExportService instance initialized with the provided dependencies
+func NewDataExportService(
+	repo repositories.IDataExportRepository,
+	userService IUserService,
+	refreshRepo repositories.IRefreshTokenRepository,
+	auditLogRepo repositories.IAuditLogRepository,
+	storage storage.Storage,
+	exportStore storage.Storage,
+	retention time.Duration,
+) *DataExportService {
+	return &DataExportService{
+		repo:         repo,
+		userService:  userService,
+		refreshRepo:  refreshRepo,
+		auditLogRepo: auditLogRepo,
+		storage:      storage,
+		exportStore:  exportStore,
+		retention:    retention,
+	}
+}
+
+// RequestExport queues the generation of an archive with all personal data of a user
+// Parameters:
+//   - userID: The ID of the user requesting the export
+//   - ipAddress: IP address of the request, stored in the audit trail
+//
+// Returns:
+//   - *models.DataExport: The queued request, or the request already waiting to be processed
+//   - error: DBQuery or DBInsert error
+func (service *DataExportService) RequestExport(userID uint, ipAddress string) (*models.DataExport, error) {
+	active, err := service.repo.FindActiveByUserID(userID)
+	if err == nil {
+		return active, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	export := &models.DataExport{
+		UserID: userID,
+		Status: models.DataExportStatusPending,
+	}
+	if err := service.repo.Create(export); err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+
+	auditLog := &models.AuditLog{
+		ActorID:     userID,
+		Action:      models.AuditActionDataExport,
+		SubjectType: models.AuditSubjectUser,
+		SubjectID:   userID,
+		IpAddress:   ipAddress,
+		Metadata: map[string]any{
+			"exportId": export.ID,
+		},
+	}
+	if err := service.auditLogRepo.Create(auditLog); err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+
+	return export, nil
+}
+
+// GetExport retrieves a data export request of a user
+// Returns NotFound when the request does not exist or belongs to another user
+func (service *DataExportService) GetExport(userID, id uint) (*models.DataExport, error) {
+	export, err := service.repo.GetByID(id)
+	if err != nil || export.UserID != userID {
+		return nil, apperror.NewNotFoundError("Data export not found")
+	}
+	return export, nil
+}
+
+// OpenExport opens the archive of a completed data export for download
+// Parameters:
+//   - userID: The ID of the user downloading the archive
+//   - id: The ID of the data export
+//
+// Returns:
+//   - io.ReadCloser: The content of the archive, the caller must close it
+//   - *models.DataExport: The data export
+//   - error: NotFound if the export does not exist, BadRequest if the archive is not ready or has expired
+func (service *DataExportService) OpenExport(userID, id uint) (io.ReadCloser, *models.DataExport, error) {
+	export, err := service.GetExport(userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if export.Status != models.DataExportStatusCompleted || export.FileKey == nil {
+		return nil, nil, apperror.NewBadRequestError("Data export is not ready for download")
+	}
+	if export.ExpiresAt != nil && export.ExpiresAt.Before(time.Now()) {
+		return nil, nil, apperror.NewBadRequestError("Data export has expired")
+	}
+
+	reader, err := service.exportStore.Get(*export.FileKey)
+	if err != nil {
+		return nil, nil, apperror.NewFileStorageError(err.Error())
+	}
+	return reader, export, nil
+}
+
+// ProcessPending generates the archives of pending data export requests
+// A request that cannot be processed is marked as failed, the others are still processed
+//
+// The function:
+//  1. Marks the request as processing
+//  2. Builds a zip archive with the profile, sessions, audit trail and uploaded files of the user
+//  3. Stores the archive and marks the request as completed until the retention period ends
+func (service *DataExportService) ProcessPending(ctx context.Context) error {
+	exports, err := service.repo.FindPending(dataExportBatchSize)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	for i := range exports {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		export := &exports[i]
+		export.Status = models.DataExportStatusProcessing
+		if err := service.repo.Update(export); err != nil {
+			return apperror.NewDBUpdateError(err.Error())
+		}
+
+		if err := service.process(export); err != nil {
+			logger.Errorf("Failed to generate data export %d: %v", export.ID, err)
+			message := err.Error()
+			if appErr, ok := apperror.ToAppError(err); ok {
+				message = appErr.Message
+			}
+			if len(message) > 255 {
+				message = message[:255]
+			}
+			export.Status = models.DataExportStatusFailed
+			export.Error = &message
+			if err := service.repo.Update(export); err != nil {
+				return apperror.NewDBUpdateError(err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+// process builds and stores the archive of a single data export
+func (service *DataExportService) process(export *models.DataExport) error {
+	archive, err := service.buildArchive(export.UserID)
+	if err != nil {
+		return err
+	}
+
+	name, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("exports/%d/%s.zip", export.UserID, name)
+	if err := service.exportStore.Put(key, bytes.NewReader(archive), "application/zip"); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(service.retention)
+	export.Status = models.DataExportStatusCompleted
+	export.FileKey = &key
+	export.FileSize = int64(len(archive))
+	export.CompletedAt = &now
+	export.ExpiresAt = &expiresAt
+	export.Error = nil
+
+	if err := service.repo.Update(export); err != nil {
+		if deleteErr := service.exportStore.Delete(key); deleteErr != nil {
+			logger.Warnf("Failed to delete data export file %s: %v", key, deleteErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// buildArchive collects the personal data of a user into a zip archive
+func (service *DataExportService) buildArchive(userID uint) ([]byte, error) {
+	user, err := service.userService.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := service.refreshRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]exportSession, 0, len(tokens))
+	for _, token := range tokens {
+		sessions = append(sessions, exportSession{
+			ID:        token.ID,
+			IpAddress: token.IpAddress,
+			UsedCount: token.UsedCount,
+			ExpiredAt: token.ExpiredAt,
+			CreatedAt: token.CreatedAt,
+		})
+	}
+
+	auditLogs, err := service.auditLogRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+
+	documents := []struct {
+		name string
+		data any
+	}{
+		{name: "user.json", data: user},
+		{name: "sessions.json", data: sessions},
+		{name: "audit_logs.json", data: auditLogs},
+	}
+	for _, document := range documents {
+		content, err := json.MarshalIndent(document.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		file, err := writer.Create(document.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write(content); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.AvatarKey != nil {
+		for _, key := range avatarVariantKeys(*user.AvatarKey) {
+			if err := service.copyFile(writer, key, "files/"+path.Base(key)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// copyFile adds a stored file to the archive, missing files are skipped
+func (service *DataExportService) copyFile(writer *zip.Writer, key, name string) error {
+	reader, err := service.storage.Get(key)
+	if err == storage.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	file, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, reader)
+	return err
+}
+
+// CleanupExpired deletes the archives of data exports whose retention period has ended
+func (service *DataExportService) CleanupExpired(ctx context.Context) error {
+	exports, err := service.repo.FindExpired(time.Now(), dataExportBatchSize)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	for i := range exports {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		export := &exports[i]
+		if export.FileKey != nil {
+			if err := service.exportStore.Delete(*export.FileKey); err != nil {
+				logger.Warnf("Failed to delete data export file %s: %v", *export.FileKey, err)
+				continue
+			}
+		}
+
+		export.Status = models.DataExportStatusExpired
+		export.FileKey = nil
+		if err := service.repo.Update(export); err != nil {
+			return apperror.NewDBUpdateError(err.Error())
+		}
+	}
+	return nil
+}
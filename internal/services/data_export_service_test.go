@@ -0,0 +1,207 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type DataExportServiceTestSuite struct {
+	suite.Suite
+	repo         *mocks.MockDataExportRepository
+	userService  *mocks.MockUserService
+	refreshRepo  *mocks.MockRefreshTokenRepository
+	auditLogRepo *mocks.MockAuditLogRepository
+	storage      *mocks.MockStorage
+	exportStore  *mocks.MockStorage
+	service      *services.DataExportService
+}
+
+func (s *DataExportServiceTestSuite) SetupTest() {
+	logger.Init()
+	s.repo = new(mocks.MockDataExportRepository)
+	s.userService = new(mocks.MockUserService)
+	s.refreshRepo = new(mocks.MockRefreshTokenRepository)
+	s.auditLogRepo = new(mocks.MockAuditLogRepository)
+	s.storage = new(mocks.MockStorage)
+	s.exportStore = new(mocks.MockStorage)
+	s.service = services.NewDataExportService(s.repo, s.userService, s.refreshRepo, s.auditLogRepo, s.storage, s.exportStore, 7*24*time.Hour)
+}
+
+func (s *DataExportServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.userService.AssertExpectations(s.T())
+	s.refreshRepo.AssertExpectations(s.T())
+	s.auditLogRepo.AssertExpectations(s.T())
+	s.storage.AssertExpectations(s.T())
+	s.exportStore.AssertExpectations(s.T())
+}
+
+func (s *DataExportServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *DataExportServiceTestSuite) TestRequestExport() {
+	s.Run("Success", func() {
+		s.repo.On("FindActiveByUserID", uint(1)).Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("Create", mock.MatchedBy(func(export *models.DataExport) bool {
+			return export.UserID == 1 && export.Status == models.DataExportStatusPending
+		})).Run(func(args mock.Arguments) { args.Get(0).(*models.DataExport).ID = 3 }).Return(nil).Once()
+		s.auditLogRepo.On("Create", mock.MatchedBy(func(log *models.AuditLog) bool {
+			return log.ActorID == 1 && log.Action == models.AuditActionDataExport && log.Metadata["exportId"] == uint(3)
+		})).Return(nil).Once()
+
+		export, err := s.service.RequestExport(1, "10.0.0.1")
+		s.NoError(err)
+		s.Equal(uint(3), export.ID)
+	})
+
+	s.Run("Returns the active export", func() {
+		active := &models.DataExport{ID: 2, UserID: 1, Status: models.DataExportStatusProcessing}
+		s.repo.On("FindActiveByUserID", uint(1)).Return(active, nil).Once()
+
+		export, err := s.service.RequestExport(1, "10.0.0.1")
+		s.NoError(err)
+		s.Equal(active, export)
+	})
+
+	s.Run("Error query", func() {
+		s.repo.On("FindActiveByUserID", uint(1)).Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.RequestExport(1, "10.0.0.1")
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *DataExportServiceTestSuite) TestOpenExport() {
+	key := "exports/1/archive.zip"
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	s.Run("Success", func() {
+		export := &models.DataExport{ID: 2, UserID: 1, Status: models.DataExportStatusCompleted, FileKey: &key, ExpiresAt: &future}
+		s.repo.On("GetByID", uint(2)).Return(export, nil).Once()
+		s.exportStore.On("Get", key).Return(io.NopCloser(strings.NewReader("zip")), nil).Once()
+
+		reader, result, err := s.service.OpenExport(1, 2)
+		s.NoError(err)
+		s.Equal(export, result)
+		content, _ := io.ReadAll(reader)
+		s.Equal("zip", string(content))
+	})
+
+	s.Run("Error export of another user", func() {
+		s.repo.On("GetByID", uint(2)).Return(&models.DataExport{ID: 2, UserID: 9}, nil).Once()
+
+		_, _, err := s.service.OpenExport(1, 2)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Error not completed", func() {
+		s.repo.On("GetByID", uint(2)).Return(&models.DataExport{ID: 2, UserID: 1, Status: models.DataExportStatusPending}, nil).Once()
+
+		_, _, err := s.service.OpenExport(1, 2)
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+
+	s.Run("Error expired", func() {
+		export := &models.DataExport{ID: 2, UserID: 1, Status: models.DataExportStatusCompleted, FileKey: &key, ExpiresAt: &past}
+		s.repo.On("GetByID", uint(2)).Return(export, nil).Once()
+
+		_, _, err := s.service.OpenExport(1, 2)
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+}
+
+func (s *DataExportServiceTestSuite) TestProcessPending() {
+	s.Run("Success", func() {
+		avatarKey := "avatars/1/abc/avatar.png"
+		user := &models.User{ID: 1, Email: "john@example.com", AvatarKey: &avatarKey}
+		export := models.DataExport{ID: 3, UserID: 1, Status: models.DataExportStatusPending}
+		var archive []byte
+
+		s.repo.On("FindPending", 10).Return([]models.DataExport{export}, nil).Once()
+		s.repo.On("Update", mock.MatchedBy(func(e *models.DataExport) bool { return e.Status == models.DataExportStatusProcessing })).Return(nil).Once()
+		s.userService.On("GetUser", uint(1)).Return(user, nil).Once()
+		s.refreshRepo.On("FindByUserID", uint(1)).Return([]models.RefreshToken{{ID: 4, RefreshToken: "secret-token", IpAddress: "10.0.0.1"}}, nil).Once()
+		s.auditLogRepo.On("FindByUserID", uint(1)).Return([]models.AuditLog{{ID: 5, Action: models.AuditActionDataExport}}, nil).Once()
+		s.storage.On("Get", "avatars/1/abc/avatar.png").Return(io.NopCloser(strings.NewReader("avatar")), nil).Once()
+		s.storage.On("Get", "avatars/1/abc/thumbnail.png").Return(nil, storage.ErrNotFound).Once()
+		s.exportStore.On("Put", mock.MatchedBy(func(key string) bool { return regexp.MustCompile(`^exports/1/[0-9a-f]{32}\.zip$`).MatchString(key) }), mock.Anything, "application/zip").
+			Run(func(args mock.Arguments) { archive, _ = io.ReadAll(args.Get(1).(io.Reader)) }).Return(nil).Once()
+		s.repo.On("Update", mock.MatchedBy(func(e *models.DataExport) bool {
+			return e.Status == models.DataExportStatusCompleted && e.FileKey != nil && e.ExpiresAt != nil && e.FileSize > 0
+		})).Return(nil).Once()
+
+		s.NoError(s.service.ProcessPending(context.Background()))
+
+		reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		s.Require().NoError(err)
+		files := map[string]string{}
+		for _, file := range reader.File {
+			content, _ := file.Open()
+			data, _ := io.ReadAll(content)
+			files[file.Name] = string(data)
+		}
+		s.Contains(files["user.json"], "john@example.com")
+		s.Contains(files["sessions.json"], "10.0.0.1")
+		s.NotContains(files["sessions.json"], "secret-token")
+		s.Contains(files["audit_logs.json"], models.AuditActionDataExport)
+		s.Equal("avatar", files["files/avatar.png"])
+		s.NotContains(files, "files/thumbnail.png")
+	})
+
+	s.Run("Marks failed exports", func() {
+		export := models.DataExport{ID: 3, UserID: 1, Status: models.DataExportStatusPending}
+		s.repo.On("FindPending", 10).Return([]models.DataExport{export}, nil).Once()
+		s.repo.On("Update", mock.MatchedBy(func(e *models.DataExport) bool { return e.Status == models.DataExportStatusProcessing })).Return(nil).Once()
+		s.userService.On("GetUser", uint(1)).Return(&models.User{}, apperror.NewNotFoundError("record not found")).Once()
+		s.repo.On("Update", mock.MatchedBy(func(e *models.DataExport) bool {
+			return e.Status == models.DataExportStatusFailed && e.Error != nil && *e.Error == "record not found"
+		})).Return(nil).Once()
+
+		s.NoError(s.service.ProcessPending(context.Background()))
+	})
+
+	s.Run("Error query", func() {
+		s.repo.On("FindPending", 10).Return([]models.DataExport{}, errors.New("db error")).Once()
+
+		err := s.service.ProcessPending(context.Background())
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *DataExportServiceTestSuite) TestCleanupExpired() {
+	key := "exports/1/archive.zip"
+	export := models.DataExport{ID: 3, UserID: 1, Status: models.DataExportStatusCompleted, FileKey: &key}
+
+	s.repo.On("FindExpired", mock.AnythingOfType("time.Time"), 10).Return([]models.DataExport{export}, nil).Once()
+	s.exportStore.On("Delete", key).Return(nil).Once()
+	s.repo.On("Update", mock.MatchedBy(func(e *models.DataExport) bool {
+		return e.Status == models.DataExportStatusExpired && e.FileKey == nil
+	})).Return(nil).Once()
+
+	s.NoError(s.service.CleanupExpired(context.Background()))
+}
+
+func TestDataExportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(DataExportServiceTestSuite))
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Resources that can be locked for editing, named after their routes
+const (
+	LockResourcePosts   = "posts"
+	LockResourcePages   = "pages"
+	LockResourceContent = "content" // Entries of every content type, their IDs are unique across types
+	LockResourceUsers   = "users"
+)
+
+// LockResources lists every resource that can be locked
+var LockResources = []string{LockResourcePosts, LockResourcePages, LockResourceContent, LockResourceUsers}
+
+// lockPermissions maps the resources to the permission required to lock their records, the one of their update route
+// Authors lock their own posts without it, users are updated by every signed in user so locking them requires none
+var lockPermissions = map[string]string{
+	LockResourcePosts:   constants.PermissionUpdatePosts,
+	LockResourcePages:   constants.PermissionManagePages,
+	LockResourceContent: constants.PermissionManageContent,
+}
+
+// EditLock tells the other users that a record is being edited
+type EditLock struct {
+	Resource   string    `json:"resource"`
+	ResourceID uint      `json:"resourceId"`
+	UserID     uint      `json:"userId"`
+	UserName   string    `json:"userName"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"` // The lock is dropped at this time unless it is renewed
+}
+
+// The scripts compare the holder and change the lock in one step, so a lock taken over meanwhile is never changed
+var (
+	// acquireLockScript takes a free lock or extends the lock of the same user, it returns 0 when another user holds it
+	acquireLockScript = redis.NewScript(`
+local holder = redis.call('HGET', KEYS[1], 'user_id')
+if holder and holder ~= ARGV[1] then
+	return 0
+end
+if not holder then
+	redis.call('HSET', KEYS[1], 'user_id', ARGV[1], 'user_name', ARGV[2], 'acquired_at', ARGV[3])
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+	// renewLockScript extends the lock of a user, it returns 0 when the user does not hold it
+	renewLockScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'user_id') ~= ARGV[1] then
+	return 0
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+return 1
+`)
+	// releaseLockScript removes the lock of a user, it returns 0 when another user holds it
+	releaseLockScript = redis.NewScript(`
+local holder = redis.call('HGET', KEYS[1], 'user_id')
+if holder and holder ~= ARGV[1] then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+return 1
+`)
+)
+
+type IEditLockService interface {
+	GetLock(resource string, id uint) (*EditLock, error)
+	Acquire(resource string, id, userID uint) (*EditLock, error)
+	Renew(resource string, id, userID uint) (*EditLock, error)
+	Release(resource string, id, userID uint) error
+	ForceRelease(resource string, id uint) error
+	CheckEditable(resource string, id, userID uint) error
+}
+
+// EditLockService keeps the pessimistic edit locks of records in Redis
+// A lock expires unless its holder renews it, so a closed editor never keeps a record locked for long
+type EditLockService struct {
+	userRepo          repositories.IUserRepository
+	postRepo          repositories.IPostRepository
+	pageRepo          repositories.IPageRepository
+	contentRepo       repositories.IContentRepository
+	permissionService IPermissionService
+	client            redis.Cmdable
+	ttl               time.Duration
+	ctx               context.Context
+}
+
+// NewEditLockService creates a new instance of EditLockService
+// Parameters:
+//   - userRepo: Resolves the names of the users holding locks, and the locked users
+//   - postRepo: Resolves the locked posts
+//   - pageRepo: Resolves the locked pages
+//   - contentRepo: Resolves the locked content entries
+//   - permissionService: Checks that a user may update the records they lock
+//   - client: The Redis client storing the locks
+//   - ttl: How long a lock is kept without being renewed
+//
+// Returns:
+//   - *EditLockService: New EditLockService instance
+func NewEditLockService(
+	userRepo repositories.IUserRepository,
+	postRepo repositories.IPostRepository,
+	pageRepo repositories.IPageRepository,
+	contentRepo repositories.IContentRepository,
+	permissionService IPermissionService,
+	client redis.Cmdable,
+	ttl time.Duration,
+) *EditLockService {
+	return &EditLockService{
+		userRepo:          userRepo,
+		postRepo:          postRepo,
+		pageRepo:          pageRepo,
+		contentRepo:       contentRepo,
+		permissionService: permissionService,
+		client:            client,
+		ttl:               ttl,
+		ctx:               context.Background(),
+	}
+}
+
+// GetLock retrieves the lock of a record
+// Parameters:
+//   - resource: One of LockResources
+//   - id: The ID of the record
+//
+// Returns:
+//   - *EditLock: The lock, nil when the record is not locked
+//   - error: NotFound error if the resource cannot be locked, CacheGet error if the lock cannot be read
+func (service *EditLockService) GetLock(resource string, id uint) (*EditLock, error) {
+	if !slices.Contains(LockResources, resource) {
+		return nil, apperror.NewNotFoundError("Resource cannot be locked")
+	}
+
+	key := editLockKey(resource, id)
+	fields, err := service.client.HGetAll(service.ctx, key).Result()
+	if err != nil {
+		return nil, apperror.NewCacheGetError(err.Error())
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	ttl, err := service.client.PTTL(service.ctx, key).Result()
+	if err != nil {
+		return nil, apperror.NewCacheGetError(err.Error())
+	}
+	// The lock expired between both reads
+	if ttl <= 0 {
+		return nil, nil
+	}
+
+	userID, _ := strconv.ParseUint(fields["user_id"], 10, 64)
+	acquiredAt, _ := strconv.ParseInt(fields["acquired_at"], 10, 64)
+	return &EditLock{
+		Resource:   resource,
+		ResourceID: id,
+		UserID:     uint(userID),
+		UserName:   fields["user_name"],
+		AcquiredAt: time.Unix(acquiredAt, 0).UTC(),
+		ExpiresAt:  time.Now().Add(ttl).UTC().Truncate(time.Second),
+	}, nil
+}
+
+// Acquire locks a record for a user, acquiring a lock the user already holds renews it
+// Only the users allowed to update the record may lock it
+// Parameters:
+//   - resource: One of LockResources
+//   - id: The ID of the record
+//   - userID: The ID of the user opening the editor
+//
+// Returns:
+//   - *EditLock: The lock held by the user
+//   - error: ResourceLocked error if another user holds the lock, NotFound error if the resource cannot be locked,
+//     the record or the user does not exist, Forbidden error if the user may not update the record,
+//     CacheSet error if the lock cannot be stored
+func (service *EditLockService) Acquire(resource string, id, userID uint) (*EditLock, error) {
+	if err := service.checkLockable(resource, id, userID); err != nil {
+		return nil, err
+	}
+	user, err := service.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("User not found")
+	}
+
+	acquired, err := acquireLockScript.Run(service.ctx, service.client, []string{editLockKey(resource, id)},
+		userID, user.Name, time.Now().Unix(), service.ttl.Milliseconds()).Int()
+	if err != nil {
+		return nil, apperror.NewCacheSetError(err.Error())
+	}
+	if acquired == 0 {
+		return nil, service.lockedError(resource, id)
+	}
+	return service.heldLock(resource, id)
+}
+
+// Renew extends the lock of a user, editors call it periodically while they are open
+// Parameters:
+//   - resource: One of LockResources
+//   - id: The ID of the record
+//   - userID: The ID of the user holding the lock
+//
+// Returns:
+//   - *EditLock: The renewed lock
+//   - error: ResourceLocked error if another user took over the expired lock, NotFound error if the lock expired,
+//     the resource cannot be locked or the record was deleted, Forbidden error if the user may no longer update the record,
+//     CacheSet error if the lock cannot be renewed
+func (service *EditLockService) Renew(resource string, id, userID uint) (*EditLock, error) {
+	if err := service.checkLockable(resource, id, userID); err != nil {
+		return nil, err
+	}
+
+	renewed, err := renewLockScript.Run(service.ctx, service.client, []string{editLockKey(resource, id)},
+		userID, service.ttl.Milliseconds()).Int()
+	if err != nil {
+		return nil, apperror.NewCacheSetError(err.Error())
+	}
+	if renewed == 0 {
+		lock, err := service.GetLock(resource, id)
+		if err != nil {
+			return nil, err
+		}
+		if lock != nil {
+			return nil, service.lockedError(resource, id)
+		}
+		// The changes made since the lock expired are still protected by the version of the record
+		return nil, apperror.NewNotFoundError("The edit lock has expired, acquire it again")
+	}
+	return service.heldLock(resource, id)
+}
+
+// Release removes the lock of a user when they close the editor, releasing a lock that expired is not an error
+// A user only ever removes their own lock, so it is released even after they lost the permission to update the record
+// Parameters:
+//   - resource: One of LockResources
+//   - id: The ID of the record
+//   - userID: The ID of the user holding the lock
+//
+// Returns:
+//   - error: ResourceLocked error if another user holds the lock, NotFound error if the resource cannot be locked,
+//     CacheDelete error if the lock cannot be removed
+func (service *EditLockService) Release(resource string, id, userID uint) error {
+	if !slices.Contains(LockResources, resource) {
+		return apperror.NewNotFoundError("Resource cannot be locked")
+	}
+
+	released, err := releaseLockScript.Run(service.ctx, service.client, []string{editLockKey(resource, id)}, userID).Int()
+	if err != nil {
+		return apperror.NewCacheDeleteError(err.Error())
+	}
+	if released == 0 {
+		return service.lockedError(resource, id)
+	}
+	return nil
+}
+
+// ForceRelease removes the lock of a record whoever holds it, e.g. when an editor was left open
+// The holder notices on their next renewal, their unsaved changes are rejected if the record is saved meanwhile
+// Parameters:
+//   - resource: One of LockResources
+//   - id: The ID of the record
+//
+// Returns:
+//   - error: NotFound error if the resource cannot be locked or the record is not locked, CacheDelete error if the lock cannot be removed
+func (service *EditLockService) ForceRelease(resource string, id uint) error {
+	if !slices.Contains(LockResources, resource) {
+		return apperror.NewNotFoundError("Resource cannot be locked")
+	}
+
+	deleted, err := service.client.Del(service.ctx, editLockKey(resource, id)).Result()
+	if err != nil {
+		return apperror.NewCacheDeleteError(err.Error())
+	}
+	if deleted == 0 {
+		return apperror.NewNotFoundError("Edit lock not found")
+	}
+	return nil
+}
+
+// CheckEditable rejects the changes of a user to a record locked by another user
+// Records are editable when Redis cannot be reached, the version of the record still rejects stale changes
+// Parameters:
+//   - resource: One of LockResources
+//   - id: The ID of the record
+//   - userID: The ID of the user saving the record
+//
+// Returns:
+//   - error: ResourceLocked error if another user holds the lock
+func (service *EditLockService) CheckEditable(resource string, id, userID uint) error {
+	lock, err := service.GetLock(resource, id)
+	if err != nil {
+		logger.Warnf("Failed to check the edit lock of %s %d: %v", resource, id, err)
+		return nil
+	}
+	if lock != nil && lock.UserID != userID {
+		return lockedError(lock)
+	}
+	return nil
+}
+
+// checkLockable rejects the locks of unknown records and of users lacking the permission of the update route of the record
+func (service *EditLockService) checkLockable(resource string, id, userID uint) error {
+	if !slices.Contains(LockResources, resource) {
+		return apperror.NewNotFoundError("Resource cannot be locked")
+	}
+
+	var err error
+	var authorID uint
+	switch resource {
+	case LockResourcePosts:
+		var post *models.Post
+		if post, err = service.postRepo.GetByID(id); err == nil {
+			authorID = post.AuthorID
+		}
+	case LockResourcePages:
+		_, err = service.pageRepo.GetByID(id)
+	case LockResourceContent:
+		_, err = service.contentRepo.GetEntryByID(id)
+	default:
+		_, err = service.userRepo.GetByID(id)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewNotFoundError("Record not found")
+		}
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	permission, ok := lockPermissions[resource]
+	if !ok || (authorID != 0 && authorID == userID) {
+		return nil
+	}
+	allowed, err := service.permissionService.HasPermission(userID, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperror.NewForbiddenError("You do not have permission to perform this action")
+	}
+	return nil
+}
+
+// heldLock reads back a lock just acquired or renewed
+func (service *EditLockService) heldLock(resource string, id uint) (*EditLock, error) {
+	lock, err := service.GetLock(resource, id)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		return nil, apperror.NewNotFoundError("The edit lock has expired, acquire it again")
+	}
+	return lock, nil
+}
+
+// lockedError describes the lock held by another user
+func (service *EditLockService) lockedError(resource string, id uint) error {
+	lock, err := service.GetLock(resource, id)
+	if err != nil || lock == nil {
+		return apperror.NewResourceLockedError("This record is being edited by another user")
+	}
+	return lockedError(lock)
+}
+
+func lockedError(lock *EditLock) error {
+	return apperror.NewResourceLockedError(fmt.Sprintf("This record is being edited by %s until %s", lock.UserName, lock.ExpiresAt.Format(time.RFC3339)))
+}
+
+// editLockKey returns the Redis key of the lock of a record, e.g. EDIT_LOCK_posts_12
+func editLockKey(resource string, id uint) string {
+	return constants.EDIT_LOCK + resource + "_" + strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,201 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type EditLockServiceTestSuite struct {
+	suite.Suite
+	repo              *mocks.MockUserRepository
+	postRepo          *mocks.MockPostRepository
+	pageRepo          *mocks.MockPageRepository
+	contentRepo       *mocks.MockContentRepository
+	permissionService *mocks.MockPermissionService
+	server            *miniredis.Miniredis
+	service           *services.EditLockService
+}
+
+func (s *EditLockServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockUserRepository)
+	s.repo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Name: "John"}, nil).Maybe()
+	s.repo.On("GetByID", uint(2)).Return(&models.User{ID: 2, Name: "Jane"}, nil).Maybe()
+	s.repo.On("GetByID", uint(3)).Return(&models.User{ID: 3, Name: "Bob"}, nil).Maybe()
+	s.postRepo = new(mocks.MockPostRepository)
+	s.postRepo.On("GetByID", uint(5)).Return(&models.Post{ID: 5, AuthorID: 3}, nil).Maybe()
+	s.pageRepo = new(mocks.MockPageRepository)
+	s.pageRepo.On("GetByID", uint(5)).Return(&models.Page{ID: 5}, nil).Maybe()
+	s.contentRepo = new(mocks.MockContentRepository)
+	s.contentRepo.On("GetEntryByID", uint(7)).Return(&models.ContentEntry{ID: 7}, nil).Maybe()
+	// John and Jane are editors, Bob may only edit his own posts
+	s.permissionService = new(mocks.MockPermissionService)
+	s.permissionService.On("HasPermission", uint(1), mock.Anything).Return(true, nil).Maybe()
+	s.permissionService.On("HasPermission", uint(2), mock.Anything).Return(true, nil).Maybe()
+	s.permissionService.On("HasPermission", uint(3), mock.Anything).Return(false, nil).Maybe()
+	s.server = miniredis.RunT(s.T())
+	client := redis.NewClient(&redis.Options{Addr: s.server.Addr()})
+	s.T().Cleanup(func() { _ = client.Close() })
+	s.service = services.NewEditLockService(s.repo, s.postRepo, s.pageRepo, s.contentRepo, s.permissionService, client, 2*time.Minute)
+}
+
+func (s *EditLockServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *EditLockServiceTestSuite) TestAcquire() {
+	lock, err := s.service.Acquire(services.LockResourcePosts, 5, 1)
+	s.Require().NoError(err)
+	s.Equal(uint(1), lock.UserID)
+	s.Equal("John", lock.UserName)
+	s.Equal(2*time.Minute, s.server.TTL("EDIT_LOCK_posts_5"))
+
+	// Acquiring the lock again keeps it for the same user
+	s.server.FastForward(time.Minute)
+	lock, err = s.service.Acquire(services.LockResourcePosts, 5, 1)
+	s.Require().NoError(err)
+	s.Equal(uint(1), lock.UserID)
+	s.Equal(2*time.Minute, s.server.TTL("EDIT_LOCK_posts_5"))
+
+	_, err = s.service.Acquire(services.LockResourcePosts, 5, 2)
+	s.assertCode(err, apperror.ErrResourceLocked)
+	s.Contains(err.Error(), "John")
+
+	// Locks of other records and resources are independent
+	_, err = s.service.Acquire(services.LockResourcePages, 5, 2)
+	s.NoError(err)
+
+	_, err = s.service.Acquire("categories", 5, 1)
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func (s *EditLockServiceTestSuite) TestAcquireExpiredLock() {
+	_, err := s.service.Acquire(services.LockResourcePosts, 5, 1)
+	s.Require().NoError(err)
+
+	s.server.FastForward(3 * time.Minute)
+
+	lock, err := s.service.Acquire(services.LockResourcePosts, 5, 2)
+	s.Require().NoError(err)
+	s.Equal("Jane", lock.UserName)
+}
+
+func (s *EditLockServiceTestSuite) TestAcquireUnknownUser() {
+	s.repo.On("GetByID", uint(9)).Return((*models.User)(nil), errors.New("record not found")).Once()
+	s.permissionService.On("HasPermission", uint(9), constants.PermissionUpdatePosts).Return(true, nil).Once()
+
+	_, err := s.service.Acquire(services.LockResourcePosts, 5, 9)
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func (s *EditLockServiceTestSuite) TestAcquireUnknownRecord() {
+	s.postRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := s.service.Acquire(services.LockResourcePosts, 9, 1)
+	s.assertCode(err, apperror.ErrNotFound)
+	s.False(s.server.Exists("EDIT_LOCK_posts_9"))
+}
+
+func (s *EditLockServiceTestSuite) TestAcquireWithoutPermission() {
+	// The permissions of the update routes are required, authors lock their own posts without it
+	_, err := s.service.Acquire(services.LockResourcePosts, 5, 3)
+	s.NoError(err)
+	s.Require().NoError(s.service.Release(services.LockResourcePosts, 5, 3))
+
+	s.postRepo.On("GetByID", uint(6)).Return(&models.Post{ID: 6, AuthorID: 1}, nil).Once()
+	_, err = s.service.Acquire(services.LockResourcePosts, 6, 3)
+	s.assertCode(err, apperror.ErrForbidden)
+
+	_, err = s.service.Acquire(services.LockResourcePages, 5, 3)
+	s.assertCode(err, apperror.ErrForbidden)
+	s.False(s.server.Exists("EDIT_LOCK_pages_5"))
+
+	_, err = s.service.Acquire(services.LockResourceContent, 7, 3)
+	s.assertCode(err, apperror.ErrForbidden)
+	s.permissionService.AssertCalled(s.T(), "HasPermission", uint(3), constants.PermissionManageContent)
+}
+
+func (s *EditLockServiceTestSuite) TestRenew() {
+	_, err := s.service.Acquire(services.LockResourceUsers, 3, 1)
+	s.Require().NoError(err)
+	s.server.FastForward(time.Minute)
+
+	_, err = s.service.Renew(services.LockResourceUsers, 3, 1)
+	s.Require().NoError(err)
+	s.Equal(2*time.Minute, s.server.TTL("EDIT_LOCK_users_3"))
+
+	_, err = s.service.Renew(services.LockResourceUsers, 3, 2)
+	s.assertCode(err, apperror.ErrResourceLocked)
+
+	s.server.FastForward(3 * time.Minute)
+	_, err = s.service.Renew(services.LockResourceUsers, 3, 1)
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func (s *EditLockServiceTestSuite) TestRenewWithoutPermission() {
+	s.server.HSet("EDIT_LOCK_pages_5", "user_id", "3", "user_name", "Bob", "acquired_at", "0")
+	s.server.SetTTL("EDIT_LOCK_pages_5", time.Minute)
+
+	_, err := s.service.Renew(services.LockResourcePages, 5, 3)
+	s.assertCode(err, apperror.ErrForbidden)
+	s.Equal(time.Minute, s.server.TTL("EDIT_LOCK_pages_5"))
+
+	// The lock can still be released
+	s.NoError(s.service.Release(services.LockResourcePages, 5, 3))
+}
+
+func (s *EditLockServiceTestSuite) TestRelease() {
+	_, err := s.service.Acquire(services.LockResourceContent, 7, 1)
+	s.Require().NoError(err)
+
+	err = s.service.Release(services.LockResourceContent, 7, 2)
+	s.assertCode(err, apperror.ErrResourceLocked)
+
+	s.Require().NoError(s.service.Release(services.LockResourceContent, 7, 1))
+	s.False(s.server.Exists("EDIT_LOCK_content_7"))
+
+	// Releasing a lock that is gone is not an error
+	s.NoError(s.service.Release(services.LockResourceContent, 7, 1))
+}
+
+func (s *EditLockServiceTestSuite) TestForceRelease() {
+	_, err := s.service.Acquire(services.LockResourcePosts, 5, 1)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.service.ForceRelease(services.LockResourcePosts, 5))
+	s.False(s.server.Exists("EDIT_LOCK_posts_5"))
+
+	err = s.service.ForceRelease(services.LockResourcePosts, 5)
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func (s *EditLockServiceTestSuite) TestCheckEditable() {
+	s.NoError(s.service.CheckEditable(services.LockResourcePosts, 5, 2))
+
+	_, err := s.service.Acquire(services.LockResourcePosts, 5, 1)
+	s.Require().NoError(err)
+
+	s.NoError(s.service.CheckEditable(services.LockResourcePosts, 5, 1))
+	s.assertCode(s.service.CheckEditable(services.LockResourcePosts, 5, 2), apperror.ErrResourceLocked)
+
+	// Records stay editable while Redis is down
+	s.server.Close()
+	s.NoError(s.service.CheckEditable(services.LockResourcePosts, 5, 2))
+}
+
+func TestEditLockServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(EditLockServiceTestSuite))
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/feed"
+)
+
+// FeedConfig describes the website publishing the feeds
+type FeedConfig struct {
+	Title       string
+	Description string
+	SiteURL     string // Base URL of the website, the posts are linked as SiteURL/posts/slug
+	Limit       int    // Number of posts in a feed
+}
+
+// FeedQuery selects the posts of a feed
+type FeedQuery struct {
+	CategorySlug string // Posts of the category and its subcategories
+	TagSlug      string // Posts with the tag
+	Locale       string // Locale of the content, see ITranslationService
+	FeedURL      string // URL the feed is served from
+}
+
+type IFeedService interface {
+	GetFeed(query FeedQuery) (*feed.Feed, error)
+}
+
+type FeedService struct {
+	postRepo           repositories.IPostRepository
+	categoryService    ICategoryService
+	tagService         ITagService
+	translationService ITranslationService
+	renderService      IRenderService
+	config             FeedConfig
+}
+
+// NewFeedService creates a new instance of FeedService
+// Parameters:
+//   - postRepo: The repository of the published posts
+//   - categoryService: Resolves the category of a feed
+//   - tagService: Resolves the tag of a feed
+//   - translationService: Localizes the posts
+//   - renderService: Renders the bodies of the posts to HTML
+//   - config: The website publishing the feeds
+//
+// Returns:
+//   - *FeedService: New FeedService instance
+func NewFeedService(
+	postRepo repositories.IPostRepository,
+	categoryService ICategoryService,
+	tagService ITagService,
+	translationService ITranslationService,
+	renderService IRenderService,
+	config FeedConfig,
+) *FeedService {
+	config.SiteURL = strings.TrimSuffix(config.SiteURL, "/")
+	return &FeedService{
+		postRepo:           postRepo,
+		categoryService:    categoryService,
+		tagService:         tagService,
+		translationService: translationService,
+		renderService:      renderService,
+		config:             config,
+	}
+}
+
+// GetFeed retrieves the most recently published posts as a feed
+// Parameters:
+//   - query: The category or tag of the posts and the locale of their content
+//
+// Returns:
+//   - *feed.Feed: The feed, ready to be encoded by the feed package
+//   - error: NotFound error if the category or tag does not exist, DBQuery error if the posts or their media cannot be loaded
+func (service *FeedService) GetFeed(query FeedQuery) (*feed.Feed, error) {
+	result := &feed.Feed{
+		Title:       service.config.Title,
+		Description: service.config.Description,
+		Link:        service.config.SiteURL,
+		FeedURL:     query.FeedURL,
+	}
+
+	var filter repositories.PostFilter
+	if query.CategorySlug != "" {
+		category, err := service.categoryService.GetCategoryBySlug(query.CategorySlug)
+		if err != nil {
+			return nil, err
+		}
+		filter.CategoryIDs, err = service.categoryService.GetSubtreeIDs(category.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.Title += " - " + category.Name
+		result.Link += "/categories/" + category.Slug
+	}
+	if query.TagSlug != "" {
+		tag, err := service.tagService.GetTagBySlug(query.TagSlug)
+		if err != nil {
+			return nil, err
+		}
+		filter.TagID = tag.ID
+		result.Title += " - " + tag.Name
+		result.Link += "/tags/" + tag.Slug
+	}
+
+	pagination, err := service.postRepo.PaginatePublished(1, service.config.Limit, filter)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	posts, _ := pagination.Data.([]models.Post)
+
+	if _, err := service.translationService.LocalizePosts(posts, query.Locale); err != nil {
+		return nil, err
+	}
+	result.Language = query.Locale
+
+	result.Items = make([]feed.Item, len(posts))
+	for i := range posts {
+		rendered, err := service.renderService.Render(posts[i].Body, posts[i].BodyFormat)
+		if err != nil {
+			return nil, err
+		}
+		result.Items[i] = service.toItem(&posts[i], rendered.HTML)
+		if result.Items[i].Updated.After(result.Updated) {
+			result.Updated = result.Items[i].Updated
+		}
+	}
+	// An empty feed has nothing to date it by, it changes when a post is published
+	if result.Updated.IsZero() {
+		result.Updated = time.Unix(0, 0).UTC()
+	}
+
+	return result, nil
+}
+
+// toItem converts a published post with its rendered body into an item of a feed
+func (service *FeedService) toItem(post *models.Post, content string) feed.Item {
+	published := post.CreatedAt
+	if post.PublishedAt != nil {
+		published = *post.PublishedAt
+	}
+	item := feed.Item{
+		ID:        service.itemID(post.ID, published),
+		Title:     post.Title,
+		Link:      service.config.SiteURL + "/posts/" + url.PathEscape(post.Slug),
+		Content:   content,
+		Published: published.UTC(),
+		Updated:   post.UpdatedAt.UTC(),
+	}
+	if post.Excerpt != nil {
+		item.Summary = *post.Excerpt
+	}
+	if post.Author != nil {
+		item.Author = post.Author.Name
+	}
+	if post.Category != nil {
+		item.Categories = append(item.Categories, post.Category.Name)
+	}
+	for _, tag := range post.Tags {
+		item.Categories = append(item.Categories, tag.Name)
+	}
+	if item.Updated.Before(item.Published) {
+		item.Updated = item.Published
+	}
+	return item
+}
+
+// itemID returns a tag URI (RFC 4151) identifying a post, it outlives changes of the title and slug
+func (service *FeedService) itemID(postID uint, published time.Time) string {
+	host := "localhost"
+	if siteURL, err := url.Parse(service.config.SiteURL); err == nil && siteURL.Hostname() != "" {
+		host = siteURL.Hostname()
+	}
+	return fmt.Sprintf("tag:%s,%s:posts/%d", host, published.UTC().Format(time.DateOnly), postID)
+}
@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type FeedServiceTestSuite struct {
+	suite.Suite
+	postRepo           *mocks.MockPostRepository
+	categoryService    *mocks.MockCategoryService
+	tagService         *mocks.MockTagService
+	translationService *mocks.MockTranslationService
+	renderService      *mocks.MockRenderService
+	service            *services.FeedService
+}
+
+func (s *FeedServiceTestSuite) SetupTest() {
+	s.postRepo = new(mocks.MockPostRepository)
+	s.categoryService = new(mocks.MockCategoryService)
+	s.tagService = new(mocks.MockTagService)
+	s.translationService = new(mocks.MockTranslationService)
+	s.renderService = new(mocks.MockRenderService)
+	s.service = services.NewFeedService(s.postRepo, s.categoryService, s.tagService, s.translationService, s.renderService, services.FeedConfig{
+		Title:   "Blog",
+		SiteURL: "https://example.com/",
+		Limit:   20,
+	})
+}
+
+func (s *FeedServiceTestSuite) TearDownTest() {
+	s.postRepo.AssertExpectations(s.T())
+	s.categoryService.AssertExpectations(s.T())
+	s.tagService.AssertExpectations(s.T())
+	s.translationService.AssertExpectations(s.T())
+	s.renderService.AssertExpectations(s.T())
+}
+
+func (s *FeedServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *FeedServiceTestSuite) TestGetFeed() {
+	publishedAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	excerpt := "Short"
+
+	s.Run("Success", func() {
+		posts := []models.Post{
+			{
+				ID: 7, Title: "Hello", Slug: "xin chao", Excerpt: &excerpt, Body: "Hi", BodyFormat: models.BodyFormatMarkdown, PublishedAt: &publishedAt, UpdatedAt: publishedAt.Add(time.Hour),
+				Author: &models.User{Name: "Author"}, Category: &models.Category{Name: "News"}, Tags: []models.Tag{{Name: "Go"}},
+			},
+			{ID: 6, Title: "Older", Slug: "older", Body: "<p>Old</p>", BodyFormat: models.BodyFormatHTML, PublishedAt: &publishedAt, UpdatedAt: publishedAt.Add(-time.Hour)},
+		}
+		s.postRepo.On("PaginatePublished", 1, 20, repositories.PostFilter{}).Return(&utils.Pagination{Data: posts}, nil).Once()
+		s.translationService.On("LocalizePosts", posts, "vi").Return([]string{"vi", "en"}, nil).Once()
+		s.renderService.On("Render", "Hi", models.BodyFormatMarkdown).Return(&markup.Result{HTML: "<p>Hi</p>\n"}, nil).Once()
+		s.renderService.On("Render", "<p>Old</p>", models.BodyFormatHTML).Return(&markup.Result{HTML: "<p>Old</p>"}, nil).Once()
+
+		result, err := s.service.GetFeed(services.FeedQuery{Locale: "vi", FeedURL: "https://api.example.com/feeds/rss.xml"})
+		s.Require().NoError(err)
+		s.Equal("Blog", result.Title)
+		s.Equal("https://example.com", result.Link)
+		s.Equal("vi", result.Language)
+		s.Equal(publishedAt.Add(time.Hour), result.Updated)
+		s.Require().Len(result.Items, 2)
+		s.Equal("tag:example.com,2030-01-02:posts/7", result.Items[0].ID)
+		s.Equal("https://example.com/posts/xin%20chao", result.Items[0].Link)
+		s.Equal("<p>Hi</p>\n", result.Items[0].Content, "the content is the rendered body")
+		s.Equal("Short", result.Items[0].Summary)
+		s.Equal("Author", result.Items[0].Author)
+		s.Equal([]string{"News", "Go"}, result.Items[0].Categories)
+		s.Equal(publishedAt, result.Items[1].Updated, "an item is never updated before it is published")
+	})
+
+	s.Run("Category and tag", func() {
+		s.categoryService.On("GetCategoryBySlug", "news").Return(&models.Category{ID: 1, Name: "News", Slug: "news"}, nil).Once()
+		s.categoryService.On("GetSubtreeIDs", uint(1)).Return([]uint{1, 2}, nil).Once()
+		s.tagService.On("GetTagBySlug", "go").Return(&models.Tag{ID: 5, Name: "Go", Slug: "go"}, nil).Once()
+		s.postRepo.On("PaginatePublished", 1, 20, repositories.PostFilter{CategoryIDs: []uint{1, 2}, TagID: 5}).
+			Return(&utils.Pagination{Data: []models.Post{}}, nil).Once()
+		s.translationService.On("LocalizePosts", mock.Anything, "en").Return([]string{}, nil).Once()
+
+		result, err := s.service.GetFeed(services.FeedQuery{CategorySlug: "news", TagSlug: "go", Locale: "en"})
+		s.Require().NoError(err)
+		s.Equal("Blog - News - Go", result.Title)
+		s.Empty(result.Items)
+		s.Equal(time.Unix(0, 0).UTC(), result.Updated)
+	})
+
+	s.Run("Unknown category", func() {
+		s.categoryService.On("GetCategoryBySlug", "missing").Return(nil, apperror.NewNotFoundError("Category not found")).Once()
+
+		_, err := s.service.GetFeed(services.FeedQuery{CategorySlug: "missing"})
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Database error", func() {
+		s.postRepo.On("PaginatePublished", 1, 20, repositories.PostFilter{}).Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.GetFeed(services.FeedQuery{})
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func TestFeedServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedServiceTestSuite))
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+)
+
+type IGlobalBlockService interface {
+	PaginateGlobalBlocks(page, limit int, search string) (*utils.Pagination, error)
+	GetGlobalBlock(id uint) (*models.GlobalBlock, error)
+	CreateGlobalBlock(block *models.GlobalBlock) error
+	UpdateGlobalBlock(block *models.GlobalBlock) error
+	DeleteGlobalBlock(id uint) error
+}
+
+type GlobalBlockService struct {
+	repo repositories.IGlobalBlockRepository
+}
+
+// NewGlobalBlockService creates a new instance of GlobalBlockService
+// Parameters:
+//   - repo: Repository of global blocks
+//
+// Returns:
+//   - *GlobalBlockService: New GlobalBlockService instance initialized with the provided repository
+func NewGlobalBlockService(repo repositories.IGlobalBlockRepository) *GlobalBlockService {
+	return &GlobalBlockService{
+		repo: repo,
+	}
+}
+
+// PaginateGlobalBlocks retrieves a page of global blocks ordered by name
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of global blocks per page
+//   - search: Only global blocks whose name contains this text, empty for every global block
+//
+// Returns:
+//   - *utils.Pagination: The page of global blocks
+//   - error: DBQuery error if the global blocks cannot be loaded
+func (service *GlobalBlockService) PaginateGlobalBlocks(page, limit int, search string) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginateGlobalBlocks(page, limit, search)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetGlobalBlock retrieves a global block by its ID
+func (service *GlobalBlockService) GetGlobalBlock(id uint) (*models.GlobalBlock, error) {
+	block, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return block, nil
+}
+
+// CreateGlobalBlock stores a new global block
+// Parameters:
+//   - block: The global block to create
+//
+// Returns:
+//   - error: ValidationError if the name is taken or a block is invalid, DBInsert error otherwise
+func (service *GlobalBlockService) CreateGlobalBlock(block *models.GlobalBlock) error {
+	if err := service.validate(block); err != nil {
+		return err
+	}
+	if err := service.repo.Create(block); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateGlobalBlock saves an existing global block, every post and page referencing it shows the change
+// Parameters:
+//   - block: The global block to save
+//
+// Returns:
+//   - error: ValidationError if the name is taken or a block is invalid, DBUpdate error otherwise
+func (service *GlobalBlockService) UpdateGlobalBlock(block *models.GlobalBlock) error {
+	if err := service.validate(block); err != nil {
+		return err
+	}
+	if err := service.repo.Update(block); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteGlobalBlock deletes a global block, the references left in bodies are skipped when they are delivered
+func (service *GlobalBlockService) DeleteGlobalBlock(id uint) error {
+	deleted, err := service.repo.Delete(id)
+	if err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	if deleted == 0 {
+		return apperror.NewNotFoundError("Global block not found")
+	}
+	return nil
+}
+
+// validate checks the blocks of a global block and that its name is free
+// Global blocks cannot reference each other, so resolving a body never loops
+func (service *GlobalBlockService) validate(block *models.GlobalBlock) error {
+	block.Name = strings.TrimSpace(block.Name)
+	if block.Blocks == nil {
+		block.Blocks = []blocks.Block{}
+	}
+
+	if fields := blockFieldErrors("blocks", blocks.Validate(block.Blocks, false)); len(fields) > 0 {
+		return apperror.NewValidationError("Validation failed", fields)
+	}
+
+	taken, err := service.repo.NameExists(block.Name, block.ID)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if taken {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "name", Message: "name is already taken"},
+		})
+	}
+	return nil
+}
+
+// validateBody checks the body of a post or page in the blocks format, bodies in other formats are free text
+func validateBody(body, format string) error {
+	if format != models.BodyFormatBlocks {
+		return nil
+	}
+
+	list, err := blocks.Parse(body)
+	if err != nil {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "body", Message: "body must be a JSON array of blocks"},
+		})
+	}
+	if fields := blockFieldErrors("body", blocks.Validate(list, true)); len(fields) > 0 {
+		return apperror.NewValidationError("Validation failed", fields)
+	}
+	return nil
+}
+
+// blockFieldErrors converts the problems of a list of blocks into the errors of the field holding it, e.g. "body[2].data.text"
+func blockFieldErrors(field string, problems []blocks.Problem) []apperror.FieldError {
+	fields := make([]apperror.FieldError, len(problems))
+	for i, problem := range problems {
+		fields[i] = apperror.FieldError{Field: field + problem.Path, Message: problem.Message}
+	}
+	return fields
+}
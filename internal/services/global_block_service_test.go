@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type GlobalBlockServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockGlobalBlockRepository
+	service *services.GlobalBlockService
+}
+
+func (s *GlobalBlockServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockGlobalBlockRepository)
+	s.service = services.NewGlobalBlockService(s.repo)
+}
+
+func (s *GlobalBlockServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *GlobalBlockServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *GlobalBlockServiceTestSuite) validationFields(err error) []string {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	fields := make([]string, len(validationErr.Fields))
+	for i, field := range validationErr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func (s *GlobalBlockServiceTestSuite) TestCreateGlobalBlock() {
+	s.Run("Success", func() {
+		block := &models.GlobalBlock{Name: " Newsletter ", Blocks: []blocks.Block{
+			{Type: blocks.TypeCallToAction, Data: map[string]any{"label": "Subscribe", "url": "/newsletter"}},
+		}}
+		s.repo.On("NameExists", "Newsletter", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", block).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreateGlobalBlock(block))
+		s.Equal("Newsletter", block.Name)
+	})
+
+	s.Run("Invalid blocks", func() {
+		block := &models.GlobalBlock{Name: "Footer", Blocks: []blocks.Block{
+			{Type: blocks.TypeGlobal, Data: map[string]any{"global_block_id": float64(2)}},
+			{Type: blocks.TypeEmbed, Data: map[string]any{"url": "javascript:alert(1)"}},
+		}}
+
+		err := s.service.CreateGlobalBlock(block)
+		s.Equal([]string{"blocks[0].type", "blocks[1].data.url"}, s.validationFields(err))
+	})
+
+	s.Run("Name taken", func() {
+		block := &models.GlobalBlock{Name: "Footer"}
+		s.repo.On("NameExists", "Footer", uint(0)).Return(true, nil).Once()
+
+		err := s.service.CreateGlobalBlock(block)
+		s.Equal([]string{"name"}, s.validationFields(err))
+		s.NotNil(block.Blocks, "an empty list is stored instead of null")
+	})
+}
+
+func (s *GlobalBlockServiceTestSuite) TestUpdateGlobalBlock() {
+	s.Run("Success", func() {
+		block := &models.GlobalBlock{ID: 4, Name: "Footer", Blocks: []blocks.Block{}}
+		s.repo.On("NameExists", "Footer", uint(4)).Return(false, nil).Once()
+		s.repo.On("Update", block).Return(nil).Once()
+
+		s.NoError(s.service.UpdateGlobalBlock(block))
+	})
+
+	s.Run("Database error", func() {
+		block := &models.GlobalBlock{ID: 4, Name: "Footer", Blocks: []blocks.Block{}}
+		s.repo.On("NameExists", "Footer", uint(4)).Return(false, nil).Once()
+		s.repo.On("Update", block).Return(errors.New("db error")).Once()
+
+		s.assertCode(s.service.UpdateGlobalBlock(block), apperror.ErrDBUpdate)
+	})
+}
+
+func (s *GlobalBlockServiceTestSuite) TestGetGlobalBlock() {
+	s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := s.service.GetGlobalBlock(9)
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func (s *GlobalBlockServiceTestSuite) TestDeleteGlobalBlock() {
+	s.Run("Success", func() {
+		s.repo.On("Delete", uint(4)).Return(int64(1), nil).Once()
+		s.NoError(s.service.DeleteGlobalBlock(4))
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("Delete", uint(5)).Return(int64(0), nil).Once()
+		s.assertCode(s.service.DeleteGlobalBlock(5), apperror.ErrNotFound)
+	})
+}
+
+func TestGlobalBlockServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(GlobalBlockServiceTestSuite))
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IImpersonationService interface {
+	Start(impersonatorID, userID uint, reason, ipAddress string) (*ImpersonationResponse, error)
+	Stop(impersonatorID, userID uint, sessionID, ipAddress string) error
+}
+
+type ImpersonationService struct {
+	userRepo          repositories.IUserRepository
+	roleRepo          repositories.IRoleRepository
+	auditLogRepo      repositories.IAuditLogRepository
+	permissionService IPermissionService
+	jwtService        IJWTService
+	redisService      IRedisService
+	ttl               time.Duration
+}
+
+// ImpersonationResponse is returned when an impersonation session starts
+type ImpersonationResponse struct {
+	AccessToken    JwtResult    `json:"accessToken"`
+	ImpersonatorID uint         `json:"impersonatorId"`
+	User           *models.User `json:"user"`
+}
+
+// NewImpersonationService creates a new instance of ImpersonationService
+// Parameters:
+//   - userRepo: User repository used to load the impersonated user
+//   - roleRepo: Role repository used to compare the permissions of both users
+//   - auditLogRepo: Repository where start and stop of sessions are recorded
+//   - permissionService: Service used to refuse impersonating privileged users
+//   - jwtService: Service issuing the impersonation token
+//   - redisService: Service keeping track of active sessions
+//   - ttl: Lifetime of an impersonation token
+//
+// Returns:
+//   - *ImpersonationService: New ImpersonationService instance initialized with the provided dependencies
+func NewImpersonationService(
+	userRepo repositories.IUserRepository,
+	roleRepo repositories.IRoleRepository,
+	auditLogRepo repositories.IAuditLogRepository,
+	permissionService IPermissionService,
+	jwtService IJWTService,
+	redisService IRedisService,
+	ttl time.Duration,
+) *ImpersonationService {
+	return &ImpersonationService{
+		userRepo:          userRepo,
+		roleRepo:          roleRepo,
+		auditLogRepo:      auditLogRepo,
+		permissionService: permissionService,
+		jwtService:        jwtService,
+		redisService:      redisService,
+		ttl:               ttl,
+	}
+}
+
+// Start begins an impersonation session and issues a short-lived access token for it
+// Parameters:
+//   - impersonatorID: The ID of the support user starting the session
+//   - userID: The ID of the user to impersonate
+//   - reason: Why the session is needed (e.g. a ticket number), stored in the audit trail
+//   - ipAddress: IP address of the request, stored in the audit trail
+//
+// Returns:
+//   - *ImpersonationResponse: The access token and the impersonated user
+//   - error: BadRequest when impersonating oneself, NotFound when the user does not exist,
+//     Forbidden when the user may impersonate others themselves or holds a permission the impersonator lacks
+//
+// The function:
+//  1. Issues an access token carrying both user IDs, no refresh token is issued
+//  2. Records the start of the session in the audit trail
+//  3. Registers the session in Redis so it can be stopped before the token expires
+func (service *ImpersonationService) Start(impersonatorID, userID uint, reason, ipAddress string) (*ImpersonationResponse, error) {
+	if impersonatorID == userID {
+		return nil, apperror.NewBadRequestError("You cannot impersonate yourself")
+	}
+
+	user, err := service.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	// Privileged users cannot be impersonated, this prevents chaining sessions to gain access
+	privileged, err := service.permissionService.HasPermission(user.ID, constants.PermissionImpersonateUsers)
+	if err != nil {
+		return nil, err
+	}
+	if privileged {
+		return nil, apperror.NewForbiddenError("This user cannot be impersonated")
+	}
+
+	// A session cannot grant the impersonator permissions they do not already have
+	targetPermissions, err := service.roleRepo.GetPermissionNamesByUserID(user.ID)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	if len(targetPermissions) > 0 {
+		ownPermissions, err := service.roleRepo.GetPermissionNamesByUserID(impersonatorID)
+		if err != nil {
+			return nil, apperror.NewDBQueryError(err.Error())
+		}
+		for _, name := range targetPermissions {
+			if !slices.Contains(ownPermissions, name) {
+				return nil, apperror.NewForbiddenError("This user cannot be impersonated")
+			}
+		}
+	}
+
+	sessionID, err := utils.GenerateSecureToken(16)
+	if err != nil {
+		return nil, apperror.NewInternalError(err.Error())
+	}
+	token, err := service.jwtService.GenerateImpersonationToken(user.ID, impersonatorID, sessionID, service.ttl)
+	if err != nil {
+		return nil, apperror.NewInternalError(err.Error())
+	}
+
+	auditLog := &models.AuditLog{
+		ActorID:     impersonatorID,
+		Action:      models.AuditActionImpersonationStart,
+		SubjectType: models.AuditSubjectUser,
+		SubjectID:   user.ID,
+		IpAddress:   ipAddress,
+		Metadata: map[string]any{
+			"sessionId": sessionID,
+			"reason":    reason,
+			"expiresAt": token.ExpiresAt,
+		},
+	}
+	if err := service.auditLogRepo.Create(auditLog); err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+
+	if err := service.redisService.Set(constants.IMPERSONATION+sessionID, strconv.Itoa(int(impersonatorID)), service.ttl); err != nil {
+		return nil, err
+	}
+
+	return &ImpersonationResponse{
+		AccessToken:    *token,
+		ImpersonatorID: impersonatorID,
+		User:           user,
+	}, nil
+}
+
+// Stop ends an impersonation session, the token of the session is rejected afterwards
+// Parameters:
+//   - impersonatorID: The ID of the support user who started the session
+//   - userID: The ID of the impersonated user
+//   - sessionID: The session ID carried by the impersonation token
+//   - ipAddress: IP address of the request, stored in the audit trail
+//
+// Returns:
+//   - error: nil if successful, otherwise the cache or database error
+func (service *ImpersonationService) Stop(impersonatorID, userID uint, sessionID, ipAddress string) error {
+	if err := service.redisService.Delete(constants.IMPERSONATION + sessionID); err != nil {
+		return err
+	}
+
+	auditLog := &models.AuditLog{
+		ActorID:     impersonatorID,
+		Action:      models.AuditActionImpersonationStop,
+		SubjectType: models.AuditSubjectUser,
+		SubjectID:   userID,
+		IpAddress:   ipAddress,
+		Metadata: map[string]any{
+			"sessionId": sessionID,
+		},
+	}
+	if err := service.auditLogRepo.Create(auditLog); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type ImpersonationServiceTestSuite struct {
+	suite.Suite
+	userRepo          *mocks.MockUserRepository
+	roleRepo          *mocks.MockRoleRepository
+	auditLogRepo      *mocks.MockAuditLogRepository
+	permissionService *mocks.MockPermissionService
+	jwtService        *mocks.MockJWTService
+	redisService      *mocks.MockRedisService
+	service           *services.ImpersonationService
+}
+
+func (s *ImpersonationServiceTestSuite) SetupTest() {
+	s.userRepo = new(mocks.MockUserRepository)
+	s.roleRepo = new(mocks.MockRoleRepository)
+	s.auditLogRepo = new(mocks.MockAuditLogRepository)
+	s.permissionService = new(mocks.MockPermissionService)
+	s.jwtService = new(mocks.MockJWTService)
+	s.redisService = new(mocks.MockRedisService)
+	s.service = services.NewImpersonationService(s.userRepo, s.roleRepo, s.auditLogRepo, s.permissionService, s.jwtService, s.redisService, 15*time.Minute)
+}
+
+func (s *ImpersonationServiceTestSuite) TearDownTest() {
+	s.userRepo.AssertExpectations(s.T())
+	s.roleRepo.AssertExpectations(s.T())
+	s.auditLogRepo.AssertExpectations(s.T())
+	s.permissionService.AssertExpectations(s.T())
+	s.jwtService.AssertExpectations(s.T())
+	s.redisService.AssertExpectations(s.T())
+}
+
+func (s *ImpersonationServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *ImpersonationServiceTestSuite) TestStart() {
+	s.Run("Success", func() {
+		user := &models.User{ID: 5, Email: "customer@example.com"}
+		var sessionID string
+		s.userRepo.On("GetByID", uint(5)).Return(user, nil).Once()
+		s.permissionService.On("HasPermission", uint(5), constants.PermissionImpersonateUsers).Return(false, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(5)).Return([]string{constants.PermissionUpdatePosts}, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(1)).Return([]string{constants.PermissionUpdatePosts, constants.PermissionImpersonateUsers}, nil).Once()
+		s.jwtService.On("GenerateImpersonationToken", uint(5), uint(1), mock.AnythingOfType("string"), 15*time.Minute).
+			Run(func(args mock.Arguments) { sessionID = args.String(2) }).
+			Return(&services.JwtResult{Token: "token", ExpiresAt: 100}, nil).Once()
+		s.auditLogRepo.On("Create", mock.MatchedBy(func(log *models.AuditLog) bool {
+			return log.ActorID == 1 && log.SubjectID == 5 && log.Action == models.AuditActionImpersonationStart &&
+				log.Metadata["reason"] == "TICKET-1" && log.Metadata["sessionId"] == sessionID && log.IpAddress == "10.0.0.1"
+		})).Return(nil).Once()
+		s.redisService.On("Set", mock.MatchedBy(func(key string) bool { return key == constants.IMPERSONATION+sessionID }), "1", 15*time.Minute).Return(nil).Once()
+
+		res, err := s.service.Start(1, 5, "TICKET-1", "10.0.0.1")
+		s.NoError(err)
+		s.Equal("token", res.AccessToken.Token)
+		s.Equal(uint(1), res.ImpersonatorID)
+		s.Equal(user, res.User)
+		s.Len(sessionID, 32)
+	})
+
+	s.Run("Error impersonating oneself", func() {
+		_, err := s.service.Start(1, 1, "TICKET-1", "10.0.0.1")
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+
+	s.Run("Error user not found", func() {
+		s.userRepo.On("GetByID", uint(9)).Return(&models.User{}, errors.New("record not found")).Once()
+
+		_, err := s.service.Start(1, 9, "TICKET-1", "10.0.0.1")
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Error privileged user", func() {
+		s.userRepo.On("GetByID", uint(2)).Return(&models.User{ID: 2}, nil).Once()
+		s.permissionService.On("HasPermission", uint(2), constants.PermissionImpersonateUsers).Return(true, nil).Once()
+
+		_, err := s.service.Start(1, 2, "TICKET-1", "10.0.0.1")
+		s.assertCode(err, apperror.ErrForbidden)
+	})
+
+	s.Run("Error user holding a permission the impersonator lacks", func() {
+		s.userRepo.On("GetByID", uint(3)).Return(&models.User{ID: 3}, nil).Once()
+		s.permissionService.On("HasPermission", uint(3), constants.PermissionImpersonateUsers).Return(false, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(3)).Return([]string{constants.PermissionInviteUsers}, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(1)).Return([]string{constants.PermissionImpersonateUsers}, nil).Once()
+
+		_, err := s.service.Start(1, 3, "TICKET-1", "10.0.0.1")
+		s.assertCode(err, apperror.ErrForbidden)
+	})
+
+	s.Run("Error permissions of the user", func() {
+		s.userRepo.On("GetByID", uint(4)).Return(&models.User{ID: 4}, nil).Once()
+		s.permissionService.On("HasPermission", uint(4), constants.PermissionImpersonateUsers).Return(false, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(4)).Return([]string(nil), errors.New("db error")).Once()
+
+		_, err := s.service.Start(1, 4, "TICKET-1", "10.0.0.1")
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+
+	s.Run("Error audit log", func() {
+		s.userRepo.On("GetByID", uint(5)).Return(&models.User{ID: 5}, nil).Once()
+		s.permissionService.On("HasPermission", uint(5), constants.PermissionImpersonateUsers).Return(false, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(5)).Return([]string{}, nil).Once()
+		s.jwtService.On("GenerateImpersonationToken", uint(5), uint(1), mock.Anything, 15*time.Minute).
+			Return(&services.JwtResult{Token: "token"}, nil).Once()
+		s.auditLogRepo.On("Create", mock.Anything).Return(errors.New("db error")).Once()
+
+		_, err := s.service.Start(1, 5, "TICKET-1", "10.0.0.1")
+		s.assertCode(err, apperror.ErrDBInsert)
+	})
+}
+
+func (s *ImpersonationServiceTestSuite) TestStop() {
+	s.Run("Success", func() {
+		s.redisService.On("Delete", constants.IMPERSONATION+"session").Return(nil).Once()
+		s.auditLogRepo.On("Create", mock.MatchedBy(func(log *models.AuditLog) bool {
+			return log.ActorID == 1 && log.SubjectID == 5 && log.Action == models.AuditActionImpersonationStop
+		})).Return(nil).Once()
+
+		s.NoError(s.service.Stop(1, 5, "session", "10.0.0.1"))
+	})
+
+	s.Run("Error cache", func() {
+		s.redisService.On("Delete", constants.IMPERSONATION+"broken").Return(apperror.NewCacheDeleteError("redis down")).Once()
+
+		err := s.service.Stop(1, 5, "broken", "10.0.0.1")
+		s.assertCode(err, apperror.ErrCacheDelete)
+	})
+}
+
+func TestImpersonationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationServiceTestSuite))
+}
@@ -0,0 +1,342 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"gorm.io/gorm"
+)
+
+// InviteInput holds the details of the user to invite
+type InviteInput struct {
+	Email   string
+	Name    string
+	RoleIDs []uint
+}
+
+type IInvitationService interface {
+	Invite(inviterID uint, input InviteInput) (*models.Invitation, error)
+	PaginateInvitations(page, limit int, status string) (*utils.Pagination, error)
+	Resend(id uint) (*models.Invitation, error)
+	Revoke(id uint) (*models.Invitation, error)
+	Accept(token, password string) (*models.User, error)
+}
+
+type InvitationService struct {
+	repo          repositories.IInvitationRepository
+	userRepo      repositories.IUserRepository
+	roleRepo      repositories.IRoleRepository
+	mailerService IMailerService
+	bcryptService IBcryptService
+	ttl           time.Duration
+}
+
+// NewInvitationService creates a new instance of InvitationService
+// Parameters:
+//   - repo: Repository of invitations
+//   - userRepo: User repository where invited users are created
+//   - roleRepo: Role repository used to validate and assign the roles of invited users
+//   - mailerService: Service sending the invitation emails
+//   - bcryptService: Service hashing the password chosen by the invited user
+//   - ttl: How long an invitation can be accepted after it was sent
+//
+// Returns:
+//   - *InvitationService: New InvitationService instance initialized with the provided dependencies
+func NewInvitationService(
+	repo repositories.IInvitationRepository,
+	userRepo repositories.IUserRepository,
+	roleRepo repositories.IRoleRepository,
+	mailerService IMailerService,
+	bcryptService IBcryptService,
+	ttl time.Duration,
+) *InvitationService {
+	return &InvitationService{
+		repo:          repo,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		mailerService: mailerService,
+		bcryptService: bcryptService,
+		ttl:           ttl,
+	}
+}
+
+// hashInvitationToken returns the hash stored for an invitation token, the token itself is only sent by email
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newInvitationToken generates the token of an invitation link from the cryptographic random number generator
+// Returns the plain token to send by email and the hash to store
+func newInvitationToken() (string, string, error) {
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return "", "", apperror.NewInternalError(fmt.Sprintf("error generating invitation token: %+v", err))
+	}
+	return token, hashInvitationToken(token), nil
+}
+
+// checkGrantable rejects roles holding a permission the inviter does not have, an invitation cannot escalate privileges
+func (service *InvitationService) checkGrantable(inviterID uint, roles []models.Role) error {
+	var granted []string
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			granted = append(granted, permission.Name)
+		}
+	}
+	if len(granted) == 0 {
+		return nil
+	}
+
+	held, err := service.roleRepo.GetPermissionNamesByUserID(inviterID)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	for _, name := range granted {
+		if !slices.Contains(held, name) {
+			return apperror.NewForbiddenError("You cannot grant a role with permissions you do not have")
+		}
+	}
+	return nil
+}
+
+// Invite creates a pending user with the given roles and emails them an invitation link
+// Parameters:
+//   - inviterID: The ID of the user sending the invitation
+//   - input: Email, name and roles of the invited user
+//
+// Returns:
+//   - *models.Invitation: The new invitation with the invited user
+//   - error: ValidationError if the email belongs to an active user or a role does not exist,
+//     Forbidden error if a role holds a permission the inviter does not have,
+//     database errors otherwise, Internal error if the email cannot be sent
+//
+// The function:
+//  1. Reuses the pending user when the email was invited before, its previous invitations are revoked
+//  2. Creates the user, assigns the roles and stores the invitation in one transaction
+//  3. Sends the invitation email with the plain token
+func (service *InvitationService) Invite(inviterID uint, input InviteInput) (*models.Invitation, error) {
+	roleIDs := slices.Compact(slices.Sorted(slices.Values(input.RoleIDs)))
+	roles, err := service.roleRepo.FindByIDs(roleIDs)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	if len(roles) != len(roleIDs) {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "role_ids", Message: "role_ids contains an unknown role"},
+		})
+	}
+	if err := service.checkGrantable(inviterID, roles); err != nil {
+		return nil, err
+	}
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := service.userRepo.FindByField("email", input.Email)
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		secret, err := utils.GenerateSecureToken(32)
+		if err != nil {
+			return nil, apperror.NewInternalError(fmt.Sprintf("error generating password: %+v", err))
+		}
+		password, err := service.bcryptService.HashPassword(secret)
+		if err != nil {
+			return nil, apperror.NewPasswordHashFailedError(err.Error())
+		}
+		// The password is unusable until the invitation is accepted
+		user = &models.User{
+			Email:    input.Email,
+			Name:     input.Name,
+			Password: password,
+			Status:   models.UserStatusInvited,
+		}
+	case err != nil:
+		return nil, apperror.NewDBQueryError(err.Error())
+	case user.Status != models.UserStatusInvited:
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "email", Message: "email is already taken"},
+		})
+	default:
+		user.Name = input.Name
+		if err := service.userRepo.Update(user); err != nil {
+			return nil, apperror.NewDBUpdateError(err.Error())
+		}
+	}
+
+	now := time.Now()
+	invitation := &models.Invitation{
+		InvitedBy: inviterID,
+		TokenHash: tokenHash,
+		Status:    models.InvitationStatusPending,
+		ExpiresAt: now.Add(service.ttl),
+		SentAt:    now,
+	}
+
+	err = service.userRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		if user.ID == 0 {
+			if _, err := service.userRepo.CreateWithTx(tx, user); err != nil {
+				return err
+			}
+		}
+		if err := service.roleRepo.AssignRolesWithTx(tx, user.ID, roleIDs); err != nil {
+			return err
+		}
+		if err := service.repo.RevokePendingWithTx(tx, user.ID, now); err != nil {
+			return err
+		}
+		invitation.UserID = user.ID
+		return service.repo.CreateWithTx(tx, invitation)
+	})
+	if err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+
+	if err := service.mailerService.SendMailInvitation(user, service.inviterName(inviterID), token, invitation.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	invitation.User = user
+	return invitation, nil
+}
+
+// PaginateInvitations retrieves a page of invitations, newest first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of invitations per page
+//   - status: Only invitations with this status are returned, empty returns all
+//
+// Returns:
+//   - *utils.Pagination: The page of invitations
+//   - error: DBQuery error if the invitations cannot be loaded
+func (service *InvitationService) PaginateInvitations(page, limit int, status string) (*utils.Pagination, error) {
+	pagination, err := service.repo.Paginate(page, limit, status)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// Resend sends a pending invitation again with a new token and a new expiry time
+// The link of the previous email stops working
+// Parameters:
+//   - id: The ID of the invitation
+//
+// Returns:
+//   - *models.Invitation: The updated invitation
+//   - error: NotFound if the invitation does not exist, BadRequest if it is not pending
+func (service *InvitationService) Resend(id uint) (*models.Invitation, error) {
+	invitation, err := service.getPending(id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	invitation.TokenHash = tokenHash
+	invitation.ExpiresAt = now.Add(service.ttl)
+	invitation.SentAt = now
+	if err := service.repo.Update(invitation); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+
+	if err := service.mailerService.SendMailInvitation(invitation.User, service.inviterName(invitation.InvitedBy), token, invitation.ExpiresAt); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// Revoke cancels a pending invitation, its link stops working
+// Parameters:
+//   - id: The ID of the invitation
+//
+// Returns:
+//   - *models.Invitation: The revoked invitation
+//   - error: NotFound if the invitation does not exist, BadRequest if it is not pending
+func (service *InvitationService) Revoke(id uint) (*models.Invitation, error) {
+	invitation, err := service.getPending(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	invitation.Status = models.InvitationStatusRevoked
+	invitation.RevokedAt = &now
+	if err := service.repo.Update(invitation); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+	return invitation, nil
+}
+
+// Accept activates the account of an invited user with the password they chose
+// Parameters:
+//   - token: The token from the invitation email
+//   - password: The password chosen by the user
+//
+// Returns:
+//   - *models.User: The activated user
+//   - error: BadRequest if the token is unknown, already used, revoked or expired
+func (service *InvitationService) Accept(token, password string) (*models.User, error) {
+	invitation, err := service.repo.FindByTokenHash(hashInvitationToken(token))
+	if err != nil || invitation.User == nil {
+		return nil, apperror.NewBadRequestError("Invalid invitation token")
+	}
+	if invitation.Status != models.InvitationStatusPending {
+		return nil, apperror.NewBadRequestError("Invitation is no longer valid")
+	}
+	if invitation.ExpiresAt.Before(time.Now()) {
+		return nil, apperror.NewTokenExpiredError("Invitation has expired")
+	}
+
+	hashedPassword, err := service.bcryptService.HashPassword(password)
+	if err != nil {
+		return nil, apperror.NewPasswordHashFailedError("Failed to hash password")
+	}
+
+	now := time.Now()
+	user := invitation.User
+	user.Password = hashedPassword
+	user.Status = models.UserStatusActive
+	invitation.Status = models.InvitationStatusAccepted
+	invitation.AcceptedAt = &now
+
+	if err := service.repo.Accept(invitation, user); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+	return user, nil
+}
+
+// getPending loads an invitation that can still be resent or revoked
+func (service *InvitationService) getPending(id uint) (*models.Invitation, error) {
+	invitation, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	if invitation.User == nil {
+		return nil, apperror.NewNotFoundError("Invited user not found")
+	}
+	if invitation.Status != models.InvitationStatusPending {
+		return nil, apperror.NewBadRequestError("Only pending invitations can be changed")
+	}
+	return invitation, nil
+}
+
+// inviterName returns the name shown in the invitation email
+func (service *InvitationService) inviterName(inviterID uint) string {
+	inviter, err := service.userRepo.GetByID(inviterID)
+	if err != nil {
+		return "An administrator"
+	}
+	return inviter.Name
+}
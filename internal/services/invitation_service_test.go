@@ -0,0 +1,272 @@
+package services_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type InvitationServiceTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	repo          *mocks.MockInvitationRepository
+	userRepo      *mocks.MockUserRepository
+	roleRepo      *mocks.MockRoleRepository
+	mailerService *mocks.MockMailerService
+	bcryptService *mocks.MockBcryptService
+	service       *services.InvitationService
+}
+
+func (s *InvitationServiceTestSuite) SetupTest() {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	s.Require().NoError(err)
+	s.db = db
+
+	s.repo = new(mocks.MockInvitationRepository)
+	s.userRepo = new(mocks.MockUserRepository)
+	s.roleRepo = new(mocks.MockRoleRepository)
+	s.mailerService = new(mocks.MockMailerService)
+	s.bcryptService = new(mocks.MockBcryptService)
+	s.service = services.NewInvitationService(s.repo, s.userRepo, s.roleRepo, s.mailerService, s.bcryptService, 72*time.Hour)
+}
+
+func (s *InvitationServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.userRepo.AssertExpectations(s.T())
+	s.roleRepo.AssertExpectations(s.T())
+	s.mailerService.AssertExpectations(s.T())
+	s.bcryptService.AssertExpectations(s.T())
+}
+
+func (s *InvitationServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *InvitationServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+func (s *InvitationServiceTestSuite) TestInvite() {
+	input := services.InviteInput{Email: "new@example.com", Name: "New", RoleIDs: []uint{2, 1, 2}}
+
+	s.Run("Success new user", func() {
+		editor := models.Role{ID: 2, Permissions: []models.Permission{{Name: "users.invite"}}}
+		s.roleRepo.On("FindByIDs", []uint{1, 2}).Return([]models.Role{{ID: 1}, editor}, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(1)).Return([]string{"users.invite", "users.impersonate"}, nil).Once()
+		s.userRepo.On("FindByField", "email", "new@example.com").Return((*models.User)(nil), gorm.ErrRecordNotFound).Once()
+		s.bcryptService.On("HashPassword", mock.Anything).Return("unusable", nil).Once()
+		s.userRepo.On("GetDB").Return(s.db).Once()
+		s.userRepo.On("CreateWithTx", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+			return user.Email == "new@example.com" && user.Status == models.UserStatusInvited && user.Password == "unusable"
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.User).ID = 5
+		}).Return(&models.User{ID: 5}, nil).Once()
+		s.roleRepo.On("AssignRolesWithTx", mock.Anything, uint(5), []uint{1, 2}).Return(nil).Once()
+		s.repo.On("RevokePendingWithTx", mock.Anything, uint(5), mock.Anything).Return(nil).Once()
+		s.repo.On("CreateWithTx", mock.Anything, mock.MatchedBy(func(inv *models.Invitation) bool {
+			return inv.UserID == 5 && inv.InvitedBy == 1 && inv.Status == models.InvitationStatusPending && len(inv.TokenHash) == 64
+		})).Return(nil).Once()
+		s.userRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Name: "Admin"}, nil).Once()
+		s.mailerService.On("SendMailInvitation", mock.Anything, "Admin", mock.MatchedBy(func(token string) bool {
+			return regexp.MustCompile("^[0-9a-f]{64}$").MatchString(token)
+		}), mock.Anything).Return(nil).Once()
+
+		invitation, err := s.service.Invite(1, input)
+		s.Require().NoError(err)
+		s.Equal(uint(5), invitation.User.ID)
+		s.WithinDuration(time.Now().Add(72*time.Hour), invitation.ExpiresAt, time.Minute)
+	})
+
+	s.Run("Success re-invite pending user", func() {
+		existing := &models.User{ID: 5, Email: "new@example.com", Name: "Old", Status: models.UserStatusInvited}
+		s.roleRepo.On("FindByIDs", []uint{1, 2}).Return([]models.Role{{ID: 1}, {ID: 2}}, nil).Once()
+		s.userRepo.On("FindByField", "email", "new@example.com").Return(existing, nil).Once()
+		s.userRepo.On("Update", existing).Return(nil).Once()
+		s.userRepo.On("GetDB").Return(s.db).Once()
+		s.roleRepo.On("AssignRolesWithTx", mock.Anything, uint(5), []uint{1, 2}).Return(nil).Once()
+		s.repo.On("RevokePendingWithTx", mock.Anything, uint(5), mock.Anything).Return(nil).Once()
+		s.repo.On("CreateWithTx", mock.Anything, mock.Anything).Return(nil).Once()
+		s.userRepo.On("GetByID", uint(1)).Return((*models.User)(nil), gorm.ErrRecordNotFound).Once()
+		s.mailerService.On("SendMailInvitation", existing, "An administrator", mock.Anything, mock.Anything).Return(nil).Once()
+
+		invitation, err := s.service.Invite(1, input)
+		s.Require().NoError(err)
+		s.Equal("New", invitation.User.Name)
+	})
+
+	s.Run("Error unknown role", func() {
+		s.roleRepo.On("FindByIDs", []uint{1, 2}).Return([]models.Role{{ID: 1}}, nil).Once()
+
+		_, err := s.service.Invite(1, input)
+		s.assertFieldError(err, "role_ids")
+	})
+
+	s.Run("Error role with permissions the inviter lacks", func() {
+		admin := models.Role{ID: 2, Permissions: []models.Permission{{Name: "users.invite"}, {Name: "users.impersonate"}}}
+		s.roleRepo.On("FindByIDs", []uint{1, 2}).Return([]models.Role{{ID: 1}, admin}, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(1)).Return([]string{"users.invite"}, nil).Once()
+
+		_, err := s.service.Invite(1, input)
+		s.assertCode(err, apperror.ErrForbidden)
+	})
+
+	s.Run("Error permissions of the inviter", func() {
+		editor := models.Role{ID: 2, Permissions: []models.Permission{{Name: "users.invite"}}}
+		s.roleRepo.On("FindByIDs", []uint{1, 2}).Return([]models.Role{{ID: 1}, editor}, nil).Once()
+		s.roleRepo.On("GetPermissionNamesByUserID", uint(1)).Return([]string(nil), errors.New("db error")).Once()
+
+		_, err := s.service.Invite(1, input)
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+
+	s.Run("Error email taken by active user", func() {
+		s.roleRepo.On("FindByIDs", []uint{1, 2}).Return([]models.Role{{ID: 1}, {ID: 2}}, nil).Once()
+		s.userRepo.On("FindByField", "email", "new@example.com").
+			Return(&models.User{ID: 3, Status: models.UserStatusActive}, nil).Once()
+
+		_, err := s.service.Invite(1, input)
+		s.assertFieldError(err, "email")
+	})
+
+	s.Run("Error transaction", func() {
+		s.roleRepo.On("FindByIDs", []uint{1, 2}).Return([]models.Role{{ID: 1}, {ID: 2}}, nil).Once()
+		s.userRepo.On("FindByField", "email", "new@example.com").Return((*models.User)(nil), gorm.ErrRecordNotFound).Once()
+		s.bcryptService.On("HashPassword", mock.Anything).Return("unusable", nil).Once()
+		s.userRepo.On("GetDB").Return(s.db).Once()
+		s.userRepo.On("CreateWithTx", mock.Anything, mock.Anything).Return((*models.User)(nil), errors.New("db error")).Once()
+
+		_, err := s.service.Invite(1, input)
+		s.assertCode(err, apperror.ErrDBInsert)
+	})
+}
+
+func (s *InvitationServiceTestSuite) TestPaginateInvitations() {
+	s.Run("Success", func() {
+		pagination := &utils.Pagination{Page: 1, Limit: 10}
+		s.repo.On("Paginate", 1, 10, models.InvitationStatusPending).Return(pagination, nil).Once()
+
+		result, err := s.service.PaginateInvitations(1, 10, models.InvitationStatusPending)
+		s.NoError(err)
+		s.Equal(pagination, result)
+	})
+
+	s.Run("Error", func() {
+		s.repo.On("Paginate", 1, 10, "").Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.PaginateInvitations(1, 10, "")
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *InvitationServiceTestSuite) TestResend() {
+	s.Run("Success", func() {
+		user := &models.User{ID: 5, Email: "new@example.com"}
+		invitation := &models.Invitation{ID: 1, UserID: 5, InvitedBy: 1, TokenHash: "old", Status: models.InvitationStatusPending, User: user}
+		s.repo.On("GetByID", uint(1)).Return(invitation, nil).Once()
+		s.repo.On("Update", invitation).Return(nil).Once()
+		s.userRepo.On("GetByID", uint(1)).Return(&models.User{ID: 1, Name: "Admin"}, nil).Once()
+		s.mailerService.On("SendMailInvitation", user, "Admin", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := s.service.Resend(1)
+		s.Require().NoError(err)
+		s.NotEqual("old", result.TokenHash)
+		s.WithinDuration(time.Now().Add(72*time.Hour), result.ExpiresAt, time.Minute)
+	})
+
+	s.Run("Error not found", func() {
+		s.repo.On("GetByID", uint(2)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.Resend(2)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Error not pending", func() {
+		invitation := &models.Invitation{ID: 3, Status: models.InvitationStatusAccepted, User: &models.User{ID: 5}}
+		s.repo.On("GetByID", uint(3)).Return(invitation, nil).Once()
+
+		_, err := s.service.Resend(3)
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+}
+
+func (s *InvitationServiceTestSuite) TestRevoke() {
+	s.Run("Success", func() {
+		invitation := &models.Invitation{ID: 1, Status: models.InvitationStatusPending, User: &models.User{ID: 5}}
+		s.repo.On("GetByID", uint(1)).Return(invitation, nil).Once()
+		s.repo.On("Update", invitation).Return(nil).Once()
+
+		result, err := s.service.Revoke(1)
+		s.Require().NoError(err)
+		s.Equal(models.InvitationStatusRevoked, result.Status)
+		s.NotNil(result.RevokedAt)
+	})
+
+	s.Run("Error update", func() {
+		invitation := &models.Invitation{ID: 2, Status: models.InvitationStatusPending, User: &models.User{ID: 5}}
+		s.repo.On("GetByID", uint(2)).Return(invitation, nil).Once()
+		s.repo.On("Update", invitation).Return(errors.New("db error")).Once()
+
+		_, err := s.service.Revoke(2)
+		s.assertCode(err, apperror.ErrDBUpdate)
+	})
+}
+
+func (s *InvitationServiceTestSuite) TestAccept() {
+	s.Run("Success", func() {
+		user := &models.User{ID: 5, Status: models.UserStatusInvited}
+		invitation := &models.Invitation{ID: 1, Status: models.InvitationStatusPending, ExpiresAt: time.Now().Add(time.Hour), User: user}
+		s.repo.On("FindByTokenHash", mock.Anything).Return(invitation, nil).Once()
+		s.bcryptService.On("HashPassword", "secret123").Return("hashed", nil).Once()
+		s.repo.On("Accept", invitation, user).Return(nil).Once()
+
+		result, err := s.service.Accept("token", "secret123")
+		s.Require().NoError(err)
+		s.Equal(models.UserStatusActive, result.Status)
+		s.Equal("hashed", result.Password)
+		s.Equal(models.InvitationStatusAccepted, invitation.Status)
+		s.NotNil(invitation.AcceptedAt)
+	})
+
+	s.Run("Error unknown token", func() {
+		s.repo.On("FindByTokenHash", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.Accept("unknown", "secret123")
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+
+	s.Run("Error revoked", func() {
+		invitation := &models.Invitation{ID: 2, Status: models.InvitationStatusRevoked, ExpiresAt: time.Now().Add(time.Hour), User: &models.User{ID: 5}}
+		s.repo.On("FindByTokenHash", mock.Anything).Return(invitation, nil).Once()
+
+		_, err := s.service.Accept("token", "secret123")
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+
+	s.Run("Error expired", func() {
+		invitation := &models.Invitation{ID: 3, Status: models.InvitationStatusPending, ExpiresAt: time.Now().Add(-time.Hour), User: &models.User{ID: 5}}
+		s.repo.On("FindByTokenHash", mock.Anything).Return(invitation, nil).Once()
+
+		_, err := s.service.Accept("token", "secret123")
+		s.assertCode(err, apperror.ErrTokenExpired)
+	})
+}
+
+func TestInvitationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(InvitationServiceTestSuite))
+}
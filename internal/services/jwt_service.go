@@ -8,8 +8,11 @@ import (
 )
 
 // CustomClaims represents JWT claims with a custom user ID field
+// For impersonation tokens ID is the impersonated user, ImpersonatorID the real actor
+// and RegisteredClaims.ID identifies the impersonation session
 type CustomClaims struct {
-	ID uint `json:"id"`
+	ID             uint `json:"id"`
+	ImpersonatorID uint `json:"impersonatorId,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -22,6 +25,7 @@ type JwtResult struct {
 // IJWTService defines JWT-related operations
 type IJWTService interface {
 	GenerateToken(id uint) (*JwtResult, error)
+	GenerateImpersonationToken(id, impersonatorID uint, sessionID string, ttl time.Duration) (*JwtResult, error)
 	ValidateToken(tokenString string) (*CustomClaims, error)
 }
 
@@ -61,6 +65,40 @@ func (s *jwtService) GenerateToken(id uint) (*JwtResult, error) {
 	}, nil
 }
 
+// GenerateImpersonationToken creates a JWT token that lets impersonatorID act as the user id
+// Parameters:
+//   - id: The ID of the impersonated user
+//   - impersonatorID: The ID of the user who is impersonating
+//   - sessionID: Identifier of the impersonation session, stored as the token ID (jti)
+//   - ttl: Lifetime of the token
+//
+// Returns:
+//   - *JwtResult: The signed token and its expiry
+//   - error: nil if successful, error otherwise
+func (s *jwtService) GenerateImpersonationToken(id, impersonatorID uint, sessionID string, ttl time.Duration) (*JwtResult, error) {
+	expiresAt := jwt.NewNumericDate(time.Now().Add(ttl))
+	claims := CustomClaims{
+		ID:             id,
+		ImpersonatorID: impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
+			ExpiresAt: expiresAt,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := token.SignedString(s.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JwtResult{
+		Token:     signedToken,
+		ExpiresAt: expiresAt.Unix(),
+	}, nil
+}
+
 // ValidateToken validates a JWT token string and returns the claims if valid
 func (s *jwtService) ValidateToken(tokenString string) (*CustomClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(t *jwt.Token) (interface{}, error) {
@@ -50,3 +50,24 @@ func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, strings.Contains(err.Error(), "signature is invalid") || strings.Contains(err.Error(), "token is invalid"))
 }
+
+func TestJWTService_GenerateImpersonationToken(t *testing.T) {
+	svc := services.NewJWTService()
+
+	result, err := svc.GenerateImpersonationToken(5, 1, "session-id", 15*time.Minute)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), time.Unix(result.ExpiresAt, 0), time.Minute)
+
+	claims, err := svc.ValidateToken(result.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), claims.ID)
+	assert.Equal(t, uint(1), claims.ImpersonatorID)
+	assert.Equal(t, "session-id", claims.RegisteredClaims.ID)
+
+	// Regular tokens carry no impersonator
+	result, err = svc.GenerateToken(5)
+	assert.NoError(t, err)
+	claims, err = svc.ValidateToken(result.Token)
+	assert.NoError(t, err)
+	assert.Zero(t, claims.ImpersonatorID)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"strconv"
+	"time"
 
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
 	"github.com/vfa-khuongdv/golang-cms/internal/utils"
@@ -13,45 +15,55 @@ import (
 
 type IMailerService interface {
 	SendMailForgotPassword(user *models.User) error
+	SendMailInvitation(user *models.User, inviterName, token string, expiresAt time.Time) error
+	SendMailNewComment(author *models.User, post *models.Post, comment *models.Comment, commenterName string) error
+	SendMailPostExpiring(author *models.User, post *models.Post) error
 }
 
-// SendMailForgotPassword sends a password reset email to the user
+type MailerService struct {
+	sender mailer.EmailSender
+}
+
+// NewMailerService creates a new instance of MailerService
 // Parameters:
-//   - user: Pointer to models.User containing user information including email and reset token
+//   - sender: The sender used to deliver the rendered emails
 //
 // Returns:
-//   - error: Returns nil on success, error on failure
-//
-// The function:
-//  1. Creates SMTP config from environment variables
-//  2. Initializes mail sender
-//  3. Parses email template
-//  4. Executes template with user data
-//  5. Sends password reset email to user
-func SendMailForgotPassword(user *models.User) error {
+//   - *MailerService: New MailerService instance sending through the provided sender
+func NewMailerService(sender mailer.EmailSender) *MailerService {
+	return &MailerService{
+		sender: sender,
+	}
+}
 
-	var config = mailer.GomailSenderConfig{
+// NewSMTPMailerService creates a MailerService sending through the SMTP server configured in the environment
+func NewSMTPMailerService() *MailerService {
+	return NewMailerService(mailer.NewGomailSender(mailer.GomailSenderConfig{
 		Host:     utils.GetEnv("MAIL_HOST", "smtp.gmail.com"),
 		Port:     utils.GetEnvAsInt("MAIL_PORT", 587),
 		Username: utils.GetEnv("MAIL_USERNAME", ""),
 		Password: utils.GetEnv("MAIL_PASSWORD", ""),
 		From:     utils.GetEnv("MAIL_FROM", ""),
-	}
-
-	mailer := mailer.NewGomailSender(mailer.GomailSenderConfig{
-		From:     config.From,
-		Host:     config.Host,
-		Port:     config.Port,
-		Username: config.Username,
-		Password: config.Password,
-	})
+	}))
+}
 
-	// Parse the email template file
-	tmpl, err := template.ParseFiles("pkg/mailer/templates/forgot_template.html")
-	if err != nil {
-		return fmt.Errorf("error parsing template: %w", err)
-	}
+// SendMailForgotPassword sends a password reset email to the user
+// Parameters:
+//   - user: Pointer to models.User containing user information including email and reset token
+//
+// Returns:
+//   - error: Returns nil on success, error on failure
+func SendMailForgotPassword(user *models.User) error {
+	return NewSMTPMailerService().SendMailForgotPassword(user)
+}
 
+// SendMailForgotPassword sends a password reset email to the user
+// Parameters:
+//   - user: Pointer to models.User containing user information including email and reset token
+//
+// Returns:
+//   - error: Returns nil on success, error on failure
+func (service *MailerService) SendMailForgotPassword(user *models.User) error {
 	// Construct reset password URL by combining frontend URL with user's reset token
 	url := utils.GetEnv("FRONTEND_URL", "") + "/reset-password?token=" + *user.Token
 
@@ -60,16 +72,95 @@ func SendMailForgotPassword(user *models.User) error {
 		"Name": user.Name,
 		"URL":  url,
 	}
+	return service.send(user.Email, "Reset your password", "forgot_template.html", data)
+}
+
+// SendMailInvitation sends an invitation email with a link to choose a password
+// Parameters:
+//   - user: The invited user
+//   - inviterName: Name of the user who sent the invitation
+//   - token: The plain invitation token, only its hash is stored
+//   - expiresAt: Time after which the invitation can no longer be accepted
+//
+// Returns:
+//   - error: Returns nil on success, error on failure
+func (service *MailerService) SendMailInvitation(user *models.User, inviterName, token string, expiresAt time.Time) error {
+	url := utils.GetEnv("FRONTEND_URL", "") + "/accept-invitation?token=" + token
+
+	data := map[string]interface{}{
+		"Name":        user.Name,
+		"InviterName": inviterName,
+		"URL":         url,
+		"ExpiresAt":   expiresAt.UTC().Format("2006-01-02 15:04 MST"),
+	}
+	return service.send(user.Email, "You have been invited", "invitation_template.html", data)
+}
+
+// SendMailNewComment tells the author of a post that a comment was posted on it
+// Parameters:
+//   - author: The author of the post
+//   - post: The commented post
+//   - comment: The new comment, the email says when it awaits moderation
+//   - commenterName: Name of the user or guest who wrote the comment
+//
+// Returns:
+//   - error: Returns nil on success, error on failure
+func (service *MailerService) SendMailNewComment(author *models.User, post *models.Post, comment *models.Comment, commenterName string) error {
+	url := utils.GetEnv("FRONTEND_URL", "") + "/posts/" + post.Slug + "#comment-" + strconv.FormatUint(uint64(comment.ID), 10)
+	if comment.Status == models.CommentStatusPending {
+		url = utils.GetEnv("FRONTEND_URL", "") + "/comments?status=" + models.CommentStatusPending
+	}
+
+	data := map[string]interface{}{
+		"Name":          author.Name,
+		"PostTitle":     post.Title,
+		"CommenterName": commenterName,
+		"Body":          comment.Body,
+		"Pending":       comment.Status == models.CommentStatusPending,
+		"URL":           url,
+	}
+	return service.send(author.Email, "New comment on "+post.Title, "comment_template.html", data)
+}
+
+// SendMailPostExpiring warns the author of a post that it will soon be taken offline
+// Parameters:
+//   - author: The author of the post
+//   - post: The post with its expiry time and expire action set
+//
+// Returns:
+//   - error: Returns nil on success, error on failure
+func (service *MailerService) SendMailPostExpiring(author *models.User, post *models.Post) error {
+	data := map[string]interface{}{
+		"Name":      author.Name,
+		"PostTitle": post.Title,
+		"ExpireAt":  post.ExpireAt.UTC().Format("2006-01-02 15:04 MST"),
+		"Archived":  post.ExpireAction == nil || *post.ExpireAction == models.PostActionArchive,
+		"URL":       utils.GetEnv("FRONTEND_URL", "") + "/posts/" + post.Slug,
+	}
+	return service.send(author.Email, post.Title+" expires soon", "post_expiring_template.html", data)
+}
+
+// send renders an embedded email template and sends it to a single recipient
+//
+// The function:
+//  1. Parses the email template
+//  2. Executes the template with the data
+//  3. Sends the rendered HTML to the recipient
+func (service *MailerService) send(to, subject, templateName string, data map[string]interface{}) error {
+	// Parse the email template file
+	tmpl, err := template.ParseFS(mailer.Templates, "templates/"+templateName)
+	if err != nil {
+		return fmt.Errorf("error parsing template: %w", err)
+	}
+
 	// Create buffer to store rendered HTML
 	var htmlBody bytes.Buffer
 	// Execute template with data and write to buffer
 	if err := tmpl.Execute(&htmlBody, data); err != nil {
 		return apperror.NewInternalError(fmt.Sprintf("error executing template: %+v", err))
 	}
-	// Send password reset email to user
-	if err := mailer.Send([]string{user.Email}, "Reset your password", "", htmlBody.String()); err != nil {
+	if err := service.sender.Send([]string{to}, subject, "", htmlBody.String()); err != nil {
 		return apperror.NewInternalError(fmt.Sprintf("error sending email: %+v", err))
 	}
 	return nil
-
 }
@@ -0,0 +1,92 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestMailerService(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "https://app.example.com")
+
+	t.Run("SendMailForgotPassword renders the reset link", func(t *testing.T) {
+		sender := new(mocks.MockEmailSender)
+		service := services.NewMailerService(sender)
+		token := "reset-token"
+		sender.On("Send", []string{"john@example.com"}, "Reset your password", "", mock.MatchedBy(func(html string) bool {
+			return strings.Contains(html, "Hello John") && strings.Contains(html, "https://app.example.com/reset-password?token=reset-token")
+		})).Return(nil).Once()
+
+		err := service.SendMailForgotPassword(&models.User{Name: "John", Email: "john@example.com", Token: &token})
+		assert.NoError(t, err)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("SendMailInvitation renders the accept link", func(t *testing.T) {
+		sender := new(mocks.MockEmailSender)
+		service := services.NewMailerService(sender)
+		expiresAt := time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC)
+		sender.On("Send", []string{"jane@example.com"}, "You have been invited", "", mock.MatchedBy(func(html string) bool {
+			return strings.Contains(html, "Admin invited you") &&
+				strings.Contains(html, "https://app.example.com/accept-invitation?token=invite-token") &&
+				strings.Contains(html, "2030-01-02 15:04 UTC")
+		})).Return(nil).Once()
+
+		err := service.SendMailInvitation(&models.User{Name: "Jane", Email: "jane@example.com"}, "Admin", "invite-token", expiresAt)
+		assert.NoError(t, err)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("SendMailNewComment links to the moderation queue", func(t *testing.T) {
+		sender := new(mocks.MockEmailSender)
+		service := services.NewMailerService(sender)
+		sender.On("Send", []string{"author@example.com"}, "New comment on Hello", "", mock.MatchedBy(func(html string) bool {
+			return strings.Contains(html, "Guest") &&
+				strings.Contains(html, "&lt;b&gt;Nice&lt;/b&gt;") &&
+				strings.Contains(html, "https://app.example.com/comments?status=pending")
+		})).Return(nil).Once()
+
+		err := service.SendMailNewComment(
+			&models.User{Name: "Author", Email: "author@example.com"},
+			&models.Post{Title: "Hello", Slug: "hello"},
+			&models.Comment{ID: 5, Body: "<b>Nice</b>", Status: models.CommentStatusPending},
+			"Guest",
+		)
+		assert.NoError(t, err)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("SendMailPostExpiring tells when the post is unpublished", func(t *testing.T) {
+		sender := new(mocks.MockEmailSender)
+		service := services.NewMailerService(sender)
+		expireAt := time.Date(2030, 1, 2, 15, 4, 0, 0, time.UTC)
+		unpublish := models.PostActionUnpublish
+		sender.On("Send", []string{"author@example.com"}, "Hello expires soon", "", mock.MatchedBy(func(html string) bool {
+			return strings.Contains(html, "will be unpublished on 2030-01-02 15:04 UTC") &&
+				strings.Contains(html, "https://app.example.com/posts/hello")
+		})).Return(nil).Once()
+
+		err := service.SendMailPostExpiring(
+			&models.User{Name: "Author", Email: "author@example.com"},
+			&models.Post{Title: "Hello", Slug: "hello", ExpireAt: &expireAt, ExpireAction: &unpublish},
+		)
+		assert.NoError(t, err)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("Send error", func(t *testing.T) {
+		sender := new(mocks.MockEmailSender)
+		service := services.NewMailerService(sender)
+		sender.On("Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("smtp error")).Once()
+
+		err := service.SendMailInvitation(&models.User{Email: "jane@example.com"}, "Admin", "invite-token", time.Now())
+		assert.ErrorContains(t, err, "smtp error")
+	})
+}
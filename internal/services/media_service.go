@@ -0,0 +1,477 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/imaging"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+	"gorm.io/gorm"
+)
+
+const (
+	MediaThumbnailSize = 150   // Width and height of the square thumbnail in pixels
+	MediaMediumSize    = 800   // Largest width or height of the medium variant in pixels
+	MediaLargeSize     = 1600  // Largest width or height of the large variant in pixels
+	mediaMaxDimension  = 10000 // Largest accepted source width or height, guards against decompression bombs
+	mediaMaxBaseName   = 100   // Longest file name kept in storage keys, without extension
+)
+
+// allowedMediaTypes maps accepted upload content types to the extension of the stored file
+var allowedMediaTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"video/mp4":       ".mp4",
+	"video/webm":      ".webm",
+	"audio/mpeg":      ".mp3",
+	"audio/wave":      ".wav",
+}
+
+// mediaVariantFormats maps the image types variants are generated for to the format they are encoded in
+var mediaVariantFormats = map[string]string{
+	"image/jpeg": imaging.FormatJPEG,
+	"image/png":  imaging.FormatPNG,
+	"image/gif":  imaging.FormatPNG,
+}
+
+type mediaVariant struct {
+	name string
+	size int
+	crop bool // Cropped to a square instead of scaled to fit
+}
+
+var mediaVariants = []mediaVariant{
+	{name: models.MediaVariantThumbnail, size: MediaThumbnailSize, crop: true},
+	{name: models.MediaVariantMedium, size: MediaMediumSize},
+	{name: models.MediaVariantLarge, size: MediaLargeSize},
+}
+
+// MediaUpload holds an uploaded file and the metadata entered with it
+type MediaUpload struct {
+	UploaderID uint
+	FileName   string // Name of the file on the uploader's device
+	Data       []byte
+	FolderID   *uint
+	AltText    *string
+	Caption    *string
+}
+
+type IMediaService interface {
+	PaginateMedia(page, limit int, filter repositories.MediaFilter) (*utils.Pagination, error)
+	GetMedia(id uint) (*models.Media, error)
+	UploadMedia(upload MediaUpload) (*models.Media, bool, error)
+	UpdateMedia(media *models.Media) error
+	DeleteMedia(media *models.Media) error
+	GetFolders() ([]models.MediaFolder, error)
+	GetFolder(id uint) (*models.MediaFolder, error)
+	CreateFolder(folder *models.MediaFolder) error
+	UpdateFolder(folder *models.MediaFolder) error
+	DeleteFolder(id uint) error
+	MaxUploadSize() int64
+}
+
+type MediaService struct {
+	repo    repositories.IMediaRepository
+	storage storage.Storage
+	maxSize int64
+}
+
+// NewMediaService creates a new instance of MediaService
+// Parameters:
+//   - repo: Repository of media files and folders
+//   - storage: Storage backend the uploaded files are written to
+//   - maxSize: Maximum accepted upload size in bytes
+//
+// Returns:
+//   - *MediaService: New MediaService instance initialized with the provided dependencies
+func NewMediaService(repo repositories.IMediaRepository, storage storage.Storage, maxSize int64) *MediaService {
+	return &MediaService{
+		repo:    repo,
+		storage: storage,
+		maxSize: maxSize,
+	}
+}
+
+// MaxUploadSize returns the largest accepted media upload in bytes, checked before the upload is read
+func (service *MediaService) MaxUploadSize() int64 {
+	return service.maxSize
+}
+
+// PaginateMedia retrieves a page of media, most recent uploads first
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of media per page
+//   - filter: Optional criteria the media must match
+//
+// Returns:
+//   - *utils.Pagination: The page of media
+//   - error: DBQuery error if the media cannot be loaded
+func (service *MediaService) PaginateMedia(page, limit int, filter repositories.MediaFilter) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginateMedia(page, limit, filter)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetMedia retrieves a media file by its ID
+func (service *MediaService) GetMedia(id uint) (*models.Media, error) {
+	media, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return media, nil
+}
+
+// UploadMedia validates and stores an uploaded file, identical content is only stored once
+// Parameters:
+//   - upload: The uploaded file and its metadata
+//
+// Returns:
+//   - *models.Media: The stored media, or the existing media with the same content
+//   - bool: true if a new media was created, false if the content was already in the library
+//   - error: ValidationError for an empty file or unknown folder, FileTooLarge or UnsupportedFileType
+//     for invalid uploads, FileStorage or DBInsert errors otherwise
+//
+// The function:
+//  1. Checks the upload size and sniffs the content type from the file content
+//  2. Returns the existing media when a file with the same SHA-256 checksum was uploaded before
+//  3. Decodes images, rejects oversized dimensions and generates the thumbnail, medium and large variants
+//  4. Stores the original file and its variants, then creates the media record
+func (service *MediaService) UploadMedia(upload MediaUpload) (*models.Media, bool, error) {
+	if len(upload.Data) == 0 {
+		return nil, false, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "file", Message: "file must not be empty"},
+		})
+	}
+	if int64(len(upload.Data)) > service.maxSize {
+		return nil, false, apperror.NewFileTooLargeError(fmt.Sprintf("File must not be larger than %d bytes", service.maxSize))
+	}
+
+	mimeType := http.DetectContentType(upload.Data)
+	ext, ok := allowedMediaTypes[mimeType]
+	if !ok {
+		return nil, false, apperror.NewUnsupportedFileTypeError("File must be an image, a PDF document, an MP4 or WebM video or an MP3 or WAV audio file")
+	}
+
+	sum := sha256.Sum256(upload.Data)
+	checksum := hex.EncodeToString(sum[:])
+	existing, err := service.repo.FindByChecksum(checksum)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, apperror.NewDBQueryError(err.Error())
+	}
+
+	if err := service.checkFolder(upload.FolderID); err != nil {
+		return nil, false, err
+	}
+
+	media := &models.Media{
+		FolderID: upload.FolderID,
+		FileName: upload.FileName,
+		MimeType: mimeType,
+		Size:     int64(len(upload.Data)),
+		Checksum: checksum,
+		AltText:  upload.AltText,
+		Caption:  upload.Caption,
+	}
+	if upload.UploaderID != 0 {
+		media.UploaderID = &upload.UploaderID
+	}
+
+	// Dimensions and variants are only available for the image formats the application can decode
+	var img image.Image
+	if _, ok := mediaVariantFormats[mimeType]; ok {
+		config, _, err := image.DecodeConfig(bytes.NewReader(upload.Data))
+		if err != nil {
+			return nil, false, apperror.NewUnsupportedFileTypeError("File is not a valid image")
+		}
+		if config.Width > mediaMaxDimension || config.Height > mediaMaxDimension {
+			return nil, false, apperror.NewFileTooLargeError(fmt.Sprintf("Image dimensions must not exceed %dx%d pixels", mediaMaxDimension, mediaMaxDimension))
+		}
+		if img, _, err = imaging.Decode(bytes.NewReader(upload.Data)); err != nil {
+			return nil, false, apperror.NewUnsupportedFileTypeError("File is not a valid image")
+		}
+		media.Width = &config.Width
+		media.Height = &config.Height
+	}
+
+	folder := fmt.Sprintf("media/%s/%s", time.Now().Format("2006/01"), utils.GenerateRandomString(16))
+	baseName := mediaBaseName(upload.FileName)
+
+	media.Key = path.Join(folder, baseName+ext)
+	if err := service.storage.Put(media.Key, bytes.NewReader(upload.Data), mimeType); err != nil {
+		return nil, false, apperror.NewFileStorageError(fmt.Sprintf("Failed to store file: %v", err))
+	}
+	media.URL = service.storage.URL(media.Key)
+
+	if img != nil {
+		variants, err := service.storeVariants(img, mediaVariantFormats[mimeType], folder, baseName)
+		if err != nil {
+			service.removeFiles([]string{media.Key})
+			return nil, false, err
+		}
+		media.Variants = variants
+	}
+
+	if err := service.repo.Create(media); err != nil {
+		service.removeFiles(mediaKeys(media))
+		return nil, false, apperror.NewDBInsertError(err.Error())
+	}
+	return media, true, nil
+}
+
+// UpdateMedia saves the metadata and folder of a media file, the file itself cannot be replaced
+// Parameters:
+//   - media: The media to save
+//
+// Returns:
+//   - error: ValidationError if the folder does not exist, DBUpdate error otherwise
+func (service *MediaService) UpdateMedia(media *models.Media) error {
+	if err := service.checkFolder(media.FolderID); err != nil {
+		return err
+	}
+	if err := service.repo.Update(media); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteMedia removes a media record and its stored files
+// Parameters:
+//   - media: The media to delete
+//
+// Returns:
+//   - error: DBDelete error if the record cannot be removed, failures to remove files are only logged
+func (service *MediaService) DeleteMedia(media *models.Media) error {
+	if err := service.repo.Delete(media.ID); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	service.removeFiles(mediaKeys(media))
+	return nil
+}
+
+// GetFolders retrieves every media folder ordered by name
+func (service *MediaService) GetFolders() ([]models.MediaFolder, error) {
+	folders, err := service.repo.GetFolders()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return folders, nil
+}
+
+// GetFolder retrieves a media folder by its ID
+func (service *MediaService) GetFolder(id uint) (*models.MediaFolder, error) {
+	folder, err := service.repo.GetFolderByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return folder, nil
+}
+
+// CreateFolder stores a new media folder
+// Parameters:
+//   - folder: The folder to create
+//
+// Returns:
+//   - error: ValidationError if the parent does not exist or already holds a folder with the same name, DBInsert error otherwise
+func (service *MediaService) CreateFolder(folder *models.MediaFolder) error {
+	if err := service.prepareFolder(folder); err != nil {
+		return err
+	}
+	if err := service.repo.CreateFolder(folder); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateFolder renames a media folder or moves it under another parent
+// Parameters:
+//   - folder: The folder to save
+//
+// Returns:
+//   - error: ValidationError if the parent does not exist, is the folder itself or one of its descendants,
+//     or already holds a folder with the same name, DBUpdate error otherwise
+func (service *MediaService) UpdateFolder(folder *models.MediaFolder) error {
+	if err := service.prepareFolder(folder); err != nil {
+		return err
+	}
+	if err := service.repo.UpdateFolder(folder); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteFolder removes a media folder without subfolders, its files are moved to the root of the library
+// Parameters:
+//   - id: The ID of the folder
+//
+// Returns:
+//   - error: BadRequest if the folder still has subfolders, DBDelete error otherwise
+func (service *MediaService) DeleteFolder(id uint) error {
+	folders, err := service.repo.GetFolders()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if slices.ContainsFunc(folders, func(folder models.MediaFolder) bool { return folder.ParentID != nil && *folder.ParentID == id }) {
+		return apperror.NewBadRequestError("Folder has subfolders, move or delete them first")
+	}
+	if err := service.repo.DeleteFolder(id); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// storeVariants resizes an image to every variant and stores them next to the original file
+func (service *MediaService) storeVariants(img image.Image, format, folder, baseName string) (map[string]models.MediaVariant, error) {
+	ext, contentType := ".png", "image/png"
+	if format == imaging.FormatJPEG {
+		ext, contentType = ".jpg", "image/jpeg"
+	}
+
+	variants := make(map[string]models.MediaVariant, len(mediaVariants))
+	keys := make([]string, 0, len(mediaVariants))
+	for _, variant := range mediaVariants {
+		resized := imaging.Fit(img, variant.size, variant.size)
+		if variant.crop {
+			resized = imaging.Fill(img, variant.size, variant.size)
+		}
+
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, resized, format); err != nil {
+			service.removeFiles(keys)
+			return nil, apperror.NewInternalError(fmt.Sprintf("Failed to encode image variant: %v", err))
+		}
+
+		key := path.Join(folder, baseName+"-"+variant.name+ext)
+		if err := service.storage.Put(key, &buf, contentType); err != nil {
+			service.removeFiles(keys)
+			return nil, apperror.NewFileStorageError(fmt.Sprintf("Failed to store image variant: %v", err))
+		}
+		keys = append(keys, key)
+
+		bounds := resized.Bounds()
+		variants[variant.name] = models.MediaVariant{
+			Key:    key,
+			URL:    service.storage.URL(key),
+			Width:  bounds.Dx(),
+			Height: bounds.Dy(),
+		}
+	}
+	return variants, nil
+}
+
+// checkFolder makes sure the folder a media file is placed in exists
+func (service *MediaService) checkFolder(folderID *uint) error {
+	if folderID == nil {
+		return nil
+	}
+	if _, err := service.repo.GetFolderByID(*folderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "folder_id", Message: "folder does not exist"},
+			})
+		}
+		return apperror.NewDBQueryError(err.Error())
+	}
+	return nil
+}
+
+// prepareFolder validates the parent and name of a folder before it is saved
+func (service *MediaService) prepareFolder(folder *models.MediaFolder) error {
+	folder.Name = strings.TrimSpace(folder.Name)
+
+	if folder.ParentID != nil {
+		folders, err := service.repo.GetFolders()
+		if err != nil {
+			return apperror.NewDBQueryError(err.Error())
+		}
+		if !slices.ContainsFunc(folders, func(existing models.MediaFolder) bool { return existing.ID == *folder.ParentID }) {
+			return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "parent_id", Message: "parent folder does not exist"},
+			})
+		}
+		if folder.ID != 0 && slices.Contains(folderSubtreeIDs(folders, folder.ID), *folder.ParentID) {
+			return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "parent_id", Message: "a folder cannot be moved under itself or one of its subfolders"},
+			})
+		}
+	}
+
+	exists, err := service.repo.FolderNameExists(folder.ParentID, folder.Name, folder.ID)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if exists {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "name", Message: "a folder with this name already exists"},
+		})
+	}
+	return nil
+}
+
+// removeFiles deletes stored files on a best effort basis, failures are only logged
+func (service *MediaService) removeFiles(keys []string) {
+	for _, key := range keys {
+		if err := service.storage.Delete(key); err != nil {
+			logger.Warnf("Failed to delete media file %s: %v", key, err)
+		}
+	}
+}
+
+// mediaKeys returns the storage keys of the original file and of every variant of a media
+func mediaKeys(media *models.Media) []string {
+	keys := []string{media.Key}
+	for _, variant := range mediaVariants {
+		if stored, ok := media.Variants[variant.name]; ok {
+			keys = append(keys, stored.Key)
+		}
+	}
+	return keys
+}
+
+// mediaBaseName turns the uploaded file name into a safe name for storage keys, without extension
+func mediaBaseName(fileName string) string {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	name = strings.TrimSuffix(name, path.Ext(name))
+	slug := utils.Slugify(name)
+	if len(slug) > mediaMaxBaseName {
+		slug = strings.TrimRight(slug[:mediaMaxBaseName], "-")
+	}
+	if slug == "" {
+		return "file"
+	}
+	return slug
+}
+
+// folderSubtreeIDs lists the ID of a folder followed by the IDs of all its subfolders
+func folderSubtreeIDs(folders []models.MediaFolder, id uint) []uint {
+	ids := []uint{id}
+	for i := 0; i < len(ids); i++ {
+		for _, folder := range folders {
+			if folder.ParentID != nil && *folder.ParentID == ids[i] {
+				ids = append(ids, folder.ID)
+			}
+		}
+	}
+	return ids
+}
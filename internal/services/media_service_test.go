@@ -0,0 +1,275 @@
+package services_test
+
+import (
+	"errors"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type MediaServiceTestSuite struct {
+	suite.Suite
+	root    string
+	repo    *mocks.MockMediaRepository
+	storage *storage.LocalStorage
+	service *services.MediaService
+}
+
+func (s *MediaServiceTestSuite) SetupTest() {
+	s.root = s.T().TempDir()
+	s.repo = new(mocks.MockMediaRepository)
+	s.storage = storage.NewLocalStorage(s.root, "http://localhost/storage")
+	s.service = services.NewMediaService(s.repo, s.storage, 1<<20)
+}
+
+func (s *MediaServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *MediaServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *MediaServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+func (s *MediaServiceTestSuite) fileExists(key string) bool {
+	_, err := os.Stat(filepath.Join(s.root, filepath.FromSlash(key)))
+	return err == nil
+}
+
+func (s *MediaServiceTestSuite) storedFiles() []string {
+	var files []string
+	_ = filepath.WalkDir(s.root, func(path string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+func (s *MediaServiceTestSuite) TestUploadMedia() {
+	s.Run("Success image with variants", func() {
+		folderID := uint(2)
+		s.repo.On("FindByChecksum", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("GetFolderByID", folderID).Return(&models.MediaFolder{ID: folderID, Name: "Photos"}, nil).Once()
+		s.repo.On("Create", mock.AnythingOfType("*models.Media")).Return(nil).Once()
+
+		media, created, err := s.service.UploadMedia(services.MediaUpload{
+			UploaderID: 1,
+			FileName:   "My Holiday Photo.JPG",
+			Data:       encodeTestImage("jpeg", 2000, 1000),
+			FolderID:   &folderID,
+			AltText:    utils.StringToPtr("Beach"),
+		})
+		s.Require().NoError(err)
+		s.True(created)
+		s.Equal("image/jpeg", media.MimeType)
+		s.Len(media.Checksum, 64)
+		s.Equal(uint(1), *media.UploaderID)
+		s.Equal(2000, *media.Width)
+		s.Equal(1000, *media.Height)
+		s.True(strings.HasPrefix(media.Key, "media/"))
+		s.True(strings.HasSuffix(media.Key, "/my-holiday-photo.jpg"))
+		s.Equal("http://localhost/storage/"+media.Key, media.URL)
+		s.True(s.fileExists(media.Key))
+
+		expected := map[string][2]int{
+			models.MediaVariantThumbnail: {services.MediaThumbnailSize, services.MediaThumbnailSize},
+			models.MediaVariantMedium:    {services.MediaMediumSize, services.MediaMediumSize / 2},
+			models.MediaVariantLarge:     {services.MediaLargeSize, services.MediaLargeSize / 2},
+		}
+		s.Require().Len(media.Variants, len(expected))
+		for name, size := range expected {
+			variant := media.Variants[name]
+			s.True(strings.HasSuffix(variant.Key, "/my-holiday-photo-"+name+".jpg"))
+			s.Equal(size[0], variant.Width)
+			s.Equal(size[1], variant.Height)
+
+			reader, err := s.storage.Get(variant.Key)
+			s.Require().NoError(err)
+			config, _, err := image.DecodeConfig(reader)
+			_ = reader.Close()
+			s.Require().NoError(err)
+			s.Equal(size[0], config.Width)
+		}
+	})
+
+	s.Run("Success document without variants", func() {
+		s.repo.On("FindByChecksum", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("Create", mock.AnythingOfType("*models.Media")).Return(nil).Once()
+
+		media, created, err := s.service.UploadMedia(services.MediaUpload{
+			FileName: "report.pdf",
+			Data:     []byte("%PDF-1.4 quarterly report"),
+		})
+		s.Require().NoError(err)
+		s.True(created)
+		s.Equal("application/pdf", media.MimeType)
+		s.True(strings.HasSuffix(media.Key, "/report.pdf"))
+		s.Nil(media.Width)
+		s.Empty(media.Variants)
+		s.Nil(media.UploaderID)
+	})
+
+	s.Run("Success duplicate returns existing media", func() {
+		existing := &models.Media{ID: 9, FileName: "logo.png"}
+		s.repo.On("FindByChecksum", mock.Anything).Return(existing, nil).Once()
+
+		before := len(s.storedFiles())
+		media, created, err := s.service.UploadMedia(services.MediaUpload{
+			FileName: "copy-of-logo.png",
+			Data:     encodeTestImage("png", 10, 10),
+		})
+		s.Require().NoError(err)
+		s.False(created)
+		s.Equal(existing, media)
+		s.Len(s.storedFiles(), before)
+	})
+
+	s.Run("Error empty file", func() {
+		_, _, err := s.service.UploadMedia(services.MediaUpload{FileName: "empty.txt"})
+		s.assertFieldError(err, "file")
+	})
+
+	s.Run("Error file too large", func() {
+		service := services.NewMediaService(s.repo, s.storage, 10)
+		_, _, err := service.UploadMedia(services.MediaUpload{FileName: "a.png", Data: encodeTestImage("png", 10, 10)})
+		s.assertCode(err, apperror.ErrFileTooLarge)
+	})
+
+	s.Run("Error unsupported type", func() {
+		_, _, err := s.service.UploadMedia(services.MediaUpload{FileName: "notes.txt", Data: []byte("plain text notes")})
+		s.assertCode(err, apperror.ErrUnsupportedFileType)
+	})
+
+	s.Run("Error corrupted image", func() {
+		s.repo.On("FindByChecksum", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+		_, _, err := s.service.UploadMedia(services.MediaUpload{FileName: "a.png", Data: encodeTestImage("png", 10, 10)[:40]})
+		s.assertCode(err, apperror.ErrUnsupportedFileType)
+	})
+
+	s.Run("Error unknown folder", func() {
+		folderID := uint(99)
+		s.repo.On("FindByChecksum", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("GetFolderByID", folderID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, _, err := s.service.UploadMedia(services.MediaUpload{FileName: "a.pdf", Data: []byte("%PDF-1.4 a"), FolderID: &folderID})
+		s.assertFieldError(err, "folder_id")
+	})
+
+	s.Run("Error storage", func() {
+		failing := new(mocks.MockStorage)
+		failing.On("Put", mock.Anything, mock.Anything, "image/png").Return(errors.New("disk full")).Once()
+		s.repo.On("FindByChecksum", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+		service := services.NewMediaService(s.repo, failing, 1<<20)
+
+		_, _, err := service.UploadMedia(services.MediaUpload{FileName: "a.png", Data: encodeTestImage("png", 12, 12)})
+		s.assertCode(err, apperror.ErrFileStorage)
+		failing.AssertExpectations(s.T())
+	})
+
+	s.Run("Error create removes stored files", func() {
+		s.repo.On("FindByChecksum", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("Create", mock.AnythingOfType("*models.Media")).Return(errors.New("db error")).Once()
+
+		before := len(s.storedFiles())
+		_, _, err := s.service.UploadMedia(services.MediaUpload{FileName: "b.png", Data: encodeTestImage("png", 14, 14)})
+		s.assertCode(err, apperror.ErrDBInsert)
+		s.Len(s.storedFiles(), before)
+	})
+}
+
+func (s *MediaServiceTestSuite) TestDeleteMedia() {
+	s.repo.On("FindByChecksum", mock.Anything).Return(nil, gorm.ErrRecordNotFound).Once()
+	s.repo.On("Create", mock.AnythingOfType("*models.Media")).Return(nil).Once()
+	media, _, err := s.service.UploadMedia(services.MediaUpload{FileName: "a.jpg", Data: encodeTestImage("jpeg", 300, 300)})
+	s.Require().NoError(err)
+	media.ID = 5
+
+	s.Run("Error delete keeps files", func() {
+		s.repo.On("Delete", uint(5)).Return(errors.New("db error")).Once()
+		s.assertCode(s.service.DeleteMedia(media), apperror.ErrDBDelete)
+		s.True(s.fileExists(media.Key))
+	})
+
+	s.Run("Success", func() {
+		s.repo.On("Delete", uint(5)).Return(nil).Once()
+		s.NoError(s.service.DeleteMedia(media))
+		s.Empty(s.storedFiles())
+	})
+}
+
+func (s *MediaServiceTestSuite) TestFolders() {
+	images, logos, documents, unknown := uint(1), uint(2), uint(3), uint(42)
+	folders := []models.MediaFolder{
+		{ID: 1, Name: "Images"},
+		{ID: 2, Name: "Logos", ParentID: &images},
+		{ID: 3, Name: "Documents"},
+	}
+
+	s.Run("Create success", func() {
+		s.repo.On("GetFolders").Return(folders, nil).Once()
+		s.repo.On("FolderNameExists", &images, "Icons", uint(0)).Return(false, nil).Once()
+		s.repo.On("CreateFolder", mock.AnythingOfType("*models.MediaFolder")).Return(nil).Once()
+
+		s.NoError(s.service.CreateFolder(&models.MediaFolder{Name: "  Icons ", ParentID: &images}))
+	})
+
+	s.Run("Create duplicate name", func() {
+		s.repo.On("FolderNameExists", (*uint)(nil), "Images", uint(0)).Return(true, nil).Once()
+		s.assertFieldError(s.service.CreateFolder(&models.MediaFolder{Name: "Images"}), "name")
+	})
+
+	s.Run("Create unknown parent", func() {
+		s.repo.On("GetFolders").Return(folders, nil).Once()
+		s.assertFieldError(s.service.CreateFolder(&models.MediaFolder{Name: "X", ParentID: &unknown}), "parent_id")
+	})
+
+	s.Run("Update under own subfolder", func() {
+		s.repo.On("GetFolders").Return(folders, nil).Once()
+		s.assertFieldError(s.service.UpdateFolder(&models.MediaFolder{ID: 1, Name: "Images", ParentID: &logos}), "parent_id")
+	})
+
+	s.Run("Update success", func() {
+		folder := &models.MediaFolder{ID: 2, Name: "Brand", ParentID: &documents}
+		s.repo.On("GetFolders").Return(folders, nil).Once()
+		s.repo.On("FolderNameExists", &documents, "Brand", uint(2)).Return(false, nil).Once()
+		s.repo.On("UpdateFolder", folder).Return(nil).Once()
+		s.NoError(s.service.UpdateFolder(folder))
+	})
+
+	s.Run("Delete with subfolders", func() {
+		s.repo.On("GetFolders").Return(folders, nil).Once()
+		s.assertCode(s.service.DeleteFolder(1), apperror.ErrBadRequest)
+	})
+
+	s.Run("Delete success", func() {
+		s.repo.On("GetFolders").Return(folders, nil).Once()
+		s.repo.On("DeleteFolder", uint(3)).Return(nil).Once()
+		s.NoError(s.service.DeleteFolder(3))
+	})
+}
+
+func TestMediaServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MediaServiceTestSuite))
+}
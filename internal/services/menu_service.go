@@ -0,0 +1,427 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+const (
+	maxMenuDepth = 3 // Levels of nested menu items, root items included
+
+	// URL prefixes of the frontend routes menu items link to, pages are served from their own path
+	menuPostPrefix     = "/posts/"
+	menuCategoryPrefix = "/categories/"
+)
+
+// MenuLink is a menu item resolved to the URL of its target, ready to be rendered by the frontend
+type MenuLink struct {
+	Label        string     `json:"label"`
+	URL          string     `json:"url"`
+	Type         string     `json:"type"`
+	OpenInNewTab bool       `json:"openInNewTab"`
+	Children     []MenuLink `json:"children"`
+}
+
+type IMenuService interface {
+	GetMenus() ([]models.Menu, error)
+	GetMenu(id uint) (*models.Menu, error)
+	GetPublicMenu(handle string) ([]MenuLink, error)
+	CreateMenu(menu *models.Menu) error
+	UpdateMenu(menu *models.Menu) error
+	ReplaceItems(menu *models.Menu, items []models.MenuItem) error
+	DeleteMenu(id uint) error
+}
+
+type MenuService struct {
+	repo         repositories.IMenuRepository
+	pageRepo     repositories.IPageRepository
+	postRepo     repositories.IPostRepository
+	categoryRepo repositories.ICategoryRepository
+}
+
+// NewMenuService creates a new instance of MenuService
+// Parameters:
+//   - repo: Repository of menus and menu items
+//   - pageRepo: Repository of the pages menu items link to
+//   - postRepo: Repository of the posts menu items link to
+//   - categoryRepo: Repository of the categories menu items link to
+//
+// Returns:
+//   - *MenuService: New MenuService instance initialized with the provided repositories
+func NewMenuService(
+	repo repositories.IMenuRepository,
+	pageRepo repositories.IPageRepository,
+	postRepo repositories.IPostRepository,
+	categoryRepo repositories.ICategoryRepository,
+) *MenuService {
+	return &MenuService{
+		repo:         repo,
+		pageRepo:     pageRepo,
+		postRepo:     postRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// menuTargets holds the records menu items link to, keyed by ID
+type menuTargets struct {
+	pages      map[uint]models.Page
+	posts      map[uint]models.Post
+	categories map[uint]models.Category
+}
+
+// GetMenus retrieves every menu ordered by name, without their items
+func (service *MenuService) GetMenus() ([]models.Menu, error) {
+	menus, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return menus, nil
+}
+
+// GetMenu retrieves a menu by its ID together with its tree of items
+// Parameters:
+//   - id: The ID of the menu
+//
+// Returns:
+//   - *models.Menu: The menu, its root items hold their nested items
+//   - error: NotFound if the menu does not exist, DBQuery error otherwise
+func (service *MenuService) GetMenu(id uint) (*models.Menu, error) {
+	menu, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	items, err := service.repo.GetItems(menu.ID)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	menu.Items = menuItemTree(items)
+	return menu, nil
+}
+
+// GetPublicMenu resolves the items of a menu to the URLs of their targets
+// Items linking to records that were deleted or are not published are left out together with their nested items
+// Parameters:
+//   - handle: The handle of the menu, e.g. "main"
+//
+// Returns:
+//   - []MenuLink: The root links with their nested links, ordered by position
+//   - error: NotFound if no menu has this handle, DBQuery error otherwise
+func (service *MenuService) GetPublicMenu(handle string) ([]MenuLink, error) {
+	menu, err := service.repo.FindByHandle(handle)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	items, err := service.repo.GetItems(menu.ID)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	targets, err := service.loadTargets(items)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolve func(items []models.MenuItem) []MenuLink
+	resolve = func(items []models.MenuItem) []MenuLink {
+		links := []MenuLink{}
+		for _, item := range items {
+			link, ok := targets.link(item)
+			if !ok {
+				continue
+			}
+			link.Children = resolve(item.Children)
+			links = append(links, link)
+		}
+		return links
+	}
+	return resolve(menuItemTree(items)), nil
+}
+
+// CreateMenu stores a new menu without items
+// Parameters:
+//   - menu: The menu to create, its handle is generated from the name when empty
+//
+// Returns:
+//   - error: ValidationError if the handle is invalid or taken, DBInsert error otherwise
+func (service *MenuService) CreateMenu(menu *models.Menu) error {
+	if err := service.prepare(menu); err != nil {
+		return err
+	}
+	if err := service.repo.Create(menu); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateMenu saves the name and handle of a menu
+// Parameters:
+//   - menu: The menu to save, its handle is generated from the name when empty
+//
+// Returns:
+//   - error: ValidationError if the handle is invalid or taken, DBUpdate error otherwise
+func (service *MenuService) UpdateMenu(menu *models.Menu) error {
+	if err := service.prepare(menu); err != nil {
+		return err
+	}
+	if err := service.repo.Update(menu); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// ReplaceItems replaces the items of a menu by a new tree of items
+// Parameters:
+//   - menu: The menu, its Items are set to the saved tree on success
+//   - items: The root items, nested items are given in their Children
+//
+// Returns:
+//   - error: ValidationError if items are nested too deeply, miss their target or URL,
+//     or link to records that do not exist, DBUpdate error otherwise
+func (service *MenuService) ReplaceItems(menu *models.Menu, items []models.MenuItem) error {
+	var flat []models.MenuItem
+	var collect func(items []models.MenuItem)
+	collect = func(items []models.MenuItem) {
+		for _, item := range items {
+			flat = append(flat, item)
+			collect(item.Children)
+		}
+	}
+	collect(items)
+
+	targets, err := service.loadTargets(flat)
+	if err != nil {
+		return err
+	}
+
+	var fields []apperror.FieldError
+	var validate func(items []models.MenuItem, prefix string, depth int)
+	validate = func(items []models.MenuItem, prefix string, depth int) {
+		for i := range items {
+			field := fmt.Sprintf("%s[%d]", prefix, i)
+			if depth > maxMenuDepth {
+				fields = append(fields, apperror.FieldError{
+					Field:   field,
+					Message: fmt.Sprintf("menu items must not be nested more than %d levels deep", maxMenuDepth),
+				})
+				continue
+			}
+			fields = append(fields, targets.validate(&items[i], field)...)
+			validate(items[i].Children, field+".children", depth+1)
+		}
+	}
+	validate(items, "items", 1)
+	if len(fields) > 0 {
+		return apperror.NewValidationError("Validation failed", fields)
+	}
+
+	if err := service.repo.ReplaceItems(menu.ID, items); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	menu.Items = items
+	return nil
+}
+
+// DeleteMenu removes a menu together with its items
+func (service *MenuService) DeleteMenu(id uint) error {
+	if err := service.repo.Delete(id); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// prepare resolves the handle of a menu before it is saved
+func (service *MenuService) prepare(menu *models.Menu) error {
+	handle, err := resolveSlug(menu.Handle, menu.Name, "menu", func(handle string) (bool, error) {
+		return service.repo.HandleExists(handle, menu.ID)
+	})
+	if err != nil {
+		// Menus call their slug a handle
+		var validationErr *apperror.ValidationError
+		if errors.As(err, &validationErr) {
+			for i := range validationErr.Fields {
+				validationErr.Fields[i].Field = "handle"
+			}
+		}
+		return err
+	}
+	menu.Handle = handle
+	return nil
+}
+
+// loadTargets loads the pages, posts and categories the given menu items link to
+func (service *MenuService) loadTargets(items []models.MenuItem) (*menuTargets, error) {
+	targets := &menuTargets{
+		pages:      map[uint]models.Page{},
+		posts:      map[uint]models.Post{},
+		categories: map[uint]models.Category{},
+	}
+
+	var postIDs []uint
+	var hasPages, hasCategories bool
+	for _, item := range items {
+		switch item.Type {
+		case models.MenuItemTypePage:
+			hasPages = true
+		case models.MenuItemTypeCategory:
+			hasCategories = true
+		case models.MenuItemTypePost:
+			if item.TargetID != nil {
+				postIDs = append(postIDs, *item.TargetID)
+			}
+		}
+	}
+
+	if hasPages {
+		pages, err := service.pageRepo.GetAll()
+		if err != nil {
+			return nil, apperror.NewDBQueryError(err.Error())
+		}
+		for _, page := range pages {
+			targets.pages[page.ID] = page
+		}
+	}
+	if len(postIDs) > 0 {
+		posts, err := service.postRepo.FindByIDs(postIDs)
+		if err != nil {
+			return nil, apperror.NewDBQueryError(err.Error())
+		}
+		for _, post := range posts {
+			targets.posts[post.ID] = post
+		}
+	}
+	if hasCategories {
+		categories, err := service.categoryRepo.GetAll()
+		if err != nil {
+			return nil, apperror.NewDBQueryError(err.Error())
+		}
+		for _, category := range categories {
+			targets.categories[category.ID] = category
+		}
+	}
+	return targets, nil
+}
+
+// validate checks that a menu item has a URL or an existing target, the fields of the unused kind are cleared
+func (targets *menuTargets) validate(item *models.MenuItem, field string) []apperror.FieldError {
+	if !slices.Contains(models.MenuItemTypes, item.Type) {
+		return []apperror.FieldError{{Field: field + ".type", Message: fmt.Sprintf("type must be one of %v", models.MenuItemTypes)}}
+	}
+
+	if item.Type == models.MenuItemTypeURL {
+		item.TargetID = nil
+		var fields []apperror.FieldError
+		if item.URL == nil || !isMenuURL(*item.URL) {
+			fields = append(fields, apperror.FieldError{Field: field + ".url", Message: "url must be an absolute http(s) URL or a path starting with /"})
+		}
+		if item.Label == nil || strings.TrimSpace(*item.Label) == "" {
+			fields = append(fields, apperror.FieldError{Field: field + ".label", Message: "label is required for url items"})
+		}
+		return fields
+	}
+
+	item.URL = nil
+	if item.TargetID == nil {
+		return []apperror.FieldError{{Field: field + ".target_id", Message: fmt.Sprintf("target_id is required for %s items", item.Type)}}
+	}
+
+	var exists bool
+	switch item.Type {
+	case models.MenuItemTypePage:
+		_, exists = targets.pages[*item.TargetID]
+	case models.MenuItemTypePost:
+		_, exists = targets.posts[*item.TargetID]
+	case models.MenuItemTypeCategory:
+		_, exists = targets.categories[*item.TargetID]
+	}
+	if !exists {
+		return []apperror.FieldError{{Field: field + ".target_id", Message: fmt.Sprintf("%s does not exist", item.Type)}}
+	}
+	return nil
+}
+
+// link resolves a menu item to the URL of its target, false if the target is missing or not published
+func (targets *menuTargets) link(item models.MenuItem) (MenuLink, bool) {
+	link := MenuLink{Type: item.Type, OpenInNewTab: item.OpenInNewTab}
+	title := ""
+
+	switch item.Type {
+	case models.MenuItemTypeURL:
+		if item.URL == nil {
+			return link, false
+		}
+		link.URL = *item.URL
+	case models.MenuItemTypePage:
+		page, ok := targets.pages[derefUint(item.TargetID)]
+		if !ok || page.Status != models.PageStatusPublished {
+			return link, false
+		}
+		link.URL, title = "/"+page.Path, page.Title
+	case models.MenuItemTypePost:
+		post, ok := targets.posts[derefUint(item.TargetID)]
+		if !ok || post.Status != models.PostStatusPublished {
+			return link, false
+		}
+		link.URL, title = menuPostPrefix+post.Slug, post.Title
+	case models.MenuItemTypeCategory:
+		category, ok := targets.categories[derefUint(item.TargetID)]
+		if !ok {
+			return link, false
+		}
+		link.URL, title = menuCategoryPrefix+category.Slug, category.Name
+	default:
+		return link, false
+	}
+
+	link.Label = title
+	if item.Label != nil && *item.Label != "" {
+		link.Label = *item.Label
+	}
+	return link, true
+}
+
+// menuItemTree nests menu items under their parents, the items must be ordered by position
+func menuItemTree(items []models.MenuItem) []models.MenuItem {
+	children := make(map[uint][]models.MenuItem)
+	roots := []models.MenuItem{}
+	for _, item := range items {
+		if item.ParentID == nil {
+			roots = append(roots, item)
+		} else {
+			children[*item.ParentID] = append(children[*item.ParentID], item)
+		}
+	}
+
+	var attach func(nodes []models.MenuItem) []models.MenuItem
+	attach = func(nodes []models.MenuItem) []models.MenuItem {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots)
+}
+
+// isMenuURL reports whether a menu URL is an absolute http(s) URL or a path of the site
+func isMenuURL(value string) bool {
+	if strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "//") {
+		return true
+	}
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// derefUint returns the value of an optional ID, 0 when it is not set
+func derefUint(value *uint) uint {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -0,0 +1,176 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type MenuServiceTestSuite struct {
+	suite.Suite
+	repo         *mocks.MockMenuRepository
+	pageRepo     *mocks.MockPageRepository
+	postRepo     *mocks.MockPostRepository
+	categoryRepo *mocks.MockCategoryRepository
+	service      *services.MenuService
+}
+
+func (s *MenuServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockMenuRepository)
+	s.pageRepo = new(mocks.MockPageRepository)
+	s.postRepo = new(mocks.MockPostRepository)
+	s.categoryRepo = new(mocks.MockCategoryRepository)
+	s.service = services.NewMenuService(s.repo, s.pageRepo, s.postRepo, s.categoryRepo)
+}
+
+func (s *MenuServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.pageRepo.AssertExpectations(s.T())
+	s.postRepo.AssertExpectations(s.T())
+	s.categoryRepo.AssertExpectations(s.T())
+}
+
+func (s *MenuServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *MenuServiceTestSuite) validationFields(err error) []string {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	fields := make([]string, len(validationErr.Fields))
+	for i, field := range validationErr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func (s *MenuServiceTestSuite) TestGetPublicMenu() {
+	s.Run("Success", func() {
+		menuID, about := uint(1), uint(10)
+		pageID, draftPageID, postID, draftPostID, categoryID := uint(1), uint(2), uint(5), uint(6), uint(7)
+		s.repo.On("FindByHandle", "main").Return(&models.Menu{ID: menuID, Handle: "main"}, nil).Once()
+		s.repo.On("GetItems", menuID).Return([]models.MenuItem{
+			{ID: 10, Type: models.MenuItemTypePage, TargetID: &pageID},
+			{ID: 11, Type: models.MenuItemTypeURL, Label: utils.StringToPtr("Docs"), URL: utils.StringToPtr("https://docs.example.com"), OpenInNewTab: true, Position: 1},
+			{ID: 12, Type: models.MenuItemTypePost, TargetID: &postID, ParentID: &about, Label: utils.StringToPtr("Launch")},
+			{ID: 13, Type: models.MenuItemTypeCategory, TargetID: &categoryID, ParentID: &about, Position: 1},
+			{ID: 14, Type: models.MenuItemTypePage, TargetID: &draftPageID, ParentID: &about, Position: 2},
+			{ID: 15, Type: models.MenuItemTypePost, TargetID: &draftPostID, ParentID: &about, Position: 3},
+		}, nil).Once()
+		s.pageRepo.On("GetAll").Return([]models.Page{
+			{ID: 1, Title: "About", Path: "about", Status: models.PageStatusPublished},
+			{ID: 2, Title: "Team", Path: "about/team", Status: models.PageStatusDraft},
+		}, nil).Once()
+		s.postRepo.On("FindByIDs", []uint{5, 6}).Return([]models.Post{
+			{ID: 5, Title: "We launched", Slug: "we-launched", Status: models.PostStatusPublished},
+			{ID: 6, Title: "Draft", Slug: "draft", Status: models.PostStatusDraft},
+		}, nil).Once()
+		s.categoryRepo.On("GetAll").Return([]models.Category{{ID: 7, Name: "News", Slug: "news"}}, nil).Once()
+
+		links, err := s.service.GetPublicMenu("main")
+		s.Require().NoError(err)
+		s.Require().Len(links, 2)
+		s.Equal(services.MenuLink{Label: "About", URL: "/about", Type: models.MenuItemTypePage, Children: []services.MenuLink{
+			{Label: "Launch", URL: "/posts/we-launched", Type: models.MenuItemTypePost, Children: []services.MenuLink{}},
+			{Label: "News", URL: "/categories/news", Type: models.MenuItemTypeCategory, Children: []services.MenuLink{}},
+		}}, links[0])
+		s.Equal("https://docs.example.com", links[1].URL)
+		s.True(links[1].OpenInNewTab)
+	})
+
+	s.Run("Error not found", func() {
+		s.repo.On("FindByHandle", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+		_, err := s.service.GetPublicMenu("missing")
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *MenuServiceTestSuite) TestReplaceItems() {
+	s.Run("Success", func() {
+		menu := &models.Menu{ID: 1}
+		pageID := uint(1)
+		items := []models.MenuItem{
+			{Type: models.MenuItemTypePage, TargetID: &pageID, URL: utils.StringToPtr("/ignored"), Children: []models.MenuItem{
+				{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("Blog"), URL: utils.StringToPtr("/blog"), TargetID: &pageID},
+			}},
+		}
+		s.pageRepo.On("GetAll").Return([]models.Page{{ID: 1, Path: "about"}}, nil).Once()
+		s.repo.On("ReplaceItems", uint(1), mock.Anything).Return(nil).Once()
+
+		s.Require().NoError(s.service.ReplaceItems(menu, items))
+		s.Nil(items[0].URL, "page items do not keep a URL")
+		s.Nil(items[0].Children[0].TargetID, "url items do not keep a target")
+		s.Len(menu.Items, 1)
+	})
+
+	s.Run("Error invalid items", func() {
+		missingPage := uint(9)
+		items := []models.MenuItem{
+			{Type: models.MenuItemTypePage, TargetID: &missingPage},
+			{Type: models.MenuItemTypeURL, URL: utils.StringToPtr("javascript:alert(1)")},
+			{Type: models.MenuItemTypeCategory, Children: []models.MenuItem{
+				{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("A"), URL: utils.StringToPtr("/a"), Children: []models.MenuItem{
+					{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("B"), URL: utils.StringToPtr("/b"), Children: []models.MenuItem{
+						{Type: models.MenuItemTypeURL, Label: utils.StringToPtr("C"), URL: utils.StringToPtr("/c")},
+					}},
+				}},
+			}},
+		}
+		s.pageRepo.On("GetAll").Return([]models.Page{}, nil).Once()
+		s.categoryRepo.On("GetAll").Return([]models.Category{}, nil).Once()
+
+		err := s.service.ReplaceItems(&models.Menu{ID: 1}, items)
+		s.Equal([]string{
+			"items[0].target_id",
+			"items[1].url",
+			"items[1].label",
+			"items[2].target_id",
+			"items[2].children[0].children[0].children[0]",
+		}, s.validationFields(err))
+	})
+}
+
+func (s *MenuServiceTestSuite) TestCreateMenu() {
+	s.Run("Success handle from name", func() {
+		menu := &models.Menu{Name: "Main Menu"}
+		s.repo.On("HandleExists", "main-menu", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", menu).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreateMenu(menu))
+		s.Equal("main-menu", menu.Handle)
+	})
+
+	s.Run("Error handle taken", func() {
+		s.repo.On("HandleExists", "footer", uint(0)).Return(true, nil).Once()
+		err := s.service.CreateMenu(&models.Menu{Name: "Footer", Handle: "footer"})
+		s.Equal([]string{"handle"}, s.validationFields(err))
+	})
+}
+
+func (s *MenuServiceTestSuite) TestGetMenu() {
+	parentID := uint(1)
+	s.repo.On("GetByID", uint(3)).Return(&models.Menu{ID: 3}, nil).Once()
+	s.repo.On("GetItems", uint(3)).Return([]models.MenuItem{
+		{ID: 1, Type: models.MenuItemTypeURL},
+		{ID: 2, Type: models.MenuItemTypeURL, ParentID: &parentID},
+	}, nil).Once()
+
+	menu, err := s.service.GetMenu(3)
+	s.Require().NoError(err)
+	s.Require().Len(menu.Items, 1)
+	s.Len(menu.Items[0].Children, 1)
+}
+
+func TestMenuServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MenuServiceTestSuite))
+}
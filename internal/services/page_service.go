@@ -0,0 +1,342 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+const (
+	maxPagePath     = 700 // Length of the path column
+	maxPageTemplate = 50  // Length of the template column
+)
+
+type IPageService interface {
+	GetTree() ([]models.Page, error)
+	GetPage(id uint) (*models.Page, error)
+	GetPublishedPage(path string) (*models.Page, error)
+	CreatePage(page *models.Page) error
+	UpdatePage(page *models.Page) error
+	MovePage(id uint, parentID *uint, position *int) (*models.Page, error)
+	DeletePage(id uint) error
+}
+
+type PageService struct {
+	repo repositories.IPageRepository
+}
+
+// NewPageService creates a new instance of PageService
+// Parameters:
+//   - repo: Repository of pages
+//
+// Returns:
+//   - *PageService: New PageService instance initialized with the provided repository
+func NewPageService(repo repositories.IPageRepository) *PageService {
+	return &PageService{
+		repo: repo,
+	}
+}
+
+// GetTree retrieves every page nested under its parent, without their bodies
+// Returns:
+//   - []models.Page: The root pages with their children, ordered by position
+//   - error: DBQuery error if the pages cannot be loaded
+func (service *PageService) GetTree() ([]models.Page, error) {
+	pages, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	children := make(map[uint][]models.Page)
+	roots := []models.Page{}
+	for _, page := range pages {
+		if page.ParentID == nil {
+			roots = append(roots, page)
+		} else {
+			children[*page.ParentID] = append(children[*page.ParentID], page)
+		}
+	}
+
+	var attach func(nodes []models.Page) []models.Page
+	attach = func(nodes []models.Page) []models.Page {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+	return attach(roots), nil
+}
+
+// GetPage retrieves a page by its ID
+func (service *PageService) GetPage(id uint) (*models.Page, error) {
+	page, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return page, nil
+}
+
+// GetPublishedPage retrieves a published page by its full path, e.g. "about/team"
+func (service *PageService) GetPublishedPage(path string) (*models.Page, error) {
+	page, err := service.repo.FindPublishedByPath(path)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return page, nil
+}
+
+// CreatePage stores a new page as the last child of its parent
+// Parameters:
+//   - page: The page to create, its slug is generated from the title when empty
+//
+// Returns:
+//   - error: ValidationError if the parent does not exist, the template is invalid or the slug is invalid
+//     or taken under the parent, DBInsert error otherwise
+func (service *PageService) CreatePage(page *models.Page) error {
+	pages, err := service.repo.GetAll()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if err := service.prepare(page, pages); err != nil {
+		return err
+	}
+
+	page.Position = len(pageChildIDs(pages, page.ParentID, 0))
+	if err := service.repo.Create(page); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdatePage saves a page, the paths of its descendants follow a changed slug
+// Its place in the tree is changed by MovePage
+// Parameters:
+//   - page: The page to save, its slug is generated from the title when empty, only saved while its Version is the stored one
+//
+// Returns:
+//   - error: ValidationError if the template is invalid or the slug is invalid or taken under the parent,
+//     VersionConflict error if the page was saved or moved since its version was loaded, DBUpdate error otherwise
+func (service *PageService) UpdatePage(page *models.Page) error {
+	pages, err := service.repo.GetAll()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if err := service.prepare(page, pages); err != nil {
+		return err
+	}
+
+	// The descendants only move when the slug of the page changed
+	paths := map[uint]string{}
+	current := slices.IndexFunc(pages, func(existing models.Page) bool { return existing.ID == page.ID })
+	if current >= 0 && pages[current].Path != page.Path {
+		paths = pageSubtreePaths(pages, page.ID, page.Path)
+		delete(paths, page.ID)
+		if err := checkPagePaths(paths); err != nil {
+			return err
+		}
+	}
+
+	if err := service.repo.Update(page, paths); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return apperror.NewVersionConflictError("The page was changed or moved by someone else, reload it and try again")
+		}
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// MovePage places a page under another parent or at another position among its siblings
+// The paths of the page and of its descendants are rebuilt under the new parent
+// Parameters:
+//   - id: The ID of the page to move
+//   - parentID: The ID of the new parent, nil to make the page a root
+//   - position: The index of the page among its new siblings, nil to place it last
+//
+// Returns:
+//   - *models.Page: The moved page, without its body
+//   - error: NotFound if the page does not exist, ValidationError if the parent does not exist, is the page itself
+//     or one of its descendants, or already has a child with the same slug, DBUpdate error otherwise
+func (service *PageService) MovePage(id uint, parentID *uint, position *int) (*models.Page, error) {
+	pages, err := service.repo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	index := slices.IndexFunc(pages, func(page models.Page) bool { return page.ID == id })
+	if index < 0 {
+		return nil, apperror.NewNotFoundError("Page not found")
+	}
+	page := pages[index]
+
+	parentPath := ""
+	if parentID != nil {
+		parent := slices.IndexFunc(pages, func(existing models.Page) bool { return existing.ID == *parentID })
+		if parent < 0 {
+			return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "parent_id", Message: "parent page does not exist"},
+			})
+		}
+		if slices.Contains(pageSubtreeIDs(pages, id), *parentID) {
+			return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "parent_id", Message: "a page cannot be moved under itself or one of its descendants"},
+			})
+		}
+		parentPath = pages[parent].Path
+	}
+
+	path := joinPagePath(parentPath, page.Slug)
+	taken, err := service.repo.PathExists(path, id)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	if taken {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "parent_id", Message: "the parent already has a page with the same slug"},
+		})
+	}
+
+	paths := pageSubtreePaths(pages, id, path)
+	if err := checkPagePaths(paths); err != nil {
+		return nil, err
+	}
+
+	siblings := pageChildIDs(pages, parentID, id)
+	at := len(siblings)
+	if position != nil && *position < at {
+		at = max(*position, 0)
+	}
+	siblings = slices.Insert(siblings, at, id)
+
+	if err := service.repo.UpdatePositions(parentID, siblings, paths); err != nil {
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+
+	page.ParentID = parentID
+	page.Position = at
+	page.Path = path
+	return &page, nil
+}
+
+// DeletePage removes a page without subpages
+// Parameters:
+//   - id: The ID of the page
+//
+// Returns:
+//   - error: BadRequest if the page still has subpages, DBDelete error otherwise
+func (service *PageService) DeletePage(id uint) error {
+	pages, err := service.repo.GetAll()
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if len(pageChildIDs(pages, &id, 0)) > 0 {
+		return apperror.NewBadRequestError("Page has subpages, move or delete them first")
+	}
+	if err := service.repo.Delete(id); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// prepare validates the body, parent and template of a page and resolves its slug and path before it is saved
+func (service *PageService) prepare(page *models.Page, pages []models.Page) error {
+	if err := validateBody(page.Body, page.BodyFormat); err != nil {
+		return err
+	}
+
+	parentPath := ""
+	if page.ParentID != nil {
+		parent := slices.IndexFunc(pages, func(existing models.Page) bool { return existing.ID == *page.ParentID })
+		if parent < 0 {
+			return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "parent_id", Message: "parent page does not exist"},
+			})
+		}
+		parentPath = pages[parent].Path
+	}
+
+	// Templates are layout keys of the frontend, e.g. "landing" or "full-width"
+	if page.Template == "" {
+		page.Template = models.PageTemplateDefault
+	}
+	if utils.Slugify(page.Template) != page.Template || len(page.Template) > maxPageTemplate {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "template", Message: "template must only contain lowercase letters, digits and hyphens"},
+		})
+	}
+
+	slug, err := resolveSlug(page.Slug, page.Title, "page", func(slug string) (bool, error) {
+		return service.repo.PathExists(joinPagePath(parentPath, slug), page.ID)
+	})
+	if err != nil {
+		return err
+	}
+	page.Slug = slug
+	page.Path = joinPagePath(parentPath, slug)
+	return checkPagePaths(map[uint]string{page.ID: page.Path})
+}
+
+// checkPagePaths rejects paths that do not fit in the path column
+func checkPagePaths(paths map[uint]string) error {
+	for _, path := range paths {
+		if len(path) > maxPagePath {
+			return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "slug", Message: fmt.Sprintf("the full path of a page must not be longer than %d characters", maxPagePath)},
+			})
+		}
+	}
+	return nil
+}
+
+// joinPagePath builds the path of a page from the path of its parent and its slug
+func joinPagePath(parentPath, slug string) string {
+	if parentPath == "" {
+		return slug
+	}
+	return parentPath + "/" + slug
+}
+
+// pageChildIDs lists in order the IDs of the children of a parent, leaving out the excluded page
+func pageChildIDs(pages []models.Page, parentID *uint, excludeID uint) []uint {
+	ids := []uint{}
+	for _, page := range pages {
+		if page.ID == excludeID {
+			continue
+		}
+		if (parentID == nil && page.ParentID == nil) ||
+			(parentID != nil && page.ParentID != nil && *parentID == *page.ParentID) {
+			ids = append(ids, page.ID)
+		}
+	}
+	return ids
+}
+
+// pageSubtreeIDs lists the ID of a page followed by the IDs of all its descendants
+func pageSubtreeIDs(pages []models.Page, id uint) []uint {
+	ids := []uint{id}
+	for i := 0; i < len(ids); i++ {
+		parentID := ids[i]
+		ids = append(ids, pageChildIDs(pages, &parentID, 0)...)
+	}
+	return ids
+}
+
+// pageSubtreePaths rebuilds the paths of a page and of all its descendants when the page gets the given path
+func pageSubtreePaths(pages []models.Page, id uint, path string) map[uint]string {
+	slugs := make(map[uint]string, len(pages))
+	for _, page := range pages {
+		slugs[page.ID] = page.Slug
+	}
+
+	paths := map[uint]string{id: path}
+	for _, parentID := range pageSubtreeIDs(pages, id) {
+		for _, childID := range pageChildIDs(pages, &parentID, 0) {
+			paths[childID] = joinPagePath(paths[parentID], slugs[childID])
+		}
+	}
+	return paths
+}
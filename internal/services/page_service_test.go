@@ -0,0 +1,199 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type PageServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockPageRepository
+	service *services.PageService
+}
+
+func (s *PageServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockPageRepository)
+	s.service = services.NewPageService(s.repo)
+}
+
+func (s *PageServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *PageServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *PageServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+// pageTree returns the pages about > team > leadership and contact, ordered as the repository returns them
+func pageTree() []models.Page {
+	about, team := uint(1), uint(2)
+	return []models.Page{
+		{ID: 1, Title: "About", Slug: "about", Path: "about", Position: 0},
+		{ID: 2, Title: "Team", Slug: "team", Path: "about/team", ParentID: &about, Position: 0},
+		{ID: 3, Title: "Leadership", Slug: "leadership", Path: "about/team/leadership", ParentID: &team, Position: 0},
+		{ID: 4, Title: "Contact", Slug: "contact", Path: "contact", Position: 1},
+	}
+}
+
+func (s *PageServiceTestSuite) TestGetTree() {
+	s.repo.On("GetAll").Return(pageTree(), nil).Once()
+
+	roots, err := s.service.GetTree()
+	s.Require().NoError(err)
+	s.Require().Len(roots, 2)
+	s.Equal("about", roots[0].Path)
+	s.Require().Len(roots[0].Children, 1)
+	s.Equal("about/team/leadership", roots[0].Children[0].Children[0].Path)
+}
+
+func (s *PageServiceTestSuite) TestCreatePage() {
+	s.Run("Success nested page", func() {
+		parentID := uint(2)
+		page := &models.Page{Title: "Our History", ParentID: &parentID}
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("PathExists", "about/team/our-history", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", page).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreatePage(page))
+		s.Equal("our-history", page.Slug)
+		s.Equal("about/team/our-history", page.Path)
+		s.Equal(models.PageTemplateDefault, page.Template)
+		s.Equal(1, page.Position)
+	})
+
+	s.Run("Success slug taken among siblings gets a suffix", func() {
+		page := &models.Page{Title: "Contact", Template: "landing"}
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("PathExists", "contact", uint(0)).Return(true, nil).Once()
+		s.repo.On("PathExists", "contact-2", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", page).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreatePage(page))
+		s.Equal("contact-2", page.Path)
+		s.Equal(2, page.Position)
+	})
+
+	s.Run("Error unknown parent", func() {
+		parentID := uint(99)
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.assertFieldError(s.service.CreatePage(&models.Page{Title: "X", ParentID: &parentID}), "parent_id")
+	})
+
+	s.Run("Error invalid template", func() {
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.assertFieldError(s.service.CreatePage(&models.Page{Title: "X", Template: "Full Width"}), "template")
+	})
+}
+
+func (s *PageServiceTestSuite) TestUpdatePage() {
+	s.Run("Success slug change moves descendants", func() {
+		about := pageTree()[0]
+		about.Slug = "company"
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("PathExists", "company", uint(1)).Return(false, nil).Once()
+		s.repo.On("Update", &about, map[uint]string{2: "company/team", 3: "company/team/leadership"}).Return(nil).Once()
+
+		s.Require().NoError(s.service.UpdatePage(&about))
+		s.Equal("company", about.Path)
+	})
+
+	s.Run("Success same slug keeps descendants", func() {
+		team := pageTree()[1]
+		team.Title = "Our team"
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("PathExists", "about/team", uint(2)).Return(false, nil).Once()
+		s.repo.On("Update", &team, map[uint]string{}).Return(nil).Once()
+
+		s.Require().NoError(s.service.UpdatePage(&team))
+	})
+
+	s.Run("Error slug taken", func() {
+		team := pageTree()[1]
+		team.Slug = "history"
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("PathExists", "about/history", uint(2)).Return(true, nil).Once()
+
+		s.assertFieldError(s.service.UpdatePage(&team), "slug")
+	})
+}
+
+func (s *PageServiceTestSuite) TestMovePage() {
+	s.Run("Success move subtree to root", func() {
+		position := 0
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("PathExists", "team", uint(2)).Return(false, nil).Once()
+		s.repo.On("UpdatePositions", (*uint)(nil), []uint{2, 1, 4}, map[uint]string{2: "team", 3: "team/leadership"}).Return(nil).Once()
+
+		page, err := s.service.MovePage(2, nil, &position)
+		s.Require().NoError(err)
+		s.Nil(page.ParentID)
+		s.Equal("team", page.Path)
+		s.Equal(0, page.Position)
+	})
+
+	s.Run("Error under own descendant", func() {
+		parentID := uint(3)
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		_, err := s.service.MovePage(1, &parentID, nil)
+		s.assertFieldError(err, "parent_id")
+	})
+
+	s.Run("Error parent has a page with the same slug", func() {
+		parentID := uint(1)
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("PathExists", "about/contact", uint(4)).Return(true, nil).Once()
+		_, err := s.service.MovePage(4, &parentID, nil)
+		s.assertFieldError(err, "parent_id")
+	})
+
+	s.Run("Error not found", func() {
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		_, err := s.service.MovePage(42, nil, nil)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *PageServiceTestSuite) TestDeletePage() {
+	s.Run("Error has subpages", func() {
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.assertCode(s.service.DeletePage(1), apperror.ErrBadRequest)
+	})
+
+	s.Run("Success", func() {
+		s.repo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("Delete", uint(4)).Return(nil).Once()
+		s.NoError(s.service.DeletePage(4))
+	})
+}
+
+func (s *PageServiceTestSuite) TestGetPublishedPage() {
+	s.repo.On("FindPublishedByPath", "about/team").Return(&models.Page{ID: 2}, nil).Once()
+	s.repo.On("FindPublishedByPath", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	page, err := s.service.GetPublishedPage("about/team")
+	s.Require().NoError(err)
+	s.Equal(uint(2), page.ID)
+
+	_, err = s.service.GetPublishedPage("missing")
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func TestPageServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PageServiceTestSuite))
+}
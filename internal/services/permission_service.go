@@ -0,0 +1,44 @@
+package services
+
+import (
+	"slices"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type IPermissionService interface {
+	HasPermission(userID uint, permission string) (bool, error)
+}
+
+type PermissionService struct {
+	repo repositories.IRoleRepository
+}
+
+// NewPermissionService creates a new instance of PermissionService
+// Parameters:
+//   - repo: Role repository used to resolve the permissions of a user
+//
+// Returns:
+//   - *PermissionService: New PermissionService instance initialized with the provided repository
+func NewPermissionService(repo repositories.IRoleRepository) *PermissionService {
+	return &PermissionService{
+		repo: repo,
+	}
+}
+
+// HasPermission reports whether any role of the user grants the given permission
+// Parameters:
+//   - userID: The ID of the user
+//   - permission: The permission name to check (e.g. constants.PermissionManageAttributes)
+//
+// Returns:
+//   - bool: true if the user holds the permission
+//   - error: DBQuery error if the permissions cannot be loaded
+func (service *PermissionService) HasPermission(userID uint, permission string) (bool, error) {
+	permissions, err := service.repo.GetPermissionNamesByUserID(userID)
+	if err != nil {
+		return false, apperror.NewDBQueryError(err.Error())
+	}
+	return slices.Contains(permissions, permission), nil
+}
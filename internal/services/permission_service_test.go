@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+func TestHasPermission(t *testing.T) {
+	t.Run("Granted", func(t *testing.T) {
+		repo := new(mocks.MockRoleRepository)
+		repo.On("GetPermissionNamesByUserID", uint(1)).Return([]string{"users.view", "attributes.manage"}, nil)
+
+		allowed, err := services.NewPermissionService(repo).HasPermission(1, "attributes.manage")
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Denied", func(t *testing.T) {
+		repo := new(mocks.MockRoleRepository)
+		repo.On("GetPermissionNamesByUserID", uint(2)).Return([]string{}, nil)
+
+		allowed, err := services.NewPermissionService(repo).HasPermission(2, "attributes.manage")
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		repo := new(mocks.MockRoleRepository)
+		repo.On("GetPermissionNamesByUserID", uint(3)).Return([]string(nil), errors.New("db error"))
+
+		allowed, err := services.NewPermissionService(repo).HasPermission(3, "attributes.manage")
+		assert.False(t, allowed)
+		appErr, ok := apperror.ToAppError(err)
+		assert.True(t, ok)
+		assert.Equal(t, apperror.ErrDBQuery, appErr.Code)
+	})
+}
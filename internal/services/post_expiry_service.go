@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+// postExpiryBatchSize is the maximum number of posts expired or notified about by one run of a worker
+const postExpiryBatchSize = 50
+
+// PostExpiryInput holds the time a post is taken offline and how
+type PostExpiryInput struct {
+	ExpireAt time.Time
+	Action   string // archive or unpublish, archive when empty
+}
+
+type IPostExpiryService interface {
+	SetExpiry(postID uint, input PostExpiryInput) (*models.Post, error)
+	ClearExpiry(postID uint) (*models.Post, error)
+	ExpireDue(ctx context.Context) error
+	NotifyExpiring(ctx context.Context) error
+}
+
+type PostExpiryService struct {
+	repo          repositories.IPostRepository
+	mailerService IMailerService
+	noticePeriod  time.Duration
+}
+
+// NewPostExpiryService creates a new instance of PostExpiryService
+// Parameters:
+//   - repo: Repository of posts and their workflow history
+//   - mailerService: Service warning the authors of the posts about to expire
+//   - noticePeriod: How long before the expiry of a post its author is warned, 0 disables the warnings
+//
+// Returns:
+//   - *PostExpiryService: New PostExpiryService instance initialized with the provided dependencies
+func NewPostExpiryService(repo repositories.IPostRepository, mailerService IMailerService, noticePeriod time.Duration) *PostExpiryService {
+	return &PostExpiryService{
+		repo:          repo,
+		mailerService: mailerService,
+		noticePeriod:  noticePeriod,
+	}
+}
+
+// SetExpiry schedules the time a post is taken offline, replacing its previous expiry
+// The author is warned again about the new expiry
+// Parameters:
+//   - postID: The ID of the post
+//   - input: The expiry time and the action applied once it has passed
+//
+// Returns:
+//   - *models.Post: The post with its new expiry
+//   - error: NotFound if the post does not exist, InvalidTransition if the post is archived or changed concurrently,
+//     ValidationError if the time is not in the future or not after the scheduled publication
+func (service *PostExpiryService) SetExpiry(postID uint, input PostExpiryInput) (*models.Post, error) {
+	action := input.Action
+	if action == "" {
+		action = models.PostActionArchive
+	}
+	if !slices.Contains(models.PostExpireActions, action) {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "action", Message: "action must be archive or unpublish"},
+		})
+	}
+
+	post, err := service.repo.GetByID(postID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	if post.Status == models.PostStatusArchived {
+		return nil, apperror.NewInvalidTransitionError("Cannot set the expiry of an archived post")
+	}
+
+	if !input.ExpireAt.After(time.Now()) {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "expire_at", Message: "expire_at must be in the future"},
+		})
+	}
+	if post.Status == models.PostStatusScheduled && post.PublishAt != nil && !input.ExpireAt.After(*post.PublishAt) {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "expire_at", Message: "expire_at must be after the scheduled publication of the post"},
+		})
+	}
+
+	expireAt := input.ExpireAt
+	post.ExpireAt = &expireAt
+	post.ExpireAction = &action
+	post.ExpiryNotifiedAt = nil
+	if err := service.updateExpiry(post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// ClearExpiry removes the expiry of a post, it then stays online until it is archived or unpublished by hand
+// Parameters:
+//   - postID: The ID of the post
+//
+// Returns:
+//   - *models.Post: The post without expiry
+//   - error: NotFound if the post does not exist, InvalidTransition if the post was changed concurrently, DBUpdate error otherwise
+func (service *PostExpiryService) ClearExpiry(postID uint) (*models.Post, error) {
+	post, err := service.repo.GetByID(postID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	clearExpiry(post)
+	if err := service.updateExpiry(post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// updateExpiry saves the expiry of a post and maps the repository errors
+func (service *PostExpiryService) updateExpiry(post *models.Post) error {
+	if err := service.repo.UpdateExpiry(post); err != nil {
+		if errors.Is(err, repositories.ErrPostStatusChanged) {
+			return apperror.NewInvalidTransitionError("The post was changed by someone else, reload it and try again")
+		}
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// ExpireDue archives or unpublishes the published posts whose expiry has passed, run by a background worker
+// The transitions are recorded without a user, like the ones of the scheduler
+// Parameters:
+//   - ctx: Context cancelled when the application stops
+//
+// Returns:
+//   - error: DBQuery error if the expired posts cannot be loaded
+func (service *PostExpiryService) ExpireDue(ctx context.Context) error {
+	posts, err := service.repo.FindDueExpiring(time.Now(), postExpiryBatchSize)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	for i := range posts {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		post := &posts[i]
+		action := models.PostActionArchive
+		if post.ExpireAction != nil {
+			action = *post.ExpireAction
+		}
+		if !slices.Contains(models.PostExpireActions, action) {
+			logger.Errorf("Unknown expire action %q of post %d", action, post.ID)
+			continue
+		}
+		rule := postWorkflow[action]
+
+		transition := &models.PostTransition{
+			PostID:     post.ID,
+			Action:     action,
+			FromStatus: post.Status,
+			ToStatus:   rule.to,
+		}
+		post.Status = rule.to
+		clearExpiry(post)
+
+		if err := service.repo.ApplyTransition(post, transition); err != nil {
+			// The post was taken offline in the meantime
+			if errors.Is(err, repositories.ErrPostStatusChanged) {
+				continue
+			}
+			logger.Errorf("Failed to expire post %d: %v", post.ID, err)
+		}
+	}
+	return nil
+}
+
+// NotifyExpiring emails the authors of the posts expiring within the notice period, each expiry is notified once
+// Each post is claimed before its email is sent so concurrent workers never warn an author twice,
+// posts without an author stay claimed and a failed email is logged and sent again on the next run
+// Parameters:
+//   - ctx: Context cancelled when the application stops
+//
+// Returns:
+//   - error: DBQuery error if the expiring posts cannot be loaded
+func (service *PostExpiryService) NotifyExpiring(ctx context.Context) error {
+	if service.noticePeriod <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	posts, err := service.repo.FindExpiringUnnotified(now.Add(service.noticePeriod), postExpiryBatchSize)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	for i := range posts {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		post := &posts[i]
+		claimed, err := service.repo.ClaimExpiryNotification(post.ID, now)
+		if err != nil {
+			logger.Errorf("Failed to mark the expiry of post %d as notified: %v", post.ID, err)
+			continue
+		}
+		// Another worker notifies this post
+		if !claimed {
+			continue
+		}
+		// Nobody can be warned, the post is left marked so it does not hold back the next ones
+		if post.Author == nil {
+			logger.Errorf("Post %d has no author to warn about its expiry", post.ID)
+			continue
+		}
+		if err := service.mailerService.SendMailPostExpiring(post.Author, post); err != nil {
+			logger.Errorf("Failed to warn the author of post %d about its expiry: %v", post.ID, err)
+			if err := service.repo.ReleaseExpiryNotification(post.ID); err != nil {
+				logger.Errorf("Failed to release the expiry notification of post %d: %v", post.ID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type PostExpiryServiceTestSuite struct {
+	suite.Suite
+	repo          *mocks.MockPostRepository
+	mailerService *mocks.MockMailerService
+	service       *services.PostExpiryService
+}
+
+func (s *PostExpiryServiceTestSuite) SetupTest() {
+	logger.Init()
+	s.repo = new(mocks.MockPostRepository)
+	s.mailerService = new(mocks.MockMailerService)
+	s.service = services.NewPostExpiryService(s.repo, s.mailerService, 72*time.Hour)
+}
+
+func (s *PostExpiryServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.mailerService.AssertExpectations(s.T())
+}
+
+func (s *PostExpiryServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *PostExpiryServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+func (s *PostExpiryServiceTestSuite) TestSetExpiry() {
+	s.Run("Success", func() {
+		notifiedAt := time.Now()
+		post := &models.Post{ID: 1, Status: models.PostStatusPublished, ExpiryNotifiedAt: &notifiedAt}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("UpdateExpiry", post).Return(nil).Once()
+
+		expireAt := time.Now().Add(24 * time.Hour)
+		result, err := s.service.SetExpiry(1, services.PostExpiryInput{ExpireAt: expireAt, Action: models.PostActionUnpublish})
+		s.Require().NoError(err)
+		s.Equal(expireAt, *result.ExpireAt)
+		s.Equal(models.PostActionUnpublish, *result.ExpireAction)
+		s.Nil(result.ExpiryNotifiedAt, "the author is warned about the new expiry")
+	})
+
+	s.Run("Archive by default", func() {
+		post := &models.Post{ID: 1, Status: models.PostStatusDraft}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("UpdateExpiry", post).Return(nil).Once()
+
+		result, err := s.service.SetExpiry(1, services.PostExpiryInput{ExpireAt: time.Now().Add(time.Hour)})
+		s.Require().NoError(err)
+		s.Equal(models.PostActionArchive, *result.ExpireAction)
+	})
+
+	s.Run("Error time in the past", func() {
+		s.repo.On("GetByID", uint(1)).Return(&models.Post{ID: 1, Status: models.PostStatusPublished}, nil).Once()
+
+		_, err := s.service.SetExpiry(1, services.PostExpiryInput{ExpireAt: time.Now().Add(-time.Hour)})
+		s.assertFieldError(err, "expire_at")
+	})
+
+	s.Run("Error before the scheduled publication", func() {
+		publishAt := time.Now().Add(48 * time.Hour)
+		s.repo.On("GetByID", uint(1)).Return(&models.Post{ID: 1, Status: models.PostStatusScheduled, PublishAt: &publishAt}, nil).Once()
+
+		_, err := s.service.SetExpiry(1, services.PostExpiryInput{ExpireAt: time.Now().Add(24 * time.Hour)})
+		s.assertFieldError(err, "expire_at")
+	})
+
+	s.Run("Error unknown action", func() {
+		_, err := s.service.SetExpiry(1, services.PostExpiryInput{ExpireAt: time.Now().Add(time.Hour), Action: models.PostActionRestore})
+		s.assertFieldError(err, "action")
+	})
+
+	s.Run("Error archived post", func() {
+		s.repo.On("GetByID", uint(1)).Return(&models.Post{ID: 1, Status: models.PostStatusArchived}, nil).Once()
+
+		_, err := s.service.SetExpiry(1, services.PostExpiryInput{ExpireAt: time.Now().Add(time.Hour)})
+		s.assertCode(err, apperror.ErrInvalidTransition)
+	})
+
+	s.Run("Error concurrent change", func() {
+		post := &models.Post{ID: 1, Status: models.PostStatusPublished}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("UpdateExpiry", post).Return(repositories.ErrPostStatusChanged).Once()
+
+		_, err := s.service.SetExpiry(1, services.PostExpiryInput{ExpireAt: time.Now().Add(time.Hour)})
+		s.assertCode(err, apperror.ErrInvalidTransition)
+	})
+
+	s.Run("Error post not found", func() {
+		s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.SetExpiry(9, services.PostExpiryInput{ExpireAt: time.Now().Add(time.Hour)})
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *PostExpiryServiceTestSuite) TestClearExpiry() {
+	s.Run("Success", func() {
+		expireAt := time.Now().Add(time.Hour)
+		archive := models.PostActionArchive
+		post := &models.Post{ID: 1, Status: models.PostStatusPublished, ExpireAt: &expireAt, ExpireAction: &archive}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("UpdateExpiry", post).Return(nil).Once()
+
+		result, err := s.service.ClearExpiry(1)
+		s.Require().NoError(err)
+		s.Nil(result.ExpireAt)
+		s.Nil(result.ExpireAction)
+	})
+
+	s.Run("Error update", func() {
+		post := &models.Post{ID: 1, Status: models.PostStatusPublished}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("UpdateExpiry", post).Return(errors.New("db error")).Once()
+
+		_, err := s.service.ClearExpiry(1)
+		s.assertCode(err, apperror.ErrDBUpdate)
+	})
+}
+
+func (s *PostExpiryServiceTestSuite) TestExpireDue() {
+	s.Run("Success", func() {
+		expireAt := time.Now().Add(-time.Minute)
+		unpublish := models.PostActionUnpublish
+		s.repo.On("FindDueExpiring", mock.Anything, 50).Return([]models.Post{
+			{ID: 1, Status: models.PostStatusPublished, ExpireAt: &expireAt},
+			{ID: 2, Status: models.PostStatusPublished, ExpireAt: &expireAt, ExpireAction: &unpublish},
+			{ID: 3, Status: models.PostStatusPublished, ExpireAt: &expireAt},
+		}, nil).Once()
+		s.repo.On("ApplyTransition", mock.MatchedBy(func(post *models.Post) bool {
+			return post.ID == 1 && post.Status == models.PostStatusArchived && post.ExpireAt == nil
+		}), mock.MatchedBy(func(transition *models.PostTransition) bool {
+			return transition.UserID == nil && transition.Action == models.PostActionArchive
+		})).Return(nil).Once()
+		s.repo.On("ApplyTransition", mock.MatchedBy(func(post *models.Post) bool {
+			return post.ID == 2 && post.Status == models.PostStatusDraft && post.ExpireAction == nil
+		}), mock.MatchedBy(func(transition *models.PostTransition) bool {
+			return transition.Action == models.PostActionUnpublish && transition.FromStatus == models.PostStatusPublished
+		})).Return(nil).Once()
+		s.repo.On("ApplyTransition", mock.MatchedBy(func(post *models.Post) bool { return post.ID == 3 }), mock.Anything).
+			Return(repositories.ErrPostStatusChanged).Once()
+
+		s.NoError(s.service.ExpireDue(context.Background()))
+	})
+
+	s.Run("Error query", func() {
+		s.repo.On("FindDueExpiring", mock.Anything, 50).Return(nil, errors.New("db error")).Once()
+
+		err := s.service.ExpireDue(context.Background())
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *PostExpiryServiceTestSuite) TestNotifyExpiring() {
+	s.Run("Success", func() {
+		expireAt := time.Now().Add(24 * time.Hour)
+		author := &models.User{ID: 5, Email: "author@example.com"}
+		s.repo.On("FindExpiringUnnotified", mock.MatchedBy(func(before time.Time) bool {
+			return before.Sub(time.Now()) > 71*time.Hour
+		}), 50).Return([]models.Post{
+			{ID: 1, ExpireAt: &expireAt, Author: author},
+			{ID: 2, ExpireAt: &expireAt, Author: author},
+			{ID: 3, ExpireAt: &expireAt},
+			{ID: 4, ExpireAt: &expireAt, Author: author},
+		}, nil).Once()
+		s.repo.On("ClaimExpiryNotification", uint(1), mock.Anything).Return(true, nil).Once()
+		s.repo.On("ClaimExpiryNotification", uint(2), mock.Anything).Return(true, nil).Once()
+		// Posts without an author stay claimed so they do not hold back the next ones
+		s.repo.On("ClaimExpiryNotification", uint(3), mock.Anything).Return(true, nil).Once()
+		// Another worker claimed the post first, its author is not warned twice
+		s.repo.On("ClaimExpiryNotification", uint(4), mock.Anything).Return(false, nil).Once()
+		s.mailerService.On("SendMailPostExpiring", author, mock.MatchedBy(func(post *models.Post) bool { return post.ID == 1 })).Return(nil).Once()
+		s.mailerService.On("SendMailPostExpiring", author, mock.MatchedBy(func(post *models.Post) bool { return post.ID == 2 })).
+			Return(errors.New("smtp error")).Once()
+		// The post whose email failed is released and retried on the next run
+		s.repo.On("ReleaseExpiryNotification", uint(2)).Return(nil).Once()
+
+		s.NoError(s.service.NotifyExpiring(context.Background()))
+	})
+
+	s.Run("Disabled without notice period", func() {
+		service := services.NewPostExpiryService(s.repo, s.mailerService, 0)
+
+		s.NoError(service.NotifyExpiring(context.Background()))
+	})
+
+	s.Run("Error query", func() {
+		s.repo.On("FindExpiringUnnotified", mock.Anything, 50).Return(nil, errors.New("db error")).Once()
+
+		err := s.service.NotifyExpiring(context.Background())
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func TestPostExpiryServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PostExpiryServiceTestSuite))
+}
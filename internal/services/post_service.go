@@ -0,0 +1,443 @@
+package services
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// RevisionChange is a field whose value differs between two revisions
+type RevisionChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// RevisionDiff lists the fields changed from one revision of a post to another
+type RevisionDiff struct {
+	PostID  uint             `json:"postId"`
+	From    int              `json:"from"`
+	To      int              `json:"to"`
+	Changes []RevisionChange `json:"changes"`
+}
+
+type IPostService interface {
+	PaginatePosts(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error)
+	PaginatePublishedPosts(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error)
+	GetPost(id uint) (*models.Post, error)
+	GetPublishedPost(slug string) (*models.Post, error)
+	GetRelatedPosts(post *models.Post, limit int) ([]models.Post, error)
+	CreatePost(post *models.Post) error
+	UpdatePost(editorID uint, post *models.Post) error
+	DeletePost(userID uint, post *models.Post) error
+	PaginateRevisions(postID uint, page, limit int) (*utils.Pagination, error)
+	GetRevision(postID uint, number int) (*models.PostRevision, error)
+	DiffRevisions(postID uint, from, to int) (*RevisionDiff, error)
+	RestoreRevision(editorID, postID uint, number int) (*models.Post, error)
+}
+
+// reviewedPostStatuses lists the statuses of the posts whose content was approved by a reviewer
+var reviewedPostStatuses = []string{models.PostStatusApproved, models.PostStatusScheduled, models.PostStatusPublished}
+
+type PostService struct {
+	repo              repositories.IPostRepository
+	categoryService   ICategoryService
+	tagService        ITagService
+	permissionService IPermissionService
+}
+
+// NewPostService creates a new instance of PostService
+// Parameters:
+//   - repo: Repository of posts
+//   - categoryService: Service used to check the category of a post
+//   - tagService: Service used to resolve and create the tags of a post
+//   - permissionService: Service checking the permissions required to change the posts of other authors
+//
+// Returns:
+//   - *PostService: New PostService instance initialized with the provided repository and services
+func NewPostService(repo repositories.IPostRepository, categoryService ICategoryService, tagService ITagService, permissionService IPermissionService) *PostService {
+	return &PostService{
+		repo:              repo,
+		categoryService:   categoryService,
+		tagService:        tagService,
+		permissionService: permissionService,
+	}
+}
+
+// PaginatePosts retrieves a page of posts of any status
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of posts per page
+//   - filter: Optional criteria such as the status, the author, the categories or the tag of the posts
+//
+// Returns:
+//   - *utils.Pagination: The page of posts
+//   - error: DBQuery error if the posts cannot be loaded
+func (service *PostService) PaginatePosts(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginatePost(page, limit, filter)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// PaginatePublishedPosts retrieves a page of published posts for the public API
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of posts per page
+//   - filter: Optional criteria such as the categories or the tag of the posts
+//
+// Returns:
+//   - *utils.Pagination: The page of posts, most recently published first
+//   - error: DBQuery error if the posts cannot be loaded
+func (service *PostService) PaginatePublishedPosts(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginatePublished(page, limit, filter)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetPost retrieves a post of any status by its ID
+// Parameters:
+//   - id: The ID of the post
+//
+// Returns:
+//   - *models.Post: The post with its author
+//   - error: NotFound if the post does not exist
+func (service *PostService) GetPost(id uint) (*models.Post, error) {
+	post, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return post, nil
+}
+
+// GetPublishedPost retrieves a published post by its slug
+// Parameters:
+//   - slug: The slug of the post
+//
+// Returns:
+//   - *models.Post: The post with its author
+//   - error: NotFound if no published post has this slug
+func (service *PostService) GetPublishedPost(slug string) (*models.Post, error) {
+	post, err := service.repo.FindPublishedBySlug(slug)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return post, nil
+}
+
+// GetRelatedPosts retrieves the published posts sharing tags or the category with a post
+// Parameters:
+//   - post: The post with its tags loaded
+//   - limit: The maximum number of posts to return
+//
+// Returns:
+//   - []models.Post: The related posts, the posts sharing the most tags first
+//   - error: DBQuery error if the posts cannot be loaded
+func (service *PostService) GetRelatedPosts(post *models.Post, limit int) ([]models.Post, error) {
+	posts, err := service.repo.FindRelatedPublished(post, limit)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return posts, nil
+}
+
+// CreatePost stores a new draft post with its first revision, it is published through the editorial workflow
+// Parameters:
+//   - post: The post to create, its slug is generated from the title when empty
+//
+// Returns:
+//   - error: ValidationError if the slug, the category or a tag is invalid, DBInsert error otherwise
+func (service *PostService) CreatePost(post *models.Post) error {
+	post.Status = models.PostStatusDraft
+	if err := service.prepare(post); err != nil {
+		return err
+	}
+	if err := service.repo.Create(post, newPostRevision(post, post.AuthorID)); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdatePost saves the changes made to the content of a post as a new revision
+// Its status is only changed by the editorial workflow, so a post that passed review is only changed by publishers
+// Parameters:
+//   - editorID: The ID of the user saving the post, the author or a user holding PermissionUpdatePosts
+//   - post: The post to save, its slug is generated from the title when empty, only saved while its Version is the stored one
+//
+// Returns:
+//   - error: Forbidden error if the editor may not change the post, ValidationError if the slug, the category or a tag is invalid,
+//     VersionConflict error if the post was saved by someone else since its version was loaded, DBUpdate error otherwise
+func (service *PostService) UpdatePost(editorID uint, post *models.Post) error {
+	if err := service.authorizeEdit(editorID, post); err != nil {
+		return err
+	}
+	return service.save(post, newPostRevision(post, editorID))
+}
+
+// save stores the content of a post together with its new revision
+func (service *PostService) save(post *models.Post, revision *models.PostRevision) error {
+	if err := service.prepare(post); err != nil {
+		return err
+	}
+	// The slug may have been normalized or generated
+	revision.Slug = post.Slug
+	if err := service.repo.Update(post, revision); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return apperror.NewVersionConflictError("The post was changed by someone else, reload it and try again")
+		}
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeletePost soft deletes a post, its slug stays reserved
+// Parameters:
+//   - userID: The ID of the user deleting the post, the author or a user holding PermissionDeletePosts
+//   - post: The post to delete
+//
+// Returns:
+//   - error: Forbidden error if the user may not delete the post, DBDelete error if the post cannot be deleted
+func (service *PostService) DeletePost(userID uint, post *models.Post) error {
+	if err := service.authorize(userID, post, constants.PermissionDeletePosts); err != nil {
+		return err
+	}
+	if err := service.repo.Delete(post.ID); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// PaginateRevisions retrieves a page of revisions of a post, newest first
+// Parameters:
+//   - postID: The ID of the post
+//   - page: The page number to retrieve
+//   - limit: The number of revisions per page
+//
+// Returns:
+//   - *utils.Pagination: The page of revisions
+//   - error: NotFound if the post does not exist, DBQuery error if the revisions cannot be loaded
+func (service *PostService) PaginateRevisions(postID uint, page, limit int) (*utils.Pagination, error) {
+	if _, err := service.GetPost(postID); err != nil {
+		return nil, err
+	}
+
+	pagination, err := service.repo.PaginateRevisions(postID, page, limit)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetRevision retrieves a revision of a post by its number
+// Parameters:
+//   - postID: The ID of the post
+//   - number: The number of the revision within the post
+//
+// Returns:
+//   - *models.PostRevision: The revision with the full snapshot of the content
+//   - error: NotFound if the revision does not exist
+func (service *PostService) GetRevision(postID uint, number int) (*models.PostRevision, error) {
+	revision, err := service.repo.GetRevision(postID, number)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return revision, nil
+}
+
+// DiffRevisions compares two revisions of a post field by field
+// Parameters:
+//   - postID: The ID of the post
+//   - from: The number of the older revision
+//   - to: The number of the newer revision
+//
+// Returns:
+//   - *RevisionDiff: The fields whose values differ, unchanged fields are left out
+//   - error: NotFound if one of the revisions does not exist
+func (service *PostService) DiffRevisions(postID uint, from, to int) (*RevisionDiff, error) {
+	fromRevision, err := service.GetRevision(postID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := service.GetRevision(postID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	fromFields := revisionFields(fromRevision)
+	toFields := revisionFields(toRevision)
+	changes := []RevisionChange{}
+	for i := range fromFields {
+		if fromFields[i].value != toFields[i].value {
+			changes = append(changes, RevisionChange{
+				Field: fromFields[i].name,
+				From:  fromFields[i].raw,
+				To:    toFields[i].raw,
+			})
+		}
+	}
+
+	return &RevisionDiff{
+		PostID:  postID,
+		From:    from,
+		To:      to,
+		Changes: changes,
+	}, nil
+}
+
+// RestoreRevision puts the content of an old revision back into a post, saved as a new revision
+// The history is kept, the restored revision is referenced by the new one
+// Parameters:
+//   - editorID: The ID of the user restoring the revision
+//   - postID: The ID of the post
+//   - number: The number of the revision to restore
+//
+// Returns:
+//   - *models.Post: The post with the restored content
+//   - error: NotFound if the post or the revision does not exist, Forbidden error if the editor may not change the post,
+//     ValidationError if its slug is now used by another post
+func (service *PostService) RestoreRevision(editorID, postID uint, number int) (*models.Post, error) {
+	post, err := service.GetPost(postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.authorizeEdit(editorID, post); err != nil {
+		return nil, err
+	}
+	revision, err := service.GetRevision(postID, number)
+	if err != nil {
+		return nil, err
+	}
+
+	post.Title = revision.Title
+	post.Slug = revision.Slug
+	post.Excerpt = revision.Excerpt
+	post.Body = revision.Body
+	post.BodyFormat = revision.BodyFormat
+
+	restored := newPostRevision(post, editorID)
+	restored.RestoredFrom = &revision.Number
+	if err := service.save(post, restored); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// authorizeEdit lets the author of a post or a user holding PermissionUpdatePosts change its content
+// The content of a post that passed review is only changed by publishers, it would otherwise go live without a new review
+func (service *PostService) authorizeEdit(userID uint, post *models.Post) error {
+	if err := service.authorize(userID, post, constants.PermissionUpdatePosts); err != nil {
+		return err
+	}
+	if !slices.Contains(reviewedPostStatuses, post.Status) {
+		return nil
+	}
+	return service.requirePermission(userID, constants.PermissionPublishPosts)
+}
+
+// authorize lets the author of a post change it, other users need the permission
+func (service *PostService) authorize(userID uint, post *models.Post, permission string) error {
+	if post.AuthorID == userID {
+		return nil
+	}
+	return service.requirePermission(userID, permission)
+}
+
+// requirePermission rejects the users lacking a permission
+func (service *PostService) requirePermission(userID uint, permission string) error {
+	allowed, err := service.permissionService.HasPermission(userID, permission)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apperror.NewForbiddenError("You do not have permission to perform this action")
+	}
+	return nil
+}
+
+// newPostRevision takes a snapshot of the content of a post
+func newPostRevision(post *models.Post, editorID uint) *models.PostRevision {
+	return &models.PostRevision{
+		EditorID:   &editorID,
+		Title:      post.Title,
+		Slug:       post.Slug,
+		Excerpt:    post.Excerpt,
+		Body:       post.Body,
+		BodyFormat: post.BodyFormat,
+	}
+}
+
+// revisionField is a content field of a revision, value is used for comparison and raw is returned in diffs
+type revisionField struct {
+	name  string
+	value string
+	raw   any
+}
+
+// revisionFields lists the content fields of a revision in a fixed order
+func revisionFields(revision *models.PostRevision) []revisionField {
+	excerpt := ""
+	if revision.Excerpt != nil {
+		excerpt = *revision.Excerpt
+	}
+	return []revisionField{
+		{name: "title", value: revision.Title, raw: revision.Title},
+		{name: "slug", value: revision.Slug, raw: revision.Slug},
+		{name: "excerpt", value: excerpt, raw: revision.Excerpt},
+		{name: "body", value: revision.Body, raw: revision.Body},
+		{name: "bodyFormat", value: revision.BodyFormat, raw: revision.BodyFormat},
+	}
+}
+
+// prepare validates the body and resolves the slug, category and tags of a post before it is saved
+//
+// The function:
+//  1. Rejects a body in the blocks format holding invalid blocks
+//  2. Normalizes a slug chosen by the user or generates one from the title, see resolveSlug
+//  3. Rejects a category that does not exist
+//  4. Replaces the tags of the post with the stored tags of the same names, creating the missing ones
+func (service *PostService) prepare(post *models.Post) error {
+	if err := validateBody(post.Body, post.BodyFormat); err != nil {
+		return err
+	}
+
+	slug, err := resolveSlug(post.Slug, post.Title, "post", func(slug string) (bool, error) {
+		return service.repo.SlugExists(slug, post.ID)
+	})
+	if err != nil {
+		return err
+	}
+	post.Slug = slug
+
+	if post.CategoryID != nil {
+		category, err := service.categoryService.GetCategory(*post.CategoryID)
+		if err != nil {
+			return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "category_id", Message: "category does not exist"},
+			})
+		}
+		post.Category = category
+	} else {
+		post.Category = nil
+	}
+
+	if len(post.Tags) > 0 {
+		names := make([]string, len(post.Tags))
+		for i, tag := range post.Tags {
+			names[i] = tag.Name
+		}
+		tags, err := service.tagService.ResolveTags(names)
+		if err != nil {
+			return err
+		}
+		post.Tags = tags
+	}
+	return nil
+}
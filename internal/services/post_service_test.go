@@ -0,0 +1,376 @@
+package services_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type PostServiceTestSuite struct {
+	suite.Suite
+	repo              *mocks.MockPostRepository
+	categoryService   *mocks.MockCategoryService
+	tagService        *mocks.MockTagService
+	permissionService *mocks.MockPermissionService
+	service           *services.PostService
+}
+
+func (s *PostServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockPostRepository)
+	s.categoryService = new(mocks.MockCategoryService)
+	s.tagService = new(mocks.MockTagService)
+	s.permissionService = new(mocks.MockPermissionService)
+	s.service = services.NewPostService(s.repo, s.categoryService, s.tagService, s.permissionService)
+}
+
+func (s *PostServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.categoryService.AssertExpectations(s.T())
+	s.tagService.AssertExpectations(s.T())
+	s.permissionService.AssertExpectations(s.T())
+}
+
+func (s *PostServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *PostServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+func (s *PostServiceTestSuite) TestCreatePost() {
+	s.Run("Success generated slug", func() {
+		post := &models.Post{Title: "Hello World", Body: "Body", AuthorID: 1}
+		s.repo.On("SlugExists", "hello-world", uint(0)).Return(true, nil).Once()
+		s.repo.On("SlugExists", "hello-world-2", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", post, mock.MatchedBy(func(revision *models.PostRevision) bool {
+			return *revision.EditorID == 1 && revision.Slug == "hello-world-2" && revision.Body == "Body"
+		})).Return(nil).Once()
+
+		err := s.service.CreatePost(post)
+		s.NoError(err)
+		s.Equal("hello-world-2", post.Slug)
+		s.Equal(models.PostStatusDraft, post.Status)
+	})
+
+	s.Run("Success custom slug always draft", func() {
+		post := &models.Post{Title: "Hello", Slug: "My Custom Slug", Body: "Body", Status: models.PostStatusPublished}
+		s.repo.On("SlugExists", "my-custom-slug", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", post, mock.Anything).Return(nil).Once()
+
+		err := s.service.CreatePost(post)
+		s.NoError(err)
+		s.Equal("my-custom-slug", post.Slug)
+		s.Equal(models.PostStatusDraft, post.Status)
+		s.Nil(post.PublishedAt)
+	})
+
+	s.Run("Success long title", func() {
+		post := &models.Post{Title: strings.Repeat("a", 255), Body: "Body", Status: models.PostStatusDraft}
+		s.repo.On("SlugExists", strings.Repeat("a", 240), uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", post, mock.Anything).Return(nil).Once()
+
+		s.NoError(s.service.CreatePost(post))
+	})
+
+	s.Run("Error custom slug taken", func() {
+		post := &models.Post{Title: "Hello", Slug: "taken", Body: "Body", Status: models.PostStatusDraft}
+		s.repo.On("SlugExists", "taken", uint(0)).Return(true, nil).Once()
+
+		err := s.service.CreatePost(post)
+		s.assertFieldError(err, "slug")
+	})
+
+	s.Run("Error invalid slug", func() {
+		post := &models.Post{Title: "Hello", Slug: "!!!", Body: "Body", Status: models.PostStatusDraft}
+
+		err := s.service.CreatePost(post)
+		s.assertFieldError(err, "slug")
+	})
+
+	s.Run("Error invalid blocks", func() {
+		post := &models.Post{Title: "Hello", Body: `[{"type":"heading","data":{"text":"Intro","level":9}}]`, BodyFormat: models.BodyFormatBlocks}
+
+		err := s.service.CreatePost(post)
+		s.assertFieldError(err, "body[0].data.level")
+	})
+
+	s.Run("Error create", func() {
+		post := &models.Post{Title: "Hello", Body: "Body", Status: models.PostStatusDraft}
+		s.repo.On("SlugExists", "hello", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", post, mock.Anything).Return(errors.New("db error")).Once()
+
+		err := s.service.CreatePost(post)
+		s.assertCode(err, apperror.ErrDBInsert)
+	})
+}
+
+func (s *PostServiceTestSuite) TestUpdatePost() {
+	s.Run("Success keeps publication time", func() {
+		publishedAt := time.Now().Add(-time.Hour)
+		post := &models.Post{ID: 3, AuthorID: 7, Title: "Hello", Slug: "hello", Status: models.PostStatusPublished, PublishedAt: &publishedAt}
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(true, nil).Once()
+		s.repo.On("SlugExists", "hello", uint(3)).Return(false, nil).Once()
+		s.repo.On("Update", post, mock.MatchedBy(func(revision *models.PostRevision) bool {
+			return *revision.EditorID == 7 && revision.Title == "Hello" && revision.Slug == "hello"
+		})).Return(nil).Once()
+
+		err := s.service.UpdatePost(7, post)
+		s.NoError(err)
+		s.Equal(publishedAt, *post.PublishedAt)
+	})
+
+	s.Run("Success post of another author", func() {
+		post := &models.Post{ID: 3, AuthorID: 2, Title: "Hello", Slug: "hello", Status: models.PostStatusDraft}
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionUpdatePosts).Return(true, nil).Once()
+		s.repo.On("SlugExists", "hello", uint(3)).Return(false, nil).Once()
+		s.repo.On("Update", post, mock.Anything).Return(nil).Once()
+
+		s.NoError(s.service.UpdatePost(7, post))
+	})
+
+	s.Run("Error post of another author", func() {
+		post := &models.Post{ID: 3, AuthorID: 2, Title: "Hello", Slug: "hello", Status: models.PostStatusDraft}
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionUpdatePosts).Return(false, nil).Once()
+
+		err := s.service.UpdatePost(7, post)
+		s.assertCode(err, apperror.ErrForbidden)
+	})
+
+	s.Run("Error reviewed post without publish permission", func() {
+		for _, status := range []string{models.PostStatusApproved, models.PostStatusScheduled, models.PostStatusPublished} {
+			post := &models.Post{ID: 3, AuthorID: 7, Title: "Hello", Slug: "hello", Status: status}
+			s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(false, nil).Once()
+
+			err := s.service.UpdatePost(7, post)
+			s.assertCode(err, apperror.ErrForbidden)
+		}
+	})
+
+	s.Run("Error update", func() {
+		post := &models.Post{ID: 3, AuthorID: 7, Title: "Hello", Slug: "hello", Status: models.PostStatusDraft}
+		s.repo.On("SlugExists", "hello", uint(3)).Return(false, nil).Once()
+		s.repo.On("Update", post, mock.Anything).Return(errors.New("db error")).Once()
+
+		err := s.service.UpdatePost(7, post)
+		s.assertCode(err, apperror.ErrDBUpdate)
+	})
+}
+
+func (s *PostServiceTestSuite) TestGetPost() {
+	s.Run("Success", func() {
+		s.repo.On("GetByID", uint(1)).Return(&models.Post{ID: 1}, nil).Once()
+
+		post, err := s.service.GetPost(1)
+		s.NoError(err)
+		s.Equal(uint(1), post.ID)
+	})
+
+	s.Run("Error not found", func() {
+		s.repo.On("GetByID", uint(2)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.GetPost(2)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Published not found", func() {
+		s.repo.On("FindPublishedBySlug", "draft").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.GetPublishedPost("draft")
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *PostServiceTestSuite) TestGetRelatedPosts() {
+	post := &models.Post{ID: 1}
+
+	s.Run("Success", func() {
+		s.repo.On("FindRelatedPublished", post, 5).Return([]models.Post{{ID: 2}}, nil).Once()
+
+		posts, err := s.service.GetRelatedPosts(post, 5)
+		s.Require().NoError(err)
+		s.Len(posts, 1)
+	})
+
+	s.Run("Error", func() {
+		s.repo.On("FindRelatedPublished", post, 5).Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.GetRelatedPosts(post, 5)
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *PostServiceTestSuite) TestPaginatePosts() {
+	s.Run("Success", func() {
+		filter := repositories.PostFilter{Status: models.PostStatusDraft}
+		s.repo.On("PaginatePost", 1, 10, filter).Return(&utils.Pagination{Page: 1}, nil).Once()
+
+		_, err := s.service.PaginatePosts(1, 10, filter)
+		s.NoError(err)
+	})
+
+	s.Run("Error published", func() {
+		filter := repositories.PostFilter{CategoryIDs: []uint{1, 2}}
+		s.repo.On("PaginatePublished", 1, 10, filter).Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.PaginatePublishedPosts(1, 10, filter)
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *PostServiceTestSuite) TestDeletePost() {
+	s.Run("Success", func() {
+		s.repo.On("Delete", uint(1)).Return(nil).Once()
+		s.NoError(s.service.DeletePost(7, &models.Post{ID: 1, AuthorID: 7}))
+	})
+
+	s.Run("Success post of another author", func() {
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionDeletePosts).Return(true, nil).Once()
+		s.repo.On("Delete", uint(1)).Return(nil).Once()
+		s.NoError(s.service.DeletePost(7, &models.Post{ID: 1, AuthorID: 2}))
+	})
+
+	s.Run("Error post of another author", func() {
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionDeletePosts).Return(false, nil).Once()
+		s.assertCode(s.service.DeletePost(7, &models.Post{ID: 1, AuthorID: 2}), apperror.ErrForbidden)
+	})
+
+	s.Run("Error", func() {
+		s.repo.On("Delete", uint(2)).Return(errors.New("db error")).Once()
+		s.assertCode(s.service.DeletePost(7, &models.Post{ID: 2, AuthorID: 7}), apperror.ErrDBDelete)
+	})
+}
+
+func (s *PostServiceTestSuite) TestCreatePostTaxonomy() {
+	categoryID := uint(4)
+
+	s.Run("Success with category and tags", func() {
+		post := &models.Post{Title: "Hello", Slug: "hello", Body: "Body", AuthorID: 1, CategoryID: &categoryID,
+			Tags: []models.Tag{{Name: "Go"}, {Name: "News"}}}
+		tags := []models.Tag{{ID: 1, Name: "Go", Slug: "go"}, {ID: 2, Name: "News", Slug: "news"}}
+		s.repo.On("SlugExists", "hello", uint(0)).Return(false, nil).Once()
+		s.categoryService.On("GetCategory", categoryID).Return(&models.Category{ID: categoryID, Name: "Tech"}, nil).Once()
+		s.tagService.On("ResolveTags", []string{"Go", "News"}).Return(tags, nil).Once()
+		s.repo.On("Create", post, mock.Anything).Return(nil).Once()
+
+		err := s.service.CreatePost(post)
+		s.Require().NoError(err)
+		s.Equal(tags, post.Tags)
+		s.Equal("Tech", post.Category.Name)
+	})
+
+	s.Run("Unknown category", func() {
+		post := &models.Post{Title: "Hello", Slug: "hello", Body: "Body", AuthorID: 1, CategoryID: &categoryID}
+		s.repo.On("SlugExists", "hello", uint(0)).Return(false, nil).Once()
+		s.categoryService.On("GetCategory", categoryID).Return(nil, apperror.NewNotFoundError("record not found")).Once()
+
+		err := s.service.CreatePost(post)
+		s.assertFieldError(err, "category_id")
+	})
+
+	s.Run("Invalid tag name", func() {
+		post := &models.Post{Title: "Hello", Slug: "hello", Body: "Body", AuthorID: 1, Tags: []models.Tag{{Name: "!!"}}}
+		s.repo.On("SlugExists", "hello", uint(0)).Return(false, nil).Once()
+		s.tagService.On("ResolveTags", []string{"!!"}).Return(nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "tags", Message: "tag names must contain letters or digits"},
+		})).Once()
+
+		err := s.service.CreatePost(post)
+		s.assertFieldError(err, "tags")
+	})
+}
+
+func (s *PostServiceTestSuite) TestRevisions() {
+	excerpt := "Short"
+	first := &models.PostRevision{PostID: 1, Number: 1, Title: "Hello", Slug: "hello", Body: "Body"}
+	second := &models.PostRevision{PostID: 1, Number: 2, Title: "Hello", Slug: "hello", Excerpt: &excerpt, Body: "New body"}
+
+	s.Run("Paginate", func() {
+		s.repo.On("GetByID", uint(1)).Return(&models.Post{ID: 1}, nil).Once()
+		s.repo.On("PaginateRevisions", uint(1), 1, 10).Return(&utils.Pagination{Page: 1}, nil).Once()
+
+		_, err := s.service.PaginateRevisions(1, 1, 10)
+		s.NoError(err)
+	})
+
+	s.Run("Paginate post not found", func() {
+		s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.PaginateRevisions(9, 1, 10)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Diff", func() {
+		s.repo.On("GetRevision", uint(1), 1).Return(first, nil).Once()
+		s.repo.On("GetRevision", uint(1), 2).Return(second, nil).Once()
+
+		diff, err := s.service.DiffRevisions(1, 1, 2)
+		s.Require().NoError(err)
+		s.Equal([]services.RevisionChange{
+			{Field: "excerpt", From: (*string)(nil), To: &excerpt},
+			{Field: "body", From: "Body", To: "New body"},
+		}, diff.Changes)
+	})
+
+	s.Run("Diff revision not found", func() {
+		s.repo.On("GetRevision", uint(1), 1).Return(first, nil).Once()
+		s.repo.On("GetRevision", uint(1), 5).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.DiffRevisions(1, 1, 5)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Restore", func() {
+		post := &models.Post{ID: 1, AuthorID: 7, Title: "Hello", Slug: "hello", Excerpt: &excerpt, Body: "New body"}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("GetRevision", uint(1), 1).Return(first, nil).Once()
+		s.repo.On("SlugExists", "hello", uint(1)).Return(false, nil).Once()
+		s.repo.On("Update", post, mock.MatchedBy(func(revision *models.PostRevision) bool {
+			return *revision.EditorID == 7 && *revision.RestoredFrom == 1 && revision.Body == "Body"
+		})).Return(nil).Once()
+
+		result, err := s.service.RestoreRevision(7, 1, 1)
+		s.Require().NoError(err)
+		s.Equal("Body", result.Body)
+		s.Nil(result.Excerpt)
+	})
+
+	s.Run("Restore slug taken", func() {
+		post := &models.Post{ID: 1, AuthorID: 7, Title: "Hello", Slug: "renamed", Body: "New body"}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("GetRevision", uint(1), 1).Return(first, nil).Once()
+		s.repo.On("SlugExists", "hello", uint(1)).Return(true, nil).Once()
+
+		_, err := s.service.RestoreRevision(7, 1, 1)
+		s.assertFieldError(err, "slug")
+	})
+
+	s.Run("Restore post of another author", func() {
+		s.repo.On("GetByID", uint(1)).Return(&models.Post{ID: 1, AuthorID: 2, Title: "Hello", Slug: "hello"}, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionUpdatePosts).Return(false, nil).Once()
+
+		_, err := s.service.RestoreRevision(7, 1, 1)
+		s.assertCode(err, apperror.ErrForbidden)
+	})
+}
+
+func TestPostServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PostServiceTestSuite))
+}
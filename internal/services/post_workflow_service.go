@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+// postSchedulerBatchSize is the maximum number of scheduled posts published by one run of the scheduler
+const postSchedulerBatchSize = 50
+
+// postTransitionRule describes one action of the editorial workflow
+type postTransitionRule struct {
+	from          []string // Statuses the action can start from
+	to            string   // Status of the post after the action
+	permission    string   // Permission required to perform the action
+	authorAllowed bool     // The author may perform the action without the permission
+}
+
+// postWorkflow lists the actions of the editorial workflow, any other move is rejected
+var postWorkflow = map[string]postTransitionRule{
+	models.PostActionSubmit: {
+		from:          []string{models.PostStatusDraft},
+		to:            models.PostStatusInReview,
+		permission:    constants.PermissionReviewPosts,
+		authorAllowed: true,
+	},
+	models.PostActionApprove: {
+		from:       []string{models.PostStatusInReview},
+		to:         models.PostStatusApproved,
+		permission: constants.PermissionReviewPosts,
+	},
+	models.PostActionReject: {
+		from:       []string{models.PostStatusInReview},
+		to:         models.PostStatusDraft,
+		permission: constants.PermissionReviewPosts,
+	},
+	models.PostActionSchedule: {
+		from:       []string{models.PostStatusApproved},
+		to:         models.PostStatusScheduled,
+		permission: constants.PermissionPublishPosts,
+	},
+	models.PostActionUnschedule: {
+		from:       []string{models.PostStatusScheduled},
+		to:         models.PostStatusApproved,
+		permission: constants.PermissionPublishPosts,
+	},
+	models.PostActionPublish: {
+		from:       []string{models.PostStatusApproved, models.PostStatusScheduled},
+		to:         models.PostStatusPublished,
+		permission: constants.PermissionPublishPosts,
+	},
+	models.PostActionArchive: {
+		from:       []string{models.PostStatusPublished},
+		to:         models.PostStatusArchived,
+		permission: constants.PermissionPublishPosts,
+	},
+	models.PostActionUnpublish: {
+		from:       []string{models.PostStatusPublished},
+		to:         models.PostStatusDraft,
+		permission: constants.PermissionPublishPosts,
+	},
+	models.PostActionRestore: {
+		from:       []string{models.PostStatusArchived},
+		to:         models.PostStatusDraft,
+		permission: constants.PermissionPublishPosts,
+	},
+}
+
+// PostTransitionInput holds the details of a workflow action performed on a post
+type PostTransitionInput struct {
+	Action    string
+	Comment   *string    // Reviewer comment, required to reject a post
+	PublishAt *time.Time // Publication time, required to schedule a post
+}
+
+type IPostWorkflowService interface {
+	Transition(userID, postID uint, input PostTransitionInput) (*models.Post, error)
+	GetTransitions(postID uint) ([]models.PostTransition, error)
+	PublishDue(ctx context.Context) error
+}
+
+type PostWorkflowService struct {
+	repo              repositories.IPostRepository
+	permissionService IPermissionService
+}
+
+// NewPostWorkflowService creates a new instance of PostWorkflowService
+// Parameters:
+//   - repo: Repository of posts and their workflow history
+//   - permissionService: Service checking the permission required by each action
+//
+// Returns:
+//   - *PostWorkflowService: New PostWorkflowService instance initialized with the provided dependencies
+func NewPostWorkflowService(repo repositories.IPostRepository, permissionService IPermissionService) *PostWorkflowService {
+	return &PostWorkflowService{
+		repo:              repo,
+		permissionService: permissionService,
+	}
+}
+
+// Transition moves a post to its next status in the editorial workflow
+// Parameters:
+//   - userID: The ID of the user performing the action
+//   - postID: The ID of the post
+//   - input: The action with its comment and publication time
+//
+// Returns:
+//   - *models.Post: The post with its new status
+//   - error: NotFound if the post does not exist, Forbidden if the user may not perform the action,
+//     InvalidTransition if the action is not allowed from the current status, ValidationError if a required detail is missing
+//
+// The function:
+//  1. Checks that the action can start from the current status of the post
+//  2. Checks the permission of the user, authors may submit their own posts
+//  3. Applies the action and records it with the comment in the history of the post
+func (service *PostWorkflowService) Transition(userID, postID uint, input PostTransitionInput) (*models.Post, error) {
+	rule, ok := postWorkflow[input.Action]
+	if !ok {
+		return nil, apperror.NewInvalidTransitionError(fmt.Sprintf("Unknown action %q", input.Action))
+	}
+
+	post, err := service.repo.GetByID(postID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	if !slices.Contains(rule.from, post.Status) {
+		return nil, apperror.NewInvalidTransitionError(
+			fmt.Sprintf("Cannot %s a post with status %s", input.Action, post.Status),
+		)
+	}
+
+	if !rule.authorAllowed || post.AuthorID != userID {
+		allowed, err := service.permissionService.HasPermission(userID, rule.permission)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, apperror.NewForbiddenError("You do not have permission to perform this action")
+		}
+	}
+
+	comment := input.Comment
+	if comment != nil && strings.TrimSpace(*comment) == "" {
+		comment = nil
+	}
+
+	now := time.Now()
+	switch input.Action {
+	case models.PostActionReject:
+		if comment == nil {
+			return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "comment", Message: "comment is required to reject a post"},
+			})
+		}
+	case models.PostActionSchedule:
+		if input.PublishAt == nil || !input.PublishAt.After(now) {
+			return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "publish_at", Message: "publish_at must be in the future"},
+			})
+		}
+		if err := checkExpiryAfter(post, *input.PublishAt); err != nil {
+			return nil, err
+		}
+		post.PublishAt = input.PublishAt
+	case models.PostActionUnschedule:
+		post.PublishAt = nil
+	case models.PostActionPublish:
+		if err := checkExpiryAfter(post, now); err != nil {
+			return nil, err
+		}
+		post.PublishAt = nil
+		if post.PublishedAt == nil {
+			post.PublishedAt = &now
+		}
+	case models.PostActionArchive, models.PostActionUnpublish:
+		clearExpiry(post)
+	}
+
+	transition := &models.PostTransition{
+		PostID:     post.ID,
+		UserID:     &userID,
+		Action:     input.Action,
+		FromStatus: post.Status,
+		ToStatus:   rule.to,
+		Comment:    comment,
+	}
+	post.Status = rule.to
+
+	if err := service.repo.ApplyTransition(post, transition); err != nil {
+		if errors.Is(err, repositories.ErrPostStatusChanged) {
+			return nil, apperror.NewInvalidTransitionError("The post was changed by someone else, reload it and try again")
+		}
+		return nil, apperror.NewDBUpdateError(err.Error())
+	}
+	return post, nil
+}
+
+// GetTransitions retrieves the workflow history of a post, including the reviewer comments
+// Parameters:
+//   - postID: The ID of the post
+//
+// Returns:
+//   - []models.PostTransition: The transitions of the post, oldest first
+//   - error: NotFound if the post does not exist, DBQuery error if the history cannot be loaded
+func (service *PostWorkflowService) GetTransitions(postID uint) ([]models.PostTransition, error) {
+	if _, err := service.repo.GetByID(postID); err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+
+	transitions, err := service.repo.FindTransitions(postID)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return transitions, nil
+}
+
+// PublishDue publishes the scheduled posts whose publication time has passed, run by the background scheduler
+// A post that cannot be published is logged and retried on the next run, the others are still published
+// Parameters:
+//   - ctx: Context cancelled when the application stops
+//
+// Returns:
+//   - error: DBQuery error if the scheduled posts cannot be loaded
+func (service *PostWorkflowService) PublishDue(ctx context.Context) error {
+	posts, err := service.repo.FindDueScheduled(time.Now(), postSchedulerBatchSize)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	for i := range posts {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		post := &posts[i]
+		transition := &models.PostTransition{
+			PostID:     post.ID,
+			Action:     models.PostActionPublish,
+			FromStatus: post.Status,
+			ToStatus:   models.PostStatusPublished,
+		}
+		post.Status = models.PostStatusPublished
+		if post.PublishedAt == nil {
+			post.PublishedAt = post.PublishAt
+		}
+		post.PublishAt = nil
+
+		if err := service.repo.ApplyTransition(post, transition); err != nil {
+			// The post was unscheduled or published in the meantime
+			if errors.Is(err, repositories.ErrPostStatusChanged) {
+				continue
+			}
+			logger.Errorf("Failed to publish scheduled post %d: %v", post.ID, err)
+		}
+	}
+	return nil
+}
+
+// checkExpiryAfter rejects the publication of a post at a time its expiry has already passed
+func checkExpiryAfter(post *models.Post, publishAt time.Time) error {
+	if post.ExpireAt != nil && !post.ExpireAt.After(publishAt) {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "expire_at", Message: "expire_at must be after the publication time, change or remove the expiry first"},
+		})
+	}
+	return nil
+}
+
+// clearExpiry removes the expiry of a post taken offline so it does not expire again once it is republished
+func clearExpiry(post *models.Post) {
+	post.ExpireAt = nil
+	post.ExpireAction = nil
+	post.ExpiryNotifiedAt = nil
+}
@@ -0,0 +1,257 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type PostWorkflowServiceTestSuite struct {
+	suite.Suite
+	repo              *mocks.MockPostRepository
+	permissionService *mocks.MockPermissionService
+	service           *services.PostWorkflowService
+}
+
+func (s *PostWorkflowServiceTestSuite) SetupTest() {
+	logger.Init()
+	s.repo = new(mocks.MockPostRepository)
+	s.permissionService = new(mocks.MockPermissionService)
+	s.service = services.NewPostWorkflowService(s.repo, s.permissionService)
+}
+
+func (s *PostWorkflowServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.permissionService.AssertExpectations(s.T())
+}
+
+func (s *PostWorkflowServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *PostWorkflowServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+func (s *PostWorkflowServiceTestSuite) TestSubmit() {
+	s.Run("Success by author", func() {
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusDraft}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.repo.On("ApplyTransition", post, mock.MatchedBy(func(transition *models.PostTransition) bool {
+			return *transition.UserID == 5 && transition.FromStatus == models.PostStatusDraft && transition.ToStatus == models.PostStatusInReview
+		})).Return(nil).Once()
+
+		result, err := s.service.Transition(5, 1, services.PostTransitionInput{Action: models.PostActionSubmit})
+		s.Require().NoError(err)
+		s.Equal(models.PostStatusInReview, result.Status)
+	})
+
+	s.Run("Error other user without permission", func() {
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusDraft}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(6), constants.PermissionReviewPosts).Return(false, nil).Once()
+
+		_, err := s.service.Transition(6, 1, services.PostTransitionInput{Action: models.PostActionSubmit})
+		s.assertCode(err, apperror.ErrForbidden)
+	})
+
+	s.Run("Error invalid transition", func() {
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusPublished}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+
+		_, err := s.service.Transition(5, 1, services.PostTransitionInput{Action: models.PostActionSubmit})
+		s.assertCode(err, apperror.ErrInvalidTransition)
+	})
+}
+
+func (s *PostWorkflowServiceTestSuite) TestReview() {
+	s.Run("Approve requires permission even for the author", func() {
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusInReview}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(5), constants.PermissionReviewPosts).Return(false, nil).Once()
+
+		_, err := s.service.Transition(5, 1, services.PostTransitionInput{Action: models.PostActionApprove})
+		s.assertCode(err, apperror.ErrForbidden)
+	})
+
+	s.Run("Reject with comment", func() {
+		comment := "Needs sources"
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusInReview}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionReviewPosts).Return(true, nil).Once()
+		s.repo.On("ApplyTransition", post, mock.MatchedBy(func(transition *models.PostTransition) bool {
+			return transition.Comment != nil && *transition.Comment == comment && transition.ToStatus == models.PostStatusDraft
+		})).Return(nil).Once()
+
+		result, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionReject, Comment: &comment})
+		s.Require().NoError(err)
+		s.Equal(models.PostStatusDraft, result.Status)
+	})
+
+	s.Run("Reject without comment", func() {
+		blank := "  "
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusInReview}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionReviewPosts).Return(true, nil).Once()
+
+		_, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionReject, Comment: &blank})
+		s.assertFieldError(err, "comment")
+	})
+}
+
+func (s *PostWorkflowServiceTestSuite) TestSchedule() {
+	s.Run("Success", func() {
+		publishAt := time.Now().Add(time.Hour)
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusApproved}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(true, nil).Once()
+		s.repo.On("ApplyTransition", post, mock.Anything).Return(nil).Once()
+
+		result, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionSchedule, PublishAt: &publishAt})
+		s.Require().NoError(err)
+		s.Equal(models.PostStatusScheduled, result.Status)
+		s.Equal(publishAt, *result.PublishAt)
+	})
+
+	s.Run("Error publish time in the past", func() {
+		publishAt := time.Now().Add(-time.Hour)
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusApproved}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(true, nil).Once()
+
+		_, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionSchedule, PublishAt: &publishAt})
+		s.assertFieldError(err, "publish_at")
+	})
+}
+
+func (s *PostWorkflowServiceTestSuite) TestPublish() {
+	s.Run("Success", func() {
+		publishAt := time.Now().Add(time.Hour)
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusScheduled, PublishAt: &publishAt}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(true, nil).Once()
+		s.repo.On("ApplyTransition", post, mock.Anything).Return(nil).Once()
+
+		result, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionPublish})
+		s.Require().NoError(err)
+		s.Equal(models.PostStatusPublished, result.Status)
+		s.Nil(result.PublishAt)
+		s.Require().NotNil(result.PublishedAt)
+		s.WithinDuration(time.Now(), *result.PublishedAt, time.Minute)
+	})
+
+	s.Run("Error expiry already passed", func() {
+		expireAt := time.Now().Add(-time.Hour)
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusApproved, ExpireAt: &expireAt}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(true, nil).Once()
+
+		_, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionPublish})
+		s.assertFieldError(err, "expire_at")
+	})
+
+	s.Run("Unpublish clears the expiry", func() {
+		expireAt := time.Now().Add(time.Hour)
+		archive := models.PostActionArchive
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusPublished, ExpireAt: &expireAt, ExpireAction: &archive}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(true, nil).Once()
+		s.repo.On("ApplyTransition", post, mock.Anything).Return(nil).Once()
+
+		result, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionUnpublish})
+		s.Require().NoError(err)
+		s.Equal(models.PostStatusDraft, result.Status)
+		s.Nil(result.ExpireAt)
+		s.Nil(result.ExpireAction)
+	})
+
+	s.Run("Error concurrent change", func() {
+		post := &models.Post{ID: 1, AuthorID: 5, Status: models.PostStatusApproved}
+		s.repo.On("GetByID", uint(1)).Return(post, nil).Once()
+		s.permissionService.On("HasPermission", uint(7), constants.PermissionPublishPosts).Return(true, nil).Once()
+		s.repo.On("ApplyTransition", post, mock.Anything).Return(repositories.ErrPostStatusChanged).Once()
+
+		_, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: models.PostActionPublish})
+		s.assertCode(err, apperror.ErrInvalidTransition)
+	})
+
+	s.Run("Error unknown action", func() {
+		_, err := s.service.Transition(7, 1, services.PostTransitionInput{Action: "delete"})
+		s.assertCode(err, apperror.ErrInvalidTransition)
+	})
+
+	s.Run("Error post not found", func() {
+		s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.Transition(7, 9, services.PostTransitionInput{Action: models.PostActionPublish})
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *PostWorkflowServiceTestSuite) TestGetTransitions() {
+	s.Run("Success", func() {
+		s.repo.On("GetByID", uint(1)).Return(&models.Post{ID: 1}, nil).Once()
+		s.repo.On("FindTransitions", uint(1)).Return([]models.PostTransition{{ID: 1}}, nil).Once()
+
+		transitions, err := s.service.GetTransitions(1)
+		s.NoError(err)
+		s.Len(transitions, 1)
+	})
+
+	s.Run("Error post not found", func() {
+		s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.GetTransitions(9)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *PostWorkflowServiceTestSuite) TestPublishDue() {
+	s.Run("Success", func() {
+		publishAt := time.Now().Add(-time.Minute)
+		s.repo.On("FindDueScheduled", mock.Anything, 50).Return([]models.Post{
+			{ID: 1, Status: models.PostStatusScheduled, PublishAt: &publishAt},
+			{ID: 2, Status: models.PostStatusScheduled, PublishAt: &publishAt},
+			{ID: 3, Status: models.PostStatusScheduled, PublishAt: &publishAt},
+		}, nil).Once()
+		s.repo.On("ApplyTransition", mock.MatchedBy(func(post *models.Post) bool {
+			return post.ID == 1 && post.Status == models.PostStatusPublished && post.PublishAt == nil && post.PublishedAt.Equal(publishAt)
+		}), mock.MatchedBy(func(transition *models.PostTransition) bool {
+			return transition.UserID == nil && transition.Action == models.PostActionPublish
+		})).Return(nil).Once()
+		s.repo.On("ApplyTransition", mock.MatchedBy(func(post *models.Post) bool { return post.ID == 2 }), mock.Anything).
+			Return(repositories.ErrPostStatusChanged).Once()
+		s.repo.On("ApplyTransition", mock.MatchedBy(func(post *models.Post) bool { return post.ID == 3 }), mock.Anything).
+			Return(errors.New("db error")).Once()
+
+		s.NoError(s.service.PublishDue(context.Background()))
+	})
+
+	s.Run("Error query", func() {
+		s.repo.On("FindDueScheduled", mock.Anything, 50).Return(nil, errors.New("db error")).Once()
+
+		err := s.service.PublishDue(context.Background())
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func TestPostWorkflowServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PostWorkflowServiceTestSuite))
+}
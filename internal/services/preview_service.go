@@ -0,0 +1,231 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+// PreviewLinkToken is a preview link together with the token to share
+type PreviewLinkToken struct {
+	models.PreviewLink
+	Token string `json:"token,omitempty"` // Left out once the link expired or was revoked
+}
+
+type IPreviewService interface {
+	CreateLink(creatorID, postID uint, revisionNumber int, ttl time.Duration) (*PreviewLinkToken, error)
+	GetLinks(postID uint) ([]PreviewLinkToken, error)
+	RevokeLink(postID, id uint) error
+	Resolve(token string) (*models.Post, error)
+}
+
+// PreviewService shares revisions of posts with people who have no account
+// A token carries the ID of its link and its expiry time signed with HMAC-SHA256, the link is looked up to honour revocations
+type PreviewService struct {
+	repo        repositories.IPreviewLinkRepository
+	postService IPostService
+	secret      []byte
+	ttl         time.Duration
+	maxTTL      time.Duration
+}
+
+// NewPreviewService creates a new instance of PreviewService
+// Parameters:
+//   - repo: Repository of preview links
+//   - postService: Loads the posts and revisions shown by the links
+//   - secret: Key signing the tokens, changing it invalidates every link
+//   - ttl: Lifetime of a link created without one
+//   - maxTTL: Longest lifetime an editor may choose
+//
+// Returns:
+//   - *PreviewService: New PreviewService instance
+func NewPreviewService(repo repositories.IPreviewLinkRepository, postService IPostService, secret []byte, ttl, maxTTL time.Duration) *PreviewService {
+	return &PreviewService{
+		repo:        repo,
+		postService: postService,
+		secret:      secret,
+		ttl:         ttl,
+		maxTTL:      maxTTL,
+	}
+}
+
+// CreateLink creates a preview link of a revision of a post
+// Parameters:
+//   - creatorID: The ID of the user sharing the preview
+//   - postID: The ID of the post
+//   - revisionNumber: The number of the revision to show, 0 for the latest one
+//   - ttl: Lifetime of the link, 0 for the default one
+//
+// Returns:
+//   - *PreviewLinkToken: The new link with its token
+//   - error: NotFound if the post or the revision does not exist, Validation error if the lifetime is too long,
+//     database errors otherwise
+func (service *PreviewService) CreateLink(creatorID, postID uint, revisionNumber int, ttl time.Duration) (*PreviewLinkToken, error) {
+	if ttl == 0 {
+		ttl = service.ttl
+	}
+	if ttl < 0 || ttl > service.maxTTL {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "expires_in", Message: fmt.Sprintf("expires_in must be at most %d seconds", int(service.maxTTL.Seconds()))},
+		})
+	}
+
+	if revisionNumber == 0 {
+		pagination, err := service.postService.PaginateRevisions(postID, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		revisions, _ := pagination.Data.([]models.PostRevision)
+		if len(revisions) == 0 {
+			return nil, apperror.NewNotFoundError("Post has no revision to preview")
+		}
+		revisionNumber = revisions[0].Number
+	} else if _, err := service.postService.GetRevision(postID, revisionNumber); err != nil {
+		return nil, err
+	}
+
+	link := &models.PreviewLink{
+		PostID:         postID,
+		RevisionNumber: revisionNumber,
+		CreatedBy:      &creatorID,
+		ExpiresAt:      time.Now().Add(ttl).Truncate(time.Second),
+	}
+	if err := service.repo.Create(link); err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+
+	return &PreviewLinkToken{PreviewLink: *link, Token: service.sign(link)}, nil
+}
+
+// GetLinks retrieves the preview links of a post, the tokens of active links can be shared again
+// Parameters:
+//   - postID: The ID of the post
+//
+// Returns:
+//   - []PreviewLinkToken: The links, newest first
+//   - error: NotFound if the post does not exist, DBQuery error if the links cannot be loaded
+func (service *PreviewService) GetLinks(postID uint) ([]PreviewLinkToken, error) {
+	if _, err := service.postService.GetPost(postID); err != nil {
+		return nil, err
+	}
+
+	links, err := service.repo.FindByPost(postID)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	now := time.Now()
+	items := make([]PreviewLinkToken, len(links))
+	for i, link := range links {
+		items[i] = PreviewLinkToken{PreviewLink: link}
+		if link.RevokedAt == nil && link.ExpiresAt.After(now) {
+			items[i].Token = service.sign(&links[i])
+		}
+	}
+	return items, nil
+}
+
+// RevokeLink stops a preview link from working before it expires
+// Parameters:
+//   - postID: The ID of the post the link belongs to
+//   - id: The ID of the preview link
+//
+// Returns:
+//   - error: NotFound if the link does not exist or was already revoked, DBUpdate error otherwise
+func (service *PreviewService) RevokeLink(postID, id uint) error {
+	revoked, err := service.repo.Revoke(postID, id, time.Now())
+	if err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	if revoked == 0 {
+		return apperror.NewNotFoundError("Preview link not found")
+	}
+	return nil
+}
+
+// Resolve retrieves the post shown by a preview token, with the content of the revision of the link
+// Parameters:
+//   - token: The token of the preview link
+//
+// Returns:
+//   - *models.Post: The post whatever its status, its title, slug, excerpt and body are those of the revision
+//   - error: NotFound if the token is invalid or revoked or its post is gone, TokenExpired if the link expired
+//
+// The function:
+//  1. Checks the signature and the expiry time carried by the token without touching the database
+//  2. Loads the link to reject revoked links
+//  3. Loads the post and overlays the content of the revision
+func (service *PreviewService) Resolve(token string) (*models.Post, error) {
+	id, expiresAt, ok := service.verify(token)
+	if !ok {
+		return nil, apperror.NewNotFoundError("Preview not found")
+	}
+	if !time.Now().Before(expiresAt) {
+		return nil, apperror.NewTokenExpiredError("Preview link has expired")
+	}
+
+	link, err := service.repo.GetByID(id)
+	if err != nil || link.RevokedAt != nil {
+		return nil, apperror.NewNotFoundError("Preview not found")
+	}
+
+	post, err := service.postService.GetPost(link.PostID)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := service.postService.GetRevision(link.PostID, link.RevisionNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	post.Title = revision.Title
+	post.Slug = revision.Slug
+	post.Excerpt = revision.Excerpt
+	post.Body = revision.Body
+	post.BodyFormat = revision.BodyFormat
+	post.UpdatedAt = revision.CreatedAt
+	return post, nil
+}
+
+// sign returns the token of a preview link, "{id}.{expiry}.{signature}"
+func (service *PreviewService) sign(link *models.PreviewLink) string {
+	payload := strconv.FormatUint(uint64(link.ID), 10) + "." + strconv.FormatInt(link.ExpiresAt.Unix(), 10)
+	return payload + "." + service.signature(payload)
+}
+
+// verify checks the signature of a token and returns the link ID and expiry time it carries
+func (service *PreviewService) verify(token string) (uint, time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, time.Time{}, false
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(service.signature(payload))) {
+		return 0, time.Time{}, false
+	}
+
+	id, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return uint(id), time.Unix(expiry, 0), true
+}
+
+// signature signs the payload of a token, the prefix keeps signatures made with the same key for other purposes apart
+func (service *PreviewService) signature(payload string) string {
+	mac := hmac.New(sha256.New, service.secret)
+	mac.Write([]byte("preview:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,166 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type PreviewServiceTestSuite struct {
+	suite.Suite
+	repo        *mocks.MockPreviewLinkRepository
+	postService *mocks.MockPostService
+	service     *services.PreviewService
+}
+
+func (s *PreviewServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockPreviewLinkRepository)
+	s.postService = new(mocks.MockPostService)
+	s.service = services.NewPreviewService(s.repo, s.postService, []byte("secret"), 72*time.Hour, 30*24*time.Hour)
+}
+
+func (s *PreviewServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.postService.AssertExpectations(s.T())
+}
+
+func (s *PreviewServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+// createLink creates a link of revision 2 of post 7 stored with the given ID
+func (s *PreviewServiceTestSuite) createLink(id uint, ttl time.Duration) *services.PreviewLinkToken {
+	s.postService.On("GetRevision", uint(7), 2).Return(&models.PostRevision{PostID: 7, Number: 2}, nil).Once()
+	s.repo.On("Create", mock.AnythingOfType("*models.PreviewLink")).Run(func(args mock.Arguments) {
+		args.Get(0).(*models.PreviewLink).ID = id
+	}).Return(nil).Once()
+
+	link, err := s.service.CreateLink(1, 7, 2, ttl)
+	s.Require().NoError(err)
+	return link
+}
+
+func (s *PreviewServiceTestSuite) TestCreateLink() {
+	s.Run("Latest revision with the default lifetime", func() {
+		s.postService.On("PaginateRevisions", uint(7), 1, 1).Return(&utils.Pagination{Data: []models.PostRevision{{PostID: 7, Number: 4}}}, nil).Once()
+		s.repo.On("Create", mock.MatchedBy(func(link *models.PreviewLink) bool {
+			return link.PostID == 7 && link.RevisionNumber == 4 && *link.CreatedBy == 1 &&
+				link.ExpiresAt.After(time.Now().Add(71*time.Hour))
+		})).Return(nil).Once()
+
+		link, err := s.service.CreateLink(1, 7, 0, 0)
+		s.Require().NoError(err)
+		s.NotEmpty(link.Token)
+	})
+
+	s.Run("Unknown revision", func() {
+		s.postService.On("GetRevision", uint(7), 9).Return(nil, apperror.NewNotFoundError("record not found")).Once()
+
+		_, err := s.service.CreateLink(1, 7, 9, 0)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Lifetime too long", func() {
+		_, err := s.service.CreateLink(1, 7, 2, 31*24*time.Hour)
+		var validationErr *apperror.ValidationError
+		s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+		s.Equal("expires_in", validationErr.Fields[0].Field)
+	})
+}
+
+func (s *PreviewServiceTestSuite) TestResolve() {
+	link := s.createLink(3, time.Hour)
+	excerpt := "Draft excerpt"
+
+	s.Run("Shows the revision of the link", func() {
+		s.repo.On("GetByID", uint(3)).Return(&link.PreviewLink, nil).Once()
+		s.postService.On("GetPost", uint(7)).Return(&models.Post{ID: 7, Title: "Live", Slug: "live", Body: "Live body", Status: models.PostStatusPublished}, nil).Once()
+		s.postService.On("GetRevision", uint(7), 2).Return(&models.PostRevision{
+			PostID: 7, Number: 2, Title: "Draft", Slug: "draft", Excerpt: &excerpt, Body: "# Draft", BodyFormat: models.BodyFormatMarkdown,
+		}, nil).Once()
+
+		post, err := s.service.Resolve(link.Token)
+		s.Require().NoError(err)
+		s.Equal("Draft", post.Title)
+		s.Equal("draft", post.Slug)
+		s.Equal(&excerpt, post.Excerpt)
+		s.Equal("# Draft", post.Body)
+		s.Equal(models.BodyFormatMarkdown, post.BodyFormat)
+	})
+
+	s.Run("Revoked link", func() {
+		revokedAt := time.Now()
+		revoked := link.PreviewLink
+		revoked.RevokedAt = &revokedAt
+		s.repo.On("GetByID", uint(3)).Return(&revoked, nil).Once()
+
+		_, err := s.service.Resolve(link.Token)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Tampered token", func() {
+		// Another link ID with the signature of link 3
+		_, err := s.service.Resolve("4" + link.Token[1:])
+		s.assertCode(err, apperror.ErrNotFound)
+
+		_, err = s.service.Resolve("not-a-token")
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Token signed with another key", func() {
+		other := services.NewPreviewService(s.repo, s.postService, []byte("other"), time.Hour, time.Hour)
+		_, err := other.Resolve(link.Token)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *PreviewServiceTestSuite) TestResolveExpired() {
+	link := s.createLink(3, time.Second)
+	time.Sleep(1100 * time.Millisecond)
+
+	// The expiry time is checked before the link is loaded
+	_, err := s.service.Resolve(link.Token)
+	s.assertCode(err, apperror.ErrTokenExpired)
+}
+
+func (s *PreviewServiceTestSuite) TestGetLinks() {
+	revokedAt := time.Now()
+	s.postService.On("GetPost", uint(7)).Return(&models.Post{ID: 7}, nil).Once()
+	s.repo.On("FindByPost", uint(7)).Return([]models.PreviewLink{
+		{ID: 3, PostID: 7, ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: 2, PostID: 7, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+		{ID: 1, PostID: 7, ExpiresAt: time.Now().Add(-time.Hour)},
+	}, nil).Once()
+
+	links, err := s.service.GetLinks(7)
+	s.Require().NoError(err)
+	s.Require().Len(links, 3)
+	s.NotEmpty(links[0].Token)
+	s.Empty(links[1].Token)
+	s.Empty(links[2].Token)
+}
+
+func (s *PreviewServiceTestSuite) TestRevokeLink() {
+	s.repo.On("Revoke", uint(7), uint(3), mock.AnythingOfType("time.Time")).Return(int64(1), nil).Once()
+	s.NoError(s.service.RevokeLink(7, 3))
+
+	s.repo.On("Revoke", uint(7), uint(3), mock.AnythingOfType("time.Time")).Return(int64(0), nil).Once()
+	s.assertCode(s.service.RevokeLink(7, 3), apperror.ErrNotFound)
+
+	s.repo.On("Revoke", uint(7), uint(4), mock.AnythingOfType("time.Time")).Return(int64(0), errors.New("db error")).Once()
+	s.assertCode(s.service.RevokeLink(7, 4), apperror.ErrDBUpdate)
+}
+
+func TestPreviewServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PreviewServiceTestSuite))
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"gorm.io/gorm"
+)
+
+const (
+	maxRedirectFromPath = 700 // Length of the from_path column
+	maxRedirectHops     = 10  // Redirects followed when looking for a loop
+)
+
+type IRedirectService interface {
+	PaginateRedirects(page, limit int, search string) (*utils.Pagination, error)
+	GetRedirect(id uint) (*models.Redirect, error)
+	Resolve(path string) (*models.Redirect, error)
+	CreateRedirect(redirect *models.Redirect) error
+	UpdateRedirect(redirect *models.Redirect) error
+	DeleteRedirect(id uint) error
+}
+
+type RedirectService struct {
+	repo repositories.IRedirectRepository
+}
+
+// NewRedirectService creates a new instance of RedirectService
+// Parameters:
+//   - repo: Repository of redirects
+//
+// Returns:
+//   - *RedirectService: New RedirectService instance initialized with the provided repository
+func NewRedirectService(repo repositories.IRedirectRepository) *RedirectService {
+	return &RedirectService{
+		repo: repo,
+	}
+}
+
+// PaginateRedirects retrieves a page of redirects ordered by their path
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of redirects per page
+//   - search: Only redirects whose paths contain this text, empty for every redirect
+//
+// Returns:
+//   - *utils.Pagination: The page of redirects
+//   - error: DBQuery error if the redirects cannot be loaded
+func (service *RedirectService) PaginateRedirects(page, limit int, search string) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginateRedirects(page, limit, search)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetRedirect retrieves a redirect by its ID
+func (service *RedirectService) GetRedirect(id uint) (*models.Redirect, error) {
+	redirect, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return redirect, nil
+}
+
+// Resolve retrieves the redirect of a path of the website
+// Parameters:
+//   - path: The path requested by a visitor, e.g. "/posts/old-slug"
+//
+// Returns:
+//   - *models.Redirect: The redirect
+//   - error: NotFound error if the path is not redirected, DBQuery error otherwise
+func (service *RedirectService) Resolve(path string) (*models.Redirect, error) {
+	redirect, err := service.repo.FindByFromPath(path)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.NewNotFoundError("Redirect not found")
+		}
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return redirect, nil
+}
+
+// CreateRedirect stores a new redirect
+// Parameters:
+//   - redirect: The redirect to create, it answers with 301 Moved Permanently when no status code is set
+//
+// Returns:
+//   - error: ValidationError if the paths or status code are invalid, DBInsert error otherwise
+func (service *RedirectService) CreateRedirect(redirect *models.Redirect) error {
+	if err := service.validate(redirect); err != nil {
+		return err
+	}
+	if err := service.repo.Create(redirect); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateRedirect saves an existing redirect, a redirect edited by an admin is no longer automatic
+// Parameters:
+//   - redirect: The redirect to save
+//
+// Returns:
+//   - error: ValidationError if the paths or status code are invalid, DBUpdate error otherwise
+func (service *RedirectService) UpdateRedirect(redirect *models.Redirect) error {
+	if err := service.validate(redirect); err != nil {
+		return err
+	}
+	redirect.Automatic = false
+	if err := service.repo.Update(redirect); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteRedirect deletes a redirect
+func (service *RedirectService) DeleteRedirect(id uint) error {
+	deleted, err := service.repo.Delete(id)
+	if err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	if deleted == 0 {
+		return apperror.NewNotFoundError("Redirect not found")
+	}
+	return nil
+}
+
+// validate normalizes a redirect before it is saved
+//
+// The function:
+//  1. Checks the from path is a path of the website without query or fragment
+//  2. Checks the target is a path of the website or an absolute http(s) URL
+//  3. Rejects a from path already redirected and a target leading back to the from path
+//
+// Returns:
+//   - error: ValidationError on the offending field, DBQuery error otherwise
+func (service *RedirectService) validate(redirect *models.Redirect) error {
+	redirect.FromPath = strings.TrimSpace(redirect.FromPath)
+	redirect.ToPath = strings.TrimSpace(redirect.ToPath)
+	if redirect.StatusCode == 0 {
+		redirect.StatusCode = 301
+	}
+
+	var fields []apperror.FieldError
+	if !strings.HasPrefix(redirect.FromPath, "/") || strings.ContainsAny(redirect.FromPath, "?#") {
+		fields = append(fields, apperror.FieldError{Field: "fromPath", Message: "fromPath must be a path starting with / without query or fragment"})
+	} else if len(redirect.FromPath) > maxRedirectFromPath {
+		fields = append(fields, apperror.FieldError{Field: "fromPath", Message: "fromPath must be at most 700 characters"})
+	}
+	if !validRedirectTarget(redirect.ToPath) {
+		fields = append(fields, apperror.FieldError{Field: "toPath", Message: "toPath must be a path starting with / or an http(s) URL"})
+	} else if redirect.ToPath == redirect.FromPath {
+		fields = append(fields, apperror.FieldError{Field: "toPath", Message: "toPath must differ from fromPath"})
+	}
+	if !slices.Contains(models.RedirectStatusCodes, redirect.StatusCode) {
+		fields = append(fields, apperror.FieldError{Field: "statusCode", Message: "statusCode must be one of 301, 302, 307, 308"})
+	}
+	if len(fields) > 0 {
+		return apperror.NewValidationError("Validation failed", fields)
+	}
+
+	taken, err := service.repo.FromPathExists(redirect.FromPath, redirect.ID)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+	if taken {
+		return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "fromPath", Message: "fromPath is already redirected"},
+		})
+	}
+
+	// Follow the redirects of the target, visitors must never be sent around in circles
+	target := redirect.ToPath
+	for hop := 0; hop < maxRedirectHops && strings.HasPrefix(target, "/"); hop++ {
+		next, err := service.repo.FindByFromPath(target)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		} else if err != nil {
+			return apperror.NewDBQueryError(err.Error())
+		}
+		if next.ToPath == redirect.FromPath {
+			return apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "toPath", Message: "toPath redirects back to fromPath"},
+			})
+		}
+		target = next.ToPath
+	}
+	return nil
+}
+
+// validRedirectTarget reports whether a target is a path of the website or an absolute http(s) URL
+func validRedirectTarget(target string) bool {
+	if strings.HasPrefix(target, "/") {
+		return !strings.HasPrefix(target, "//")
+	}
+	parsed, err := url.Parse(target)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
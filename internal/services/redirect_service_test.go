@@ -0,0 +1,153 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type RedirectServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockRedirectRepository
+	service *services.RedirectService
+}
+
+func (s *RedirectServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockRedirectRepository)
+	s.service = services.NewRedirectService(s.repo)
+}
+
+func (s *RedirectServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *RedirectServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *RedirectServiceTestSuite) validationFields(err error) []string {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	fields := make([]string, len(validationErr.Fields))
+	for i, field := range validationErr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func (s *RedirectServiceTestSuite) TestResolve() {
+	s.Run("Success", func() {
+		redirect := &models.Redirect{ID: 1, FromPath: "/posts/old", ToPath: "/posts/new", StatusCode: 301}
+		s.repo.On("FindByFromPath", "/posts/old").Return(redirect, nil).Once()
+
+		result, err := s.service.Resolve("/posts/old")
+		s.Require().NoError(err)
+		s.Equal(redirect, result)
+	})
+
+	s.Run("Not redirected", func() {
+		s.repo.On("FindByFromPath", "/posts/live").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.Resolve("/posts/live")
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Database error", func() {
+		s.repo.On("FindByFromPath", "/posts/x").Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.Resolve("/posts/x")
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *RedirectServiceTestSuite) TestCreateRedirect() {
+	s.Run("Success defaults to 301", func() {
+		redirect := &models.Redirect{FromPath: " /old ", ToPath: "/about"}
+		s.repo.On("FromPathExists", "/old", uint(0)).Return(false, nil).Once()
+		s.repo.On("FindByFromPath", "/about").Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("Create", redirect).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreateRedirect(redirect))
+		s.Equal("/old", redirect.FromPath)
+		s.Equal(301, redirect.StatusCode)
+	})
+
+	s.Run("Absolute URL target", func() {
+		redirect := &models.Redirect{FromPath: "/shop", ToPath: "https://shop.example.com/", StatusCode: 302}
+		s.repo.On("FromPathExists", "/shop", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", redirect).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreateRedirect(redirect))
+	})
+
+	s.Run("Invalid paths and status code", func() {
+		err := s.service.CreateRedirect(&models.Redirect{FromPath: "old?x=1", ToPath: "ftp://example.com", StatusCode: 200})
+		s.ElementsMatch([]string{"fromPath", "toPath", "statusCode"}, s.validationFields(err))
+	})
+
+	s.Run("Same paths", func() {
+		err := s.service.CreateRedirect(&models.Redirect{FromPath: "/old", ToPath: "/old"})
+		s.Equal([]string{"toPath"}, s.validationFields(err))
+	})
+
+	s.Run("From path taken", func() {
+		s.repo.On("FromPathExists", "/old", uint(0)).Return(true, nil).Once()
+
+		err := s.service.CreateRedirect(&models.Redirect{FromPath: "/old", ToPath: "/new"})
+		s.Equal([]string{"fromPath"}, s.validationFields(err))
+	})
+
+	s.Run("Loop", func() {
+		s.repo.On("FromPathExists", "/a", uint(0)).Return(false, nil).Once()
+		s.repo.On("FindByFromPath", "/b").Return(&models.Redirect{FromPath: "/b", ToPath: "/c"}, nil).Once()
+		s.repo.On("FindByFromPath", "/c").Return(&models.Redirect{FromPath: "/c", ToPath: "/a"}, nil).Once()
+
+		err := s.service.CreateRedirect(&models.Redirect{FromPath: "/a", ToPath: "/b"})
+		s.Equal([]string{"toPath"}, s.validationFields(err))
+	})
+
+	s.Run("Database error", func() {
+		redirect := &models.Redirect{FromPath: "/old", ToPath: "/new"}
+		s.repo.On("FromPathExists", "/old", uint(0)).Return(false, nil).Once()
+		s.repo.On("FindByFromPath", "/new").Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("Create", redirect).Return(errors.New("db error")).Once()
+
+		s.assertCode(s.service.CreateRedirect(redirect), apperror.ErrDBInsert)
+	})
+}
+
+func (s *RedirectServiceTestSuite) TestUpdateRedirect() {
+	s.Run("Edited redirect is no longer automatic", func() {
+		redirect := &models.Redirect{ID: 3, FromPath: "/posts/old", ToPath: "/posts/other", StatusCode: 308, Automatic: true}
+		s.repo.On("FromPathExists", "/posts/old", uint(3)).Return(false, nil).Once()
+		s.repo.On("FindByFromPath", "/posts/other").Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("Update", redirect).Return(nil).Once()
+
+		s.Require().NoError(s.service.UpdateRedirect(redirect))
+		s.False(redirect.Automatic)
+	})
+}
+
+func (s *RedirectServiceTestSuite) TestDeleteRedirect() {
+	s.Run("Success", func() {
+		s.repo.On("Delete", uint(1)).Return(int64(1), nil).Once()
+		s.Require().NoError(s.service.DeleteRedirect(1))
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("Delete", uint(9)).Return(int64(0), nil).Once()
+		s.assertCode(s.service.DeleteRedirect(9), apperror.ErrNotFound)
+	})
+}
+
+func TestRedirectServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RedirectServiceTestSuite))
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+)
+
+type IRenderService interface {
+	Render(body, format string) (*markup.Result, error)
+}
+
+// RenderService turns the bodies of posts and pages into sanitized HTML and caches the result in Redis
+type RenderService struct {
+	mediaRepo       repositories.IMediaRepository
+	globalBlockRepo repositories.IGlobalBlockRepository
+	client          redis.Cmdable
+	ttl             time.Duration
+	ctx             context.Context
+}
+
+// NewRenderService creates a new instance of RenderService
+// Parameters:
+//   - mediaRepo: Resolves the media files referenced by the bodies, e.g. ![Logo](media:42)
+//   - globalBlockRepo: Resolves the global blocks referenced by the bodies in the blocks format
+//   - client: The Redis client caching the rendered bodies
+//   - ttl: How long a rendered body is kept, 0 disables the cache
+//
+// Returns:
+//   - *RenderService: New RenderService instance
+func NewRenderService(
+	mediaRepo repositories.IMediaRepository,
+	globalBlockRepo repositories.IGlobalBlockRepository,
+	client redis.Cmdable,
+	ttl time.Duration,
+) *RenderService {
+	return &RenderService{
+		mediaRepo:       mediaRepo,
+		globalBlockRepo: globalBlockRepo,
+		client:          client,
+		ttl:             ttl,
+		ctx:             context.Background(),
+	}
+}
+
+// Render converts a body to sanitized HTML with its table of contents and reading time, see markup.Render
+// Rendered bodies are cached under the hash of their source, so an edited body is rendered again
+// A media file deleted after its body was rendered keeps its URL until the cache expires
+// Bodies in the blocks format are hashed once their global blocks are expanded, so an edited global block shows at once
+// Parameters:
+//   - body: The raw body of a post or page
+//   - format: models.BodyFormatMarkdown, models.BodyFormatHTML or models.BodyFormatBlocks
+//
+// Returns:
+//   - *markup.Result: The rendered body, with the resolved block tree for the blocks format
+//   - error: DBQuery error if the referenced media files or global blocks cannot be loaded
+func (service *RenderService) Render(body, format string) (*markup.Result, error) {
+	var tree []blocks.Block
+	if format == models.BodyFormatBlocks {
+		var err error
+		if tree, err = service.expandBlocks(body); err != nil {
+			return nil, err
+		}
+		// The expanded blocks are the source the cache key is computed from
+		expanded, _ := json.Marshal(tree)
+		body = string(expanded)
+	}
+
+	sum := sha256.Sum256([]byte(format + "\x00" + body))
+	key := constants.RENDERED + hex.EncodeToString(sum[:])
+
+	if service.ttl > 0 {
+		value, err := service.client.Get(service.ctx, key).Bytes()
+		if err == nil {
+			var result markup.Result
+			if err := json.Unmarshal(value, &result); err == nil {
+				return &result, nil
+			}
+		} else if err != redis.Nil {
+			logger.Warnf("Failed to get rendered body: %v", err)
+		}
+	}
+
+	// Blocks are converted to HTML, which is then rendered as any HTML body
+	source := body
+	if tree != nil {
+		source = blocks.ToHTML(tree)
+	}
+
+	media, err := service.mediaRepo.FindByIDs(markup.MediaIDs(source))
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	files := make(map[uint]*models.Media, len(media))
+	for i := range media {
+		files[media[i].ID] = &media[i]
+	}
+
+	resolve := func(id uint, variant string) (string, bool) {
+		file, ok := files[id]
+		if !ok {
+			return "", false
+		}
+		// Files without the requested resized copy, e.g. documents, are linked as they are
+		if copy, ok := file.Variants[variant]; ok {
+			return copy.URL, true
+		}
+		return file.URL, true
+	}
+	result := markup.Render(source, toMarkupFormat(format), resolve)
+	if tree != nil {
+		result.Blocks, _ = json.Marshal(blocks.ResolveMedia(tree, resolve))
+	}
+
+	if service.ttl > 0 {
+		value, _ := json.Marshal(result)
+		if err := service.client.Set(service.ctx, key, value, service.ttl).Err(); err != nil {
+			logger.Warnf("Failed to cache rendered body: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// expandBlocks reads a body in the blocks format and replaces its references to global blocks with their blocks
+// A body that is not a valid list of blocks, e.g. a translation written in another format, renders empty
+func (service *RenderService) expandBlocks(body string) ([]blocks.Block, error) {
+	tree, err := blocks.Parse(body)
+	if err != nil {
+		logger.Warnf("Failed to parse blocks: %v", err)
+		return []blocks.Block{}, nil
+	}
+
+	ids := blocks.GlobalIDs(tree)
+	if len(ids) == 0 {
+		return tree, nil
+	}
+	globalBlocks, err := service.globalBlockRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	globals := make(map[uint]blocks.Global, len(globalBlocks))
+	for _, globalBlock := range globalBlocks {
+		globals[globalBlock.ID] = blocks.Global{Name: globalBlock.Name, Blocks: globalBlock.Blocks}
+	}
+	return blocks.Expand(tree, globals), nil
+}
+
+// toMarkupFormat maps the format of a body to the format of markup.Render, bodies saved before formats existed are HTML
+func toMarkupFormat(format string) string {
+	if format == models.BodyFormatMarkdown {
+		return markup.FormatMarkdown
+	}
+	return markup.FormatHTML
+}
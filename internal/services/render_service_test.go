@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type RenderServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockMediaRepository
+	globals *mocks.MockGlobalBlockRepository
+	server  *miniredis.Miniredis
+	service *services.RenderService
+}
+
+func (s *RenderServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockMediaRepository)
+	s.globals = new(mocks.MockGlobalBlockRepository)
+	s.server = miniredis.RunT(s.T())
+	client := redis.NewClient(&redis.Options{Addr: s.server.Addr()})
+	s.T().Cleanup(func() { _ = client.Close() })
+	s.service = services.NewRenderService(s.repo, s.globals, client, time.Hour)
+}
+
+func (s *RenderServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.globals.AssertExpectations(s.T())
+}
+
+func (s *RenderServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *RenderServiceTestSuite) TestRender() {
+	s.Run("Markdown with media references", func() {
+		body := "# Intro\n\n![Logo](media:1/thumbnail) [Guide](media:2) ![Gone](media:3)\n\n<script>alert(1)</script>"
+		s.repo.On("FindByIDs", []uint{1, 2, 3}).Return([]models.Media{
+			{ID: 1, URL: "https://cdn.example.com/logo.png", Variants: map[string]models.MediaVariant{
+				"thumbnail": {URL: "https://cdn.example.com/logo-thumb.png"},
+			}},
+			{ID: 2, URL: "https://cdn.example.com/guide.pdf"},
+		}, nil).Once()
+
+		result, err := s.service.Render(body, models.BodyFormatMarkdown)
+		s.Require().NoError(err)
+		s.Contains(result.HTML, `<h1 id="intro">Intro</h1>`)
+		s.Contains(result.HTML, `src="https://cdn.example.com/logo-thumb.png"`)
+		s.Contains(result.HTML, `href="https://cdn.example.com/guide.pdf"`)
+		s.NotContains(result.HTML, "media:3")
+		s.NotContains(result.HTML, "script")
+		s.Len(result.TableOfContents, 1)
+		s.Equal(1, result.ReadingTime)
+
+		// The second render is served from the cache without querying the media files
+		cached, err := s.service.Render(body, models.BodyFormatMarkdown)
+		s.Require().NoError(err)
+		s.Equal(result, cached)
+	})
+
+	s.Run("HTML is not converted", func() {
+		s.server.FlushAll()
+		s.repo.On("FindByIDs", []uint(nil)).Return([]models.Media{}, nil).Once()
+
+		result, err := s.service.Render("# Title", models.BodyFormatHTML)
+		s.Require().NoError(err)
+		s.Equal("# Title", result.HTML)
+		s.Empty(result.TableOfContents)
+	})
+
+	s.Run("Database error", func() {
+		s.server.FlushAll()
+		s.repo.On("FindByIDs", []uint{7}).Return(nil, errors.New("connection refused")).Once()
+
+		result, err := s.service.Render(`<img src="media:7">`, models.BodyFormatHTML)
+		s.Nil(result)
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+
+	s.Run("Blocks with a global block", func() {
+		s.server.FlushAll()
+		body := `[{"type":"heading","data":{"text":"Intro"}},{"type":"global","data":{"global_block_id":3}},{"type":"image","data":{"media_id":1}}]`
+		newsletter := models.GlobalBlock{ID: 3, Name: "Newsletter", Blocks: []blocks.Block{
+			{Type: blocks.TypeParagraph, Data: map[string]any{"text": "Subscribe <script>x</script>"}},
+		}}
+		s.globals.On("FindByIDs", []uint{3}).Return([]models.GlobalBlock{newsletter}, nil).Twice()
+		s.repo.On("FindByIDs", []uint{1}).Return([]models.Media{{ID: 1, URL: "https://cdn.example.com/logo.png"}}, nil).Once()
+
+		result, err := s.service.Render(body, models.BodyFormatBlocks)
+		s.Require().NoError(err)
+		s.Equal(`<h2 id="intro">Intro</h2><div class="global-block"><p>Subscribe </p></div>`+
+			`<figure><img src="https://cdn.example.com/logo.png" alt=""></figure>`, result.HTML)
+		s.JSONEq(`[
+			{"type":"heading","data":{"text":"Intro"}},
+			{"type":"global","data":{"global_block_id":3,"name":"Newsletter","blocks":[{"type":"paragraph","data":{"text":"Subscribe "}}]}},
+			{"type":"image","data":{"media_id":1,"url":"https://cdn.example.com/logo.png"}}
+		]`, string(result.Blocks))
+
+		// The global blocks are loaded on every render, the media files only when the expanded body is not cached
+		cached, err := s.service.Render(body, models.BodyFormatBlocks)
+		s.Require().NoError(err)
+		s.Equal(result.HTML, cached.HTML)
+
+		// A deleted global block changes the expanded body, so it is rendered again instead of served from the cache
+		s.globals.On("FindByIDs", []uint{3}).Return([]models.GlobalBlock{}, nil).Once()
+		s.repo.On("FindByIDs", []uint{1}).Return([]models.Media{{ID: 1, URL: "https://cdn.example.com/logo.png"}}, nil).Once()
+		deleted, err := s.service.Render(body, models.BodyFormatBlocks)
+		s.Require().NoError(err)
+		s.NotContains(deleted.HTML, "global-block")
+	})
+
+	s.Run("Blocks sent to the front ends are sanitized", func() {
+		s.server.FlushAll()
+		body := `[{"type":"paragraph","data":{"text":"<p>Hello<script>alert(1)</script><img src=\"x\" onerror=\"alert(1)\"></p>"}}]`
+		s.repo.On("FindByIDs", []uint(nil)).Return([]models.Media{}, nil).Once()
+
+		result, err := s.service.Render(body, models.BodyFormatBlocks)
+		s.Require().NoError(err)
+		s.Contains(string(result.Blocks), "Hello")
+		s.NotContains(string(result.Blocks), "script")
+		s.NotContains(string(result.Blocks), "onerror")
+	})
+
+	s.Run("Invalid blocks render empty", func() {
+		s.server.FlushAll()
+		s.repo.On("FindByIDs", []uint(nil)).Return([]models.Media{}, nil).Once()
+
+		result, err := s.service.Render("Plain text", models.BodyFormatBlocks)
+		s.Require().NoError(err)
+		s.Empty(result.HTML)
+		s.JSONEq(`[]`, string(result.Blocks))
+	})
+
+	s.Run("Cache disabled", func() {
+		service := services.NewRenderService(s.repo, s.globals, redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"}), 0)
+		s.repo.On("FindByIDs", []uint(nil)).Return([]models.Media{}, nil).Twice()
+
+		for range 2 {
+			result, err := service.Render("**bold**", models.BodyFormatMarkdown)
+			s.Require().NoError(err)
+			s.Equal("<p><strong>bold</strong></p>\n", result.HTML)
+		}
+	})
+}
+
+func TestRenderServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RenderServiceTestSuite))
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+// Tags of the cached responses of the delivery API, a change of content invalidates every response with its tag
+const (
+	CacheTagPosts      = "posts"
+	CacheTagCategories = "categories"
+	CacheTagPages      = "pages"
+	CacheTagMenus      = "menus"
+	CacheTagComments   = "comments"
+	CacheTagRedirects  = "redirects"
+)
+
+// CacheTables maps the tables read by the delivery API to the tags of the responses rendered from their rows
+var CacheTables = map[string][]string{
+	"posts":         {CacheTagPosts, CacheTagMenus},
+	"post_tags":     {CacheTagPosts},
+	"categories":    {CacheTagPosts, CacheTagCategories, CacheTagMenus},
+	"tags":          {CacheTagPosts},
+	"users":         {CacheTagPosts, CacheTagComments}, // Author names
+	"pages":         {CacheTagPages, CacheTagMenus},
+	"menus":         {CacheTagMenus},
+	"menu_items":    {CacheTagMenus},
+	"translations":  {CacheTagPosts, CacheTagPages, CacheTagMenus},
+	"comments":      {CacheTagComments},
+	"redirects":     {CacheTagRedirects},
+	"global_blocks": {CacheTagPosts, CacheTagPages}, // Resolved into the bodies of posts and pages
+}
+
+// CachedResponse is a response of the delivery API as it is replayed to the clients
+type CachedResponse struct {
+	Status       int       `json:"status"`
+	ContentType  string    `json:"contentType"`
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"lastModified"`
+}
+
+type IResponseCacheService interface {
+	Get(key string) (*CachedResponse, error)
+	Set(key string, response *CachedResponse, tags []string) error
+	Invalidate(tags ...string) error
+	InvalidateTable(table string, ids []uint)
+}
+
+// ResponseCacheService stores rendered responses in Redis and drops them by tag when the content they show changes
+type ResponseCacheService struct {
+	client redis.Cmdable
+	ttl    time.Duration
+	ctx    context.Context
+}
+
+// NewResponseCacheService creates a new instance of ResponseCacheService
+// Parameters:
+//   - client: The Redis client
+//   - ttl: How long a response is kept when no change invalidates it, 0 disables the cache
+//
+// Returns:
+//   - *ResponseCacheService: New ResponseCacheService instance
+func NewResponseCacheService(client redis.Cmdable, ttl time.Duration) *ResponseCacheService {
+	return &ResponseCacheService{
+		client: client,
+		ttl:    ttl,
+		ctx:    context.Background(),
+	}
+}
+
+// Get retrieves a cached response
+// Returns nil without error when the response is not cached or the cache is disabled
+func (service *ResponseCacheService) Get(key string) (*CachedResponse, error) {
+	if service.ttl <= 0 {
+		return nil, nil
+	}
+
+	value, err := service.client.Get(service.ctx, constants.RESPONSE_CACHE+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, apperror.NewCacheGetError(err.Error())
+	}
+
+	var response CachedResponse
+	if err := json.Unmarshal(value, &response); err != nil {
+		return nil, apperror.NewCacheGetError(err.Error())
+	}
+	return &response, nil
+}
+
+// Set caches a response under the given tags
+// Parameters:
+//   - key: The key of the request, see middlewares.ResponseCacheMiddleware
+//   - response: The rendered response
+//   - tags: The tags invalidating the response, e.g. CacheTagPosts
+//
+// Returns:
+//   - error: CacheSet error if the response cannot be stored
+func (service *ResponseCacheService) Set(key string, response *CachedResponse, tags []string) error {
+	if service.ttl <= 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(response)
+	if err != nil {
+		return apperror.NewCacheSetError(err.Error())
+	}
+
+	// The tag sets outlive the responses they list, a key left behind by an expired response is harmless
+	_, err = service.client.TxPipelined(service.ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(service.ctx, constants.RESPONSE_CACHE+key, value, service.ttl)
+		for _, tag := range tags {
+			pipe.SAdd(service.ctx, constants.RESPONSE_TAG+tag, key)
+			pipe.Expire(service.ctx, constants.RESPONSE_TAG+tag, 2*service.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return apperror.NewCacheSetError(err.Error())
+	}
+	return nil
+}
+
+// Invalidate drops every cached response with one of the tags
+func (service *ResponseCacheService) Invalidate(tags ...string) error {
+	if service.ttl <= 0 {
+		return nil
+	}
+
+	for _, tag := range tags {
+		keys, err := service.client.SMembers(service.ctx, constants.RESPONSE_TAG+tag).Result()
+		if err != nil {
+			return apperror.NewCacheListError(err.Error())
+		}
+
+		toDelete := []string{constants.RESPONSE_TAG + tag}
+		for _, key := range keys {
+			toDelete = append(toDelete, constants.RESPONSE_CACHE+key)
+		}
+		if err := service.client.Del(service.ctx, toDelete...).Err(); err != nil {
+			return apperror.NewCacheDeleteError(err.Error())
+		}
+	}
+	return nil
+}
+
+// InvalidateTable drops the cached responses showing rows of a changed table, it is registered with repositories.WatchChanges
+// Errors are logged, the responses expire with their TTL when Redis cannot be reached
+func (service *ResponseCacheService) InvalidateTable(table string, ids []uint) {
+	tags, ok := CacheTables[table]
+	if !ok {
+		return
+	}
+	if err := service.Invalidate(tags...); err != nil {
+		logger.Warnf("Failed to invalidate cached responses of %s: %v", table, err)
+	}
+}
@@ -0,0 +1,95 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+func setupResponseCache(t *testing.T, ttl time.Duration) (*services.ResponseCacheService, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return services.NewResponseCacheService(client, ttl), server
+}
+
+func TestResponseCacheService(t *testing.T) {
+	response := &services.CachedResponse{
+		Status:       200,
+		ContentType:  "application/json; charset=utf-8",
+		Body:         []byte(`{"slug":"hello"}`),
+		ETag:         `"abc"`,
+		LastModified: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	t.Run("Set and Get", func(t *testing.T) {
+		cache, server := setupResponseCache(t, time.Minute)
+
+		require.NoError(t, cache.Set("key", response, []string{services.CacheTagPosts}))
+
+		cached, err := cache.Get("key")
+		require.NoError(t, err)
+		assert.Equal(t, response.Body, cached.Body)
+		assert.Equal(t, response.ETag, cached.ETag)
+		assert.True(t, response.LastModified.Equal(cached.LastModified))
+		assert.Equal(t, time.Minute, server.TTL("RESPONSE_key"))
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		cache, _ := setupResponseCache(t, time.Minute)
+
+		cached, err := cache.Get("missing")
+		assert.NoError(t, err)
+		assert.Nil(t, cached)
+	})
+
+	t.Run("Invalidate drops only tagged responses", func(t *testing.T) {
+		cache, _ := setupResponseCache(t, time.Minute)
+		require.NoError(t, cache.Set("post", response, []string{services.CacheTagPosts}))
+		require.NoError(t, cache.Set("menu", response, []string{services.CacheTagMenus}))
+
+		require.NoError(t, cache.Invalidate(services.CacheTagPosts))
+
+		cached, err := cache.Get("post")
+		assert.NoError(t, err)
+		assert.Nil(t, cached)
+		cached, err = cache.Get("menu")
+		assert.NoError(t, err)
+		assert.NotNil(t, cached)
+	})
+
+	t.Run("InvalidateTable", func(t *testing.T) {
+		cache, _ := setupResponseCache(t, time.Minute)
+		require.NoError(t, cache.Set("post", response, []string{services.CacheTagPosts}))
+		require.NoError(t, cache.Set("comments", response, []string{services.CacheTagComments}))
+
+		cache.InvalidateTable("audit_logs", []uint{1})
+		cache.InvalidateTable("post_tags", []uint{1})
+
+		cached, _ := cache.Get("post")
+		assert.Nil(t, cached)
+		cached, _ = cache.Get("comments")
+		assert.NotNil(t, cached)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cache, server := setupResponseCache(t, 0)
+
+		require.NoError(t, cache.Set("key", response, []string{services.CacheTagPosts}))
+		assert.Empty(t, server.Keys())
+	})
+
+	t.Run("Redis unavailable", func(t *testing.T) {
+		cache, server := setupResponseCache(t, time.Minute)
+		server.Close()
+
+		_, err := cache.Get("key")
+		assert.Error(t, err)
+		assert.Error(t, cache.Set("key", response, nil))
+	})
+}
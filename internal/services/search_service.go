@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"sync"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/search"
+)
+
+// Types of the documents in the search index
+const (
+	SearchTypePost = "post"
+	SearchTypePage = "page"
+	SearchTypeUser = "user"
+)
+
+// SearchTypes lists every type of document in the search index
+var SearchTypes = []string{SearchTypePost, SearchTypePage, SearchTypeUser}
+
+// searchTableTypes maps the watched tables to the type of their documents
+var searchTableTypes = map[string]string{
+	"posts": SearchTypePost,
+	"pages": SearchTypePage,
+	"users": SearchTypeUser,
+}
+
+// searchBatchSize is the number of records loaded at once while rebuilding the index
+const searchBatchSize = 200
+
+// SearchQuery is a full-text search with the page of results to return
+type SearchQuery struct {
+	Text    string            // Words to look for
+	Types   []string          // Types of documents to search, see SearchTypes
+	Filters map[string]string // Exact values of document fields, e.g. {"status": "published"}
+	Page    int
+	Limit   int
+}
+
+type ISearchService interface {
+	Search(query SearchQuery) (*utils.Pagination, error)
+	QueueChange(table string, ids []uint)
+	QueueReindex()
+	ProcessPending(ctx context.Context) error
+}
+
+// SearchService keeps the search index in sync with posts, pages and users and runs searches on it
+// Changes are queued in memory by QueueChange and written to the index by ProcessPending
+type SearchService struct {
+	repo  repositories.ISearchRepository
+	index search.Index
+
+	mu      sync.Mutex
+	pending map[string]map[uint]bool // IDs to index again, keyed by document type
+	rebuild map[string]bool          // Document types to rebuild entirely
+}
+
+// NewSearchService creates a new instance of SearchService
+// Parameters:
+//   - repo: Repository loading the indexed records
+//   - index: Backend storing the documents
+//
+// Returns:
+//   - *SearchService: New SearchService instance initialized with the provided dependencies
+func NewSearchService(repo repositories.ISearchRepository, index search.Index) *SearchService {
+	return &SearchService{
+		repo:    repo,
+		index:   index,
+		pending: make(map[string]map[uint]bool),
+		rebuild: make(map[string]bool),
+	}
+}
+
+// Search runs a full-text search and returns a page of hits, most relevant first
+// Parameters:
+//   - query: The words, types, filters and page of the search
+//
+// Returns:
+//   - *utils.Pagination: The page of hits, each hit carries the highlighted title and body snippet
+//   - error: ValidationError if the text has no word, DBQuery error if the index cannot be searched
+func (service *SearchService) Search(query SearchQuery) (*utils.Pagination, error) {
+	if len(search.Tokenize(query.Text)) == 0 {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "q", Message: "q must contain at least one word"},
+		})
+	}
+
+	result, err := service.index.Search(search.Query{
+		Text:    query.Text,
+		Types:   query.Types,
+		Filters: query.Filters,
+		Offset:  (query.Page - 1) * query.Limit,
+		Limit:   query.Limit,
+	})
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	return &utils.Pagination{
+		Page:       query.Page,
+		Limit:      query.Limit,
+		TotalItems: int(result.Total),
+		TotalPages: utils.CalculateTotalPages(result.Total, query.Limit),
+		Data:       result.Hits,
+	}, nil
+}
+
+// QueueChange records changed rows of an indexed table, it is registered with ISearchRepository.WatchChanges
+// Parameters:
+//   - table: The table of the rows, e.g. "posts"
+//   - ids: The IDs of the rows, nil rebuilds every document of the table
+func (service *SearchService) QueueChange(table string, ids []uint) {
+	docType, ok := searchTableTypes[table]
+	if !ok {
+		return
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if ids == nil {
+		service.rebuild[docType] = true
+		return
+	}
+	if service.pending[docType] == nil {
+		service.pending[docType] = make(map[uint]bool)
+	}
+	for _, id := range ids {
+		service.pending[docType][id] = true
+	}
+}
+
+// QueueReindex schedules a rebuild of every document type, e.g. on start up with an in-process index
+func (service *SearchService) QueueReindex() {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	for _, docType := range SearchTypes {
+		service.rebuild[docType] = true
+	}
+}
+
+// ProcessPending writes the queued changes to the index, run by a background job
+// Changes that cannot be written are queued again and retried on the next run
+// Parameters:
+//   - ctx: Context cancelled when the application stops
+//
+// Returns:
+//   - error: The errors of the document types that failed
+//
+// The function:
+//  1. Takes the queued changes, new changes are queued for the next run meanwhile
+//  2. Rebuilds the document types queued for a rebuild, their pending IDs are covered by the rebuild
+//  3. Loads the changed records, indexes the existing ones and removes the deleted ones from the index
+func (service *SearchService) ProcessPending(ctx context.Context) error {
+	service.mu.Lock()
+	pending, rebuild := service.pending, service.rebuild
+	service.pending, service.rebuild = make(map[string]map[uint]bool), make(map[string]bool)
+	service.mu.Unlock()
+
+	var errs []error
+	for _, docType := range SearchTypes {
+		if ctx.Err() != nil {
+			// Queue what is left so it is processed after a restart of the job
+			service.requeue(docType, pending[docType], rebuild[docType])
+			continue
+		}
+
+		if rebuild[docType] {
+			if err := service.rebuildType(ctx, docType); err != nil {
+				service.requeue(docType, nil, true)
+				errs = append(errs, fmt.Errorf("rebuild %s documents: %w", docType, err))
+			}
+			continue
+		}
+
+		if len(pending[docType]) == 0 {
+			continue
+		}
+		ids := make([]uint, 0, len(pending[docType]))
+		for id := range pending[docType] {
+			ids = append(ids, id)
+		}
+		if err := service.indexIDs(docType, ids); err != nil {
+			service.requeue(docType, pending[docType], false)
+			errs = append(errs, fmt.Errorf("index %s documents: %w", docType, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// requeue puts back changes that were not written to the index
+func (service *SearchService) requeue(docType string, ids map[uint]bool, rebuild bool) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if rebuild {
+		service.rebuild[docType] = true
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if service.pending[docType] == nil {
+		service.pending[docType] = make(map[uint]bool)
+	}
+	maps.Copy(service.pending[docType], ids)
+}
+
+// indexIDs indexes the records of a type with the given IDs and removes the missing ones from the index
+func (service *SearchService) indexIDs(docType string, ids []uint) error {
+	docs, err := service.load(docType, ids, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	found := make(map[uint]bool, len(docs))
+	for _, doc := range docs {
+		found[doc.ID] = true
+		if err := service.index.Index(doc); err != nil {
+			return err
+		}
+	}
+	for _, id := range ids {
+		if !found[id] {
+			if err := service.index.Delete(docType, id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rebuildType removes every document of a type and indexes all its records again, batch by batch
+// Searches may miss documents of the type until the rebuild completes
+func (service *SearchService) rebuildType(ctx context.Context, docType string) error {
+	if err := service.index.DeleteType(docType); err != nil {
+		return err
+	}
+
+	var afterID uint
+	for ctx.Err() == nil {
+		docs, err := service.load(docType, nil, afterID, searchBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			if err := service.index.Index(doc); err != nil {
+				return err
+			}
+			afterID = doc.ID
+		}
+		if len(docs) < searchBatchSize {
+			logger.Infof("Rebuilt the %s documents of the search index", docType)
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// load builds the documents of the records with the given IDs, or of the next batch after afterID when ids is nil
+func (service *SearchService) load(docType string, ids []uint, afterID uint, limit int) ([]search.Document, error) {
+	var docs []search.Document
+	switch docType {
+	case SearchTypePost:
+		var posts []models.Post
+		var err error
+		if ids != nil {
+			posts, err = service.repo.FindPosts(ids)
+		} else {
+			posts, err = service.repo.FindPostsAfter(afterID, limit)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			docs = append(docs, postDocument(post))
+		}
+	case SearchTypePage:
+		var pages []models.Page
+		var err error
+		if ids != nil {
+			pages, err = service.repo.FindPages(ids)
+		} else {
+			pages, err = service.repo.FindPagesAfter(afterID, limit)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range pages {
+			docs = append(docs, pageDocument(page))
+		}
+	case SearchTypeUser:
+		var users []models.User
+		var err error
+		if ids != nil {
+			users, err = service.repo.FindUsers(ids)
+		} else {
+			users, err = service.repo.FindUsersAfter(afterID, limit)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			docs = append(docs, userDocument(user))
+		}
+	}
+	return docs, nil
+}
+
+// postDocument builds the search document of a post, its status, author and category can be filtered on
+func postDocument(post models.Post) search.Document {
+	body := post.Body
+	if post.Excerpt != nil {
+		body = *post.Excerpt + "\n" + body
+	}
+	fields := map[string]string{
+		"status":    post.Status,
+		"slug":      post.Slug,
+		"author_id": strconv.FormatUint(uint64(post.AuthorID), 10),
+	}
+	if post.CategoryID != nil {
+		fields["category_id"] = strconv.FormatUint(uint64(*post.CategoryID), 10)
+	}
+	return search.Document{
+		Type:   SearchTypePost,
+		ID:     post.ID,
+		Title:  post.Title,
+		Body:   search.PlainText(body),
+		Fields: fields,
+	}
+}
+
+// pageDocument builds the search document of a page, its status can be filtered on
+func pageDocument(page models.Page) search.Document {
+	return search.Document{
+		Type:  SearchTypePage,
+		ID:    page.ID,
+		Title: page.Title,
+		Body:  search.PlainText(page.Body),
+		Fields: map[string]string{
+			"status": page.Status,
+			"path":   "/" + page.Path,
+		},
+	}
+}
+
+// userDocument builds the search document of a user, matched on name and email
+func userDocument(user models.User) search.Document {
+	return search.Document{
+		Type:  SearchTypeUser,
+		ID:    user.ID,
+		Title: user.Name,
+		Body:  user.Email,
+		Fields: map[string]string{
+			"status": user.Status,
+			"email":  user.Email,
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/search"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type SearchServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockSearchRepository
+	index   *search.MemoryIndex
+	service *services.SearchService
+}
+
+func (s *SearchServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockSearchRepository)
+	s.index = search.NewMemoryIndex()
+	s.service = services.NewSearchService(s.repo, s.index)
+}
+
+func (s *SearchServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *SearchServiceTestSuite) hits(text string, filters map[string]string, types ...string) []search.Hit {
+	pagination, err := s.service.Search(services.SearchQuery{Text: text, Types: types, Filters: filters, Page: 1, Limit: 10})
+	s.Require().NoError(err)
+	return pagination.Data.([]search.Hit)
+}
+
+func (s *SearchServiceTestSuite) TestProcessPending() {
+	s.Run("Indexes changed records and removes deleted ones", func() {
+		categoryID := uint(4)
+		s.Require().NoError(s.index.Index(search.Document{Type: services.SearchTypePost, ID: 2, Title: "Golang tips"}))
+		s.repo.On("FindPosts", mock.MatchedBy(func(ids []uint) bool { return len(ids) == 2 })).Return([]models.Post{
+			{ID: 1, Title: "Hello Golang", Slug: "hello", Body: "<p>Learn <b>generics</b></p>", AuthorID: 3, CategoryID: &categoryID, Status: models.PostStatusPublished},
+		}, nil).Once()
+		s.repo.On("FindUsers", []uint{5}).Return([]models.User{{ID: 5, Name: "Jane Doe", Email: "jane@example.com", Status: models.UserStatusActive}}, nil).Once()
+
+		s.service.QueueChange("posts", []uint{1, 2})
+		s.service.QueueChange("users", []uint{5})
+		s.service.QueueChange("categories", []uint{9})
+		s.Require().NoError(s.service.ProcessPending(context.Background()))
+
+		hits := s.hits("golang", nil)
+		s.Require().Len(hits, 1)
+		s.Equal(uint(1), hits[0].ID)
+		s.Equal(map[string]string{"status": "published", "slug": "hello", "author_id": "3", "category_id": "4"}, hits[0].Fields)
+		s.Equal("Learn <mark>generics</mark>", s.hits("generics", nil)[0].Highlights["body"])
+		s.Len(s.hits("jane", map[string]string{"status": models.UserStatusActive}, services.SearchTypeUser), 1)
+	})
+
+	s.Run("Rebuilds a type when the changed rows are unknown", func() {
+		s.Require().NoError(s.index.Index(search.Document{Type: services.SearchTypePage, ID: 9, Title: "Stale page"}))
+		s.repo.On("FindPagesAfter", uint(0), 200).Return([]models.Page{
+			{ID: 1, Title: "About us", Path: "about", Status: models.PageStatusPublished},
+		}, nil).Once()
+
+		s.service.QueueChange("pages", []uint{3})
+		s.service.QueueChange("pages", nil)
+		s.Require().NoError(s.service.ProcessPending(context.Background()))
+
+		s.Empty(s.hits("stale", nil))
+		hits := s.hits("about", nil, services.SearchTypePage)
+		s.Require().Len(hits, 1)
+		s.Equal("/about", hits[0].Fields["path"])
+	})
+
+	s.Run("Retries failed changes on the next run", func() {
+		s.repo.On("FindPosts", []uint{7}).Return(nil, errors.New("connection lost")).Once()
+		s.service.QueueChange("posts", []uint{7})
+		s.Error(s.service.ProcessPending(context.Background()))
+
+		s.repo.On("FindPosts", []uint{7}).Return([]models.Post{{ID: 7, Title: "Recovered", Slug: "recovered"}}, nil).Once()
+		s.Require().NoError(s.service.ProcessPending(context.Background()))
+		s.Len(s.hits("recovered", nil), 1)
+	})
+}
+
+func (s *SearchServiceTestSuite) TestQueueReindex() {
+	s.repo.On("FindPostsAfter", uint(0), 200).Return([]models.Post{{ID: 1, Title: "Post"}}, nil).Once()
+	s.repo.On("FindPagesAfter", uint(0), 200).Return([]models.Page{}, nil).Once()
+	s.repo.On("FindUsersAfter", uint(0), 200).Return([]models.User{{ID: 1, Name: "User"}}, nil).Once()
+
+	s.service.QueueReindex()
+	s.Require().NoError(s.service.ProcessPending(context.Background()))
+	s.Len(s.hits("post user", nil), 2)
+
+	// Nothing is left to do on the next run
+	s.Require().NoError(s.service.ProcessPending(context.Background()))
+}
+
+func (s *SearchServiceTestSuite) TestSearch() {
+	for i := uint(1); i <= 3; i++ {
+		s.Require().NoError(s.index.Index(search.Document{Type: services.SearchTypePost, ID: i, Title: "Release notes"}))
+	}
+
+	s.Run("Paginates", func() {
+		pagination, err := s.service.Search(services.SearchQuery{Text: "release", Page: 2, Limit: 2})
+		s.Require().NoError(err)
+		s.Equal(3, pagination.TotalItems)
+		s.Equal(2, pagination.TotalPages)
+		s.Len(pagination.Data, 1)
+	})
+
+	s.Run("Error without words", func() {
+		_, err := s.service.Search(services.SearchQuery{Text: "?!", Page: 1, Limit: 10})
+		var validationErr *apperror.ValidationError
+		s.Require().True(errors.As(err, &validationErr))
+		s.Equal("q", validationErr.Fields[0].Field)
+	})
+}
+
+func TestSearchServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchServiceTestSuite))
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vfa-khuongdv/golang-cms/internal/constants"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+	"github.com/vfa-khuongdv/golang-cms/pkg/sitemap"
+)
+
+// Sections of the sitemap, each section is split into files of at most sitemap.MaxURLs URLs
+const (
+	SitemapSectionPosts      = "posts"
+	SitemapSectionPages      = "pages"
+	SitemapSectionCategories = "categories"
+	SitemapSectionTags       = "tags"
+)
+
+// SitemapSections lists the sections of the sitemap in the order of the index
+var SitemapSections = []string{SitemapSectionPosts, SitemapSectionPages, SitemapSectionCategories, SitemapSectionTags}
+
+// SitemapTables maps the tables read by the sitemap to the sections regenerated when their rows change
+// Taxonomy archives are listed once they have a published post, so publishing a post changes them too
+var SitemapTables = map[string][]string{
+	"posts":      {SitemapSectionPosts, SitemapSectionCategories, SitemapSectionTags},
+	"post_tags":  {SitemapSectionTags},
+	"pages":      {SitemapSectionPages},
+	"categories": {SitemapSectionCategories},
+	"tags":       {SitemapSectionTags},
+}
+
+// sitemapFilesField is the field of a section hash holding the ID after which each of its files starts
+// A file lists the rows after its own ID up to the one of the next file, the files are stored under their number
+const sitemapFilesField = "files"
+
+// sitemapLastModField returns the field of a section hash holding the lastmod of one of its files
+func sitemapLastModField(number int) string {
+	return "lastmod:" + strconv.Itoa(number)
+}
+
+type ISitemapService interface {
+	GetIndex() ([]byte, error)
+	GetSitemap(section string, number int) ([]byte, error)
+	InvalidateTable(table string, ids []uint)
+}
+
+type SitemapService struct {
+	repo    repositories.ISitemapRepository
+	client  redis.Cmdable
+	siteURL string
+	ttl     time.Duration
+	ctx     context.Context
+}
+
+// NewSitemapService creates a new instance of SitemapService
+// Parameters:
+//   - repo: The repository of the published content
+//   - client: The Redis client caching the generated files
+//   - siteURL: Base URL of the website, the sitemap files are linked as siteURL/sitemaps/posts-1.xml
+//   - ttl: How long generated files are kept when no change regenerates them, 0 disables the cache
+//
+// Returns:
+//   - *SitemapService: New SitemapService instance
+func NewSitemapService(repo repositories.ISitemapRepository, client redis.Cmdable, siteURL string, ttl time.Duration) *SitemapService {
+	return &SitemapService{
+		repo:    repo,
+		client:  client,
+		siteURL: strings.TrimSuffix(siteURL, "/"),
+		ttl:     ttl,
+		ctx:     context.Background(),
+	}
+}
+
+// GetIndex retrieves the sitemap index listing the files of every section
+// Sections are generated on the first request after they changed, the others are read from the cache
+// Returns:
+//   - []byte: The encoded index
+//   - error: DBQuery error if a section cannot be generated
+func (service *SitemapService) GetIndex() ([]byte, error) {
+	var sitemaps []sitemap.Sitemap
+	for _, section := range SitemapSections {
+		lastMods, err := service.cachedLastMods(section)
+		if err != nil {
+			return nil, err
+		}
+		for i, lastMod := range lastMods {
+			sitemaps = append(sitemaps, sitemap.Sitemap{
+				Loc:     fmt.Sprintf("%s/sitemaps/%s-%d.xml", service.siteURL, section, i+1),
+				LastMod: lastMod,
+			})
+		}
+	}
+
+	body, err := sitemap.EncodeIndex(sitemaps)
+	if err != nil {
+		return nil, apperror.NewInternalError(err.Error())
+	}
+	return body, nil
+}
+
+// GetSitemap retrieves a file of a section
+// Parameters:
+//   - section: The section, e.g. SitemapSectionPosts
+//   - number: The number of the file within the section, starting at 1
+//
+// Returns:
+//   - []byte: The encoded sitemap
+//   - error: NotFound error if the section has no such file, DBQuery error if the section cannot be generated
+func (service *SitemapService) GetSitemap(section string, number int) ([]byte, error) {
+	if !slices.Contains(SitemapSections, section) || number < 1 {
+		return nil, apperror.NewNotFoundError("Sitemap not found")
+	}
+
+	body, err := service.client.HGet(service.ctx, constants.SITEMAP+section, strconv.Itoa(number)).Bytes()
+	if err == nil {
+		return body, nil
+	} else if err != redis.Nil {
+		logger.Warnf("Failed to get cached sitemap %s-%d: %v", section, number, err)
+	}
+
+	// A missing file of a generated section either does not exist or was invalidated, only the latter is generated
+	if err == redis.Nil {
+		if afterIDs, ok := service.cachedFiles(section); ok {
+			if number > len(afterIDs) {
+				return nil, apperror.NewNotFoundError("Sitemap not found")
+			}
+			_, body, err := service.regenerate(section, number, afterIDs)
+			if err != nil {
+				return nil, err
+			}
+			if body != nil {
+				return body, nil
+			}
+		}
+	}
+
+	_, body, err = service.generate(section, number)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, apperror.NewNotFoundError("Sitemap not found")
+	}
+	return body, nil
+}
+
+// InvalidateTable drops the cached sitemap files listing rows of a changed table, it is registered with repositories.WatchChanges
+// Only the files holding the changed IDs are dropped from the section of the table itself, they are generated again on the next request
+// The other sections, and every section when the IDs are unknown, are dropped as a whole, e.g. a post changes the archives of its tags
+// Errors are logged and the files expire with their TTL
+func (service *SitemapService) InvalidateTable(table string, ids []uint) {
+	sections, ok := SitemapTables[table]
+	if !ok {
+		return
+	}
+
+	var keys []string
+	for _, section := range sections {
+		key := constants.SITEMAP + section
+		if section != table || len(ids) == 0 {
+			keys = append(keys, key)
+			continue
+		}
+
+		afterIDs, ok := service.cachedFiles(section)
+		if !ok {
+			keys = append(keys, key)
+			continue
+		}
+		var fields []string
+		for _, number := range sitemapFileNumbers(afterIDs, ids) {
+			fields = append(fields, strconv.Itoa(number), sitemapLastModField(number))
+		}
+		if err := service.client.HDel(service.ctx, key, fields...).Err(); err != nil {
+			logger.Warnf("Failed to invalidate sitemap files of %s: %v", table, err)
+		}
+	}
+
+	if len(keys) == 0 {
+		return
+	}
+	if err := service.client.Del(service.ctx, keys...).Err(); err != nil {
+		logger.Warnf("Failed to invalidate sitemap sections of %s: %v", table, err)
+	}
+}
+
+// sitemapFileNumbers returns the numbers of the files whose ID range holds some of the IDs
+func sitemapFileNumbers(afterIDs []uint, ids []uint) []int {
+	var numbers []int
+	for _, id := range ids {
+		// The file starting after the greatest ID below this one
+		index, _ := slices.BinarySearch(afterIDs, id)
+		if index > 0 && !slices.Contains(numbers, index) {
+			numbers = append(numbers, index)
+		}
+	}
+	return numbers
+}
+
+// cachedFiles returns the ID after which each file of a cached section starts, false when the section is not cached
+func (service *SitemapService) cachedFiles(section string) ([]uint, bool) {
+	value, err := service.client.HGet(service.ctx, constants.SITEMAP+section, sitemapFilesField).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			logger.Warnf("Failed to get cached sitemap %s: %v", section, err)
+		}
+		return nil, false
+	}
+
+	var afterIDs []uint
+	if err := json.Unmarshal(value, &afterIDs); err != nil {
+		return nil, false
+	}
+	return afterIDs, true
+}
+
+// cachedLastMods returns the lastmod of each file of a section
+// The section is generated when it is not cached, and its invalidated files when it is
+func (service *SitemapService) cachedLastMods(section string) ([]time.Time, error) {
+	afterIDs, ok := service.cachedFiles(section)
+	if !ok {
+		lastMods, _, err := service.generate(section, 0)
+		return lastMods, err
+	}
+
+	fields := make([]string, len(afterIDs))
+	for i := range afterIDs {
+		fields[i] = sitemapLastModField(i + 1)
+	}
+	values, err := service.client.HMGet(service.ctx, constants.SITEMAP+section, fields...).Result()
+	if err != nil {
+		logger.Warnf("Failed to get cached sitemap %s: %v", section, err)
+		lastMods, _, err := service.generate(section, 0)
+		return lastMods, err
+	}
+
+	lastMods := make([]time.Time, len(afterIDs))
+	for i, value := range values {
+		if text, ok := value.(string); ok {
+			if lastMod, err := time.Parse(time.RFC3339Nano, text); err == nil {
+				lastMods[i] = lastMod
+				continue
+			}
+		}
+
+		lastMod, body, err := service.regenerate(section, i+1, afterIDs)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			lastMods, _, err := service.generate(section, 0)
+			return lastMods, err
+		}
+		lastMods[i] = lastMod
+	}
+	return lastMods, nil
+}
+
+// generate encodes every file of a section and caches them, one file is queried and encoded at a time
+// Parameters:
+//   - section: The section to generate
+//   - number: The number of the file to return, 0 for none
+//
+// Returns:
+//   - []time.Time: The lastmod of each file, the latest UpdatedAt of its URLs
+//   - []byte: The requested file, nil if the section has no such file
+//   - error: DBQuery error if the content cannot be loaded
+func (service *SitemapService) generate(section string, number int) ([]time.Time, []byte, error) {
+	fields := map[string]any{}
+	lastMods := []time.Time{}
+	afterIDs := []uint{}
+	var requested []byte
+	var afterID uint
+
+	for {
+		entries, err := service.find(section, afterID, sitemap.MaxURLs)
+		if err != nil {
+			return nil, nil, apperror.NewDBQueryError(err.Error())
+		}
+		if len(entries) == 0 {
+			break
+		}
+
+		lastMod, body, err := service.encode(section, entries)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		lastMods = append(lastMods, lastMod)
+		afterIDs = append(afterIDs, afterID)
+		fields[strconv.Itoa(len(lastMods))] = body
+		fields[sitemapLastModField(len(lastMods))] = lastMod.Format(time.RFC3339Nano)
+		if len(lastMods) == number {
+			requested = body
+		}
+
+		afterID = entries[len(entries)-1].ID
+		if len(entries) < sitemap.MaxURLs {
+			break
+		}
+	}
+
+	if service.ttl <= 0 {
+		return lastMods, requested, nil
+	}
+
+	value, _ := json.Marshal(afterIDs)
+	fields[sitemapFilesField] = value
+	key := constants.SITEMAP + section
+	_, err := service.client.TxPipelined(service.ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(service.ctx, key)
+		pipe.HSet(service.ctx, key, fields)
+		pipe.Expire(service.ctx, key, service.ttl)
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("Failed to cache sitemap %s: %v", section, err)
+	}
+
+	return lastMods, requested, nil
+}
+
+// regenerate encodes one file of a cached section again, only the rows of its ID range are queried
+// Parameters:
+//   - section: The section of the file
+//   - number: The number of the file, starting at 1
+//   - afterIDs: The ID after which each file of the section starts
+//
+// Returns:
+//   - time.Time: The lastmod of the file
+//   - []byte: The file, nil when it became empty or holds too many URLs, the whole section must then be generated again
+//   - error: DBQuery error if the content cannot be loaded
+func (service *SitemapService) regenerate(section string, number int, afterIDs []uint) (time.Time, []byte, error) {
+	// One more row than a file holds tells whether the range still fits
+	entries, err := service.find(section, afterIDs[number-1], sitemap.MaxURLs+1)
+	if err != nil {
+		return time.Time{}, nil, apperror.NewDBQueryError(err.Error())
+	}
+	if number < len(afterIDs) {
+		entries = slices.DeleteFunc(entries, func(entry repositories.SitemapEntry) bool {
+			return entry.ID > afterIDs[number]
+		})
+	}
+	if len(entries) == 0 || len(entries) > sitemap.MaxURLs {
+		return time.Time{}, nil, nil
+	}
+
+	lastMod, body, err := service.encode(section, entries)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	if service.ttl > 0 {
+		key := constants.SITEMAP + section
+		_, err := service.client.TxPipelined(service.ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(service.ctx, key, strconv.Itoa(number), body, sitemapLastModField(number), lastMod.Format(time.RFC3339Nano))
+			pipe.Expire(service.ctx, key, service.ttl)
+			return nil
+		})
+		if err != nil {
+			logger.Warnf("Failed to cache sitemap %s-%d: %v", section, number, err)
+		}
+	}
+	return lastMod, body, nil
+}
+
+// encode encodes the URLs of a file, its lastmod is the latest UpdatedAt of its URLs
+func (service *SitemapService) encode(section string, entries []repositories.SitemapEntry) (time.Time, []byte, error) {
+	urls := make([]sitemap.URL, len(entries))
+	var lastMod time.Time
+	for i, entry := range entries {
+		urls[i] = sitemap.URL{Loc: service.entryURL(section, entry.Slug), LastMod: entry.UpdatedAt}
+		if entry.UpdatedAt.After(lastMod) {
+			lastMod = entry.UpdatedAt
+		}
+	}
+	body, err := sitemap.EncodeURLSet(urls)
+	if err != nil {
+		return time.Time{}, nil, apperror.NewInternalError(err.Error())
+	}
+	return lastMod.UTC(), body, nil
+}
+
+// find retrieves the next batch of URLs of a section
+func (service *SitemapService) find(section string, afterID uint, limit int) ([]repositories.SitemapEntry, error) {
+	switch section {
+	case SitemapSectionPosts:
+		return service.repo.FindPostsAfter(afterID, limit)
+	case SitemapSectionPages:
+		return service.repo.FindPagesAfter(afterID, limit)
+	case SitemapSectionCategories:
+		return service.repo.FindCategoriesAfter(afterID, limit)
+	default:
+		return service.repo.FindTagsAfter(afterID, limit)
+	}
+}
+
+// entryURL returns the URL of a record on the website, the same URLs are linked by the feeds
+func (service *SitemapService) entryURL(section, slug string) string {
+	segments := strings.Split(slug, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	path := strings.Join(segments, "/")
+
+	switch section {
+	case SitemapSectionPosts:
+		return service.siteURL + "/posts/" + path
+	case SitemapSectionCategories:
+		return service.siteURL + "/categories/" + path
+	case SitemapSectionTags:
+		return service.siteURL + "/tags/" + path
+	default:
+		return service.siteURL + "/" + path
+	}
+}
@@ -0,0 +1,184 @@
+package services_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/sitemap"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+)
+
+type SitemapServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockSitemapRepository
+	server  *miniredis.Miniredis
+	service *services.SitemapService
+}
+
+func (s *SitemapServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockSitemapRepository)
+	s.server = miniredis.RunT(s.T())
+	client := redis.NewClient(&redis.Options{Addr: s.server.Addr()})
+	s.T().Cleanup(func() { _ = client.Close() })
+	s.service = services.NewSitemapService(s.repo, client, "https://example.com/", time.Hour)
+}
+
+func (s *SitemapServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *SitemapServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+// mockEmptySections expects the sections other than the posts to be generated without URLs
+func (s *SitemapServiceTestSuite) mockEmptySections() {
+	s.repo.On("FindPagesAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{}, nil).Once()
+	s.repo.On("FindCategoriesAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{}, nil).Once()
+	s.repo.On("FindTagsAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{}, nil).Once()
+}
+
+func (s *SitemapServiceTestSuite) TestGetIndex() {
+	s.Run("Splits sections at the URL limit", func() {
+		updated := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		full := make([]repositories.SitemapEntry, sitemap.MaxURLs)
+		for i := range full {
+			full[i] = repositories.SitemapEntry{ID: uint(i + 1), Slug: fmt.Sprintf("post-%d", i+1), UpdatedAt: updated}
+		}
+		s.repo.On("FindPostsAfter", uint(0), sitemap.MaxURLs).Return(full, nil).Once()
+		s.repo.On("FindPostsAfter", uint(sitemap.MaxURLs), sitemap.MaxURLs).Return([]repositories.SitemapEntry{
+			{ID: 60000, Slug: "last", UpdatedAt: updated.Add(time.Hour)},
+		}, nil).Once()
+		s.mockEmptySections()
+
+		body, err := s.service.GetIndex()
+		s.Require().NoError(err)
+		s.Contains(string(body), "<sitemap><loc>https://example.com/sitemaps/posts-1.xml</loc><lastmod>2030-01-02T03:04:05Z</lastmod></sitemap>")
+		s.Contains(string(body), "<sitemap><loc>https://example.com/sitemaps/posts-2.xml</loc><lastmod>2030-01-02T04:04:05Z</lastmod></sitemap>")
+		s.NotContains(string(body), "pages-1.xml")
+
+		// The files are served from the cache without querying again
+		file, err := s.service.GetSitemap(services.SitemapSectionPosts, 2)
+		s.Require().NoError(err)
+		s.Contains(string(file), "<loc>https://example.com/posts/last</loc>")
+
+		_, err = s.service.GetSitemap(services.SitemapSectionPosts, 3)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Database error", func() {
+		s.server.FlushAll()
+		s.repo.On("FindPostsAfter", uint(0), sitemap.MaxURLs).Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.GetIndex()
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *SitemapServiceTestSuite) TestGetSitemap() {
+	updated := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	s.Run("Generates a missing section", func() {
+		s.repo.On("FindPagesAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{
+			{ID: 1, Slug: "about/our team", UpdatedAt: updated},
+		}, nil).Once()
+
+		body, err := s.service.GetSitemap(services.SitemapSectionPages, 1)
+		s.Require().NoError(err)
+		s.Contains(string(body), "<url><loc>https://example.com/about/our%20team</loc><lastmod>2030-01-02T03:04:05Z</lastmod></url>")
+		s.True(s.server.Exists("SITEMAP_pages"))
+	})
+
+	s.Run("Unknown section", func() {
+		_, err := s.service.GetSitemap("users", 1)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Changes regenerate the affected sections", func() {
+		s.repo.On("FindTagsAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{{ID: 1, Slug: "go", UpdatedAt: updated}}, nil).Once()
+		_, err := s.service.GetSitemap(services.SitemapSectionTags, 1)
+		s.Require().NoError(err)
+
+		s.service.InvalidateTable("post_tags", []uint{1})
+		s.False(s.server.Exists("SITEMAP_tags"))
+		s.True(s.server.Exists("SITEMAP_pages"), "other sections are kept")
+
+		s.repo.On("FindTagsAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{{ID: 2, Slug: "rust", UpdatedAt: updated}}, nil).Once()
+		body, err := s.service.GetSitemap(services.SitemapSectionTags, 1)
+		s.Require().NoError(err)
+		s.Contains(string(body), "https://example.com/tags/rust")
+	})
+
+	s.Run("Changes regenerate the files holding the changed rows", func() {
+		s.server.FlushAll()
+		full := make([]repositories.SitemapEntry, sitemap.MaxURLs)
+		for i := range full {
+			full[i] = repositories.SitemapEntry{ID: uint(i + 1), Slug: fmt.Sprintf("post-%d", i+1), UpdatedAt: updated}
+		}
+		s.repo.On("FindPostsAfter", uint(0), sitemap.MaxURLs).Return(full, nil).Once()
+		s.repo.On("FindPostsAfter", uint(sitemap.MaxURLs), sitemap.MaxURLs).Return([]repositories.SitemapEntry{
+			{ID: 60000, Slug: "last", UpdatedAt: updated},
+		}, nil).Once()
+		_, err := s.service.GetSitemap(services.SitemapSectionPosts, 1)
+		s.Require().NoError(err)
+
+		s.service.InvalidateTable("posts", []uint{60000, 60001})
+		s.True(s.server.Exists("SITEMAP_posts"))
+		s.NotEmpty(s.server.HGet("SITEMAP_posts", "1"), "the first file is kept")
+		s.Empty(s.server.HGet("SITEMAP_posts", "2"))
+
+		// Only the range of the second file is queried again
+		s.repo.On("FindPostsAfter", uint(sitemap.MaxURLs), sitemap.MaxURLs+1).Return([]repositories.SitemapEntry{
+			{ID: 60000, Slug: "renamed", UpdatedAt: updated.Add(time.Hour)},
+			{ID: 60001, Slug: "new", UpdatedAt: updated.Add(2 * time.Hour)},
+		}, nil).Once()
+		s.mockEmptySections()
+
+		body, err := s.service.GetIndex()
+		s.Require().NoError(err)
+		s.Contains(string(body), "<sitemap><loc>https://example.com/sitemaps/posts-1.xml</loc><lastmod>2030-01-02T03:04:05Z</lastmod></sitemap>")
+		s.Contains(string(body), "<sitemap><loc>https://example.com/sitemaps/posts-2.xml</loc><lastmod>2030-01-02T05:04:05Z</lastmod></sitemap>")
+
+		file, err := s.service.GetSitemap(services.SitemapSectionPosts, 2)
+		s.Require().NoError(err)
+		s.Contains(string(file), "https://example.com/posts/renamed")
+		s.Contains(string(file), "https://example.com/posts/new")
+	})
+
+	s.Run("An emptied file regenerates the whole section", func() {
+		s.server.FlushAll()
+		s.repo.On("FindPagesAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{{ID: 1, Slug: "about", UpdatedAt: updated}}, nil).Once()
+		_, err := s.service.GetSitemap(services.SitemapSectionPages, 1)
+		s.Require().NoError(err)
+
+		s.service.InvalidateTable("pages", []uint{1})
+		s.repo.On("FindPagesAfter", uint(0), sitemap.MaxURLs+1).Return([]repositories.SitemapEntry{}, nil).Once()
+		s.repo.On("FindPagesAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{}, nil).Once()
+
+		_, err = s.service.GetSitemap(services.SitemapSectionPages, 1)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+
+	s.Run("Redis unavailable", func() {
+		s.server.Close()
+		s.repo.On("FindCategoriesAfter", uint(0), sitemap.MaxURLs).Return([]repositories.SitemapEntry{{ID: 1, Slug: "news", UpdatedAt: updated}}, nil).Once()
+
+		body, err := s.service.GetSitemap(services.SitemapSectionCategories, 1)
+		s.Require().NoError(err)
+		s.Contains(string(body), "https://example.com/categories/news")
+	})
+}
+
+func TestSitemapServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SitemapServiceTestSuite))
+}
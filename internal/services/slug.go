@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+const (
+	maxSlugAttempts = 100 // Numeric suffixes tried when a generated slug is already taken
+	maxSlugBase     = 240 // Leaves room for a suffix in the 255 characters of the slug column
+)
+
+// resolveSlug returns the slug under which a record is saved
+//
+// The function:
+//  1. Normalizes a slug chosen by the user and rejects it when another record uses it
+//  2. Generates a slug from the title otherwise, adding a numeric suffix until it is unique
+//
+// Parameters:
+//   - slug: The slug chosen by the user, empty to generate one
+//   - title: The title the slug is generated from
+//   - fallback: The slug used when the title has no letters or digits, e.g. "post"
+//   - exists: Reports whether another record already uses a slug
+//
+// Returns:
+//   - string: The normalized and unique slug
+//   - error: ValidationError on the slug field if the chosen slug is invalid or taken, DBQuery error otherwise
+func resolveSlug(slug, title, fallback string, exists func(slug string) (bool, error)) (string, error) {
+	if slug == "" {
+		return uniqueSlug(title, fallback, exists)
+	}
+
+	slug = utils.Slugify(slug)
+	if slug == "" {
+		return "", apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "slug", Message: "slug must contain letters or digits"},
+		})
+	}
+	taken, err := exists(slug)
+	if err != nil {
+		return "", apperror.NewDBQueryError(err.Error())
+	}
+	if taken {
+		return "", apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "slug", Message: "slug is already taken"},
+		})
+	}
+	return slug, nil
+}
+
+// uniqueSlug generates a slug from a title which is not used by another record, e.g. "hello-world-2"
+func uniqueSlug(title, fallback string, exists func(slug string) (bool, error)) (string, error) {
+	base := utils.Slugify(title)
+	if base == "" {
+		base = fallback
+	}
+	if len(base) > maxSlugBase {
+		base = strings.TrimRight(base[:maxSlugBase], "-")
+	}
+
+	slug := base
+	for attempt := 2; attempt <= maxSlugAttempts; attempt++ {
+		taken, err := exists(slug)
+		if err != nil {
+			return "", apperror.NewDBQueryError(err.Error())
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, attempt)
+	}
+
+	// Fall back to a random suffix for titles used over and over again
+	return base + "-" + strings.ToLower(utils.GenerateRandomString(8)), nil
+}
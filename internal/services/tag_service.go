@@ -0,0 +1,159 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+)
+
+type ITagService interface {
+	PaginateTags(page, limit int, search string) (*utils.Pagination, error)
+	GetTag(id uint) (*models.Tag, error)
+	GetTagBySlug(slug string) (*models.Tag, error)
+	ResolveTags(names []string) ([]models.Tag, error)
+	CreateTag(tag *models.Tag) error
+	UpdateTag(tag *models.Tag) error
+	DeleteTag(id uint) error
+}
+
+type TagService struct {
+	repo repositories.ITagRepository
+}
+
+// NewTagService creates a new instance of TagService
+// Parameters:
+//   - repo: Repository of tags
+//
+// Returns:
+//   - *TagService: New TagService instance initialized with the provided repository
+func NewTagService(repo repositories.ITagRepository) *TagService {
+	return &TagService{
+		repo: repo,
+	}
+}
+
+// PaginateTags retrieves a page of tags ordered by name
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of tags per page
+//   - search: Only tags whose name contains this text, empty for every tag
+//
+// Returns:
+//   - *utils.Pagination: The page of tags
+//   - error: DBQuery error if the tags cannot be loaded
+func (service *TagService) PaginateTags(page, limit int, search string) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginateTags(page, limit, search)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// GetTag retrieves a tag by its ID
+func (service *TagService) GetTag(id uint) (*models.Tag, error) {
+	tag, err := service.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return tag, nil
+}
+
+// GetTagBySlug retrieves a tag by its slug
+func (service *TagService) GetTagBySlug(slug string) (*models.Tag, error) {
+	tag, err := service.repo.FindBySlug(slug)
+	if err != nil {
+		return nil, apperror.NewNotFoundError(err.Error())
+	}
+	return tag, nil
+}
+
+// ResolveTags turns the tag names entered on a post into stored tags, creating the tags that do not exist yet
+// Names are matched on their slug, so "Go Lang" and "go-lang" are the same tag
+// Parameters:
+//   - names: The tag names, duplicates are ignored
+//
+// Returns:
+//   - []models.Tag: The stored tags
+//   - error: ValidationError on the tags field if a name has no letters or digits, DBInsert error otherwise
+func (service *TagService) ResolveTags(names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := utils.Slugify(name)
+		if slug == "" {
+			return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+				{Field: "tags", Message: "tag names must contain letters or digits"},
+			})
+		}
+		if len(slug) > maxSlugBase {
+			slug = strings.TrimRight(slug[:maxSlugBase], "-")
+		}
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, models.Tag{Name: name, Slug: slug})
+	}
+
+	stored, err := service.repo.FindOrCreate(tags)
+	if err != nil {
+		return nil, apperror.NewDBInsertError(err.Error())
+	}
+	return stored, nil
+}
+
+// CreateTag stores a new tag
+// Parameters:
+//   - tag: The tag to create, its slug is generated from the name when empty
+//
+// Returns:
+//   - error: ValidationError if the slug is invalid or taken, DBInsert error otherwise
+func (service *TagService) CreateTag(tag *models.Tag) error {
+	if err := service.prepare(tag); err != nil {
+		return err
+	}
+	if err := service.repo.Create(tag); err != nil {
+		return apperror.NewDBInsertError(err.Error())
+	}
+	return nil
+}
+
+// UpdateTag saves an existing tag, a renamed tag stays on its posts
+// Parameters:
+//   - tag: The tag to save, its slug is generated from the name when empty
+//
+// Returns:
+//   - error: ValidationError if the slug is invalid or taken, DBUpdate error otherwise
+func (service *TagService) UpdateTag(tag *models.Tag) error {
+	if err := service.prepare(tag); err != nil {
+		return err
+	}
+	if err := service.repo.Update(tag); err != nil {
+		return apperror.NewDBUpdateError(err.Error())
+	}
+	return nil
+}
+
+// DeleteTag removes a tag from every post and deletes it
+func (service *TagService) DeleteTag(id uint) error {
+	if err := service.repo.Delete(id); err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	return nil
+}
+
+// prepare resolves the slug of a tag before it is saved
+func (service *TagService) prepare(tag *models.Tag) error {
+	slug, err := resolveSlug(tag.Slug, tag.Name, "tag", func(slug string) (bool, error) {
+		return service.repo.SlugExists(slug, tag.ID)
+	})
+	if err != nil {
+		return err
+	}
+	tag.Slug = slug
+	return nil
+}
@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type TagServiceTestSuite struct {
+	suite.Suite
+	repo    *mocks.MockTagRepository
+	service *services.TagService
+}
+
+func (s *TagServiceTestSuite) SetupTest() {
+	s.repo = new(mocks.MockTagRepository)
+	s.service = services.NewTagService(s.repo)
+}
+
+func (s *TagServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *TagServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *TagServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+func (s *TagServiceTestSuite) TestResolveTags() {
+	s.Run("Success", func() {
+		stored := []models.Tag{{ID: 1, Name: "Go Lang", Slug: "go-lang"}, {ID: 2, Name: "Tin tức", Slug: "tin-tuc"}}
+		// Names with the same slug are the same tag
+		s.repo.On("FindOrCreate", []models.Tag{{Name: "Go Lang", Slug: "go-lang"}, {Name: "Tin tức", Slug: "tin-tuc"}}).Return(stored, nil).Once()
+
+		tags, err := s.service.ResolveTags([]string{" Go Lang ", "Tin tức", "go-lang"})
+		s.Require().NoError(err)
+		s.Equal(stored, tags)
+	})
+
+	s.Run("Name without letters", func() {
+		_, err := s.service.ResolveTags([]string{"Go", "!!!"})
+		s.assertFieldError(err, "tags")
+	})
+
+	s.Run("Database error", func() {
+		s.repo.On("FindOrCreate", []models.Tag{{Name: "Go", Slug: "go"}}).Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.ResolveTags([]string{"Go"})
+		s.assertCode(err, apperror.ErrDBInsert)
+	})
+}
+
+func (s *TagServiceTestSuite) TestCreateTag() {
+	s.Run("Success generated slug", func() {
+		tag := &models.Tag{Name: "Go Lang"}
+		s.repo.On("SlugExists", "go-lang", uint(0)).Return(false, nil).Once()
+		s.repo.On("Create", tag).Return(nil).Once()
+
+		s.Require().NoError(s.service.CreateTag(tag))
+		s.Equal("go-lang", tag.Slug)
+	})
+
+	s.Run("Slug taken", func() {
+		s.repo.On("SlugExists", "go", uint(0)).Return(true, nil).Once()
+
+		err := s.service.CreateTag(&models.Tag{Name: "Go", Slug: "go"})
+		s.assertFieldError(err, "slug")
+	})
+}
+
+func (s *TagServiceTestSuite) TestUpdateAndDeleteTag() {
+	tag := &models.Tag{ID: 3, Name: "Go", Slug: "go"}
+	s.repo.On("SlugExists", "go", uint(3)).Return(false, nil).Once()
+	s.repo.On("Update", tag).Return(nil).Once()
+	s.NoError(s.service.UpdateTag(tag))
+
+	s.repo.On("Delete", uint(3)).Return(errors.New("db error")).Once()
+	s.assertCode(s.service.DeleteTag(3), apperror.ErrDBDelete)
+}
+
+func (s *TagServiceTestSuite) TestGetTags() {
+	s.repo.On("PaginateTags", 1, 10, "go").Return(&utils.Pagination{Page: 1}, nil).Once()
+	_, err := s.service.PaginateTags(1, 10, "go")
+	s.NoError(err)
+
+	s.repo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err = s.service.GetTag(9)
+	s.assertCode(err, apperror.ErrNotFound)
+
+	s.repo.On("FindBySlug", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err = s.service.GetTagBySlug("missing")
+	s.assertCode(err, apperror.ErrNotFound)
+}
+
+func TestTagServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TagServiceTestSuite))
+}
@@ -0,0 +1,414 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+	"gorm.io/gorm"
+)
+
+type ITranslationService interface {
+	GetTranslations(contentType string, contentID uint) ([]models.Translation, error)
+	SaveTranslation(translation *models.Translation) (bool, error)
+	DeleteTranslation(contentType string, contentID uint, locale string) error
+	PaginateMissing(contentType, locale string, page, limit int) (*utils.Pagination, error)
+	ResolvePostSlug(slug, locale string) (string, error)
+	LocalizePosts(posts []models.Post, locale string) ([]string, error)
+	ResolvePagePath(path, locale string) (string, error)
+	LocalizePage(page *models.Page, locale string) (string, error)
+}
+
+// TranslationService stores the translations of posts and pages and applies them to published content
+// Missing translations fall back along the chain of the requested locale, down to the content of the record itself
+type TranslationService struct {
+	repo     repositories.ITranslationRepository
+	postRepo repositories.IPostRepository
+	pageRepo repositories.IPageRepository
+	locales  *i18n.Locales
+}
+
+// NewTranslationService creates a new instance of TranslationService
+// Parameters:
+//   - repo: Repository of translations
+//   - postRepo: Repository of the translated posts
+//   - pageRepo: Repository of the translated pages
+//   - locales: The supported locales and their fallbacks
+//
+// Returns:
+//   - *TranslationService: New TranslationService instance initialized with the provided dependencies
+func NewTranslationService(
+	repo repositories.ITranslationRepository,
+	postRepo repositories.IPostRepository,
+	pageRepo repositories.IPageRepository,
+	locales *i18n.Locales,
+) *TranslationService {
+	return &TranslationService{
+		repo:     repo,
+		postRepo: postRepo,
+		pageRepo: pageRepo,
+		locales:  locales,
+	}
+}
+
+// GetTranslations retrieves every translation of a post or a page
+// Returns NotFound if the post or the page does not exist
+func (service *TranslationService) GetTranslations(contentType string, contentID uint) ([]models.Translation, error) {
+	if err := service.checkContent(contentType, contentID); err != nil {
+		return nil, err
+	}
+
+	translations, err := service.repo.FindByContent(contentType, contentID)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return translations, nil
+}
+
+// SaveTranslation creates or replaces the translation of a post or a page in a locale
+// Parameters:
+//   - translation: The translation with its content type, content ID and locale, its slug is generated from the title when empty
+//
+// Returns:
+//   - bool: true if the translation was created, false if an existing one was replaced
+//   - error: ValidationError if the locale is not a supported translation locale or the slug is invalid
+//     or taken in the locale, NotFound if the content does not exist, DBInsert or DBUpdate error otherwise
+//
+// The function:
+//  1. Normalizes the locale, content in the default locale is edited on the record itself
+//  2. Keeps the ID of the existing translation in the locale so it is replaced
+//  3. Resolves a slug unique among the translations of the same type in the locale
+func (service *TranslationService) SaveTranslation(translation *models.Translation) (bool, error) {
+	locale, err := service.translationLocale(translation.Locale)
+	if err != nil {
+		return false, err
+	}
+	translation.Locale = locale
+
+	if err := service.checkContent(translation.ContentType, translation.ContentID); err != nil {
+		return false, err
+	}
+	if translation.ContentType != models.TranslationTypePost {
+		translation.Excerpt = nil
+	}
+
+	existing, err := service.repo.GetByLocale(translation.ContentType, translation.ContentID, locale)
+	switch {
+	case err == nil:
+		translation.ID = existing.ID
+		translation.CreatedAt = existing.CreatedAt
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		translation.ID = 0
+	default:
+		return false, apperror.NewDBQueryError(err.Error())
+	}
+
+	slug, err := resolveSlug(translation.Slug, translation.Title, translation.ContentType, func(slug string) (bool, error) {
+		return service.repo.SlugExists(translation.ContentType, locale, slug, translation.ID)
+	})
+	if err != nil {
+		return false, err
+	}
+	translation.Slug = slug
+
+	created := translation.ID == 0
+	if err := service.repo.Save(translation); err != nil {
+		if created {
+			return false, apperror.NewDBInsertError(err.Error())
+		}
+		return false, apperror.NewDBUpdateError(err.Error())
+	}
+	return created, nil
+}
+
+// DeleteTranslation removes the translation of a post or a page in a locale
+// Returns NotFound if the content has no translation in this locale
+func (service *TranslationService) DeleteTranslation(contentType string, contentID uint, locale string) error {
+	if normalized, ok := service.locales.Normalize(locale); ok {
+		locale = normalized
+	}
+
+	deleted, err := service.repo.Delete(contentType, contentID, locale)
+	if err != nil {
+		return apperror.NewDBDeleteError(err.Error())
+	}
+	if deleted == 0 {
+		return apperror.NewNotFoundError("Translation not found")
+	}
+	return nil
+}
+
+// PaginateMissing retrieves a page of the posts or pages without a translation in a locale
+// Parameters:
+//   - contentType: models.TranslationTypePost or models.TranslationTypePage
+//   - locale: A supported locale other than the default one
+//   - page: The page number to retrieve
+//   - limit: The number of items per page
+//
+// Returns:
+//   - *utils.Pagination: The page of []repositories.MissingTranslation
+//   - error: ValidationError if the type or the locale is invalid, DBQuery error otherwise
+func (service *TranslationService) PaginateMissing(contentType, locale string, page, limit int) (*utils.Pagination, error) {
+	if !slices.Contains(models.TranslationTypes, contentType) {
+		return nil, apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "type", Message: fmt.Sprintf("type must be one of %v", models.TranslationTypes)},
+		})
+	}
+	locale, err := service.translationLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination, err := service.repo.PaginateMissing(contentType, locale, page, limit)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	return pagination, nil
+}
+
+// ResolvePostSlug finds the slug of the post a translated slug belongs to
+// Parameters:
+//   - slug: The slug requested on the public API, in the requested locale or in the default one
+//   - locale: The requested locale
+//
+// Returns:
+//   - string: The slug of the post, the requested slug when no translation in the fallback chain uses it
+//   - error: DBQuery error if the translations cannot be loaded
+func (service *TranslationService) ResolvePostSlug(slug, locale string) (string, error) {
+	translated := service.translatedChain(locale)
+	if len(translated) == 0 {
+		return slug, nil
+	}
+
+	translations, err := service.repo.FindBySlug(models.TranslationTypePost, slug, translated)
+	if err != nil {
+		return "", apperror.NewDBQueryError(err.Error())
+	}
+	translation := pickTranslation(translations, translated)
+	if translation == nil {
+		return slug, nil
+	}
+
+	post, err := service.postRepo.GetByID(translation.ContentID)
+	if err != nil {
+		// The post was deleted, its translations are kept with its slug
+		return slug, nil
+	}
+	return post.Slug, nil
+}
+
+// LocalizePosts replaces the title, slug, excerpt and body of posts by their translation in a locale
+// Each post uses the first translation found along the fallback chain of the locale, or keeps its own content
+// Parameters:
+//   - posts: The posts to localize, changed in place
+//   - locale: The requested locale
+//
+// Returns:
+//   - []string: The locale of the content of each post, in the order of the posts
+//   - error: DBQuery error if the translations cannot be loaded
+func (service *TranslationService) LocalizePosts(posts []models.Post, locale string) ([]string, error) {
+	result := make([]string, len(posts))
+	for i := range result {
+		result[i] = service.locales.Default()
+	}
+	translated := service.translatedChain(locale)
+	if len(translated) == 0 || len(posts) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uint, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	translations, err := service.repo.FindForContents(models.TranslationTypePost, ids, translated)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	byPost := make(map[uint][]models.Translation)
+	for _, translation := range translations {
+		byPost[translation.ContentID] = append(byPost[translation.ContentID], translation)
+	}
+	for i := range posts {
+		translation := pickTranslation(byPost[posts[i].ID], translated)
+		if translation == nil {
+			continue
+		}
+		posts[i].Title = translation.Title
+		posts[i].Slug = translation.Slug
+		posts[i].Excerpt = translation.Excerpt
+		posts[i].Body = translation.Body
+		result[i] = translation.Locale
+	}
+	return result, nil
+}
+
+// ResolvePagePath finds the path of the page a translated path belongs to, e.g. "gioi-thieu/nhom" gives "about/team"
+// Each segment of a translated path is the slug of the page in the first locale of the fallback chain it is translated in
+// Parameters:
+//   - path: The path requested on the public API
+//   - locale: The requested locale
+//
+// Returns:
+//   - string: The path of the page, the requested path when no page has this translated path
+//   - error: DBQuery error if the pages or their translations cannot be loaded
+func (service *TranslationService) ResolvePagePath(path, locale string) (string, error) {
+	translated := service.translatedChain(locale)
+	if len(translated) == 0 {
+		return path, nil
+	}
+
+	paths, err := service.localizedPagePaths(translated)
+	if err != nil {
+		return "", err
+	}
+	for id, localized := range paths.localized {
+		if localized == path {
+			return paths.pages[id].Path, nil
+		}
+	}
+	return path, nil
+}
+
+// LocalizePage replaces the title, slug, body and path of a page by their translation in a locale
+// Parameters:
+//   - page: The page to localize, changed in place
+//   - locale: The requested locale
+//
+// Returns:
+//   - string: The locale of the title and body of the page
+//   - error: DBQuery error if the pages or their translations cannot be loaded
+func (service *TranslationService) LocalizePage(page *models.Page, locale string) (string, error) {
+	translated := service.translatedChain(locale)
+	if len(translated) == 0 {
+		return service.locales.Default(), nil
+	}
+
+	paths, err := service.localizedPagePaths(translated)
+	if err != nil {
+		return "", err
+	}
+	if localized, ok := paths.localized[page.ID]; ok {
+		page.Path = localized
+	}
+
+	translations, err := service.repo.FindForContents(models.TranslationTypePage, []uint{page.ID}, translated)
+	if err != nil {
+		return "", apperror.NewDBQueryError(err.Error())
+	}
+	translation := pickTranslation(translations, translated)
+	if translation == nil {
+		return service.locales.Default(), nil
+	}
+	page.Title = translation.Title
+	page.Slug = translation.Slug
+	page.Body = translation.Body
+	return translation.Locale, nil
+}
+
+// pagePaths holds every page with its path in a locale
+type pagePaths struct {
+	pages     map[uint]models.Page // Pages with their stored path and their slug in the locale
+	localized map[uint]string      // Paths in the locale keyed by page ID
+}
+
+// localizedPagePaths builds the path of every page from the slugs of the page and its ancestors in the given locales
+func (service *TranslationService) localizedPagePaths(translated []string) (*pagePaths, error) {
+	pages, err := service.pageRepo.GetAll()
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+	translations, err := service.repo.FindByType(models.TranslationTypePage, translated)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	byPage := make(map[uint][]models.Translation)
+	for _, translation := range translations {
+		byPage[translation.ContentID] = append(byPage[translation.ContentID], translation)
+	}
+	result := &pagePaths{pages: make(map[uint]models.Page, len(pages)), localized: make(map[uint]string, len(pages))}
+	for _, page := range pages {
+		if translation := pickTranslation(byPage[page.ID], translated); translation != nil {
+			page.Slug = translation.Slug
+		}
+		result.pages[page.ID] = page
+	}
+
+	var build func(id uint, depth int) string
+	build = func(id uint, depth int) string {
+		if path, ok := result.localized[id]; ok {
+			return path
+		}
+		page := result.pages[id]
+		path := page.Slug
+		// The depth guards against a corrupted tree with a cycle
+		if page.ParentID != nil && depth < len(pages) {
+			if _, ok := result.pages[*page.ParentID]; ok {
+				path = build(*page.ParentID, depth+1) + "/" + path
+			}
+		}
+		result.localized[id] = path
+		return path
+	}
+	for id := range result.pages {
+		build(id, 0)
+	}
+	return result, nil
+}
+
+// translatedChain returns the fallback chain of a locale without the default locale, whose content is on the records
+func (service *TranslationService) translatedChain(locale string) []string {
+	chain := service.locales.Chain(locale)
+	return slices.DeleteFunc(chain, func(l string) bool { return l == service.locales.Default() })
+}
+
+// translationLocale normalizes the locale of a translation, which must be supported and not the default locale
+func (service *TranslationService) translationLocale(locale string) (string, error) {
+	normalized, ok := service.locales.Normalize(locale)
+	if !ok || normalized == service.locales.Default() {
+		return "", apperror.NewValidationError("Validation failed", []apperror.FieldError{
+			{Field: "locale", Message: fmt.Sprintf("locale must be one of %v", service.translationLocales())},
+		})
+	}
+	return normalized, nil
+}
+
+// translationLocales lists the locales translations can be written in
+func (service *TranslationService) translationLocales() []string {
+	return slices.DeleteFunc(service.locales.Supported(), func(l string) bool { return l == service.locales.Default() })
+}
+
+// checkContent returns NotFound if the translated post or page does not exist
+func (service *TranslationService) checkContent(contentType string, contentID uint) error {
+	var err error
+	switch contentType {
+	case models.TranslationTypePost:
+		_, err = service.postRepo.GetByID(contentID)
+	case models.TranslationTypePage:
+		_, err = service.pageRepo.GetByID(contentID)
+	default:
+		return apperror.NewBadRequestError("Unknown content type " + contentType)
+	}
+	if err != nil {
+		return apperror.NewNotFoundError(err.Error())
+	}
+	return nil
+}
+
+// pickTranslation returns the translation in the first locale of the chain, nil if none of them is translated
+func pickTranslation(translations []models.Translation, chain []string) *models.Translation {
+	for _, locale := range chain {
+		for i := range translations {
+			if translations[i].Locale == locale {
+				return &translations[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
+	"gorm.io/gorm"
+)
+
+type TranslationServiceTestSuite struct {
+	suite.Suite
+	repo     *mocks.MockTranslationRepository
+	postRepo *mocks.MockPostRepository
+	pageRepo *mocks.MockPageRepository
+	service  *services.TranslationService
+}
+
+func (s *TranslationServiceTestSuite) SetupTest() {
+	locales, err := i18n.NewLocales("en", []string{"vi", "pt", "pt-BR"}, map[string]string{"pt-BR": "pt"})
+	s.Require().NoError(err)
+
+	s.repo = new(mocks.MockTranslationRepository)
+	s.postRepo = new(mocks.MockPostRepository)
+	s.pageRepo = new(mocks.MockPageRepository)
+	s.service = services.NewTranslationService(s.repo, s.postRepo, s.pageRepo, locales)
+}
+
+func (s *TranslationServiceTestSuite) TearDownTest() {
+	s.repo.AssertExpectations(s.T())
+	s.postRepo.AssertExpectations(s.T())
+	s.pageRepo.AssertExpectations(s.T())
+}
+
+func (s *TranslationServiceTestSuite) assertCode(err error, code int) {
+	appErr, ok := apperror.ToAppError(err)
+	s.Require().True(ok, "expected an AppError, got %v", err)
+	s.Equal(code, appErr.Code)
+}
+
+func (s *TranslationServiceTestSuite) assertFieldError(err error, field string) {
+	var validationErr *apperror.ValidationError
+	s.Require().True(errors.As(err, &validationErr), "expected a validation error, got %v", err)
+	s.Require().Len(validationErr.Fields, 1)
+	s.Equal(field, validationErr.Fields[0].Field)
+}
+
+func (s *TranslationServiceTestSuite) TestGetTranslations() {
+	s.Run("Success", func() {
+		s.postRepo.On("GetByID", uint(1)).Return(&models.Post{ID: 1}, nil).Once()
+		s.repo.On("FindByContent", models.TranslationTypePost, uint(1)).Return([]models.Translation{{Locale: "vi"}}, nil).Once()
+
+		translations, err := s.service.GetTranslations(models.TranslationTypePost, 1)
+		s.Require().NoError(err)
+		s.Len(translations, 1)
+	})
+
+	s.Run("Page not found", func() {
+		s.pageRepo.On("GetByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		_, err := s.service.GetTranslations(models.TranslationTypePage, 9)
+		s.assertCode(err, apperror.ErrNotFound)
+	})
+}
+
+func (s *TranslationServiceTestSuite) TestSaveTranslation() {
+	s.Run("Success creates with generated slug", func() {
+		translation := &models.Translation{ContentType: models.TranslationTypePost, ContentID: 1, Locale: "PT_br", Title: "Olá Mundo", Body: "Corpo"}
+		s.postRepo.On("GetByID", uint(1)).Return(&models.Post{ID: 1}, nil).Once()
+		s.repo.On("GetByLocale", models.TranslationTypePost, uint(1), "pt-BR").Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("SlugExists", models.TranslationTypePost, "pt-BR", "ola-mundo", uint(0)).Return(true, nil).Once()
+		s.repo.On("SlugExists", models.TranslationTypePost, "pt-BR", "ola-mundo-2", uint(0)).Return(false, nil).Once()
+		s.repo.On("Save", translation).Return(nil).Once()
+
+		created, err := s.service.SaveTranslation(translation)
+		s.Require().NoError(err)
+		s.True(created)
+		s.Equal("pt-BR", translation.Locale)
+		s.Equal("ola-mundo-2", translation.Slug)
+	})
+
+	s.Run("Success replaces existing and drops page excerpt", func() {
+		excerpt := "Tóm tắt"
+		translation := &models.Translation{ContentType: models.TranslationTypePage, ContentID: 2, Locale: "vi", Title: "Nhóm", Slug: "nhom", Excerpt: &excerpt, Body: "Nội dung"}
+		s.pageRepo.On("GetByID", uint(2)).Return(&models.Page{ID: 2}, nil).Once()
+		s.repo.On("GetByLocale", models.TranslationTypePage, uint(2), "vi").Return(&models.Translation{ID: 7}, nil).Once()
+		s.repo.On("SlugExists", models.TranslationTypePage, "vi", "nhom", uint(7)).Return(false, nil).Once()
+		s.repo.On("Save", translation).Return(nil).Once()
+
+		created, err := s.service.SaveTranslation(translation)
+		s.Require().NoError(err)
+		s.False(created)
+		s.Equal(uint(7), translation.ID)
+		s.Nil(translation.Excerpt)
+	})
+
+	s.Run("Default locale is rejected", func() {
+		_, err := s.service.SaveTranslation(&models.Translation{ContentType: models.TranslationTypePost, ContentID: 1, Locale: "en"})
+		s.assertFieldError(err, "locale")
+	})
+
+	s.Run("Unsupported locale is rejected", func() {
+		_, err := s.service.SaveTranslation(&models.Translation{ContentType: models.TranslationTypePost, ContentID: 1, Locale: "de"})
+		s.assertFieldError(err, "locale")
+	})
+
+	s.Run("Slug taken in the locale", func() {
+		translation := &models.Translation{ContentType: models.TranslationTypePost, ContentID: 1, Locale: "vi", Title: "Xin chào", Slug: "xin-chao"}
+		s.postRepo.On("GetByID", uint(1)).Return(&models.Post{ID: 1}, nil).Once()
+		s.repo.On("GetByLocale", models.TranslationTypePost, uint(1), "vi").Return(nil, gorm.ErrRecordNotFound).Once()
+		s.repo.On("SlugExists", models.TranslationTypePost, "vi", "xin-chao", uint(0)).Return(true, nil).Once()
+
+		_, err := s.service.SaveTranslation(translation)
+		s.assertFieldError(err, "slug")
+	})
+
+	s.Run("Unknown content type", func() {
+		_, err := s.service.SaveTranslation(&models.Translation{ContentType: "video", ContentID: 1, Locale: "vi"})
+		s.assertCode(err, apperror.ErrBadRequest)
+	})
+}
+
+func (s *TranslationServiceTestSuite) TestDeleteTranslation() {
+	s.Run("Success", func() {
+		s.repo.On("Delete", models.TranslationTypePost, uint(1), "pt-BR").Return(int64(1), nil).Once()
+		s.NoError(s.service.DeleteTranslation(models.TranslationTypePost, 1, "pt-br"))
+	})
+
+	s.Run("Not found", func() {
+		s.repo.On("Delete", models.TranslationTypePost, uint(1), "vi").Return(int64(0), nil).Once()
+		s.assertCode(s.service.DeleteTranslation(models.TranslationTypePost, 1, "vi"), apperror.ErrNotFound)
+	})
+
+	s.Run("Database error", func() {
+		s.repo.On("Delete", models.TranslationTypePost, uint(2), "vi").Return(int64(0), errors.New("db error")).Once()
+		s.assertCode(s.service.DeleteTranslation(models.TranslationTypePost, 2, "vi"), apperror.ErrDBDelete)
+	})
+}
+
+func (s *TranslationServiceTestSuite) TestPaginateMissing() {
+	s.Run("Success", func() {
+		s.repo.On("PaginateMissing", models.TranslationTypePage, "vi", 1, 10).Return(&utils.Pagination{Page: 1, Limit: 10}, nil).Once()
+
+		pagination, err := s.service.PaginateMissing(models.TranslationTypePage, "VI", 1, 10)
+		s.Require().NoError(err)
+		s.Equal(1, pagination.Page)
+	})
+
+	s.Run("Invalid type", func() {
+		_, err := s.service.PaginateMissing("video", "vi", 1, 10)
+		s.assertFieldError(err, "type")
+	})
+
+	s.Run("Missing locale", func() {
+		_, err := s.service.PaginateMissing(models.TranslationTypePost, "", 1, 10)
+		s.assertFieldError(err, "locale")
+	})
+}
+
+func (s *TranslationServiceTestSuite) TestResolvePostSlug() {
+	s.Run("Translated slug", func() {
+		s.repo.On("FindBySlug", models.TranslationTypePost, "ola", []string{"pt-BR", "pt"}).
+			Return([]models.Translation{{ContentID: 3, Locale: "pt", Slug: "ola"}}, nil).Once()
+		s.postRepo.On("GetByID", uint(3)).Return(&models.Post{ID: 3, Slug: "hello"}, nil).Once()
+
+		slug, err := s.service.ResolvePostSlug("ola", "pt-BR")
+		s.Require().NoError(err)
+		s.Equal("hello", slug)
+	})
+
+	s.Run("Slug of the default locale", func() {
+		s.repo.On("FindBySlug", models.TranslationTypePost, "hello", []string{"vi"}).Return([]models.Translation{}, nil).Once()
+
+		slug, err := s.service.ResolvePostSlug("hello", "vi")
+		s.Require().NoError(err)
+		s.Equal("hello", slug)
+	})
+
+	s.Run("Default locale skips the translations", func() {
+		slug, err := s.service.ResolvePostSlug("hello", "en")
+		s.Require().NoError(err)
+		s.Equal("hello", slug)
+	})
+}
+
+func (s *TranslationServiceTestSuite) TestLocalizePosts() {
+	s.Run("Falls back along the chain", func() {
+		posts := []models.Post{{ID: 1, Title: "One", Slug: "one"}, {ID: 2, Title: "Two", Slug: "two"}, {ID: 3, Title: "Three", Slug: "three"}}
+		s.repo.On("FindForContents", models.TranslationTypePost, []uint{1, 2, 3}, []string{"pt-BR", "pt"}).Return([]models.Translation{
+			{ContentID: 1, Locale: "pt", Title: "Um", Slug: "um"},
+			{ContentID: 1, Locale: "pt-BR", Title: "Um BR", Slug: "um-br"},
+			{ContentID: 2, Locale: "pt", Title: "Dois", Slug: "dois"},
+		}, nil).Once()
+
+		locales, err := s.service.LocalizePosts(posts, "pt-BR")
+		s.Require().NoError(err)
+		s.Equal([]string{"pt-BR", "pt", "en"}, locales)
+		s.Equal("um-br", posts[0].Slug)
+		s.Equal("Dois", posts[1].Title)
+		s.Equal("Three", posts[2].Title)
+	})
+
+	s.Run("Database error", func() {
+		s.repo.On("FindForContents", models.TranslationTypePost, []uint{1}, []string{"vi"}).Return(nil, errors.New("db error")).Once()
+
+		_, err := s.service.LocalizePosts([]models.Post{{ID: 1}}, "vi")
+		s.assertCode(err, apperror.ErrDBQuery)
+	})
+}
+
+func (s *TranslationServiceTestSuite) TestResolveAndLocalizePage() {
+	translations := []models.Translation{
+		{ContentID: 1, Locale: "vi", Slug: "gioi-thieu"},
+		{ContentID: 2, Locale: "vi", Slug: "nhom"},
+	}
+
+	s.Run("Resolve translated path", func() {
+		s.pageRepo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("FindByType", models.TranslationTypePage, []string{"vi"}).Return(translations, nil).Once()
+
+		path, err := s.service.ResolvePagePath("gioi-thieu/nhom/leadership", "vi")
+		s.Require().NoError(err)
+		s.Equal("about/team/leadership", path)
+	})
+
+	s.Run("Localize page", func() {
+		page := &models.Page{ID: 2, Title: "Team", Slug: "team", Path: "about/team"}
+		s.pageRepo.On("GetAll").Return(pageTree(), nil).Once()
+		s.repo.On("FindByType", models.TranslationTypePage, []string{"vi"}).Return(translations, nil).Once()
+		s.repo.On("FindForContents", models.TranslationTypePage, []uint{2}, []string{"vi"}).
+			Return([]models.Translation{{ContentID: 2, Locale: "vi", Title: "Nhóm", Slug: "nhom", Body: "Nội dung"}}, nil).Once()
+
+		locale, err := s.service.LocalizePage(page, "vi")
+		s.Require().NoError(err)
+		s.Equal("vi", locale)
+		s.Equal("gioi-thieu/nhom", page.Path)
+		s.Equal("Nhóm", page.Title)
+	})
+
+	s.Run("Default locale", func() {
+		page := &models.Page{ID: 2, Title: "Team"}
+
+		locale, err := s.service.LocalizePage(page, "en")
+		s.Require().NoError(err)
+		s.Equal("en", locale)
+		s.Equal("Team", page.Title)
+	})
+}
+
+func TestTranslationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TranslationServiceTestSuite))
+}
@@ -1,12 +1,16 @@
 package services
 
 import (
+	"errors"
+
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
 	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
 	"github.com/vfa-khuongdv/golang-cms/pkg/apperror"
 )
 
 type IUserService interface {
+	PaginateUser(page, limit int, filter repositories.UserFilter) (*utils.Pagination, error)
 	GetUser(id uint) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
 	CreateUser(user *models.User, roleIds []uint) error
@@ -43,9 +47,67 @@ func (service *UserService) GetUser(id uint) (*models.User, error) {
 	if err != nil {
 		return nil, apperror.NewNotFoundError(err.Error())
 	}
+	if err := service.attachAttributes([]*models.User{data}); err != nil {
+		return nil, err
+	}
 	return data, nil
 }
 
+// PaginateUser retrieves a page of users together with their custom attributes.
+// Parameters:
+//   - page: The page number to retrieve
+//   - limit: The number of users per page
+//   - filter: Optional criteria such as attribute values the users must have
+//
+// Returns:
+//   - *utils.Pagination: The page of users
+//   - error: nil if successful, otherwise returns the error that occurred
+//
+// Example:
+//
+//	users, err := service.PaginateUser(1, 10, repositories.UserFilter{})
+func (service *UserService) PaginateUser(page, limit int, filter repositories.UserFilter) (*utils.Pagination, error) {
+	pagination, err := service.repo.PaginateUser(page, limit, filter)
+	if err != nil {
+		return nil, apperror.NewDBQueryError(err.Error())
+	}
+
+	if users, ok := pagination.Data.([]models.User); ok {
+		pointers := make([]*models.User, len(users))
+		for i := range users {
+			pointers[i] = &users[i]
+		}
+		if err := service.attachAttributes(pointers); err != nil {
+			return nil, err
+		}
+	}
+	return pagination, nil
+}
+
+// attachAttributes loads the custom attribute values of the given users into their Attributes field
+func (service *UserService) attachAttributes(users []*models.User) error {
+	ids := make([]uint, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+
+	values, err := service.repo.GetAttributes(ids)
+	if err != nil {
+		return apperror.NewDBQueryError(err.Error())
+	}
+
+	grouped := make(map[uint][]models.UserAttribute)
+	for _, value := range values {
+		grouped[value.UserID] = append(grouped[value.UserID], value)
+	}
+	for _, user := range users {
+		if userValues := grouped[user.ID]; len(userValues) > 0 {
+			user.Attributes = AttributeValueMap(userValues)
+		}
+	}
+	return nil
+}
+
 // GetUserByEmail retrieves a user by their email address from the database.
 // Parameters:
 //   - email: The email address of the user to retrieve
@@ -100,10 +162,11 @@ func (service *UserService) CreateUser(user *models.User, roleIds []uint) error
 
 // UpdateUser updates an existing user's information in the database.
 // Parameters:
-//   - user: Pointer to models.User containing the updated user information
+//   - user: Pointer to models.User containing the updated user information, saved only while its Version is the stored one
 //
 // Returns:
-//   - error: nil if successful, otherwise returns the error that occurred
+//   - error: VersionConflict error if the user was saved by someone else since its version was loaded,
+//     otherwise returns the error that occurred
 //
 // Example:
 //
@@ -116,6 +179,9 @@ func (service *UserService) CreateUser(user *models.User, roleIds []uint) error
 func (service *UserService) UpdateUser(user *models.User) error {
 	err := service.repo.Update(user)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return apperror.NewVersionConflictError("The user was changed by someone else, reload it and try again")
+		}
 		return apperror.NewDBUpdateError(err.Error())
 	}
 	return nil
@@ -174,6 +240,9 @@ func (service *UserService) GetProfile(id uint) (*models.User, error) {
 	if err != nil {
 		return nil, apperror.NewNotFoundError(err.Error())
 	}
+	if err := service.attachAttributes([]*models.User{data}); err != nil {
+		return nil, err
+	}
 	return data, nil
 }
 
@@ -195,6 +264,9 @@ func (service *UserService) GetProfile(id uint) (*models.User, error) {
 func (service *UserService) UpdateProfile(user *models.User) error {
 	err := service.repo.UpdateProfile(user)
 	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return apperror.NewVersionConflictError("Your profile was changed meanwhile, reload it and try again")
+		}
 		return apperror.NewDBUpdateError(err.Error())
 	}
 	return nil
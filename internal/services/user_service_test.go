@@ -6,7 +6,9 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
 	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
 	"github.com/vfa-khuongdv/golang-cms/tests/mocks"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -41,10 +43,23 @@ func (s *UserServiceTestSuite) TestGetUser() {
 		// Mock repo
 		expectedUser := &models.User{ID: 1, Email: "example@gmail.com", Password: "password123"}
 		s.repo.On("GetByID", uint(1)).Return(expectedUser, nil).Once()
+		s.repo.On("GetAttributes", []uint{1}).Return([]models.UserAttribute{
+			{UserID: 1, Value: "Sales", Definition: models.AttributeDefinition{Name: "department", Type: models.AttributeTypeText}},
+			{UserID: 1, Value: "42", Definition: models.AttributeDefinition{Name: "floor", Type: models.AttributeTypeNumber}},
+		}, nil).Once()
 		// Call service
 		user, err := s.service.GetUser(1)
 		s.NoError(err)
 		s.Equal(expectedUser, user)
+		s.Equal(map[string]any{"department": "Sales", "floor": float64(42)}, user.Attributes)
+	})
+	s.Run("Error attributes", func() {
+		s.repo.On("GetByID", uint(2)).Return(&models.User{ID: 2}, nil).Once()
+		s.repo.On("GetAttributes", []uint{2}).Return([]models.UserAttribute{}, errors.New("db error")).Once()
+
+		user, err := s.service.GetUser(2)
+		s.Error(err)
+		s.Nil(user)
 	})
 	s.Run("Error", func() {
 		// Mock repo
@@ -125,6 +140,7 @@ func (s *UserServiceTestSuite) TestGetProfile() {
 		// Mock repo
 		expectedUser := &models.User{ID: 1, Email: "email@example.com", Password: "password123"}
 		s.repo.On("GetProfile", uint(1)).Return(expectedUser, nil).Once()
+		s.repo.On("GetAttributes", []uint{1}).Return([]models.UserAttribute{}, nil).Once()
 		// Call service
 		user, err := s.service.GetProfile(1)
 		s.NoError(err)
@@ -141,6 +157,30 @@ func (s *UserServiceTestSuite) TestGetProfile() {
 	})
 }
 
+func (s *UserServiceTestSuite) TestPaginateUser() {
+	s.Run("Success", func() {
+		filter := repositories.UserFilter{Attributes: map[string]string{"department": "Sales"}}
+		users := []models.User{{ID: 2}, {ID: 1}}
+		s.repo.On("PaginateUser", 1, 10, filter).Return(&utils.Pagination{Page: 1, Limit: 10, TotalItems: 2, TotalPages: 1, Data: users}, nil).Once()
+		s.repo.On("GetAttributes", []uint{2, 1}).Return([]models.UserAttribute{
+			{UserID: 1, Value: "true", Definition: models.AttributeDefinition{Name: "remote", Type: models.AttributeTypeBoolean}},
+		}, nil).Once()
+
+		pagination, err := s.service.PaginateUser(1, 10, filter)
+		s.NoError(err)
+		data := pagination.Data.([]models.User)
+		s.Nil(data[0].Attributes)
+		s.Equal(map[string]any{"remote": true}, data[1].Attributes)
+	})
+	s.Run("Error", func() {
+		s.repo.On("PaginateUser", 2, 10, repositories.UserFilter{}).Return(&utils.Pagination{}, errors.New("db error")).Once()
+
+		pagination, err := s.service.PaginateUser(2, 10, repositories.UserFilter{})
+		s.Error(err)
+		s.Nil(pagination)
+	})
+}
+
 func (s *UserServiceTestSuite) TestUpdateProfile() {
 	s.Run("Success", func() {
 		// Mock repo
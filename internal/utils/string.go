@@ -1,8 +1,14 @@
 package utils
 
 import (
+	crand "crypto/rand"
+	"encoding/hex"
 	"math/rand"
+	"strings"
 	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // GenerateRandomString generates a random string of specified length using alphanumeric characters
@@ -22,6 +28,22 @@ func GenerateRandomString(n int) string {
 	return string(result)
 }
 
+// GenerateSecureToken generates an unguessable hex encoded token from the cryptographic random number generator
+// Use it for credentials and private file names, GenerateRandomString is predictable
+// Parameters:
+//   - n: number of random bytes, the token is twice as long
+//
+// Returns:
+//   - string: the hex encoded token
+//   - error: nil if successful, otherwise the error of the random number generator
+func GenerateSecureToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := crand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
 // StringToPtr converts a string to a pointer to a string
 // Parameters:
 //   - s: the string to convert
@@ -34,3 +56,42 @@ func StringToPtr(s string) *string {
 	}
 	return &s
 }
+
+// slugLetters transliterates the Latin letters which are not a base letter with accents, e.g. the Vietnamese "đ"
+var slugLetters = map[rune]string{
+	'đ': "d", 'ð': "d", 'ø': "o", 'ł': "l", 'ß': "ss", 'æ': "ae", 'œ': "oe", 'þ': "th", 'ı': "i",
+}
+
+// Slugify converts a text into a lowercase URL friendly slug
+// Accents are removed, letters such as "đ" are transliterated and every run of other characters becomes a single hyphen
+// Parameters:
+//   - s: the text to convert, e.g. a title
+//
+// Returns:
+//   - string: the slug, e.g. "Crème Brûlée, 2nd edition" becomes "creme-brulee-2nd-edition" and "Đường phố" becomes "duong-pho"
+func Slugify(s string) string {
+	decomposed := norm.NFD.String(strings.ToLower(s))
+
+	var builder strings.Builder
+	hyphen := false
+	write := func(text string) {
+		if hyphen && builder.Len() > 0 {
+			builder.WriteByte('-')
+		}
+		builder.WriteString(text)
+		hyphen = false
+	}
+	for _, r := range decomposed {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Drop the accents split off by the decomposition
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(r))
+		case slugLetters[r] != "":
+			write(slugLetters[r])
+		default:
+			hyphen = true
+		}
+	}
+	return builder.String()
+}
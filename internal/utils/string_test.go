@@ -43,6 +43,17 @@ func TestGenerateRandomStringCharset(t *testing.T) {
 	}
 }
 
+// TestGenerateSecureToken checks the length and the hex encoding of the token
+func TestGenerateSecureToken(t *testing.T) {
+	token, err := utils.GenerateSecureToken(32)
+	assert.NoError(t, err)
+	assert.Regexp(t, "^[0-9a-f]{64}$", token)
+
+	other, err := utils.GenerateSecureToken(32)
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
 func TestStringToPtr(t *testing.T) {
 	t.Run("returns pointer when string is non-empty", func(t *testing.T) {
 		input := "hello"
@@ -56,3 +67,20 @@ func TestStringToPtr(t *testing.T) {
 		assert.Nil(t, ptr)
 	})
 }
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Hello World":               "hello-world",
+		"  Leading and trailing  ":  "leading-and-trailing",
+		"Crème Brûlée, 2nd edition": "creme-brulee-2nd-edition",
+		"multiple---hyphens__here":  "multiple-hyphens-here",
+		"Go 1.23 released!":         "go-1-23-released",
+		"日本語":                       "",
+		"Đường phố Hà Nội":          "duong-pho-ha-noi",
+		"Cà phê sữa đá":             "ca-phe-sua-da",
+		"Øresund Straße":            "oresund-strasse",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, utils.Slugify(input), input)
+	}
+}
@@ -0,0 +1,72 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+// Job is a unit of background work, it is called once per interval
+type Job func(ctx context.Context) error
+
+type task struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// Runner runs registered jobs periodically in background goroutines
+type Runner struct {
+	tasks []task
+}
+
+// NewRunner creates a new Runner without any job
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Add registers a job that runs every interval once the runner is started
+// Parameters:
+//   - name: Name of the job used in log messages
+//   - interval: Time between two runs of the job
+//   - job: The function to run
+func (runner *Runner) Add(name string, interval time.Duration, job Job) {
+	runner.tasks = append(runner.tasks, task{name: name, interval: interval, job: job})
+}
+
+// Start runs every registered job in its own goroutine until ctx is cancelled
+// Jobs run once immediately and then every interval, errors and panics are logged and do not stop the job
+func (runner *Runner) Start(ctx context.Context) {
+	for _, t := range runner.tasks {
+		go runner.run(ctx, t)
+	}
+}
+
+func (runner *Runner) run(ctx context.Context, t task) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		runner.runOnce(ctx, t)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce calls the job once, a panicking job is logged and runs again at the next interval
+func (runner *Runner) runOnce(ctx context.Context, t task) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("Worker %s panicked: %v", t.name, r)
+		}
+	}()
+
+	if err := t.job(ctx); err != nil {
+		logger.Errorf("Worker %s failed: %v", t.name, err)
+	}
+}
@@ -0,0 +1,60 @@
+package workers_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/internal/workers"
+	"github.com/vfa-khuongdv/golang-cms/pkg/logger"
+)
+
+func TestRunner(t *testing.T) {
+	logger.Init()
+
+	t.Run("Runs jobs repeatedly until cancelled", func(t *testing.T) {
+		var calls, failures atomic.Int32
+		ctx, cancel := context.WithCancel(context.Background())
+
+		runner := workers.NewRunner()
+		runner.Add("counter", 10*time.Millisecond, func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		})
+		runner.Add("failing", 10*time.Millisecond, func(ctx context.Context) error {
+			failures.Add(1)
+			return errors.New("job failed")
+		})
+		runner.Start(ctx)
+
+		assert.Eventually(t, func() bool {
+			return calls.Load() >= 3 && failures.Load() >= 3
+		}, time.Second, 5*time.Millisecond)
+
+		cancel()
+		time.Sleep(30 * time.Millisecond)
+		stopped := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load())
+	})
+
+	t.Run("Keeps running a panicking job", func(t *testing.T) {
+		var panics atomic.Int32
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		runner := workers.NewRunner()
+		runner.Add("panicking", 10*time.Millisecond, func(ctx context.Context) error {
+			panics.Add(1)
+			panic("job panicked")
+		})
+		runner.Start(ctx)
+
+		assert.Eventually(t, func() bool {
+			return panics.Load() >= 3
+		}, time.Second, 5*time.Millisecond)
+	})
+}
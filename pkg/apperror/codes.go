@@ -33,4 +33,16 @@ const (
 	ErrCacheDelete = 4004 // Delete cache error
 	ErrCacheList   = 4005 // List cache error
 	ErrCacheExists = 4006 // Cache key exists check error
+
+	// File errors
+	ErrFileTooLarge        = 5000 // Uploaded file exceeds the size limit
+	ErrUnsupportedFileType = 5001 // Uploaded file type is not allowed
+	ErrFileStorage         = 5002 // Failed to read or write file storage
+
+	// Workflow errors
+	ErrInvalidTransition = 6000 // Content cannot move from its current state with the requested action
+
+	// Concurrent editing errors
+	ErrVersionConflict = 6001 // The record was saved by someone else since it was loaded
+	ErrResourceLocked  = 6002 // The record is being edited by another user
 )
@@ -169,3 +169,51 @@ func NewValidationDataError(message string) *AppError {
 		Message:        message,
 	}
 }
+
+// === File errors ===
+func NewFileTooLargeError(message string) *AppError {
+	return &AppError{
+		HttpStatusCode: http.StatusRequestEntityTooLarge,
+		Code:           ErrFileTooLarge,
+		Message:        message,
+	}
+}
+func NewUnsupportedFileTypeError(message string) *AppError {
+	return &AppError{
+		HttpStatusCode: http.StatusUnsupportedMediaType,
+		Code:           ErrUnsupportedFileType,
+		Message:        message,
+	}
+}
+func NewFileStorageError(message string) *AppError {
+	return &AppError{
+		HttpStatusCode: http.StatusInternalServerError,
+		Code:           ErrFileStorage,
+		Message:        message,
+	}
+}
+
+// === Workflow errors ===
+func NewInvalidTransitionError(message string) *AppError {
+	return &AppError{
+		HttpStatusCode: http.StatusConflict,
+		Code:           ErrInvalidTransition,
+		Message:        message,
+	}
+}
+
+// === Concurrent editing errors ===
+func NewVersionConflictError(message string) *AppError {
+	return &AppError{
+		HttpStatusCode: http.StatusConflict,
+		Code:           ErrVersionConflict,
+		Message:        message,
+	}
+}
+func NewResourceLockedError(message string) *AppError {
+	return &AppError{
+		HttpStatusCode: http.StatusLocked,
+		Code:           ErrResourceLocked,
+		Message:        message,
+	}
+}
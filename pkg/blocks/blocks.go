@@ -0,0 +1,280 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Types of blocks
+const (
+	TypeHeading      = "heading"
+	TypeParagraph    = "paragraph"
+	TypeImage        = "image"
+	TypeGallery      = "gallery"
+	TypeQuote        = "quote"
+	TypeEmbed        = "embed"
+	TypeCallToAction = "call_to_action"
+	TypeGlobal       = "global" // Reference to a global block, replaced by the blocks of the global block when the document is resolved
+)
+
+// Types lists every type of block
+var Types = []string{TypeHeading, TypeParagraph, TypeImage, TypeGallery, TypeQuote, TypeEmbed, TypeCallToAction, TypeGlobal}
+
+// MaxBlocks is the largest number of blocks of a document
+const MaxBlocks = 500
+
+// Block is an element of a document, the keys of its data depend on its type
+type Block struct {
+	Type string         `json:"type"`
+	Data map[string]any `json:"data"`
+}
+
+// Problem is a validation error of a block
+type Problem struct {
+	Path    string // Location of the invalid value, e.g. "[2].data.text"
+	Message string
+}
+
+// Kinds of values of the data of blocks
+const (
+	kindText     = "text"
+	kindHTML     = "html" // Inline HTML, sanitized when the document is rendered and by ResolveMedia
+	kindInteger  = "integer"
+	kindURL      = "url"
+	kindID       = "id"
+	kindIDList   = "id_list"
+	kindOption   = "option"
+	kindResolved = "resolved" // Set when the document is resolved, rejected on input
+)
+
+// field describes a key of the data of a type of block
+type field struct {
+	name     string
+	kind     string
+	required bool
+	max      int      // text, html: maximum number of characters, integer: maximum value, id_list: maximum number of IDs
+	options  []string // option: allowed values
+}
+
+// schemas lists the keys of the data of every type of block
+var schemas = map[string][]field{
+	TypeHeading: {
+		{name: "text", kind: kindText, required: true, max: 255},
+		{name: "level", kind: kindInteger, max: 6}, // 2 when empty
+	},
+	TypeParagraph: {
+		{name: "text", kind: kindHTML, required: true, max: 20000},
+	},
+	TypeImage: {
+		{name: "media_id", kind: kindID, required: true},
+		{name: "variant", kind: kindText, max: 50}, // Resized copy shown, e.g. "medium"
+		{name: "alt", kind: kindText, max: 255},
+		{name: "caption", kind: kindText, max: 500},
+		{name: "link", kind: kindURL},
+		{name: "url", kind: kindResolved},
+	},
+	TypeGallery: {
+		{name: "media_ids", kind: kindIDList, required: true, max: 50},
+		{name: "variant", kind: kindText, max: 50},
+		{name: "caption", kind: kindText, max: 500},
+		{name: "images", kind: kindResolved},
+	},
+	TypeQuote: {
+		{name: "text", kind: kindText, required: true, max: 5000},
+		{name: "citation", kind: kindText, max: 255},
+	},
+	TypeEmbed: {
+		{name: "url", kind: kindURL, required: true}, // e.g. a video, embedded by the front end
+		{name: "caption", kind: kindText, max: 500},
+	},
+	TypeCallToAction: {
+		{name: "label", kind: kindText, required: true, max: 100},
+		{name: "url", kind: kindURL, required: true},
+		{name: "text", kind: kindText, max: 1000},
+		{name: "style", kind: kindOption, options: []string{"primary", "secondary"}},
+	},
+	TypeGlobal: {
+		{name: "global_block_id", kind: kindID, required: true},
+		{name: "name", kind: kindResolved},
+		{name: "blocks", kind: kindResolved},
+	},
+}
+
+// Parse reads the blocks of a document stored as a JSON array, an empty source has no blocks
+func Parse(source string) ([]Block, error) {
+	if strings.TrimSpace(source) == "" {
+		return []Block{}, nil
+	}
+	var list []Block
+	if err := json.Unmarshal([]byte(source), &list); err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = []Block{}
+	}
+	return list, nil
+}
+
+// Validate checks the type and data of every block of a document
+// Parameters:
+//   - list: The blocks of the document
+//   - allowGlobal: Whether the document may reference global blocks, global blocks cannot reference each other
+//
+// Returns:
+//   - []Problem: Every invalid value in document order, empty when the document is valid
+func Validate(list []Block, allowGlobal bool) []Problem {
+	var problems []Problem
+	if len(list) > MaxBlocks {
+		return []Problem{{Path: "", Message: fmt.Sprintf("must have at most %d blocks", MaxBlocks)}}
+	}
+
+	for i, block := range list {
+		path := "[" + strconv.Itoa(i) + "]"
+		schema, ok := schemas[block.Type]
+		if !ok || (block.Type == TypeGlobal && !allowGlobal) {
+			problems = append(problems, Problem{Path: path + ".type", Message: "type must be one of " + strings.Join(allowedTypes(allowGlobal), " ")})
+			continue
+		}
+
+		for key := range block.Data {
+			if !slices.ContainsFunc(schema, func(f field) bool { return f.name == key && f.kind != kindResolved }) {
+				problems = append(problems, Problem{Path: path + ".data." + key, Message: key + " is not a field of " + block.Type + " blocks"})
+			}
+		}
+		for _, f := range schema {
+			if f.kind == kindResolved {
+				continue
+			}
+			value, ok := block.Data[f.name]
+			if !ok || value == nil {
+				if f.required {
+					problems = append(problems, Problem{Path: path + ".data." + f.name, Message: f.name + " is required"})
+				}
+				continue
+			}
+			if message := checkValue(f, value); message != "" {
+				problems = append(problems, Problem{Path: path + ".data." + f.name, Message: f.name + " " + message})
+			}
+		}
+	}
+	slices.SortStableFunc(problems, func(a, b Problem) int { return strings.Compare(a.Path, b.Path) })
+	return problems
+}
+
+// allowedTypes lists the types accepted by Validate
+func allowedTypes(allowGlobal bool) []string {
+	if allowGlobal {
+		return Types
+	}
+	return slices.DeleteFunc(slices.Clone(Types), func(t string) bool { return t == TypeGlobal })
+}
+
+// checkValue returns why a value does not fit a field, empty when it fits
+func checkValue(f field, value any) string {
+	switch f.kind {
+	case kindText, kindHTML:
+		text, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if f.required && strings.TrimSpace(text) == "" {
+			return "must not be blank"
+		}
+		if f.max > 0 && len([]rune(text)) > f.max {
+			return fmt.Sprintf("must be at most %d characters", f.max)
+		}
+	case kindInteger:
+		number, ok := toID(value)
+		if !ok || number < 1 || (f.max > 0 && number > uint(f.max)) {
+			return fmt.Sprintf("must be a whole number between 1 and %d", f.max)
+		}
+	case kindURL:
+		text, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		parsed, err := url.Parse(text)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https" && !strings.HasPrefix(text, "/")) || strings.HasPrefix(text, "//") {
+			return "must be an http(s) URL or a path starting with /"
+		}
+	case kindID:
+		if _, ok := toID(value); !ok {
+			return "must be a positive whole number"
+		}
+	case kindIDList:
+		items, ok := value.([]any)
+		if !ok || len(items) == 0 {
+			return "must be a non-empty list"
+		}
+		if len(items) > f.max {
+			return fmt.Sprintf("must have at most %d items", f.max)
+		}
+		for _, item := range items {
+			if _, ok := toID(item); !ok {
+				return "must only contain positive whole numbers"
+			}
+		}
+	case kindOption:
+		if text, ok := value.(string); !ok || !slices.Contains(f.options, text) {
+			return "must be one of " + strings.Join(f.options, " ")
+		}
+	}
+	return ""
+}
+
+// toID converts a JSON number to a positive whole number
+func toID(value any) (uint, bool) {
+	number, ok := value.(float64)
+	if !ok || number < 1 || number != math.Trunc(number) || number > math.MaxUint32 {
+		return 0, false
+	}
+	return uint(number), true
+}
+
+// GlobalIDs returns the IDs of the global blocks referenced by a document, each ID once in order of appearance
+func GlobalIDs(list []Block) []uint {
+	var ids []uint
+	for _, block := range list {
+		if block.Type != TypeGlobal {
+			continue
+		}
+		if id, ok := toID(block.Data["global_block_id"]); ok && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Global is a global block a document is expanded with
+type Global struct {
+	Name   string
+	Blocks []Block
+}
+
+// Expand replaces the references to global blocks with the blocks they hold, as the "blocks" of the reference
+// References to missing global blocks are removed, the list is not changed
+func Expand(list []Block, globals map[uint]Global) []Block {
+	expanded := make([]Block, 0, len(list))
+	for _, block := range list {
+		if block.Type != TypeGlobal {
+			expanded = append(expanded, block)
+			continue
+		}
+		id, _ := toID(block.Data["global_block_id"])
+		global, ok := globals[id]
+		if !ok {
+			continue
+		}
+		expanded = append(expanded, Block{Type: TypeGlobal, Data: map[string]any{
+			"global_block_id": float64(id),
+			"name":            global.Name,
+			"blocks":          global.Blocks,
+		}})
+	}
+	return expanded
+}
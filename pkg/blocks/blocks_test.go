@@ -0,0 +1,137 @@
+package blocks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/blocks"
+)
+
+func TestParse(t *testing.T) {
+	list, err := blocks.Parse(`[{"type":"heading","data":{"text":"Intro","level":1}}]`)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "Intro", list[0].Data["text"])
+
+	list, err = blocks.Parse("  ")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	_, err = blocks.Parse(`{"type":"heading"}`)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid document", func(t *testing.T) {
+		list, err := blocks.Parse(`[
+			{"type":"heading","data":{"text":"Intro","level":2}},
+			{"type":"paragraph","data":{"text":"Hello <b>world</b>"}},
+			{"type":"image","data":{"media_id":4,"variant":"medium","alt":"Cat","link":"/cats"}},
+			{"type":"gallery","data":{"media_ids":[4,5]}},
+			{"type":"quote","data":{"text":"Quote","citation":"Someone"}},
+			{"type":"embed","data":{"url":"https://video.example.com/1"}},
+			{"type":"call_to_action","data":{"label":"Buy","url":"https://shop.example.com","style":"secondary"}},
+			{"type":"global","data":{"global_block_id":3}}
+		]`)
+		require.NoError(t, err)
+		assert.Empty(t, blocks.Validate(list, true))
+	})
+
+	t.Run("Invalid values", func(t *testing.T) {
+		list, err := blocks.Parse(`[
+			{"type":"heading","data":{"text":" ","level":7}},
+			{"type":"image","data":{"media_id":1.5,"extra":true}},
+			{"type":"gallery","data":{"media_ids":[]}},
+			{"type":"call_to_action","data":{"label":"Buy","url":"javascript:alert(1)","style":"loud"}},
+			{"type":"video","data":{}},
+			{"type":"paragraph"}
+		]`)
+		require.NoError(t, err)
+
+		problems := blocks.Validate(list, true)
+		paths := make([]string, len(problems))
+		for i, problem := range problems {
+			paths[i] = problem.Path
+		}
+		assert.Equal(t, []string{
+			"[0].data.level", "[0].data.text",
+			"[1].data.extra", "[1].data.media_id",
+			"[2].data.media_ids",
+			"[3].data.style", "[3].data.url",
+			"[4].type",
+			"[5].data.text",
+		}, paths)
+	})
+
+	t.Run("Global blocks cannot reference each other", func(t *testing.T) {
+		list := []blocks.Block{{Type: blocks.TypeGlobal, Data: map[string]any{"global_block_id": float64(1)}}}
+		problems := blocks.Validate(list, false)
+		require.Len(t, problems, 1)
+		assert.Equal(t, "[0].type", problems[0].Path)
+	})
+
+	t.Run("Resolved fields are rejected on input", func(t *testing.T) {
+		list := []blocks.Block{{Type: blocks.TypeImage, Data: map[string]any{"media_id": float64(1), "url": "https://x"}}}
+		problems := blocks.Validate(list, true)
+		require.Len(t, problems, 1)
+		assert.Equal(t, "[0].data.url", problems[0].Path)
+	})
+}
+
+func TestExpandAndRender(t *testing.T) {
+	list, err := blocks.Parse(`[
+		{"type":"heading","data":{"text":"Tom & Jerry"}},
+		{"type":"global","data":{"global_block_id":3}},
+		{"type":"global","data":{"global_block_id":9}},
+		{"type":"image","data":{"media_id":4,"variant":"medium","caption":"A cat"}},
+		{"type":"gallery","data":{"media_ids":[4,8]}}
+	]`)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{3, 9}, blocks.GlobalIDs(list))
+
+	expanded := blocks.Expand(list, map[uint]blocks.Global{
+		3: {Name: "Newsletter", Blocks: []blocks.Block{{Type: blocks.TypeCallToAction, Data: map[string]any{"label": "Subscribe", "url": "/newsletter"}}}},
+	})
+	require.Len(t, expanded, 4)
+
+	assert.Equal(t, `<h2>Tom &amp; Jerry</h2>`+
+		`<div class="global-block"><div class="call-to-action call-to-action-primary"><a href="/newsletter">Subscribe</a></div></div>`+
+		`<figure><img src="media:4/medium" alt=""><figcaption>A cat</figcaption></figure>`+
+		`<figure class="gallery"><img src="media:4" alt=""><img src="media:8" alt=""></figure>`, blocks.ToHTML(expanded))
+
+	resolved := blocks.ResolveMedia(expanded, func(id uint, variant string) (string, bool) {
+		if id == 8 {
+			return "", false
+		}
+		return fmt.Sprintf("https://cdn.example.com/%d-%s.jpg", id, variant), true
+	})
+	assert.Equal(t, "Newsletter", resolved[1].Data["name"])
+	assert.Equal(t, "https://cdn.example.com/4-medium.jpg", resolved[2].Data["url"])
+	assert.Equal(t, []blocks.ResolvedImage{{MediaID: 4, URL: "https://cdn.example.com/4-.jpg"}}, resolved[3].Data["images"])
+	// The document itself is left unchanged
+	assert.NotContains(t, expanded[2].Data, "url")
+}
+
+func TestResolveMediaSanitizesHTML(t *testing.T) {
+	payload := `<p>Hello<script>alert(1)</script><img src="x" onerror="alert(1)"></p>`
+	list := blocks.Expand([]blocks.Block{
+		{Type: blocks.TypeParagraph, Data: map[string]any{"text": payload}},
+		{Type: blocks.TypeGlobal, Data: map[string]any{"global_block_id": float64(3)}},
+	}, map[uint]blocks.Global{
+		3: {Name: "Footer", Blocks: []blocks.Block{{Type: blocks.TypeParagraph, Data: map[string]any{"text": payload}}}},
+	})
+
+	resolved := blocks.ResolveMedia(list, func(id uint, variant string) (string, bool) { return "", false })
+	nested, ok := resolved[1].Data["blocks"].([]blocks.Block)
+	require.True(t, ok)
+	for _, text := range []any{resolved[0].Data["text"], nested[0].Data["text"]} {
+		assert.Contains(t, text, "Hello")
+		assert.NotContains(t, text, "<script")
+		assert.NotContains(t, text, "alert(1)")
+		assert.NotContains(t, text, "onerror")
+	}
+	// The document itself is left unchanged
+	assert.Equal(t, payload, list[0].Data["text"])
+}
@@ -0,0 +1,180 @@
+package blocks
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+)
+
+// ToHTML converts a document to HTML, images reference the media library as "media:{id}/{variant}"
+// The HTML is not sanitized, the text of paragraphs is inline HTML written by editors: pass the result to markup.Render
+func ToHTML(list []Block) string {
+	var builder strings.Builder
+	writeBlocks(&builder, list)
+	return builder.String()
+}
+
+func writeBlocks(builder *strings.Builder, list []Block) {
+	for _, block := range list {
+		switch block.Type {
+		case TypeHeading:
+			level := 2
+			if number, ok := toID(block.Data["level"]); ok && number <= 6 {
+				level = int(number)
+			}
+			tag := "h" + strconv.Itoa(level)
+			builder.WriteString("<" + tag + ">" + text(block, "text") + "</" + tag + ">")
+		case TypeParagraph:
+			value, _ := block.Data["text"].(string)
+			builder.WriteString("<p>" + value + "</p>")
+		case TypeImage:
+			id, ok := toID(block.Data["media_id"])
+			if !ok {
+				continue
+			}
+			builder.WriteString("<figure>")
+			link, _ := block.Data["link"].(string)
+			if link != "" {
+				builder.WriteString(`<a href="` + html.EscapeString(link) + `">`)
+			}
+			builder.WriteString(`<img src="` + mediaReference(id, block) + `" alt="` + text(block, "alt") + `">`)
+			if link != "" {
+				builder.WriteString("</a>")
+			}
+			writeCaption(builder, text(block, "caption"))
+			builder.WriteString("</figure>")
+		case TypeGallery:
+			items, _ := block.Data["media_ids"].([]any)
+			builder.WriteString(`<figure class="gallery">`)
+			for _, item := range items {
+				if id, ok := toID(item); ok {
+					builder.WriteString(`<img src="` + mediaReference(id, block) + `" alt="">`)
+				}
+			}
+			writeCaption(builder, text(block, "caption"))
+			builder.WriteString("</figure>")
+		case TypeQuote:
+			builder.WriteString("<figure><blockquote><p>" + text(block, "text") + "</p></blockquote>")
+			writeCaption(builder, text(block, "citation"))
+			builder.WriteString("</figure>")
+		case TypeEmbed:
+			// Frames are not allowed in sanitized HTML, the front end embeds the URL from the block tree
+			target := text(block, "url")
+			label := text(block, "caption")
+			if label == "" {
+				label = target
+			}
+			builder.WriteString(`<figure class="embed"><a href="` + target + `">` + label + "</a></figure>")
+		case TypeCallToAction:
+			style := "primary"
+			if value, ok := block.Data["style"].(string); ok && value != "" {
+				style = value
+			}
+			builder.WriteString(`<div class="call-to-action call-to-action-` + html.EscapeString(style) + `">`)
+			if value := text(block, "text"); value != "" {
+				builder.WriteString("<p>" + value + "</p>")
+			}
+			builder.WriteString(`<a href="` + text(block, "url") + `">` + text(block, "label") + "</a></div>")
+		case TypeGlobal:
+			builder.WriteString(`<div class="global-block">`)
+			writeBlocks(builder, children(block))
+			builder.WriteString("</div>")
+		}
+	}
+}
+
+func writeCaption(builder *strings.Builder, caption string) {
+	if caption != "" {
+		builder.WriteString("<figcaption>" + caption + "</figcaption>")
+	}
+}
+
+// text returns a string of the data of a block escaped for HTML
+func text(block Block, key string) string {
+	value, _ := block.Data[key].(string)
+	return html.EscapeString(value)
+}
+
+// mediaReference returns the reference to a file of the media library in the variant chosen by a block
+func mediaReference(id uint, block Block) string {
+	reference := "media:" + strconv.FormatUint(uint64(id), 10)
+	if variant, ok := block.Data["variant"].(string); ok && variant != "" {
+		reference += "/" + html.EscapeString(variant)
+	}
+	return reference
+}
+
+// children returns the blocks of an expanded global block, whether they were expanded in memory or read back from JSON
+func children(block Block) []Block {
+	switch value := block.Data["blocks"].(type) {
+	case []Block:
+		return value
+	case []any:
+		list := make([]Block, 0, len(value))
+		for _, item := range value {
+			object, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			child := Block{Data: map[string]any{}}
+			child.Type, _ = object["type"].(string)
+			if data, ok := object["data"].(map[string]any); ok {
+				child.Data = data
+			}
+			list = append(list, child)
+		}
+		return list
+	}
+	return nil
+}
+
+// ResolvedImage is an image of a gallery in the resolved block tree
+type ResolvedImage struct {
+	MediaID uint   `json:"mediaId"`
+	URL     string `json:"url"`
+}
+
+// ResolveMedia returns a copy of a document with the URLs of its images, for the front ends rendering the block tree
+// Image blocks get a "url" and galleries get their "images", images of missing files are removed
+// HTML values are sanitized like the rendered body, front ends may display them as they are
+func ResolveMedia(list []Block, resolve markup.MediaResolver) []Block {
+	resolved := make([]Block, 0, len(list))
+	for _, block := range list {
+		data := make(map[string]any, len(block.Data)+1)
+		for key, value := range block.Data {
+			data[key] = value
+		}
+		for _, field := range schemas[block.Type] {
+			if text, ok := data[field.name].(string); ok && field.kind == kindHTML {
+				data[field.name] = markup.Sanitize(text)
+			}
+		}
+		variant, _ := block.Data["variant"].(string)
+
+		switch block.Type {
+		case TypeImage:
+			id, _ := toID(block.Data["media_id"])
+			url, ok := resolve(id, variant)
+			if !ok {
+				continue
+			}
+			data["url"] = url
+		case TypeGallery:
+			items, _ := block.Data["media_ids"].([]any)
+			images := []ResolvedImage{}
+			for _, item := range items {
+				id, _ := toID(item)
+				if url, ok := resolve(id, variant); ok {
+					images = append(images, ResolvedImage{MediaID: id, URL: url})
+				}
+			}
+			data["images"] = images
+		case TypeGlobal:
+			data["blocks"] = ResolveMedia(children(block), resolve)
+		}
+		resolved = append(resolved, Block{Type: block.Type, Data: data})
+	}
+	return resolved
+}
@@ -0,0 +1,120 @@
+package feed_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/feed"
+)
+
+func sampleFeed() *feed.Feed {
+	published := time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("ICT", 7*60*60))
+	return &feed.Feed{
+		Title:       "News & Views",
+		Description: "Latest <posts>",
+		Link:        "https://example.com",
+		FeedURL:     "https://example.com/feeds/rss.xml",
+		Language:    "vi",
+		Updated:     published.Add(time.Hour),
+		Items: []feed.Item{{
+			ID:         "tag:example.com,2030-01-01:posts/1",
+			Title:      "Cà phê & <trà>",
+			Link:       "https://example.com/posts/ca-phe",
+			Summary:    "Short",
+			Content:    "<p>Hello \x01world</p>",
+			Author:     "Author",
+			Categories: []string{"Drinks", "Go"},
+			Published:  published,
+			Updated:    published.Add(time.Hour),
+		}},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	body, err := feed.RSS(sampleFeed())
+	require.NoError(t, err)
+
+	var document struct {
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title      string   `xml:"title"`
+				GUID       string   `xml:"guid"`
+				Content    string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+				Categories []string `xml:"category"`
+				PubDate    string   `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &document), "the feed must be well-formed")
+	assert.Equal(t, "News & Views", document.Channel.Title)
+	assert.Equal(t, "Wed, 02 Jan 2030 04:04:05 +0700", document.Channel.LastBuildDate)
+	require.Len(t, document.Channel.Items, 1)
+	assert.Equal(t, "Cà phê & <trà>", document.Channel.Items[0].Title)
+	assert.Equal(t, "<p>Hello �world</p>", document.Channel.Items[0].Content, "invalid XML characters are replaced")
+	assert.Equal(t, []string{"Drinks", "Go"}, document.Channel.Items[0].Categories)
+	assert.Equal(t, "Wed, 02 Jan 2030 03:04:05 +0700", document.Channel.Items[0].PubDate)
+	assert.Contains(t, string(body), `<guid isPermaLink="false">tag:example.com,2030-01-01:posts/1</guid>`)
+	assert.Contains(t, string(body), `<atom:link href="https://example.com/feeds/rss.xml" rel="self"`)
+}
+
+func TestAtom(t *testing.T) {
+	body, err := feed.Atom(sampleFeed())
+	require.NoError(t, err)
+
+	var document struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			ID        string `xml:"id"`
+			Title     string `xml:"title"`
+			Published string `xml:"published"`
+			Content   struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &document), "the feed must be well-formed")
+	assert.Equal(t, "2030-01-02T04:04:05+07:00", document.Updated)
+	require.Len(t, document.Entries, 1)
+	assert.Equal(t, "tag:example.com,2030-01-01:posts/1", document.Entries[0].ID)
+	assert.Equal(t, "2030-01-02T03:04:05+07:00", document.Entries[0].Published)
+	assert.Equal(t, "html", document.Entries[0].Content.Type)
+	assert.Contains(t, string(body), `xml:lang="vi"`)
+}
+
+func TestJSON(t *testing.T) {
+	body, err := feed.JSON(sampleFeed())
+	require.NoError(t, err)
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(body, &document))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", document["version"])
+	assert.Equal(t, "https://example.com/feeds/rss.xml", document["feed_url"])
+
+	items := document["items"].([]any)
+	require.Len(t, items, 1)
+	item := items[0].(map[string]any)
+	assert.Equal(t, "Cà phê & <trà>", item["title"])
+	assert.Equal(t, "2030-01-02T03:04:05+07:00", item["date_published"])
+	assert.Equal(t, []any{map[string]any{"name": "Author"}}, item["authors"])
+	assert.Equal(t, []any{"Drinks", "Go"}, item["tags"])
+}
+
+func TestEmptyFeed(t *testing.T) {
+	empty := &feed.Feed{Title: "Empty", Link: "https://example.com", FeedURL: "https://example.com/feeds/feed.json"}
+
+	body, err := feed.JSON(empty)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"items": []`)
+
+	body, err = feed.RSS(empty)
+	require.NoError(t, err)
+	assert.NoError(t, xml.Unmarshal(body, new(struct{})))
+}
@@ -0,0 +1,151 @@
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Locales holds the locales content is published in and the order in which missing translations fall back
+// The content stored on a record itself is written in the default locale
+type Locales struct {
+	defaultLocale string
+	supported     []string
+	fallbacks     map[string]string
+}
+
+// NewLocales validates and creates a set of locales
+// Parameters:
+//   - defaultLocale: The locale of the content stored on the records, e.g. "en", it is always supported
+//   - supported: The locales translations can be written in, e.g. ["en", "vi", "pt-BR"]
+//   - fallbacks: The locale tried next when a translation is missing, e.g. {"pt-BR": "pt"}, the chain ends with the default locale
+//
+// Returns:
+//   - *Locales: The locales, spelled in their canonical form, e.g. "pt-BR"
+//   - error: If a locale is not a valid BCP 47 tag or a fallback is not supported
+func NewLocales(defaultLocale string, supported []string, fallbacks map[string]string) (*Locales, error) {
+	canonical := func(locale string) (string, error) {
+		tag, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+		if err != nil {
+			return "", fmt.Errorf("invalid locale %q: %w", locale, err)
+		}
+		return tag.String(), nil
+	}
+
+	def, err := canonical(defaultLocale)
+	if err != nil {
+		return nil, err
+	}
+	locales := &Locales{defaultLocale: def, supported: []string{def}, fallbacks: make(map[string]string)}
+	for _, locale := range supported {
+		if strings.TrimSpace(locale) == "" {
+			continue
+		}
+		value, err := canonical(locale)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(locales.supported, value) {
+			locales.supported = append(locales.supported, value)
+		}
+	}
+
+	for from, to := range fallbacks {
+		fromLocale, ok := locales.Normalize(from)
+		if !ok {
+			return nil, fmt.Errorf("fallback of unsupported locale %q", from)
+		}
+		toLocale, ok := locales.Normalize(to)
+		if !ok {
+			return nil, fmt.Errorf("unsupported fallback %q of locale %q", to, from)
+		}
+		locales.fallbacks[fromLocale] = toLocale
+	}
+	return locales, nil
+}
+
+// ParseFallbacks reads fallbacks written as "pt-BR:pt,es-MX:es", each locale followed by the locale tried next
+func ParseFallbacks(value string) (map[string]string, error) {
+	fallbacks := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(entry, ":")
+		if !ok || strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+			return nil, fmt.Errorf("invalid locale fallback %q, expected locale:fallback", entry)
+		}
+		fallbacks[strings.TrimSpace(from)] = strings.TrimSpace(to)
+	}
+	return fallbacks, nil
+}
+
+// Default returns the locale of the content stored on the records
+func (locales *Locales) Default() string {
+	return locales.defaultLocale
+}
+
+// Supported returns every supported locale, the default locale first
+func (locales *Locales) Supported() []string {
+	return slices.Clone(locales.supported)
+}
+
+// Normalize returns the supported locale matching a locale written in any case, e.g. "pt_br" gives "pt-BR"
+func (locales *Locales) Normalize(locale string) (string, bool) {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	for _, supported := range locales.supported {
+		if strings.EqualFold(supported, locale) {
+			return supported, true
+		}
+	}
+	return "", false
+}
+
+// Chain returns the locales tried in order for content requested in a locale, it always ends with the default locale
+// The configured fallbacks are followed first, then the language without its region when it is supported,
+// e.g. "fr-CA" gives ["fr-CA", "fr", "en"]
+func (locales *Locales) Chain(locale string) []string {
+	current, ok := locales.Normalize(locale)
+	if !ok {
+		return []string{locales.defaultLocale}
+	}
+
+	var chain []string
+	for current != "" && !slices.Contains(chain, current) {
+		chain = append(chain, current)
+		if next, ok := locales.fallbacks[current]; ok {
+			current = next
+			continue
+		}
+		current = ""
+		if base, _, found := strings.Cut(chain[len(chain)-1], "-"); found {
+			current, _ = locales.Normalize(base)
+		}
+	}
+	if !slices.Contains(chain, locales.defaultLocale) {
+		chain = append(chain, locales.defaultLocale)
+	}
+	return chain
+}
+
+// Negotiate picks the supported locale preferred by an Accept-Language header, e.g. "vi-VN,vi;q=0.9,en;q=0.8"
+// A language is accepted without its region when only the language is supported
+// Returns the default locale when the header is empty, invalid or lists no supported locale
+func (locales *Locales) Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return locales.defaultLocale
+	}
+	for _, tag := range tags {
+		if locale, ok := locales.Normalize(tag.String()); ok {
+			return locale
+		}
+		base, _ := tag.Base()
+		if locale, ok := locales.Normalize(base.String()); ok {
+			return locale
+		}
+	}
+	return locales.defaultLocale
+}
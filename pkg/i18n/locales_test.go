@@ -0,0 +1,68 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/i18n"
+)
+
+func newLocales(t *testing.T) *i18n.Locales {
+	locales, err := i18n.NewLocales("en", []string{"vi", "pt", "pt_br", "fr", "fr-CA", "ja"}, map[string]string{"pt-BR": "pt", "ja": "vi"})
+	require.NoError(t, err)
+	return locales
+}
+
+func TestNewLocales(t *testing.T) {
+	locales := newLocales(t)
+	assert.Equal(t, "en", locales.Default())
+	assert.Equal(t, []string{"en", "vi", "pt", "pt-BR", "fr", "fr-CA", "ja"}, locales.Supported())
+
+	_, err := i18n.NewLocales("not a locale!", nil, nil)
+	assert.Error(t, err)
+
+	_, err = i18n.NewLocales("en", []string{"vi"}, map[string]string{"vi": "ko"})
+	assert.ErrorContains(t, err, "unsupported fallback")
+}
+
+func TestParseFallbacks(t *testing.T) {
+	fallbacks, err := i18n.ParseFallbacks(" pt-BR:pt, es-MX:es ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pt-BR": "pt", "es-MX": "es"}, fallbacks)
+
+	_, err = i18n.ParseFallbacks("pt-BR")
+	assert.Error(t, err)
+}
+
+func TestNormalize(t *testing.T) {
+	locales := newLocales(t)
+
+	locale, ok := locales.Normalize("PT_br")
+	assert.True(t, ok)
+	assert.Equal(t, "pt-BR", locale)
+
+	_, ok = locales.Normalize("de")
+	assert.False(t, ok)
+}
+
+func TestChain(t *testing.T) {
+	locales := newLocales(t)
+
+	assert.Equal(t, []string{"pt-BR", "pt", "en"}, locales.Chain("pt-br"))
+	assert.Equal(t, []string{"fr-CA", "fr", "en"}, locales.Chain("fr-CA"))
+	assert.Equal(t, []string{"ja", "vi", "en"}, locales.Chain("ja"))
+	assert.Equal(t, []string{"en"}, locales.Chain("en"))
+	assert.Equal(t, []string{"en"}, locales.Chain("de"))
+}
+
+func TestNegotiate(t *testing.T) {
+	locales := newLocales(t)
+
+	assert.Equal(t, "vi", locales.Negotiate("vi-VN,vi;q=0.9,en;q=0.8"))
+	assert.Equal(t, "fr-CA", locales.Negotiate("fr-ca"))
+	assert.Equal(t, "ja", locales.Negotiate("de;q=0.9,ja;q=0.5"))
+	assert.Equal(t, "en", locales.Negotiate("de"))
+	assert.Equal(t, "en", locales.Negotiate(""))
+	assert.Equal(t, "en", locales.Negotiate(";;;"))
+}
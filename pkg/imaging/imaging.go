@@ -0,0 +1,89 @@
+package imaging
+
+import (
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+)
+
+// ErrUnsupportedFormat is returned when an image cannot be decoded or encoded in the requested format
+var ErrUnsupportedFormat = errors.New("imaging: unsupported image format")
+
+// Formats supported by Decode and Encode
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+)
+
+// Decode reads an image and returns it together with its format name ("jpeg", "png" or "gif")
+// Animated GIFs are decoded to their first frame
+func Decode(r io.Reader) (image.Image, string, error) {
+	img, format, err := image.Decode(r)
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, "", ErrUnsupportedFormat
+		}
+		return nil, "", err
+	}
+	return img, format, nil
+}
+
+// Encode writes img to w in the given format
+// JPEG images are written with quality 85
+func Encode(w io.Writer, img image.Image, format string) error {
+	switch format {
+	case FormatJPEG:
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	case FormatPNG:
+		return png.Encode(w, img)
+	case FormatGIF:
+		return gif.Encode(w, img, nil)
+	default:
+		return ErrUnsupportedFormat
+	}
+}
+
+// Fit scales img down so that it fits within maxWidth x maxHeight, preserving the aspect ratio
+// Images that already fit are returned unchanged
+func Fit(img image.Image, maxWidth, maxHeight int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	ratio := min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+	newWidth := max(1, int(float64(width)*ratio+0.5))
+	newHeight := max(1, int(float64(height)*ratio+0.5))
+
+	return resize(img, bounds, newWidth, newHeight)
+}
+
+// Fill crops the center of img to the aspect ratio of width x height and scales it to exactly that size
+func Fill(img image.Image, width, height int) image.Image {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	// Compute the largest centered rectangle with the target aspect ratio
+	cropWidth, cropHeight := srcWidth, srcWidth*height/width
+	if cropHeight > srcHeight {
+		cropWidth, cropHeight = srcHeight*width/height, srcHeight
+	}
+	x0 := bounds.Min.X + (srcWidth-cropWidth)/2
+	y0 := bounds.Min.Y + (srcHeight-cropHeight)/2
+	crop := image.Rect(x0, y0, x0+cropWidth, y0+cropHeight)
+
+	return resize(img, crop, width, height)
+}
+
+func resize(img image.Image, src image.Rectangle, width, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Over, nil)
+	return dst
+}
@@ -0,0 +1,84 @@
+package imaging_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/imaging"
+)
+
+func newImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, newImage(20, 10)))
+
+	img, format, err := imaging.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, imaging.FormatPNG, format)
+	assert.Equal(t, 20, img.Bounds().Dx())
+
+	_, _, err = imaging.Decode(strings.NewReader("not an image"))
+	assert.ErrorIs(t, err, imaging.ErrUnsupportedFormat)
+}
+
+func TestEncode(t *testing.T) {
+	img := newImage(8, 8)
+	for _, format := range []string{imaging.FormatJPEG, imaging.FormatPNG, imaging.FormatGIF} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, imaging.Encode(&buf, img, format))
+
+			_, decodedFormat, err := imaging.Decode(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, format, decodedFormat)
+		})
+	}
+
+	assert.ErrorIs(t, imaging.Encode(&bytes.Buffer{}, img, "bmp"), imaging.ErrUnsupportedFormat)
+}
+
+func TestFit(t *testing.T) {
+	t.Run("scales down preserving aspect ratio", func(t *testing.T) {
+		img := imaging.Fit(newImage(400, 200), 100, 100)
+		assert.Equal(t, 100, img.Bounds().Dx())
+		assert.Equal(t, 50, img.Bounds().Dy())
+	})
+
+	t.Run("keeps small images unchanged", func(t *testing.T) {
+		src := newImage(40, 20)
+		assert.Same(t, src, imaging.Fit(src, 100, 100))
+	})
+}
+
+func TestFill(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+	}{
+		{name: "landscape", width: 300, height: 100},
+		{name: "portrait", width: 100, height: 300},
+		{name: "upscale", width: 10, height: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := imaging.Fill(newImage(tt.width, tt.height), 64, 64)
+			assert.Equal(t, image.Rect(0, 0, 64, 64), img.Bounds())
+		})
+	}
+}
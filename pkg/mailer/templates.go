@@ -0,0 +1,8 @@
+package mailer
+
+import "embed"
+
+// Templates holds the HTML email templates, they are embedded so emails render regardless of the working directory
+//
+//go:embed templates/*.html
+var Templates embed.FS
@@ -0,0 +1,670 @@
+package markup
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	atxHeadingPattern  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextPattern      = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	thematicPattern    = regexp.MustCompile(`^ {0,3}((?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fencePattern       = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	bulletPattern      = regexp.MustCompile(`^( {0,3})([-*+])([ \t]+|$)`)
+	orderedPattern     = regexp.MustCompile(`^( {0,3})(\d{1,9})([.)])([ \t]+|$)`)
+	htmlBlockPattern   = regexp.MustCompile(`^ {0,3}<(?:/?[a-zA-Z][a-zA-Z0-9-]*(?:[\s/>]|$)|!--)`)
+	tableDelimPattern  = regexp.MustCompile(`^ *\|? *:?-+:? *(?:\| *:?-+:? *)*\|? *$`)
+	entityPattern      = regexp.MustCompile(`^&(?:[a-zA-Z][a-zA-Z0-9]{1,31}|#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6});`)
+	inlineHTMLPattern  = regexp.MustCompile(`^<(?:/?[a-zA-Z][a-zA-Z0-9-]*(?:\s+[a-zA-Z_:][a-zA-Z0-9_.:-]*(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?>|!--[\s\S]*?-->)`)
+	autolinkPattern    = regexp.MustCompile(`^<((?:https?|mailto):[^\s<>]+)>`)
+	linkDestinationEnd = regexp.MustCompile(`^\s*(?:"([^"]*)"|'([^']*)')?\s*$`)
+)
+
+// MarkdownToHTML converts Markdown to HTML, the output is not sanitized
+// It covers the CommonMark blocks and inlines editors use together with GitHub tables and ~~strikethrough~~
+// Raw HTML is kept as it is and left to Sanitize
+func MarkdownToHTML(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	var builder strings.Builder
+	renderBlocks(&builder, strings.Split(source, "\n"))
+	return builder.String()
+}
+
+// renderBlocks renders lines as a sequence of blocks, nested blocks of quotes and list items render their own lines
+func renderBlocks(builder *strings.Builder, lines []string) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			builder.WriteString("<p>")
+			builder.WriteString(renderInline(strings.Join(paragraph, "\n")))
+			builder.WriteString("</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := expandTabs(lines[i])
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		// A paragraph underlined with = or - is a heading
+		if len(paragraph) > 0 {
+			if match := setextPattern.FindStringSubmatch(line); match != nil {
+				level := 1
+				if match[1][0] == '-' {
+					level = 2
+				}
+				writeHeading(builder, level, strings.Join(paragraph, "\n"))
+				paragraph = nil
+				continue
+			}
+		}
+
+		if match := atxHeadingPattern.FindStringSubmatch(line); match != nil {
+			flush()
+			writeHeading(builder, len(match[1]), match[2])
+			continue
+		}
+
+		if thematicPattern.MatchString(line) {
+			flush()
+			builder.WriteString("<hr>\n")
+			continue
+		}
+
+		if match := fencePattern.FindStringSubmatch(line); match != nil {
+			flush()
+			indent, fence := len(match[1]), match[2]
+			var code []string
+			for i++; i < len(lines); i++ {
+				closing := strings.TrimSpace(lines[i])
+				if strings.HasPrefix(closing, fence[:1]) && strings.Trim(closing, fence[:1]) == "" && len(closing) >= len(fence) {
+					break
+				}
+				code = append(code, trimIndent(lines[i], indent))
+			}
+			writeCode(builder, strings.Fields(match[3]), code)
+			continue
+		}
+
+		// Indented code cannot interrupt a paragraph
+		if len(paragraph) == 0 && strings.HasPrefix(line, "    ") {
+			var code []string
+			for ; i < len(lines); i++ {
+				next := expandTabs(lines[i])
+				if strings.TrimSpace(next) != "" && !strings.HasPrefix(next, "    ") {
+					break
+				}
+				code = append(code, trimIndent(next, 4))
+			}
+			i--
+			for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+				code = code[:len(code)-1]
+			}
+			writeCode(builder, nil, code)
+			continue
+		}
+
+		if strings.HasPrefix(strings.TrimLeft(line, " "), ">") {
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				next := strings.TrimLeft(expandTabs(lines[i]), " ")
+				if !strings.HasPrefix(next, ">") {
+					// Lazy continuation of a quoted paragraph
+					if strings.TrimSpace(next) != "" && len(quoted) > 0 && strings.TrimSpace(quoted[len(quoted)-1]) != "" && !startsBlock(next) {
+						quoted = append(quoted, next)
+						continue
+					}
+					break
+				}
+				next = strings.TrimPrefix(next, ">")
+				quoted = append(quoted, strings.TrimPrefix(next, " "))
+			}
+			i--
+			builder.WriteString("<blockquote>\n")
+			renderBlocks(builder, quoted)
+			builder.WriteString("</blockquote>\n")
+			continue
+		}
+
+		if marker, ok := listMarker(line); ok && (len(paragraph) == 0 || marker.content != "") {
+			flush()
+			i = renderList(builder, lines, i, marker) - 1
+			continue
+		}
+
+		if len(paragraph) == 0 && htmlBlockPattern.MatchString(line) {
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				builder.WriteString(lines[i])
+				builder.WriteString("\n")
+			}
+			continue
+		}
+
+		if len(paragraph) == 0 && i+1 < len(lines) && strings.Contains(line, "|") && tableDelimPattern.MatchString(lines[i+1]) {
+			if end, ok := renderTable(builder, lines, i); ok {
+				i = end - 1
+				continue
+			}
+		}
+
+		paragraph = append(paragraph, strings.TrimLeft(line, " "))
+	}
+	flush()
+}
+
+// startsBlock reports whether a line starts a block which interrupts a paragraph
+func startsBlock(line string) bool {
+	if atxHeadingPattern.MatchString(line) || thematicPattern.MatchString(line) || fencePattern.MatchString(line) {
+		return true
+	}
+	_, ok := listMarker(line)
+	return ok
+}
+
+// writeHeading writes a heading, its ID is set when the table of contents is generated
+func writeHeading(builder *strings.Builder, level int, text string) {
+	tag := "h" + strconv.Itoa(level)
+	builder.WriteString("<" + tag + ">")
+	builder.WriteString(renderInline(strings.TrimSpace(text)))
+	builder.WriteString("</" + tag + ">\n")
+}
+
+// writeCode writes a code block, the first word of the info string of a fence is its language
+func writeCode(builder *strings.Builder, info []string, code []string) {
+	builder.WriteString("<pre><code")
+	if len(info) > 0 {
+		builder.WriteString(` class="language-` + html.EscapeString(info[0]) + `"`)
+	}
+	builder.WriteString(">")
+	for _, line := range code {
+		builder.WriteString(html.EscapeString(line))
+		builder.WriteString("\n")
+	}
+	builder.WriteString("</code></pre>\n")
+}
+
+type listItemMarker struct {
+	ordered bool
+	bullet  byte   // Character of a bullet list, the delimiter of an ordered list
+	start   int    // Number of the first item of an ordered list
+	width   int    // Columns of the marker and its spaces, the indentation of the lines of the item
+	content string // Text following the marker on its line
+}
+
+// listMarker parses the marker of a list item
+func listMarker(line string) (listItemMarker, bool) {
+	if match := bulletPattern.FindStringSubmatch(line); match != nil && !thematicPattern.MatchString(line) {
+		return listItemMarker{bullet: match[2][0], width: markerWidth(match[0]), content: line[len(match[0]):]}, true
+	}
+	if match := orderedPattern.FindStringSubmatch(line); match != nil {
+		start, _ := strconv.Atoi(match[2])
+		return listItemMarker{ordered: true, bullet: match[3][0], start: start, width: markerWidth(match[0]), content: line[len(match[0]):]}, true
+	}
+	return listItemMarker{}, false
+}
+
+// markerWidth returns the indentation of the content of an item, spaces past the first four belong to the content
+func markerWidth(marker string) int {
+	trimmed := strings.TrimRight(marker, " \t")
+	spaces := len(marker) - len(trimmed)
+	if spaces == 0 || spaces > 4 {
+		spaces = 1
+	}
+	return len(trimmed) + spaces
+}
+
+// renderList renders the items of a list starting at a line and returns the index of the line following the list
+func renderList(builder *strings.Builder, lines []string, start int, first listItemMarker) int {
+	type item struct {
+		lines []string
+	}
+	var items []item
+	loose := false
+	blankBefore := false
+
+	i := start
+	for i < len(lines) {
+		line := expandTabs(lines[i])
+		marker, ok := listMarker(line)
+		if !ok || marker.ordered != first.ordered || marker.bullet != first.bullet {
+			break
+		}
+		if blankBefore && len(items) > 0 {
+			loose = true
+		}
+
+		current := item{lines: []string{marker.content}}
+		blankBefore = false
+		for i++; i < len(lines); i++ {
+			next := expandTabs(lines[i])
+			if strings.TrimSpace(next) == "" {
+				blankBefore = true
+				current.lines = append(current.lines, "")
+				continue
+			}
+			indent := len(next) - len(strings.TrimLeft(next, " "))
+			if indent >= marker.width {
+				if blankBefore && hasContent(current.lines) {
+					loose = true
+				}
+				blankBefore = false
+				current.lines = append(current.lines, next[marker.width:])
+				continue
+			}
+			// Lazy continuation of the paragraph of the item
+			if !blankBefore && !startsBlock(next) && !strings.HasPrefix(strings.TrimLeft(next, " "), ">") {
+				current.lines = append(current.lines, strings.TrimLeft(next, " "))
+				continue
+			}
+			break
+		}
+		for len(current.lines) > 0 && strings.TrimSpace(current.lines[len(current.lines)-1]) == "" {
+			current.lines = current.lines[:len(current.lines)-1]
+		}
+		items = append(items, current)
+	}
+
+	tag := "ul"
+	if first.ordered {
+		tag = "ol"
+	}
+	builder.WriteString("<" + tag)
+	if first.ordered && first.start != 1 {
+		builder.WriteString(` start="` + strconv.Itoa(first.start) + `"`)
+	}
+	builder.WriteString(">\n")
+	for _, item := range items {
+		builder.WriteString("<li>")
+		var nested strings.Builder
+		renderBlocks(&nested, item.lines)
+		content := nested.String()
+		if !loose {
+			// Paragraphs of tight lists are not wrapped
+			content = strings.ReplaceAll(strings.ReplaceAll(content, "<p>", ""), "</p>\n", "\n")
+		}
+		builder.WriteString(strings.TrimSuffix(content, "\n"))
+		builder.WriteString("</li>\n")
+	}
+	builder.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func hasContent(lines []string) bool {
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// renderTable renders a GitHub table starting at its header row and returns the index of the line following it
+func renderTable(builder *strings.Builder, lines []string, start int) (int, bool) {
+	header := splitTableRow(lines[start])
+	delimiters := splitTableRow(lines[start+1])
+	if len(header) != len(delimiters) {
+		return start, false
+	}
+
+	aligns := make([]string, len(delimiters))
+	for i, delimiter := range delimiters {
+		left, right := strings.HasPrefix(delimiter, ":"), strings.HasSuffix(delimiter, ":")
+		switch {
+		case left && right:
+			aligns[i] = "center"
+		case right:
+			aligns[i] = "right"
+		case left:
+			aligns[i] = "left"
+		}
+	}
+	writeRow := func(cells []string, tag string) {
+		builder.WriteString("<tr>")
+		for i := range aligns {
+			builder.WriteString("<" + tag)
+			if aligns[i] != "" {
+				builder.WriteString(` align="` + aligns[i] + `"`)
+			}
+			builder.WriteString(">")
+			if i < len(cells) {
+				builder.WriteString(renderInline(cells[i]))
+			}
+			builder.WriteString("</" + tag + ">")
+		}
+		builder.WriteString("</tr>\n")
+	}
+
+	builder.WriteString("<table>\n<thead>\n")
+	writeRow(header, "th")
+	builder.WriteString("</thead>\n")
+	i := start + 2
+	if i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+		builder.WriteString("<tbody>\n")
+		for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]); i++ {
+			writeRow(splitTableRow(lines[i]), "td")
+		}
+		builder.WriteString("</tbody>\n")
+	}
+	builder.WriteString("</table>\n")
+	return i, true
+}
+
+// splitTableRow splits a row of a table into its cells, escaped pipes stay in their cell
+func splitTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// renderInline renders the inlines of a paragraph, heading or table cell
+func renderInline(text string) string {
+	var builder strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			builder.WriteString("<br>\n")
+			i += 2
+
+		case c == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]):
+			builder.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+
+		case c == '`':
+			run := countRun(text[i:], '`')
+			closing := strings.Index(text[i+run:], strings.Repeat("`", run))
+			if closing < 0 {
+				builder.WriteString(text[i : i+run])
+				i += run
+				continue
+			}
+			code := strings.ReplaceAll(text[i+run:i+run+closing], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			builder.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			i += run + closing + run
+
+		case c == '<':
+			if match := autolinkPattern.FindStringSubmatch(text[i:]); match != nil {
+				builder.WriteString(`<a href="` + html.EscapeString(match[1]) + `">` + html.EscapeString(match[1]) + "</a>")
+				i += len(match[0])
+			} else if match := inlineHTMLPattern.FindString(text[i:]); match != "" {
+				builder.WriteString(match)
+				i += len(match)
+			} else {
+				builder.WriteString("&lt;")
+				i++
+			}
+
+		case c == '&':
+			if match := entityPattern.FindString(text[i:]); match != "" {
+				builder.WriteString(match)
+				i += len(match)
+			} else {
+				builder.WriteString("&amp;")
+				i++
+			}
+
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if alt, destination, title, end, ok := parseLink(text, i+1); ok {
+				builder.WriteString(`<img src="` + html.EscapeString(destination) + `" alt="` + html.EscapeString(plainText(alt)) + `"`)
+				if title != "" {
+					builder.WriteString(` title="` + html.EscapeString(title) + `"`)
+				}
+				builder.WriteString(">")
+				i = end
+			} else {
+				builder.WriteByte('!')
+				i++
+			}
+
+		case c == '[':
+			if label, destination, title, end, ok := parseLink(text, i); ok {
+				builder.WriteString(`<a href="` + html.EscapeString(destination) + `"`)
+				if title != "" {
+					builder.WriteString(` title="` + html.EscapeString(title) + `"`)
+				}
+				builder.WriteString(">" + renderInline(label) + "</a>")
+				i = end
+			} else {
+				builder.WriteByte('[')
+				i++
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if tag, inner, end, ok := parseEmphasis(text, i); ok {
+				builder.WriteString("<" + tag + ">" + renderInline(inner) + "</" + tag + ">")
+				i = end
+			} else {
+				run := countRun(text[i:], c)
+				builder.WriteString(text[i : i+run])
+				i += run
+			}
+
+		case c == '\n':
+			// Two trailing spaces make a hard line break
+			written := builder.String()
+			if strings.HasSuffix(written, "  ") {
+				trimmed := strings.TrimRight(written, " ")
+				builder.Reset()
+				builder.WriteString(trimmed)
+				builder.WriteString("<br>")
+			}
+			builder.WriteByte('\n')
+			i++
+
+		case c == '>':
+			builder.WriteString("&gt;")
+			i++
+
+		case c == '"':
+			builder.WriteString("&#34;")
+			i++
+
+		default:
+			builder.WriteByte(c)
+			i++
+		}
+	}
+	return strings.TrimRight(builder.String(), " ")
+}
+
+// parseLink parses [label](destination "title") starting at the opening bracket
+func parseLink(text string, start int) (label, destination, title string, end int, ok bool) {
+	depth := 0
+	closing := -1
+	for i := start; i < len(text) && closing < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '`':
+			if run := countRun(text[i:], '`'); run > 0 {
+				if next := strings.Index(text[i+run:], strings.Repeat("`", run)); next >= 0 {
+					i += run + next + run - 1
+				}
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closing = i
+			}
+		}
+	}
+	if closing < 0 || closing+1 >= len(text) || text[closing+1] != '(' {
+		return "", "", "", 0, false
+	}
+
+	parens := 0
+	for i := closing + 2; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			parens++
+		case ')':
+			if parens > 0 {
+				parens--
+				continue
+			}
+			inside := strings.TrimSpace(text[closing+2 : i])
+			if strings.HasPrefix(inside, "<") {
+				if gt := strings.Index(inside, ">"); gt > 0 {
+					destination, inside = inside[1:gt], inside[gt+1:]
+				}
+			} else if space := strings.IndexAny(inside, " \t\n"); space >= 0 {
+				destination, inside = inside[:space], inside[space:]
+			} else {
+				destination, inside = inside, ""
+			}
+			match := linkDestinationEnd.FindStringSubmatch(inside)
+			if match == nil {
+				return "", "", "", 0, false
+			}
+			return text[start+1 : closing], html.UnescapeString(destination), match[1] + match[2], i + 1, true
+		case '\n':
+			if strings.TrimSpace(text[closing+2:i]) == "" {
+				continue
+			}
+		}
+	}
+	return "", "", "", 0, false
+}
+
+// parseEmphasis parses **strong**, *emphasis* and ~~strikethrough~~ starting at the opening delimiter
+func parseEmphasis(text string, start int) (tag, inner string, end int, ok bool) {
+	delimiter := text[start]
+	run := countRun(text[start:], delimiter)
+	size := 1
+	tag = "em"
+	switch {
+	case delimiter == '~':
+		if run != 2 {
+			return "", "", 0, false
+		}
+		size, tag = 2, "del"
+	case run >= 2:
+		size, tag = 2, "strong"
+	}
+
+	open := start + size
+	// An opening delimiter is followed by text, and an underscore does not open inside a word
+	if open >= len(text) || text[open] == ' ' || text[open] == '\n' {
+		return "", "", 0, false
+	}
+	if delimiter == '_' && start > 0 && isWordByte(text[start-1]) {
+		return "", "", 0, false
+	}
+
+	marker := strings.Repeat(string(delimiter), size)
+	for i := open; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '`':
+			if run := countRun(text[i:], '`'); run > 0 {
+				if next := strings.Index(text[i+run:], strings.Repeat("`", run)); next >= 0 {
+					i += run + next + run - 1
+				}
+			}
+		case strings.HasPrefix(text[i:], marker) && text[i-1] != ' ' && text[i-1] != '\n' && i > open:
+			closingRun := countRun(text[i:], delimiter)
+			// **strong** is not closed by the first * of a nested *emphasis*
+			if size == 1 && closingRun >= 2 {
+				i += closingRun - 1
+				continue
+			}
+			if delimiter == '_' && i+size < len(text) && isWordByte(text[i+size]) {
+				continue
+			}
+			// The last delimiters of a run close **strong *and emphasis***
+			if size == 2 && closingRun > 2 {
+				return tag, text[open : i+closingRun-2], i + closingRun, true
+			}
+			return tag, text[open:i], i + size, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// plainText strips the Markdown of an image description, used as its alt text
+func plainText(text string) string {
+	return strings.NewReplacer("*", "", "_", "", "`", "", "[", "", "]", "").Replace(text)
+}
+
+func countRun(text string, c byte) int {
+	run := 0
+	for run < len(text) && text[run] == c {
+		run++
+	}
+	return run
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// expandTabs replaces the tabs of the indentation of a line with spaces, to the next multiple of 4 columns
+func expandTabs(line string) string {
+	if !strings.Contains(line, "\t") {
+		return line
+	}
+	var builder strings.Builder
+	column := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\t':
+			spaces := 4 - column%4
+			builder.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		case ' ':
+			builder.WriteByte(' ')
+			column++
+		default:
+			builder.WriteString(line[i:])
+			return builder.String()
+		}
+	}
+	return builder.String()
+}
+
+// trimIndent removes up to n spaces of indentation
+func trimIndent(line string, n int) string {
+	line = expandTabs(line)
+	for i := 0; i < n && strings.HasPrefix(line, " "); i++ {
+		line = line[1:]
+	}
+	return line
+}
@@ -0,0 +1,43 @@
+package markup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"Headings", "# Title #\n\nSub\n---", "<h1>Title</h1>\n<h2>Sub</h2>\n"},
+		{"Paragraph with hard break", "one  \ntwo\nthree", "<p>one<br>\ntwo\nthree</p>\n"},
+		{"Emphasis", "**bold** *em* _em_ ~~del~~ snake_case_name", "<p><strong>bold</strong> <em>em</em> <em>em</em> <del>del</del> snake_case_name</p>\n"},
+		{"Nested emphasis", "**bold *and em***", "<p><strong>bold <em>and em</em></strong></p>\n"},
+		{"Code span", "use `a < b` here", "<p>use <code>a &lt; b</code> here</p>\n"},
+		{"Escapes and entities", `\*not em\* &copy; AT&T`, "<p>*not em* &copy; AT&amp;T</p>\n"},
+		{"Link and image", `[docs](https://example.com "Docs") ![a *cat*](media:4)`, `<p><a href="https://example.com" title="Docs">docs</a> <img src="media:4" alt="a cat"></p>` + "\n"},
+		{"Autolink", "see <https://example.com>", `<p>see <a href="https://example.com">https://example.com</a></p>` + "\n"},
+		{"Fenced code", "```js\nif (a < b) {}\n```", `<pre><code class="language-js">if (a &lt; b) {}` + "\n</code></pre>\n"},
+		{"Indented code", "    x := 1", "<pre><code>x := 1\n</code></pre>\n"},
+		{"Blockquote", "> quoted\nlazy", "<blockquote>\n<p>quoted\nlazy</p>\n</blockquote>\n"},
+		{"Tight list", "- a\n- b", "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n"},
+		{"Loose list", "- a\n\n- b", "<ul>\n<li><p>a</p></li>\n<li><p>b</p></li>\n</ul>\n"},
+		{"Ordered list start", "3. c\n4. d", "<ol start=\"3\">\n<li>c</li>\n<li>d</li>\n</ol>\n"},
+		{"Nested list", "- a\n  - b", "<ul>\n<li>a\n<ul>\n<li>b</li>\n</ul></li>\n</ul>\n"},
+		{"Thematic break", "a\n\n***", "<p>a</p>\n<hr>\n"},
+		{"Table", "| A | B |\n|:-:|---|\n| 1 | x\\|y |", "<table>\n<thead>\n<tr><th align=\"center\">A</th><th>B</th></tr>\n</thead>\n<tbody>\n<tr><td align=\"center\">1</td><td>x|y</td></tr>\n</tbody>\n</table>\n"},
+		{"HTML block", "<div class=\"note\">\n*raw*\n</div>", "<div class=\"note\">\n*raw*\n</div>\n"},
+		{"Inline HTML", "a <kbd>Ctrl</kbd> b", "<p>a <kbd>Ctrl</kbd> b</p>\n"},
+		{"Vietnamese", "# Xin chào\n\nĐường phố", "<h1>Xin chào</h1>\n<p>Đường phố</p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, markup.MarkdownToHTML(tt.markdown))
+		})
+	}
+}
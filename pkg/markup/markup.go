@@ -0,0 +1,195 @@
+package markup
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Formats of the source of a document
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// WordsPerMinute is the reading speed the reading time is estimated with
+const WordsPerMinute = 200
+
+// mediaPattern matches references to files of the media library, e.g. "media:42" or "media:42/thumbnail" for a resized copy
+var mediaPattern = regexp.MustCompile(`media:([0-9]+)(?:/([a-z]+))?`)
+
+// Heading is an entry of the table of contents, linked to the heading by its ID
+type Heading struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// Result is a rendered document
+type Result struct {
+	HTML            string          `json:"html"`            // Sanitized HTML, safe to display
+	TableOfContents []Heading       `json:"tableOfContents"` // Every heading in document order
+	WordCount       int             `json:"wordCount"`
+	ReadingTime     int             `json:"readingTime"`      // Minutes at WordsPerMinute, at least 1 for a document with words
+	Blocks          json.RawMessage `json:"blocks,omitempty"` // Resolved block tree of documents made of blocks, set by the caller
+}
+
+// MediaResolver returns the URL of a file of the media library, or of one of its resized copies when variant is set
+// It returns false for a missing file, the reference is then removed
+type MediaResolver func(id uint, variant string) (string, bool)
+
+// MediaIDs returns the IDs of the media files referenced by a source, each ID once in order of appearance
+func MediaIDs(source string) []uint {
+	var ids []uint
+	seen := map[uint]bool{}
+	for _, match := range mediaPattern.FindAllStringSubmatch(source, -1) {
+		id, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || seen[uint(id)] {
+			continue
+		}
+		seen[uint(id)] = true
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+// Render turns a source into sanitized HTML with its table of contents and reading time
+//
+// The function:
+//  1. Converts Markdown to HTML, HTML sources are used as they are
+//  2. Sanitizes the HTML against the allowlist of Sanitize
+//  3. Replaces media references in URLs with the URLs returned by resolve, nil removes them
+//  4. Gives every heading a unique ID for the table of contents
+//
+// Parameters:
+//   - source: The body of the document
+//   - format: FormatMarkdown or FormatHTML
+//   - resolve: Returns the URLs of the referenced media files
+func Render(source, format string, resolve MediaResolver) *Result {
+	if format == FormatMarkdown {
+		source = MarkdownToHTML(source)
+	}
+
+	result := &Result{TableOfContents: []Heading{}}
+	var heading *Heading
+	var headingText strings.Builder
+
+	var builder strings.Builder
+	sanitize(&builder, source, &visitor{
+		url: func(value string) string {
+			match := mediaPattern.FindStringSubmatch(value)
+			if match == nil || match[0] != value || resolve == nil {
+				return value
+			}
+			id, err := strconv.ParseUint(match[1], 10, 32)
+			if err != nil {
+				return ""
+			}
+			url, _ := resolve(uint(id), match[2])
+			return url
+		},
+		start: func(e *element) {
+			if headingLevel(e.tag) > 0 {
+				heading = &Heading{Level: headingLevel(e.tag)}
+				headingText.Reset()
+			}
+		},
+		end: func(tag string) {
+			if heading != nil && headingLevel(tag) == heading.Level {
+				heading.Text = strings.Join(strings.Fields(headingText.String()), " ")
+				result.TableOfContents = append(result.TableOfContents, *heading)
+				heading = nil
+			}
+		},
+		text: func(text string) {
+			result.WordCount += countWords(text)
+			if heading != nil {
+				headingText.WriteString(text)
+			}
+		},
+	})
+
+	// IDs are only known once the text of a heading is complete, text is escaped so every "<h" left is a heading
+	taken := map[string]bool{}
+	for i := range result.TableOfContents {
+		result.TableOfContents[i].ID = uniqueID(result.TableOfContents[i].Text, taken)
+	}
+	index := 0
+	result.HTML = headingTagPattern.ReplaceAllStringFunc(builder.String(), func(tag string) string {
+		if index >= len(result.TableOfContents) {
+			return tag
+		}
+		id := result.TableOfContents[index].ID
+		index++
+		return tag[:3] + ` id="` + id + `"` + tag[3:]
+	})
+
+	if result.WordCount > 0 {
+		result.ReadingTime = int(math.Ceil(float64(result.WordCount) / WordsPerMinute))
+	}
+	return result
+}
+
+var headingTagPattern = regexp.MustCompile(`<h[1-6][ >]`)
+
+// headingLevel returns the level of a heading tag, 0 for other tags
+func headingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
+
+// uniqueID turns the text of a heading into an ID not taken by a previous heading, e.g. "getting-started-2"
+func uniqueID(text string, taken map[string]bool) string {
+	var builder strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && builder.Len() > 0 {
+				builder.WriteByte('-')
+			}
+			builder.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	base := builder.String()
+	if base == "" {
+		base = "section"
+	}
+
+	id := base
+	for n := 2; taken[id]; n++ {
+		id = base + "-" + strconv.Itoa(n)
+	}
+	taken[id] = true
+	return id
+}
+
+// countWords counts the words of a text, Chinese and Japanese characters count as one word each
+func countWords(text string) int {
+	count := 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			count++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			if !inWord {
+				count++
+				inWord = true
+			}
+		case r == '\'' || r == '’' || r == '-':
+			// Apostrophes and hyphens join the parts of a word
+		default:
+			inWord = false
+		}
+	}
+	return count
+}
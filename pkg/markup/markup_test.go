@@ -0,0 +1,89 @@
+package markup_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{"Script removed with content", `<p>a<script>alert(1)</script>b</p>`, `<p>ab</p>`},
+		{"Event handlers removed", `<img src="/a.png" onerror="alert(1)">`, `<img src="/a.png">`},
+		{"Javascript URL removed", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"Obfuscated javascript URL removed", `<a href="java&#x09;script&#58;alert(1)">x</a>`, `<a>x</a>`},
+		{"Data URL removed", `<img src="data:image/svg+xml;base64,PHN2Zz4=">`, `<img>`},
+		{"Relative and mailto URLs kept", `<a href="/about#team">a</a><a href="mailto:a@example.com">m</a>`, `<a href="/about#team">a</a><a href="mailto:a@example.com">m</a>`},
+		{"Unknown tags unwrapped", `<custom><b>bold</b></custom>`, `<b>bold</b>`},
+		{"Style and iframe removed", `<style>p{}</style><iframe src="https://x"></iframe><p style="color:red">x</p>`, `<p>x</p>`},
+		{"Blank target gets rel", `<a href="https://x.com" target="_blank" rel="opener">x</a>`, `<a href="https://x.com" target="_blank" rel="noopener noreferrer">x</a>`},
+		{"Unclosed tags closed", `<p><em>open`, `<p><em>open</em></p>`},
+		{"Stray end tag dropped", `a</div>b`, `ab`},
+		{"Text escaped", `<p>"1 &lt; 2"</p>`, `<p>&#34;1 &lt; 2&#34;</p>`},
+		{"Comments removed", `a<!-- <script>x</script> -->b`, `ab`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, markup.Sanitize(tt.html))
+		})
+	}
+}
+
+func TestMediaIDs(t *testing.T) {
+	assert.Equal(t, []uint{4, 7}, markup.MediaIDs(`![a](media:4) <img src="media:7/thumbnail"> [b](media:4)`))
+	assert.Empty(t, markup.MediaIDs("no media"))
+}
+
+func TestRender(t *testing.T) {
+	resolve := func(id uint, variant string) (string, bool) {
+		if id == 9 {
+			return "", false
+		}
+		if variant != "" {
+			return fmt.Sprintf("https://cdn.example.com/%d-%s.jpg", id, variant), true
+		}
+		return fmt.Sprintf("https://cdn.example.com/%d.jpg", id), true
+	}
+
+	t.Run("Markdown", func(t *testing.T) {
+		result := markup.Render("# Intro\n\nHello **world**\n\n## Intro\n\n![cat](media:4/medium) ![gone](media:9)\n\n<script>x</script>", markup.FormatMarkdown, resolve)
+
+		assert.Contains(t, result.HTML, `<h1 id="intro">Intro</h1>`)
+		assert.Contains(t, result.HTML, `<h2 id="intro-2">Intro</h2>`)
+		assert.Contains(t, result.HTML, `<img src="https://cdn.example.com/4-medium.jpg" alt="cat">`)
+		assert.Contains(t, result.HTML, `<img alt="gone">`)
+		assert.NotContains(t, result.HTML, "script")
+		require.Len(t, result.TableOfContents, 2)
+		assert.Equal(t, markup.Heading{Level: 1, ID: "intro", Text: "Intro"}, result.TableOfContents[0])
+		assert.Equal(t, markup.Heading{Level: 2, ID: "intro-2", Text: "Intro"}, result.TableOfContents[1])
+		assert.Equal(t, 4, result.WordCount)
+		assert.Equal(t, 1, result.ReadingTime)
+	})
+
+	t.Run("HTML is not converted", func(t *testing.T) {
+		result := markup.Render(`<h2 id="x" onclick="y">Tiêu đề <em>chính</em></h2><p>**not bold**</p>`, markup.FormatHTML, nil)
+
+		assert.Equal(t, `<h2 id="tiêu-đề-chính">Tiêu đề <em>chính</em></h2><p>**not bold**</p>`, result.HTML)
+		assert.Equal(t, "Tiêu đề chính", result.TableOfContents[0].Text)
+	})
+
+	t.Run("Reading time", func(t *testing.T) {
+		result := markup.Render(strings.Repeat("word ", 401), markup.FormatMarkdown, nil)
+		assert.Equal(t, 401, result.WordCount)
+		assert.Equal(t, 3, result.ReadingTime)
+
+		empty := markup.Render("", markup.FormatMarkdown, nil)
+		assert.Equal(t, "", empty.HTML)
+		assert.Empty(t, empty.TableOfContents)
+		assert.Zero(t, empty.ReadingTime)
+	})
+}
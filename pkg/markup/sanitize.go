@@ -0,0 +1,288 @@
+package markup
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// allowedTags lists the elements kept by Sanitize with the attributes they keep, the attributes of globalAttributes are kept on every element
+var allowedTags = map[string][]string{
+	"a":          {"href", "target"},
+	"abbr":       nil,
+	"audio":      {"src", "controls", "loop", "muted"},
+	"b":          nil,
+	"blockquote": {"cite"},
+	"br":         nil,
+	"caption":    nil,
+	"cite":       nil,
+	"code":       nil,
+	"dd":         nil,
+	"del":        nil,
+	"details":    {"open"},
+	"div":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"figcaption": nil,
+	"figure":     nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "width", "height", "loading"},
+	"ins":        nil,
+	"kbd":        nil,
+	"li":         {"value"},
+	"mark":       nil,
+	"ol":         {"start", "reversed"},
+	"p":          nil,
+	"pre":        nil,
+	"q":          {"cite"},
+	"s":          nil,
+	"small":      nil,
+	"source":     {"src", "type"},
+	"span":       nil,
+	"strong":     nil,
+	"sub":        nil,
+	"summary":    nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"align", "colspan", "rowspan"},
+	"tfoot":      nil,
+	"th":         {"align", "colspan", "rowspan", "scope"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+	"video":      {"src", "controls", "loop", "muted", "poster", "width", "height"},
+}
+
+var globalAttributes = []string{"class", "title", "lang", "dir"}
+
+// droppedTags are removed together with their content, the text of other unknown elements is kept
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "noscript": true,
+	"template": true, "textarea": true, "select": true, "svg": true, "math": true, "head": true, "title": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true, "source": true}
+
+// urlAttributes hold URLs, only the allowed schemes and relative URLs are kept
+var urlAttributes = map[string]bool{"href": true, "src": true, "cite": true, "poster": true}
+
+var (
+	allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true, "tel": true}
+	classPattern   = regexp.MustCompile(`^[a-zA-Z0-9 _-]+$`)
+	numberPattern  = regexp.MustCompile(`^[0-9]{1,5}%?$`)
+)
+
+// Sanitize keeps the allowlisted elements and attributes of HTML and drops everything else, so the result is safe to display
+// Scripts, styles and embedded frames are removed with their content, event handlers and javascript: URLs are removed
+// Unclosed elements are closed, stray end tags are dropped
+func Sanitize(markup string) string {
+	var builder strings.Builder
+	sanitize(&builder, markup, nil)
+	return builder.String()
+}
+
+// element is an allowed start tag with its kept attributes
+type element struct {
+	tag   string
+	attrs []html.Attribute
+}
+
+// attr returns the value of an attribute of an element
+func (e *element) attr(name string) string {
+	for _, attr := range e.attrs {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// setAttr replaces or adds an attribute of an element
+func (e *element) setAttr(name, value string) {
+	for i := range e.attrs {
+		if e.attrs[i].Key == name {
+			e.attrs[i].Val = value
+			return
+		}
+	}
+	e.attrs = append(e.attrs, html.Attribute{Key: name, Val: value})
+}
+
+// visitor is called by sanitize while it walks the elements, e.g. to collect the headings
+type visitor struct {
+	url   func(value string) string // Rewrites URLs before they are checked, e.g. media references
+	start func(e *element)          // Called for each kept start tag before it is written
+	end   func(tag string)          // Called for each end tag written
+	text  func(text string)         // Called for each text
+}
+
+func sanitize(builder *strings.Builder, markup string, visit *visitor) {
+	tokenizer := html.NewTokenizer(strings.NewReader(markup))
+	var open []string
+	dropping := ""
+	dropDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := tokenizer.Token()
+		tag := token.Data
+
+		// The content of a dropped element is skipped up to its end tag
+		if dropping != "" {
+			if tag == dropping {
+				switch tokenType {
+				case html.StartTagToken:
+					dropDepth++
+				case html.EndTagToken:
+					dropDepth--
+					if dropDepth == 0 {
+						dropping = ""
+					}
+				}
+			}
+			continue
+		}
+
+		switch tokenType {
+		case html.TextToken:
+			if visit != nil && visit.text != nil {
+				visit.text(token.Data)
+			}
+			builder.WriteString(html.EscapeString(token.Data))
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[tag] {
+				if tokenType == html.StartTagToken {
+					dropping, dropDepth = tag, 1
+				}
+				continue
+			}
+			allowed, ok := allowedTags[tag]
+			if !ok {
+				continue
+			}
+
+			if visit != nil && visit.url != nil {
+				for i, attr := range token.Attr {
+					if urlAttributes[strings.ToLower(attr.Key)] {
+						token.Attr[i].Val = visit.url(strings.TrimSpace(attr.Val))
+					}
+				}
+			}
+			e := &element{tag: tag, attrs: allowedAttributes(token.Attr, allowed)}
+			if tag == "a" && e.attr("target") == "_blank" {
+				e.setAttr("rel", "noopener noreferrer")
+			}
+			if visit != nil && visit.start != nil {
+				visit.start(e)
+			}
+			writeStartTag(builder, e)
+			if !voidTags[tag] && tokenType == html.StartTagToken {
+				open = append(open, tag)
+			} else if !voidTags[tag] {
+				builder.WriteString("</" + tag + ">")
+			}
+
+		case html.EndTagToken:
+			// Elements left open inside the closed one are closed first
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != tag {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					builder.WriteString("</" + open[j] + ">")
+					if visit != nil && visit.end != nil {
+						visit.end(open[j])
+					}
+				}
+				open = open[:i]
+				break
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		builder.WriteString("</" + open[i] + ">")
+		if visit != nil && visit.end != nil {
+			visit.end(open[i])
+		}
+	}
+}
+
+// allowedAttributes keeps the allowed attributes of an element with safe values
+func allowedAttributes(attrs []html.Attribute, allowed []string) []html.Attribute {
+	kept := make([]html.Attribute, 0, len(attrs))
+	seen := map[string]bool{}
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || seen[key] || !(slices.Contains(allowed, key) || slices.Contains(globalAttributes, key)) {
+			continue
+		}
+		value := strings.TrimSpace(attr.Val)
+		switch {
+		case urlAttributes[key]:
+			if !safeURL(value) {
+				continue
+			}
+		case key == "class":
+			if !classPattern.MatchString(value) {
+				continue
+			}
+		case key == "width" || key == "height" || key == "colspan" || key == "rowspan" || key == "start" || key == "value":
+			if !numberPattern.MatchString(value) {
+				continue
+			}
+		case key == "target":
+			if value != "_blank" {
+				continue
+			}
+		}
+		seen[key] = true
+		kept = append(kept, html.Attribute{Key: key, Val: value})
+	}
+	return kept
+}
+
+// safeURL reports whether a URL is relative or uses an allowed scheme
+func safeURL(value string) bool {
+	// Browsers ignore control characters and whitespace inside the scheme, e.g. "java\tscript:"
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	if cleaned == "" {
+		return false
+	}
+	colon := strings.IndexByte(cleaned, ':')
+	if colon < 0 || strings.ContainsAny(cleaned[:colon], "/?#") {
+		return true
+	}
+	parsed, err := url.Parse(cleaned)
+	return err == nil && allowedSchemes[strings.ToLower(parsed.Scheme)]
+}
+
+func writeStartTag(builder *strings.Builder, e *element) {
+	builder.WriteString("<" + e.tag)
+	for _, attr := range e.attrs {
+		builder.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	builder.WriteString(">")
+}
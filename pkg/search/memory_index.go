@@ -0,0 +1,180 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Parameters of the BM25 ranking function
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type memoryKey struct {
+	docType string
+	id      uint
+}
+
+type memoryDocument struct {
+	doc    Document
+	terms  map[string]float64 // Weighted frequency of each term, title terms count titleBoost times
+	length float64
+}
+
+// MemoryIndex keeps documents in process and ranks them with BM25
+// It is meant for tests and single instance development setups, its content is lost on restart
+type MemoryIndex struct {
+	mu   sync.RWMutex
+	docs map[memoryKey]*memoryDocument
+}
+
+// NewMemoryIndex creates an empty in-process index
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{
+		docs: make(map[memoryKey]*memoryDocument),
+	}
+}
+
+// Index adds a document or replaces the document with the same type and ID
+func (index *MemoryIndex) Index(doc Document) error {
+	if err := ValidateFilters(doc.Fields); err != nil {
+		return err
+	}
+
+	stored := &memoryDocument{doc: doc, terms: make(map[string]float64)}
+	for _, term := range Tokenize(doc.Title) {
+		stored.terms[term] += titleBoost
+		stored.length += titleBoost
+	}
+	for _, term := range Tokenize(doc.Body) {
+		stored.terms[term]++
+		stored.length++
+	}
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.docs[memoryKey{doc.Type, doc.ID}] = stored
+	return nil
+}
+
+// Delete removes a document
+func (index *MemoryIndex) Delete(docType string, id uint) error {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	delete(index.docs, memoryKey{docType, id})
+	return nil
+}
+
+// DeleteType removes every document of a type
+func (index *MemoryIndex) DeleteType(docType string) error {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	for key := range index.docs {
+		if key.docType == docType {
+			delete(index.docs, key)
+		}
+	}
+	return nil
+}
+
+// Search retrieves the documents containing any term of the query, ranked with BM25
+// The statistics of the ranking are computed over the documents of the searched types that pass the filters
+func (index *MemoryIndex) Search(query Query) (*Result, error) {
+	if err := ValidateFilters(query.Filters); err != nil {
+		return nil, err
+	}
+
+	terms := Tokenize(query.Text)
+	result := &Result{Hits: []Hit{}}
+	if len(terms) == 0 {
+		return result, nil
+	}
+
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+
+	var candidates []*memoryDocument
+	var totalLength float64
+	for _, stored := range index.docs {
+		if !matchesQuery(stored.doc, query) {
+			continue
+		}
+		candidates = append(candidates, stored)
+		totalLength += stored.length
+	}
+	if len(candidates) == 0 {
+		return result, nil
+	}
+	averageLength := totalLength / float64(len(candidates))
+
+	frequencies := make(map[string]int)
+	for _, term := range terms {
+		if _, counted := frequencies[term]; counted {
+			continue
+		}
+		for _, stored := range candidates {
+			if stored.terms[term] > 0 {
+				frequencies[term]++
+			}
+		}
+	}
+
+	for _, stored := range candidates {
+		var score float64
+		for term, frequency := range frequencies {
+			tf := stored.terms[term]
+			if tf == 0 {
+				continue
+			}
+			idf := math.Log(1 + (float64(len(candidates))-float64(frequency)+0.5)/(float64(frequency)+0.5))
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*stored.length/averageLength))
+		}
+		if score == 0 {
+			continue
+		}
+		result.Hits = append(result.Hits, Hit{
+			Type:       stored.doc.Type,
+			ID:         stored.doc.ID,
+			Title:      stored.doc.Title,
+			Fields:     stored.doc.Fields,
+			Score:      score,
+			Highlights: highlights(stored.doc, terms),
+		})
+	}
+
+	sort.Slice(result.Hits, func(i, j int) bool {
+		a, b := result.Hits[i], result.Hits[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.ID < b.ID
+	})
+
+	result.Total = int64(len(result.Hits))
+	start := min(max(query.Offset, 0), len(result.Hits))
+	end := len(result.Hits)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, end)
+	}
+	result.Hits = result.Hits[start:end]
+	return result, nil
+}
+
+// matchesQuery checks the type and the filters of a query against a document
+func matchesQuery(doc Document, query Query) bool {
+	if len(query.Types) > 0 && !slices.Contains(query.Types, doc.Type) {
+		return false
+	}
+	for key, value := range query.Filters {
+		if doc.Fields[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/search"
+)
+
+func newMemoryIndex(t *testing.T) *search.MemoryIndex {
+	index := search.NewMemoryIndex()
+	docs := []search.Document{
+		{Type: "post", ID: 1, Title: "Getting started with Go", Body: "Install the toolchain and write a program", Fields: map[string]string{"status": "published"}},
+		{Type: "post", ID: 2, Title: "Release notes", Body: "This release is written in Go and Go is fast", Fields: map[string]string{"status": "draft"}},
+		{Type: "page", ID: 1, Title: "About", Body: "We love Go", Fields: map[string]string{"status": "published"}},
+		{Type: "user", ID: 1, Title: "Jane Doe", Body: "jane@example.com"},
+	}
+	for _, doc := range docs {
+		require.NoError(t, index.Index(doc))
+	}
+	return index
+}
+
+func TestMemoryIndexSearch(t *testing.T) {
+	t.Run("Ranks title matches first", func(t *testing.T) {
+		result, err := newMemoryIndex(t).Search(search.Query{Text: "go"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), result.Total)
+		require.Len(t, result.Hits, 3)
+		assert.Equal(t, "post", result.Hits[0].Type)
+		assert.Equal(t, uint(1), result.Hits[0].ID)
+		assert.Equal(t, "Getting started with <mark>Go</mark>", result.Hits[0].Highlights["title"])
+		assert.GreaterOrEqual(t, result.Hits[0].Score, result.Hits[1].Score)
+	})
+
+	t.Run("Filters on type and fields", func(t *testing.T) {
+		result, err := newMemoryIndex(t).Search(search.Query{
+			Text:    "go",
+			Types:   []string{"post", "page"},
+			Filters: map[string]string{"status": "published"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Total)
+		for _, hit := range result.Hits {
+			assert.Equal(t, "published", hit.Fields["status"])
+		}
+	})
+
+	t.Run("Paginates", func(t *testing.T) {
+		result, err := newMemoryIndex(t).Search(search.Query{Text: "go", Offset: 2, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), result.Total)
+		assert.Len(t, result.Hits, 1)
+	})
+
+	t.Run("Matches any word", func(t *testing.T) {
+		result, err := newMemoryIndex(t).Search(search.Query{Text: "jane toolchain"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Total)
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		result, err := newMemoryIndex(t).Search(search.Query{Text: "  "})
+		require.NoError(t, err)
+		assert.Zero(t, result.Total)
+		assert.NotNil(t, result.Hits)
+	})
+
+	t.Run("Invalid filter", func(t *testing.T) {
+		_, err := newMemoryIndex(t).Search(search.Query{Text: "go", Filters: map[string]string{"Bad Key": "1"}})
+		assert.ErrorIs(t, err, search.ErrInvalidFilter)
+	})
+}
+
+func TestMemoryIndexUpdates(t *testing.T) {
+	index := newMemoryIndex(t)
+
+	require.NoError(t, index.Index(search.Document{Type: "page", ID: 1, Title: "About", Body: "We love Rust"}))
+	result, err := index.Search(search.Query{Text: "rust"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+
+	require.NoError(t, index.Delete("page", 1))
+	result, err = index.Search(search.Query{Text: "rust"})
+	require.NoError(t, err)
+	assert.Zero(t, result.Total)
+
+	require.NoError(t, index.DeleteType("post"))
+	result, err = index.Search(search.Query{Text: "go"})
+	require.NoError(t, err)
+	assert.Zero(t, result.Total)
+}
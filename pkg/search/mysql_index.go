@@ -0,0 +1,151 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// documentRow is a document stored in the search_documents table
+type documentRow struct {
+	ID        uint              `gorm:"column:id;primaryKey"`
+	DocType   string            `gorm:"column:doc_type"`
+	DocID     uint              `gorm:"column:doc_id"`
+	Title     string            `gorm:"column:title"`
+	Body      string            `gorm:"column:body"`
+	Fields    map[string]string `gorm:"column:fields;serializer:json"`
+	UpdatedAt time.Time         `gorm:"column:updated_at"`
+	Score     float64           `gorm:"column:score;->;-:migration"` // Relevance computed by a search
+}
+
+func (documentRow) TableName() string {
+	return "search_documents"
+}
+
+// MySQLIndex stores documents in the search_documents table and searches them with MySQL FULLTEXT indexes
+// Matching follows the natural language mode of MySQL, words shorter than innodb_ft_min_token_size
+// and stopwords are ignored
+type MySQLIndex struct {
+	db *gorm.DB
+}
+
+// NewMySQLIndex creates an index backed by the search_documents table
+// Parameters:
+//   - db: Connection to a MySQL database with the search_documents table
+//
+// Returns:
+//   - *MySQLIndex: The initialized index
+func NewMySQLIndex(db *gorm.DB) *MySQLIndex {
+	return &MySQLIndex{
+		db: db,
+	}
+}
+
+// Index adds a document or replaces the document with the same type and ID
+func (index *MySQLIndex) Index(doc Document) error {
+	if err := ValidateFilters(doc.Fields); err != nil {
+		return err
+	}
+
+	fields := doc.Fields
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	row := documentRow{
+		DocType:   doc.Type,
+		DocID:     doc.ID,
+		Title:     doc.Title,
+		Body:      doc.Body,
+		Fields:    fields,
+		UpdatedAt: time.Now(),
+	}
+	return index.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "doc_type"}, {Name: "doc_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "body", "fields", "updated_at"}),
+	}).Create(&row).Error
+}
+
+// Delete removes a document
+func (index *MySQLIndex) Delete(docType string, id uint) error {
+	return index.db.Where("doc_type = ? AND doc_id = ?", docType, id).Delete(&documentRow{}).Error
+}
+
+// DeleteType removes every document of a type
+func (index *MySQLIndex) DeleteType(docType string) error {
+	return index.db.Where("doc_type = ?", docType).Delete(&documentRow{}).Error
+}
+
+// Search retrieves the documents matching a query, matches in the title weigh more than matches in the body
+// Parameters:
+//   - query: The words, types, filters and page of the search
+//
+// Returns:
+//   - *Result: The page of hits with the total number of matching documents
+//   - error: ErrInvalidFilter if a filter key is not usable, otherwise the database error that occurred
+func (index *MySQLIndex) Search(query Query) (*Result, error) {
+	if err := ValidateFilters(query.Filters); err != nil {
+		return nil, err
+	}
+
+	terms := Tokenize(query.Text)
+	result := &Result{Hits: []Hit{}}
+	if len(terms) == 0 {
+		return result, nil
+	}
+	text := strings.Join(terms, " ")
+
+	// Filters are applied in a stable order so identical searches produce identical statements
+	keys := make([]string, 0, len(query.Filters))
+	for key := range query.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	scope := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("MATCH(title, body) AGAINST (? IN NATURAL LANGUAGE MODE)", text)
+		if len(query.Types) > 0 {
+			db = db.Where("doc_type IN ?", query.Types)
+		}
+		for _, key := range keys {
+			db = db.Where("JSON_UNQUOTE(JSON_EXTRACT(fields, ?)) = ?", `$."`+key+`"`, query.Filters[key])
+		}
+		return db
+	}
+
+	if err := index.db.Model(&documentRow{}).Scopes(scope).Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+	if result.Total == 0 {
+		return result, nil
+	}
+
+	var rows []documentRow
+	db := index.db.Model(&documentRow{}).Scopes(scope).
+		Select("doc_type, doc_id, title, body, fields, "+
+			"MATCH(title) AGAINST (? IN NATURAL LANGUAGE MODE) * ? + MATCH(title, body) AGAINST (? IN NATURAL LANGUAGE MODE) AS score",
+			text, titleBoost, text).
+		Order("score DESC, doc_type ASC, doc_id ASC").
+		Offset(max(query.Offset, 0))
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		doc := Document{Type: row.DocType, ID: row.DocID, Title: row.Title, Body: row.Body, Fields: row.Fields}
+		result.Hits = append(result.Hits, Hit{
+			Type:       row.DocType,
+			ID:         row.DocID,
+			Title:      row.Title,
+			Fields:     row.Fields,
+			Score:      row.Score,
+			Highlights: highlights(doc, terms),
+		})
+	}
+	return result, nil
+}
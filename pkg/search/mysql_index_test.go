@@ -0,0 +1,79 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/search"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestMySQLIndexWrites(t *testing.T) {
+	// The FULLTEXT search itself needs MySQL, writes are checked against SQLite
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(`CREATE TABLE search_documents (
+		id integer PRIMARY KEY AUTOINCREMENT,
+		doc_type text NOT NULL,
+		doc_id integer NOT NULL,
+		title text NOT NULL,
+		body text NOT NULL,
+		fields text,
+		updated_at datetime,
+		UNIQUE (doc_type, doc_id)
+	)`).Error)
+	index := search.NewMySQLIndex(db)
+
+	count := func(where string, args ...any) int64 {
+		var total int64
+		require.NoError(t, db.Table("search_documents").Where(where, args...).Count(&total).Error)
+		return total
+	}
+
+	require.NoError(t, index.Index(search.Document{Type: "post", ID: 1, Title: "Hello", Body: "World", Fields: map[string]string{"status": "draft"}}))
+	require.NoError(t, index.Index(search.Document{Type: "post", ID: 1, Title: "Hello again", Body: "World"}))
+	require.NoError(t, index.Index(search.Document{Type: "post", ID: 2, Title: "Other", Body: ""}))
+	require.NoError(t, index.Index(search.Document{Type: "user", ID: 1, Title: "Jane", Body: "jane@example.com"}))
+	assert.Equal(t, int64(1), count("doc_type = ? AND doc_id = ? AND title = ?", "post", 1, "Hello again"))
+	assert.Equal(t, int64(3), count("1 = 1"))
+
+	assert.ErrorIs(t, index.Index(search.Document{Type: "post", ID: 3, Fields: map[string]string{"Bad": "1"}}), search.ErrInvalidFilter)
+
+	require.NoError(t, index.Delete("post", 2))
+	assert.Equal(t, int64(0), count("doc_type = ? AND doc_id = ?", "post", 2))
+
+	require.NoError(t, index.DeleteType("post"))
+	assert.Equal(t, int64(1), count("1 = 1"))
+}
+
+func TestMySQLIndexSearchStatements(t *testing.T) {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:secret@tcp(127.0.0.1:3306)/cms",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	var statements []string
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:capture", func(tx *gorm.DB) {
+		statements = append(statements, tx.Dialector.Explain(tx.Statement.SQL.String(), tx.Statement.Vars...))
+	}))
+
+	_, err = search.NewMySQLIndex(db).Search(search.Query{
+		Text:    "Cà phê!",
+		Types:   []string{"post", "page"},
+		Filters: map[string]string{"status": "published"},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, statements, 1, "the hits are not loaded when nothing matches")
+	assert.Equal(t, "SELECT count(*) FROM `search_documents` WHERE MATCH(title, body) AGAINST ('ca phe' IN NATURAL LANGUAGE MODE) "+
+		"AND doc_type IN ('post','page') AND JSON_UNQUOTE(JSON_EXTRACT(fields, '$.\"status\"')) = 'published'", statements[0])
+
+	_, err = search.NewMySQLIndex(db).Search(search.Query{Text: "go", Filters: map[string]string{"status') OR ('1": "x"}})
+	assert.ErrorIs(t, err, search.ErrInvalidFilter)
+}
@@ -0,0 +1,248 @@
+package search
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrInvalidFilter is returned when a filter key is not a lowercase identifier
+var ErrInvalidFilter = errors.New("search: invalid filter key")
+
+// titleBoost is the weight of a match in the title compared to a match in the body
+const titleBoost = 3
+
+// snippetLength is the maximum number of characters of the body shown around the first match
+const snippetLength = 200
+
+// filterKeyPattern matches the keys accepted by Fields and Query.Filters, e.g. "status" or "author_id"
+var filterKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// Document is one searchable record, identified by its type and the ID of the record
+type Document struct {
+	Type   string            // Kind of record, e.g. "post" or "user"
+	ID     uint              // ID of the record within its type
+	Title  string            // Matched with a higher weight than the body
+	Body   string            // Plain text, markup should be removed before indexing
+	Fields map[string]string // Values returned with the hits and matched exactly by the filters of a query
+}
+
+// Query describes a full-text search
+type Query struct {
+	Text    string            // Words to look for, a document matches when it contains any of them
+	Types   []string          // Types of documents to search, all types when empty
+	Filters map[string]string // Values the fields of a matching document must have
+	Offset  int
+	Limit   int
+}
+
+// Hit is a document matching a query
+type Hit struct {
+	Type       string            `json:"type"`
+	ID         uint              `json:"id"`
+	Title      string            `json:"title"`
+	Fields     map[string]string `json:"fields"`
+	Score      float64           `json:"score"`      // Relevance of the document, only comparable within the same search
+	Highlights map[string]string `json:"highlights"` // Escaped title and body snippet with the matched words wrapped in <mark>
+}
+
+// Result is a page of hits ordered by relevance
+type Result struct {
+	Total int64 `json:"total"` // Number of matching documents across all pages
+	Hits  []Hit `json:"hits"`
+}
+
+// Index abstracts the backend storing searchable documents
+type Index interface {
+	// Index adds a document or replaces the document with the same type and ID
+	Index(doc Document) error
+	// Delete removes a document. Deleting a missing document is not an error
+	Delete(docType string, id uint) error
+	// DeleteType removes every document of a type, used before the type is rebuilt
+	DeleteType(docType string) error
+	// Search retrieves the documents matching a query, most relevant first
+	Search(query Query) (*Result, error)
+}
+
+// ValidateFilters rejects filter keys that are not lowercase identifiers
+// Parameters:
+//   - filters: The fields or filters to check
+//
+// Returns:
+//   - error: ErrInvalidFilter if a key is not usable
+func ValidateFilters(filters map[string]string) error {
+	for key := range filters {
+		if !filterKeyPattern.MatchString(key) {
+			return ErrInvalidFilter
+		}
+	}
+	return nil
+}
+
+// Tokenize splits a text into the lowercase terms used for matching
+// Accents are removed so "cà phê" matches "ca phe", duplicated terms are kept
+// Parameters:
+//   - text: The text to split
+//
+// Returns:
+//   - []string: The terms in the order of the text
+func Tokenize(text string) []string {
+	words := splitWords(text)
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = w.term
+	}
+	return terms
+}
+
+// Highlight escapes a text and wraps the words matching any of the terms in <mark>
+// Long texts are cut to a snippet around the first match
+// Parameters:
+//   - text: The text to highlight, e.g. the body of a document
+//   - terms: The terms of the query, as returned by Tokenize
+//   - maxLength: Maximum number of characters kept, 0 keeps the whole text
+//
+// Returns:
+//   - string: The escaped highlighted text, empty when no word matches
+func Highlight(text string, terms []string, maxLength int) string {
+	wanted := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		wanted[term] = true
+	}
+
+	runes := []rune(text)
+	var matches []word
+	for _, w := range splitWords(text) {
+		if wanted[w.term] {
+			matches = append(matches, w)
+		}
+	}
+	if len(matches) == 0 {
+		return ""
+	}
+
+	// Start a little before the first match so the snippet has some context
+	start, end := 0, len(runes)
+	if maxLength > 0 && len(runes) > maxLength {
+		start = max(matches[0].start-maxLength/4, 0)
+		for start > 0 && !unicode.IsSpace(runes[start-1]) {
+			start--
+		}
+		end = min(start+maxLength, len(runes))
+	}
+
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("…")
+	}
+	position := start
+	for _, m := range matches {
+		if m.start < start || m.end > end {
+			continue
+		}
+		builder.WriteString(html.EscapeString(string(runes[position:m.start])))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(string(runes[m.start:m.end])))
+		builder.WriteString("</mark>")
+		position = m.end
+	}
+	builder.WriteString(html.EscapeString(string(runes[position:end])))
+	if end < len(runes) {
+		builder.WriteString("…")
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// PlainText removes the HTML tags of a text and collapses its whitespace, scripts and styles are dropped
+// Parameters:
+//   - markup: The text to clean, e.g. the body of a post
+//
+// Returns:
+//   - string: The visible text
+func PlainText(markup string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(markup))
+	var builder strings.Builder
+	skip := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if tokenizer.Err() != io.EOF {
+				return markup
+			}
+			return strings.Join(strings.Fields(builder.String()), " ")
+		case html.StartTagToken:
+			if name, _ := tokenizer.TagName(); string(name) == "script" || string(name) == "style" {
+				skip++
+			}
+			builder.WriteByte(' ')
+		case html.EndTagToken:
+			if name, _ := tokenizer.TagName(); (string(name) == "script" || string(name) == "style") && skip > 0 {
+				skip--
+			}
+			builder.WriteByte(' ')
+		case html.TextToken:
+			if skip == 0 {
+				builder.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+// highlights builds the highlighted title and body snippet of a hit
+func highlights(doc Document, terms []string) map[string]string {
+	result := map[string]string{}
+	if title := Highlight(doc.Title, terms, 0); title != "" {
+		result["title"] = title
+	}
+	if body := Highlight(doc.Body, terms, snippetLength); body != "" {
+		result["body"] = body
+	}
+	return result
+}
+
+// word is a run of letters and digits of a text with its position in runes
+type word struct {
+	start, end int
+	term       string
+}
+
+func splitWords(text string) []word {
+	var words []word
+	start := -1
+	runes := []rune(text)
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || unicode.Is(unicode.Mn, runes[i])) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			if term := fold(string(runes[start:i])); term != "" {
+				words = append(words, word{start: start, end: i, term: term})
+			}
+			start = -1
+		}
+	}
+	return words
+}
+
+// fold lowercases a word and removes its accents
+func fold(s string) string {
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case r == 'đ':
+			builder.WriteRune('d')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,47 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vfa-khuongdv/golang-cms/pkg/search"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"ca", "phe", "sua", "da", "2024"}, search.Tokenize("Cà phê sữa đá, 2024!"))
+	assert.Equal(t, []string{"john", "example", "com"}, search.Tokenize("john@example.com"))
+	assert.Empty(t, search.Tokenize(" -- "))
+}
+
+func TestHighlight(t *testing.T) {
+	t.Run("Marks every matching word and escapes the text", func(t *testing.T) {
+		highlighted := search.Highlight("Go <b>loves</b> gophers, go!", []string{"go"}, 0)
+		assert.Equal(t, "<mark>Go</mark> &lt;b&gt;loves&lt;/b&gt; gophers, <mark>go</mark>!", highlighted)
+	})
+
+	t.Run("Matches without accents", func(t *testing.T) {
+		assert.Equal(t, "Quán <mark>cà</mark> phê", search.Highlight("Quán cà phê", search.Tokenize("ca"), 0))
+	})
+
+	t.Run("Cuts a snippet around the first match", func(t *testing.T) {
+		text := "lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor needle incididunt ut labore"
+		snippet := search.Highlight(text, []string{"needle"}, 40)
+		assert.Contains(t, snippet, "<mark>needle</mark>")
+		assert.True(t, len([]rune(snippet)) < len(text))
+		assert.Equal(t, "…", string([]rune(snippet)[0]))
+	})
+
+	t.Run("No match", func(t *testing.T) {
+		assert.Empty(t, search.Highlight("Nothing here", []string{"missing"}, 0))
+	})
+}
+
+func TestPlainText(t *testing.T) {
+	assert.Equal(t, "Hello world !", search.PlainText("<p>Hello <strong>world</strong></p><script>alert(1)</script>!"))
+	assert.Equal(t, "plain text", search.PlainText("plain   text"))
+}
+
+func TestValidateFilters(t *testing.T) {
+	assert.NoError(t, search.ValidateFilters(map[string]string{"author_id": "1"}))
+	assert.ErrorIs(t, search.ValidateFilters(map[string]string{`x") OR 1=1`: "1"}), search.ErrInvalidFilter)
+}
@@ -0,0 +1,77 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs is the maximum number of URLs of a sitemap file, and of sitemap files of an index, set by the protocol
+const MaxURLs = 50000
+
+// ContentType is the content type of the encoded sitemaps and indexes
+const ContentType = "application/xml; charset=utf-8"
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL is a page of the website listed by a sitemap
+type URL struct {
+	Loc     string
+	LastMod time.Time // Left out when zero
+}
+
+// Sitemap is a sitemap file listed by an index
+type Sitemap struct {
+	Loc     string
+	LastMod time.Time // Left out when zero
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []locElement `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []locElement `xml:"sitemap"`
+}
+
+type locElement struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// EncodeURLSet encodes the URLs of a sitemap file, the caller splits the URLs at MaxURLs
+func EncodeURLSet(urls []URL) ([]byte, error) {
+	document := urlSet{XMLNS: namespace, URLs: make([]locElement, len(urls))}
+	for i, url := range urls {
+		document.URLs[i] = locElement{Loc: url.Loc, LastMod: formatLastMod(url.LastMod)}
+	}
+	return encode(document)
+}
+
+// EncodeIndex encodes a sitemap index listing sitemap files
+func EncodeIndex(sitemaps []Sitemap) ([]byte, error) {
+	document := sitemapIndex{XMLNS: namespace, Sitemaps: make([]locElement, len(sitemaps))}
+	for i, sitemap := range sitemaps {
+		document.Sitemaps[i] = locElement{Loc: sitemap.Loc, LastMod: formatLastMod(sitemap.LastMod)}
+	}
+	return encode(document)
+}
+
+// formatLastMod formats a time in the W3C Datetime format required by the protocol
+func formatLastMod(lastMod time.Time) string {
+	if lastMod.IsZero() {
+		return ""
+	}
+	return lastMod.UTC().Format(time.RFC3339)
+}
+
+func encode(document any) ([]byte, error) {
+	body, err := xml.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
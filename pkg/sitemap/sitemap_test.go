@@ -0,0 +1,34 @@
+package sitemap_test
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/sitemap"
+)
+
+func TestEncodeURLSet(t *testing.T) {
+	body, err := sitemap.EncodeURLSet([]sitemap.URL{
+		{Loc: "https://example.com/posts/a?x=1&y=2", LastMod: time.Date(2030, 1, 2, 10, 4, 5, 0, time.FixedZone("ICT", 7*60*60))},
+		{Loc: "https://example.com/about"},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, string(body), `<url><loc>https://example.com/posts/a?x=1&amp;y=2</loc><lastmod>2030-01-02T03:04:05Z</lastmod></url>`)
+	assert.Contains(t, string(body), `<url><loc>https://example.com/about</loc></url>`)
+	assert.NoError(t, xml.Unmarshal(body, new(struct{})))
+}
+
+func TestEncodeIndex(t *testing.T) {
+	body, err := sitemap.EncodeIndex([]sitemap.Sitemap{
+		{Loc: "https://example.com/sitemaps/posts-1.xml", LastMod: time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, string(body), `<sitemap><loc>https://example.com/sitemaps/posts-1.xml</loc><lastmod>2030-01-02T03:04:05Z</lastmod></sitemap>`)
+}
@@ -0,0 +1,77 @@
+package storage_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+)
+
+func TestLocalStorage_PutGetDelete(t *testing.T) {
+	root := t.TempDir()
+	s := storage.NewLocalStorage(root, "http://localhost:3000/storage/")
+
+	err := s.Put("avatars/1/avatar.jpg", strings.NewReader("image-data"), "image/jpeg")
+	require.NoError(t, err)
+
+	// The file is written below the root directory
+	content, err := os.ReadFile(filepath.Join(root, "avatars", "1", "avatar.jpg"))
+	require.NoError(t, err)
+	assert.Equal(t, "image-data", string(content))
+
+	reader, err := s.Get("avatars/1/avatar.jpg")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	_ = reader.Close()
+	assert.Equal(t, "image-data", string(data))
+
+	assert.Equal(t, "http://localhost:3000/storage/avatars/1/avatar.jpg", s.URL("avatars/1/avatar.jpg"))
+
+	require.NoError(t, s.Delete("avatars/1/avatar.jpg"))
+	_, err = s.Get("avatars/1/avatar.jpg")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	// Deleting a missing object is not an error
+	assert.NoError(t, s.Delete("avatars/1/avatar.jpg"))
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	s := storage.NewLocalStorage(t.TempDir(), "http://localhost")
+
+	assert.ErrorIs(t, s.Put("../outside.txt", strings.NewReader("x"), "text/plain"), storage.ErrInvalidKey)
+	_, err := s.Get("a/../../b")
+	assert.ErrorIs(t, err, storage.ErrInvalidKey)
+	assert.ErrorIs(t, s.Delete(""), storage.ErrInvalidKey)
+	assert.Equal(t, "", s.URL("/"))
+}
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+		err      error
+	}{
+		{key: "avatars/1/a.jpg", expected: "avatars/1/a.jpg"},
+		{key: "/avatars//1/./a.jpg", expected: "avatars/1/a.jpg"},
+		{key: "avatars\\1\\a.jpg", expected: "avatars/1/a.jpg"},
+		{key: "..\\secret", err: storage.ErrInvalidKey},
+		{key: "", err: storage.ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			key, err := storage.CleanKey(tt.key)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, key)
+		})
+	}
+}
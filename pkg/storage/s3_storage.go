@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config holds the settings of an S3-compatible object store (AWS S3, MinIO, DigitalOcean Spaces, ...)
+type S3Config struct {
+	Endpoint     string // Base URL of the service, e.g. "https://s3.ap-southeast-1.amazonaws.com" or "http://localhost:9000"
+	Region       string // Region used for request signing, e.g. "us-east-1"
+	Bucket       string // Bucket objects are stored in
+	AccessKey    string
+	SecretKey    string
+	PublicURL    string // Optional URL prefix used to build public object URLs (e.g. a CDN). Defaults to the bucket URL
+	UsePathStyle bool   // Address the bucket as <endpoint>/<bucket> instead of <bucket>.<endpoint-host>
+}
+
+// S3Storage stores objects in an S3-compatible bucket using the REST API and Signature Version 4
+type S3Storage struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Storage creates a storage backend for an S3-compatible object store
+// Parameters:
+//   - config: Connection settings of the object store
+//   - client: HTTP client used to call the service. http.DefaultClient is used when nil
+//
+// Returns:
+//   - *S3Storage: The initialized S3 storage backend
+//   - error: Error if the endpoint cannot be parsed or the bucket is missing
+func NewS3Storage(config S3Config, client *http.Client) (*S3Storage, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket must not be empty")
+	}
+	if _, err := url.Parse(config.Endpoint); err != nil || config.Endpoint == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", config.Endpoint)
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &S3Storage{
+		config: config,
+		client: client,
+		now:    time.Now,
+	}, nil
+}
+
+// bucketURL returns the base URL of the bucket, honoring the addressing style
+func (s *S3Storage) bucketURL() string {
+	endpoint, _ := url.Parse(strings.TrimRight(s.config.Endpoint, "/"))
+	if s.config.UsePathStyle {
+		return endpoint.String() + "/" + uriEncode(s.config.Bucket, true)
+	}
+	endpoint.Host = s.config.Bucket + "." + endpoint.Host
+	return endpoint.String()
+}
+
+func (s *S3Storage) objectURL(key string) (string, error) {
+	cleaned, err := CleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return s.bucketURL() + "/" + uriEncode(cleaned, false), nil
+}
+
+// do builds, signs and executes a request against the object identified by key
+func (s *S3Storage) do(method, key string, body []byte, contentType string) (*http.Response, error) {
+	target, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.ContentLength = int64(len(body))
+
+	signV4(req, sha256Hex(body), s.config.AccessKey, s.config.SecretKey, s.config.Region, s.now())
+
+	return s.client.Do(req)
+}
+
+// Put uploads body to the bucket under key
+func (s *S3Storage) Put(key string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read object body: %w", err)
+	}
+
+	res, err := s.do(http.MethodPut, key, data, contentType)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return responseError(http.MethodPut, key, res)
+	}
+	return nil
+}
+
+// Get downloads the object stored under key
+func (s *S3Storage) Get(key string) (io.ReadCloser, error) {
+	res, err := s.do(http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		defer res.Body.Close()
+		return nil, responseError(http.MethodGet, key, res)
+	}
+	return res.Body, nil
+}
+
+// Delete removes the object stored under key
+func (s *S3Storage) Delete(key string) error {
+	res, err := s.do(http.MethodDelete, key, nil, "")
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 && res.StatusCode != http.StatusNotFound {
+		return responseError(http.MethodDelete, key, res)
+	}
+	return nil
+}
+
+// URL returns the public URL of the object stored under key
+func (s *S3Storage) URL(key string) string {
+	if s.config.PublicURL != "" {
+		cleaned, err := CleanKey(key)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimRight(s.config.PublicURL, "/") + "/" + uriEncode(cleaned, false)
+	}
+
+	target, err := s.objectURL(key)
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+func responseError(method, key string, res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3 %s %s failed with status %d: %s", method, key, res.StatusCode, strings.TrimSpace(string(body)))
+}
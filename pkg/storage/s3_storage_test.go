@@ -0,0 +1,141 @@
+package storage_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/storage"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible service using path-style addressing
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	types   map[string]string
+	auth    []string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: map[string]string{}, types: map[string]string{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") ||
+		r.Header.Get("X-Amz-Content-Sha256") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = string(body)
+		f.types[r.URL.Path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Storage_PutGetDelete(t *testing.T) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s, err := storage.NewS3Storage(storage.S3Config{
+		Endpoint:     server.URL,
+		Bucket:       "media",
+		AccessKey:    "access",
+		SecretKey:    "secret",
+		UsePathStyle: true,
+	}, server.Client())
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put("avatars/1/avatar.png", strings.NewReader("png-data"), "image/png"))
+	assert.Equal(t, "png-data", fake.objects["/media/avatars/1/avatar.png"])
+	assert.Equal(t, "image/png", fake.types["/media/avatars/1/avatar.png"])
+
+	reader, err := s.Get("avatars/1/avatar.png")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	_ = reader.Close()
+	assert.Equal(t, "png-data", string(data))
+
+	require.NoError(t, s.Delete("avatars/1/avatar.png"))
+	_, err = s.Get("avatars/1/avatar.png")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	assert.Equal(t, server.URL+"/media/avatars/1/avatar.png", s.URL("avatars/1/avatar.png"))
+	assert.Len(t, fake.auth, 4)
+}
+
+func TestS3Storage_ErrorResponse(t *testing.T) {
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s, err := storage.NewS3Storage(storage.S3Config{
+		Endpoint:     server.URL,
+		Bucket:       "media",
+		AccessKey:    "wrong",
+		SecretKey:    "secret",
+		UsePathStyle: true,
+	}, server.Client())
+	require.NoError(t, err)
+
+	err = s.Put("a.txt", strings.NewReader("x"), "text/plain")
+	assert.ErrorContains(t, err, "status 403")
+
+	_, err = s.Get("a.txt")
+	assert.ErrorContains(t, err, "status 403")
+
+	err = s.Delete("a.txt")
+	assert.ErrorContains(t, err, "status 403")
+}
+
+func TestS3Storage_URL(t *testing.T) {
+	t.Run("virtual hosted style", func(t *testing.T) {
+		s, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint: "https://s3.ap-southeast-1.amazonaws.com",
+			Bucket:   "media",
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "https://media.s3.ap-southeast-1.amazonaws.com/avatars/a%20b.jpg", s.URL("avatars/a b.jpg"))
+	})
+
+	t.Run("public url", func(t *testing.T) {
+		s, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:  "https://s3.amazonaws.com",
+			Bucket:    "media",
+			PublicURL: "https://cdn.example.com/",
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "https://cdn.example.com/avatars/1.jpg", s.URL("avatars/1.jpg"))
+		assert.Equal(t, "", s.URL("../1.jpg"))
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		_, err := storage.NewS3Storage(storage.S3Config{Endpoint: "https://s3.amazonaws.com"}, nil)
+		assert.Error(t, err)
+
+		_, err = storage.NewS3Storage(storage.S3Config{Bucket: "media"}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+// ErrNotFound is returned when the requested object does not exist in the storage backend
+var ErrNotFound = errors.New("storage: object not found")
+
+// ErrInvalidKey is returned when an object key is empty or tries to escape the storage root
+var ErrInvalidKey = errors.New("storage: invalid object key")
+
+// Storage abstracts the backend used to persist uploaded files
+type Storage interface {
+	// Put stores the content of body under key, replacing any existing object
+	Put(key string, body io.Reader, contentType string) error
+	// Get opens the object stored under key. The caller must close the returned reader
+	Get(key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key. Deleting a missing object is not an error
+	Delete(key string) error
+	// URL returns the public URL of the object stored under key
+	URL(key string) string
+}
+
+// CleanKey normalizes an object key and rejects keys that are empty or point outside the storage root
+// Parameters:
+//   - key: The object key to normalize (e.g. "avatars/1/original.jpg")
+//
+// Returns:
+//   - string: The normalized key without leading slashes
+//   - error: ErrInvalidKey if the key is not usable
+func CleanKey(key string) (string, error) {
+	normalized := strings.ReplaceAll(key, "\\", "/")
+	for _, part := range strings.Split(normalized, "/") {
+		if part == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+
+	cleaned := strings.TrimPrefix(path.Clean("/"+normalized), "/")
+	if cleaned == "" {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
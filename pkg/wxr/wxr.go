@@ -0,0 +1,407 @@
+package wxr
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of the items of an export
+const (
+	ItemTypePost       = "post"
+	ItemTypePage       = "page"
+	ItemTypeAttachment = "attachment"
+)
+
+// Taxonomies of the terms of an item
+const (
+	DomainCategory = "category"
+	DomainTag      = "post_tag"
+)
+
+// contentNamespace is the namespace of <content:encoded>, the excerpt uses the same local name in a namespace of WordPress
+const contentNamespace = "http://purl.org/rss/1.0/modules/content/"
+
+// dateLayout is the layout of the dates of an export, e.g. "2021-03-04 05:06:07"
+const dateLayout = "2006-01-02 15:04:05"
+
+// Export is the content of a WordPress eXtended RSS file, the format of Tools > Export in WordPress
+type Export struct {
+	Title      string
+	SiteURL    string // Identifies the site the export comes from, e.g. "https://blog.example.com"
+	Authors    []Author
+	Categories []Category
+	Tags       []Tag
+	Items      []Item // Posts, pages and attachments, in the order of the file
+}
+
+// Author is a user of the exported site
+type Author struct {
+	ID          int
+	Login       string // Referenced by the Creator of items
+	Email       string
+	DisplayName string
+	FirstName   string
+	LastName    string
+}
+
+// Category is a category of posts, categories are nested through the slug of their parent
+type Category struct {
+	TermID      int
+	Slug        string
+	Parent      string // Slug of the parent category, empty for a root category
+	Name        string
+	Description string
+}
+
+// Tag is a tag of posts
+type Tag struct {
+	TermID      int
+	Slug        string
+	Name        string
+	Description string
+}
+
+// Term is a category or tag assigned to an item
+type Term struct {
+	Domain string // DomainCategory or DomainTag, other taxonomies are kept as exported
+	Slug   string
+	Name   string
+}
+
+// Item is a post, page or attachment
+type Item struct {
+	ID            int
+	Type          string // ItemTypePost, ItemTypePage, ItemTypeAttachment or a custom post type
+	Title         string
+	Link          string
+	Creator       string // Login of the author
+	Content       string // HTML where paragraphs are separated by blank lines, see Autop
+	Excerpt       string
+	Slug          string
+	Status        string // e.g. "publish", "draft", "pending", "future", "private", "trash"
+	ParentID      int    // Parent page, or the post an attachment was uploaded to
+	MenuOrder     int
+	Date          time.Time // Publication time in UTC, the scheduled time of future posts
+	Modified      time.Time // Zero when the export does not carry it
+	AttachmentURL string    // Attachments only
+	Terms         []Term
+	Meta          map[string]string // Custom fields, e.g. "_wp_attachment_image_alt"
+	Comments      []Comment
+}
+
+// Comment is a comment, pingback or trackback of an item
+type Comment struct {
+	ID          int
+	Author      string
+	AuthorEmail string
+	AuthorURL   string
+	AuthorIP    string
+	Date        time.Time
+	Content     string
+	Approved    string // "1", "0", "spam" or "trash"
+	Type        string // Empty or "comment" for comments, "pingback" or "trackback" otherwise
+	ParentID    int
+	UserID      int // Author ID of the commenter when they were signed in, 0 for guests
+}
+
+// The elements of WordPress are matched on their local name: the namespace changes with the version of the format
+
+type rssElement struct {
+	Channel channelElement `xml:"channel"`
+}
+
+type channelElement struct {
+	Title       string            `xml:"title"`
+	Link        string            `xml:"link"`
+	BaseSiteURL string            `xml:"base_site_url"`
+	Authors     []authorElement   `xml:"author"`
+	Categories  []categoryElement `xml:"category"`
+	Tags        []tagElement      `xml:"tag"`
+	Items       []itemElement     `xml:"item"`
+}
+
+type authorElement struct {
+	ID          string `xml:"author_id"`
+	Login       string `xml:"author_login"`
+	Email       string `xml:"author_email"`
+	DisplayName string `xml:"author_display_name"`
+	FirstName   string `xml:"author_first_name"`
+	LastName    string `xml:"author_last_name"`
+}
+
+type categoryElement struct {
+	TermID      string `xml:"term_id"`
+	Nicename    string `xml:"category_nicename"`
+	Parent      string `xml:"category_parent"`
+	Name        string `xml:"cat_name"`
+	Description string `xml:"category_description"`
+}
+
+type tagElement struct {
+	TermID      string `xml:"term_id"`
+	Slug        string `xml:"tag_slug"`
+	Name        string `xml:"tag_name"`
+	Description string `xml:"tag_description"`
+}
+
+type encodedElement struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type termElement struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+type metaElement struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+type itemElement struct {
+	Title         string           `xml:"title"`
+	Link          string           `xml:"link"`
+	Creator       string           `xml:"creator"`
+	Encoded       []encodedElement `xml:"encoded"` // <content:encoded> and <excerpt:encoded>
+	ID            string           `xml:"post_id"`
+	Date          string           `xml:"post_date"`
+	DateGMT       string           `xml:"post_date_gmt"`
+	ModifiedGMT   string           `xml:"post_modified_gmt"`
+	Name          string           `xml:"post_name"`
+	Status        string           `xml:"status"`
+	Parent        string           `xml:"post_parent"`
+	MenuOrder     string           `xml:"menu_order"`
+	Type          string           `xml:"post_type"`
+	AttachmentURL string           `xml:"attachment_url"`
+	Terms         []termElement    `xml:"category"`
+	Meta          []metaElement    `xml:"postmeta"`
+	Comments      []commentElement `xml:"comment"`
+}
+
+type commentElement struct {
+	ID          string `xml:"comment_id"`
+	Author      string `xml:"comment_author"`
+	AuthorEmail string `xml:"comment_author_email"`
+	AuthorURL   string `xml:"comment_author_url"`
+	AuthorIP    string `xml:"comment_author_IP"`
+	Date        string `xml:"comment_date"`
+	DateGMT     string `xml:"comment_date_gmt"`
+	Content     string `xml:"comment_content"`
+	Approved    string `xml:"comment_approved"`
+	Type        string `xml:"comment_type"`
+	Parent      string `xml:"comment_parent"`
+	UserID      string `xml:"comment_user_id"`
+}
+
+// Parse reads a WordPress export
+// Parameters:
+//   - reader: The WXR file, version 1.0 to 1.2
+//
+// Returns:
+//   - *Export: The content of the export, text values are trimmed
+//   - error: The XML error when the file cannot be read
+func Parse(reader io.Reader) (*Export, error) {
+	var document rssElement
+	decoder := xml.NewDecoder(reader)
+	// Exports declare UTF-8, older sites sometimes declare their legacy charset for UTF-8 content
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("read WXR export: %w", err)
+	}
+
+	channel := document.Channel
+	export := &Export{
+		Title:   strings.TrimSpace(channel.Title),
+		SiteURL: strings.TrimRight(strings.TrimSpace(channel.BaseSiteURL), "/"),
+	}
+	if export.SiteURL == "" {
+		export.SiteURL = strings.TrimRight(strings.TrimSpace(channel.Link), "/")
+	}
+
+	for _, element := range channel.Authors {
+		export.Authors = append(export.Authors, Author{
+			ID:          toInt(element.ID),
+			Login:       strings.TrimSpace(element.Login),
+			Email:       strings.TrimSpace(element.Email),
+			DisplayName: strings.TrimSpace(element.DisplayName),
+			FirstName:   strings.TrimSpace(element.FirstName),
+			LastName:    strings.TrimSpace(element.LastName),
+		})
+	}
+	for _, element := range channel.Categories {
+		// A plain RSS <category> of the channel has no nicename
+		if strings.TrimSpace(element.Nicename) == "" {
+			continue
+		}
+		export.Categories = append(export.Categories, Category{
+			TermID:      toInt(element.TermID),
+			Slug:        strings.TrimSpace(element.Nicename),
+			Parent:      strings.TrimSpace(element.Parent),
+			Name:        strings.TrimSpace(element.Name),
+			Description: strings.TrimSpace(element.Description),
+		})
+	}
+	for _, element := range channel.Tags {
+		export.Tags = append(export.Tags, Tag{
+			TermID:      toInt(element.TermID),
+			Slug:        strings.TrimSpace(element.Slug),
+			Name:        strings.TrimSpace(element.Name),
+			Description: strings.TrimSpace(element.Description),
+		})
+	}
+	for _, element := range channel.Items {
+		export.Items = append(export.Items, toItem(element))
+	}
+	return export, nil
+}
+
+func toItem(element itemElement) Item {
+	item := Item{
+		ID:            toInt(element.ID),
+		Type:          strings.TrimSpace(element.Type),
+		Title:         strings.TrimSpace(element.Title),
+		Link:          strings.TrimSpace(element.Link),
+		Creator:       strings.TrimSpace(element.Creator),
+		Slug:          strings.TrimSpace(element.Name),
+		Status:        strings.TrimSpace(element.Status),
+		ParentID:      toInt(element.Parent),
+		MenuOrder:     toInt(element.MenuOrder),
+		Date:          toTime(element.DateGMT, element.Date),
+		Modified:      toTime(element.ModifiedGMT, ""),
+		AttachmentURL: strings.TrimSpace(element.AttachmentURL),
+		Meta:          map[string]string{},
+	}
+	for _, encoded := range element.Encoded {
+		if encoded.XMLName.Space == contentNamespace {
+			item.Content = strings.TrimSpace(encoded.Value)
+		} else {
+			item.Excerpt = strings.TrimSpace(encoded.Value)
+		}
+	}
+	for _, term := range element.Terms {
+		item.Terms = append(item.Terms, Term{
+			Domain: term.Domain,
+			Slug:   strings.TrimSpace(term.Nicename),
+			Name:   strings.TrimSpace(term.Name),
+		})
+	}
+	for _, meta := range element.Meta {
+		item.Meta[strings.TrimSpace(meta.Key)] = meta.Value
+	}
+	for _, comment := range element.Comments {
+		item.Comments = append(item.Comments, Comment{
+			ID:          toInt(comment.ID),
+			Author:      strings.TrimSpace(comment.Author),
+			AuthorEmail: strings.TrimSpace(comment.AuthorEmail),
+			AuthorURL:   strings.TrimSpace(comment.AuthorURL),
+			AuthorIP:    strings.TrimSpace(comment.AuthorIP),
+			Date:        toTime(comment.DateGMT, comment.Date),
+			Content:     strings.TrimSpace(comment.Content),
+			Approved:    strings.TrimSpace(comment.Approved),
+			Type:        strings.TrimSpace(comment.Type),
+			ParentID:    toInt(comment.Parent),
+			UserID:      toInt(comment.UserID),
+		})
+	}
+	return item
+}
+
+// TermSlugs returns the slugs of the terms of an item in a taxonomy
+func (item Item) TermSlugs(domain string) []string {
+	var slugs []string
+	for _, term := range item.Terms {
+		if term.Domain == domain && term.Slug != "" {
+			slugs = append(slugs, term.Slug)
+		}
+	}
+	return slugs
+}
+
+// toInt reads a number of the export, invalid and empty values are 0
+func toInt(value string) int {
+	number, _ := strconv.Atoi(strings.TrimSpace(value))
+	return number
+}
+
+// toTime reads a date of the export in UTC, the local date is used when the UTC one is empty, e.g. for drafts
+func toTime(gmt, local string) time.Time {
+	for _, value := range []string{gmt, local} {
+		value = strings.TrimSpace(value)
+		if value == "" || strings.HasPrefix(value, "0000-00-00") {
+			continue
+		}
+		if parsed, err := time.Parse(dateLayout, value); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+// blockTags are the tags Autop leaves unwrapped when a chunk of content starts with them
+var blockTags = []string{
+	"address", "article", "aside", "blockquote", "dd", "details", "div", "dl", "dt", "figure", "footer", "form",
+	"h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "ol", "p", "pre", "section", "table", "ul",
+}
+
+// Autop converts the content of the classic WordPress editor to HTML
+// WordPress stores paragraphs as text separated by blank lines and adds the <p> and <br> tags when it shows them,
+// content of the block editor already holds its tags and is returned unchanged
+func Autop(content string) string {
+	content = strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+	if content == "" || strings.Contains(content, "<!-- wp:") {
+		return content
+	}
+
+	var chunks []string
+	for _, chunk := range splitParagraphs(content) {
+		if startsWithBlock(chunk) {
+			chunks = append(chunks, chunk)
+			continue
+		}
+		chunks = append(chunks, "<p>"+strings.ReplaceAll(chunk, "\n", "<br>\n")+"</p>")
+	}
+	return strings.Join(chunks, "\n")
+}
+
+// splitParagraphs splits text on blank lines, lines holding only spaces are blank
+func splitParagraphs(content string) []string {
+	var chunks []string
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			chunks = append(chunks, strings.Join(lines, "\n"))
+			lines = nil
+		}
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	flush()
+	return chunks
+}
+
+// startsWithBlock reports whether a chunk opens with a block-level tag such as <h2> or <ul class="...">
+func startsWithBlock(chunk string) bool {
+	if !strings.HasPrefix(chunk, "<") {
+		return false
+	}
+	name := strings.ToLower(strings.TrimPrefix(chunk, "<"))
+	end := strings.IndexAny(name, " \t\n>/")
+	if end < 0 {
+		return false
+	}
+	return slices.Contains(blockTags, name[:end])
+}
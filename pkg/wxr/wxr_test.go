@@ -0,0 +1,155 @@
+package wxr_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vfa-khuongdv/golang-cms/pkg/wxr"
+)
+
+const sampleExport = `<?xml version="1.0" encoding="UTF-8" ?>
+<rss version="2.0"
+	xmlns:excerpt="http://wordpress.org/export/1.2/excerpt/"
+	xmlns:content="http://purl.org/rss/1.0/modules/content/"
+	xmlns:dc="http://purl.org/dc/elements/1.1/"
+	xmlns:wp="http://wordpress.org/export/1.2/">
+<channel>
+	<title>Old Blog</title>
+	<link>https://blog.example.com</link>
+	<wp:wxr_version>1.2</wp:wxr_version>
+	<wp:base_site_url>https://blog.example.com/</wp:base_site_url>
+	<wp:author><wp:author_id>2</wp:author_id><wp:author_login><![CDATA[jane]]></wp:author_login><wp:author_email><![CDATA[jane@example.com]]></wp:author_email><wp:author_display_name><![CDATA[Jane Roe]]></wp:author_display_name><wp:author_first_name><![CDATA[Jane]]></wp:author_first_name><wp:author_last_name><![CDATA[Roe]]></wp:author_last_name></wp:author>
+	<wp:category><wp:term_id>3</wp:term_id><wp:category_nicename><![CDATA[news]]></wp:category_nicename><wp:category_parent><![CDATA[]]></wp:category_parent><wp:cat_name><![CDATA[News]]></wp:cat_name></wp:category>
+	<wp:category><wp:term_id>4</wp:term_id><wp:category_nicename><![CDATA[local]]></wp:category_nicename><wp:category_parent><![CDATA[news]]></wp:category_parent><wp:cat_name><![CDATA[Local news]]></wp:cat_name></wp:category>
+	<wp:tag><wp:term_id>5</wp:term_id><wp:tag_slug><![CDATA[go]]></wp:tag_slug><wp:tag_name><![CDATA[Go]]></wp:tag_name></wp:tag>
+	<item>
+		<title>Hello &amp; welcome</title>
+		<link>https://blog.example.com/2021/03/hello/</link>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[First line
+second line
+
+<h2>Next</h2>]]></content:encoded>
+		<excerpt:encoded><![CDATA[Short]]></excerpt:encoded>
+		<wp:post_id>10</wp:post_id>
+		<wp:post_date><![CDATA[2021-03-04 12:06:07]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[2021-03-04 05:06:07]]></wp:post_date_gmt>
+		<wp:comment_status><![CDATA[open]]></wp:comment_status>
+		<wp:post_name><![CDATA[hello]]></wp:post_name>
+		<wp:status><![CDATA[publish]]></wp:status>
+		<wp:post_parent>0</wp:post_parent>
+		<wp:menu_order>0</wp:menu_order>
+		<wp:post_type><![CDATA[post]]></wp:post_type>
+		<category domain="category" nicename="local"><![CDATA[Local news]]></category>
+		<category domain="post_tag" nicename="go"><![CDATA[Go]]></category>
+		<wp:postmeta><wp:meta_key><![CDATA[_edit_last]]></wp:meta_key><wp:meta_value><![CDATA[2]]></wp:meta_value></wp:postmeta>
+		<wp:comment>
+			<wp:comment_id>7</wp:comment_id>
+			<wp:comment_author><![CDATA[Guest]]></wp:comment_author>
+			<wp:comment_author_email><![CDATA[guest@example.com]]></wp:comment_author_email>
+			<wp:comment_author_IP><![CDATA[192.0.2.1]]></wp:comment_author_IP>
+			<wp:comment_date><![CDATA[2021-03-05 08:00:00]]></wp:comment_date>
+			<wp:comment_date_gmt><![CDATA[2021-03-05 01:00:00]]></wp:comment_date_gmt>
+			<wp:comment_content><![CDATA[Nice post]]></wp:comment_content>
+			<wp:comment_approved><![CDATA[1]]></wp:comment_approved>
+			<wp:comment_type><![CDATA[comment]]></wp:comment_type>
+			<wp:comment_parent>0</wp:comment_parent>
+			<wp:comment_user_id>0</wp:comment_user_id>
+		</wp:comment>
+	</item>
+	<item>
+		<title>Draft</title>
+		<dc:creator><![CDATA[jane]]></dc:creator>
+		<content:encoded><![CDATA[]]></content:encoded>
+		<excerpt:encoded><![CDATA[]]></excerpt:encoded>
+		<wp:post_id>11</wp:post_id>
+		<wp:post_date><![CDATA[2021-04-01 09:00:00]]></wp:post_date>
+		<wp:post_date_gmt><![CDATA[0000-00-00 00:00:00]]></wp:post_date_gmt>
+		<wp:status><![CDATA[draft]]></wp:status>
+		<wp:post_type><![CDATA[page]]></wp:post_type>
+	</item>
+	<item>
+		<title>logo</title>
+		<wp:post_id>12</wp:post_id>
+		<wp:post_parent>10</wp:post_parent>
+		<wp:post_type><![CDATA[attachment]]></wp:post_type>
+		<wp:attachment_url><![CDATA[https://blog.example.com/wp-content/uploads/2021/03/logo.png]]></wp:attachment_url>
+		<wp:postmeta><wp:meta_key><![CDATA[_wp_attachment_image_alt]]></wp:meta_key><wp:meta_value><![CDATA[Our logo]]></wp:meta_value></wp:postmeta>
+	</item>
+</channel>
+</rss>`
+
+func TestParse(t *testing.T) {
+	export, err := wxr.Parse(strings.NewReader(sampleExport))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Old Blog", export.Title)
+	assert.Equal(t, "https://blog.example.com", export.SiteURL)
+	assert.Equal(t, []wxr.Author{{ID: 2, Login: "jane", Email: "jane@example.com", DisplayName: "Jane Roe", FirstName: "Jane", LastName: "Roe"}}, export.Authors)
+	assert.Equal(t, []wxr.Category{
+		{TermID: 3, Slug: "news", Name: "News"},
+		{TermID: 4, Slug: "local", Parent: "news", Name: "Local news"},
+	}, export.Categories)
+	assert.Equal(t, []wxr.Tag{{TermID: 5, Slug: "go", Name: "Go"}}, export.Tags)
+	require.Len(t, export.Items, 3)
+
+	post := export.Items[0]
+	assert.Equal(t, 10, post.ID)
+	assert.Equal(t, wxr.ItemTypePost, post.Type)
+	assert.Equal(t, "Hello & welcome", post.Title)
+	assert.Equal(t, "jane", post.Creator)
+	assert.Equal(t, "First line\nsecond line\n\n<h2>Next</h2>", post.Content)
+	assert.Equal(t, "Short", post.Excerpt)
+	assert.Equal(t, "publish", post.Status)
+	assert.Equal(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), post.Date, "the UTC date is preferred")
+	assert.Equal(t, []string{"local"}, post.TermSlugs(wxr.DomainCategory))
+	assert.Equal(t, []string{"go"}, post.TermSlugs(wxr.DomainTag))
+	assert.Equal(t, "2", post.Meta["_edit_last"])
+	assert.Equal(t, []wxr.Comment{{
+		ID:          7,
+		Author:      "Guest",
+		AuthorEmail: "guest@example.com",
+		AuthorIP:    "192.0.2.1",
+		Date:        time.Date(2021, 3, 5, 1, 0, 0, 0, time.UTC),
+		Content:     "Nice post",
+		Approved:    "1",
+		Type:        "comment",
+	}}, post.Comments)
+
+	draft := export.Items[1]
+	assert.Equal(t, wxr.ItemTypePage, draft.Type)
+	assert.Equal(t, time.Date(2021, 4, 1, 9, 0, 0, 0, time.UTC), draft.Date, "drafts fall back to the local date")
+
+	attachment := export.Items[2]
+	assert.Equal(t, 10, attachment.ParentID)
+	assert.Equal(t, "https://blog.example.com/wp-content/uploads/2021/03/logo.png", attachment.AttachmentURL)
+	assert.Equal(t, "Our logo", attachment.Meta["_wp_attachment_image_alt"])
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := wxr.Parse(strings.NewReader("<rss><channel>"))
+	assert.Error(t, err)
+}
+
+func TestAutop(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"Paragraphs and line breaks", "One\ntwo\n\n  \nThree", "<p>One<br>\ntwo</p>\n<p>Three</p>"},
+		{"Block tags left alone", "Intro\r\n\r\n<ul>\n<li>a</li>\n</ul>\n\n<h2 id=\"x\">Title</h2>", "<p>Intro</p>\n<ul>\n<li>a</li>\n</ul>\n<h2 id=\"x\">Title</h2>"},
+		{"Inline tags wrapped", "<strong>Bold</strong> text", "<p><strong>Bold</strong> text</p>"},
+		{"Block editor content unchanged", "<!-- wp:paragraph -->\n<p>Hi</p>\n<!-- /wp:paragraph -->", "<!-- wp:paragraph -->\n<p>Hi</p>\n<!-- /wp:paragraph -->"},
+		{"Empty", "  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, wxr.Autop(tt.content))
+		})
+	}
+}
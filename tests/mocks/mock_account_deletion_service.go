@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockAccountDeletionService struct {
+	mock.Mock
+}
+
+func (m *MockAccountDeletionService) RequestDeletion(userID uint, password, ipAddress string) (*models.User, error) {
+	args := m.Called(userID, password, ipAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAccountDeletionService) CancelDeletion(userID uint, ipAddress string) (*models.User, error) {
+	args := m.Called(userID, ipAddress)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockAccountDeletionService) ProcessDue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockAttributeRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttributeRepository) GetAll() ([]models.AttributeDefinition, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeRepository) GetByID(id uint) (*models.AttributeDefinition, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeRepository) Create(definition *models.AttributeDefinition) error {
+	args := m.Called(definition)
+	return args.Error(0)
+}
+
+func (m *MockAttributeRepository) Update(definition *models.AttributeDefinition) error {
+	args := m.Called(definition)
+	return args.Error(0)
+}
+
+func (m *MockAttributeRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
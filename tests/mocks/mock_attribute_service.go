@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockAttributeService struct {
+	mock.Mock
+}
+
+func (m *MockAttributeService) GetDefinitions() ([]models.AttributeDefinition, error) {
+	args := m.Called()
+	return args.Get(0).([]models.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeService) GetDefinition(id uint) (*models.AttributeDefinition, error) {
+	args := m.Called(id)
+	return args.Get(0).(*models.AttributeDefinition), args.Error(1)
+}
+
+func (m *MockAttributeService) CreateDefinition(definition *models.AttributeDefinition) error {
+	args := m.Called(definition)
+	return args.Error(0)
+}
+
+func (m *MockAttributeService) UpdateDefinition(definition *models.AttributeDefinition) error {
+	args := m.Called(definition)
+	return args.Error(0)
+}
+
+func (m *MockAttributeService) DeleteDefinition(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAttributeService) SetUserAttributes(userID uint, values map[string]any) (map[string]any, error) {
+	args := m.Called(userID, values)
+	return args.Get(0).(map[string]any), args.Error(1)
+}
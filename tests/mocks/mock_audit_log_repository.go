@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogRepository) Create(log *models.AuditLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogRepository) Paginate(page, limit int, filter repositories.AuditLogFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockAuditLogRepository) FindByUserID(userID uint) ([]models.AuditLog, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.AuditLog), args.Error(1)
+}
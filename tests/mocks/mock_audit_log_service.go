@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockAuditLogService struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogService) PaginateAuditLogs(page, limit int, filter repositories.AuditLogFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
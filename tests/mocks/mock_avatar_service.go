@@ -0,0 +1,25 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockAvatarService struct {
+	mock.Mock
+}
+
+func (m *MockAvatarService) UploadAvatar(user *models.User, data []byte) error {
+	args := m.Called(user, data)
+	return args.Error(0)
+}
+
+func (m *MockAvatarService) DeleteAvatar(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockAvatarService) MaxUploadSize() int64 {
+	args := m.Called()
+	return args.Get(0).(int64)
+}
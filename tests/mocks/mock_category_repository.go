@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) GetAll() ([]models.Category, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) GetByID(id uint) (*models.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindBySlug(slug string) (*models.Category, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	args := m.Called(slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Create(category *models.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Update(category *models.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) UpdatePositions(parentID *uint, orderedIDs []uint) error {
+	args := m.Called(parentID, orderedIDs)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) PaginateComments(page, limit int, filter repositories.CommentFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockCommentRepository) PaginateThreads(postID uint, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(postID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindApprovedReplies(rootIDs []uint) ([]models.Comment, error) {
+	args := m.Called(rootIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) GetByID(id uint) (*models.Comment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) CountApprovedByAuthor(authorID uint) (int64, error) {
+	args := m.Called(authorID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCommentRepository) Create(comment *models.Comment) error {
+	args := m.Called(comment)
+	return args.Error(0)
+}
+
+func (m *MockCommentRepository) UpdateStatus(ids []uint, status string, moderatorID uint) (int64, error) {
+	args := m.Called(ids, status, moderatorID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCommentRepository) DeleteWithReplies(ids []uint) (int64, error) {
+	args := m.Called(ids)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockCommentService struct {
+	mock.Mock
+}
+
+func (m *MockCommentService) GetComments(page, limit int, filter repositories.CommentFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockCommentService) GetComment(id uint) (*models.Comment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) GetPublicThreads(slug string, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(slug, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockCommentService) CreateComment(slug string, input services.CommentInput) (*models.Comment, error) {
+	args := m.Called(slug, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentService) ModerateComments(moderatorID uint, ids []uint, action string) (int64, error) {
+	args := m.Called(moderatorID, ids, action)
+	return args.Get(0).(int64), args.Error(1)
+}
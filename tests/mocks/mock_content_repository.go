@@ -0,0 +1,86 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockContentRepository struct {
+	mock.Mock
+}
+
+func (m *MockContentRepository) GetTypes() ([]models.ContentType, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ContentType), args.Error(1)
+}
+
+func (m *MockContentRepository) GetTypeBySlug(slug string) (*models.ContentType, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentType), args.Error(1)
+}
+
+func (m *MockContentRepository) CreateType(contentType *models.ContentType) error {
+	args := m.Called(contentType)
+	return args.Error(0)
+}
+
+func (m *MockContentRepository) UpdateType(contentType *models.ContentType) error {
+	args := m.Called(contentType)
+	return args.Error(0)
+}
+
+func (m *MockContentRepository) DeleteType(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockContentRepository) PaginateEntries(contentTypeID uint, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(contentTypeID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockContentRepository) GetEntry(contentTypeID, id uint) (*models.ContentEntry, error) {
+	args := m.Called(contentTypeID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentEntry), args.Error(1)
+}
+
+func (m *MockContentRepository) GetEntryByID(id uint) (*models.ContentEntry, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentEntry), args.Error(1)
+}
+
+func (m *MockContentRepository) CountEntries(contentTypeID uint, ids []uint) (int64, error) {
+	args := m.Called(contentTypeID, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockContentRepository) CreateEntry(entry *models.ContentEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockContentRepository) UpdateEntry(entry *models.ContentEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockContentRepository) DeleteEntry(contentTypeID, id uint) (int64, error) {
+	args := m.Called(contentTypeID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
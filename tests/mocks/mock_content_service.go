@@ -0,0 +1,79 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockContentService struct {
+	mock.Mock
+}
+
+func (m *MockContentService) GetTypes() ([]models.ContentType, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ContentType), args.Error(1)
+}
+
+func (m *MockContentService) GetType(slug string) (*models.ContentType, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentType), args.Error(1)
+}
+
+func (m *MockContentService) CreateType(contentType *models.ContentType) error {
+	args := m.Called(contentType)
+	return args.Error(0)
+}
+
+func (m *MockContentService) UpdateType(contentType *models.ContentType) error {
+	args := m.Called(contentType)
+	return args.Error(0)
+}
+
+func (m *MockContentService) DeleteType(contentType *models.ContentType) error {
+	args := m.Called(contentType)
+	return args.Error(0)
+}
+
+func (m *MockContentService) PaginateEntries(contentType *models.ContentType, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(contentType, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockContentService) GetEntry(contentType *models.ContentType, id uint) (*models.ContentEntry, error) {
+	args := m.Called(contentType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentEntry), args.Error(1)
+}
+
+func (m *MockContentService) CreateEntry(contentType *models.ContentType, data map[string]any, authorID uint) (*models.ContentEntry, error) {
+	args := m.Called(contentType, data, authorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentEntry), args.Error(1)
+}
+
+func (m *MockContentService) UpdateEntry(contentType *models.ContentType, id uint, version *uint, data map[string]any) (*models.ContentEntry, error) {
+	args := m.Called(contentType, id, version, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ContentEntry), args.Error(1)
+}
+
+func (m *MockContentService) DeleteEntry(contentType *models.ContentType, id uint) error {
+	args := m.Called(contentType, id)
+	return args.Error(0)
+}
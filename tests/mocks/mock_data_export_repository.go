@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockDataExportRepository struct {
+	mock.Mock
+}
+
+func (m *MockDataExportRepository) Create(export *models.DataExport) error {
+	args := m.Called(export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) Update(export *models.DataExport) error {
+	args := m.Called(export)
+	return args.Error(0)
+}
+
+func (m *MockDataExportRepository) GetByID(id uint) (*models.DataExport, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) FindByUserID(userID uint) ([]models.DataExport, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) FindActiveByUserID(userID uint) (*models.DataExport, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) FindPending(limit int) ([]models.DataExport, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) FindExpired(now time.Time, limit int) ([]models.DataExport, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]models.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportRepository) DeleteByUserID(userID uint) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
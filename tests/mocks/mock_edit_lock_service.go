@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+type MockEditLockService struct {
+	mock.Mock
+}
+
+func (m *MockEditLockService) GetLock(resource string, id uint) (*services.EditLock, error) {
+	args := m.Called(resource, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EditLock), args.Error(1)
+}
+
+func (m *MockEditLockService) Acquire(resource string, id, userID uint) (*services.EditLock, error) {
+	args := m.Called(resource, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EditLock), args.Error(1)
+}
+
+func (m *MockEditLockService) Renew(resource string, id, userID uint) (*services.EditLock, error) {
+	args := m.Called(resource, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EditLock), args.Error(1)
+}
+
+func (m *MockEditLockService) Release(resource string, id, userID uint) error {
+	args := m.Called(resource, id, userID)
+	return args.Error(0)
+}
+
+func (m *MockEditLockService) ForceRelease(resource string, id uint) error {
+	args := m.Called(resource, id)
+	return args.Error(0)
+}
+
+func (m *MockEditLockService) CheckEditable(resource string, id, userID uint) error {
+	args := m.Called(resource, id, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,14 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEmailSender struct {
+	mock.Mock
+}
+
+func (m *MockEmailSender) Send(to []string, subject, plainText, html string) error {
+	args := m.Called(to, subject, plainText, html)
+	return args.Error(0)
+}
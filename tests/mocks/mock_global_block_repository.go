@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockGlobalBlockRepository struct {
+	mock.Mock
+}
+
+func (m *MockGlobalBlockRepository) PaginateGlobalBlocks(page, limit int, search string) (*utils.Pagination, error) {
+	args := m.Called(page, limit, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockGlobalBlockRepository) GetByID(id uint) (*models.GlobalBlock, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GlobalBlock), args.Error(1)
+}
+
+func (m *MockGlobalBlockRepository) FindByIDs(ids []uint) ([]models.GlobalBlock, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.GlobalBlock), args.Error(1)
+}
+
+func (m *MockGlobalBlockRepository) NameExists(name string, excludeID uint) (bool, error) {
+	args := m.Called(name, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGlobalBlockRepository) Create(block *models.GlobalBlock) error {
+	args := m.Called(block)
+	return args.Error(0)
+}
+
+func (m *MockGlobalBlockRepository) Update(block *models.GlobalBlock) error {
+	args := m.Called(block)
+	return args.Error(0)
+}
+
+func (m *MockGlobalBlockRepository) Delete(id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockGlobalBlockService struct {
+	mock.Mock
+}
+
+func (m *MockGlobalBlockService) PaginateGlobalBlocks(page, limit int, search string) (*utils.Pagination, error) {
+	args := m.Called(page, limit, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockGlobalBlockService) GetGlobalBlock(id uint) (*models.GlobalBlock, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GlobalBlock), args.Error(1)
+}
+
+func (m *MockGlobalBlockService) CreateGlobalBlock(block *models.GlobalBlock) error {
+	args := m.Called(block)
+	return args.Error(0)
+}
+
+func (m *MockGlobalBlockService) UpdateGlobalBlock(block *models.GlobalBlock) error {
+	args := m.Called(block)
+	return args.Error(0)
+}
+
+func (m *MockGlobalBlockService) DeleteGlobalBlock(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+type MockImpersonationService struct {
+	mock.Mock
+}
+
+func (m *MockImpersonationService) Start(impersonatorID, userID uint, reason, ipAddress string) (*services.ImpersonationResponse, error) {
+	args := m.Called(impersonatorID, userID, reason, ipAddress)
+	return args.Get(0).(*services.ImpersonationResponse), args.Error(1)
+}
+
+func (m *MockImpersonationService) Stop(impersonatorID, userID uint, sessionID, ipAddress string) error {
+	args := m.Called(impersonatorID, userID, sessionID, ipAddress)
+	return args.Error(0)
+}
@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+	"gorm.io/gorm"
+)
+
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationRepository) CreateWithTx(tx *gorm.DB, invitation *models.Invitation) error {
+	args := m.Called(tx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) RevokePendingWithTx(tx *gorm.DB, userID uint, revokedAt time.Time) error {
+	args := m.Called(tx, userID, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) Update(invitation *models.Invitation) error {
+	args := m.Called(invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) GetByID(id uint) (*models.Invitation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindByTokenHash(tokenHash string) (*models.Invitation, error) {
+	args := m.Called(tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) Paginate(page, limit int, status string) (*utils.Pagination, error) {
+	args := m.Called(page, limit, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockInvitationRepository) Accept(invitation *models.Invitation, user *models.User) error {
+	args := m.Called(invitation, user)
+	return args.Error(0)
+}
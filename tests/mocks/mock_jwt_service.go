@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 	"github.com/vfa-khuongdv/golang-cms/internal/services"
 )
@@ -15,6 +17,11 @@ func (m *MockJWTService) GenerateToken(id uint) (*services.JwtResult, error) {
 	return args.Get(0).(*services.JwtResult), args.Error(1)
 }
 
+func (m *MockJWTService) GenerateImpersonationToken(id, impersonatorID uint, sessionID string, ttl time.Duration) (*services.JwtResult, error) {
+	args := m.Called(id, impersonatorID, sessionID, ttl)
+	return args.Get(0).(*services.JwtResult), args.Error(1)
+}
+
 func (m *MockJWTService) ValidateToken(tokenString string) (*services.CustomClaims, error) {
 	args := m.Called(tokenString)
 	return args.Get(0).(*services.CustomClaims), args.Error(1)
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockMailerService struct {
+	mock.Mock
+}
+
+func (m *MockMailerService) SendMailForgotPassword(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockMailerService) SendMailInvitation(user *models.User, inviterName, token string, expiresAt time.Time) error {
+	args := m.Called(user, inviterName, token, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockMailerService) SendMailNewComment(author *models.User, post *models.Post, comment *models.Comment, commenterName string) error {
+	args := m.Called(author, post, comment, commenterName)
+	return args.Error(0)
+}
+
+func (m *MockMailerService) SendMailPostExpiring(author *models.User, post *models.Post) error {
+	args := m.Called(author, post)
+	return args.Error(0)
+}
@@ -0,0 +1,95 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockMediaRepository struct {
+	mock.Mock
+}
+
+func (m *MockMediaRepository) PaginateMedia(page, limit int, filter repositories.MediaFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockMediaRepository) GetByID(id uint) (*models.Media, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) FindByIDs(ids []uint) ([]models.Media, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) FindByChecksum(checksum string) (*models.Media, error) {
+	args := m.Called(checksum)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Media), args.Error(1)
+}
+
+func (m *MockMediaRepository) Create(media *models.Media) error {
+	args := m.Called(media)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) Update(media *models.Media) error {
+	args := m.Called(media)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) GetFolders() ([]models.MediaFolder, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MediaFolder), args.Error(1)
+}
+
+func (m *MockMediaRepository) GetFolderByID(id uint) (*models.MediaFolder, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MediaFolder), args.Error(1)
+}
+
+func (m *MockMediaRepository) FolderNameExists(parentID *uint, name string, excludeID uint) (bool, error) {
+	args := m.Called(parentID, name, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMediaRepository) CreateFolder(folder *models.MediaFolder) error {
+	args := m.Called(folder)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) UpdateFolder(folder *models.MediaFolder) error {
+	args := m.Called(folder)
+	return args.Error(0)
+}
+
+func (m *MockMediaRepository) DeleteFolder(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,83 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockMediaService struct {
+	mock.Mock
+}
+
+func (m *MockMediaService) PaginateMedia(page, limit int, filter repositories.MediaFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockMediaService) GetMedia(id uint) (*models.Media, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Media), args.Error(1)
+}
+
+func (m *MockMediaService) UploadMedia(upload services.MediaUpload) (*models.Media, bool, error) {
+	args := m.Called(upload)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*models.Media), args.Bool(1), args.Error(2)
+}
+
+func (m *MockMediaService) UpdateMedia(media *models.Media) error {
+	args := m.Called(media)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) DeleteMedia(media *models.Media) error {
+	args := m.Called(media)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) GetFolders() ([]models.MediaFolder, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MediaFolder), args.Error(1)
+}
+
+func (m *MockMediaService) GetFolder(id uint) (*models.MediaFolder, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MediaFolder), args.Error(1)
+}
+
+func (m *MockMediaService) CreateFolder(folder *models.MediaFolder) error {
+	args := m.Called(folder)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) UpdateFolder(folder *models.MediaFolder) error {
+	args := m.Called(folder)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) DeleteFolder(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockMediaService) MaxUploadSize() int64 {
+	args := m.Called()
+	return args.Get(0).(int64)
+}
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockMenuRepository struct {
+	mock.Mock
+}
+
+func (m *MockMenuRepository) GetAll() ([]models.Menu, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Menu), args.Error(1)
+}
+
+func (m *MockMenuRepository) GetByID(id uint) (*models.Menu, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Menu), args.Error(1)
+}
+
+func (m *MockMenuRepository) FindByHandle(handle string) (*models.Menu, error) {
+	args := m.Called(handle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Menu), args.Error(1)
+}
+
+func (m *MockMenuRepository) HandleExists(handle string, excludeID uint) (bool, error) {
+	args := m.Called(handle, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMenuRepository) Create(menu *models.Menu) error {
+	args := m.Called(menu)
+	return args.Error(0)
+}
+
+func (m *MockMenuRepository) Update(menu *models.Menu) error {
+	args := m.Called(menu)
+	return args.Error(0)
+}
+
+func (m *MockMenuRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockMenuRepository) GetItems(menuID uint) ([]models.MenuItem, error) {
+	args := m.Called(menuID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MenuItem), args.Error(1)
+}
+
+func (m *MockMenuRepository) ReplaceItems(menuID uint, items []models.MenuItem) error {
+	args := m.Called(menuID, items)
+	return args.Error(0)
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+type MockMenuService struct {
+	mock.Mock
+}
+
+func (m *MockMenuService) GetMenus() ([]models.Menu, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Menu), args.Error(1)
+}
+
+func (m *MockMenuService) GetMenu(id uint) (*models.Menu, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Menu), args.Error(1)
+}
+
+func (m *MockMenuService) GetPublicMenu(handle string) ([]services.MenuLink, error) {
+	args := m.Called(handle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.MenuLink), args.Error(1)
+}
+
+func (m *MockMenuService) CreateMenu(menu *models.Menu) error {
+	args := m.Called(menu)
+	return args.Error(0)
+}
+
+func (m *MockMenuService) UpdateMenu(menu *models.Menu) error {
+	args := m.Called(menu)
+	return args.Error(0)
+}
+
+func (m *MockMenuService) ReplaceItems(menu *models.Menu, items []models.MenuItem) error {
+	args := m.Called(menu, items)
+	return args.Error(0)
+}
+
+func (m *MockMenuService) DeleteMenu(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockPageRepository struct {
+	mock.Mock
+}
+
+func (m *MockPageRepository) GetAll() ([]models.Page, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Page), args.Error(1)
+}
+
+func (m *MockPageRepository) GetByID(id uint) (*models.Page, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page), args.Error(1)
+}
+
+func (m *MockPageRepository) FindPublishedByPath(path string) (*models.Page, error) {
+	args := m.Called(path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page), args.Error(1)
+}
+
+func (m *MockPageRepository) PathExists(path string, excludeID uint) (bool, error) {
+	args := m.Called(path, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPageRepository) Create(page *models.Page) error {
+	args := m.Called(page)
+	return args.Error(0)
+}
+
+func (m *MockPageRepository) Update(page *models.Page, paths map[uint]string) error {
+	args := m.Called(page, paths)
+	return args.Error(0)
+}
+
+func (m *MockPageRepository) UpdatePositions(parentID *uint, orderedIDs []uint, paths map[uint]string) error {
+	args := m.Called(parentID, orderedIDs, paths)
+	return args.Error(0)
+}
+
+func (m *MockPageRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockPageService struct {
+	mock.Mock
+}
+
+func (m *MockPageService) GetTree() ([]models.Page, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Page), args.Error(1)
+}
+
+func (m *MockPageService) GetPage(id uint) (*models.Page, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page), args.Error(1)
+}
+
+func (m *MockPageService) GetPublishedPage(path string) (*models.Page, error) {
+	args := m.Called(path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page), args.Error(1)
+}
+
+func (m *MockPageService) CreatePage(page *models.Page) error {
+	args := m.Called(page)
+	return args.Error(0)
+}
+
+func (m *MockPageService) UpdatePage(page *models.Page) error {
+	args := m.Called(page)
+	return args.Error(0)
+}
+
+func (m *MockPageService) MovePage(id uint, parentID *uint, position *int) (*models.Page, error) {
+	args := m.Called(id, parentID, position)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page), args.Error(1)
+}
+
+func (m *MockPageService) DeletePage(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,14 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPermissionService struct {
+	mock.Mock
+}
+
+func (m *MockPermissionService) HasPermission(userID uint, permission string) (bool, error) {
+	args := m.Called(userID, permission)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+type MockPostExpiryService struct {
+	mock.Mock
+}
+
+func (m *MockPostExpiryService) SetExpiry(postID uint, input services.PostExpiryInput) (*models.Post, error) {
+	args := m.Called(postID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostExpiryService) ClearExpiry(postID uint) (*models.Post, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostExpiryService) ExpireDue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockPostExpiryService) NotifyExpiring(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,150 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockPostRepository struct {
+	mock.Mock
+}
+
+func (m *MockPostRepository) PaginatePost(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockPostRepository) PaginatePublished(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockPostRepository) GetByID(id uint) (*models.Post, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindPublishedBySlug(slug string) (*models.Post, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	args := m.Called(slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) Create(post *models.Post, revision *models.PostRevision) error {
+	args := m.Called(post, revision)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) Update(post *models.Post, revision *models.PostRevision) error {
+	args := m.Called(post, revision)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) ApplyTransition(post *models.Post, transition *models.PostTransition) error {
+	args := m.Called(post, transition)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) FindTransitions(postID uint) ([]models.PostTransition, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PostTransition), args.Error(1)
+}
+
+func (m *MockPostRepository) FindDueScheduled(before time.Time, limit int) ([]models.Post, error) {
+	args := m.Called(before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) UpdateExpiry(post *models.Post) error {
+	args := m.Called(post)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) FindDueExpiring(before time.Time, limit int) ([]models.Post, error) {
+	args := m.Called(before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindExpiringUnnotified(before time.Time, limit int) ([]models.Post, error) {
+	args := m.Called(before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) ClaimExpiryNotification(id uint, notifiedAt time.Time) (bool, error) {
+	args := m.Called(id, notifiedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) ReleaseExpiryNotification(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) PaginateRevisions(postID uint, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(postID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockPostRepository) GetRevision(postID uint, number int) (*models.PostRevision, error) {
+	args := m.Called(postID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PostRevision), args.Error(1)
+}
+
+func (m *MockPostRepository) FindByIDs(ids []uint) ([]models.Post, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
+
+func (m *MockPostRepository) FindRelatedPublished(post *models.Post, limit int) ([]models.Post, error) {
+	args := m.Called(post, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
@@ -0,0 +1,100 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockPostService struct {
+	mock.Mock
+}
+
+func (m *MockPostService) PaginatePosts(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockPostService) PaginatePublishedPosts(page, limit int, filter repositories.PostFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockPostService) GetPost(id uint) (*models.Post, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostService) GetPublishedPost(slug string) (*models.Post, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostService) GetRelatedPosts(post *models.Post, limit int) ([]models.Post, error) {
+	args := m.Called(post, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
+
+func (m *MockPostService) CreatePost(post *models.Post) error {
+	args := m.Called(post)
+	return args.Error(0)
+}
+
+func (m *MockPostService) UpdatePost(editorID uint, post *models.Post) error {
+	args := m.Called(editorID, post)
+	return args.Error(0)
+}
+
+func (m *MockPostService) DeletePost(userID uint, post *models.Post) error {
+	args := m.Called(userID, post)
+	return args.Error(0)
+}
+
+func (m *MockPostService) PaginateRevisions(postID uint, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(postID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockPostService) GetRevision(postID uint, number int) (*models.PostRevision, error) {
+	args := m.Called(postID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PostRevision), args.Error(1)
+}
+
+func (m *MockPostService) DiffRevisions(postID uint, from, to int) (*services.RevisionDiff, error) {
+	args := m.Called(postID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.RevisionDiff), args.Error(1)
+}
+
+func (m *MockPostService) RestoreRevision(editorID, postID uint, number int) (*models.Post, error) {
+	args := m.Called(editorID, postID, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
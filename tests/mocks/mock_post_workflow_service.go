@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+type MockPostWorkflowService struct {
+	mock.Mock
+}
+
+func (m *MockPostWorkflowService) Transition(userID, postID uint, input services.PostTransitionInput) (*models.Post, error) {
+	args := m.Called(userID, postID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
+
+func (m *MockPostWorkflowService) GetTransitions(postID uint) ([]models.PostTransition, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PostTransition), args.Error(1)
+}
+
+func (m *MockPostWorkflowService) PublishDue(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
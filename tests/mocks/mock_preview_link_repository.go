@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+)
+
+type MockPreviewLinkRepository struct {
+	mock.Mock
+}
+
+func (m *MockPreviewLinkRepository) FindByPost(postID uint) ([]models.PreviewLink, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PreviewLink), args.Error(1)
+}
+
+func (m *MockPreviewLinkRepository) GetByID(id uint) (*models.PreviewLink, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PreviewLink), args.Error(1)
+}
+
+func (m *MockPreviewLinkRepository) Create(link *models.PreviewLink) error {
+	args := m.Called(link)
+	return args.Error(0)
+}
+
+func (m *MockPreviewLinkRepository) Revoke(postID, id uint, revokedAt time.Time) (int64, error) {
+	args := m.Called(postID, id, revokedAt)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+)
+
+type MockPreviewService struct {
+	mock.Mock
+}
+
+func (m *MockPreviewService) CreateLink(creatorID, postID uint, revisionNumber int, ttl time.Duration) (*services.PreviewLinkToken, error) {
+	args := m.Called(creatorID, postID, revisionNumber, ttl)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PreviewLinkToken), args.Error(1)
+}
+
+func (m *MockPreviewService) GetLinks(postID uint) ([]services.PreviewLinkToken, error) {
+	args := m.Called(postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.PreviewLinkToken), args.Error(1)
+}
+
+func (m *MockPreviewService) RevokeLink(postID, id uint) error {
+	args := m.Called(postID, id)
+	return args.Error(0)
+}
+
+func (m *MockPreviewService) Resolve(token string) (*models.Post, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Post), args.Error(1)
+}
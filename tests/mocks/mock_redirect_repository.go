@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockRedirectRepository struct {
+	mock.Mock
+}
+
+func (m *MockRedirectRepository) PaginateRedirects(page, limit int, search string) (*utils.Pagination, error) {
+	args := m.Called(page, limit, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockRedirectRepository) GetByID(id uint) (*models.Redirect, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Redirect), args.Error(1)
+}
+
+func (m *MockRedirectRepository) FindByFromPath(path string) (*models.Redirect, error) {
+	args := m.Called(path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Redirect), args.Error(1)
+}
+
+func (m *MockRedirectRepository) FromPathExists(path string, excludeID uint) (bool, error) {
+	args := m.Called(path, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRedirectRepository) Create(redirect *models.Redirect) error {
+	args := m.Called(redirect)
+	return args.Error(0)
+}
+
+func (m *MockRedirectRepository) Update(redirect *models.Redirect) error {
+	args := m.Called(redirect)
+	return args.Error(0)
+}
+
+func (m *MockRedirectRepository) Delete(id uint) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockRedirectService struct {
+	mock.Mock
+}
+
+func (m *MockRedirectService) PaginateRedirects(page, limit int, search string) (*utils.Pagination, error) {
+	args := m.Called(page, limit, search)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockRedirectService) GetRedirect(id uint) (*models.Redirect, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Redirect), args.Error(1)
+}
+
+func (m *MockRedirectService) Resolve(path string) (*models.Redirect, error) {
+	args := m.Called(path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Redirect), args.Error(1)
+}
+
+func (m *MockRedirectService) CreateRedirect(redirect *models.Redirect) error {
+	args := m.Called(redirect)
+	return args.Error(0)
+}
+
+func (m *MockRedirectService) UpdateRedirect(redirect *models.Redirect) error {
+	args := m.Called(redirect)
+	return args.Error(0)
+}
+
+func (m *MockRedirectService) DeleteRedirect(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
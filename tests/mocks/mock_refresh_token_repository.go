@@ -28,3 +28,8 @@ func (m *MockRefreshTokenRepository) First(token string) (*models.RefreshToken,
 	args := m.Called(token)
 	return args.Get(0).(*models.RefreshToken), args.Error(1)
 }
+
+func (m *MockRefreshTokenRepository) FindByUserID(userID uint) ([]models.RefreshToken, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.RefreshToken), args.Error(1)
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/pkg/markup"
+)
+
+type MockRenderService struct {
+	mock.Mock
+}
+
+func (m *MockRenderService) Render(body, format string) (*markup.Result, error) {
+	args := m.Called(body, format)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*markup.Result), args.Error(1)
+}
@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"gorm.io/gorm"
+)
+
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) GetPermissionNamesByUserID(userID uint) ([]string, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRoleRepository) AssignRolesWithTx(tx *gorm.DB, userID uint, roleIDs []uint) error {
+	args := m.Called(tx, userID, roleIDs)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) FindByIDs(ids []uint) ([]models.Role, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.Role), args.Error(1)
+}
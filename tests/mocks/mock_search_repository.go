@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+)
+
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) FindPosts(ids []uint) ([]models.Post, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
+
+func (m *MockSearchRepository) FindPostsAfter(afterID uint, limit int) ([]models.Post, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Post), args.Error(1)
+}
+
+func (m *MockSearchRepository) FindPages(ids []uint) ([]models.Page, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Page), args.Error(1)
+}
+
+func (m *MockSearchRepository) FindPagesAfter(afterID uint, limit int) ([]models.Page, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Page), args.Error(1)
+}
+
+func (m *MockSearchRepository) FindUsers(ids []uint) ([]models.User, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockSearchRepository) FindUsersAfter(afterID uint, limit int) ([]models.User, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockSearchRepository) WatchChanges(handler repositories.ChangeHandler) error {
+	args := m.Called(handler)
+	return args.Error(0)
+}
@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/services"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) Search(query services.SearchQuery) (*utils.Pagination, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockSearchService) QueueChange(table string, ids []uint) {
+	m.Called(table, ids)
+}
+
+func (m *MockSearchService) QueueReindex() {
+	m.Called()
+}
+
+func (m *MockSearchService) ProcessPending(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
+)
+
+type MockSitemapRepository struct {
+	mock.Mock
+}
+
+func (m *MockSitemapRepository) FindPostsAfter(afterID uint, limit int) ([]repositories.SitemapEntry, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repositories.SitemapEntry), args.Error(1)
+}
+
+func (m *MockSitemapRepository) FindPagesAfter(afterID uint, limit int) ([]repositories.SitemapEntry, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repositories.SitemapEntry), args.Error(1)
+}
+
+func (m *MockSitemapRepository) FindCategoriesAfter(afterID uint, limit int) ([]repositories.SitemapEntry, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repositories.SitemapEntry), args.Error(1)
+}
+
+func (m *MockSitemapRepository) FindTagsAfter(afterID uint, limit int) ([]repositories.SitemapEntry, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repositories.SitemapEntry), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSitemapService struct {
+	mock.Mock
+}
+
+func (m *MockSitemapService) GetIndex() ([]byte, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSitemapService) GetSitemap(section string, number int) ([]byte, error) {
+	args := m.Called(section, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSitemapService) InvalidateTable(table string, ids []uint) {
+	m.Called(table, ids)
+}
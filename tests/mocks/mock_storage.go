@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Put(key string, body io.Reader, contentType string) error {
+	args := m.Called(key, body, contentType)
+	return args.Error(0)
+}
+
+func (m *MockStorage) Get(key string) (io.ReadCloser, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockStorage) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockStorage) URL(key string) string {
+	args := m.Called(key)
+	return args.String(0)
+}
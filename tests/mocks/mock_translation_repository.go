@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockTranslationRepository struct {
+	mock.Mock
+}
+
+func (m *MockTranslationRepository) FindByContent(contentType string, contentID uint) ([]models.Translation, error) {
+	args := m.Called(contentType, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Translation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) FindForContents(contentType string, contentIDs []uint, locales []string) ([]models.Translation, error) {
+	args := m.Called(contentType, contentIDs, locales)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Translation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) FindByType(contentType string, locales []string) ([]models.Translation, error) {
+	args := m.Called(contentType, locales)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Translation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) FindBySlug(contentType, slug string, locales []string) ([]models.Translation, error) {
+	args := m.Called(contentType, slug, locales)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Translation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) GetByLocale(contentType string, contentID uint, locale string) (*models.Translation, error) {
+	args := m.Called(contentType, contentID, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Translation), args.Error(1)
+}
+
+func (m *MockTranslationRepository) SlugExists(contentType, locale, slug string, excludeID uint) (bool, error) {
+	args := m.Called(contentType, locale, slug, excludeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTranslationRepository) Save(translation *models.Translation) error {
+	args := m.Called(translation)
+	return args.Error(0)
+}
+
+func (m *MockTranslationRepository) Delete(contentType string, contentID uint, locale string) (int64, error) {
+	args := m.Called(contentType, contentID, locale)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockTranslationRepository) PaginateMissing(contentType, locale string, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(contentType, locale, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
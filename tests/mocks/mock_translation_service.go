@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/utils"
+)
+
+type MockTranslationService struct {
+	mock.Mock
+}
+
+func (m *MockTranslationService) GetTranslations(contentType string, contentID uint) ([]models.Translation, error) {
+	args := m.Called(contentType, contentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Translation), args.Error(1)
+}
+
+func (m *MockTranslationService) SaveTranslation(translation *models.Translation) (bool, error) {
+	args := m.Called(translation)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTranslationService) DeleteTranslation(contentType string, contentID uint, locale string) error {
+	args := m.Called(contentType, contentID, locale)
+	return args.Error(0)
+}
+
+func (m *MockTranslationService) PaginateMissing(contentType, locale string, page, limit int) (*utils.Pagination, error) {
+	args := m.Called(contentType, locale, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*utils.Pagination), args.Error(1)
+}
+
+func (m *MockTranslationService) ResolvePostSlug(slug, locale string) (string, error) {
+	args := m.Called(slug, locale)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTranslationService) LocalizePosts(posts []models.Post, locale string) ([]string, error) {
+	args := m.Called(posts, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTranslationService) ResolvePagePath(path, locale string) (string, error) {
+	args := m.Called(path, locale)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTranslationService) LocalizePage(page *models.Page, locale string) (string, error) {
+	args := m.Called(page, locale)
+	return args.String(0), args.Error(1)
+}
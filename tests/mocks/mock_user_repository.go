@@ -1,8 +1,11 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/stretchr/testify/mock"
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
 	"github.com/vfa-khuongdv/golang-cms/internal/utils"
 	"gorm.io/gorm"
 )
@@ -11,8 +14,8 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) PaginateUser(page, limit int) (*utils.Pagination, error) {
-	args := m.Called(page, limit)
+func (m *MockUserRepository) PaginateUser(page, limit int, filter repositories.UserFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
 	return args.Get(0).(*utils.Pagination), args.Error(1)
 }
 
@@ -65,3 +68,23 @@ func (m *MockUserRepository) GetDB() *gorm.DB {
 	args := m.Called()
 	return args.Get(0).(*gorm.DB)
 }
+
+func (m *MockUserRepository) GetAttributes(userIDs []uint) ([]models.UserAttribute, error) {
+	args := m.Called(userIDs)
+	return args.Get(0).([]models.UserAttribute), args.Error(1)
+}
+
+func (m *MockUserRepository) SaveAttributes(userID uint, values []models.UserAttribute, removeDefinitionIDs []uint) error {
+	args := m.Called(userID, values, removeDefinitionIDs)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) FindDueForDeletion(before time.Time, limit int) ([]models.User, error) {
+	args := m.Called(before, limit)
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Erase(user *models.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
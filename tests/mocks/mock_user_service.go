@@ -3,6 +3,7 @@ package mocks
 import (
 	"github.com/stretchr/testify/mock"
 	"github.com/vfa-khuongdv/golang-cms/internal/models"
+	"github.com/vfa-khuongdv/golang-cms/internal/repositories"
 	"github.com/vfa-khuongdv/golang-cms/internal/utils"
 )
 
@@ -10,8 +11,8 @@ type MockUserService struct {
 	mock.Mock
 }
 
-func (m *MockUserService) PaginateUser(page, limit int) (*utils.Pagination, error) {
-	args := m.Called(page, limit)
+func (m *MockUserService) PaginateUser(page, limit int, filter repositories.UserFilter) (*utils.Pagination, error) {
+	args := m.Called(page, limit, filter)
 	return args.Get(0).(*utils.Pagination), args.Error(1)
 }
 